@@ -0,0 +1,161 @@
+// Команда ankiexport выгружает слова словаря в колоду Anki (.apkg).
+//
+// Использование:
+//
+//	ankiexport [флаги] -o deck.apkg
+//
+// Без -ids и флагов фильтра выгружается весь словарь. Подключение к БД и
+// хранилище медиафайлов настраиваются так же, как у сервера
+// (config.yaml / переменные окружения).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/heartmarshall/my-english/internal/app"
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ankiexport:", err)
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	var (
+		configPath        = flag.String("config", ".env", "путь к файлу конфигурации")
+		output            = flag.String("o", "", "файл пакета .apkg (обязательный)")
+		deck              = flag.String("deck", anki.DefaultDeckName, "имя колоды")
+		ids               = flag.String("ids", "", "ID слов через запятую (нельзя сочетать с фильтром)")
+		includeScheduling = flag.Bool("include-scheduling", false, "перенести состояние карточек и историю повторений")
+	)
+
+	var filter dictionary.DictionaryFilter
+	hasFilter := false
+	flag.Func("search", "поисковый запрос", func(v string) error {
+		filter.Search, hasFilter = v, true
+		return nil
+	})
+	flag.Func("language", "язык слов (ISO 639)", func(v string) error {
+		filter.Language, hasFilter = &v, true
+		return nil
+	})
+	flag.Func("part-of-speech", "часть речи (NOUN, VERB, ...)", func(v string) error {
+		pos := model.PartOfSpeech(strings.ToUpper(v))
+		filter.PartOfSpeech, hasFilter = &pos, true
+		return nil
+	})
+	flag.Func("has-card", "только слова с карточкой (true) или без неё (false)", func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		filter.HasCard, hasFilter = &b, true
+		return nil
+	})
+	flag.Func("cefr", "уровни CEFR через запятую", func(v string) error {
+		filter.CefrLevels, hasFilter = anki.SplitCommaList(v), true
+		return nil
+	})
+	flag.Func("tag", "только слова с этим тегом", func(v string) error {
+		filter.Tag, hasFilter = &v, true
+		return nil
+	})
+	flag.Func("list", "только слова из этого списка", func(v string) error {
+		filter.List, hasFilter = &v, true
+		return nil
+	})
+	for name, dst := range map[string]**int{
+		"min-frequency-rank": &filter.MinFrequencyRank,
+		"max-frequency-rank": &filter.MaxFrequencyRank,
+	} {
+		flag.Func(name, "частотный ранг (включительно)", func(v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			*dst, hasFilter = &n, true
+			return nil
+		})
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Использование: ankiexport [флаги] -o deck.apkg")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *output == "" || flag.NArg() != 0 {
+		flag.Usage()
+		return fmt.Errorf("expected -o and no positional arguments")
+	}
+
+	input := anki.ExportInput{
+		IDs:               anki.SplitCommaList(*ids),
+		DeckName:          *deck,
+		IncludeScheduling: *includeScheduling,
+	}
+	if hasFilter {
+		input.Filter = &filter
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	slog.SetDefault(app.NewLogger(cfg.Log))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := app.NewPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	services, _, err := app.NewServices(cfg, pool)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		// Недописанный пакет не нужен
+		if err != nil {
+			_ = os.Remove(*output)
+		}
+	}()
+
+	result, err := services.Anki.Export(ctx, f, input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("notes:       %d\n", result.Notes)
+	fmt.Printf("cards:       %d\n", result.Cards)
+	fmt.Printf("review logs: %d\n", result.ReviewLogs)
+	fmt.Printf("media:       %d\n", result.Media)
+	for _, w := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	return nil
+}
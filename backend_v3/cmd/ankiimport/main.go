@@ -0,0 +1,130 @@
+// Команда ankiimport импортирует колоду Anki (.apkg) в словарь.
+//
+// Использование:
+//
+//	ankiimport [флаги] deck.apkg
+//
+// Подключение к БД и хранилище медиафайлов настраиваются так же, как у сервера
+// (config.yaml / переменные окружения). Поля сопоставления принимают имена
+// полей Anki через запятую; пустое значение отключает цель.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/heartmarshall/my-english/internal/app"
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ankiimport:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		configPath          = flag.String("config", ".env", "путь к файлу конфигурации")
+		language            = flag.String("language", "", "язык слов (ISO 639), по умолчанию en")
+		translationLanguage = flag.String("translation-language", "", "язык переводов (ISO 639), по умолчанию ru")
+	)
+
+	mapping := anki.DefaultFieldMapping()
+	targets := map[string]*[]string{
+		"text":                &mapping.Text,
+		"definition":          &mapping.Definition,
+		"translation":         &mapping.Translation,
+		"example":             &mapping.Example,
+		"example-translation": &mapping.ExampleTranslation,
+		"transcription":       &mapping.Transcription,
+		"notes":               &mapping.Notes,
+	}
+	for name, dst := range targets {
+		flag.Func(name, fmt.Sprintf("поля Anki для цели %q через запятую (по умолчанию %s)", name, strings.Join(*dst, ",")),
+			func(value string) error {
+				*dst = splitFields(value)
+				return nil
+			})
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Использование: ankiimport [флаги] deck.apkg")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		return fmt.Errorf("expected exactly one .apkg file")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	slog.SetDefault(app.NewLogger(cfg.Log))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := app.NewPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	services, _, err := app.NewServices(cfg, pool)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	result, err := services.Anki.Import(ctx, f, info.Size(), anki.ImportInput{
+		Mapping:             &mapping,
+		Language:            *language,
+		TranslationLanguage: *translationLanguage,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("notes:       %d\n", result.Notes)
+	fmt.Printf("imported:    %d\n", result.Imported)
+	fmt.Printf("duplicates:  %d\n", result.Duplicates)
+	fmt.Printf("skipped:     %d\n", result.Skipped)
+	fmt.Printf("cards:       %d\n", result.Cards)
+	fmt.Printf("review logs: %d\n", result.ReviewLogs)
+	fmt.Printf("media:       %d\n", result.Media)
+	for _, w := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	return nil
+}
+
+// splitFields делит список имён полей через запятую.
+func splitFields(value string) []string {
+	fields := []string{}
+	for _, f := range strings.Split(value, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,170 @@
+// Команда backup выгружает все данные в резервную копию (NDJSON)
+// и восстанавливает их из неё.
+//
+// Использование:
+//
+//	backup export [флаги] -o backup.ndjson
+//	backup restore [флаги] backup.ndjson
+//
+// Вместо имени файла можно указать "-" (stdout/stdin). Подключение к БД
+// настраивается так же, как у сервера (config.yaml / переменные окружения).
+// Медиафайлы в копию входят только метаданными: каталог или бакет
+// хранилища переносится отдельно.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/heartmarshall/my-english/internal/app"
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/service"
+	"github.com/heartmarshall/my-english/internal/service/backup"
+)
+
+const usage = `Использование:
+  backup export [флаги] -o backup.ndjson
+  backup restore [флаги] backup.ndjson`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "backup:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return fmt.Errorf("expected a command: export or restore")
+	}
+
+	switch args[0] {
+	case "export":
+		return runExport(args[1:])
+	case "restore":
+		return runRestore(args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	configPath := fs.String("config", ".env", "путь к файлу конфигурации")
+	output := fs.String("o", "", `файл копии или "-" для stdout (обязательный)`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return fmt.Errorf("expected -o and no positional arguments")
+	}
+
+	return withServices(*configPath, func(ctx context.Context, services *service.Services) (err error) {
+		var out io.Writer = os.Stdout
+		if *output != "-" {
+			f, err := os.Create(*output)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if cerr := f.Close(); err == nil {
+					err = cerr
+				}
+				// Недописанная копия не нужна
+				if err != nil {
+					_ = os.Remove(*output)
+				}
+			}()
+			out = f
+		}
+
+		summary, err := services.Backup.Export(ctx, out)
+		if err != nil {
+			return err
+		}
+		printSummary(summary)
+		return nil
+	})
+}
+
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	configPath := fs.String("config", ".env", "путь к файлу конфигурации")
+	mode := fs.String("mode", string(backup.ModePreserve),
+		"PRESERVE — сохранить ID (только в пустую БД), REMAP — выдать новые ID и добавить к существующим данным")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected exactly one backup file")
+	}
+
+	return withServices(*configPath, func(ctx context.Context, services *service.Services) error {
+		var in io.Reader = os.Stdin
+		if name := fs.Arg(0); name != "-" {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		summary, err := services.Backup.Restore(ctx, in, backup.RestoreInput{
+			Mode: backup.Mode(strings.ToUpper(*mode)),
+		})
+		if err != nil {
+			return err
+		}
+		printSummary(summary)
+		if summary.ReusedMedia > 0 {
+			fmt.Fprintf(os.Stderr, "%-20s %d\n", "reused media", summary.ReusedMedia)
+		}
+		return nil
+	})
+}
+
+// withServices подключается к БД и вызывает fn с сервисами приложения.
+func withServices(configPath string, fn func(ctx context.Context, services *service.Services) error) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	// stdout может быть занят копией, поэтому журнал пишется в stderr
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := app.NewPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	services, _, err := app.NewServices(cfg, pool)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, services)
+}
+
+// printSummary печатает количество строк по таблицам в stderr, чтобы не
+// смешивать его с копией в stdout.
+func printSummary(summary *backup.Summary) {
+	for _, t := range summary.Tables {
+		fmt.Fprintf(os.Stderr, "%-20s %d\n", t.Table, t.Rows)
+	}
+	fmt.Fprintf(os.Stderr, "%-20s %d\n", "total", summary.Rows)
+}
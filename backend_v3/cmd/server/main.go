@@ -12,11 +12,7 @@ import (
 
 	"github.com/heartmarshall/my-english/internal/app"
 	"github.com/heartmarshall/my-english/internal/config"
-	"github.com/heartmarshall/my-english/internal/database"
-	"github.com/heartmarshall/my-english/internal/database/repository"
-	"github.com/heartmarshall/my-english/internal/service"
 	transport "github.com/heartmarshall/my-english/internal/transport/http"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func main() {
@@ -35,49 +31,28 @@ func main() {
 	logger.Info("starting application", slog.String("env", "dev")) // Можно добавить поле env в конфиг
 
 	// 3. Подключение к БД
-	poolConfig, err := pgxpool.ParseConfig(cfg.Database.DSN())
-	if err != nil {
-		logger.Error("failed to parse db config", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	// Настройка пула
-	poolConfig.MaxConns = int32(cfg.Database.MaxOpenConns)
-	poolConfig.MinConns = int32(cfg.Database.MaxIdleConns)
-	poolConfig.MaxConnLifetime = cfg.Database.ConnMaxLifetime
-
-	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	pool, err := app.NewPool(context.Background(), cfg.Database)
 	if err != nil {
 		logger.Error("failed to connect to database", slog.Any("error", err))
 		os.Exit(1)
 	}
 	defer pool.Close()
-
-	// Проверка соединения
-	if err := pool.Ping(context.Background()); err != nil {
-		logger.Error("failed to ping database", slog.Any("error", err))
-		os.Exit(1)
-	}
 	logger.Info("connected to database")
 
-	// 4. Инициализация слоев
-	// Repository
-	repos := repository.NewRegistry(pool)
-	txManager := database.NewTxManager(pool)
-
-	// Services
-	// TODO: Сюда нужно будет добавить реальных провайдеров для SuggestionService (OpenAI, FreeDict)
-	// Пока передаем пустой список или nil
-	services, err := service.NewServices(service.Deps{
-		Repos:     repos,
-		TxManager: txManager,
-		Providers: nil, // Провайдеры подсказок добавим позже
-	})
+	// 4. Инициализация слоев: репозитории, хранилище медиафайлов, сервисы
+	services, repos, err := app.NewServices(cfg, pool)
 	if err != nil {
 		logger.Error("failed to initialize services", slog.Any("error", err))
 		os.Exit(1)
 	}
 
+	// Фоновые задачи: очистка корзины и обработка изображений
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go app.NewTrashWorker(services.Dictionary, cfg.Trash, logger).Run(workerCtx)
+	go app.NewMediaWorker(services.Media, cfg.Media, logger).Run(workerCtx)
+	go app.NewImportWorker(services.CSVImport, cfg.Import, logger).Run(workerCtx)
+
 	// 5. Настройка HTTP сервера
 	handler := transport.NewHandler(cfg, logger, services, repos)
 
@@ -111,6 +86,7 @@ func main() {
 	<-quit
 
 	logger.Info("server shutting down...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -0,0 +1,107 @@
+// Команда wiktionaryimport загружает дамп Викисловаря в формате Wiktextract
+// (JSONL, https://kaikki.org) в офлайн-словарь подсказок.
+//
+// Использование:
+//
+//	wiktionaryimport [флаги] kaikki.org-dictionary-English.jsonl[.gz]
+//
+// Подключение к БД настраивается так же, как у сервера (config.yaml /
+// переменные окружения). Повторный импорт заменяет статьи языка целиком.
+// Файлы .gz распаковываются на лету.
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/heartmarshall/my-english/internal/app"
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/service/wiktionary"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "wiktionaryimport:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		configPath          = flag.String("config", ".env", "путь к файлу конфигурации")
+		language            = flag.String("language", "", "язык слов (ISO 639), по умолчанию en")
+		translationLanguage = flag.String("translation-language", "", "язык переводов (ISO 639), по умолчанию ru")
+	)
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Использование: wiktionaryimport [флаги] dump.jsonl[.gz]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		return fmt.Errorf("expected exactly one dump file")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	slog.SetDefault(app.NewLogger(cfg.Log))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := app.NewPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	services, _, err := app.NewServices(cfg, pool)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(f.Name(), ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("open gzip: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	result, err := services.Wiktionary.Import(ctx, r, wiktionary.ImportInput{
+		Language:            *language,
+		TranslationLanguage: *translationLanguage,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("language: %s\n", result.Language)
+	fmt.Printf("entries:  %d\n", result.Lines)
+	fmt.Printf("imported: %d\n", result.Imported)
+	fmt.Printf("skipped:  %d\n", result.Skipped)
+	fmt.Printf("invalid:  %d\n", result.Invalid)
+	fmt.Printf("replaced: %d\n", result.Replaced)
+	for _, w := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	return nil
+}
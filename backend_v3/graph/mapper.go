@@ -1,14 +1,34 @@
 package graph
 
 import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/graph/model"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	internalmodel "github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+	"github.com/heartmarshall/my-english/internal/service/csvimport"
 	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/inbox"
+	"github.com/heartmarshall/my-english/internal/service/kindle"
+	"github.com/heartmarshall/my-english/internal/service/mining"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/internal/transport"
+	"github.com/heartmarshall/my-english/pkg/textmine"
 )
 
 // mapCreateWordInput конвертирует GraphQL input в сервисный input
 func mapCreateWordInput(input model.CreateWordInput) dictionary.CreateWordInput {
 	return dictionary.CreateWordInput{
 		Text:           input.Text,
+		Language:       getString(input.Language),
+		Notes:          input.Notes,
+		NotesOnCard:    input.NotesOnCard != nil && *input.NotesOnCard,
 		Senses:         mapSensesInput(input.Senses),
 		Images:         mapImagesInput(input.Images),
 		Pronunciations: mapPronunciationsInput(input.Pronunciations),
@@ -19,11 +39,31 @@ func mapCreateWordInput(input model.CreateWordInput) dictionary.CreateWordInput
 // mapUpdateWordInput конвертирует GraphQL input в сервисный input
 func mapUpdateWordInput(id string, input model.UpdateWordInput) dictionary.UpdateWordInput {
 	return dictionary.UpdateWordInput{
-		ID:             id,
-		Text:           input.Text,
-		Senses:         mapSensesInput(input.Senses),
-		Images:         nil,
-		Pronunciations: nil,
+		ID:                     id,
+		Text:                   input.Text,
+		Language:               input.Language,
+		Notes:                  input.Notes,
+		NotesOnCard:            input.NotesOnCard,
+		Senses:                 mapSenseUpsertsInput(input.Senses),
+		Images:                 mapImageUpsertsInput(input.Images),
+		Pronunciations:         mapPronunciationUpsertsInput(input.Pronunciations),
+		DeleteSenseIDs:         mapUUIDs(input.DeleteSenseIds),
+		DeleteImageIDs:         mapUUIDs(input.DeleteImageIds),
+		DeletePronunciationIDs: mapUUIDs(input.DeletePronunciationIds),
+	}
+}
+
+// mapAddSenseInput конвертирует GraphQL SenseInput в input для добавления смысла
+func mapAddSenseInput(entryID string, in model.SenseInput) dictionary.AddSenseInput {
+	return dictionary.AddSenseInput{
+		EntryID:      entryID,
+		Definition:   in.Definition,
+		PartOfSpeech: in.PartOfSpeech,
+		SourceSlug:   getString(in.SourceSlug),
+		Notes:        in.Notes,
+		Translations: mapTranslationsInput(in.Translations),
+		Examples:     mapExamplesInput(in.Examples),
+		Relations:    mapRelationsInput(in.Relations),
 	}
 }
 
@@ -40,8 +80,10 @@ func mapSensesInput(inputs []*model.SenseInput) []dictionary.SenseInput {
 			Definition:   in.Definition, // ИСПРАВЛЕНО: передаем указатель как есть
 			PartOfSpeech: in.PartOfSpeech,
 			SourceSlug:   getString(in.SourceSlug),
+			Notes:        in.Notes,
 			Translations: mapTranslationsInput(in.Translations),
 			Examples:     mapExamplesInput(in.Examples),
+			Relations:    mapRelationsInput(in.Relations),
 		}
 	}
 	return res
@@ -58,6 +100,7 @@ func mapTranslationsInput(inputs []*model.TranslationInput) []dictionary.Transla
 		}
 		res[i] = dictionary.TranslationInput{
 			Text:       in.Text,
+			Language:   getString(in.Language),
 			SourceSlug: getString(in.SourceSlug),
 		}
 	}
@@ -82,6 +125,24 @@ func mapExamplesInput(inputs []*model.ExampleInput) []dictionary.ExampleInput {
 	return res
 }
 
+func mapRelationsInput(inputs []*model.RelationInput) []dictionary.RelationInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.RelationInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.RelationInput{
+			Type:       in.Type,
+			TargetText: in.TargetText,
+			SourceSlug: getString(in.SourceSlug),
+		}
+	}
+	return res
+}
+
 func mapImagesInput(inputs []*model.ImageInput) []dictionary.ImageInput {
 	if len(inputs) == 0 {
 		return nil
@@ -92,9 +153,10 @@ func mapImagesInput(inputs []*model.ImageInput) []dictionary.ImageInput {
 			continue
 		}
 		res[i] = dictionary.ImageInput{
-			URL:        in.URL,
+			URL:        getString(in.URL),
 			Caption:    in.Caption,
 			SourceSlug: getString(in.SourceSlug),
+			MediaID:    mapOptionalUUID(in.MediaID),
 		}
 	}
 	return res
@@ -110,10 +172,114 @@ func mapPronunciationsInput(inputs []*model.PronunciationInput) []dictionary.Pro
 			continue
 		}
 		res[i] = dictionary.PronunciationInput{
-			AudioURL:      in.AudioURL,
+			AudioURL:      getString(in.AudioURL),
+			Transcription: in.Transcription,
+			Region:        in.Region,
+			SourceSlug:    getString(in.SourceSlug),
+			MediaID:       mapOptionalUUID(in.MediaID),
+		}
+	}
+	return res
+}
+
+func mapSenseUpsertsInput(inputs []*model.SenseUpsertInput) []dictionary.SenseUpsertInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.SenseUpsertInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.SenseUpsertInput{
+			ID:                   mapOptionalUUID(in.ID),
+			Definition:           in.Definition,
+			PartOfSpeech:         in.PartOfSpeech,
+			SourceSlug:           getString(in.SourceSlug),
+			Notes:                in.Notes,
+			Translations:         mapTranslationUpsertsInput(in.Translations),
+			Examples:             mapExampleUpsertsInput(in.Examples),
+			DeleteTranslationIDs: mapUUIDs(in.DeleteTranslationIds),
+			DeleteExampleIDs:     mapUUIDs(in.DeleteExampleIds),
+		}
+	}
+	return res
+}
+
+func mapTranslationUpsertsInput(inputs []*model.TranslationUpsertInput) []dictionary.TranslationUpsertInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.TranslationUpsertInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.TranslationUpsertInput{
+			ID:         mapOptionalUUID(in.ID),
+			Text:       in.Text,
+			Language:   getString(in.Language),
+			SourceSlug: getString(in.SourceSlug),
+		}
+	}
+	return res
+}
+
+func mapExampleUpsertsInput(inputs []*model.ExampleUpsertInput) []dictionary.ExampleUpsertInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.ExampleUpsertInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.ExampleUpsertInput{
+			ID:          mapOptionalUUID(in.ID),
+			Sentence:    in.Sentence,
+			Translation: in.Translation,
+			SourceSlug:  getString(in.SourceSlug),
+		}
+	}
+	return res
+}
+
+func mapImageUpsertsInput(inputs []*model.ImageUpsertInput) []dictionary.ImageUpsertInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.ImageUpsertInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.ImageUpsertInput{
+			ID:         mapOptionalUUID(in.ID),
+			URL:        getString(in.URL),
+			Caption:    in.Caption,
+			SourceSlug: getString(in.SourceSlug),
+			MediaID:    mapOptionalUUID(in.MediaID),
+		}
+	}
+	return res
+}
+
+func mapPronunciationUpsertsInput(inputs []*model.PronunciationUpsertInput) []dictionary.PronunciationUpsertInput {
+	if len(inputs) == 0 {
+		return nil
+	}
+	res := make([]dictionary.PronunciationUpsertInput, len(inputs))
+	for i, in := range inputs {
+		if in == nil {
+			continue
+		}
+		res[i] = dictionary.PronunciationUpsertInput{
+			ID:            mapOptionalUUID(in.ID),
+			AudioURL:      getString(in.AudioURL),
 			Transcription: in.Transcription,
 			Region:        in.Region,
 			SourceSlug:    getString(in.SourceSlug),
+			MediaID:       mapOptionalUUID(in.MediaID),
 		}
 	}
 	return res
@@ -126,13 +292,482 @@ func mapDictionaryFilter(f *model.WordFilter) dictionary.DictionaryFilter {
 	}
 
 	return dictionary.DictionaryFilter{
-		Search:       getString(f.Search),
-		PartOfSpeech: f.PartOfSpeech,
-		HasCard:      f.HasCard,
-		Limit:        getInt(f.Limit, 20),
-		Offset:       getInt(f.Offset, 0),
-		SortBy:       f.SortBy,
-		SortDir:      f.SortDir,
+		Language:         f.Language,
+		Search:           getString(f.Search),
+		PartOfSpeech:     f.PartOfSpeech,
+		HasCard:          f.HasCard,
+		CefrLevels:       f.CefrLevels,
+		MinFrequencyRank: f.MinFrequencyRank,
+		MaxFrequencyRank: f.MaxFrequencyRank,
+		Tag:              f.Tag,
+		List:             f.List,
+		Limit:            getInt(f.Limit, 20),
+		Offset:           getInt(f.Offset, 0),
+		SortBy:           f.SortBy,
+		SortDir:          f.SortDir,
+	}
+}
+
+// mapBulkSelection конвертирует аргументы массовой операции в сервисный input
+func mapBulkSelection(ids []uuid.UUID, filter *model.WordFilter) dictionary.BulkSelectionInput {
+	input := dictionary.BulkSelectionInput{IDs: mapUUIDs(ids)}
+	if filter != nil {
+		f := mapDictionaryFilter(filter)
+		input.Filter = &f
+	}
+	return input
+}
+
+// mapBulkResult мапит итог массовой операции
+func mapBulkResult(r *dictionary.BulkResult) *model.BulkResult {
+	return &model.BulkResult{
+		OperationID: r.OperationID,
+		Matched:     r.Matched,
+		Affected:    r.Affected,
+		Chunks:      r.Chunks,
+	}
+}
+
+// bulkError преобразует ошибку массовой операции. Если часть выборки уже
+// зафиксирована, в extensions ошибки добавляются operationId, completedChunks, chunks,
+// matched и affected: по ним клиент видит, что уже применено.
+func bulkError(ctx context.Context, result *dictionary.BulkResult, err error) error {
+	gqlErr := transport.HandleError(ctx, err)
+	if result == nil || result.CompletedChunks == 0 {
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = make(map[string]interface{})
+	}
+	gqlErr.Extensions["operationId"] = result.OperationID.String()
+	gqlErr.Extensions["completedChunks"] = result.CompletedChunks
+	gqlErr.Extensions["chunks"] = result.Chunks
+	gqlErr.Extensions["matched"] = result.Matched
+	gqlErr.Extensions["affected"] = result.Affected
+	return gqlErr
+}
+
+// mapAnkiImportInput мапит параметры импорта колоды Anki.
+// Не указанные цели сопоставления получают значения по умолчанию.
+func mapAnkiImportInput(input *model.AnkiImportInput) anki.ImportInput {
+	if input == nil {
+		return anki.ImportInput{}
+	}
+
+	result := anki.ImportInput{
+		Language:            getString(input.Language),
+		TranslationLanguage: getString(input.TranslationLanguage),
+	}
+	if m := input.Mapping; m != nil {
+		mapping := anki.DefaultFieldMapping()
+		override := func(dst *[]string, src []string) {
+			if src != nil {
+				*dst = src
+			}
+		}
+		override(&mapping.Text, m.Text)
+		override(&mapping.Definition, m.Definition)
+		override(&mapping.Translation, m.Translation)
+		override(&mapping.Example, m.Example)
+		override(&mapping.ExampleTranslation, m.ExampleTranslation)
+		override(&mapping.Transcription, m.Transcription)
+		override(&mapping.Notes, m.Notes)
+		result.Mapping = &mapping
+	}
+	return result
+}
+
+// mapAnkiImportResult мапит итог импорта колоды Anki
+func mapAnkiImportResult(r *anki.ImportResult) *model.AnkiImportResult {
+	return &model.AnkiImportResult{
+		Notes:      r.Notes,
+		Imported:   r.Imported,
+		Duplicates: r.Duplicates,
+		Skipped:    r.Skipped,
+		Cards:      r.Cards,
+		ReviewLogs: r.ReviewLogs,
+		Media:      r.Media,
+		Warnings:   r.Warnings,
+	}
+}
+
+// mapCSVImportInput мапит параметры импорта CSV/TSV
+func mapCSVImportInput(input model.CSVImportInput) csvimport.ImportInput {
+	result := csvimport.ImportInput{
+		HasHeader:           input.HasHeader == nil || *input.HasHeader,
+		Language:            getString(input.Language),
+		TranslationLanguage: getString(input.TranslationLanguage),
+		CreateCards:         input.CreateCards != nil && *input.CreateCards,
+	}
+	if input.Format != nil {
+		result.Format = csvimport.Format(strings.ToLower(string(*input.Format)))
+	}
+	if input.Duplicates != nil {
+		result.Duplicates = csvimport.DuplicatePolicy(*input.Duplicates)
+	}
+	if m := input.Mapping; m != nil {
+		result.Mapping = csvimport.ColumnMapping{
+			Text:         m.Text,
+			Definition:   getString(m.Definition),
+			PartOfSpeech: getString(m.PartOfSpeech),
+			Translation:  getString(m.Translation),
+			Example:      getString(m.Example),
+		}
+	}
+	return result
+}
+
+// mapCSVImportPreview мапит предпросмотр импорта CSV/TSV
+func mapCSVImportPreview(r *csvimport.Report) *model.CSVImportPreview {
+	rows := make([]*model.CSVImportRow, len(r.Rows))
+	for i, row := range r.Rows {
+		rows[i] = &model.CSVImportRow{
+			Line:   row.Line,
+			Text:   row.Text,
+			Action: model.CSVRowAction(row.Action),
+		}
+		if row.Message != "" {
+			rows[i].Message = &row.Message
+		}
+	}
+	return &model.CSVImportPreview{
+		Rows:  rows,
+		New:   r.New,
+		Merge: r.Merge,
+		Skip:  r.Skip,
+		Error: r.Error,
+	}
+}
+
+// mapCSVImportJob мапит задание импорта CSV/TSV
+func mapCSVImportJob(job *internalmodel.ImportJob) (*model.CSVImportJob, error) {
+	rowErrors, err := csvimport.DecodeRowErrors(job)
+	if err != nil {
+		return nil, err
+	}
+	result := &model.CSVImportJob{
+		ID:            job.ID,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		Created:       job.CreatedCount,
+		Merged:        job.MergedCount,
+		Skipped:       job.SkippedCount,
+		Failed:        job.FailedCount,
+		RowErrors:     make([]*model.CSVImportRowError, len(rowErrors)),
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+	for i, e := range rowErrors {
+		result.RowErrors[i] = &model.CSVImportRowError{Line: e.Line, Message: e.Message}
+	}
+	return result, nil
+}
+
+// mapKindleImportInput мапит параметры импорта Kindle
+func mapKindleImportInput(input *model.KindleImportInput) kindle.ImportInput {
+	if input == nil {
+		return kindle.ImportInput{}
+	}
+	return kindle.ImportInput{Full: input.Full != nil && *input.Full}
+}
+
+// mapKindleImportResult мапит итог импорта Kindle
+func mapKindleImportResult(r *kindle.ImportResult) *model.KindleImportResult {
+	return &model.KindleImportResult{
+		Lookups:       r.Lookups,
+		NewLookups:    r.NewLookups,
+		Imported:      r.Imported,
+		Duplicates:    r.Duplicates,
+		Mastered:      r.Mastered,
+		ImportedUntil: r.ImportedUntil,
+	}
+}
+
+// mineWordsSource возвращает источник текста для подбора слов:
+// ровно один из text и file.
+func mineWordsSource(text *string, file *graphql.Upload) (io.Reader, error) {
+	switch {
+	case text != nil && file != nil:
+		return nil, types.NewValidationError("text", "pass either text or file, not both")
+	case text != nil:
+		return strings.NewReader(*text), nil
+	case file != nil:
+		return file.File, nil
+	default:
+		return nil, types.NewValidationError("text", "text or file is required")
+	}
+}
+
+// mapSuggestionResults мапит подсказки провайдеров
+func mapSuggestionResults(results []suggestion.Result) []*model.SuggestionResult {
+	out := make([]*model.SuggestionResult, len(results))
+	for i, res := range results {
+		senses := make([]*model.SuggestedSense, len(res.Senses))
+		for j, s := range res.Senses {
+			translations := make([]string, len(s.Translations))
+			for k, t := range s.Translations {
+				translations[k] = t.Text
+			}
+			examples := make([]*model.SuggestedExample, len(s.Examples))
+			for k, e := range s.Examples {
+				examples[k] = &model.SuggestedExample{
+					Sentence:    e.Sentence,
+					Translation: e.Translation,
+				}
+			}
+			relations := make([]*model.SuggestedRelation, len(s.Relations))
+			for k, rel := range s.Relations {
+				relations[k] = &model.SuggestedRelation{Type: rel.Type, Text: rel.TargetText}
+			}
+			senses[j] = &model.SuggestedSense{
+				Definition:   getString(s.Definition),
+				PartOfSpeech: s.PartOfSpeech,
+				Translations: translations,
+				Examples:     examples,
+				Relations:    relations,
+			}
+		}
+
+		images := make([]*model.SuggestedImage, len(res.Images))
+		for j, img := range res.Images {
+			images[j] = &model.SuggestedImage{URL: img.URL, Caption: img.Caption}
+		}
+
+		pronunciations := make([]*model.SuggestedPronunciation, len(res.Pronunciations))
+		for j, p := range res.Pronunciations {
+			pronunciations[j] = &model.SuggestedPronunciation{
+				AudioURL:      p.AudioURL,
+				Transcription: p.Transcription,
+				Region:        p.Region,
+			}
+		}
+
+		forms := make([]*model.SuggestedForm, len(res.Forms))
+		for j, f := range res.Forms {
+			tags := f.Tags
+			if tags == nil {
+				tags = []string{}
+			}
+			forms[j] = &model.SuggestedForm{Text: f.Text, Tags: tags}
+		}
+
+		synonyms := res.Synonyms
+		if synonyms == nil {
+			synonyms = []string{}
+		}
+
+		out[i] = &model.SuggestionResult{
+			SourceSlug:     res.SourceSlug,
+			SourceName:     res.SourceName,
+			Senses:         senses,
+			Images:         images,
+			Pronunciations: pronunciations,
+			Synonyms:       synonyms,
+			Forms:          forms,
+			Cached:         res.Cached,
+		}
+	}
+	return out
+}
+
+// mapMineWordsInput мапит параметры подбора слов из текста
+func mapMineWordsInput(input *model.MineWordsInput) mining.AnalyzeInput {
+	if input == nil {
+		return mining.AnalyzeInput{}
+	}
+	result := mining.AnalyzeInput{}
+	if input.Format != nil {
+		result.Format = textmine.Format(*input.Format)
+	}
+	if input.Limit != nil {
+		result.Limit = *input.Limit
+	}
+	return result
+}
+
+// mapMineWordsResult мапит итог подбора слов из текста
+func mapMineWordsResult(r *mining.Result) *model.MineWordsResult {
+	return &model.MineWordsResult{
+		Format:     model.TextFormat(r.Format),
+		Tokens:     r.Tokens,
+		Words:      r.Words,
+		Known:      r.Known,
+		Candidates: mapMinedWords(r.Candidates),
+	}
+}
+
+// mapMinedWords мапит незнакомые слова из текста
+func mapMinedWords(candidates []mining.Candidate) []*model.MinedWord {
+	out := make([]*model.MinedWord, len(candidates))
+	for i, c := range candidates {
+		out[i] = &model.MinedWord{
+			Lemma:         c.Lemma,
+			Forms:         c.Forms,
+			Count:         c.Count,
+			FrequencyRank: c.FrequencyRank,
+			CefrLevel:     c.CefrLevel,
+			Score:         c.Score,
+			Context:       c.Context,
+			Examples:      c.Examples,
+		}
+	}
+	return out
+}
+
+// mapBookCoverageInput мапит параметры анализа книги
+func mapBookCoverageInput(input *model.BookCoverageInput) mining.CoverageInput {
+	if input == nil {
+		return mining.CoverageInput{}
+	}
+	result := mining.CoverageInput{AddToInbox: input.AddToInbox != nil && *input.AddToInbox}
+	if input.Limit != nil {
+		result.Limit = *input.Limit
+	}
+	return result
+}
+
+// mapBookCoverage мапит итог анализа книги
+func mapBookCoverage(r *mining.CoverageResult) *model.BookCoverage {
+	return &model.BookCoverage{
+		Title:          r.Title,
+		Author:         r.Author,
+		Tokens:         r.Tokens,
+		KnownTokens:    r.KnownTokens,
+		FunctionTokens: r.FunctionTokens,
+		Coverage:       r.Coverage,
+		Words:          r.Words,
+		KnownWords:     r.KnownWords,
+		LearningWords:  r.LearningWords,
+		UnknownWords:   r.UnknownWords,
+		Candidates:     mapMinedWords(r.Candidates),
+		AddedToInbox:   r.AddedToInbox,
+	}
+}
+
+// mapInboxItemInputs мапит заметки для пакетного добавления во входящие
+func mapInboxItemInputs(items []*model.InboxItemInput) []inbox.ItemInput {
+	result := make([]inbox.ItemInput, len(items))
+	for i, item := range items {
+		result[i] = inbox.ItemInput{Text: item.Text, Context: item.Context}
+	}
+	return result
+}
+
+// mapVocabularyCoverage мапит покрытие уровней CEFR
+func mapVocabularyCoverage(coverage []repository.LevelCoverage) []*model.CefrCoverage {
+	out := make([]*model.CefrCoverage, len(coverage))
+	for i, c := range coverage {
+		percent := 0.0
+		if c.TotalWords > 0 {
+			percent = float64(c.Known) / float64(c.TotalWords) * 100
+		}
+		out[i] = &model.CefrCoverage{
+			Level:        c.Level,
+			TotalWords:   c.TotalWords,
+			InDictionary: c.InDictionary,
+			Known:        c.Known,
+			KnownPercent: percent,
+		}
+	}
+	return out
+}
+
+// mapTranslationMatches мапит результаты обратного поиска по переводу
+func mapTranslationMatches(results []dictionary.TranslationLookupResult) []*model.TranslationMatch {
+	out := make([]*model.TranslationMatch, len(results))
+	for i := range results {
+		out[i] = &model.TranslationMatch{
+			Entry:       &results[i].Entry,
+			Sense:       &results[i].Sense,
+			Translation: &results[i].Translation,
+			Similarity:  results[i].Similarity,
+		}
+	}
+	return out
+}
+
+// mapPageInfo мапит сведения о странице курсорной пагинации
+func mapPageInfo[T any](page *types.Page[T]) *model.PageInfo {
+	return &model.PageInfo{
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
+		StartCursor:     page.StartCursor(),
+		EndCursor:       page.EndCursor(),
+	}
+}
+
+// mapDictionaryConnection мапит страницу слов в Relay connection
+func mapDictionaryConnection(page *types.Page[internalmodel.DictionaryEntry]) *model.DictionaryConnection {
+	edges := make([]*model.DictionaryEntryEdge, len(page.Items))
+	for i := range page.Items {
+		edges[i] = &model.DictionaryEntryEdge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+	return &model.DictionaryConnection{
+		Edges:      edges,
+		PageInfo:   mapPageInfo(page),
+		TotalCount: int(page.TotalCount),
+	}
+}
+
+// mapInboxItemConnection мапит страницу входящих в Relay connection
+func mapInboxItemConnection(page *types.Page[internalmodel.InboxItem]) *model.InboxItemConnection {
+	edges := make([]*model.InboxItemEdge, len(page.Items))
+	for i := range page.Items {
+		edges[i] = &model.InboxItemEdge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+	return &model.InboxItemConnection{
+		Edges:      edges,
+		PageInfo:   mapPageInfo(page),
+		TotalCount: int(page.TotalCount),
+	}
+}
+
+// mapAuditRecordConnection мапит страницу истории изменений в Relay connection
+func mapAuditRecordConnection(page *types.Page[internalmodel.AuditRecord]) *model.AuditRecordConnection {
+	edges := make([]*model.AuditRecordEdge, len(page.Items))
+	for i := range page.Items {
+		edges[i] = &model.AuditRecordEdge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+	return &model.AuditRecordConnection{
+		Edges:      edges,
+		PageInfo:   mapPageInfo(page),
+		TotalCount: int(page.TotalCount),
+	}
+}
+
+func mapDuplicateCandidates(candidates []dictionary.DuplicateCandidate) []*model.DuplicateCandidate {
+	out := make([]*model.DuplicateCandidate, len(candidates))
+	for i := range candidates {
+		out[i] = &model.DuplicateCandidate{
+			Entry:      &candidates[i].Entry,
+			Duplicate:  &candidates[i].Duplicate,
+			Similarity: candidates[i].Similarity,
+		}
+	}
+	return out
+}
+
+// mapWordLists мапит списки слов
+func mapWordLists(lists []dictionary.WordList) []*model.WordList {
+	out := make([]*model.WordList, len(lists))
+	for i, l := range lists {
+		out[i] = &model.WordList{
+			Name:      l.Name,
+			WordCount: l.WordCount,
+		}
+	}
+	return out
+}
+
+// mapInvalidateSuggestionCacheInput мапит аргументы очистки кэша подсказок
+func mapInvalidateSuggestionCacheInput(provider, text *string, expiredOnly *bool) suggestion.InvalidateInput {
+	return suggestion.InvalidateInput{
+		Provider:    getString(provider),
+		Text:        getString(text),
+		ExpiredOnly: getBool(expiredOnly),
 	}
 }
 
@@ -145,6 +780,38 @@ func getString(s *string) string {
 	return *s
 }
 
+func mapOptionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// mapMediaURL возвращает адрес сохранённого файла или nil.
+func mapMediaURL(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	u := internalmodel.MediaURL(*id)
+	return &u
+}
+
+func mapUUIDs(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res
+}
+
+func getBool(b *bool) bool {
+	return b != nil && *b
+}
+
 func getInt(i *int, def int) int {
 	if i == nil {
 		return def
@@ -13,16 +13,167 @@ import (
 	"github.com/heartmarshall/my-english/internal/model"
 )
 
+// Сопоставление полей записи Anki с данными слова.
+// Для каждой цели — имена полей-кандидатов (без учёта регистра), берётся первое
+// существующее у типа записи. Не указанная цель получает значение по умолчанию,
+// пустой список отключает её.
+type AnkiFieldMapping struct {
+	Text               []string `json:"text,omitempty"`
+	Definition         []string `json:"definition,omitempty"`
+	Translation        []string `json:"translation,omitempty"`
+	Example            []string `json:"example,omitempty"`
+	ExampleTranslation []string `json:"exampleTranslation,omitempty"`
+	Transcription      []string `json:"transcription,omitempty"`
+	Notes              []string `json:"notes,omitempty"`
+}
+
+type AnkiImportInput struct {
+	Mapping             *AnkiFieldMapping `json:"mapping,omitempty"`
+	Language            *string           `json:"language,omitempty"`
+	TranslationLanguage *string           `json:"translationLanguage,omitempty"`
+}
+
+// Итог импорта колоды Anki.
+type AnkiImportResult struct {
+	Notes      int      `json:"notes"`
+	Imported   int      `json:"imported"`
+	Duplicates int      `json:"duplicates"`
+	Skipped    int      `json:"skipped"`
+	Cards      int      `json:"cards"`
+	ReviewLogs int      `json:"reviewLogs"`
+	Media      int      `json:"media"`
+	Warnings   []string `json:"warnings"`
+}
+
+type AuditRecordConnection struct {
+	Edges      []*AuditRecordEdge `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount int                `json:"totalCount"`
+}
+
+type AuditRecordEdge struct {
+	Cursor string             `json:"cursor"`
+	Node   *model.AuditRecord `json:"node"`
+}
+
+// Покрытие книги выученными словами. Доли считаются по всем словам текста
+// с повторами; служебные слова и имена собственные считаются понятными.
+type BookCoverage struct {
+	Title          string       `json:"title"`
+	Author         string       `json:"author"`
+	Tokens         int          `json:"tokens"`
+	KnownTokens    int          `json:"knownTokens"`
+	FunctionTokens int          `json:"functionTokens"`
+	Coverage       float64      `json:"coverage"`
+	Words          int          `json:"words"`
+	KnownWords     int          `json:"knownWords"`
+	LearningWords  int          `json:"learningWords"`
+	UnknownWords   int          `json:"unknownWords"`
+	Candidates     []*MinedWord `json:"candidates"`
+	AddedToInbox   int          `json:"addedToInbox"`
+}
+
+type BookCoverageInput struct {
+	Limit      *int  `json:"limit,omitempty"`
+	AddToInbox *bool `json:"addToInbox,omitempty"`
+}
+
+// Итог массовой операции над словами.
+//
+// Выборка до 1000 слов обрабатывается одной транзакцией с одной агрегированной
+// записью аудита. Большая выборка обрабатывается частями по 1000 слов: каждая часть
+// фиксируется отдельно и пишет свою агрегированную запись аудита под общим operationId
+// (поля chunk и chunks), чтобы каждая зафиксированная часть была в аудите, даже если
+// следующая упадёт. Если часть падает, мутация возвращает ошибку; когда часть выборки
+// уже обработана, в extensions ошибки есть operationId, completedChunks, chunks,
+// matched и affected.
+type BulkResult struct {
+	OperationID uuid.UUID `json:"operationId"`
+	Matched     int       `json:"matched"`
+	Affected    int       `json:"affected"`
+	Chunks      int       `json:"chunks"`
+}
+
+type CefrCoverage struct {
+	Level        string  `json:"level"`
+	TotalWords   int     `json:"totalWords"`
+	InDictionary int     `json:"inDictionary"`
+	Known        int     `json:"known"`
+	KnownPercent float64 `json:"knownPercent"`
+}
+
 // Основной инпут для создания слова.
 // Позволяет доставать данные из разных источников.
 type CreateWordInput struct {
 	Text           string                `json:"text"`
+	Language       *string               `json:"language,omitempty"`
+	Notes          *string               `json:"notes,omitempty"`
+	NotesOnCard    *bool                 `json:"notesOnCard,omitempty"`
 	Senses         []*SenseInput         `json:"senses"`
 	Images         []*ImageInput         `json:"images,omitempty"`
 	Pronunciations []*PronunciationInput `json:"pronunciations,omitempty"`
 	CreateCard     bool                  `json:"createCard"`
 }
 
+// Колонки файла для полей слова: имя из заголовка (без учёта регистра)
+// или номер колонки начиная с 1. Не указанное поле не заполняется.
+type CSVColumnMapping struct {
+	Text         string  `json:"text"`
+	Definition   *string `json:"definition,omitempty"`
+	PartOfSpeech *string `json:"partOfSpeech,omitempty"`
+	Translation  *string `json:"translation,omitempty"`
+	Example      *string `json:"example,omitempty"`
+}
+
+type CSVImportInput struct {
+	Format              *CSVImportFormat    `json:"format,omitempty"`
+	Mapping             *CSVColumnMapping   `json:"mapping"`
+	HasHeader           *bool               `json:"hasHeader,omitempty"`
+	Language            *string             `json:"language,omitempty"`
+	TranslationLanguage *string             `json:"translationLanguage,omitempty"`
+	Duplicates          *CSVDuplicatePolicy `json:"duplicates,omitempty"`
+	CreateCards         *bool               `json:"createCards,omitempty"`
+}
+
+// Фоновое задание импорта CSV/TSV. Строки записываются пачками.
+type CSVImportJob struct {
+	ID            uuid.UUID             `json:"id"`
+	Status        model.ImportJobStatus `json:"status"`
+	TotalRows     int                   `json:"totalRows"`
+	ProcessedRows int                   `json:"processedRows"`
+	Created       int                   `json:"created"`
+	Merged        int                   `json:"merged"`
+	Skipped       int                   `json:"skipped"`
+	Failed        int                   `json:"failed"`
+	RowErrors     []*CSVImportRowError  `json:"rowErrors"`
+	Error         *string               `json:"error,omitempty"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	UpdatedAt     time.Time             `json:"updatedAt"`
+	FinishedAt    *time.Time            `json:"finishedAt,omitempty"`
+}
+
+// Предпросмотр импорта: что произойдёт с каждой строкой. Ничего не записывает.
+type CSVImportPreview struct {
+	Rows  []*CSVImportRow `json:"rows"`
+	New   int             `json:"new"`
+	Merge int             `json:"merge"`
+	Skip  int             `json:"skip"`
+	Error int             `json:"error"`
+}
+
+// Проверка строки файла при предпросмотре импорта.
+type CSVImportRow struct {
+	Line    int          `json:"line"`
+	Text    string       `json:"text"`
+	Action  CSVRowAction `json:"action"`
+	Message *string      `json:"message,omitempty"`
+}
+
+type CSVImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
 type DashboardStats struct {
 	TotalWords    int `json:"totalWords"`
 	TotalCards    int `json:"totalCards"`
@@ -33,31 +184,146 @@ type DashboardStats struct {
 	DueToday      int `json:"dueToday"`
 }
 
+type DictionaryConnection struct {
+	Edges      []*DictionaryEntryEdge `json:"edges"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+type DictionaryEntryEdge struct {
+	Cursor string                 `json:"cursor"`
+	Node   *model.DictionaryEntry `json:"node"`
+}
+
+// Пара слов, которые вероятно являются дубликатами (например "colour"/"color").
+// Кандидат для mergeWords.
+type DuplicateCandidate struct {
+	Entry      *model.DictionaryEntry `json:"entry"`
+	Duplicate  *model.DictionaryEntry `json:"duplicate"`
+	Similarity float64                `json:"similarity"`
+}
+
 type ExampleInput struct {
 	Sentence    string  `json:"sentence"`
 	Translation *string `json:"translation,omitempty"`
 	SourceSlug  *string `json:"sourceSlug,omitempty"`
 }
 
+type ExampleUpsertInput struct {
+	ID          *uuid.UUID `json:"id,omitempty"`
+	Sentence    string     `json:"sentence"`
+	Translation *string    `json:"translation,omitempty"`
+	SourceSlug  *string    `json:"sourceSlug,omitempty"`
+}
+
 type ImageInput struct {
-	URL        string  `json:"url"`
-	Caption    *string `json:"caption,omitempty"`
-	SourceSlug *string `json:"sourceSlug,omitempty"`
+	URL        *string    `json:"url,omitempty"`
+	Caption    *string    `json:"caption,omitempty"`
+	SourceSlug *string    `json:"sourceSlug,omitempty"`
+	MediaID    *uuid.UUID `json:"mediaId,omitempty"`
+}
+
+type ImageUpsertInput struct {
+	ID         *uuid.UUID `json:"id,omitempty"`
+	URL        *string    `json:"url,omitempty"`
+	Caption    *string    `json:"caption,omitempty"`
+	SourceSlug *string    `json:"sourceSlug,omitempty"`
+	MediaID    *uuid.UUID `json:"mediaId,omitempty"`
+}
+
+type InboxItemConnection struct {
+	Edges      []*InboxItemEdge `json:"edges"`
+	PageInfo   *PageInfo        `json:"pageInfo"`
+	TotalCount int              `json:"totalCount"`
+}
+
+type InboxItemEdge struct {
+	Cursor string           `json:"cursor"`
+	Node   *model.InboxItem `json:"node"`
+}
+
+type InboxItemInput struct {
+	Text    string  `json:"text"`
+	Context *string `json:"context,omitempty"`
+}
+
+type KindleImportInput struct {
+	Full *bool `json:"full,omitempty"`
+}
+
+// Итог импорта Vocabulary Builder Kindle.
+type KindleImportResult struct {
+	Lookups       int        `json:"lookups"`
+	NewLookups    int        `json:"newLookups"`
+	Imported      int        `json:"imported"`
+	Duplicates    int        `json:"duplicates"`
+	Mastered      int        `json:"mastered"`
+	ImportedUntil *time.Time `json:"importedUntil,omitempty"`
+}
+
+type MineWordsInput struct {
+	Format *TextFormat `json:"format,omitempty"`
+	Limit  *int        `json:"limit,omitempty"`
+}
+
+type MineWordsResult struct {
+	Format     TextFormat   `json:"format"`
+	Tokens     int          `json:"tokens"`
+	Words      int          `json:"words"`
+	Known      int          `json:"known"`
+	Candidates []*MinedWord `json:"candidates"`
+}
+
+// Незнакомое слово из текста.
+type MinedWord struct {
+	Lemma         string   `json:"lemma"`
+	Forms         []string `json:"forms"`
+	Count         int      `json:"count"`
+	FrequencyRank *int     `json:"frequencyRank,omitempty"`
+	CefrLevel     *string  `json:"cefrLevel,omitempty"`
+	Score         float64  `json:"score"`
+	Context       string   `json:"context"`
+	Examples      []string `json:"examples"`
 }
 
 type Mutation struct {
 }
 
+// Информация о странице. Курсоры непрозрачны: передавайте endCursor в after,
+// чтобы получить следующую страницу.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type PronunciationInput struct {
-	AudioURL      string  `json:"audioUrl"`
-	Transcription *string `json:"transcription,omitempty"`
-	Region        *string `json:"region,omitempty"`
-	SourceSlug    *string `json:"sourceSlug,omitempty"`
+	AudioURL      *string    `json:"audioUrl,omitempty"`
+	Transcription *string    `json:"transcription,omitempty"`
+	Region        *string    `json:"region,omitempty"`
+	SourceSlug    *string    `json:"sourceSlug,omitempty"`
+	MediaID       *uuid.UUID `json:"mediaId,omitempty"`
+}
+
+type PronunciationUpsertInput struct {
+	ID            *uuid.UUID `json:"id,omitempty"`
+	AudioURL      *string    `json:"audioUrl,omitempty"`
+	Transcription *string    `json:"transcription,omitempty"`
+	Region        *string    `json:"region,omitempty"`
+	SourceSlug    *string    `json:"sourceSlug,omitempty"`
+	MediaID       *uuid.UUID `json:"mediaId,omitempty"`
 }
 
 type Query struct {
 }
 
+type RelationInput struct {
+	Type       model.RelationType `json:"type"`
+	TargetText string             `json:"targetText"`
+	SourceSlug *string            `json:"sourceSlug,omitempty"`
+}
+
 type ReviewResult struct {
 	Entry        *model.DictionaryEntry `json:"entry"`
 	NextReviewAt time.Time              `json:"nextReviewAt"`
@@ -67,14 +333,22 @@ type SenseInput struct {
 	Definition   *string             `json:"definition,omitempty"`
 	PartOfSpeech *model.PartOfSpeech `json:"partOfSpeech,omitempty"`
 	SourceSlug   *string             `json:"sourceSlug,omitempty"`
+	Notes        *string             `json:"notes,omitempty"`
 	Translations []*TranslationInput `json:"translations,omitempty"`
 	Examples     []*ExampleInput     `json:"examples,omitempty"`
+	Relations    []*RelationInput    `json:"relations,omitempty"`
 }
 
-type SenseRelation struct {
-	ID            uuid.UUID    `json:"id"`
-	TargetEntryID uuid.UUID    `json:"targetEntryId"`
-	Type          RelationType `json:"type"`
+type SenseUpsertInput struct {
+	ID                   *uuid.UUID                `json:"id,omitempty"`
+	Definition           *string                   `json:"definition,omitempty"`
+	PartOfSpeech         *model.PartOfSpeech       `json:"partOfSpeech,omitempty"`
+	SourceSlug           *string                   `json:"sourceSlug,omitempty"`
+	Notes                *string                   `json:"notes,omitempty"`
+	Translations         []*TranslationUpsertInput `json:"translations,omitempty"`
+	Examples             []*ExampleUpsertInput     `json:"examples,omitempty"`
+	DeleteTranslationIds []uuid.UUID               `json:"deleteTranslationIds,omitempty"`
+	DeleteExampleIds     []uuid.UUID               `json:"deleteExampleIds,omitempty"`
 }
 
 type SuggestedExample struct {
@@ -82,6 +356,11 @@ type SuggestedExample struct {
 	Translation *string `json:"translation,omitempty"`
 }
 
+type SuggestedForm struct {
+	Text string   `json:"text"`
+	Tags []string `json:"tags"`
+}
+
 type SuggestedImage struct {
 	URL          string  `json:"url"`
 	ThumbnailURL *string `json:"thumbnailUrl,omitempty"`
@@ -94,11 +373,17 @@ type SuggestedPronunciation struct {
 	Region        *string `json:"region,omitempty"`
 }
 
+type SuggestedRelation struct {
+	Type model.RelationType `json:"type"`
+	Text string             `json:"text"`
+}
+
 type SuggestedSense struct {
-	Definition   string              `json:"definition"`
-	PartOfSpeech *model.PartOfSpeech `json:"partOfSpeech,omitempty"`
-	Examples     []*SuggestedExample `json:"examples"`
-	Translations []string            `json:"translations"`
+	Definition   string               `json:"definition"`
+	PartOfSpeech *model.PartOfSpeech  `json:"partOfSpeech,omitempty"`
+	Examples     []*SuggestedExample  `json:"examples"`
+	Translations []string             `json:"translations"`
+	Relations    []*SuggestedRelation `json:"relations"`
 }
 
 // Результат поиска во внешних источниках.
@@ -108,74 +393,285 @@ type SuggestionResult struct {
 	Senses         []*SuggestedSense         `json:"senses"`
 	Images         []*SuggestedImage         `json:"images"`
 	Pronunciations []*SuggestedPronunciation `json:"pronunciations"`
+	Synonyms       []string                  `json:"synonyms"`
+	Forms          []*SuggestedForm          `json:"forms"`
+	Cached         bool                      `json:"cached"`
 }
 
 type TranslationInput struct {
 	Text       string  `json:"text"`
+	Language   *string `json:"language,omitempty"`
 	SourceSlug *string `json:"sourceSlug,omitempty"`
 }
 
+// Результат обратного поиска по переводу (RU -> EN).
+type TranslationMatch struct {
+	Entry       *model.DictionaryEntry `json:"entry"`
+	Sense       *model.Sense           `json:"sense"`
+	Translation *model.Translation     `json:"translation"`
+	Similarity  float64                `json:"similarity"`
+}
+
+type TranslationUpsertInput struct {
+	ID         *uuid.UUID `json:"id,omitempty"`
+	Text       string     `json:"text"`
+	Language   *string    `json:"language,omitempty"`
+	SourceSlug *string    `json:"sourceSlug,omitempty"`
+}
+
+// Патч для обновления слова.
+// Вложенные сущности обновляются точечно по ID:
+// элемент с id обновляет существующую сущность, без id — создает новую,
+// delete*Ids удаляют сущности. Не упомянутые сущности не изменяются.
 type UpdateWordInput struct {
-	Text   *string       `json:"text,omitempty"`
-	Senses []*SenseInput `json:"senses,omitempty"`
+	Text                   *string                     `json:"text,omitempty"`
+	Language               *string                     `json:"language,omitempty"`
+	Notes                  *string                     `json:"notes,omitempty"`
+	NotesOnCard            *bool                       `json:"notesOnCard,omitempty"`
+	Senses                 []*SenseUpsertInput         `json:"senses,omitempty"`
+	Images                 []*ImageUpsertInput         `json:"images,omitempty"`
+	Pronunciations         []*PronunciationUpsertInput `json:"pronunciations,omitempty"`
+	DeleteSenseIds         []uuid.UUID                 `json:"deleteSenseIds,omitempty"`
+	DeleteImageIds         []uuid.UUID                 `json:"deleteImageIds,omitempty"`
+	DeletePronunciationIds []uuid.UUID                 `json:"deletePronunciationIds,omitempty"`
 }
 
 type WordFilter struct {
-	Search       *string              `json:"search,omitempty"`
-	HasCard      *bool                `json:"hasCard,omitempty"`
-	PartOfSpeech *model.PartOfSpeech  `json:"partOfSpeech,omitempty"`
-	Limit        *int                 `json:"limit,omitempty"`
-	Offset       *int                 `json:"offset,omitempty"`
-	SortBy       *model.WordSortField `json:"sortBy,omitempty"`
-	SortDir      *model.SortDirection `json:"sortDir,omitempty"`
+	Language         *string              `json:"language,omitempty"`
+	Search           *string              `json:"search,omitempty"`
+	HasCard          *bool                `json:"hasCard,omitempty"`
+	PartOfSpeech     *model.PartOfSpeech  `json:"partOfSpeech,omitempty"`
+	CefrLevels       []string             `json:"cefrLevels,omitempty"`
+	MinFrequencyRank *int                 `json:"minFrequencyRank,omitempty"`
+	MaxFrequencyRank *int                 `json:"maxFrequencyRank,omitempty"`
+	Tag              *string              `json:"tag,omitempty"`
+	List             *string              `json:"list,omitempty"`
+	Limit            *int                 `json:"limit,omitempty"`
+	Offset           *int                 `json:"offset,omitempty"`
+	SortBy           *model.WordSortField `json:"sortBy,omitempty"`
+	SortDir          *model.SortDirection `json:"sortDir,omitempty"`
+}
+
+// Список слов (см. bulkMoveToList).
+type WordList struct {
+	Name      string `json:"name"`
+	WordCount int    `json:"wordCount"`
+}
+
+// Что делать со словом, которое уже есть в словаре или выше в файле.
+type CSVDuplicatePolicy string
+
+const (
+	CSVDuplicatePolicySkip  CSVDuplicatePolicy = "SKIP"
+	CSVDuplicatePolicyMerge CSVDuplicatePolicy = "MERGE"
+)
+
+var AllCSVDuplicatePolicy = []CSVDuplicatePolicy{
+	CSVDuplicatePolicySkip,
+	CSVDuplicatePolicyMerge,
+}
+
+func (e CSVDuplicatePolicy) IsValid() bool {
+	switch e {
+	case CSVDuplicatePolicySkip, CSVDuplicatePolicyMerge:
+		return true
+	}
+	return false
+}
+
+func (e CSVDuplicatePolicy) String() string {
+	return string(e)
+}
+
+func (e *CSVDuplicatePolicy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CSVDuplicatePolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CsvDuplicatePolicy", str)
+	}
+	return nil
+}
+
+func (e CSVDuplicatePolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CSVDuplicatePolicy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CSVDuplicatePolicy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CSVImportFormat string
+
+const (
+	CSVImportFormatCSV CSVImportFormat = "CSV"
+	CSVImportFormatTsv CSVImportFormat = "TSV"
+)
+
+var AllCSVImportFormat = []CSVImportFormat{
+	CSVImportFormatCSV,
+	CSVImportFormatTsv,
+}
+
+func (e CSVImportFormat) IsValid() bool {
+	switch e {
+	case CSVImportFormatCSV, CSVImportFormatTsv:
+		return true
+	}
+	return false
+}
+
+func (e CSVImportFormat) String() string {
+	return string(e)
+}
+
+func (e *CSVImportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CSVImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CsvImportFormat", str)
+	}
+	return nil
+}
+
+func (e CSVImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CSVImportFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CSVImportFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CSVRowAction string
+
+const (
+	CSVRowActionNew   CSVRowAction = "NEW"
+	CSVRowActionMerge CSVRowAction = "MERGE"
+	CSVRowActionSkip  CSVRowAction = "SKIP"
+	CSVRowActionError CSVRowAction = "ERROR"
+)
+
+var AllCSVRowAction = []CSVRowAction{
+	CSVRowActionNew,
+	CSVRowActionMerge,
+	CSVRowActionSkip,
+	CSVRowActionError,
+}
+
+func (e CSVRowAction) IsValid() bool {
+	switch e {
+	case CSVRowActionNew, CSVRowActionMerge, CSVRowActionSkip, CSVRowActionError:
+		return true
+	}
+	return false
+}
+
+func (e CSVRowAction) String() string {
+	return string(e)
+}
+
+func (e *CSVRowAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CSVRowAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CsvRowAction", str)
+	}
+	return nil
+}
+
+func (e CSVRowAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CSVRowAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CSVRowAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
 
-type RelationType string
+type TextFormat string
 
 const (
-	RelationTypeSynonym     RelationType = "SYNONYM"
-	RelationTypeAntonym     RelationType = "ANTONYM"
-	RelationTypeRelated     RelationType = "RELATED"
-	RelationTypeCollocation RelationType = "COLLOCATION"
+	TextFormatText TextFormat = "TEXT"
+	TextFormatSrt  TextFormat = "SRT"
+	TextFormatVtt  TextFormat = "VTT"
 )
 
-var AllRelationType = []RelationType{
-	RelationTypeSynonym,
-	RelationTypeAntonym,
-	RelationTypeRelated,
-	RelationTypeCollocation,
+var AllTextFormat = []TextFormat{
+	TextFormatText,
+	TextFormatSrt,
+	TextFormatVtt,
 }
 
-func (e RelationType) IsValid() bool {
+func (e TextFormat) IsValid() bool {
 	switch e {
-	case RelationTypeSynonym, RelationTypeAntonym, RelationTypeRelated, RelationTypeCollocation:
+	case TextFormatText, TextFormatSrt, TextFormatVtt:
 		return true
 	}
 	return false
 }
 
-func (e RelationType) String() string {
+func (e TextFormat) String() string {
 	return string(e)
 }
 
-func (e *RelationType) UnmarshalGQL(v any) error {
+func (e *TextFormat) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = RelationType(str)
+	*e = TextFormat(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid RelationType", str)
+		return fmt.Errorf("%s is not a valid TextFormat", str)
 	}
 	return nil
 }
 
-func (e RelationType) MarshalGQL(w io.Writer) {
+func (e TextFormat) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *RelationType) UnmarshalJSON(b []byte) error {
+func (e *TextFormat) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -183,7 +679,7 @@ func (e *RelationType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e RelationType) MarshalJSON() ([]byte, error) {
+func (e TextFormat) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
@@ -6,8 +6,12 @@ package graph
 // Code generated by github.com/99designs/gqlgen version v0.17.85
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	model1 "github.com/heartmarshall/my-english/graph/model"
 	"github.com/heartmarshall/my-english/graph/scalar"
@@ -48,6 +52,22 @@ func (r *cardResolver) ReviewHistory(ctx context.Context, obj *model.Card, limit
 	return res, nil
 }
 
+// CardBack is the resolver for the cardBack field.
+func (r *dictionaryEntryResolver) CardBack(ctx context.Context, obj *model.DictionaryEntry) (*string, error) {
+	if !obj.NotesOnCard {
+		return nil, nil
+	}
+	return obj.Notes, nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *dictionaryEntryResolver) Tags(ctx context.Context, obj *model.DictionaryEntry) ([]string, error) {
+	if obj.Tags == nil {
+		return []string{}, nil
+	}
+	return obj.Tags, nil
+}
+
 // Pronunciations is the resolver for the pronunciations field.
 func (r *dictionaryEntryResolver) Pronunciations(ctx context.Context, obj *model.DictionaryEntry) ([]*model.Pronunciation, error) {
 	// Используем DataLoader
@@ -128,9 +148,96 @@ func (r *dictionaryEntryResolver) CardEnabled(ctx context.Context, obj *model.Di
 }
 
 // AuditLog is the resolver for the auditLog field.
-func (r *dictionaryEntryResolver) AuditLog(ctx context.Context, obj *model.DictionaryEntry) ([]*model.AuditRecord, error) {
-	// TODO: Реализовать получение аудита через сервис/репо. Пока пустой список.
-	return []*model.AuditRecord{}, nil
+func (r *dictionaryEntryResolver) AuditLog(ctx context.Context, obj *model.DictionaryEntry, entityType *model.EntityType, from *time.Time, to *time.Time, limit *int, offset *int) ([]*model.AuditRecord, error) {
+	records, err := r.Services.Dictionary.AuditLog(ctx, dictservice.AuditFilter{
+		EntryID:    &obj.ID,
+		EntityType: entityType,
+		From:       from,
+		To:         to,
+		Limit:      getInt(limit, 50),
+		Offset:     getInt(offset, 0),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+
+	res := make([]*model.AuditRecord, len(records))
+	for i := range records {
+		res[i] = &records[i]
+	}
+	return res, nil
+}
+
+// AuditLogConnection is the resolver for the auditLogConnection field.
+func (r *dictionaryEntryResolver) AuditLogConnection(ctx context.Context, obj *model.DictionaryEntry, entityType *model.EntityType, from *time.Time, to *time.Time, first *int, after *string) (*model1.AuditRecordConnection, error) {
+	page, err := r.Services.Dictionary.AuditLogPage(ctx, dictservice.AuditFilter{
+		EntryID:    &obj.ID,
+		EntityType: entityType,
+		From:       from,
+		To:         to,
+	}, getInt(first, 50), getString(after))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapAuditRecordConnection(page), nil
+}
+
+// Media is the resolver for the media field.
+func (r *imageResolver) Media(ctx context.Context, obj *model.Image) (*model.Media, error) {
+	if obj.MediaID == nil {
+		return nil, nil
+	}
+	loaders, err := dataloader.MustFor(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	media, err := loaders.MediaByID.Load(ctx, *obj.MediaID)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return media, nil
+}
+
+// ThumbnailURL is the resolver for the thumbnailUrl field.
+func (r *imageResolver) ThumbnailURL(ctx context.Context, obj *model.Image) (*string, error) {
+	media, err := r.Media(ctx, obj)
+	if err != nil || media == nil {
+		return nil, err
+	}
+	return mapMediaURL(media.ThumbnailID), nil
+}
+
+// Width is the resolver for the width field.
+func (r *imageResolver) Width(ctx context.Context, obj *model.Image) (*int, error) {
+	media, err := r.Media(ctx, obj)
+	if err != nil || media == nil {
+		return nil, err
+	}
+	return media.Width, nil
+}
+
+// Height is the resolver for the height field.
+func (r *imageResolver) Height(ctx context.Context, obj *model.Image) (*int, error) {
+	media, err := r.Media(ctx, obj)
+	if err != nil || media == nil {
+		return nil, err
+	}
+	return media.Height, nil
+}
+
+// URL is the resolver for the url field.
+func (r *mediaResolver) URL(ctx context.Context, obj *model.Media) (string, error) {
+	return model.MediaURL(obj.ID), nil
+}
+
+// ThumbnailURL is the resolver for the thumbnailUrl field.
+func (r *mediaResolver) ThumbnailURL(ctx context.Context, obj *model.Media) (*string, error) {
+	return mapMediaURL(obj.ThumbnailID), nil
+}
+
+// CardURL is the resolver for the cardUrl field.
+func (r *mediaResolver) CardURL(ctx context.Context, obj *model.Media) (*string, error) {
+	return mapMediaURL(obj.CardID), nil
 }
 
 // CreateWord is the resolver for the createWord field.
@@ -160,6 +267,317 @@ func (r *mutationResolver) DeleteWord(ctx context.Context, id uuid.UUID) (bool,
 	return true, nil
 }
 
+// RestoreWord is the resolver for the restoreWord field.
+func (r *mutationResolver) RestoreWord(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.RestoreWord(ctx, id.String())
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// PurgeWord is the resolver for the purgeWord field.
+func (r *mutationResolver) PurgeWord(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.Services.Dictionary.PurgeWord(ctx, id.String()); err != nil {
+		return false, transport.HandleError(ctx, err)
+	}
+	return true, nil
+}
+
+// MergeWords is the resolver for the mergeWords field.
+func (r *mutationResolver) MergeWords(ctx context.Context, targetID uuid.UUID, sourceIds []uuid.UUID) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.MergeWords(ctx, dictservice.MergeWordsInput{
+		TargetID:  targetID.String(),
+		SourceIDs: mapUUIDs(sourceIds),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// BulkDeleteWords is the resolver for the bulkDeleteWords field.
+func (r *mutationResolver) BulkDeleteWords(ctx context.Context, ids []uuid.UUID, filter *model1.WordFilter) (*model1.BulkResult, error) {
+	result, err := r.Services.Dictionary.BulkDeleteWords(ctx, mapBulkSelection(ids, filter))
+	if err != nil {
+		return nil, bulkError(ctx, result, err)
+	}
+	return mapBulkResult(result), nil
+}
+
+// BulkCreateCards is the resolver for the bulkCreateCards field.
+func (r *mutationResolver) BulkCreateCards(ctx context.Context, ids []uuid.UUID, filter *model1.WordFilter) (*model1.BulkResult, error) {
+	result, err := r.Services.Dictionary.BulkCreateCards(ctx, mapBulkSelection(ids, filter))
+	if err != nil {
+		return nil, bulkError(ctx, result, err)
+	}
+	return mapBulkResult(result), nil
+}
+
+// BulkResetCards is the resolver for the bulkResetCards field.
+func (r *mutationResolver) BulkResetCards(ctx context.Context, ids []uuid.UUID, filter *model1.WordFilter) (*model1.BulkResult, error) {
+	result, err := r.Services.Dictionary.BulkResetCards(ctx, mapBulkSelection(ids, filter))
+	if err != nil {
+		return nil, bulkError(ctx, result, err)
+	}
+	return mapBulkResult(result), nil
+}
+
+// BulkTag is the resolver for the bulkTag field.
+func (r *mutationResolver) BulkTag(ctx context.Context, ids []uuid.UUID, filter *model1.WordFilter, add []string, remove []string) (*model1.BulkResult, error) {
+	result, err := r.Services.Dictionary.BulkTag(ctx, mapBulkSelection(ids, filter), dictservice.BulkTagInput{Add: add, Remove: remove})
+	if err != nil {
+		return nil, bulkError(ctx, result, err)
+	}
+	return mapBulkResult(result), nil
+}
+
+// BulkMoveToList is the resolver for the bulkMoveToList field.
+func (r *mutationResolver) BulkMoveToList(ctx context.Context, ids []uuid.UUID, filter *model1.WordFilter, list *string) (*model1.BulkResult, error) {
+	result, err := r.Services.Dictionary.BulkMoveToList(ctx, mapBulkSelection(ids, filter), list)
+	if err != nil {
+		return nil, bulkError(ctx, result, err)
+	}
+	return mapBulkResult(result), nil
+}
+
+// RestoreWordVersion is the resolver for the restoreWordVersion field.
+func (r *mutationResolver) RestoreWordVersion(ctx context.Context, entryID uuid.UUID, at time.Time) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.RestoreWordVersion(ctx, entryID.String(), at)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// AddSense is the resolver for the addSense field.
+func (r *mutationResolver) AddSense(ctx context.Context, entryID uuid.UUID, input model1.SenseInput) (*model.DictionaryEntry, error) {
+	sense, err := r.Services.Dictionary.AddSense(ctx, mapAddSenseInput(entryID.String(), input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+
+	entry, err := r.Services.Dictionary.GetByID(ctx, sense.EntryID)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// AddExamples is the resolver for the addExamples field.
+func (r *mutationResolver) AddExamples(ctx context.Context, senseID uuid.UUID, examples []*model1.ExampleInput) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.AddExamples(ctx, dictservice.AddExamplesInput{
+		SenseID:  senseID.String(),
+		Examples: mapExamplesInput(examples),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// AddTranslations is the resolver for the addTranslations field.
+func (r *mutationResolver) AddTranslations(ctx context.Context, senseID uuid.UUID, translations []*model1.TranslationInput) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.AddTranslations(ctx, dictservice.AddTranslationsInput{
+		SenseID:      senseID.String(),
+		Translations: mapTranslationsInput(translations),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// AddRelations is the resolver for the addRelations field.
+func (r *mutationResolver) AddRelations(ctx context.Context, senseID uuid.UUID, relations []*model1.RelationInput) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.AddRelations(ctx, dictservice.AddRelationsInput{
+		SenseID:   senseID.String(),
+		Relations: mapRelationsInput(relations),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// AddImages is the resolver for the addImages field.
+func (r *mutationResolver) AddImages(ctx context.Context, entryID uuid.UUID, images []*model1.ImageInput) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.AddImages(ctx, dictservice.AddImagesInput{
+		EntryID: entryID.String(),
+		Images:  mapImagesInput(images),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// AddPronunciations is the resolver for the addPronunciations field.
+func (r *mutationResolver) AddPronunciations(ctx context.Context, entryID uuid.UUID, pronunciations []*model1.PronunciationInput) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.AddPronunciations(ctx, dictservice.AddPronunciationsInput{
+		EntryID:        entryID.String(),
+		Pronunciations: mapPronunciationsInput(pronunciations),
+	})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// DeleteSense is the resolver for the deleteSense field.
+func (r *mutationResolver) DeleteSense(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.DeleteSense(ctx, dictservice.DeleteSenseInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// DeleteExample is the resolver for the deleteExample field.
+func (r *mutationResolver) DeleteExample(ctx context.Context, id uuid.UUID) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.DeleteExample(ctx, dictservice.DeleteExampleInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// DeleteTranslation is the resolver for the deleteTranslation field.
+func (r *mutationResolver) DeleteTranslation(ctx context.Context, id uuid.UUID) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.DeleteTranslation(ctx, dictservice.DeleteTranslationInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// DeleteRelation is the resolver for the deleteRelation field.
+func (r *mutationResolver) DeleteRelation(ctx context.Context, id uuid.UUID) (*model.Sense, error) {
+	sense, err := r.Services.Dictionary.DeleteRelation(ctx, dictservice.DeleteRelationInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return sense, nil
+}
+
+// DeleteImage is the resolver for the deleteImage field.
+func (r *mutationResolver) DeleteImage(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.DeleteImage(ctx, dictservice.DeleteImageInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// DeletePronunciation is the resolver for the deletePronunciation field.
+func (r *mutationResolver) DeletePronunciation(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	entry, err := r.Services.Dictionary.DeletePronunciation(ctx, dictservice.DeletePronunciationInput{ID: id.String()})
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entry, nil
+}
+
+// UploadMedia is the resolver for the uploadMedia field.
+func (r *mutationResolver) UploadMedia(ctx context.Context, file graphql.Upload) (*model.Media, error) {
+	media, err := r.Services.Media.Upload(ctx, file.File, file.ContentType)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return media, nil
+}
+
+// ImportMedia is the resolver for the importMedia field.
+func (r *mutationResolver) ImportMedia(ctx context.Context, url string) (*model.Media, error) {
+	media, err := r.Services.Media.ImportURL(ctx, url)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return media, nil
+}
+
+// ImportAnki is the resolver for the importAnki field.
+func (r *mutationResolver) ImportAnki(ctx context.Context, file graphql.Upload, input *model1.AnkiImportInput) (*model1.AnkiImportResult, error) {
+	// gqlgen хранит загрузку в памяти или во временном файле — оба поддерживают ReaderAt
+	reader, ok := file.File.(io.ReaderAt)
+	if !ok {
+		data, err := io.ReadAll(file.File)
+		if err != nil {
+			return nil, transport.HandleError(ctx, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	result, err := r.Services.Anki.Import(ctx, reader, file.Size, mapAnkiImportInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapAnkiImportResult(result), nil
+}
+
+// PreviewCSVImport is the resolver for the previewCsvImport field.
+func (r *mutationResolver) PreviewCSVImport(ctx context.Context, file graphql.Upload, input model1.CSVImportInput) (*model1.CSVImportPreview, error) {
+	report, err := r.Services.CSVImport.Preview(ctx, file.File, mapCSVImportInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapCSVImportPreview(report), nil
+}
+
+// StartCSVImport is the resolver for the startCsvImport field.
+func (r *mutationResolver) StartCSVImport(ctx context.Context, file graphql.Upload, input model1.CSVImportInput) (*model1.CSVImportJob, error) {
+	job, err := r.Services.CSVImport.Start(ctx, file.File, mapCSVImportInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	result, err := mapCSVImportJob(job)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return result, nil
+}
+
+// ImportKindleVocab is the resolver for the importKindleVocab field.
+func (r *mutationResolver) ImportKindleVocab(ctx context.Context, file graphql.Upload, input *model1.KindleImportInput) (*model1.KindleImportResult, error) {
+	result, err := r.Services.Kindle.Import(ctx, file.File, mapKindleImportInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapKindleImportResult(result), nil
+}
+
+// MineWords is the resolver for the mineWords field.
+func (r *mutationResolver) MineWords(ctx context.Context, text *string, file *graphql.Upload, input *model1.MineWordsInput) (*model1.MineWordsResult, error) {
+	reader, err := mineWordsSource(text, file)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+
+	result, err := r.Services.Mining.Analyze(ctx, reader, mapMineWordsInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapMineWordsResult(result), nil
+}
+
+// AnalyzeBookCoverage is the resolver for the analyzeBookCoverage field.
+func (r *mutationResolver) AnalyzeBookCoverage(ctx context.Context, file graphql.Upload, input *model1.BookCoverageInput) (*model1.BookCoverage, error) {
+	result, err := r.Services.Mining.Coverage(ctx, file.File, mapBookCoverageInput(input))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapBookCoverage(result), nil
+}
+
+// InvalidateSuggestionCache is the resolver for the invalidateSuggestionCache field.
+func (r *mutationResolver) InvalidateSuggestionCache(ctx context.Context, provider *string, text *string, expiredOnly *bool) (int, error) {
+	n, err := r.Services.Suggestion.InvalidateCache(ctx, mapInvalidateSuggestionCacheInput(provider, text, expiredOnly))
+	if err != nil {
+		return 0, transport.HandleError(ctx, err)
+	}
+	return n, nil
+}
+
 // AddToInbox is the resolver for the addToInbox field.
 func (r *mutationResolver) AddToInbox(ctx context.Context, text string, context *string) (*model.InboxItem, error) {
 	item, err := r.Services.Inbox.AddToInbox(ctx, text, context)
@@ -169,6 +587,19 @@ func (r *mutationResolver) AddToInbox(ctx context.Context, text string, context
 	return item, nil
 }
 
+// AddToInboxBatch is the resolver for the addToInboxBatch field.
+func (r *mutationResolver) AddToInboxBatch(ctx context.Context, items []*model1.InboxItemInput) ([]*model.InboxItem, error) {
+	created, err := r.Services.Inbox.AddToInboxBatch(ctx, mapInboxItemInputs(items))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	result := make([]*model.InboxItem, len(created))
+	for i := range created {
+		result[i] = &created[i]
+	}
+	return result, nil
+}
+
 // DeleteInboxItem is the resolver for the deleteInboxItem field.
 func (r *mutationResolver) DeleteInboxItem(ctx context.Context, id uuid.UUID) (bool, error) {
 	err := r.Services.Inbox.Delete(ctx, id)
@@ -218,6 +649,22 @@ func (r *mutationResolver) ReviewCard(ctx context.Context, cardID uuid.UUID, gra
 	}, nil
 }
 
+// Media is the resolver for the media field.
+func (r *pronunciationResolver) Media(ctx context.Context, obj *model.Pronunciation) (*model.Media, error) {
+	if obj.MediaID == nil {
+		return nil, nil
+	}
+	loaders, err := dataloader.MustFor(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	media, err := loaders.MediaByID.Load(ctx, *obj.MediaID)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return media, nil
+}
+
 // FetchSuggestions is the resolver for the fetchSuggestions field.
 func (r *queryResolver) FetchSuggestions(ctx context.Context, text string, sources []string) ([]*model1.SuggestionResult, error) {
 	results, err := r.Services.Suggestion.FetchSuggestions(ctx, text, sources)
@@ -225,52 +672,7 @@ func (r *queryResolver) FetchSuggestions(ctx context.Context, text string, sourc
 		return nil, transport.HandleError(ctx, err)
 	}
 
-	// Mapping []suggestion.Result -> []*model1.SuggestionResult
-	// Это лучше вынести в mapper, но для скорости напишем тут.
-	out := make([]*model1.SuggestionResult, len(results))
-	for i, res := range results {
-		// Mapping SenseInput -> SuggestedSense
-		senses := make([]*model1.SuggestedSense, len(res.Senses))
-		for j, s := range res.Senses {
-			// Mapping TranslationInput string -> string
-			trans := make([]string, len(s.Translations))
-			for k, t := range s.Translations {
-				trans[k] = t.Text
-			}
-			// Mapping Examples
-			exs := make([]*model1.SuggestedExample, len(s.Examples))
-			for k, e := range s.Examples {
-				exs[k] = &model1.SuggestedExample{
-					Sentence:    e.Sentence,
-					Translation: e.Translation,
-				}
-			}
-
-			def := ""
-			if s.Definition != nil {
-				def = *s.Definition
-			}
-
-			senses[j] = &model1.SuggestedSense{
-				Definition:   def,
-				PartOfSpeech: s.PartOfSpeech,
-				Translations: trans,
-				Examples:     exs,
-			}
-		}
-
-		// Images and Pronunciations mapping skipped for brevity (similar logic)
-		// ...
-
-		out[i] = &model1.SuggestionResult{
-			SourceSlug:     res.SourceSlug,
-			SourceName:     res.SourceName,
-			Senses:         senses,
-			Images:         []*model1.SuggestedImage{},         // TODO: map images
-			Pronunciations: []*model1.SuggestedPronunciation{}, // TODO: map pron
-		}
-	}
-	return out, nil
+	return mapSuggestionResults(results), nil
 }
 
 // Dictionary is the resolver for the dictionary field.
@@ -287,6 +689,15 @@ func (r *queryResolver) Dictionary(ctx context.Context, filter *model1.WordFilte
 	return res, nil
 }
 
+// DictionaryConnection is the resolver for the dictionaryConnection field.
+func (r *queryResolver) DictionaryConnection(ctx context.Context, filter *model1.WordFilter, first *int, after *string) (*model1.DictionaryConnection, error) {
+	page, err := r.Services.Dictionary.FindPage(ctx, mapDictionaryFilter(filter), getInt(first, 20), getString(after))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapDictionaryConnection(page), nil
+}
+
 // DictionaryEntry is the resolver for the dictionaryEntry field.
 func (r *queryResolver) DictionaryEntry(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
 	entry, err := r.Services.Dictionary.GetByID(ctx, id)
@@ -296,6 +707,51 @@ func (r *queryResolver) DictionaryEntry(ctx context.Context, id uuid.UUID) (*mod
 	return entry, nil
 }
 
+// LookupByTranslation is the resolver for the lookupByTranslation field.
+func (r *queryResolver) LookupByTranslation(ctx context.Context, text string, limit *int) ([]*model1.TranslationMatch, error) {
+	results, err := r.Services.Dictionary.LookupByTranslation(ctx, text, getInt(limit, 20))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapTranslationMatches(results), nil
+}
+
+// Trash is the resolver for the trash field.
+func (r *queryResolver) Trash(ctx context.Context, limit *int, offset *int) ([]*model.DictionaryEntry, error) {
+	entries, err := r.Services.Dictionary.ListTrash(ctx, getInt(limit, 50), getInt(offset, 0))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+
+	res := make([]*model.DictionaryEntry, len(entries))
+	for i := range entries {
+		res[i] = &entries[i]
+	}
+	return res, nil
+}
+
+// DuplicateCandidates is the resolver for the duplicateCandidates field.
+func (r *queryResolver) DuplicateCandidates(ctx context.Context, minSimilarity *float64, limit *int) ([]*model1.DuplicateCandidate, error) {
+	minSim := dictservice.DefaultDuplicateSimilarity
+	if minSimilarity != nil {
+		minSim = *minSimilarity
+	}
+	candidates, err := r.Services.Dictionary.DuplicateCandidates(ctx, minSim, getInt(limit, 20))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapDuplicateCandidates(candidates), nil
+}
+
+// WordLists is the resolver for the wordLists field.
+func (r *queryResolver) WordLists(ctx context.Context) ([]*model1.WordList, error) {
+	lists, err := r.Services.Dictionary.ListWordLists(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapWordLists(lists), nil
+}
+
 // InboxItems is the resolver for the inboxItems field.
 func (r *queryResolver) InboxItems(ctx context.Context) ([]*model.InboxItem, error) {
 	items, err := r.Services.Inbox.List(ctx)
@@ -310,13 +766,22 @@ func (r *queryResolver) InboxItems(ctx context.Context) ([]*model.InboxItem, err
 	return res, nil
 }
 
+// InboxItemsConnection is the resolver for the inboxItemsConnection field.
+func (r *queryResolver) InboxItemsConnection(ctx context.Context, first *int, after *string) (*model1.InboxItemConnection, error) {
+	page, err := r.Services.Inbox.ListPage(ctx, getInt(first, 20), getString(after))
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapInboxItemConnection(page), nil
+}
+
 // StudyQueue is the resolver for the studyQueue field.
-func (r *queryResolver) StudyQueue(ctx context.Context, limit *int) ([]*model.DictionaryEntry, error) {
+func (r *queryResolver) StudyQueue(ctx context.Context, limit *int, language *string) ([]*model.DictionaryEntry, error) {
 	lim := 20
 	if limit != nil {
 		lim = *limit
 	}
-	entries, err := r.Services.Study.GetStudyQueue(ctx, lim)
+	entries, err := r.Services.Study.GetStudyQueue(ctx, lim, language)
 	if err != nil {
 		return nil, transport.HandleError(ctx, err)
 	}
@@ -347,6 +812,28 @@ func (r *queryResolver) DashboardStats(ctx context.Context) (*model1.DashboardSt
 	}, nil
 }
 
+// VocabularyCoverage is the resolver for the vocabularyCoverage field.
+func (r *queryResolver) VocabularyCoverage(ctx context.Context) ([]*model1.CefrCoverage, error) {
+	coverage, err := r.Services.Study.GetVocabularyCoverage(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return mapVocabularyCoverage(coverage), nil
+}
+
+// CSVImportJob is the resolver for the csvImportJob field.
+func (r *queryResolver) CSVImportJob(ctx context.Context, id uuid.UUID) (*model1.CSVImportJob, error) {
+	job, err := r.Services.CSVImport.GetJob(ctx, id)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	result, err := mapCSVImportJob(job)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return result, nil
+}
+
 // Translations is the resolver for the translations field.
 func (r *senseResolver) Translations(ctx context.Context, obj *model.Sense) ([]*model.Translation, error) {
 	loaders, err := dataloader.MustFor(ctx)
@@ -382,9 +869,33 @@ func (r *senseResolver) Examples(ctx context.Context, obj *model.Sense) ([]*mode
 }
 
 // Relations is the resolver for the relations field.
-func (r *senseResolver) Relations(ctx context.Context, obj *model.Sense) ([]*model1.SenseRelation, error) {
-	// Not implemented
-	return []*model1.SenseRelation{}, nil
+func (r *senseResolver) Relations(ctx context.Context, obj *model.Sense) ([]*model.SenseRelation, error) {
+	loaders, err := dataloader.MustFor(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	items, err := loaders.RelationsBySenseID.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	res := make([]*model.SenseRelation, len(items))
+	for i := range items {
+		res[i] = &items[i]
+	}
+	return res, nil
+}
+
+// TargetEntryID is the resolver for the targetEntryId field.
+func (r *senseRelationResolver) TargetEntryID(ctx context.Context, obj *model.SenseRelation) (*uuid.UUID, error) {
+	loaders, err := dataloader.MustFor(ctx)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	entryID, err := loaders.RelationTargetByID.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, transport.HandleError(ctx, err)
+	}
+	return entryID, nil
 }
 
 // AuditRecord returns AuditRecordResolver implementation.
@@ -396,18 +907,34 @@ func (r *Resolver) Card() CardResolver { return &cardResolver{r} }
 // DictionaryEntry returns DictionaryEntryResolver implementation.
 func (r *Resolver) DictionaryEntry() DictionaryEntryResolver { return &dictionaryEntryResolver{r} }
 
+// Image returns ImageResolver implementation.
+func (r *Resolver) Image() ImageResolver { return &imageResolver{r} }
+
+// Media returns MediaResolver implementation.
+func (r *Resolver) Media() MediaResolver { return &mediaResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Pronunciation returns PronunciationResolver implementation.
+func (r *Resolver) Pronunciation() PronunciationResolver { return &pronunciationResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 // Sense returns SenseResolver implementation.
 func (r *Resolver) Sense() SenseResolver { return &senseResolver{r} }
 
+// SenseRelation returns SenseRelationResolver implementation.
+func (r *Resolver) SenseRelation() SenseRelationResolver { return &senseRelationResolver{r} }
+
 type auditRecordResolver struct{ *Resolver }
 type cardResolver struct{ *Resolver }
 type dictionaryEntryResolver struct{ *Resolver }
+type imageResolver struct{ *Resolver }
+type mediaResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type pronunciationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type senseResolver struct{ *Resolver }
+type senseRelationResolver struct{ *Resolver }
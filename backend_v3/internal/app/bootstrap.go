@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/service"
+	"github.com/heartmarshall/my-english/internal/service/media"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPool подключается к БД с настройками пула из конфигурации и проверяет соединение.
+// Используется сервером и утилитами командной строки.
+func NewPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("parse db config: %w", err)
+	}
+
+	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	poolConfig.MinConns = int32(cfg.MaxIdleConns)
+	poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return pool, nil
+}
+
+// NewServices создаёт репозитории, хранилище медиафайлов и сервисы приложения.
+func NewServices(cfg *config.Config, pool *pgxpool.Pool) (*service.Services, *repository.Registry, error) {
+	repos := repository.NewRegistry(pool)
+
+	store, err := NewStorage(cfg.Media)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialize media storage: %w", err)
+	}
+
+	providers, err := NewProviders(cfg.Suggestion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialize suggestion providers: %w", err)
+	}
+
+	services, err := service.NewServices(service.Deps{
+		Repos:     repos,
+		TxManager: database.NewTxManager(pool),
+		Providers: providers,
+		Storage:   store,
+		Media: media.Config{
+			MaxSize:         cfg.Media.MaxSize,
+			DownloadTimeout: cfg.Media.DownloadTimeout,
+			ThumbnailSize:   cfg.Media.ThumbnailSize,
+			CardSize:        cfg.Media.CardSize,
+
+			AllowPrivateNetworks: cfg.Media.AllowPrivateNetworks,
+		},
+		ChunkSize:  cfg.Import.ChunkSize,
+		Wiktionary: cfg.Suggestion.Wiktionary.Enabled,
+
+		SuggestionCache: newSuggestionCacheConfig(cfg.Suggestion.Cache),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialize services: %w", err)
+	}
+	return services, repos, nil
+}
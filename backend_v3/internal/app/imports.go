@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/config"
+)
+
+// ImportProcessor — интерфейс фоновой записи заданий импорта.
+type ImportProcessor interface {
+	ProcessPending(ctx context.Context) (bool, error)
+}
+
+// ImportWorker периодически записывает пачки строк из незавершённых
+// заданий импорта.
+type ImportWorker struct {
+	processor ImportProcessor
+	cfg       config.ImportConfig
+	logger    *slog.Logger
+}
+
+// NewImportWorker создаёт новый ImportWorker.
+func NewImportWorker(processor ImportProcessor, cfg config.ImportConfig, logger *slog.Logger) *ImportWorker {
+	return &ImportWorker{
+		processor: processor,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Run запускает цикл импорта и блокируется до отмены ctx.
+// Если интервал не задан, импорт не выполняется.
+func (w *ImportWorker) Run(ctx context.Context) {
+	if w.cfg.ProcessInterval <= 0 {
+		w.logger.Info("background import disabled")
+		return
+	}
+
+	ticker := time.NewTicker(w.cfg.ProcessInterval)
+	defer ticker.Stop()
+
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// process записывает пачки, пока не останется незавершённых заданий.
+func (w *ImportWorker) process(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.processor.ProcessPending(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("import processing failed", slog.Any("error", err))
+			}
+			return
+		}
+		if !processed {
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/config"
+)
+
+// ImageProcessor — интерфейс фоновой обработки загруженных изображений.
+type ImageProcessor interface {
+	ProcessPending(ctx context.Context, limit int) (int, error)
+}
+
+// MediaWorker периодически создаёт миниатюры и варианты для карточек
+// для новых изображений в хранилище.
+type MediaWorker struct {
+	processor ImageProcessor
+	cfg       config.MediaConfig
+	logger    *slog.Logger
+}
+
+// NewMediaWorker создаёт новый MediaWorker.
+func NewMediaWorker(processor ImageProcessor, cfg config.MediaConfig, logger *slog.Logger) *MediaWorker {
+	return &MediaWorker{
+		processor: processor,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Run запускает цикл обработки и блокируется до отмены ctx.
+// Если интервал или размер пачки не заданы, обработка не выполняется.
+func (w *MediaWorker) Run(ctx context.Context) {
+	if w.cfg.ProcessInterval <= 0 || w.cfg.ProcessBatchSize <= 0 {
+		w.logger.Info("image processing disabled")
+		return
+	}
+
+	ticker := time.NewTicker(w.cfg.ProcessInterval)
+	defer ticker.Stop()
+
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// process обрабатывает пачки изображений, пока очередь не опустеет.
+func (w *MediaWorker) process(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.processor.ProcessPending(ctx, w.cfg.ProcessBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("image processing failed", slog.Any("error", err))
+			}
+			return
+		}
+		if processed > 0 {
+			w.logger.Info("images processed", slog.Int("count", processed))
+		}
+		if processed < w.cfg.ProcessBatchSize {
+			return
+		}
+	}
+}
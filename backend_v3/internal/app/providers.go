@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/clients/freedict"
+	"github.com/heartmarshall/my-english/internal/clients/localdict"
+	"github.com/heartmarshall/my-english/internal/clients/wordnet"
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+// NewProviders создаёт включённые в конфигурации провайдеры подсказок.
+// Файлы локальных словарей и WordNet открываются на всё время работы приложения.
+func NewProviders(cfg config.SuggestionConfig) ([]suggestion.Provider, error) {
+	var providers []suggestion.Provider
+
+	if cfg.FreeDict.Enabled {
+		client, err := freedict.NewClient(freedict.Config{
+			BaseURL: cfg.FreeDict.BaseURL,
+			Timeout: cfg.FreeDict.Timeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create freedict provider: %w", err)
+		}
+		providers = append(providers, client)
+	}
+
+	dictionaries, err := openLocalDictionaries(cfg.LocalDictionaries)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dictionaries {
+		providers = append(providers, d)
+	}
+
+	if dir := strings.TrimSpace(cfg.WordNet.Dir); dir != "" {
+		wn, err := wordnet.Open(dir)
+		if err != nil {
+			for _, d := range dictionaries {
+				d.Close()
+			}
+			return nil, fmt.Errorf("create wordnet provider: %w", err)
+		}
+		providers = append(providers, wn)
+	}
+
+	return providers, nil
+}
+
+// openLocalDictionaries открывает файлы словарей. Идентификаторы провайдеров
+// строятся по именам файлов и должны быть уникальными.
+func openLocalDictionaries(cfg config.LocalDictConfig) ([]*localdict.Provider, error) {
+	var dictionaries []*localdict.Provider
+	closeAll := func() {
+		for _, d := range dictionaries {
+			d.Close()
+		}
+	}
+
+	slugs := make(map[string]string)
+	for _, path := range cfg.Paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		slug := localdict.SlugFor(path)
+		if prev, ok := slugs[slug]; ok {
+			closeAll()
+			return nil, fmt.Errorf("local dictionaries %s and %s have the same provider slug %q", prev, path, slug)
+		}
+		slugs[slug] = path
+
+		d, err := localdict.Open(localdict.Config{Path: path, Language: cfg.Language})
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("create local dictionary provider: %w", err)
+		}
+		dictionaries = append(dictionaries, d)
+	}
+	return dictionaries, nil
+}
+
+// newSuggestionCacheConfig возвращает настройки кэша подсказок или nil, если кэш выключен.
+func newSuggestionCacheConfig(cfg config.SuggestionCacheConfig) *suggestion.CacheConfig {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &suggestion.CacheConfig{
+		TTL:         cfg.TTL,
+		NegativeTTL: cfg.NegativeTTL,
+		ProviderTTL: cfg.ProviderTTL,
+		MemorySize:  cfg.MemorySize,
+	}
+}
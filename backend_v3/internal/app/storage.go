@@ -0,0 +1,27 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/config"
+	"github.com/heartmarshall/my-english/internal/storage"
+)
+
+// NewStorage создаёт хранилище медиафайлов согласно конфигурации.
+func NewStorage(cfg config.MediaConfig) (storage.Storage, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return storage.NewLocalStorage(cfg.LocalDir)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			Prefix:          cfg.S3.Prefix,
+		})
+	default:
+		return nil, fmt.Errorf("unknown media backend %q", cfg.Backend)
+	}
+}
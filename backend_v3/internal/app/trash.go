@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/config"
+)
+
+// TrashPurger — интерфейс окончательной очистки корзины.
+type TrashPurger interface {
+	PurgeExpired(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// TrashWorker периодически удаляет слова, пролежавшие в корзине дольше срока хранения.
+type TrashWorker struct {
+	purger TrashPurger
+	cfg    config.TrashConfig
+	logger *slog.Logger
+}
+
+// NewTrashWorker создаёт новый TrashWorker.
+func NewTrashWorker(purger TrashPurger, cfg config.TrashConfig, logger *slog.Logger) *TrashWorker {
+	return &TrashWorker{
+		purger: purger,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Run запускает цикл очистки и блокируется до отмены ctx.
+// Если срок хранения или интервал не заданы, очистка не выполняется.
+func (w *TrashWorker) Run(ctx context.Context) {
+	if w.cfg.Retention <= 0 || w.cfg.PurgeInterval <= 0 {
+		w.logger.Info("trash purge disabled")
+		return
+	}
+
+	ticker := time.NewTicker(w.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	// Первая очистка сразу после старта, чтобы не ждать целый интервал
+	w.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.purge(ctx)
+		}
+	}
+}
+
+func (w *TrashWorker) purge(ctx context.Context) {
+	purged, err := w.purger.PurgeExpired(ctx, w.cfg.Retention)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("trash purge failed", slog.Any("error", err))
+		}
+		return
+	}
+	if purged > 0 {
+		w.logger.Info("trash purged", slog.Int64("entries", purged))
+	}
+}
@@ -1 +1,253 @@
+// Package freedict — клиент Free Dictionary API (https://dictionaryapi.dev),
+// провайдер подсказок для английских слов: определения с частью речи,
+// примеры и записи произношения с транскрипцией.
 package freedict
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+const (
+	// Slug — идентификатор провайдера в запросах подсказок.
+	Slug = "freedict"
+
+	// DefaultBaseURL — адрес статей английских слов.
+	DefaultBaseURL = "https://api.dictionaryapi.dev/api/v2/entries/en"
+
+	// DefaultTimeout — таймаут одного запроса.
+	DefaultTimeout = 5 * time.Second
+
+	// maxResponseSize — ответы больше считаются ошибкой.
+	maxResponseSize = 2 << 20
+)
+
+// Config — параметры клиента.
+type Config struct {
+	BaseURL    string        // По умолчанию DefaultBaseURL
+	Timeout    time.Duration // По умолчанию DefaultTimeout
+	HTTPClient *http.Client  // Необязательно, по умолчанию http.DefaultClient
+}
+
+// Client запрашивает статьи слов и реализует suggestion.Provider.
+type Client struct {
+	baseURL *url.URL
+	timeout time.Duration
+	client  *http.Client
+}
+
+var _ suggestion.Provider = (*Client)(nil)
+
+// NewClient создаёт клиент Free Dictionary API.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	baseURL, err := url.Parse(strings.TrimRight(cfg.BaseURL, "/"))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("freedict: invalid base url %q", cfg.BaseURL)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL: baseURL,
+		timeout: cfg.Timeout,
+		client:  client,
+	}, nil
+}
+
+// Slug возвращает идентификатор провайдера.
+func (c *Client) Slug() string { return Slug }
+
+// Name возвращает название провайдера.
+func (c *Client) Name() string { return "Free Dictionary API" }
+
+// Fetch запрашивает статьи слова и превращает их в подсказку.
+// Для неизвестного слова возвращает nil без ошибки.
+func (c *Client) Fetch(ctx context.Context, text string) (*suggestion.Result, error) {
+	word := strings.TrimSpace(text)
+	if word == "" {
+		return nil, nil
+	}
+
+	entries, err := c.Lookup(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return c.toResult(entries), nil
+}
+
+// Lookup запрашивает статьи слова. Для неизвестного слова (404) возвращает nil.
+func (c *Client) Lookup(ctx context.Context, word string) ([]Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	endpoint := c.baseURL.String() + "/" + url.PathEscape(word)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("freedict: build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("freedict: request %q: %w", word, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("freedict: read response: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("freedict: response exceeds %d bytes", maxResponseSize)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode/100 != 2:
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(body, apiErr) // Тело ошибки необязательно
+		apiErr.StatusCode = resp.StatusCode
+		return nil, apiErr
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("freedict: decode response: %w", err)
+	}
+	return entries, nil
+}
+
+// ============================================================================
+// MAPPING
+// ============================================================================
+
+// toResult превращает статьи в подсказку: каждое определение — смысл
+// с частью речи и примером, записи произношения — без повторов.
+func (c *Client) toResult(entries []Entry) *suggestion.Result {
+	result := &suggestion.Result{
+		SourceSlug:     Slug,
+		SourceName:     c.Name(),
+		Senses:         []dictionary.SenseInput{},
+		Images:         []dictionary.ImageInput{},
+		Pronunciations: []dictionary.PronunciationInput{},
+	}
+
+	seenAudio := make(map[string]bool)
+	for _, e := range entries {
+		for _, m := range e.Meanings {
+			pos := mapPartOfSpeech(m.PartOfSpeech)
+			for _, d := range m.Definitions {
+				definition := strings.TrimSpace(d.Definition)
+				if definition == "" {
+					continue
+				}
+				sense := dictionary.SenseInput{
+					Definition:   &definition,
+					PartOfSpeech: pos,
+					SourceSlug:   Slug,
+				}
+				if example := strings.TrimSpace(d.Example); example != "" {
+					sense.Examples = []dictionary.ExampleInput{{Sentence: example, SourceSlug: Slug}}
+				}
+				result.Senses = append(result.Senses, sense)
+			}
+		}
+
+		for _, p := range e.Phonetics {
+			audio := strings.TrimSpace(p.Audio)
+			if audio == "" || seenAudio[audio] {
+				continue
+			}
+			seenAudio[audio] = true
+			if strings.HasPrefix(audio, "//") {
+				audio = "https:" + audio
+			}
+
+			pron := dictionary.PronunciationInput{AudioURL: audio, SourceSlug: Slug}
+			transcription := strings.TrimSpace(p.Text)
+			if transcription == "" {
+				transcription = strings.TrimSpace(e.Phonetic)
+			}
+			if transcription != "" {
+				pron.Transcription = &transcription
+			}
+			if region := audioRegion(audio); region != "" {
+				pron.Region = &region
+			}
+			result.Pronunciations = append(result.Pronunciations, pron)
+		}
+	}
+	return result
+}
+
+// partsOfSpeech — части речи API (Викисловарь) и их соответствие в модели.
+var partsOfSpeech = map[string]model.PartOfSpeech{
+	"noun":         model.PosNoun,
+	"proper noun":  model.PosNoun,
+	"verb":         model.PosVerb,
+	"adjective":    model.PosAdjective,
+	"adverb":       model.PosAdverb,
+	"pronoun":      model.PosPronoun,
+	"preposition":  model.PosPreposition,
+	"conjunction":  model.PosConjunction,
+	"interjection": model.PosInterjection,
+	"exclamation":  model.PosInterjection,
+	"phrase":       model.PosPhrase,
+	"idiom":        model.PosIdiom,
+}
+
+// mapPartOfSpeech возвращает часть речи модели; неизвестные — OTHER,
+// пустая — nil.
+func mapPartOfSpeech(s string) *model.PartOfSpeech {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return nil
+	}
+	pos, ok := partsOfSpeech[s]
+	if !ok {
+		pos = model.PosOther
+	}
+	return &pos
+}
+
+// audioRegion определяет вариант произношения по имени файла записи:
+// hello-us.mp3 -> US, hello-uk.mp3 -> UK.
+func audioRegion(audioURL string) string {
+	name := path.Base(audioURL)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return ""
+	}
+	switch region := strings.ToUpper(name[i+1:]); region {
+	case "US", "UK", "AU", "CA", "NZ", "IE", "SCO", "IN":
+		return region
+	default:
+		return ""
+	}
+}
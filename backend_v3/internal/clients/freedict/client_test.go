@@ -0,0 +1,208 @@
+package freedict
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// newTestClient запускает заглушку API с обработчиком handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL + "/api/v2/entries/en/", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestClient_Fetch(t *testing.T) {
+	body, err := os.ReadFile("testdata/hello.json")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var gotPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+
+	result, err := c.Fetch(context.Background(), " hello ")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if gotPath != "/api/v2/entries/en/hello" {
+		t.Errorf("request path = %q", gotPath)
+	}
+	if result.SourceSlug != Slug || result.SourceName != "Free Dictionary API" {
+		t.Errorf("source = %q, %q", result.SourceSlug, result.SourceName)
+	}
+
+	// Пустое определение пропущено
+	wantSenses := []struct {
+		definition string
+		pos        model.PartOfSpeech
+		example    string
+	}{
+		{`"Hello!" or an equivalent greeting.`, model.PosNoun, ""},
+		{"Used as a greeting or to begin a phone conversation.", model.PosInterjection, "hello there, Katie!"},
+		{`To greet with "hello".`, model.PosVerb, ""},
+		{"A made-up sense for mapping tests.", model.PosOther, ""},
+	}
+	if len(result.Senses) != len(wantSenses) {
+		t.Fatalf("len(Senses) = %d, want %d", len(result.Senses), len(wantSenses))
+	}
+	for i, want := range wantSenses {
+		got := result.Senses[i]
+		if *got.Definition != want.definition || *got.PartOfSpeech != want.pos || got.SourceSlug != Slug {
+			t.Errorf("Senses[%d] = %q %s %q", i, *got.Definition, *got.PartOfSpeech, got.SourceSlug)
+		}
+		switch {
+		case want.example == "" && len(got.Examples) != 0:
+			t.Errorf("Senses[%d].Examples = %+v, want none", i, got.Examples)
+		case want.example != "" && (len(got.Examples) != 1 || got.Examples[0].Sentence != want.example):
+			t.Errorf("Senses[%d].Examples = %+v, want %q", i, got.Examples, want.example)
+		}
+	}
+
+	// Записи без повторов, без записи — пропущены, ссылка без схемы дополнена
+	if len(result.Pronunciations) != 2 {
+		t.Fatalf("Pronunciations = %+v, want 2", result.Pronunciations)
+	}
+	gb, us := result.Pronunciations[0], result.Pronunciations[1]
+	if gb.AudioURL != "https://ssl.gstatic.com/dictionary/static/sounds/20200429/hello--_gb_1.mp3" ||
+		*gb.Transcription != "həˈləʊ" || gb.Region != nil {
+		t.Errorf("Pronunciations[0] = %+v", gb)
+	}
+	if us.AudioURL != "https://api.dictionaryapi.dev/media/pronunciations/en/hello-us.mp3" ||
+		*us.Transcription != "/həˈloʊ/" || *us.Region != "US" {
+		t.Errorf("Pronunciations[1] = %+v", us)
+	}
+}
+
+func TestClient_FetchEscapesWord(t *testing.T) {
+	var gotPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.Write([]byte(`[]`))
+	})
+
+	result, err := c.Fetch(context.Background(), "ice cream/x?")
+	if err != nil || result != nil {
+		t.Fatalf("Fetch() = %+v, %v; want nil, nil", result, err)
+	}
+	if gotPath != "/api/v2/entries/en/ice%20cream%2Fx%3F" {
+		t.Errorf("request path = %q", gotPath)
+	}
+}
+
+func TestClient_FetchNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"title":"No Definitions Found","message":"Sorry pal, we couldn't find definitions.","resolution":""}`))
+	})
+
+	result, err := c.Fetch(context.Background(), "qwertyuiop")
+	if err != nil || result != nil {
+		t.Errorf("Fetch() = %+v, %v; want nil, nil", result, err)
+	}
+}
+
+func TestClient_FetchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"title":"API Rate Limit Exceeded","message":"Slow down."}`))
+			},
+			check: func(t *testing.T, err error) {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Title != "API Rate Limit Exceeded" {
+					t.Errorf("error = %v, want APIError 429", err)
+				}
+			},
+		},
+		{
+			name: "server error without body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte(`<html>Bad Gateway</html>`))
+			},
+			check: func(t *testing.T, err error) {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+					t.Errorf("error = %v, want APIError 502", err)
+				}
+			},
+		},
+		{
+			name: "invalid json",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"word":`))
+			},
+			check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "decode response") {
+					t.Errorf("error = %v, want decode error", err)
+				}
+			},
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(3 * time.Second):
+				}
+			},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("error = %v, want deadline exceeded", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.handler)
+			c.timeout = 100 * time.Millisecond
+
+			result, err := c.Fetch(context.Background(), "hello")
+			if result != nil {
+				t.Errorf("Fetch() result = %+v, want nil", result)
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient(Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if c.baseURL.String() != DefaultBaseURL || c.timeout != DefaultTimeout {
+		t.Errorf("defaults = %s, %v", c.baseURL, c.timeout)
+	}
+
+	if _, err := NewClient(Config{BaseURL: "not a url"}); err == nil {
+		t.Error("NewClient() with invalid base url: want error")
+	}
+}
@@ -1 +1,58 @@
 package freedict
+
+import "fmt"
+
+// ============================================================================
+// API RESPONSE
+// ============================================================================
+
+// Entry — статья ответа GET /entries/en/{word}. На одно слово API
+// возвращает массив статей (обычно по статье на этимологию).
+type Entry struct {
+	Word       string     `json:"word"`
+	Phonetic   string     `json:"phonetic"`
+	Phonetics  []Phonetic `json:"phonetics"`
+	Meanings   []Meaning  `json:"meanings"`
+	SourceURLs []string   `json:"sourceUrls"`
+}
+
+// Phonetic — транскрипция и, если есть, ссылка на запись произношения.
+type Phonetic struct {
+	Text      string `json:"text"`
+	Audio     string `json:"audio"` // Может быть пустым
+	SourceURL string `json:"sourceUrl"`
+}
+
+// Meaning — значения слова одной части речи.
+type Meaning struct {
+	PartOfSpeech string       `json:"partOfSpeech"`
+	Definitions  []Definition `json:"definitions"`
+	Synonyms     []string     `json:"synonyms"`
+	Antonyms     []string     `json:"antonyms"`
+}
+
+// Definition — одно определение с необязательным примером.
+type Definition struct {
+	Definition string   `json:"definition"`
+	Example    string   `json:"example"`
+	Synonyms   []string `json:"synonyms"`
+	Antonyms   []string `json:"antonyms"`
+}
+
+// ============================================================================
+// ERRORS
+// ============================================================================
+
+// APIError — ответ API с кодом, отличным от 2xx (кроме 404 «слово не найдено»).
+type APIError struct {
+	StatusCode int
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Title == "" && e.Message == "" {
+		return fmt.Sprintf("freedict: unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("freedict: status %d: %s %s", e.StatusCode, e.Title, e.Message)
+}
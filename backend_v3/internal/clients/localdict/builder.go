@@ -0,0 +1,225 @@
+package localdict
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+// ============================================================================
+// RESULT BUILDER
+// ============================================================================
+
+// builder собирает подсказку из статей словаря. Части речи, транскрипции,
+// значения и примеры добавляются по порядку статьи: пример относится
+// к последнему значению, часть речи — ко всем следующим значениям.
+type builder struct {
+	slug        string
+	headword    string
+	translation string // Язык переводов; пусто — статьи толковые
+	result      *suggestion.Result
+	pos         *model.PartOfSpeech
+	seenIPA     map[string]bool
+}
+
+func newBuilder(slug, name, headword, translation string) *builder {
+	return &builder{
+		slug:        slug,
+		headword:    headword,
+		translation: translation,
+		result: &suggestion.Result{
+			SourceSlug:     slug,
+			SourceName:     name,
+			Senses:         []dictionary.SenseInput{},
+			Images:         []dictionary.ImageInput{},
+			Pronunciations: []dictionary.PronunciationInput{},
+		},
+		seenIPA: make(map[string]bool),
+	}
+}
+
+// build возвращает подсказку или nil, если в статьях ничего не нашлось.
+func (b *builder) build() *suggestion.Result {
+	if len(b.result.Senses) == 0 && len(b.result.Pronunciations) == 0 {
+		return nil
+	}
+	return b.result
+}
+
+// startArticle сбрасывает часть речи перед следующей статьёй.
+func (b *builder) startArticle() {
+	b.pos = nil
+}
+
+// transcription добавляет транскрипцию без записи произношения.
+func (b *builder) transcription(text string) {
+	ipa := strings.Trim(strings.TrimSpace(text), "[]/")
+	if ipa == "" || b.seenIPA[ipa] {
+		return
+	}
+	b.seenIPA[ipa] = true
+	b.result.Pronunciations = append(b.result.Pronunciations, dictionary.PronunciationInput{
+		Transcription: &ipa,
+		SourceSlug:    b.slug,
+	})
+}
+
+// partOfSpeech задаёт часть речи следующих значений по помете словаря.
+// Возвращает false, если помета — не часть речи.
+func (b *builder) partOfSpeech(label string) bool {
+	pos, ok := partsOfSpeech[posKey(label)]
+	if ok {
+		b.pos = &pos
+	}
+	return ok
+}
+
+// meaning добавляет значение: в двуязычном словаре текст делится на
+// переводы по запятым и точкам с запятой, в толковом — это определение.
+func (b *builder) meaning(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(b.result.Senses) == MaxSenses {
+		return
+	}
+
+	sense := dictionary.SenseInput{PartOfSpeech: b.pos, SourceSlug: b.slug}
+	if b.translation == "" {
+		sense.Definition = &text
+	} else {
+		for _, t := range splitTranslations(text) {
+			sense.Translations = append(sense.Translations, dictionary.TranslationInput{
+				Text:       t,
+				Language:   b.translation,
+				SourceSlug: b.slug,
+			})
+		}
+		if len(sense.Translations) == 0 {
+			return
+		}
+	}
+	b.result.Senses = append(b.result.Senses, sense)
+}
+
+// example добавляет пример к последнему значению. В двуязычном словаре
+// перевод примера отделён тире: «a hunting dog — охотничья собака».
+func (b *builder) example(text string) {
+	text = strings.TrimSpace(text)
+	n := len(b.result.Senses)
+	if text == "" || n == 0 || len(b.result.Senses[n-1].Examples) == MaxExamples {
+		return
+	}
+
+	ex := dictionary.ExampleInput{Sentence: text, SourceSlug: b.slug}
+	if b.translation != "" {
+		for _, dash := range []string{" — ", " – "} {
+			if sentence, translation, ok := strings.Cut(text, dash); ok {
+				sentence, translation = strings.TrimSpace(sentence), strings.TrimSpace(translation)
+				if sentence != "" && translation != "" {
+					ex.Sentence, ex.Translation = sentence, &translation
+				}
+				break
+			}
+		}
+	}
+	b.result.Senses[n-1].Examples = append(b.result.Senses[n-1].Examples, ex)
+}
+
+// text разбирает статью простым текстом построчно. В строке убираются
+// нумерация (I., 1., 1), а)), транскрипция в начале ([kæt], /kæt/) и помета
+// части речи (n., _v., сущ.). Остаток — значение; строка с самим словом
+// пропускается.
+func (b *builder) text(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for {
+			rest := numberingPattern.ReplaceAllString(line, "")
+			if m := transcriptionPattern.FindStringSubmatch(rest); m != nil {
+				b.transcription(m[1] + m[2])
+				rest = rest[len(m[0]):]
+			}
+			rest = b.leadingPartOfSpeech(rest)
+			if rest == line {
+				break
+			}
+			line = rest
+		}
+		if line == "" || strings.EqualFold(line, b.headword) {
+			continue
+		}
+		b.meaning(line)
+	}
+}
+
+// leadingPartOfSpeech убирает из начала строки помету части речи: отдельное
+// слово с точкой (n.), с подчёркиванием (_v) или вся строка (noun).
+func (b *builder) leadingPartOfSpeech(line string) string {
+	token, rest, _ := strings.Cut(line, " ")
+	if !strings.HasSuffix(token, ".") && !strings.HasPrefix(token, "_") && rest != "" {
+		return line
+	}
+	if !b.partOfSpeech(token) {
+		return line
+	}
+	return strings.TrimSpace(rest)
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+var (
+	// numberingPattern — нумерация значения в начале строки.
+	numberingPattern = regexp.MustCompile(`^(?:[IVX]+\.|\d+[.)]|[a-zа-я]\))\s*`)
+
+	// transcriptionPattern — транскрипция в начале строки.
+	transcriptionPattern = regexp.MustCompile(`^(?:\[([^\]]+)\]|/([^/\s][^/]*)/)\s*`)
+)
+
+// partsOfSpeech — пометы частей речи английских и русских словарей.
+var partsOfSpeech = map[string]model.PartOfSpeech{
+	"n": model.PosNoun, "noun": model.PosNoun, "сущ": model.PosNoun,
+	"v": model.PosVerb, "vt": model.PosVerb, "vi": model.PosVerb, "verb": model.PosVerb, "гл": model.PosVerb,
+	"a": model.PosAdjective, "adj": model.PosAdjective, "adjective": model.PosAdjective, "прил": model.PosAdjective,
+	"adv": model.PosAdverb, "adverb": model.PosAdverb, "нареч": model.PosAdverb,
+	"pron": model.PosPronoun, "pronoun": model.PosPronoun, "мест": model.PosPronoun,
+	"prep": model.PosPreposition, "preposition": model.PosPreposition, "предл": model.PosPreposition,
+	"cj": model.PosConjunction, "conj": model.PosConjunction, "conjunction": model.PosConjunction, "союз": model.PosConjunction,
+	"int": model.PosInterjection, "interj": model.PosInterjection, "interjection": model.PosInterjection, "межд": model.PosInterjection,
+	"phr": model.PosPhrase, "phrase": model.PosPhrase,
+	"idiom": model.PosIdiom, "идиом": model.PosIdiom,
+}
+
+// posKey приводит помету к ключу partsOfSpeech: «_n.» → «n».
+func posKey(label string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(label), "_."))
+}
+
+// splitTranslations делит текст на переводы по запятым и точкам с запятой
+// вне скобок: «красить, окрашивать (тж. перен.)».
+func splitTranslations(text string) []string {
+	var result []string
+	depth, start := 0, 0
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	for i, r := range text {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth = max(depth-1, 0)
+		case ',', ';':
+			if depth == 0 {
+				add(text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(text[start:])
+	return result
+}
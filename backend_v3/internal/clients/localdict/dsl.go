@@ -0,0 +1,107 @@
+package localdict
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/heartmarshall/my-english/pkg/dsl"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// ============================================================================
+// DSL
+// ============================================================================
+
+// dslSource — словарь ABBYY Lingvo DSL.
+type dslSource struct {
+	dict *dsl.Dictionary
+}
+
+// openDSL загружает словарь. Язык статей берётся из #CONTENTS_LANGUAGE;
+// если он неизвестен — из cfg.Language. Словарь с одинаковыми языками
+// заголовков и статей — толковый.
+func openDSL(cfg Config) (*Provider, error) {
+	dict, err := dsl.Open(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	index := dsl.LanguageCode(dict.IndexLanguage)
+	if index == "" {
+		index = textnorm.DefaultEntryLanguage
+	}
+	contents := dsl.LanguageCode(dict.ContentsLanguage)
+	if contents == "" {
+		contents = cfg.Language
+	}
+	translation := contents
+	if contents == index {
+		translation = ""
+	}
+
+	name := dict.Name
+	if name == "" {
+		name = filepath.Base(cfg.Path)
+	}
+	return &Provider{
+		slug:        SlugFor(cfg.Path),
+		name:        name,
+		translation: translation,
+		source:      &dslSource{dict: dict},
+	}, nil
+}
+
+func (s *dslSource) close() error { return nil }
+
+// fetch разбирает карточки построчно: [t] — транскрипция, [p] — пометы
+// (часть речи задаёт значения ниже), [trn] — значение, [ex] — примеры,
+// [com] — комментарии (пропускаются). Если в карточке нет зон [trn],
+// строки разбираются как простой текст.
+func (s *dslSource) fetch(word string, b *builder) (bool, error) {
+	cards := s.dict.Lookup(word)
+	if len(cards) == 0 {
+		return false, nil
+	}
+
+	for _, card := range cards {
+		b.startArticle()
+		zoned := hasZone(card.Body, dsl.TagTranslation)
+		for _, line := range card.Body {
+			var zones, examples []string
+			zones, line = dsl.Extract(line, dsl.TagTranscription)
+			for _, z := range zones {
+				b.transcription(dsl.Plain(z))
+			}
+			_, line = dsl.Extract(line, dsl.TagComment)
+			examples, line = dsl.Extract(line, dsl.TagExample)
+			zones, line = dsl.Extract(line, dsl.TagPartOfSpeech)
+			for _, z := range zones {
+				b.partOfSpeech(dsl.Plain(z))
+			}
+
+			if zoned {
+				zones, _ = dsl.Extract(line, dsl.TagTranslation)
+				for _, z := range zones {
+					b.meaning(dsl.Plain(z))
+				}
+			} else {
+				b.text(dsl.Plain(line))
+			}
+
+			for _, ex := range examples {
+				b.example(dsl.Plain(ex))
+			}
+		}
+	}
+	return true, nil
+}
+
+// hasZone сообщает, есть ли в строках зона [tag].
+func hasZone(lines []string, tag string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, "["+tag+"]") {
+			return true
+		}
+	}
+	return false
+}
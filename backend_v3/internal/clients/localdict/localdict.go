@@ -0,0 +1,167 @@
+// Package localdict — провайдеры подсказок по локальным файлам словарей:
+// StarDict (.ifo с .idx и .dict или .dict.dz) и ABBYY Lingvo DSL (.dsl,
+// .dsl.dz). Каждый файл — отдельный провайдер со своим идентификатором,
+// подсказки строятся без сети.
+//
+// Разметка статей разбирается эвристически: транскрипция, пометы частей
+// речи, значения (переводы в двуязычном словаре, определения в толковом)
+// и примеры. Заголовки статей — английские слова.
+package localdict
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+const (
+	// MaxSenses — сколько значений слова возвращается в подсказке.
+	MaxSenses = 50
+
+	// MaxExamples — сколько примеров добавляется к значению.
+	MaxExamples = 3
+)
+
+// Config — параметры словаря.
+type Config struct {
+	// Path — путь к файлу .ifo (StarDict) или .dsl/.dsl.dz (DSL).
+	Path string
+	// Language — язык статей StarDict (ISO 639); пусто — русский.
+	// Если он совпадает с языком слов (английский), словарь толковый.
+	// У DSL язык статей берётся из заголовка файла.
+	Language string
+}
+
+// source — открытый файл словаря.
+type source interface {
+	// fetch добавляет в b статьи слова. Возвращает false, если слова нет.
+	fetch(word string, b *builder) (bool, error)
+	close() error
+}
+
+// Provider строит подсказки по одному файлу словаря и реализует
+// suggestion.Provider.
+type Provider struct {
+	slug        string
+	name        string
+	translation string // Язык переводов; пусто — словарь толковый
+	source      source
+}
+
+var (
+	_ suggestion.Provider        = (*Provider)(nil)
+	_ suggestion.OfflineProvider = (*Provider)(nil)
+)
+
+// Open открывает словарь. Формат определяется по расширению файла.
+func Open(cfg Config) (*Provider, error) {
+	if cfg.Language == "" {
+		cfg.Language = textnorm.DefaultTranslationLanguage
+	}
+	if !textnorm.IsValidLanguage(cfg.Language) {
+		return nil, fmt.Errorf("localdict: invalid language %q", cfg.Language)
+	}
+
+	var (
+		p   *Provider
+		err error
+	)
+	switch format(cfg.Path) {
+	case formatStarDict:
+		p, err = openStarDict(cfg)
+	case formatDSL:
+		p, err = openDSL(cfg)
+	default:
+		return nil, fmt.Errorf("localdict: unsupported dictionary file %q (expected .ifo, .dsl or .dsl.dz)", cfg.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("localdict: open %s: %w", cfg.Path, err)
+	}
+	return p, nil
+}
+
+// Slug возвращает идентификатор провайдера.
+func (p *Provider) Slug() string { return p.slug }
+
+// Name возвращает название словаря.
+func (p *Provider) Name() string { return p.name }
+
+// Offline сообщает, что ответы читаются локально и не кэшируются.
+func (p *Provider) Offline() bool { return true }
+
+// Close закрывает файлы словаря.
+func (p *Provider) Close() error { return p.source.close() }
+
+// Fetch ищет статьи слова и превращает их в подсказку.
+// Для слова, которого нет в словаре, возвращает nil без ошибки.
+func (p *Provider) Fetch(ctx context.Context, text string) (*suggestion.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	word := strings.TrimSpace(text)
+	if word == "" {
+		return nil, nil
+	}
+
+	b := newBuilder(p.slug, p.name, word, p.translation)
+	found, err := p.source.fetch(word, b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.slug, err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return b.build(), nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+const (
+	formatStarDict = "stardict"
+	formatDSL      = "dsl"
+)
+
+// format возвращает формат словаря по расширению файла.
+func format(path string) string {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".ifo"):
+		return formatStarDict
+	case strings.HasSuffix(lower, ".dsl"), strings.HasSuffix(lower, ".dsl.dz"):
+		return formatDSL
+	}
+	return ""
+}
+
+// slugUnsafe — символы, которые заменяются в идентификаторе дефисом.
+var slugUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SlugFor возвращает идентификатор провайдера для файла: формат и имя файла
+// без расширения, например "stardict-mueller" для mueller.ifo.
+func SlugFor(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	for _, ext := range []string{".dz", ".dsl", ".ifo"} {
+		base = strings.TrimSuffix(base, ext)
+	}
+	name := strings.Trim(slugUnsafe.ReplaceAllString(base, "-"), "-")
+	if name == "" {
+		name = "dictionary"
+	}
+	return format(path) + "-" + name
+}
+
+// translationLanguage возвращает язык переводов для языка статей
+// или пустую строку, если словарь толковый.
+func translationLanguage(contents string) string {
+	if contents == textnorm.DefaultEntryLanguage {
+		return ""
+	}
+	return contents
+}
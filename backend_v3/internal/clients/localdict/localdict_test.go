@@ -0,0 +1,232 @@
+package localdict
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+// sense — значение подсказки в удобном для сравнения виде.
+type sense struct {
+	pos          model.PartOfSpeech
+	definition   string
+	translations string // Через «|»
+	examples     string // «предложение=перевод» через «|»
+}
+
+func summarize(r *suggestion.Result) (ipa []string, senses []sense) {
+	for _, p := range r.Pronunciations {
+		if p.AudioURL != "" {
+			ipa = append(ipa, "audio:"+p.AudioURL)
+		}
+		ipa = append(ipa, *p.Transcription)
+	}
+	for _, s := range r.Senses {
+		var got sense
+		if s.PartOfSpeech != nil {
+			got.pos = *s.PartOfSpeech
+		}
+		if s.Definition != nil {
+			got.definition = *s.Definition
+		}
+		var parts []string
+		for _, t := range s.Translations {
+			parts = append(parts, t.Text+"@"+t.Language)
+		}
+		got.translations = strings.Join(parts, "|")
+		parts = parts[:0]
+		for _, ex := range s.Examples {
+			e := ex.Sentence
+			if ex.Translation != nil {
+				e += "=" + *ex.Translation
+			}
+			parts = append(parts, e)
+		}
+		got.examples = strings.Join(parts, "|")
+		senses = append(senses, got)
+	}
+	return ipa, senses
+}
+
+func openTest(t *testing.T, cfg Config) *Provider {
+	t.Helper()
+	p, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open(%s) error = %v", cfg.Path, err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func fetch(t *testing.T, p *Provider, word string) *suggestion.Result {
+	t.Helper()
+	result, err := p.Fetch(context.Background(), word)
+	if err != nil {
+		t.Fatalf("Fetch(%q) error = %v", word, err)
+	}
+	if result == nil {
+		t.Fatalf("Fetch(%q) = nil", word)
+	}
+	if result.SourceSlug != p.Slug() || result.SourceName != p.Name() {
+		t.Errorf("source = %q, %q", result.SourceSlug, result.SourceName)
+	}
+	return result
+}
+
+func TestProvider_StarDictXDXF(t *testing.T) {
+	p := openTest(t, Config{Path: "testdata/xdxf.ifo"})
+	if p.Slug() != "stardict-xdxf" || p.Name() != "Test XDXF En-Ru" {
+		t.Errorf("provider = %q, %q", p.Slug(), p.Name())
+	}
+
+	ipa, senses := summarize(fetch(t, p, "Dog"))
+	if !reflect.DeepEqual(ipa, []string{"dɒɡ"}) {
+		t.Errorf("dog transcriptions = %q", ipa)
+	}
+	want := []sense{
+		{pos: model.PosNoun, translations: "собака@ru|пёс@ru", examples: "a hunting dog=охотничья собака"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("dog senses = %+v, want %+v", senses, want)
+	}
+
+	_, senses = summarize(fetch(t, p, "run"))
+	want = []sense{
+		{pos: model.PosVerb, translations: "бежать@ru|бегать@ru"},
+		{pos: model.PosVerb, translations: "управлять@ru", examples: "run a business=управлять делом"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("run senses = %+v, want %+v", senses, want)
+	}
+}
+
+func TestProvider_StarDictText(t *testing.T) {
+	// Толковый словарь: статьи на языке слов
+	p := openTest(t, Config{Path: "testdata/plain.ifo", Language: "en"})
+	if p.Slug() != "stardict-plain" || p.Name() != "Test Plain" {
+		t.Errorf("provider = %q, %q", p.Slug(), p.Name())
+	}
+
+	ipa, senses := summarize(fetch(t, p, "cat"))
+	if !reflect.DeepEqual(ipa, []string{"kæt"}) {
+		t.Errorf("cat transcriptions = %q", ipa)
+	}
+	// Вторая статья (HTML): строка с самим словом пропущена, часть речи своя
+	want := []sense{
+		{pos: model.PosNoun, definition: "a small domesticated carnivorous mammal"},
+		{pos: model.PosNoun, definition: "any member of the cat family"},
+		{pos: model.PosVerb, definition: "to hoist an anchor & secure it"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("cat senses = %+v, want %+v", senses, want)
+	}
+
+	ipa, senses = summarize(fetch(t, p, "purr"))
+	want = []sense{{pos: model.PosVerb, definition: "to make a low vibrating sound"}}
+	if !reflect.DeepEqual(ipa, []string{"pɜː"}) || !reflect.DeepEqual(senses, want) {
+		t.Errorf("purr = %q, %+v", ipa, senses)
+	}
+}
+
+func TestProvider_DSL(t *testing.T) {
+	// Язык статей из заголовка важнее настройки
+	p := openTest(t, Config{Path: "testdata/test.dsl", Language: "en"})
+	if p.Slug() != "dsl-test" || p.Name() != "Test En-Ru" {
+		t.Errorf("provider = %q, %q", p.Slug(), p.Name())
+	}
+
+	ipa, senses := summarize(fetch(t, p, "color"))
+	if !reflect.DeepEqual(ipa, []string{"ˈkʌlə"}) {
+		t.Errorf("color transcriptions = %q", ipa)
+	}
+	want := []sense{
+		{pos: model.PosNoun, translations: "цвет@ru|оттенок@ru", examples: "bright colours=яркие цвета"},
+		{pos: model.PosVerb, translations: "красить@ru|окрашивать@ru"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("color senses = %+v, want %+v", senses, want)
+	}
+
+	// Текст вне [trn] («см. тж. hound») пропускается
+	_, senses = summarize(fetch(t, p, "dog"))
+	want = []sense{
+		{pos: model.PosNoun, translations: "собака@ru|пёс@ru", examples: "a hunting dog=охотничья собака"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("dog senses = %+v, want %+v", senses, want)
+	}
+}
+
+func TestProvider_FetchNotFound(t *testing.T) {
+	for _, path := range []string{"testdata/xdxf.ifo", "testdata/test.dsl"} {
+		p := openTest(t, Config{Path: path})
+		for _, word := range []string{"unknown", "  "} {
+			result, err := p.Fetch(context.Background(), word)
+			if err != nil || result != nil {
+				t.Errorf("%s: Fetch(%q) = %v, %v; want nil, nil", path, word, result, err)
+			}
+		}
+	}
+}
+
+func TestProvider_FetchCanceled(t *testing.T) {
+	p := openTest(t, Config{Path: "testdata/test.dsl"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Fetch(ctx, "dog"); err == nil {
+		t.Error("Fetch() with canceled context: want error")
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	tests := []Config{
+		{Path: "testdata/words.txt"},
+		{Path: "testdata/missing.ifo"},
+		{Path: "testdata/missing.dsl"},
+		{Path: "testdata/test.dsl", Language: "russian"},
+	}
+	for _, cfg := range tests {
+		if _, err := Open(cfg); err == nil {
+			t.Errorf("Open(%+v): want error", cfg)
+		}
+	}
+}
+
+func TestSlugFor(t *testing.T) {
+	tests := map[string]string{
+		"/dicts/Mueller En-Ru/mueller.ifo": "stardict-mueller",
+		"LingvoUniversal (En-Ru).dsl.dz":   "dsl-lingvouniversal-en-ru",
+		"/dicts/Словарь.dsl":               "dsl-dictionary",
+	}
+	for path, want := range tests {
+		if got := SlugFor(path); got != want {
+			t.Errorf("SlugFor(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestBuilder_Text(t *testing.T) {
+	b := newBuilder("test", "Test", "dog", "ru")
+	b.text("dog\n[dɒɡ] _n.\nI. 1) собака, пёс (охотничья)\nа) кобель\n_v. выслеживать")
+
+	ipa, senses := summarize(b.build())
+	if !reflect.DeepEqual(ipa, []string{"dɒɡ"}) {
+		t.Errorf("transcriptions = %q", ipa)
+	}
+	want := []sense{
+		{pos: model.PosNoun, translations: "собака@ru|пёс (охотничья)@ru"},
+		{pos: model.PosNoun, translations: "кобель@ru"},
+		{pos: model.PosVerb, translations: "выслеживать@ru"},
+	}
+	if !reflect.DeepEqual(senses, want) {
+		t.Errorf("senses = %+v, want %+v", senses, want)
+	}
+
+	if newBuilder("test", "Test", "dog", "").build() != nil {
+		t.Error("empty builder: want nil result")
+	}
+}
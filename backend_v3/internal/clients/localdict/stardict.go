@@ -0,0 +1,153 @@
+package localdict
+
+import (
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/heartmarshall/my-english/pkg/stardict"
+)
+
+// ============================================================================
+// STARDICT
+// ============================================================================
+
+// starDictSource — словарь StarDict.
+type starDictSource struct {
+	dict *stardict.Dictionary
+}
+
+func openStarDict(cfg Config) (*Provider, error) {
+	dict, err := stardict.Open(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := dict.Info.BookName
+	if name == "" {
+		name = filepath.Base(cfg.Path)
+	}
+	return &Provider{
+		slug:        SlugFor(cfg.Path),
+		name:        name,
+		translation: translationLanguage(cfg.Language),
+		source:      &starDictSource{dict: dict},
+	}, nil
+}
+
+func (s *starDictSource) close() error { return s.dict.Close() }
+
+// fetch разбирает части статей: транскрипцию (t), XDXF (x), HTML (h),
+// разметку Pango (g) и простой текст (m). Двоичные части (звук, картинки)
+// пропускаются.
+func (s *starDictSource) fetch(word string, b *builder) (bool, error) {
+	articles, err := s.dict.Lookup(word)
+	if err != nil || len(articles) == 0 {
+		return false, err
+	}
+
+	for _, a := range articles {
+		b.startArticle()
+		for _, part := range a.Parts {
+			text := string(part.Data)
+			switch part.Type {
+			case 't':
+				b.transcription(text)
+			case 'x':
+				addXDXF(b, text)
+			case 'h', 'g':
+				b.text(stripHTML(text))
+			case 'm':
+				b.text(text)
+			}
+		}
+	}
+	return true, nil
+}
+
+// ============================================================================
+// XDXF
+// ============================================================================
+
+// xdxfBreak — теги, которые делят статью XDXF на строки.
+var xdxfBreak = regexp.MustCompile(`<(?:/?def(?:\s[^>]*)?|br\s*/?)>`)
+
+// addXDXF разбирает статью XDXF: <tr> — транскрипция, <abr>/<gr>/<pos> —
+// пометы (часть речи задаёт значения ниже), <dtrn> — переводы одного
+// значения, <ex> — примеры, <co> — комментарии (пропускаются). Строки без
+// <dtrn> разбираются как простой текст.
+func addXDXF(b *builder, text string) {
+	_, text = extractXML(text, "k")
+	transcriptions, text := extractXML(text, "tr")
+	for _, t := range transcriptions {
+		b.transcription(stripHTML(t))
+	}
+	text = xdxfBreak.ReplaceAllString(text, "\n")
+
+	for _, line := range strings.Split(text, "\n") {
+		var examples, labels, translations []string
+		_, line = extractXML(line, "co")
+		examples, line = extractXML(line, "ex")
+		for _, tag := range []string{"abr", "gr", "pos"} {
+			labels, line = extractXML(line, tag)
+			for _, l := range labels {
+				b.partOfSpeech(stripHTML(l))
+			}
+		}
+
+		translations, line = extractXML(line, "dtrn")
+		if len(translations) > 0 {
+			parts := make([]string, 0, len(translations))
+			for _, t := range translations {
+				parts = append(parts, stripHTML(t))
+			}
+			b.meaning(strings.Join(parts, "; "))
+		} else {
+			b.text(stripHTML(line))
+		}
+
+		for _, ex := range examples {
+			b.example(stripHTML(ex))
+		}
+	}
+}
+
+// xmlPatterns — регулярные выражения extractXML по имени тега.
+var xmlPatterns = map[string]*regexp.Regexp{}
+
+func init() {
+	for _, tag := range []string{"k", "tr", "co", "ex", "abr", "gr", "pos", "dtrn"} {
+		xmlPatterns[tag] = regexp.MustCompile(`(?s)<` + tag + `(?:\s[^>]*)?>(.*?)</` + tag + `>`)
+	}
+}
+
+// extractXML вырезает элементы <tag>...</tag> и возвращает их содержимое
+// и текст без них.
+func extractXML(text, tag string) ([]string, string) {
+	re := xmlPatterns[tag]
+	var zones []string
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		zones = append(zones, m[1])
+	}
+	return zones, re.ReplaceAllString(text, "")
+}
+
+// ============================================================================
+// HTML
+// ============================================================================
+
+var (
+	// htmlBreak — теги, после которых начинается новая строка.
+	htmlBreak = regexp.MustCompile(`(?i)<(?:br\s*/?|/?(?:p|div|li|ol|ul|tr|h\d)(?:\s[^>]*)?)>`)
+	// htmlTag — любой тег.
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+)
+
+// stripHTML убирает теги HTML и Pango, сохраняя переносы строк,
+// и раскрывает сущности (&amp; → &).
+func stripHTML(text string) string {
+	text = htmlBreak.ReplaceAllString(text, "\n")
+	text = htmlTag.ReplaceAllString(text, "")
+	return html.UnescapeString(text)
+}
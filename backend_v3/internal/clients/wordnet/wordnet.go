@@ -0,0 +1,241 @@
+// Package wordnet — офлайн-провайдер подсказок по базе Princeton WordNet
+// (каталог dict в формате WNdb). Синсеты слова становятся значениями:
+// глосса даёт определение и примеры, а синонимы синсета, антонимы
+// и гиперонимы предлагаются как связи смысла, которые можно принять
+// в словарь.
+package wordnet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/pkg/wndb"
+)
+
+const (
+	// Slug — идентификатор провайдера.
+	Slug = "wordnet"
+	// Name — название провайдера.
+	Name = "WordNet"
+
+	// MaxSenses — сколько синсетов слова возвращается в подсказке.
+	MaxSenses = 50
+
+	// MaxExamples — сколько примеров из глоссы добавляется к значению.
+	MaxExamples = 3
+)
+
+// partsOfSpeech — соответствие частей речи WordNet нашим.
+var partsOfSpeech = map[wndb.POS]model.PartOfSpeech{
+	wndb.Noun:      model.PosNoun,
+	wndb.Verb:      model.PosVerb,
+	wndb.Adjective: model.PosAdjective,
+	wndb.Satellite: model.PosAdjective,
+	wndb.Adverb:    model.PosAdverb,
+}
+
+// Provider строит подсказки по базе WordNet и реализует suggestion.Provider.
+type Provider struct {
+	db *wndb.Database
+}
+
+var (
+	_ suggestion.Provider        = (*Provider)(nil)
+	_ suggestion.OfflineProvider = (*Provider)(nil)
+)
+
+// Open открывает базу WordNet в каталоге dir (обычно WordNet-3.0/dict).
+func Open(dir string) (*Provider, error) {
+	db, err := wndb.Open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("wordnet: %w", err)
+	}
+	return &Provider{db: db}, nil
+}
+
+// Slug возвращает идентификатор провайдера.
+func (p *Provider) Slug() string { return Slug }
+
+// Name возвращает название провайдера.
+func (p *Provider) Name() string { return Name }
+
+// Offline сообщает, что ответы читаются локально и не кэшируются.
+func (p *Provider) Offline() bool { return true }
+
+// Close закрывает файлы базы.
+func (p *Provider) Close() error { return p.db.Close() }
+
+// Fetch ищет синсеты слова всех частей речи. Для слова, которого нет
+// в WordNet, возвращает nil без ошибки.
+func (p *Provider) Fetch(ctx context.Context, text string) (*suggestion.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	word := strings.TrimSpace(text)
+	if word == "" {
+		return nil, nil
+	}
+
+	b := &builder{db: p.db, synsets: make(map[synsetKey]*wndb.Synset)}
+	for _, pos := range wndb.AllPOS {
+		synsets, err := p.db.Lookup(word, pos)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", Slug, err)
+		}
+		lemmas := p.db.Lemmas(word, pos)
+		for i := range synsets {
+			if len(b.senses) == MaxSenses {
+				break
+			}
+			if err := b.add(&synsets[i], lemmas); err != nil {
+				return nil, fmt.Errorf("%s: %w", Slug, err)
+			}
+		}
+	}
+	if len(b.senses) == 0 {
+		return nil, nil
+	}
+
+	return &suggestion.Result{
+		SourceSlug: Slug,
+		SourceName: Name,
+		Senses:     b.senses,
+		Synonyms:   b.synonyms,
+	}, nil
+}
+
+// ============================================================================
+// BUILDER
+// ============================================================================
+
+type synsetKey struct {
+	pos    wndb.POS
+	offset int64
+}
+
+// builder собирает значения подсказки; синсеты, на которые ссылаются
+// указатели, читаются один раз.
+type builder struct {
+	db       *wndb.Database
+	synsets  map[synsetKey]*wndb.Synset
+	senses   []dictionary.SenseInput
+	synonyms []string
+}
+
+// add добавляет значение синсета s. lemmas — начальные формы искомого
+// слова: они не предлагаются как синонимы и по ним выбираются антонимы.
+func (b *builder) add(s *wndb.Synset, lemmas []string) error {
+	sense := dictionary.SenseInput{SourceSlug: Slug}
+	if definition := s.Definition(); definition != "" {
+		sense.Definition = &definition
+	}
+	if pos, ok := partsOfSpeech[s.POS]; ok {
+		sense.PartOfSpeech = &pos
+	}
+	for _, ex := range s.Examples() {
+		if len(sense.Examples) == MaxExamples {
+			break
+		}
+		sense.Examples = append(sense.Examples, dictionary.ExampleInput{Sentence: ex, SourceSlug: Slug})
+	}
+
+	// Номер искомого слова в синсете: антонимы в WordNet связывают слова
+	wordIndex := 0
+	for _, lemma := range lemmas {
+		if wordIndex = s.WordIndex(lemma); wordIndex != 0 {
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	addRelation := func(typ model.RelationType, w wndb.Word) {
+		key := string(typ) + "|" + strings.ToLower(w.Lemma)
+		if seen[key] || isLemma(w.Lemma, lemmas) {
+			return
+		}
+		seen[key] = true
+		sense.Relations = append(sense.Relations, dictionary.RelationInput{
+			Type:       typ,
+			TargetText: w.Text(),
+			SourceSlug: Slug,
+		})
+	}
+
+	for _, w := range s.Words {
+		addRelation(model.RelationSynonym, w)
+		if !isLemma(w.Lemma, lemmas) {
+			b.addSynonym(w.Text())
+		}
+	}
+
+	for _, ptr := range s.Pointers {
+		var typ model.RelationType
+		switch ptr.Symbol {
+		case wndb.PointerAntonym:
+			if ptr.Source != 0 && ptr.Source != wordIndex {
+				continue
+			}
+			typ = model.RelationAntonym
+		case wndb.PointerHypernym, wndb.PointerInstanceHypernym:
+			typ = model.RelationHypernym
+		default:
+			continue
+		}
+
+		target, err := b.synset(ptr.POS, ptr.Offset)
+		if err != nil {
+			return err
+		}
+		if ptr.Target > 0 && ptr.Target <= len(target.Words) {
+			addRelation(typ, target.Words[ptr.Target-1])
+			continue
+		}
+		for _, w := range target.Words {
+			addRelation(typ, w)
+		}
+	}
+
+	b.senses = append(b.senses, sense)
+	return nil
+}
+
+// synset читает синсет по указателю.
+func (b *builder) synset(pos wndb.POS, offset int64) (*wndb.Synset, error) {
+	if pos == wndb.Satellite {
+		pos = wndb.Adjective
+	}
+	key := synsetKey{pos: pos, offset: offset}
+	if s, ok := b.synsets[key]; ok {
+		return s, nil
+	}
+	s, err := b.db.Synset(pos, offset)
+	if err != nil {
+		return nil, err
+	}
+	b.synsets[key] = s
+	return s, nil
+}
+
+// addSynonym добавляет синоним в общий список подсказки без повторов.
+func (b *builder) addSynonym(text string) {
+	for _, s := range b.synonyms {
+		if strings.EqualFold(s, text) {
+			return
+		}
+	}
+	b.synonyms = append(b.synonyms, text)
+}
+
+// isLemma сообщает, является ли слово синсета одной из форм искомого слова.
+func isLemma(lemma string, lemmas []string) bool {
+	for _, l := range lemmas {
+		if strings.EqualFold(lemma, l) {
+			return true
+		}
+	}
+	return false
+}
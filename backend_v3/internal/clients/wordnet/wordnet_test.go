@@ -0,0 +1,156 @@
+package wordnet
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+// sense — значение подсказки в удобном для сравнения виде.
+type sense struct {
+	pos        model.PartOfSpeech
+	definition string
+	examples   string // Через «|»
+	relations  string // «ТИП:текст» через «|»
+}
+
+func summarize(r *suggestion.Result) []sense {
+	var senses []sense
+	for _, s := range r.Senses {
+		var got sense
+		if s.PartOfSpeech != nil {
+			got.pos = *s.PartOfSpeech
+		}
+		if s.Definition != nil {
+			got.definition = *s.Definition
+		}
+		var parts []string
+		for _, ex := range s.Examples {
+			parts = append(parts, ex.Sentence)
+		}
+		got.examples = strings.Join(parts, "|")
+		parts = parts[:0]
+		for _, rel := range s.Relations {
+			parts = append(parts, string(rel.Type)+":"+rel.TargetText)
+		}
+		got.relations = strings.Join(parts, "|")
+		senses = append(senses, got)
+	}
+	return senses
+}
+
+func openTest(t *testing.T) *Provider {
+	t.Helper()
+	p, err := Open("testdata/dict")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func fetch(t *testing.T, p *Provider, word string) *suggestion.Result {
+	t.Helper()
+	result, err := p.Fetch(context.Background(), word)
+	if err != nil {
+		t.Fatalf("Fetch(%q) error = %v", word, err)
+	}
+	if result == nil {
+		t.Fatalf("Fetch(%q) = nil", word)
+	}
+	if result.SourceSlug != Slug || result.SourceName != Name {
+		t.Errorf("source = %q, %q", result.SourceSlug, result.SourceName)
+	}
+	return result
+}
+
+func TestProvider_Fetch(t *testing.T) {
+	p := openTest(t)
+
+	// Словоформа приводится к лемме; слово синсета не предлагается
+	// как связь с самим собой
+	result := fetch(t, p, "dogs")
+	want := []sense{
+		{
+			pos:        model.PosNoun,
+			definition: "a member of the genus Canis",
+			examples:   "the dog barked all night",
+			relations:  "SYNONYM:domestic dog|SYNONYM:Canis familiaris|HYPERNYM:canine|HYPERNYM:canid",
+		},
+		{
+			pos:        model.PosNoun,
+			definition: "a dull unattractive unpleasant girl or woman",
+			examples:   "she got a reputation as a frump|she's a real dog",
+			relations:  "SYNONYM:frump",
+		},
+		{
+			pos:        model.PosVerb,
+			definition: "go after with the intent to catch",
+			examples:   "The policeman chased the mugger; then he ran|the dog chased the rabbit",
+			relations:  "SYNONYM:chase|SYNONYM:tail",
+		},
+	}
+	if got := summarize(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("senses =\n%+v\nwant\n%+v", got, want)
+	}
+	if want := []string{"domestic dog", "Canis familiaris", "frump", "chase", "tail"}; !reflect.DeepEqual(result.Synonyms, want) {
+		t.Errorf("Synonyms = %q, want %q", result.Synonyms, want)
+	}
+	for _, s := range result.Senses {
+		if s.SourceSlug != Slug {
+			t.Errorf("sense source = %q", s.SourceSlug)
+		}
+		for _, rel := range s.Relations {
+			if rel.SourceSlug != Slug {
+				t.Errorf("relation source = %q", rel.SourceSlug)
+			}
+		}
+	}
+}
+
+func TestProvider_Fetch_Antonyms(t *testing.T) {
+	p := openTest(t)
+
+	// Антоним берётся по номеру слова, указатель «похожее» пропускается
+	result := fetch(t, p, "colder")
+	want := []sense{{
+		pos:        model.PosAdjective,
+		definition: "having a low or inadequate temperature",
+		relations:  "ANTONYM:hot",
+	}}
+	if got := summarize(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("senses = %+v, want %+v", got, want)
+	}
+	if result.Synonyms != nil {
+		t.Errorf("Synonyms = %q, want nil", result.Synonyms)
+	}
+}
+
+func TestProvider_Fetch_NotFound(t *testing.T) {
+	p := openTest(t)
+
+	for _, word := range []string{"cat", "  "} {
+		result, err := p.Fetch(context.Background(), word)
+		if err != nil || result != nil {
+			t.Errorf("Fetch(%q) = %+v, %v; want nil, nil", word, result, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Fetch(ctx, "dog"); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: err = %v", err)
+	}
+}
+
+func TestOpen_MissingDir(t *testing.T) {
+	if _, err := Open(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want ErrNotExist", err)
+	}
+}
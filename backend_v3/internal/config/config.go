@@ -10,10 +10,15 @@ import (
 // Теги `env` используются для чтения переменных окружения.
 // Теги `env-default` задают значения по умолчанию.
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	GraphQL  GraphQLConfig  `yaml:"graphql"`
-	Log      LogConfig      `yaml:"log"`
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	GraphQL    GraphQLConfig    `yaml:"graphql"`
+	Log        LogConfig        `yaml:"log"`
+	Trash      TrashConfig      `yaml:"trash"`
+	Media      MediaConfig      `yaml:"media"`
+	Import     ImportConfig     `yaml:"import"`
+	Backup     BackupConfig     `yaml:"backup"`
+	Suggestion SuggestionConfig `yaml:"suggestion"`
 }
 
 // ServerConfig — конфигурация HTTP сервера.
@@ -56,6 +61,8 @@ type GraphQLConfig struct {
 	EnablePlayground    bool `yaml:"enable_playground" env:"GRAPHQL_PLAYGROUND" env-default:"true"`
 	EnableIntrospection bool `yaml:"enable_introspection" env:"GRAPHQL_INTROSPECTION" env-default:"true"`
 	QueryCacheSize      int  `yaml:"query_cache_size" env:"GRAPHQL_QUERY_CACHE_SIZE" env-default:"1000"`
+	// Максимальный размер multipart-запроса с файлами (колоды Anki бывают большими)
+	MaxUploadSize int64 `yaml:"max_upload_size" env:"GRAPHQL_MAX_UPLOAD_SIZE" env-default:"209715200"` // Байт
 }
 
 // LogConfig — конфигурация логгера.
@@ -63,3 +70,105 @@ type LogConfig struct {
 	Level  string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`   // debug, info, warn, error
 	Format string `yaml:"format" env:"LOG_FORMAT" env-default:"text"` // json, text
 }
+
+// TrashConfig — конфигурация корзины удалённых слов.
+type TrashConfig struct {
+	// Retention — сколько слово хранится в корзине до окончательного удаления.
+	// Нулевое значение отключает фоновую очистку.
+	Retention     time.Duration `yaml:"retention" env:"TRASH_RETENTION" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// MediaConfig — конфигурация хранилища медиафайлов (изображений и аудио).
+type MediaConfig struct {
+	Backend         string        `yaml:"backend" env:"MEDIA_BACKEND" env-default:"local"` // local, s3
+	LocalDir        string        `yaml:"local_dir" env:"MEDIA_LOCAL_DIR" env-default:"./data/media"`
+	MaxSize         int64         `yaml:"max_size" env:"MEDIA_MAX_SIZE" env-default:"10485760"` // Байт
+	DownloadTimeout time.Duration `yaml:"download_timeout" env:"MEDIA_DOWNLOAD_TIMEOUT" env-default:"15s"`
+	S3              S3Config      `yaml:"s3"`
+
+	// Разрешить скачивание по URL с адресов локальной сети. Только для
+	// разработки: иначе через importMedia можно обращаться к внутренним сервисам.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" env:"MEDIA_ALLOW_PRIVATE_NETWORKS" env-default:"false"`
+
+	// Фоновая обработка изображений (миниатюры и вариант для карточки).
+	// Нулевой ProcessInterval отключает обработку.
+	ThumbnailSize    int           `yaml:"thumbnail_size" env:"MEDIA_THUMBNAIL_SIZE" env-default:"256"` // px, большая сторона
+	CardSize         int           `yaml:"card_size" env:"MEDIA_CARD_SIZE" env-default:"1024"`
+	ProcessInterval  time.Duration `yaml:"process_interval" env:"MEDIA_PROCESS_INTERVAL" env-default:"30s"`
+	ProcessBatchSize int           `yaml:"process_batch_size" env:"MEDIA_PROCESS_BATCH_SIZE" env-default:"20"`
+}
+
+// ImportConfig — конфигурация фонового импорта слов из таблиц.
+type ImportConfig struct {
+	// Как часто проверять новые задания импорта. Нулевое значение
+	// отключает фоновый импорт.
+	ProcessInterval time.Duration `yaml:"process_interval" env:"IMPORT_PROCESS_INTERVAL" env-default:"5s"`
+	ChunkSize       int           `yaml:"chunk_size" env:"IMPORT_CHUNK_SIZE" env-default:"100"` // Строк за одну пачку
+}
+
+// BackupConfig — конфигурация HTTP-эндпоинтов резервного копирования.
+type BackupConfig struct {
+	// Token — токен доступа (заголовок Authorization: Bearer <token>).
+	// Пустое значение отключает эндпоинты; CLI работает без него.
+	Token          string `yaml:"token" env:"BACKUP_TOKEN"`
+	MaxRestoreSize int64  `yaml:"max_restore_size" env:"BACKUP_MAX_RESTORE_SIZE" env-default:"1073741824"` // Байт
+}
+
+// SuggestionConfig — конфигурация провайдеров подсказок.
+type SuggestionConfig struct {
+	FreeDict          FreeDictConfig        `yaml:"freedict"`
+	Wiktionary        WiktionaryConfig      `yaml:"wiktionary"`
+	LocalDictionaries LocalDictConfig       `yaml:"local_dictionaries"`
+	WordNet           WordNetConfig         `yaml:"wordnet"`
+	Cache             SuggestionCacheConfig `yaml:"cache"`
+}
+
+// FreeDictConfig — конфигурация провайдера Free Dictionary API.
+type FreeDictConfig struct {
+	Enabled bool          `yaml:"enabled" env:"FREEDICT_ENABLED" env-default:"true"`
+	BaseURL string        `yaml:"base_url" env:"FREEDICT_BASE_URL" env-default:"https://api.dictionaryapi.dev/api/v2/entries/en"`
+	Timeout time.Duration `yaml:"timeout" env:"FREEDICT_TIMEOUT" env-default:"5s"`
+}
+
+// WiktionaryConfig — конфигурация офлайн-провайдера по дампу Викисловаря.
+// Дамп загружается командой wiktionaryimport.
+type WiktionaryConfig struct {
+	Enabled bool `yaml:"enabled" env:"WIKTIONARY_ENABLED" env-default:"true"`
+}
+
+// LocalDictConfig — локальные файлы словарей StarDict (.ifo) и DSL
+// (.dsl, .dsl.dz). Каждый файл — отдельный провайдер подсказок.
+type LocalDictConfig struct {
+	Paths []string `yaml:"paths" env:"LOCAL_DICTIONARIES" env-separator:","`
+	// Язык статей StarDict; en — толковые словари. У DSL берётся из файла.
+	Language string `yaml:"language" env:"LOCAL_DICTIONARIES_LANGUAGE" env-default:"ru"`
+}
+
+// WordNetConfig — офлайн-провайдер по базе WordNet. Dir — каталог dict
+// дистрибутива WordNet 3.x; пустое значение отключает провайдер.
+type WordNetConfig struct {
+	Dir string `yaml:"dir" env:"WORDNET_DIR"`
+}
+
+// SuggestionCacheConfig — кэш ответов провайдеров подсказок в PostgreSQL
+// с LRU в памяти. Офлайн-провайдеры (Викисловарь, WordNet, локальные
+// словари) не кэшируются.
+type SuggestionCacheConfig struct {
+	Enabled     bool          `yaml:"enabled" env:"SUGGESTION_CACHE_ENABLED" env-default:"true"`
+	TTL         time.Duration `yaml:"ttl" env:"SUGGESTION_CACHE_TTL" env-default:"168h"`
+	NegativeTTL time.Duration `yaml:"negative_ttl" env:"SUGGESTION_CACHE_NEGATIVE_TTL" env-default:"1h"` // «Слово не найдено»; 0 — не кэшировать
+	// Срок по провайдеру вместо ttl, например freedict:720h; 0 — не кэшировать провайдер
+	ProviderTTL map[string]time.Duration `yaml:"provider_ttl" env:"SUGGESTION_CACHE_PROVIDER_TTL" env-separator:","`
+	MemorySize  int                      `yaml:"memory_size" env:"SUGGESTION_CACHE_MEMORY_SIZE" env-default:"1000"` // Записей в памяти; 0 — только БД
+}
+
+// S3Config — параметры S3-совместимого хранилища (AWS S3, MinIO, R2).
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint" env:"MEDIA_S3_ENDPOINT"`
+	Region          string `yaml:"region" env:"MEDIA_S3_REGION" env-default:"us-east-1"`
+	Bucket          string `yaml:"bucket" env:"MEDIA_S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"MEDIA_S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"MEDIA_S3_SECRET_ACCESS_KEY"`
+	Prefix          string `yaml:"prefix" env:"MEDIA_S3_PREFIX"`
+}
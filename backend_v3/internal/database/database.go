@@ -57,11 +57,36 @@ type TxFunc func(ctx context.Context, q Querier) error
 // При ошибке или панике — автоматический rollback.
 // При успехе — commit.
 func WithTx(ctx context.Context, pool *pgxpool.Pool, fn TxFunc) error {
-	tx, err := pool.Begin(ctx)
+	return WithTxOptions(ctx, pool, pgx.TxOptions{}, fn)
+}
+
+// WithTxOptions выполняет функцию в транзакции с заданными уровнем
+// изоляции и режимом доступа.
+func WithTxOptions(ctx context.Context, pool *pgxpool.Pool, opts pgx.TxOptions, fn TxFunc) error {
+	tx, err := pool.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return runTx(ctx, tx, fn)
+}
+
+// withSavepoint выполняет функцию во вложенной транзакции (SAVEPOINT)
+// внутри открытой транзакции q: при ошибке откатываются только её изменения.
+// Если q не транзакция, функция выполняется на нём напрямую.
+func withSavepoint(ctx context.Context, q Querier, fn TxFunc) error {
+	outer, ok := q.(pgx.Tx)
+	if !ok {
+		return fn(ctx, q)
+	}
+	tx, err := outer.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	return runTx(ctx, tx, fn)
+}
 
+// runTx выполняет функцию в начатой транзакции и завершает её.
+func runTx(ctx context.Context, tx pgx.Tx, fn TxFunc) error {
 	defer func() {
 		if p := recover(); p != nil {
 			_ = tx.Rollback(ctx)
@@ -84,6 +109,7 @@ func WithTx(ctx context.Context, pool *pgxpool.Pool, fn TxFunc) error {
 // TxManager управляет транзакциями и предоставляет доступ к пулу.
 type TxManager struct {
 	pool *pgxpool.Pool
+	tx   Querier // Открытая транзакция, к которой привязан менеджер (см. Bind)
 }
 
 // NewTxManager создаёт новый TxManager.
@@ -91,17 +117,43 @@ func NewTxManager(pool *pgxpool.Pool) *TxManager {
 	return &TxManager{pool: pool}
 }
 
+// Bind возвращает менеджер, привязанный к открытой транзакции q.
+// Его RunInTx и RunInSnapshot выполняют функцию во вложенной транзакции
+// внутри q, а Q возвращает q: так сервис, вызванный из чужой транзакции,
+// становится её частью.
+func (m *TxManager) Bind(q Querier) *TxManager {
+	return &TxManager{pool: m.pool, tx: q}
+}
+
 // RunInTx выполняет функцию в рамках транзакции.
 func (m *TxManager) RunInTx(ctx context.Context, fn TxFunc) error {
+	if m.tx != nil {
+		return withSavepoint(ctx, m.tx, fn)
+	}
 	return WithTx(ctx, m.pool, fn)
 }
 
+// RunInSnapshot выполняет функцию в читающей транзакции REPEATABLE READ:
+// все запросы видят один и тот же снимок данных.
+func (m *TxManager) RunInSnapshot(ctx context.Context, fn TxFunc) error {
+	if m.tx != nil {
+		return withSavepoint(ctx, m.tx, fn)
+	}
+	return WithTxOptions(ctx, m.pool, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}, fn)
+}
+
 // Pool возвращает пул соединений с БД.
 func (m *TxManager) Pool() *pgxpool.Pool {
 	return m.pool
 }
 
-// Q возвращает Querier (пул) для обычных запросов.
+// Q возвращает Querier (пул или привязанную транзакцию) для обычных запросов.
 func (m *TxManager) Q() Querier {
+	if m.tx != nil {
+		return m.tx
+	}
 	return m.pool
 }
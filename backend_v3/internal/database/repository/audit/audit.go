@@ -4,7 +4,9 @@ package audit
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository/base"
@@ -12,6 +14,57 @@ import (
 	"github.com/heartmarshall/my-english/internal/model"
 )
 
+// ============================================================================
+// CONSTANTS
+// ============================================================================
+
+const (
+	// DefaultLimit — лимит по умолчанию для списка записей аудита.
+	DefaultLimit = 50
+
+	// MaxLimit — максимальный лимит для списка записей аудита.
+	MaxLimit = 500
+
+	// CursorKey — ключ сортировки в курсорах аудита.
+	CursorKey = "CREATED_AT"
+)
+
+// ============================================================================
+// FILTER
+// ============================================================================
+
+// AuditFilter содержит параметры фильтрации и пагинации для истории изменений.
+// Все поля опциональны и комбинируются через AND.
+type AuditFilter struct {
+	// EntityType и EntityID — записи конкретной сущности
+	EntityType *model.EntityType
+	EntityID   *uuid.UUID
+
+	// EntryID — все записи слова, включая вложенные сущности и карточку
+	EntryID *uuid.UUID
+
+	// From и To — диапазон времени создания записи (включительно)
+	From *time.Time
+	To   *time.Time
+
+	// Пагинация
+	Limit  int
+	Offset int
+}
+
+// Normalize нормализует фильтр, применяя дефолтные значения.
+func (f *AuditFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = DefaultLimit
+	}
+	if f.Limit > MaxLimit {
+		f.Limit = MaxLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
+
 // ============================================================================
 // REPOSITORY
 // ============================================================================
@@ -41,8 +94,10 @@ func NewAuditRepository(q database.Querier) *AuditRepository {
 // Параметры:
 //   - audit.EntityType: тип сущности (ENTRY, SENSE, CARD и т.д.)
 //   - audit.EntityID: ID сущности (не может быть nil или zero UUID)
+//   - audit.EntryID: опциональный ID слова, к которому относится сущность
 //   - audit.Action: действие (CREATE, UPDATE, DELETE)
 //   - audit.Changes: опциональные детали изменений (JSONB)
+//   - audit.Snapshot: опциональный снимок контента слова (JSONB, пустой — NULL)
 func (r *AuditRepository) Create(ctx context.Context, audit *model.AuditRecord) (*model.AuditRecord, error) {
 	if audit == nil {
 		return nil, fmt.Errorf("%w: audit is required", database.ErrInvalidInput)
@@ -60,18 +115,166 @@ func (r *AuditRepository) Create(ctx context.Context, audit *model.AuditRecord)
 		return nil, fmt.Errorf("%w: action is required", database.ErrInvalidInput)
 	}
 
+	// Пустой снимок пишем как NULL, а не как JSON null
+	var snapshot any
+	if len(audit.Snapshot) > 0 {
+		snapshot = audit.Snapshot
+	}
+
 	insert := r.InsertBuilder().
 		Columns(schema.AuditRecords.InsertColumns()...).
 		Values(
 			audit.EntityType,
 			audit.EntityID,
+			audit.EntryID,
 			audit.Action,
 			audit.Changes,
+			snapshot,
 		)
 
 	return r.InsertReturning(ctx, insert)
 }
 
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// Find возвращает записи аудита по фильтру: сначала новые.
+func (r *AuditRepository) Find(ctx context.Context, f AuditFilter) ([]model.AuditRecord, error) {
+	f.Normalize()
+
+	b := r.applyFilters(r.SelectBuilder(), f).
+		OrderBy(schema.AuditRecords.CreatedAt.Bare()+" DESC", schema.AuditRecords.ID.Bare()+" DESC").
+		Limit(uint64(f.Limit))
+	if f.Offset > 0 {
+		b = b.Offset(uint64(f.Offset))
+	}
+
+	return r.List(ctx, b)
+}
+
+// FindPage возвращает страницу записей аудита для keyset-пагинации (новые первыми):
+// f.Limit записей строго после курсора after (nil — с начала) и флаг наличия следующей страницы.
+// Offset игнорируется.
+func (r *AuditRepository) FindPage(ctx context.Context, f AuditFilter, after *base.Cursor) ([]model.AuditRecord, bool, error) {
+	f.Normalize()
+
+	createdCol := schema.AuditRecords.CreatedAt.Bare()
+	idCol := schema.AuditRecords.ID.Bare()
+
+	b := r.applyFilters(r.SelectBuilder(), f)
+	if after != nil {
+		if after.Key != CursorKey {
+			return nil, false, fmt.Errorf("%w: cursor does not match sort field", database.ErrInvalidInput)
+		}
+		createdAt, err := after.Time()
+		if err != nil {
+			return nil, false, err
+		}
+		b = b.Where(base.KeysetAfter(createdCol, idCol, true, createdAt, after.ID))
+	}
+	b = b.OrderBy(createdCol+" DESC", idCol+" DESC").Limit(uint64(f.Limit + 1))
+
+	records, err := r.List(ctx, b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(records) > f.Limit
+	if hasNext {
+		records = records[:f.Limit]
+	}
+	return records, hasNext, nil
+}
+
+// RecordCursor возвращает курсор записи аудита (см. FindPage).
+func RecordCursor(record model.AuditRecord) base.Cursor {
+	return base.NewTimeCursor(CursorKey, record.CreatedAt, record.ID)
+}
+
+// CountTotal возвращает общее количество записей аудита по фильтру (без пагинации).
+func (r *AuditRepository) CountTotal(ctx context.Context, f AuditFilter) (int64, error) {
+	b := r.applyFilters(base.Builder().Select("COUNT(*)").From(schema.AuditRecords.Name.String()), f)
+
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return 0, database.WrapDBError(err)
+	}
+
+	var count int64
+	if err := r.Q().QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.WrapDBError(err)
+	}
+
+	return count, nil
+}
+
+// ListByEntity возвращает историю изменений конкретной сущности.
+func (r *AuditRepository) ListByEntity(ctx context.Context, entityType model.EntityType, entityID uuid.UUID, limit, offset int) ([]model.AuditRecord, error) {
+	if err := base.ValidateUUID(entityID, "entity_id"); err != nil {
+		return nil, err
+	}
+	return r.Find(ctx, AuditFilter{
+		EntityType: &entityType,
+		EntityID:   &entityID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// ListByEntry возвращает историю изменений слова, включая вложенные сущности и карточку.
+func (r *AuditRepository) ListByEntry(ctx context.Context, entryID uuid.UUID, limit, offset int) ([]model.AuditRecord, error) {
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return nil, err
+	}
+	return r.Find(ctx, AuditFilter{
+		EntryID: &entryID,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// GetLatestSnapshot возвращает последнюю запись слова со снимком контента,
+// созданную не позже at.
+// Возвращает database.ErrNotFound, если такой записи нет.
+func (r *AuditRepository) GetLatestSnapshot(ctx context.Context, entryID uuid.UUID, at time.Time) (*model.AuditRecord, error) {
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return nil, err
+	}
+
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{
+			schema.AuditRecords.EntityType.Bare(): model.EntityEntry,
+			schema.AuditRecords.EntityID.Bare():   entryID,
+		}).
+		Where(schema.AuditRecords.Snapshot.IsNotNull()).
+		Where(squirrel.LtOrEq{schema.AuditRecords.CreatedAt.Bare(): at}).
+		OrderBy(schema.AuditRecords.CreatedAt.Bare()+" DESC", schema.AuditRecords.ID.Bare()+" DESC").
+		Limit(1)
+
+	return r.GetOne(ctx, query)
+}
+
+// applyFilters применяет условия фильтра к запросу.
+func (r *AuditRepository) applyFilters(b squirrel.SelectBuilder, f AuditFilter) squirrel.SelectBuilder {
+	if f.EntityType != nil {
+		b = b.Where(squirrel.Eq{schema.AuditRecords.EntityType.Bare(): *f.EntityType})
+	}
+	if f.EntityID != nil {
+		b = b.Where(squirrel.Eq{schema.AuditRecords.EntityID.Bare(): *f.EntityID})
+	}
+	if f.EntryID != nil {
+		b = b.Where(squirrel.Eq{schema.AuditRecords.EntryID.Bare(): *f.EntryID})
+	}
+	if f.From != nil {
+		b = b.Where(squirrel.GtOrEq{schema.AuditRecords.CreatedAt.Bare(): *f.From})
+	}
+	if f.To != nil {
+		b = b.Where(squirrel.LtOrEq{schema.AuditRecords.CreatedAt.Bare(): *f.To})
+	}
+	return b
+}
+
 // ============================================================================
 // HELPER FUNCTIONS
 // ============================================================================
@@ -0,0 +1,232 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var auditColumns = []string{"id", "entity_type", "entity_id", "entry_id", "action", "changes", "snapshot", "created_at"}
+
+func TestAuditRepository_Create(t *testing.T) {
+	entityID := uuid.New()
+	entryID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		audit   *model.AuditRecord
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "with entry and snapshot",
+			audit: &model.AuditRecord{
+				EntityType: model.EntityEntry,
+				EntityID:   &entryID,
+				EntryID:    &entryID,
+				Action:     model.ActionUpdate,
+				Changes:    model.JSON{"text": "hello"},
+				Snapshot:   model.JSON{"text": "hello"},
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(auditColumns).
+					AddRow(uuid.New(), model.EntityEntry, &entryID, &entryID, model.ActionUpdate, model.JSON{}, model.JSON{}, now)
+				mock.ExpectQuery(`INSERT INTO audit_records`).
+					WithArgs(model.EntityEntry, pgxmock.AnyArg(), pgxmock.AnyArg(), model.ActionUpdate, pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "child entity without snapshot",
+			audit: &model.AuditRecord{
+				EntityType: model.EntitySense,
+				EntityID:   &entityID,
+				EntryID:    &entryID,
+				Action:     model.ActionCreate,
+				Changes:    model.JSON{},
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(auditColumns).
+					AddRow(uuid.New(), model.EntitySense, &entityID, &entryID, model.ActionCreate, model.JSON{}, nil, now)
+				mock.ExpectQuery(`INSERT INTO audit_records`).
+					WithArgs(model.EntitySense, pgxmock.AnyArg(), pgxmock.AnyArg(), model.ActionCreate, pgxmock.AnyArg(), nil).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nil audit",
+			audit:   nil,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+		{
+			name: "missing entity id",
+			audit: &model.AuditRecord{
+				EntityType: model.EntityEntry,
+				Action:     model.ActionCreate,
+			},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewAuditRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			result, err := repo.Create(ctx, tt.audit)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && result == nil {
+				t.Error("Create() returned nil result")
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestAuditRepository_Find(t *testing.T) {
+	entryID := uuid.New()
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	tests := []struct {
+		name    string
+		filter  AuditFilter
+		setup   func(mock pgxmock.PgxPoolIface)
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "by entry",
+			filter: AuditFilter{EntryID: &entryID},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(auditColumns).
+					AddRow(uuid.New(), model.EntityEntry, &entryID, &entryID, model.ActionCreate, model.JSON{}, nil, to).
+					AddRow(uuid.New(), model.EntitySense, &entryID, &entryID, model.ActionCreate, model.JSON{}, nil, to)
+				mock.ExpectQuery(`SELECT .+ FROM audit_records WHERE entry_id = \$1 ORDER BY created_at DESC, id DESC LIMIT 50`).
+					WithArgs(pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:   "by time range with pagination",
+			filter: AuditFilter{From: &from, To: &to, Limit: 10, Offset: 20},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(auditColumns)
+				mock.ExpectQuery(`SELECT .+ FROM audit_records WHERE created_at >= \$1 AND created_at <= \$2 ORDER BY created_at DESC, id DESC LIMIT 10 OFFSET 20`).
+					WithArgs(from, to).
+					WillReturnRows(rows)
+			},
+			want:    0,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewAuditRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			got, err := repo.Find(ctx, tt.filter)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("Find() returned %d records, want %d", len(got), tt.want)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestAuditRepository_GetLatestSnapshot(t *testing.T) {
+	entryID := uuid.New()
+	at := time.Now()
+
+	tests := []struct {
+		name    string
+		entryID uuid.UUID
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name:    "found",
+			entryID: entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(auditColumns).
+					AddRow(uuid.New(), model.EntityEntry, &entryID, &entryID, model.ActionUpdate, model.JSON{}, model.JSON{"text": "hello"}, at)
+				mock.ExpectQuery(`SELECT .+ FROM audit_records WHERE .+ AND audit_records.snapshot IS NOT NULL AND created_at <= \$3 ORDER BY created_at DESC, id DESC LIMIT 1`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), at).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "no snapshot",
+			entryID: entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`SELECT`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), at).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "zero uuid",
+			entryID: uuid.UUID{},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewAuditRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			result, err := repo.GetLatestSnapshot(ctx, tt.entryID, at)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLatestSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && len(result.Snapshot) == 0 {
+				t.Error("GetLatestSnapshot() returned record without snapshot")
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
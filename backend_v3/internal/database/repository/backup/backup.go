@@ -0,0 +1,213 @@
+// Package backup содержит репозиторий полной выгрузки и загрузки данных.
+//
+// Строки таблиц передаются как JSON-объекты с именами колонок в ключах:
+// to_jsonb при выгрузке и jsonb_populate_recordset при загрузке. Так
+// выгрузка переносит все колонки без отдельного кода для каждой модели.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+)
+
+// ============================================================================
+// TABLES
+// ============================================================================
+
+// Table описывает таблицу, входящую в резервную копию.
+type Table struct {
+	Name       string
+	OrderBy    string   // Порядок строк при выгрузке
+	References []string // Колонки со ссылками на ID других строк копии
+}
+
+// Tables — таблицы резервной копии в порядке загрузки: таблица идёт после
+// таблиц, на которые ссылается. word_levels (справочник из миграции),
+// wiktionary_entries (офлайн-словарь, загружается заново из дампа),
+// import_jobs (временные задания), import_checkpoints (отметки импорта
+// с устройств) и suggestion_cache (кэш подсказок) в копию не входят.
+var Tables = []Table{
+	{
+		// Сначала варианты изображений: на них ссылаются оригиналы
+		Name:       schema.Media.Name.String(),
+		OrderBy:    "(thumbnail_id IS NOT NULL OR card_id IS NOT NULL), created_at, id",
+		References: []string{schema.Media.ThumbnailID.Bare(), schema.Media.CardID.Bare()},
+	},
+	{Name: schema.DictionaryEntries.Name.String(), OrderBy: "created_at, id"},
+	{Name: schema.Senses.Name.String(), OrderBy: "created_at, id", References: []string{schema.Senses.EntryID.Bare()}},
+	{Name: schema.Translations.Name.String(), OrderBy: "id", References: []string{schema.Translations.SenseID.Bare()}},
+	{Name: schema.Examples.Name.String(), OrderBy: "created_at, id", References: []string{schema.Examples.SenseID.Bare()}},
+	{Name: schema.SenseRelations.Name.String(), OrderBy: "created_at, id", References: []string{schema.SenseRelations.SenseID.Bare()}},
+	{
+		Name:       schema.Images.Name.String(),
+		OrderBy:    "id",
+		References: []string{schema.Images.EntryID.Bare(), schema.Images.MediaID.Bare()},
+	},
+	{
+		Name:       schema.Pronunciations.Name.String(),
+		OrderBy:    "id",
+		References: []string{schema.Pronunciations.EntryID.Bare(), schema.Pronunciations.MediaID.Bare()},
+	},
+	{Name: schema.Cards.Name.String(), OrderBy: "created_at, id", References: []string{schema.Cards.EntryID.Bare()}},
+	{Name: schema.ReviewLogs.Name.String(), OrderBy: "reviewed_at, id", References: []string{schema.ReviewLogs.CardID.Bare()}},
+	{Name: schema.Hints.Name.String(), OrderBy: "created_at, id", References: []string{schema.Hints.CardID.Bare()}},
+	{Name: schema.InboxItems.Name.String(), OrderBy: "created_at, id"},
+	{
+		// Ссылки аудита не закреплены внешними ключами и могут указывать на удалённые строки
+		Name:       schema.AuditRecords.Name.String(),
+		OrderBy:    "created_at, id",
+		References: []string{schema.AuditRecords.EntityID.Bare(), schema.AuditRecords.EntryID.Bare()},
+	},
+}
+
+// FindTable возвращает таблицу копии по имени.
+func FindTable(name string) (Table, bool) {
+	for _, t := range Tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// ============================================================================
+// REPOSITORY
+// ============================================================================
+
+// BackupRepository выгружает и загружает строки таблиц резервной копии.
+// Запросы не ограничены DefaultQueryTimeout: выгрузка большой таблицы
+// может идти дольше.
+type BackupRepository struct {
+	q database.Querier
+}
+
+// NewBackupRepository создаёт новый репозиторий резервных копий.
+func NewBackupRepository(q database.Querier) *BackupRepository {
+	return &BackupRepository{q: q}
+}
+
+// Stream передаёт в fn строки таблицы в порядке Table.OrderBy.
+// Ошибка fn прерывает выгрузку и возвращается как есть.
+func (r *BackupRepository) Stream(ctx context.Context, table string, fn func(row json.RawMessage) error) error {
+	t, ok := FindTable(table)
+	if !ok {
+		return fmt.Errorf("%w: unknown backup table %q", database.ErrInvalidInput, table)
+	}
+
+	sql := fmt.Sprintf("SELECT to_jsonb(t) FROM %s t ORDER BY %s", t.Name, t.OrderBy)
+	rows, err := r.q.Query(ctx, sql)
+	if err != nil {
+		return database.WrapWithTable(database.WrapDBError(err), t.Name)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return database.WrapWithTable(database.WrapDBError(err), t.Name)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return database.WrapWithTable(database.WrapDBError(err), t.Name)
+	}
+	return nil
+}
+
+// Insert вставляет строки в таблицу как есть, включая ID и служебные
+// колонки. Отсутствующие в строке колонки получают NULL.
+//
+// Возвращает:
+//   - ErrInvalidInput: если таблица не входит в копию или строк больше MaxBatchSize
+//   - ErrDuplicate, ErrForeignKeyViolation, ErrConstraintViolation: при нарушении ограничений
+func (r *BackupRepository) Insert(ctx context.Context, table string, rows []json.RawMessage) (int64, error) {
+	t, ok := FindTable(table)
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown backup table %q", database.ErrInvalidInput, table)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if len(rows) > base.MaxBatchSize {
+		return 0, fmt.Errorf("%w: batch size %d exceeds maximum %d", database.ErrInvalidInput, len(rows), base.MaxBatchSize)
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.Write(row)
+	}
+	sb.WriteByte(']')
+
+	sql := fmt.Sprintf("INSERT INTO %[1]s SELECT * FROM jsonb_populate_recordset(NULL::%[1]s, $1::jsonb)", t.Name)
+	tag, err := r.q.Exec(ctx, sql, sb.String())
+	if err != nil {
+		return 0, database.WrapWithTable(database.WrapDBError(err), t.Name)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// IsEmpty сообщает, что во всех таблицах копии нет строк.
+func (r *BackupRepository) IsEmpty(ctx context.Context) (bool, error) {
+	checks := make([]string, len(Tables))
+	for i, t := range Tables {
+		checks[i] = fmt.Sprintf("EXISTS (SELECT 1 FROM %s)", t.Name)
+	}
+
+	var notEmpty bool
+	if err := r.q.QueryRow(ctx, "SELECT "+strings.Join(checks, " OR ")).Scan(&notEmpty); err != nil {
+		return false, database.WrapDBError(err)
+	}
+	return !notEmpty, nil
+}
+
+// MediaIDsBySHA256 возвращает ID уже сохранённых медиафайлов по хешам
+// содержимого. Хеши, которых нет в таблице, в результат не попадают.
+func (r *BackupRepository) MediaIDsBySHA256(ctx context.Context, hashes []string) (map[string]uuid.UUID, error) {
+	result := make(map[string]uuid.UUID)
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	sql, args, err := base.Builder().
+		Select(schema.Media.SHA256.Bare(), schema.Media.ID.Bare()).
+		From(schema.Media.Name.String()).
+		Where(schema.Media.SHA256.In(hashes)).
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	rows, err := r.q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			hash string
+			id   uuid.UUID
+		)
+		if err := rows.Scan(&hash, &id); err != nil {
+			return nil, database.WrapDBError(err)
+		}
+		result[hash] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, database.WrapDBError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+func TestTables_ReferencesPrecedingTables(t *testing.T) {
+	// Каждая таблица загружается после тех, на которые ссылается
+	parents := map[string]string{
+		"media_id": "media", "thumbnail_id": "media", "card_id": "cards",
+		"entry_id": "dictionary_entries", "sense_id": "senses",
+	}
+	seen := make(map[string]bool)
+	for _, table := range Tables {
+		if table.Name == "media" || table.Name == "audit_records" {
+			seen[table.Name] = true
+			continue // Ссылки на саму таблицу и ссылки без внешних ключей
+		}
+		for _, ref := range table.References {
+			if parent, ok := parents[ref]; !ok || !seen[parent] {
+				t.Errorf("table %s references %s before %q is loaded", table.Name, ref, parents[ref])
+			}
+		}
+		seen[table.Name] = true
+	}
+}
+
+func TestBackupRepository_Stream(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewBackupRepository(querier)
+
+	mock.ExpectQuery(`SELECT to_jsonb\(t\) FROM senses t ORDER BY created_at, id`).
+		WillReturnRows(pgxmock.NewRows([]string{"to_jsonb"}).
+			AddRow([]byte(`{"id":"1"}`)).
+			AddRow([]byte(`{"id":"2"}`)))
+
+	var got []string
+	err := repo.Stream(context.Background(), "senses", func(row json.RawMessage) error {
+		got = append(got, string(row))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if len(got) != 2 || got[1] != `{"id":"2"}` {
+		t.Errorf("Stream() rows = %v", got)
+	}
+
+	if err := repo.Stream(context.Background(), "word_levels", func(json.RawMessage) error { return nil }); err == nil {
+		t.Error("Stream() expected error for a table outside the backup")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestBackupRepository_Insert(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewBackupRepository(querier)
+
+	mock.ExpectExec(`INSERT INTO inbox_items SELECT \* FROM jsonb_populate_recordset\(NULL::inbox_items, \$1::jsonb\)`).
+		WithArgs(`[{"text":"a"},{"text":"b"}]`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	n, err := repo.Insert(context.Background(), "inbox_items", []json.RawMessage{
+		json.RawMessage(`{"text":"a"}`), json.RawMessage(`{"text":"b"}`),
+	})
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Insert() = %d, want 2", n)
+	}
+
+	// Пустая пачка — без запроса
+	if n, err := repo.Insert(context.Background(), "inbox_items", nil); err != nil || n != 0 {
+		t.Errorf("Insert(nil) = %d, %v", n, err)
+	}
+
+	if _, err := repo.Insert(context.Background(), "import_jobs", []json.RawMessage{json.RawMessage(`{}`)}); err == nil {
+		t.Error("Insert() expected error for a table outside the backup")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestBackupRepository_IsEmpty(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewBackupRepository(querier)
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM media\) OR .+ OR EXISTS \(SELECT 1 FROM audit_records\)`).
+		WillReturnRows(pgxmock.NewRows([]string{"?column?"}).AddRow(true))
+
+	empty, err := repo.IsEmpty(context.Background())
+	if err != nil {
+		t.Fatalf("IsEmpty() error = %v", err)
+	}
+	if empty {
+		t.Error("IsEmpty() = true, want false")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestBackupRepository_MediaIDsBySHA256(t *testing.T) {
+	id := uuid.New()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewBackupRepository(querier)
+
+	mock.ExpectQuery(`SELECT sha256, id FROM media WHERE media.sha256 IN \(\$1,\$2\)`).
+		WithArgs("aaa", "bbb").
+		WillReturnRows(pgxmock.NewRows([]string{"sha256", "id"}).AddRow("aaa", id))
+
+	got, err := repo.MediaIDsBySHA256(context.Background(), []string{"aaa", "bbb"})
+	if err != nil {
+		t.Fatalf("MediaIDsBySHA256() error = %v", err)
+	}
+	if len(got) != 1 || got["aaa"] != id {
+		t.Errorf("MediaIDsBySHA256() = %v", got)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
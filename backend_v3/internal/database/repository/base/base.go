@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -59,6 +60,15 @@ func UUIDsToAny(ids []uuid.UUID) []any {
 	return result
 }
 
+// likeEscaper экранирует спецсимволы LIKE; в PostgreSQL escape-символ по умолчанию — \.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike экранирует \, % и _ в s, чтобы строка совпадала в LIKE буквально.
+// Используется перед добавлением своих подстановочных символов: EscapeLike(s) + "%".
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // IsZeroUUID проверяет, является ли UUID нулевым.
 func IsZeroUUID(id uuid.UUID) bool {
 	return id == uuid.UUID{}
@@ -607,6 +617,30 @@ func (r *Base[T]) Update(ctx context.Context, update squirrel.UpdateBuilder) (*T
 	return &dest, nil
 }
 
+// UpdateWhere выполняет UPDATE без RETURNING.
+// Возвращает количество обновлённых записей (0 — не ошибка).
+// Используйте для массовых обновлений, когда сами записи не нужны.
+func (r *Base[T]) UpdateWhere(ctx context.Context, update squirrel.UpdateBuilder) (int64, error) {
+	// Проверяем контекст перед выполнением запроса
+	if err := ctx.Err(); err != nil {
+		return 0, database.WrapDBError(err)
+	}
+
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return 0, database.WrapDBError(err)
+	}
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tag, err := r.querier.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, database.WrapDBError(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
 // Delete удаляет запись по ID.
 //
 // Возвращает:
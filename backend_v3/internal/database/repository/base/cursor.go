@@ -0,0 +1,79 @@
+package base
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+)
+
+// ============================================================================
+// KEYSET CURSOR
+// ============================================================================
+
+// Cursor — позиция в keyset-пагинации: значение ключа сортировки и ID записи.
+// ID разрешает неоднозначность при одинаковых значениях ключа.
+// Для клиента курсор непрозрачен (base64 от JSON).
+type Cursor struct {
+	Key   string    `json:"k"`           // Ключ сортировки (например, "CREATED_AT")
+	Dir   string    `json:"d,omitempty"` // Направление сортировки ("ASC"/"DESC"), если оно выбирается клиентом
+	Value string    `json:"v"`           // Значение ключа сортировки у записи
+	ID    uuid.UUID `json:"id"`
+}
+
+// NewTimeCursor создаёт курсор для ключа сортировки по времени.
+func NewTimeCursor(key string, t time.Time, id uuid.UUID) Cursor {
+	return Cursor{Key: key, Value: t.UTC().Format(time.RFC3339Nano), ID: id}
+}
+
+// Time возвращает значение курсора как время.
+func (c Cursor) Time() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid cursor value", database.ErrInvalidInput)
+	}
+	return t, nil
+}
+
+// Encode кодирует курсор в непрозрачную строку.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor декодирует курсор из строки.
+// Пустая строка означает начало выборки и возвращает nil.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+	if c.Key == "" || c.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	return &c, nil
+}
+
+// KeysetAfter возвращает условие "строго после курсора" для сортировки
+// ORDER BY sortCol, idCol в одном направлении (desc — по убыванию).
+func KeysetAfter(sortCol, idCol string, desc bool, value any, id uuid.UUID) squirrel.Sqlizer {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return squirrel.Expr(fmt.Sprintf("(%s, %s) %s (?, ?)", sortCol, idCol, op), value, id)
+}
@@ -75,11 +75,15 @@ func NewCardRepository(q database.Querier) *CardRepository {
 // ============================================================================
 
 // GetByID получает карточку по ID.
+// Карточки слов из корзины не возвращаются.
 func (r *CardRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Card, error) {
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Cards.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Cards.ID.Bare(): id}).
+		Where(schema.ActiveEntry(schema.Cards.EntryID))
+	return r.GetOne(ctx, query)
 }
 
 // GetByEntryID получает карточку по ID записи словаря.
@@ -87,7 +91,11 @@ func (r *CardRepository) GetByEntryID(ctx context.Context, entryID uuid.UUID) (*
 	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
 		return nil, err
 	}
-	return r.FindOneBy(ctx, schema.Cards.EntryID.Bare(), entryID)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Cards.EntryID.Bare(): entryID}).
+		Where(schema.ActiveEntry(schema.Cards.EntryID)).
+		Limit(1)
+	return r.GetOne(ctx, query)
 }
 
 // GetByIDForUpdate получает карточку с блокировкой строки (SELECT FOR UPDATE).
@@ -99,6 +107,7 @@ func (r *CardRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*m
 
 	query := r.SelectBuilder().
 		Where(squirrel.Eq{schema.Cards.ID.Bare(): id}).
+		Where(schema.ActiveEntry(schema.Cards.EntryID)).
 		Suffix("FOR UPDATE")
 
 	return r.GetOne(ctx, query)
@@ -106,7 +115,9 @@ func (r *CardRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*m
 
 // GetDueCards получает карточки, которые нужно повторить до указанного времени.
 // Карточки сортируются по времени следующего повторения (самые просроченные первыми).
-func (r *CardRepository) GetDueCards(ctx context.Context, now time.Time, limit int) ([]model.Card, error) {
+// Карточки слов из корзины не попадают в очередь повторения.
+// language ограничивает очередь словами одного языка (nil — все языки).
+func (r *CardRepository) GetDueCards(ctx context.Context, now time.Time, limit int, language *string) ([]model.Card, error) {
 	// Проверяем контекст перед выполнением
 	if err := ctx.Err(); err != nil {
 		return nil, database.WrapDBError(err)
@@ -122,14 +133,24 @@ func (r *CardRepository) GetDueCards(ctx context.Context, now time.Time, limit i
 
 	query := r.SelectBuilder().
 		Where(squirrel.LtOrEq{schema.Cards.NextReviewAt.Bare(): now}).
+		Where(schema.ActiveEntry(schema.Cards.EntryID)).
 		OrderBy(schema.Cards.NextReviewAt.Bare() + " ASC").
 		Limit(uint64(limit))
 
+	if language != nil {
+		query = query.Where(squirrel.Expr(
+			"EXISTS (SELECT 1 FROM "+schema.DictionaryEntries.Name.String()+" de"+
+				" WHERE de.id = "+string(schema.Cards.EntryID)+" AND de.language = ?)",
+			*language,
+		))
+	}
+
 	return r.List(ctx, query)
 }
 
 // GetDashboardStats возвращает агрегированную статистику для дашборда.
 // Выполняет один оптимизированный запрос вместо множества.
+// Слова из корзины и их карточки не учитываются.
 func (r *CardRepository) GetDashboardStats(ctx context.Context) (*DashboardStats, error) {
 	// Проверяем контекст перед выполнением
 	if err := ctx.Err(); err != nil {
@@ -140,7 +161,7 @@ func (r *CardRepository) GetDashboardStats(ctx context.Context) (*DashboardStats
 	// FILTER быстрее CASE WHEN для агрегации
 	sql := `
 		SELECT
-			(SELECT COUNT(*) FROM dictionary_entries)::int as total_words,
+			(SELECT COUNT(*) FROM dictionary_entries WHERE deleted_at IS NULL)::int as total_words,
 			COUNT(*)::int as total_cards,
 			COUNT(*) FILTER (WHERE status = 'NEW')::int as new_cards,
 			COUNT(*) FILTER (WHERE status = 'LEARNING')::int as learning_cards,
@@ -148,6 +169,7 @@ func (r *CardRepository) GetDashboardStats(ctx context.Context) (*DashboardStats
 			COUNT(*) FILTER (WHERE status = 'MASTERED')::int as mastered_cards,
 			COUNT(*) FILTER (WHERE next_review_at <= NOW())::int as due_today
 		FROM cards
+		JOIN dictionary_entries de ON de.id = cards.entry_id AND de.deleted_at IS NULL
 	`
 
 	var stats DashboardStats
@@ -198,6 +220,41 @@ func (r *CardRepository) Create(ctx context.Context, card *model.Card) (*model.C
 	return r.InsertReturning(ctx, insert)
 }
 
+// BatchCreate создаёт несколько карточек за один запрос.
+// Дефолтные значения применяются так же, как в Create.
+func (r *CardRepository) BatchCreate(ctx context.Context, cards []model.Card) ([]model.Card, error) {
+	if len(cards) == 0 {
+		return []model.Card{}, nil
+	}
+
+	for i, c := range cards {
+		if err := base.ValidateUUID(c.EntryID, fmt.Sprintf("cards[%d].entry_id", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	columns := schema.Cards.InsertColumns()
+	valuesFunc := func(c model.Card) []any {
+		status := c.Status
+		if status == "" {
+			status = model.StatusNew
+		}
+		easeFactor := c.EaseFactor
+		if easeFactor == 0 {
+			easeFactor = DefaultEaseFactor
+		}
+		return []any{
+			c.EntryID,
+			status,
+			c.NextReviewAt,
+			c.IntervalDays,
+			easeFactor,
+		}
+	}
+
+	return r.BatchInsertReturning(ctx, columns, cards, valuesFunc)
+}
+
 // Update обновляет карточку полностью.
 func (r *CardRepository) Update(ctx context.Context, id uuid.UUID, card *model.Card) (*model.Card, error) {
 	if card == nil {
@@ -266,6 +323,61 @@ func (r *CardRepository) UpdateSRSFields(
 	return err
 }
 
+// ResetProgress сбрасывает SRS-состояние карточек к состоянию новой карточки.
+// История повторений сохраняется. Возвращает количество сброшенных карточек.
+func (r *CardRepository) ResetProgress(ctx context.Context, ids []uuid.UUID, easeFactor float64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if easeFactor < MinEaseFactor {
+		return 0, fmt.Errorf("%w: ease_factor must be >= %.1f, got %.2f", database.ErrInvalidInput, MinEaseFactor, easeFactor)
+	}
+
+	update := r.UpdateBuilder().
+		Set("status", model.StatusNew).
+		Set("next_review_at", nil).
+		Set("interval_days", 0).
+		Set("ease_factor", easeFactor).
+		Where(squirrel.Eq{schema.Cards.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
+// MoveToEntry привязывает карточку к другой записи словаря.
+// У целевой записи не должно быть своей карточки (UNIQUE entry_id).
+func (r *CardRepository) MoveToEntry(ctx context.Context, id uuid.UUID, entryID uuid.UUID) (*model.Card, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Cards.EntryID.Bare(), entryID).
+		Where(squirrel.Eq{schema.Cards.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveHints переносит подсказки с карточек fromCardIDs на карточку toCardID.
+// Возвращает количество перенесённых подсказок.
+func (r *CardRepository) MoveHints(ctx context.Context, fromCardIDs []uuid.UUID, toCardID uuid.UUID) (int64, error) {
+	if len(fromCardIDs) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(toCardID, "card_id"); err != nil {
+		return 0, err
+	}
+
+	update := base.Builder().
+		Update(schema.Hints.Name.String()).
+		Set(schema.Hints.CardID.Bare(), toCardID).
+		Where(squirrel.Eq{schema.Hints.CardID.Bare(): fromCardIDs})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет карточку.
 func (r *CardRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
@@ -312,6 +424,53 @@ func (r *ReviewLogRepository) Create(ctx context.Context, log *model.ReviewLog)
 	return r.InsertReturning(ctx, insert)
 }
 
+// BatchCreate создаёт несколько записей о ревью за один запрос.
+// В отличие от Create, время повторения берётся из ReviewedAt (нулевое — текущее время):
+// используется при импорте истории из других приложений.
+func (r *ReviewLogRepository) BatchCreate(ctx context.Context, logs []model.ReviewLog) ([]model.ReviewLog, error) {
+	if len(logs) == 0 {
+		return []model.ReviewLog{}, nil
+	}
+
+	for i, l := range logs {
+		if err := base.ValidateUUID(l.CardID, fmt.Sprintf("logs[%d].card_id", i)); err != nil {
+			return nil, err
+		}
+		if l.Grade == "" {
+			return nil, fmt.Errorf("%w: logs[%d].grade is required", database.ErrInvalidInput, i)
+		}
+	}
+
+	now := time.Now()
+	columns := append(schema.ReviewLogs.InsertColumns(), schema.ReviewLogs.ReviewedAt.Bare())
+	valuesFunc := func(l model.ReviewLog) []any {
+		reviewedAt := l.ReviewedAt
+		if reviewedAt.IsZero() {
+			reviewedAt = now
+		}
+		return []any{l.CardID, l.Grade, l.DurationMs, reviewedAt}
+	}
+
+	return r.BatchInsertReturning(ctx, columns, logs, valuesFunc)
+}
+
+// MoveToCard переносит историю повторений с карточек fromCardIDs на карточку toCardID.
+// Возвращает количество перенесённых записей.
+func (r *ReviewLogRepository) MoveToCard(ctx context.Context, fromCardIDs []uuid.UUID, toCardID uuid.UUID) (int64, error) {
+	if len(fromCardIDs) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(toCardID, "card_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.ReviewLogs.CardID.Bare(), toCardID).
+		Where(squirrel.Eq{schema.ReviewLogs.CardID.Bare(): fromCardIDs})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // ListByCardID возвращает историю повторений для карточки.
 // Записи сортируются по дате повторения (новые первыми).
 func (r *ReviewLogRepository) ListByCardID(ctx context.Context, cardID uuid.UUID, limit int) ([]model.ReviewLog, error) {
@@ -334,6 +493,20 @@ func (r *ReviewLogRepository) ListByCardID(ctx context.Context, cardID uuid.UUID
 	return r.List(ctx, query)
 }
 
+// ListByCardIDs возвращает историю повторений нескольких карточек,
+// отсортированную по дате повторения (старые первыми). Используется при экспорте.
+func (r *ReviewLogRepository) ListByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]model.ReviewLog, error) {
+	if len(cardIDs) == 0 {
+		return []model.ReviewLog{}, nil
+	}
+
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.ReviewLogs.CardID.Bare(): cardIDs}).
+		OrderBy(schema.ReviewLogs.ReviewedAt.Bare()+" ASC", schema.ReviewLogs.ID.Bare()+" ASC")
+
+	return r.List(ctx, query)
+}
+
 // ListByEntryIDs возвращает список карточек для указанных entryIDs.
 // Используется для DataLoaders.
 func (r *CardRepository) ListByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]model.Card, error) {
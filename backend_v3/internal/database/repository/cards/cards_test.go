@@ -371,13 +371,16 @@ func TestCardRepository_GetDueCards(t *testing.T) {
 	now := time.Now()
 	dueTime := now.Add(-1 * time.Hour)
 
+	german := "de"
+
 	tests := []struct {
-		name    string
-		now     time.Time
-		limit   int
-		setup   func(mock pgxmock.PgxPoolIface)
-		wantLen int
-		wantErr bool
+		name     string
+		now      time.Time
+		limit    int
+		language *string
+		setup    func(mock pgxmock.PgxPoolIface)
+		wantLen  int
+		wantErr  bool
 	}{
 		{
 			name:  "returns due cards",
@@ -407,6 +410,21 @@ func TestCardRepository_GetDueCards(t *testing.T) {
 			wantLen: 0,
 			wantErr: false,
 		},
+		{
+			name:     "scoped by language",
+			now:      now,
+			limit:    10,
+			language: &german,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "entry_id", "status", "next_review_at", "interval_days", "ease_factor", "created_at", "updated_at"}).
+					AddRow(cardID1, entryID1, model.StatusReview, &dueTime, 7, 2.5, now, now)
+				mock.ExpectQuery(`SELECT .+ de\.language = \$2`).
+					WithArgs(pgxmock.AnyArg(), "de").
+					WillReturnRows(rows)
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -417,7 +435,7 @@ func TestCardRepository_GetDueCards(t *testing.T) {
 			tt.setup(mock)
 
 			ctx := context.Background()
-			result, err := repo.GetDueCards(ctx, tt.now, tt.limit)
+			result, err := repo.GetDueCards(ctx, tt.now, tt.limit, tt.language)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetDueCards() error = %v, wantErr %v", err, tt.wantErr)
@@ -433,6 +451,63 @@ func TestCardRepository_GetDueCards(t *testing.T) {
 	}
 }
 
+func TestCardRepository_BatchCreate(t *testing.T) {
+	entryIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	now := time.Now()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewCardRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"id", "entry_id", "status", "next_review_at", "interval_days", "ease_factor", "created_at", "updated_at"}).
+		AddRow(uuid.New(), entryIDs[0], model.StatusNew, nil, 0, DefaultEaseFactor, now, now).
+		AddRow(uuid.New(), entryIDs[1], model.StatusNew, nil, 0, DefaultEaseFactor, now, now)
+	mock.ExpectQuery(`INSERT INTO cards .+ VALUES \(.+\),\(.+\) RETURNING`).
+		WithArgs(
+			entryIDs[0], model.StatusNew, pgxmock.AnyArg(), 0, DefaultEaseFactor,
+			entryIDs[1], model.StatusNew, pgxmock.AnyArg(), 0, DefaultEaseFactor,
+		).
+		WillReturnRows(rows)
+
+	created, err := repo.BatchCreate(context.Background(), []model.Card{{EntryID: entryIDs[0]}, {EntryID: entryIDs[1]}})
+	if err != nil {
+		t.Fatalf("BatchCreate() error = %v", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("BatchCreate() returned %d cards, want 2", len(created))
+	}
+
+	if _, err := repo.BatchCreate(context.Background(), []model.Card{{}}); err == nil {
+		t.Error("BatchCreate() expected error for zero entry_id")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestCardRepository_ResetProgress(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewCardRepository(querier)
+
+	mock.ExpectExec(`UPDATE cards SET status = \$1, next_review_at = \$2, interval_days = \$3, ease_factor = \$4 WHERE id IN \(\$5,\$6\)`).
+		WithArgs(model.StatusNew, nil, 0, DefaultEaseFactor, ids[0], ids[1]).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	reset, err := repo.ResetProgress(context.Background(), ids, DefaultEaseFactor)
+	if err != nil {
+		t.Fatalf("ResetProgress() error = %v", err)
+	}
+	if reset != 2 {
+		t.Errorf("ResetProgress() = %d, want 2", reset)
+	}
+
+	if _, err := repo.ResetProgress(context.Background(), ids, 1.0); err == nil {
+		t.Error("ResetProgress() expected error for ease factor below minimum")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
 func TestReviewLogRepository_Create(t *testing.T) {
 	logID := uuid.New()
 	cardID := uuid.New()
@@ -502,6 +577,42 @@ func TestReviewLogRepository_Create(t *testing.T) {
 	}
 }
 
+func TestReviewLogRepository_BatchCreate(t *testing.T) {
+	cardID := uuid.New()
+	reviewedAt := time.Date(2023, 11, 16, 10, 0, 0, 0, time.UTC)
+	duration := 4000
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewReviewLogRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"id", "card_id", "grade", "duration_ms", "reviewed_at"}).
+		AddRow(uuid.New(), cardID, model.GradeAgain, &duration, reviewedAt).
+		AddRow(uuid.New(), cardID, model.GradeGood, nil, time.Now())
+	mock.ExpectQuery(`INSERT INTO review_logs \(card_id,grade,duration_ms,reviewed_at\) VALUES \(.+\),\(.+\) RETURNING`).
+		WithArgs(
+			cardID, model.GradeAgain, &duration, reviewedAt,
+			cardID, model.GradeGood, pgxmock.AnyArg(), pgxmock.AnyArg(),
+		).
+		WillReturnRows(rows)
+
+	created, err := repo.BatchCreate(context.Background(), []model.ReviewLog{
+		{CardID: cardID, Grade: model.GradeAgain, DurationMs: &duration, ReviewedAt: reviewedAt},
+		{CardID: cardID, Grade: model.GradeGood},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreate() error = %v", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("BatchCreate() returned %d logs, want 2", len(created))
+	}
+
+	if _, err := repo.BatchCreate(context.Background(), []model.ReviewLog{{CardID: cardID}}); err == nil {
+		t.Error("BatchCreate() expected error for empty grade")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
 func TestReviewLogRepository_ListByCardID(t *testing.T) {
 	cardID := uuid.New()
 	logID1 := uuid.New()
@@ -586,7 +697,103 @@ func TestReviewLogRepository_ListByCardID(t *testing.T) {
 	}
 }
 
+func TestReviewLogRepository_ListByCardIDs(t *testing.T) {
+	cardID1 := uuid.New()
+	cardID2 := uuid.New()
+	now := time.Now()
+
+	t.Run("returns logs of all cards", func(t *testing.T) {
+		querier, mock := testutil.NewMockQuerier(t)
+		repo := NewReviewLogRepository(querier)
+
+		rows := pgxmock.NewRows([]string{"id", "card_id", "grade", "duration_ms", "reviewed_at"}).
+			AddRow(uuid.New(), cardID1, model.GradeGood, nil, now.Add(-time.Hour)).
+			AddRow(uuid.New(), cardID2, model.GradeAgain, nil, now)
+		mock.ExpectQuery(`SELECT .* FROM review_logs WHERE card_id IN \(\$1,\$2\) ORDER BY reviewed_at ASC`).
+			WithArgs(cardID1, cardID2).
+			WillReturnRows(rows)
+
+		result, err := repo.ListByCardIDs(context.Background(), []uuid.UUID{cardID1, cardID2})
+		if err != nil {
+			t.Fatalf("ListByCardIDs() error = %v", err)
+		}
+		if len(result) != 2 {
+			t.Errorf("ListByCardIDs() returned %d logs, want 2", len(result))
+		}
+		testutil.ExpectationsWereMet(t, mock)
+	})
+
+	t.Run("empty ids skip the query", func(t *testing.T) {
+		querier, mock := testutil.NewMockQuerier(t)
+		repo := NewReviewLogRepository(querier)
+
+		result, err := repo.ListByCardIDs(context.Background(), nil)
+		if err != nil || len(result) != 0 {
+			t.Errorf("ListByCardIDs(nil) = %v, %v", result, err)
+		}
+		testutil.ExpectationsWereMet(t, mock)
+	})
+}
+
 // Helper function
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestReviewLogRepository_MoveToCard(t *testing.T) {
+	toCardID := uuid.New()
+
+	tests := []struct {
+		name     string
+		from     []uuid.UUID
+		toCardID uuid.UUID
+		setup    func(mock pgxmock.PgxPoolIface)
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name:     "moves review logs",
+			from:     []uuid.UUID{uuid.New()},
+			toCardID: toCardID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(`UPDATE review_logs SET card_id = \$1 WHERE card_id IN \(\$2\)`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 5))
+			},
+			want: 5,
+		},
+		{
+			name:     "empty source list is a no-op",
+			from:     nil,
+			toCardID: toCardID,
+			setup:    func(mock pgxmock.PgxPoolIface) {},
+			want:     0,
+		},
+		{
+			name:     "zero target card",
+			from:     []uuid.UUID{uuid.New()},
+			toCardID: uuid.UUID{},
+			setup:    func(mock pgxmock.PgxPoolIface) {},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewReviewLogRepository(querier)
+
+			tt.setup(mock)
+
+			got, err := repo.MoveToCard(context.Background(), tt.from, tt.toCardID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveToCard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MoveToCard() = %d, want %d", got, tt.want)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
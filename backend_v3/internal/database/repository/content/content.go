@@ -1,12 +1,15 @@
 // Package content содержит репозитории для работы с контентом словаря:
 // смыслы (senses), примеры (examples), переводы (translations),
-// изображения (images) и произношения (pronunciations).
+// связи смыслов (sense relations), изображения (images) и произношения
+// (pronunciations).
 package content
 
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository/base"
@@ -38,7 +41,10 @@ func (r *SenseRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Sen
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Senses.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Senses.ID.Bare(): id}).
+		Where(schema.ActiveEntry(schema.Senses.EntryID))
+	return r.GetOne(ctx, query)
 }
 
 // ListByEntryIDs получает смыслы для списка записей словаря.
@@ -49,6 +55,14 @@ func (r *SenseRepository) ListByEntryIDs(ctx context.Context, entryIDs []uuid.UU
 	return r.ListByUUIDs(ctx, schema.Senses.EntryID.Bare(), entryIDs)
 }
 
+// ListByIDs получает смыслы по списку ID.
+func (r *SenseRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.Sense, error) {
+	if len(ids) == 0 {
+		return []model.Sense{}, nil
+	}
+	return r.ListByUUIDs(ctx, schema.Senses.ID.Bare(), ids)
+}
+
 // Create создает новый смысл.
 func (r *SenseRepository) Create(ctx context.Context, sense *model.Sense) (*model.Sense, error) {
 	if sense == nil {
@@ -69,6 +83,7 @@ func (r *SenseRepository) Create(ctx context.Context, sense *model.Sense) (*mode
 			sense.PartOfSpeech,
 			sense.SourceSlug,
 			sense.CefrLevel,
+			sense.Notes,
 		)
 
 	return r.InsertReturning(ctx, insert)
@@ -109,12 +124,75 @@ func (r *SenseRepository) BatchCreate(ctx context.Context, senses []model.Sense)
 			s.PartOfSpeech,
 			s.SourceSlug,
 			s.CefrLevel,
+			s.Notes,
 		}
 	}
 
 	return r.BatchInsertReturning(ctx, columns, senses, valuesFunc)
 }
 
+// Update обновляет поля смысла.
+//
+// Возвращает:
+//   - ErrNotFound: если смысл не найден
+//   - ErrInvalidInput: если id пустой или sense nil
+func (r *SenseRepository) Update(ctx context.Context, id uuid.UUID, sense *model.Sense) (*model.Sense, error) {
+	if sense == nil {
+		return nil, fmt.Errorf("%w: sense is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(sense.SourceSlug, "source_slug"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set("definition", sense.Definition).
+		Set("part_of_speech", sense.PartOfSpeech).
+		Set("source_slug", sense.SourceSlug).
+		Set("cefr_level", sense.CefrLevel).
+		Set("notes", sense.Notes).
+		Where(squirrel.Eq{schema.Senses.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveToEntry переносит смыслы с указанными ID к другой записи словаря.
+// Возвращает количество перенесённых записей.
+func (r *SenseRepository) MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Senses.EntryID.Bare(), entryID).
+		Where(squirrel.Eq{schema.Senses.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
+// FillCefrLevel проставляет уровень CEFR смыслам записи, у которых он не задан.
+// Возвращает количество обновлённых смыслов.
+func (r *SenseRepository) FillCefrLevel(ctx context.Context, entryID uuid.UUID, level string) (int64, error) {
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return 0, err
+	}
+	if err := base.ValidateString(level, "cefr_level"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Senses.CefrLevel.Bare(), level).
+		Where(squirrel.Eq{schema.Senses.EntryID.Bare(): entryID}).
+		Where(schema.Senses.CefrLevel.IsNull())
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет смысл.
 func (r *SenseRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
@@ -147,7 +225,10 @@ func (r *ExampleRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.E
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Examples.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Examples.ID.Bare(): id}).
+		Where(schema.ActiveSense(schema.Examples.SenseID))
+	return r.GetOne(ctx, query)
 }
 
 // ListBySenseIDs получает примеры для списка смыслов.
@@ -195,6 +276,47 @@ func (r *ExampleRepository) BatchCreate(ctx context.Context, examples []model.Ex
 	return r.BatchInsertReturning(ctx, columns, examples, valuesFunc)
 }
 
+// Update обновляет поля примера.
+func (r *ExampleRepository) Update(ctx context.Context, id uuid.UUID, example *model.Example) (*model.Example, error) {
+	if example == nil {
+		return nil, fmt.Errorf("%w: example is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(example.Sentence, "sentence"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(example.SourceSlug, "source_slug"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set("sentence", example.Sentence).
+		Set("translation", example.Translation).
+		Set("source_slug", example.SourceSlug).
+		Where(squirrel.Eq{schema.Examples.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveToSense переносит примеры с указанными ID к другому смыслу.
+// Возвращает количество перенесённых записей.
+func (r *ExampleRepository) MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(senseID, "sense_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Examples.SenseID.Bare(), senseID).
+		Where(squirrel.Eq{schema.Examples.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет пример.
 func (r *ExampleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
@@ -227,7 +349,10 @@ func (r *TranslationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Translations.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Translations.ID.Bare(): id}).
+		Where(schema.ActiveSense(schema.Translations.SenseID))
+	return r.GetOne(ctx, query)
 }
 
 // ListBySenseIDs получает переводы для списка смыслов.
@@ -238,6 +363,77 @@ func (r *TranslationRepository) ListBySenseIDs(ctx context.Context, senseIDs []u
 	return r.ListByUUIDs(ctx, schema.Translations.SenseID.Bare(), senseIDs)
 }
 
+// TranslationMatch — перевод, найденный обратным поиском, вместе с оценкой похожести.
+type TranslationMatch struct {
+	model.Translation
+	Similarity float64 `db:"similarity"`
+}
+
+const (
+	// MinTrigramSearchLength — минимальная длина запроса для поиска по триграммам.
+	// Более короткие запросы ищутся по префиксу.
+	MinTrigramSearchLength = 3
+
+	// DefaultSearchLimit — лимит результатов обратного поиска по умолчанию.
+	DefaultSearchLimit = 20
+
+	// MaxSearchLimit — максимальный лимит результатов обратного поиска.
+	MaxSearchLimit = 100
+)
+
+// translationTextNormExpr — нормализованное выражение над текстом перевода
+// (NFKC, регистр, ё/е — как textnorm.Normalize для русского).
+// Должно совпадать с выражением индекса ix_translations_text_norm_trgm.
+const translationTextNormExpr = "replace(lower(normalize(translations.text, NFKC)), 'ё', 'е')"
+
+// SearchByText ищет переводы, похожие на text, и сортирует их по убыванию похожести.
+//
+// Ожидает уже нормализованный запрос (нижний регистр, ё -> е).
+// Для коротких запросов используется поиск по префиксу, для длинных — pg_trgm.
+//
+// Производительность:
+//   - Требует GIN индекса ix_translations_text_norm_trgm
+func (r *TranslationRepository) SearchByText(ctx context.Context, text string, limit int) ([]TranslationMatch, error) {
+	if err := base.ValidateString(text, "text"); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	query := r.SelectBuilder().
+		Column(fmt.Sprintf("similarity(%s, ?) AS similarity", translationTextNormExpr), text)
+
+	if utf8.RuneCountInString(text) < MinTrigramSearchLength {
+		// Prefix search для коротких запросов
+		query = query.Where(squirrel.Like{translationTextNormExpr: base.EscapeLike(text) + "%"})
+	} else {
+		// Fuzzy search через pg_trgm (оператор similarity %)
+		query = query.Where(squirrel.Expr(translationTextNormExpr+" % ?", text))
+	}
+
+	// Переводы слов из корзины не участвуют в поиске
+	query = query.Where(schema.ActiveSense(schema.Translations.SenseID))
+
+	query = query.
+		OrderBy("similarity DESC", schema.Translations.Text.Bare()+" ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build search query: %w", err)
+	}
+
+	matches := make([]TranslationMatch, 0)
+	if err := r.QueryRaw(ctx, &matches, sql, args...); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
 // BatchCreate создает несколько переводов за один запрос.
 func (r *TranslationRepository) BatchCreate(ctx context.Context, translations []model.Translation) ([]model.Translation, error) {
 	// Проверяем контекст перед выполнением
@@ -257,6 +453,9 @@ func (r *TranslationRepository) BatchCreate(ctx context.Context, translations []
 		if err := base.ValidateString(translations[i].Text, "text"); err != nil {
 			return nil, fmt.Errorf("translation[%d]: %w", i, err)
 		}
+		if err := base.ValidateString(translations[i].Language, "language"); err != nil {
+			return nil, fmt.Errorf("translation[%d]: %w", i, err)
+		}
 		if err := base.ValidateString(translations[i].SourceSlug, "source_slug"); err != nil {
 			return nil, fmt.Errorf("translation[%d]: %w", i, err)
 		}
@@ -267,6 +466,7 @@ func (r *TranslationRepository) BatchCreate(ctx context.Context, translations []
 		return []any{
 			t.SenseID,
 			t.Text,
+			t.Language,
 			t.SourceSlug,
 		}
 	}
@@ -274,6 +474,50 @@ func (r *TranslationRepository) BatchCreate(ctx context.Context, translations []
 	return r.BatchInsertReturning(ctx, columns, translations, valuesFunc)
 }
 
+// Update обновляет поля перевода.
+func (r *TranslationRepository) Update(ctx context.Context, id uuid.UUID, translation *model.Translation) (*model.Translation, error) {
+	if translation == nil {
+		return nil, fmt.Errorf("%w: translation is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(translation.Text, "text"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(translation.Language, "language"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(translation.SourceSlug, "source_slug"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set("text", translation.Text).
+		Set("language", translation.Language).
+		Set("source_slug", translation.SourceSlug).
+		Where(squirrel.Eq{schema.Translations.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveToSense переносит переводы с указанными ID к другому смыслу.
+// Возвращает количество перенесённых записей.
+func (r *TranslationRepository) MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(senseID, "sense_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Translations.SenseID.Bare(), senseID).
+		Where(squirrel.Eq{schema.Translations.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет перевод.
 func (r *TranslationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
@@ -282,6 +526,161 @@ func (r *TranslationRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return r.Base.Delete(ctx, schema.Translations.ID.Bare(), id)
 }
 
+// ============================================================================
+// SENSE RELATIONS REPOSITORY
+// ============================================================================
+
+// SenseRelationRepository предоставляет методы для работы со связями смыслов.
+type SenseRelationRepository struct {
+	*base.Base[model.SenseRelation]
+}
+
+// NewSenseRelationRepository создаёт новый репозиторий связей смыслов.
+func NewSenseRelationRepository(q database.Querier) *SenseRelationRepository {
+	return &SenseRelationRepository{
+		Base: base.MustNewBase[model.SenseRelation](q, base.Config{
+			Table:   schema.SenseRelations.Name.String(),
+			Columns: schema.SenseRelations.Columns(),
+		}),
+	}
+}
+
+// GetByID получает связь по ID.
+func (r *SenseRelationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.SenseRelation, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.SenseRelations.ID.Bare(): id}).
+		Where(schema.ActiveSense(schema.SenseRelations.SenseID))
+	return r.GetOne(ctx, query)
+}
+
+// ListBySenseIDs получает связи для списка смыслов.
+func (r *SenseRelationRepository) ListBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]model.SenseRelation, error) {
+	if len(senseIDs) == 0 {
+		return []model.SenseRelation{}, nil
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.SenseRelations.SenseID.Bare(): senseIDs}).
+		OrderBy(schema.SenseRelations.CreatedAt.Bare(), schema.SenseRelations.ID.Bare())
+	return r.List(ctx, query)
+}
+
+// BatchCreate создает несколько связей за один запрос.
+// Связи, которые уже есть у смысла (тот же тип и нормализованный текст),
+// пропускаются: возвращаются только созданные.
+func (r *SenseRelationRepository) BatchCreate(ctx context.Context, relations []model.SenseRelation) ([]model.SenseRelation, error) {
+	// Проверяем контекст перед выполнением
+	if err := ctx.Err(); err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	if len(relations) == 0 {
+		return []model.SenseRelation{}, nil
+	}
+	if len(relations) > base.MaxBatchSize {
+		return nil, fmt.Errorf("%w: too many relations (max %d)", database.ErrInvalidInput, base.MaxBatchSize)
+	}
+
+	// Валидация всех элементов перед вставкой
+	insert := r.InsertBuilder().Columns(schema.SenseRelations.InsertColumns()...)
+	for i, rel := range relations {
+		if err := base.ValidateUUID(rel.SenseID, "sense_id"); err != nil {
+			return nil, fmt.Errorf("relation[%d]: %w", i, err)
+		}
+		if !rel.Type.IsValid() {
+			return nil, fmt.Errorf("relation[%d]: %w: unknown type %q", i, database.ErrInvalidInput, rel.Type)
+		}
+		if err := base.ValidateString(rel.TargetText, "target_text"); err != nil {
+			return nil, fmt.Errorf("relation[%d]: %w", i, err)
+		}
+		if err := base.ValidateString(rel.TargetTextNormalized, "target_text_normalized"); err != nil {
+			return nil, fmt.Errorf("relation[%d]: %w", i, err)
+		}
+		if err := base.ValidateString(rel.SourceSlug, "source_slug"); err != nil {
+			return nil, fmt.Errorf("relation[%d]: %w", i, err)
+		}
+		insert = insert.Values(rel.SenseID, rel.Type, rel.TargetText, rel.TargetTextNormalized, rel.SourceSlug)
+	}
+
+	sql, args, err := insert.
+		Suffix("ON CONFLICT (sense_id, type, target_text_normalized) DO NOTHING RETURNING *").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build insert query: %w", err)
+	}
+
+	created := make([]model.SenseRelation, 0, len(relations))
+	if err := r.QueryRaw(ctx, &created, sql, args...); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// MoveToSense переносит связи с указанными ID к другому смыслу.
+// Вызывающий отвечает за то, чтобы у смысла не было таких же связей.
+// Возвращает количество перенесённых записей.
+func (r *SenseRelationRepository) MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(senseID, "sense_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.SenseRelations.SenseID.Bare(), senseID).
+		Where(squirrel.Eq{schema.SenseRelations.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
+// Delete удаляет связь.
+func (r *SenseRelationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return err
+	}
+	return r.Base.Delete(ctx, schema.SenseRelations.ID.Bare(), id)
+}
+
+// RelationTarget — запись словаря, на которую указывает связь.
+type RelationTarget struct {
+	RelationID uuid.UUID `db:"relation_id"`
+	EntryID    uuid.UUID `db:"entry_id"`
+}
+
+// ListTargets находит записи словаря для связей: активную запись того же
+// языка, что и запись смысла, с нормализованным текстом связи.
+// Связи без такой записи в результат не попадают.
+func (r *SenseRelationRepository) ListTargets(ctx context.Context, relationIDs []uuid.UUID) ([]RelationTarget, error) {
+	if len(relationIDs) == 0 {
+		return []RelationTarget{}, nil
+	}
+
+	query := base.Builder().
+		Select("sr.id AS relation_id", "target.id AS entry_id").
+		From(schema.SenseRelations.Name.String() + " sr").
+		Join(schema.Senses.Name.String() + " s ON s.id = sr.sense_id").
+		Join(schema.DictionaryEntries.Name.String() + " de ON de.id = s.entry_id").
+		Join(schema.DictionaryEntries.Name.String() + " target" +
+			" ON target.language = de.language" +
+			" AND target.text_normalized = sr.target_text_normalized" +
+			" AND target.deleted_at IS NULL").
+		Where(squirrel.Eq{"sr.id": relationIDs})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build targets query: %w", err)
+	}
+
+	targets := make([]RelationTarget, 0, len(relationIDs))
+	if err := r.QueryRaw(ctx, &targets, sql, args...); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
 // ============================================================================
 // IMAGES REPOSITORY
 // ============================================================================
@@ -306,7 +705,10 @@ func (r *ImageRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Ima
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Images.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Images.ID.Bare(): id}).
+		Where(schema.ActiveEntry(schema.Images.EntryID))
+	return r.GetOne(ctx, query)
 }
 
 // ListByEntryIDs получает изображения для списка записей словаря.
@@ -343,12 +745,55 @@ func (r *ImageRepository) BatchCreate(ctx context.Context, images []model.Image)
 			img.URL,
 			img.Caption,
 			img.SourceSlug,
+			img.MediaID,
 		}
 	}
 
 	return r.BatchInsertReturning(ctx, columns, images, valuesFunc)
 }
 
+// Update обновляет поля изображения.
+func (r *ImageRepository) Update(ctx context.Context, id uuid.UUID, image *model.Image) (*model.Image, error) {
+	if image == nil {
+		return nil, fmt.Errorf("%w: image is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(image.URL, "url"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(image.SourceSlug, "source_slug"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set("url", image.URL).
+		Set("caption", image.Caption).
+		Set("source_slug", image.SourceSlug).
+		Set("media_id", image.MediaID).
+		Where(squirrel.Eq{schema.Images.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveToEntry переносит изображения с указанными ID к другой записи словаря.
+// Возвращает количество перенесённых записей.
+func (r *ImageRepository) MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Images.EntryID.Bare(), entryID).
+		Where(squirrel.Eq{schema.Images.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет изображение.
 func (r *ImageRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
@@ -381,7 +826,10 @@ func (r *PronunciationRepository) GetByID(ctx context.Context, id uuid.UUID) (*m
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return nil, err
 	}
-	return r.Base.GetByID(ctx, schema.Pronunciations.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.Pronunciations.ID.Bare(): id}).
+		Where(schema.ActiveEntry(schema.Pronunciations.EntryID))
+	return r.GetOne(ctx, query)
 }
 
 // ListByEntryIDs получает произношения для списка записей словаря.
@@ -416,12 +864,53 @@ func (r *PronunciationRepository) BatchCreate(ctx context.Context, pronunciation
 			p.Transcription,
 			p.Region,
 			p.SourceSlug,
+			p.MediaID,
 		}
 	}
 
 	return r.BatchInsertReturning(ctx, columns, pronunciations, valuesFunc)
 }
 
+// Update обновляет поля произношения.
+func (r *PronunciationRepository) Update(ctx context.Context, id uuid.UUID, pronunciation *model.Pronunciation) (*model.Pronunciation, error) {
+	if pronunciation == nil {
+		return nil, fmt.Errorf("%w: pronunciation is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(pronunciation.SourceSlug, "source_slug"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set("audio_url", pronunciation.AudioURL).
+		Set("transcription", pronunciation.Transcription).
+		Set("region", pronunciation.Region).
+		Set("source_slug", pronunciation.SourceSlug).
+		Set("media_id", pronunciation.MediaID).
+		Where(squirrel.Eq{schema.Pronunciations.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MoveToEntry переносит произношения с указанными ID к другой записи словаря.
+// Возвращает количество перенесённых записей.
+func (r *PronunciationRepository) MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := base.ValidateUUID(entryID, "entry_id"); err != nil {
+		return 0, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Pronunciations.EntryID.Bare(), entryID).
+		Where(squirrel.Eq{schema.Pronunciations.ID.Bare(): ids})
+
+	return r.UpdateWhere(ctx, update)
+}
+
 // Delete удаляет произношение.
 func (r *PronunciationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
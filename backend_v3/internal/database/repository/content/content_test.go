@@ -0,0 +1,247 @@
+package content
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+func TestTranslationRepository_SearchByText(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		limit     int
+		setup     func(mock pgxmock.PgxPoolIface)
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:  "trigram search for long query",
+			text:  "привет",
+			limit: 10,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "sense_id", "text", "source_slug", "similarity"}).
+					AddRow(uuid.New(), uuid.New(), "привет", "user", 1.0).
+					AddRow(uuid.New(), uuid.New(), "приветствие", "user", 0.5)
+				mock.ExpectQuery(`SELECT .+ similarity\(replace\(lower\(normalize\(translations.text, NFKC\)\), 'ё', 'е'\), \$1\) AS similarity FROM translations WHERE replace\(lower\(normalize\(translations.text, NFKC\)\), 'ё', 'е'\) % \$2 AND EXISTS \(SELECT 1 FROM senses s JOIN dictionary_entries de .+ de.deleted_at IS NULL\) ORDER BY similarity DESC`).
+					WithArgs("привет", "привет").
+					WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name:  "prefix search for short query",
+			text:  "да",
+			limit: 0,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "sense_id", "text", "source_slug", "similarity"}).
+					AddRow(uuid.New(), uuid.New(), "да", "user", 1.0)
+				mock.ExpectQuery(`FROM translations WHERE replace\(lower\(normalize\(translations.text, NFKC\)\), 'ё', 'е'\) LIKE \$2 .+ LIMIT 20`).
+					WithArgs("да", "да%").
+					WillReturnRows(rows)
+			},
+			wantCount: 1,
+		},
+		{
+			name:  "prefix search escapes like wildcards",
+			text:  "a_",
+			limit: 5,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "sense_id", "text", "source_slug", "similarity"})
+				mock.ExpectQuery(`LIKE \$2 .+ LIMIT 5`).
+					WithArgs("a_", `a\_%`).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:    "empty text",
+			text:    "",
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewTranslationRepository(querier)
+
+			tt.setup(mock)
+
+			result, err := repo.SearchByText(context.Background(), tt.text, tt.limit)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SearchByText() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && len(result) != tt.wantCount {
+				t.Errorf("SearchByText() returned %d results, want %d", len(result), tt.wantCount)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestSenseRepository_MoveToEntry(t *testing.T) {
+	entryID := uuid.New()
+
+	tests := []struct {
+		name    string
+		ids     []uuid.UUID
+		entryID uuid.UUID
+		setup   func(mock pgxmock.PgxPoolIface)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "moves senses",
+			ids:     []uuid.UUID{uuid.New(), uuid.New()},
+			entryID: entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(`UPDATE senses SET entry_id = \$1 WHERE id IN \(\$2,\$3\)`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			},
+			want: 2,
+		},
+		{
+			name:    "empty ids is a no-op",
+			ids:     nil,
+			entryID: entryID,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			want:    0,
+		},
+		{
+			name:    "zero entry id",
+			ids:     []uuid.UUID{uuid.New()},
+			entryID: uuid.UUID{},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewSenseRepository(querier)
+
+			tt.setup(mock)
+
+			got, err := repo.MoveToEntry(context.Background(), tt.ids, tt.entryID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveToEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MoveToEntry() = %d, want %d", got, tt.want)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestSenseRelationRepository_BatchCreate(t *testing.T) {
+	senseID := uuid.New()
+	relation := func(typ model.RelationType, text string) model.SenseRelation {
+		return model.SenseRelation{
+			SenseID:              senseID,
+			Type:                 typ,
+			TargetText:           text,
+			TargetTextNormalized: text,
+			SourceSlug:           "wordnet",
+		}
+	}
+	columns := []string{"id", "sense_id", "type", "target_text", "target_text_normalized", "source_slug", "created_at"}
+
+	tests := []struct {
+		name      string
+		relations []model.SenseRelation
+		setup     func(mock pgxmock.PgxPoolIface)
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "skips existing relations",
+			relations: []model.SenseRelation{relation(model.RelationSynonym, "hound"), relation(model.RelationHypernym, "canine")},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(columns).
+					AddRow(uuid.New(), senseID, model.RelationHypernym, "canine", "canine", "wordnet", time.Now())
+				mock.ExpectQuery(`INSERT INTO sense_relations \(sense_id,type,target_text,target_text_normalized,source_slug\) VALUES \(\$1,\$2,\$3,\$4,\$5\),\(\$6,\$7,\$8,\$9,\$10\) ON CONFLICT \(sense_id, type, target_text_normalized\) DO NOTHING RETURNING \*`).
+					WithArgs(senseID, model.RelationSynonym, "hound", "hound", "wordnet",
+						senseID, model.RelationHypernym, "canine", "canine", "wordnet").
+					WillReturnRows(rows)
+			},
+			wantCount: 1,
+		},
+		{
+			name:      "empty input is a no-op",
+			setup:     func(mock pgxmock.PgxPoolIface) {},
+			wantCount: 0,
+		},
+		{
+			name:      "unknown type",
+			relations: []model.SenseRelation{relation("MERONYM", "tail")},
+			setup:     func(mock pgxmock.PgxPoolIface) {},
+			wantErr:   true,
+		},
+		{
+			name:      "empty target text",
+			relations: []model.SenseRelation{relation(model.RelationSynonym, "")},
+			setup:     func(mock pgxmock.PgxPoolIface) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewSenseRelationRepository(querier)
+
+			tt.setup(mock)
+
+			got, err := repo.BatchCreate(context.Background(), tt.relations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BatchCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got) != tt.wantCount {
+				t.Errorf("BatchCreate() returned %d relations, want %d", len(got), tt.wantCount)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestSenseRelationRepository_ListTargets(t *testing.T) {
+	relationID, entryID := uuid.New(), uuid.New()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewSenseRelationRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"relation_id", "entry_id"}).AddRow(relationID, entryID)
+	mock.ExpectQuery(`SELECT sr.id AS relation_id, target.id AS entry_id FROM sense_relations sr JOIN senses s ON s.id = sr.sense_id JOIN dictionary_entries de ON de.id = s.entry_id JOIN dictionary_entries target ON target.language = de.language AND target.text_normalized = sr.target_text_normalized AND target.deleted_at IS NULL WHERE sr.id IN \(\$1,\$2\)`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(rows)
+
+	got, err := repo.ListTargets(context.Background(), []uuid.UUID{relationID, uuid.New()})
+	if err != nil {
+		t.Fatalf("ListTargets() error = %v", err)
+	}
+	if len(got) != 1 || got[0].RelationID != relationID || got[0].EntryID != entryID {
+		t.Errorf("ListTargets() = %+v", got)
+	}
+
+	// Пустой список не обращается к БД
+	if got, err := repo.ListTargets(context.Background(), nil); err != nil || len(got) != 0 {
+		t.Errorf("ListTargets(nil) = %+v, %v", got, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
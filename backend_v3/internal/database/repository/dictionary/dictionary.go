@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/Masterminds/squirrel"
@@ -30,6 +31,12 @@ const (
 	// MaxLimit — максимальный лимит для списка записей.
 	// Ограничивает потенциально тяжелые запросы.
 	MaxLimit = 1000
+
+	// DefaultDuplicateLimit — лимит по умолчанию для кандидатов на слияние.
+	DefaultDuplicateLimit = 20
+
+	// MaxDuplicateLimit — максимальный лимит для кандидатов на слияние.
+	MaxDuplicateLimit = 200
 )
 
 // ============================================================================
@@ -41,12 +48,29 @@ type DictionaryFilter struct {
 	// Search — поисковый запрос (prefix для коротких, trigram для длинных)
 	Search string
 
+	// Language — фильтр по языку слова (ISO 639)
+	Language *string
+
 	// PartOfSpeech — фильтр по части речи (через EXISTS подзапрос к senses)
 	PartOfSpeech *model.PartOfSpeech
 
 	// HasCard — фильтр по наличию карточки (true/false/nil)
 	HasCard *bool
 
+	// CefrLevels — фильтр по оценке уровня CEFR (любой из перечисленных)
+	CefrLevels []string
+
+	// MinFrequencyRank, MaxFrequencyRank — частотный диапазон (включительно).
+	// Записи без частотного ранга в диапазон не попадают.
+	MinFrequencyRank *int
+	MaxFrequencyRank *int
+
+	// Tag — только записи с этой меткой
+	Tag *string
+
+	// List — только записи из этого списка
+	List *string
+
 	// Пагинация
 	Limit  int
 	Offset int
@@ -68,6 +92,16 @@ func (f *DictionaryFilter) Normalize() {
 		f.Offset = 0
 	}
 	f.Search = strings.TrimSpace(f.Search)
+
+	// Метки хранятся в нижнем регистре, в метках и названиях списков пробелы схлопнуты
+	if f.Tag != nil {
+		tag := strings.Join(strings.Fields(strings.ToLower(*f.Tag)), " ")
+		f.Tag = &tag
+	}
+	if f.List != nil {
+		list := strings.Join(strings.Fields(*f.List), " ")
+		f.List = &list
+	}
 }
 
 // ============================================================================
@@ -94,35 +128,91 @@ func NewDictionaryRepository(q database.Querier) *DictionaryRepository {
 // ============================================================================
 
 // GetByID получает словарную запись по ID.
+// Записи из корзины не возвращаются.
 func (r *DictionaryRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
 	if base.IsZeroUUID(id) {
 		return nil, fmt.Errorf("%w: id is required", database.ErrInvalidInput)
 	}
-	return r.Base.GetByID(ctx, schema.DictionaryEntries.ID.Bare(), id)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id}).
+		Where(schema.DictionaryEntries.NotDeleted())
+	return r.GetOne(ctx, query)
 }
 
-// FindByNormalizedText находит запись по нормализованному тексту.
-func (r *DictionaryRepository) FindByNormalizedText(ctx context.Context, text string) (*model.DictionaryEntry, error) {
+// FindByNormalizedText находит активную запись языка language по нормализованному тексту.
+func (r *DictionaryRepository) FindByNormalizedText(ctx context.Context, language, text string) (*model.DictionaryEntry, error) {
 	if text == "" {
 		return nil, fmt.Errorf("%w: text is required", database.ErrInvalidInput)
 	}
-	return r.FindOneBy(ctx, schema.DictionaryEntries.TextNormalized.Bare(), text)
+	if err := base.ValidateString(language, "language"); err != nil {
+		return nil, err
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.DictionaryEntries.Language.Bare(): language}).
+		Where(squirrel.Eq{schema.DictionaryEntries.TextNormalized.Bare(): text}).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		Limit(1)
+	return r.GetOne(ctx, query)
 }
 
-// ListByIDs получает записи по списку ID.
+// ListByNormalizedTexts получает активные записи языка language
+// с нормализованным текстом из texts (пакетная проверка дубликатов).
+func (r *DictionaryRepository) ListByNormalizedTexts(ctx context.Context, language string, texts []string) ([]model.DictionaryEntry, error) {
+	if len(texts) == 0 {
+		return []model.DictionaryEntry{}, nil
+	}
+	if err := base.ValidateString(language, "language"); err != nil {
+		return nil, err
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.DictionaryEntries.Language.Bare(): language}).
+		Where(squirrel.Eq{schema.DictionaryEntries.TextNormalized.Bare(): texts}).
+		Where(schema.DictionaryEntries.NotDeleted())
+	return r.List(ctx, query)
+}
+
+// ListByIDs получает активные записи по списку ID.
 func (r *DictionaryRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.DictionaryEntry, error) {
 	if len(ids) == 0 {
 		return []model.DictionaryEntry{}, nil
 	}
-	return r.Base.ListByUUIDs(ctx, schema.DictionaryEntries.ID.Bare(), ids)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): ids}).
+		Where(schema.DictionaryEntries.NotDeleted())
+	return r.List(ctx, query)
 }
 
-// ExistsByNormalizedText проверяет существование слова по нормализованному тексту.
-func (r *DictionaryRepository) ExistsByNormalizedText(ctx context.Context, text string) (bool, error) {
+// ExistsByNormalizedText проверяет существование активного слова языка language
+// по нормализованному тексту.
+func (r *DictionaryRepository) ExistsByNormalizedText(ctx context.Context, language, text string) (bool, error) {
 	if text == "" {
 		return false, fmt.Errorf("%w: text is required", database.ErrInvalidInput)
 	}
-	return r.Exists(ctx, schema.DictionaryEntries.TextNormalized.Bare(), text)
+	if err := base.ValidateString(language, "language"); err != nil {
+		return false, err
+	}
+
+	query := base.Builder().
+		Select("1").
+		From(schema.DictionaryEntries.Name.String()).
+		Where(squirrel.Eq{schema.DictionaryEntries.Language.Bare(): language}).
+		Where(squirrel.Eq{schema.DictionaryEntries.TextNormalized.Bare(): text}).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, database.WrapDBError(err)
+	}
+
+	var exists int
+	if err := r.Q().QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, database.WrapDBError(err)
+	}
+	return true, nil
 }
 
 // ============================================================================
@@ -154,6 +244,36 @@ func (r *DictionaryRepository) Find(ctx context.Context, f DictionaryFilter) ([]
 	return r.List(ctx, b)
 }
 
+// FindIDs возвращает ID активных записей по фильтру (без пагинации, не больше limit),
+// начиная с самых старых. Используется массовыми операциями.
+func (r *DictionaryRepository) FindIDs(ctx context.Context, f DictionaryFilter, limit int) ([]uuid.UUID, error) {
+	if limit <= 0 {
+		return []uuid.UUID{}, nil
+	}
+	f.Normalize()
+
+	b := base.Builder().
+		Select(schema.DictionaryEntries.ID.Bare()).
+		From(schema.DictionaryEntries.Name.String())
+	b, err := r.applyFilters(b, f)
+	if err != nil {
+		return nil, err
+	}
+	b = b.OrderBy(schema.DictionaryEntries.CreatedAt.Asc(), schema.DictionaryEntries.ID.Asc()).
+		Limit(uint64(limit))
+
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	var ids []uuid.UUID
+	if err := r.QueryRaw(ctx, &ids, sql, args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // CountTotal возвращает общее количество записей по фильтру (без пагинации).
 func (r *DictionaryRepository) CountTotal(ctx context.Context, f DictionaryFilter) (int64, error) {
 	f.Normalize()
@@ -178,6 +298,96 @@ func (r *DictionaryRepository) CountTotal(ctx context.Context, f DictionaryFilte
 	return count, nil
 }
 
+// FindPage возвращает страницу записей для keyset-пагинации: f.Limit записей
+// строго после курсора after (nil — с начала) и флаг наличия следующей страницы.
+// Offset игнорируется.
+//
+// Сортировка по релевантности поиска не выражается курсором,
+// поэтому без явного SortBy используется CREATED_AT DESC.
+func (r *DictionaryRepository) FindPage(ctx context.Context, f DictionaryFilter, after *base.Cursor) ([]model.DictionaryEntry, bool, error) {
+	f.Normalize()
+
+	field, dir := pageSort(f)
+	desc := dir == model.SortDirDesc
+	sortCol := sortColumn(field)
+	idCol := schema.DictionaryEntries.ID.Bare()
+
+	b, err := r.applyFilters(r.SelectBuilder(), f)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if after != nil {
+		if after.Key != string(field) {
+			return nil, false, fmt.Errorf("%w: cursor does not match sort field", database.ErrInvalidInput)
+		}
+		if after.Dir != string(dir) {
+			return nil, false, fmt.Errorf("%w: cursor does not match sort direction", database.ErrInvalidInput)
+		}
+		var value any = after.Value
+		if field != model.SortFieldText {
+			if value, err = after.Time(); err != nil {
+				return nil, false, err
+			}
+		}
+		b = b.Where(base.KeysetAfter(sortCol, idCol, desc, value, after.ID))
+	}
+
+	direction := " " + string(dir)
+	// Запрашиваем на одну запись больше, чтобы узнать о следующей странице
+	b = b.OrderBy(sortCol+direction, idCol+direction).Limit(uint64(f.Limit + 1))
+
+	entries, err := r.List(ctx, b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(entries) > f.Limit
+	if hasNext {
+		entries = entries[:f.Limit]
+	}
+	return entries, hasNext, nil
+}
+
+// EntryCursor возвращает курсор записи для сортировки фильтра f (см. FindPage).
+func EntryCursor(entry model.DictionaryEntry, f DictionaryFilter) base.Cursor {
+	field, dir := pageSort(f)
+	var c base.Cursor
+	switch field {
+	case model.SortFieldText:
+		c = base.Cursor{Key: string(field), Value: entry.Text, ID: entry.ID}
+	case model.SortFieldUpdatedAt:
+		c = base.NewTimeCursor(string(field), entry.UpdatedAt, entry.ID)
+	default:
+		c = base.NewTimeCursor(string(field), entry.CreatedAt, entry.ID)
+	}
+	c.Dir = string(dir)
+	return c
+}
+
+// pageSort возвращает поле и направление сортировки для keyset-пагинации.
+func pageSort(f DictionaryFilter) (model.WordSortField, model.SortDirection) {
+	if f.SortBy == nil {
+		return model.SortFieldCreatedAt, model.SortDirDesc
+	}
+	if f.SortDir != nil && *f.SortDir == model.SortDirDesc {
+		return *f.SortBy, model.SortDirDesc
+	}
+	return *f.SortBy, model.SortDirAsc
+}
+
+// sortColumn возвращает колонку для поля сортировки.
+func sortColumn(field model.WordSortField) string {
+	switch field {
+	case model.SortFieldText:
+		return schema.DictionaryEntries.Text.Bare()
+	case model.SortFieldUpdatedAt:
+		return schema.DictionaryEntries.UpdatedAt.Bare()
+	default:
+		return schema.DictionaryEntries.CreatedAt.Bare()
+	}
+}
+
 // applyFilters добавляет условия WHERE к билдеру.
 //
 // Производительность:
@@ -185,13 +395,21 @@ func (r *DictionaryRepository) CountTotal(ctx context.Context, f DictionaryFilte
 //   - HasCard: требует индекса на cards(entry_id)
 //   - Search: требует GIN индекса на text_normalized для триграмм
 func (r *DictionaryRepository) applyFilters(b squirrel.SelectBuilder, f DictionaryFilter) (squirrel.SelectBuilder, error) {
+	// 0. Записи из корзины никогда не попадают в выдачу
+	b = b.Where(schema.DictionaryEntries.NotDeleted())
+
+	// Слова другого языка в выдачу не попадают
+	if f.Language != nil {
+		b = b.Where(squirrel.Eq{schema.DictionaryEntries.Language.Bare(): *f.Language})
+	}
+
 	// 1. Фильтр по PartOfSpeech (через подзапрос EXISTS)
 	// Оптимизация: EXISTS обычно быстрее JOIN для проверки наличия
 	if f.PartOfSpeech != nil {
 		// Используем корреляционный подзапрос с параметризованным запросом
 		// ВАЖНО: используем параметризацию для безопасности от SQL injection
 		b = b.Where(squirrel.Expr(
-			fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.entry_id = dictionary_entries.id AND s.part_of_speech = $1)",
+			fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.entry_id = dictionary_entries.id AND s.part_of_speech = ?)",
 				schema.Senses.Name.String()),
 			*f.PartOfSpeech,
 		))
@@ -211,7 +429,27 @@ func (r *DictionaryRepository) applyFilters(b squirrel.SelectBuilder, f Dictiona
 		}
 	}
 
-	// 3. Поиск (Prefix для коротких слов, Trigram для длинных)
+	// 3. Фильтры по уровню CEFR и частотному диапазону
+	// Оптимизация: частичные индексы на cefr_level и frequency_rank
+	if len(f.CefrLevels) > 0 {
+		b = b.Where(squirrel.Eq{schema.DictionaryEntries.CefrLevel.Bare(): f.CefrLevels})
+	}
+	if f.MinFrequencyRank != nil {
+		b = b.Where(squirrel.GtOrEq{schema.DictionaryEntries.FrequencyRank.Bare(): *f.MinFrequencyRank})
+	}
+	if f.MaxFrequencyRank != nil {
+		b = b.Where(squirrel.LtOrEq{schema.DictionaryEntries.FrequencyRank.Bare(): *f.MaxFrequencyRank})
+	}
+
+	// 4. Метка и список (индексы ix_dictionary_entries_tags и ix_dictionary_entries_list)
+	if f.Tag != nil {
+		b = b.Where(squirrel.Expr(schema.DictionaryEntries.Tags.Bare()+" @> ?", []string{*f.Tag}))
+	}
+	if f.List != nil {
+		b = b.Where(squirrel.Eq{schema.DictionaryEntries.List.Bare(): *f.List})
+	}
+
+	// 5. Поиск (Prefix для коротких слов, Trigram и полнотекстовый поиск по заметкам для длинных)
 	if f.Search != "" {
 		textCol := schema.DictionaryEntries.Text.Bare()
 		queryLen := utf8.RuneCountInString(f.Search)
@@ -227,13 +465,28 @@ func (r *DictionaryRepository) applyFilters(b squirrel.SelectBuilder, f Dictiona
 			// Требует расширения: CREATE EXTENSION IF NOT EXISTS pg_trgm;
 			// Рекомендуется GIN индекс: CREATE INDEX idx_text_trgm ON dictionary_entries USING GIN(text gin_trgm_ops);
 			// Используем ? вместо $1, чтобы squirrel автоматически нумеровал параметры
-			b = b.Where(squirrel.Expr(textCol+" % ?", f.Search))
+			// Заметки слова и его смыслов ищутся полнотекстово (индексы *_notes_fts)
+			b = b.Where(squirrel.Or{
+				squirrel.Expr(textCol+" % ?", f.Search),
+				squirrel.Expr(notesMatch(schema.DictionaryEntries.Notes.Bare()), f.Search),
+				squirrel.Expr(
+					fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.entry_id = dictionary_entries.id AND %s)",
+						schema.Senses.Name.String(), notesMatch("s.notes")),
+					f.Search,
+				),
+			})
 		}
 	}
 
 	return b, nil
 }
 
+// notesMatch возвращает условие полнотекстового совпадения колонки заметок
+// с поисковым запросом (один плейсхолдер). Выражение совпадает с индексами *_notes_fts.
+func notesMatch(col string) string {
+	return fmt.Sprintf("to_tsvector('simple', coalesce(%s, '')) @@ plainto_tsquery('simple', ?)", col)
+}
+
 // applySorting применяет сортировку к запросу.
 func (r *DictionaryRepository) applySorting(b squirrel.SelectBuilder, f DictionaryFilter) squirrel.SelectBuilder {
 	textCol := schema.DictionaryEntries.Text.Bare()
@@ -273,6 +526,87 @@ func (r *DictionaryRepository) applySorting(b squirrel.SelectBuilder, f Dictiona
 	return b.OrderBy(schema.DictionaryEntries.CreatedAt.Bare() + " DESC")
 }
 
+// ============================================================================
+// DUPLICATES
+// ============================================================================
+
+// DuplicatePair — пара активных записей с похожим нормализованным текстом.
+// EntryID всегда меньше DuplicateID, поэтому каждая пара возвращается один раз.
+type DuplicatePair struct {
+	EntryID     uuid.UUID `db:"entry_id"`
+	DuplicateID uuid.UUID `db:"duplicate_id"`
+	Similarity  float64   `db:"similarity"`
+}
+
+// FindDuplicatePairs ищет пары активных записей одного языка, похожих по text_normalized (pg_trgm).
+// Пары сортируются по убыванию похожести.
+//
+// Оператор % отсекает пары ниже pg_trgm.similarity_threshold (по умолчанию 0.3)
+// и позволяет использовать индекс ix_dictionary_entries_text_norm_trgm,
+// поэтому minSimilarity ниже этого порога не расширяет выдачу.
+func (r *DictionaryRepository) FindDuplicatePairs(ctx context.Context, minSimilarity float64, limit int) ([]DuplicatePair, error) {
+	if minSimilarity <= 0 || minSimilarity > 1 {
+		return nil, fmt.Errorf("%w: min similarity must be in (0, 1]", database.ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = DefaultDuplicateLimit
+	}
+	if limit > MaxDuplicateLimit {
+		limit = MaxDuplicateLimit
+	}
+
+	sql := `
+		SELECT
+			a.id AS entry_id,
+			b.id AS duplicate_id,
+			similarity(a.text_normalized, b.text_normalized) AS similarity
+		FROM dictionary_entries a
+		JOIN dictionary_entries b
+			ON a.id < b.id
+			AND a.language = b.language
+			AND a.text_normalized % b.text_normalized
+		WHERE a.deleted_at IS NULL
+			AND b.deleted_at IS NULL
+			AND similarity(a.text_normalized, b.text_normalized) >= $1
+		ORDER BY similarity DESC, a.text_normalized ASC, b.text_normalized ASC
+		LIMIT $2
+	`
+
+	pairs := make([]DuplicatePair, 0)
+	if err := r.QueryRaw(ctx, &pairs, sql, minSimilarity, limit); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// WordList — список слов и количество активных записей в нём.
+type WordList struct {
+	Name      string `db:"name"`
+	WordCount int    `db:"word_count"`
+}
+
+// ListWordLists возвращает непустые списки слов по алфавиту.
+func (r *DictionaryRepository) ListWordLists(ctx context.Context) ([]WordList, error) {
+	listCol := schema.DictionaryEntries.List.Bare()
+	sql, args, err := base.Builder().
+		Select(listCol+" AS name", "COUNT(*) AS word_count").
+		From(schema.DictionaryEntries.Name.String()).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		Where(squirrel.NotEq{listCol: nil}).
+		GroupBy(listCol).
+		OrderBy(listCol + " ASC").
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	lists := make([]WordList, 0)
+	if err := r.QueryRaw(ctx, &lists, sql, args...); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
 // ============================================================================
 // WRITE OPERATIONS
 // ============================================================================
@@ -280,8 +614,8 @@ func (r *DictionaryRepository) applySorting(b squirrel.SelectBuilder, f Dictiona
 // Create создает новое слово.
 //
 // Возвращает:
-//   - ErrDuplicate: если слово с таким text_normalized уже существует
-//   - ErrInvalidInput: если text или text_normalized пусты
+//   - ErrDuplicate: если слово с таким text_normalized уже существует в языке
+//   - ErrInvalidInput: если text, text_normalized или language пусты
 func (r *DictionaryRepository) Create(ctx context.Context, entry *model.DictionaryEntry) (*model.DictionaryEntry, error) {
 	if entry == nil {
 		return nil, fmt.Errorf("%w: entry is required", database.ErrInvalidInput)
@@ -292,21 +626,24 @@ func (r *DictionaryRepository) Create(ctx context.Context, entry *model.Dictiona
 	if err := base.ValidateString(entry.TextNormalized, "text_normalized"); err != nil {
 		return nil, err
 	}
+	if err := base.ValidateString(entry.Language, "language"); err != nil {
+		return nil, err
+	}
 
 	insert := r.InsertBuilder().
 		Columns(schema.DictionaryEntries.InsertColumns()...).
-		Values(entry.Text, entry.TextNormalized)
+		Values(entry.Text, entry.TextNormalized, entry.Language, entry.FrequencyRank, entry.CefrLevel, entry.Notes, entry.NotesOnCard)
 
 	return r.InsertReturning(ctx, insert)
 }
 
-// CreateOrGet создает новое слово или возвращает существующее по text_normalized.
+// CreateOrGet создает новое слово или возвращает существующее активное по (language, text_normalized).
 //
 // Использует атомарную операцию INSERT ... ON CONFLICT для предотвращения race condition.
 // Это идемпотентная операция — безопасна для повторных вызовов.
 //
 // Производительность:
-//   - Требует UNIQUE индекса на text_normalized
+//   - Требует частичного UNIQUE индекса на (language, text_normalized) WHERE deleted_at IS NULL
 //   - Оптимизирован для конкурентных вставок
 //   - Использует минимальное обновление для минимизации блокировок
 func (r *DictionaryRepository) CreateOrGet(ctx context.Context, entry *model.DictionaryEntry) (*model.DictionaryEntry, error) {
@@ -324,14 +661,17 @@ func (r *DictionaryRepository) CreateOrGet(ctx context.Context, entry *model.Dic
 	if err := base.ValidateString(entry.TextNormalized, "text_normalized"); err != nil {
 		return nil, err
 	}
+	if err := base.ValidateString(entry.Language, "language"); err != nil {
+		return nil, err
+	}
 
 	// ON CONFLICT ... DO UPDATE SET id = EXCLUDED.id гарантирует возврат записи
 	// Используем минимальное обновление (id = id) чтобы сработал RETURNING
 	// Это минимизирует блокировки и overhead при конфликтах
 	insert := r.InsertBuilder().
 		Columns(schema.DictionaryEntries.InsertColumns()...).
-		Values(entry.Text, entry.TextNormalized).
-		Suffix("ON CONFLICT (text_normalized) DO UPDATE SET id = dictionary_entries.id RETURNING *")
+		Values(entry.Text, entry.TextNormalized, entry.Language, entry.FrequencyRank, entry.CefrLevel, entry.Notes, entry.NotesOnCard).
+		Suffix("ON CONFLICT (language, text_normalized) WHERE deleted_at IS NULL DO UPDATE SET id = dictionary_entries.id RETURNING *")
 
 	sql, args, err := insert.ToSql()
 	if err != nil {
@@ -347,7 +687,7 @@ func (r *DictionaryRepository) CreateOrGet(ctx context.Context, entry *model.Dic
 	return &result, nil
 }
 
-// Update обновляет словарную запись.
+// Update обновляет активную словарную запись.
 //
 // Возвращает:
 //   - ErrNotFound: если запись не найдена
@@ -365,20 +705,225 @@ func (r *DictionaryRepository) Update(ctx context.Context, id uuid.UUID, entry *
 	if err := base.ValidateString(entry.TextNormalized, "text_normalized"); err != nil {
 		return nil, err
 	}
+	if err := base.ValidateString(entry.Language, "language"); err != nil {
+		return nil, err
+	}
 
 	update := r.UpdateBuilder().
 		Set("text", entry.Text).
 		Set("text_normalized", entry.TextNormalized).
-		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id})
+		Set("language", entry.Language).
+		Set("frequency_rank", entry.FrequencyRank).
+		Set("cefr_level", entry.CefrLevel).
+		Set("notes", entry.Notes).
+		Set("notes_on_card", entry.NotesOnCard).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id}).
+		Where(schema.DictionaryEntries.NotDeleted())
 
 	return r.Base.Update(ctx, update)
 }
 
-// Delete удаляет словарную запись.
-// CASCADE удалит связанные senses, examples, translations и т.д.
+// Delete окончательно удаляет словарную запись (в том числе из корзины).
+// CASCADE удалит связанные senses, examples, translations, cards и review_logs.
 func (r *DictionaryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := base.ValidateUUID(id, "id"); err != nil {
 		return err
 	}
 	return r.Base.Delete(ctx, schema.DictionaryEntries.ID.Bare(), id)
 }
+
+// ============================================================================
+// TRASH OPERATIONS
+// ============================================================================
+
+// SoftDelete помещает активную запись в корзину, проставляя deleted_at.
+// Связанные данные (senses, cards, review_logs) сохраняются.
+//
+// Возвращает:
+//   - ErrNotFound: если активная запись не найдена
+func (r *DictionaryRepository) SoftDelete(ctx context.Context, id uuid.UUID, at time.Time) (*model.DictionaryEntry, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.DictionaryEntries.DeletedAt.Bare(), at).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id}).
+		Where(schema.DictionaryEntries.NotDeleted())
+
+	return r.Base.Update(ctx, update)
+}
+
+// SoftDeleteMany помещает активные записи с указанными ID в корзину.
+// Уже удалённые и несуществующие ID пропускаются.
+// Возвращает записи, попавшие в корзину.
+func (r *DictionaryRepository) SoftDeleteMany(ctx context.Context, ids []uuid.UUID, at time.Time) ([]model.DictionaryEntry, error) {
+	if len(ids) == 0 {
+		return []model.DictionaryEntry{}, nil
+	}
+
+	sql, args, err := r.UpdateBuilder().
+		Set(schema.DictionaryEntries.DeletedAt.Bare(), at).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): ids}).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		Suffix("RETURNING *").
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	entries := make([]model.DictionaryEntry, 0, len(ids))
+	if err := r.QueryRaw(ctx, &entries, sql, args...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UpdateTags добавляет метки add и снимает метки remove у активных записей ids.
+// Метки сохраняются отсортированными и без повторов; метка из обоих списков снимается.
+// Возвращает только записи, метки которых изменились.
+func (r *DictionaryRepository) UpdateTags(ctx context.Context, ids []uuid.UUID, add, remove []string) ([]model.DictionaryEntry, error) {
+	if len(ids) == 0 {
+		return []model.DictionaryEntry{}, nil
+	}
+	if add == nil {
+		add = []string{}
+	}
+	if remove == nil {
+		remove = []string{}
+	}
+
+	tagsCol := schema.DictionaryEntries.Tags.Bare()
+	sql, args, err := r.UpdateBuilder().
+		Set(tagsCol, squirrel.Expr(
+			"ARRAY(SELECT DISTINCT t FROM unnest("+tagsCol+" || ?::text[]) AS t WHERE t <> ALL(?::text[]) ORDER BY t)",
+			add, remove,
+		)).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): ids}).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		// Пропускаем записи, у которых уже есть все метки add и нет ни одной из remove
+		Where(squirrel.Expr("(NOT "+tagsCol+" @> ?::text[] OR "+tagsCol+" && ?::text[])", add, remove)).
+		Suffix("RETURNING *").
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	entries := make([]model.DictionaryEntry, 0, len(ids))
+	if err := r.QueryRaw(ctx, &entries, sql, args...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SetList переносит активные записи ids в список list; nil убирает их из списков.
+// Возвращает только записи, список которых изменился.
+func (r *DictionaryRepository) SetList(ctx context.Context, ids []uuid.UUID, list *string) ([]model.DictionaryEntry, error) {
+	if len(ids) == 0 {
+		return []model.DictionaryEntry{}, nil
+	}
+
+	listCol := schema.DictionaryEntries.List.Bare()
+	sql, args, err := r.UpdateBuilder().
+		Set(listCol, list).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): ids}).
+		Where(schema.DictionaryEntries.NotDeleted()).
+		Where(squirrel.Expr(listCol+" IS DISTINCT FROM ?::text", list)).
+		Suffix("RETURNING *").
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	entries := make([]model.DictionaryEntry, 0, len(ids))
+	if err := r.QueryRaw(ctx, &entries, sql, args...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Restore возвращает запись из корзины.
+//
+// Возвращает:
+//   - ErrNotFound: если запись не найдена в корзине
+//   - ErrDuplicate: если за время нахождения в корзине создано активное слово с тем же текстом
+func (r *DictionaryRepository) Restore(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.DictionaryEntries.DeletedAt.Bare(), nil).
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id}).
+		Where(schema.DictionaryEntries.DeletedAt.IsNotNull())
+
+	return r.Base.Update(ctx, update)
+}
+
+// GetDeletedByID получает запись из корзины по ID.
+func (r *DictionaryRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.DictionaryEntries.ID.Bare(): id}).
+		Where(schema.DictionaryEntries.DeletedAt.IsNotNull())
+	return r.GetOne(ctx, query)
+}
+
+// ListDeleted возвращает содержимое корзины: сначала недавно удалённые.
+func (r *DictionaryRepository) ListDeleted(ctx context.Context, limit, offset int) ([]model.DictionaryEntry, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	query := r.SelectBuilder().
+		Where(schema.DictionaryEntries.DeletedAt.IsNotNull()).
+		OrderBy(schema.DictionaryEntries.DeletedAt.Bare()+" DESC", schema.DictionaryEntries.ID.Bare()+" ASC").
+		Limit(uint64(limit))
+	if offset > 0 {
+		query = query.Offset(uint64(offset))
+	}
+
+	return r.List(ctx, query)
+}
+
+// CountDeleted возвращает количество записей в корзине.
+func (r *DictionaryRepository) CountDeleted(ctx context.Context) (int64, error) {
+	query := base.Builder().
+		Select("COUNT(*)").
+		From(schema.DictionaryEntries.Name.String()).
+		Where(schema.DictionaryEntries.DeletedAt.IsNotNull())
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, database.WrapDBError(err)
+	}
+
+	var count int64
+	if err := r.Q().QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.WrapDBError(err)
+	}
+	return count, nil
+}
+
+// PurgeDeletedBefore окончательно удаляет записи, попавшие в корзину раньше cutoff.
+// Возвращает ID удалённых записей.
+func (r *DictionaryRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	sql, args, err := r.DeleteBuilder().
+		Where(squirrel.Lt{schema.DictionaryEntries.DeletedAt.Bare(): cutoff}).
+		Suffix("RETURNING " + schema.DictionaryEntries.ID.Bare()).
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	ids := []uuid.UUID{}
+	if err := r.QueryRaw(ctx, &ids, sql, args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
 	"github.com/heartmarshall/my-english/internal/database/testutil"
 	"github.com/heartmarshall/my-english/internal/model"
 	"github.com/jackc/pgx/v5"
@@ -25,6 +26,7 @@ func TestDictionaryRepository_Create(t *testing.T) {
 			entry: &model.DictionaryEntry{
 				Text:           "Hello",
 				TextNormalized: "hello",
+				Language:       "en",
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				entryID := uuid.New()
@@ -32,7 +34,7 @@ func TestDictionaryRepository_Create(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
 					AddRow(entryID, "Hello", "hello", now, now)
 				mock.ExpectQuery(`INSERT INTO dictionary_entries`).
-					WithArgs("Hello", "hello").
+					WithArgs("Hello", "hello", "en", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -61,6 +63,15 @@ func TestDictionaryRepository_Create(t *testing.T) {
 			setup:   func(mock pgxmock.PgxPoolIface) {},
 			wantErr: true,
 		},
+		{
+			name: "empty language",
+			entry: &model.DictionaryEntry{
+				Text:           "Hello",
+				TextNormalized: "hello",
+			},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,6 +110,7 @@ func TestDictionaryRepository_CreateOrGet(t *testing.T) {
 			entry: &model.DictionaryEntry{
 				Text:           "Hello",
 				TextNormalized: "hello",
+				Language:       "en",
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				entryID := uuid.New()
@@ -106,7 +118,7 @@ func TestDictionaryRepository_CreateOrGet(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
 					AddRow(entryID, "Hello", "hello", now, now)
 				mock.ExpectQuery(`INSERT INTO dictionary_entries`).
-					WithArgs("Hello", "hello").
+					WithArgs("Hello", "hello", "en", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -116,6 +128,7 @@ func TestDictionaryRepository_CreateOrGet(t *testing.T) {
 			entry: &model.DictionaryEntry{
 				Text:           "World",
 				TextNormalized: "world",
+				Language:       "en",
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				entryID := uuid.New()
@@ -123,7 +136,7 @@ func TestDictionaryRepository_CreateOrGet(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
 					AddRow(entryID, "World", "world", now, now)
 				mock.ExpectQuery(`INSERT INTO dictionary_entries`).
-					WithArgs("World", "world").
+					WithArgs("World", "world", "en", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -235,12 +248,13 @@ func TestDictionaryRepository_Update(t *testing.T) {
 			entry: &model.DictionaryEntry{
 				Text:           "Hello Updated",
 				TextNormalized: "hello updated",
+				Language:       "en",
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
 					AddRow(entryID, "Hello Updated", "hello updated", now, now)
 				mock.ExpectQuery(`UPDATE dictionary_entries`).
-					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -251,10 +265,11 @@ func TestDictionaryRepository_Update(t *testing.T) {
 			entry: &model.DictionaryEntry{
 				Text:           "Hello",
 				TextNormalized: "hello",
+				Language:       "en",
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery(`UPDATE dictionary_entries`).
-					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 					WillReturnError(pgx.ErrNoRows)
 			},
 			wantErr: true,
@@ -262,7 +277,7 @@ func TestDictionaryRepository_Update(t *testing.T) {
 		{
 			name:    "zero uuid",
 			id:      uuid.UUID{},
-			entry:   &model.DictionaryEntry{Text: "Hello", TextNormalized: "hello"},
+			entry:   &model.DictionaryEntry{Text: "Hello", TextNormalized: "hello", Language: "en"},
 			setup:   func(mock pgxmock.PgxPoolIface) {},
 			wantErr: true,
 		},
@@ -348,6 +363,276 @@ func TestDictionaryRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestDictionaryRepository_SoftDelete(t *testing.T) {
+	entryID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "successful soft delete",
+			id:   entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at", "deleted_at"}).
+					AddRow(entryID, "Hello", "hello", now, now, &now)
+				mock.ExpectQuery(`UPDATE dictionary_entries SET deleted_at = \$1 WHERE id = \$2 AND deleted_at IS NULL`).
+					WithArgs(now, pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "already in trash",
+			id:   entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`UPDATE dictionary_entries`).
+					WithArgs(now, pgxmock.AnyArg()).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "zero uuid",
+			id:      uuid.UUID{},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewDictionaryRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			result, err := repo.SoftDelete(ctx, tt.id, now)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SoftDelete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && result.DeletedAt == nil {
+				t.Error("SoftDelete() returned entry without deleted_at")
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestDictionaryRepository_SoftDeleteMany(t *testing.T) {
+	now := time.Now()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	// Второй ID уже в корзине и не возвращается
+	rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at", "deleted_at"}).
+		AddRow(ids[0], "Hello", "hello", now, now, &now)
+	mock.ExpectQuery(`UPDATE dictionary_entries SET deleted_at = \$1 WHERE id IN \(\$2,\$3\) AND deleted_at IS NULL RETURNING \*`).
+		WithArgs(now, ids[0], ids[1]).
+		WillReturnRows(rows)
+
+	trashed, err := repo.SoftDeleteMany(context.Background(), ids, now)
+	if err != nil {
+		t.Fatalf("SoftDeleteMany() error = %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != ids[0] {
+		t.Errorf("SoftDeleteMany() = %+v, want only %s", trashed, ids[0])
+	}
+
+	// Пустой список — без запроса
+	if trashed, err := repo.SoftDeleteMany(context.Background(), nil, now); err != nil || len(trashed) != 0 {
+		t.Errorf("SoftDeleteMany(nil) = %v, %v", trashed, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestDictionaryRepository_UpdateTags(t *testing.T) {
+	now := time.Now()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	add := []string{"travel"}
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	// У второй записи метка уже есть: она не возвращается
+	rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "tags", "created_at", "updated_at", "deleted_at"}).
+		AddRow(ids[0], "Hello", "hello", []string{"travel"}, now, now, nil)
+	mock.ExpectQuery(`UPDATE dictionary_entries SET tags = ARRAY\(SELECT DISTINCT t FROM unnest\(tags \|\| \$1::text\[\]\) AS t WHERE t <> ALL\(\$2::text\[\]\) ORDER BY t\) WHERE id IN \(\$3,\$4\) AND deleted_at IS NULL AND \(NOT tags @> \$5::text\[\] OR tags && \$6::text\[\]\) RETURNING \*`).
+		WithArgs(add, []string{}, ids[0], ids[1], add, []string{}).
+		WillReturnRows(rows)
+
+	updated, err := repo.UpdateTags(context.Background(), ids, add, nil)
+	if err != nil {
+		t.Fatalf("UpdateTags() error = %v", err)
+	}
+	if len(updated) != 1 || updated[0].ID != ids[0] || len(updated[0].Tags) != 1 {
+		t.Errorf("UpdateTags() = %+v, want only %s", updated, ids[0])
+	}
+
+	// Пустой список — без запроса
+	if updated, err := repo.UpdateTags(context.Background(), nil, add, nil); err != nil || len(updated) != 0 {
+		t.Errorf("UpdateTags(nil) = %v, %v", updated, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestDictionaryRepository_SetList(t *testing.T) {
+	now := time.Now()
+	ids := []uuid.UUID{uuid.New()}
+	list := "Travel"
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "list", "created_at", "updated_at", "deleted_at"}).
+		AddRow(ids[0], "Hello", "hello", &list, now, now, nil)
+	mock.ExpectQuery(`UPDATE dictionary_entries SET list = \$1 WHERE id IN \(\$2\) AND deleted_at IS NULL AND list IS DISTINCT FROM \$3::text RETURNING \*`).
+		WithArgs(&list, ids[0], &list).
+		WillReturnRows(rows)
+
+	updated, err := repo.SetList(context.Background(), ids, &list)
+	if err != nil {
+		t.Fatalf("SetList() error = %v", err)
+	}
+	if len(updated) != 1 || updated[0].List == nil || *updated[0].List != list {
+		t.Errorf("SetList() = %+v, want list %q", updated, list)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestDictionaryRepository_ListWordLists(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"name", "word_count"}).
+		AddRow("Kitchen", 3).
+		AddRow("Travel", 12)
+	mock.ExpectQuery(`SELECT list AS name, COUNT\(\*\) AS word_count FROM dictionary_entries WHERE .*deleted_at IS NULL AND list IS NOT NULL GROUP BY list ORDER BY list ASC`).
+		WillReturnRows(rows)
+
+	lists, err := repo.ListWordLists(context.Background())
+	if err != nil {
+		t.Fatalf("ListWordLists() error = %v", err)
+	}
+	if len(lists) != 2 || lists[1].Name != "Travel" || lists[1].WordCount != 12 {
+		t.Errorf("ListWordLists() = %+v", lists)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestDictionaryRepository_FindIDs(t *testing.T) {
+	language := "de"
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1])
+	mock.ExpectQuery(`SELECT id FROM dictionary_entries WHERE .*deleted_at IS NULL AND language = \$1 ORDER BY dictionary_entries.created_at ASC, dictionary_entries.id ASC LIMIT 11`).
+		WithArgs(language).
+		WillReturnRows(rows)
+
+	got, err := repo.FindIDs(context.Background(), DictionaryFilter{Language: &language, Limit: 2, Offset: 5}, 11)
+	if err != nil {
+		t.Fatalf("FindIDs() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != ids[0] {
+		t.Errorf("FindIDs() = %v, want %v", got, ids)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestDictionaryRepository_Restore(t *testing.T) {
+	entryID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "successful restore",
+			id:   entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at", "deleted_at"}).
+					AddRow(entryID, "Hello", "hello", now, now, nil)
+				mock.ExpectQuery(`UPDATE dictionary_entries SET deleted_at = \$1 WHERE id = \$2 AND dictionary_entries.deleted_at IS NOT NULL`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not in trash",
+			id:   entryID,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`UPDATE dictionary_entries`).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewDictionaryRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			_, err := repo.Restore(ctx, tt.id)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestDictionaryRepository_PurgeDeletedBefore(t *testing.T) {
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	rows := pgxmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]).AddRow(ids[2])
+	mock.ExpectQuery(`DELETE FROM dictionary_entries WHERE deleted_at < \$1 RETURNING id`).
+		WithArgs(cutoff).
+		WillReturnRows(rows)
+
+	purged, err := repo.PurgeDeletedBefore(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("PurgeDeletedBefore() error = %v", err)
+	}
+	if len(purged) != 3 || purged[0] != ids[0] {
+		t.Errorf("PurgeDeletedBefore() = %v, want %v", purged, ids)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
 func TestDictionaryRepository_ExistsByNormalizedText(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -362,7 +647,7 @@ func TestDictionaryRepository_ExistsByNormalizedText(t *testing.T) {
 			setup: func(mock pgxmock.PgxPoolIface) {
 				rows := pgxmock.NewRows([]string{"1"}).AddRow(1)
 				mock.ExpectQuery(`SELECT`).
-					WithArgs("hello").
+					WithArgs("en", "hello").
 					WillReturnRows(rows)
 			},
 			want:    true,
@@ -373,7 +658,7 @@ func TestDictionaryRepository_ExistsByNormalizedText(t *testing.T) {
 			text: "world",
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery(`SELECT`).
-					WithArgs("world").
+					WithArgs("en", "world").
 					WillReturnError(pgx.ErrNoRows)
 			},
 			want:    false,
@@ -396,7 +681,7 @@ func TestDictionaryRepository_ExistsByNormalizedText(t *testing.T) {
 			tt.setup(mock)
 
 			ctx := context.Background()
-			got, err := repo.ExistsByNormalizedText(ctx, tt.text)
+			got, err := repo.ExistsByNormalizedText(ctx, "en", tt.text)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExistsByNormalizedText() error = %v, wantErr %v", err, tt.wantErr)
@@ -429,7 +714,7 @@ func TestDictionaryRepository_FindByNormalizedText(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
 					AddRow(entryID, "Hello", "hello", now, now)
 				mock.ExpectQuery(`SELECT`).
-					WithArgs("hello").
+					WithArgs("en", "hello").
 					WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -439,7 +724,7 @@ func TestDictionaryRepository_FindByNormalizedText(t *testing.T) {
 			text: "world",
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery(`SELECT`).
-					WithArgs("world").
+					WithArgs("en", "world").
 					WillReturnError(pgx.ErrNoRows)
 			},
 			wantErr: true,
@@ -460,7 +745,7 @@ func TestDictionaryRepository_FindByNormalizedText(t *testing.T) {
 			tt.setup(mock)
 
 			ctx := context.Background()
-			result, err := repo.FindByNormalizedText(ctx, tt.text)
+			result, err := repo.FindByNormalizedText(ctx, "en", tt.text)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FindByNormalizedText() error = %v, wantErr %v", err, tt.wantErr)
@@ -480,7 +765,40 @@ func TestDictionaryRepository_FindByNormalizedText(t *testing.T) {
 	}
 }
 
+func TestDictionaryRepository_ListByNormalizedTexts(t *testing.T) {
+	now := time.Now()
+	entryID := uuid.New()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewDictionaryRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
+		AddRow(entryID, "Hello", "hello", now, now)
+	mock.ExpectQuery(`SELECT .+ FROM dictionary_entries WHERE language = \$1 AND text_normalized IN \(\$2,\$3\) AND .*deleted_at IS NULL`).
+		WithArgs("en", "hello", "world").
+		WillReturnRows(rows)
+
+	got, err := repo.ListByNormalizedTexts(context.Background(), "en", []string{"hello", "world"})
+	if err != nil {
+		t.Fatalf("ListByNormalizedTexts() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != entryID {
+		t.Errorf("ListByNormalizedTexts() = %+v, want only %s", got, entryID)
+	}
+
+	// Пустой список — без запроса
+	if got, err := repo.ListByNormalizedTexts(context.Background(), "en", nil); err != nil || len(got) != 0 {
+		t.Errorf("ListByNormalizedTexts(nil) = %v, %v", got, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
 func TestDictionaryRepository_CountTotal(t *testing.T) {
+	pos := model.PosNoun
+	maxRank := 1000
+	tag, list := "travel", "Kitchen"
+
 	tests := []struct {
 		name    string
 		filter  DictionaryFilter
@@ -506,13 +824,45 @@ func TestDictionaryRepository_CountTotal(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(5))
-				mock.ExpectQuery(`SELECT COUNT`).
-					WithArgs(pgxmock.AnyArg()).
+				mock.ExpectQuery(`SELECT COUNT.+text % \$1 OR .+notes.+plainto_tsquery\('simple', \$2\).+s\.notes.+plainto_tsquery\('simple', \$3\)`).
+					WithArgs("hello", "hello", "hello").
 					WillReturnRows(rows)
 			},
 			want:    5,
 			wantErr: false,
 		},
+		{
+			name: "count with part of speech, level and frequency filters",
+			filter: DictionaryFilter{
+				Search:           "hello",
+				PartOfSpeech:     &pos,
+				CefrLevels:       []string{model.CefrB1, model.CefrB2},
+				MaxFrequencyRank: &maxRank,
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(2))
+				mock.ExpectQuery(`SELECT COUNT.+part_of_speech = \$1.+cefr_level IN \(\$2,\$3\) AND frequency_rank <= \$4 AND \(text % \$5`).
+					WithArgs(pos, model.CefrB1, model.CefrB2, maxRank, "hello", "hello", "hello").
+					WillReturnRows(rows)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "count with tag and list filters",
+			filter: DictionaryFilter{
+				Tag:  &tag,
+				List: &list,
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(4))
+				mock.ExpectQuery(`SELECT COUNT.+deleted_at IS NULL AND tags @> \$1 AND list = \$2`).
+					WithArgs([]string{tag}, list).
+					WillReturnRows(rows)
+			},
+			want:    4,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -614,3 +964,190 @@ func TestDictionaryFilter_Normalize(t *testing.T) {
 		})
 	}
 }
+
+func TestDictionaryRepository_FindPage(t *testing.T) {
+	now := time.Now()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	textSort := model.SortFieldText
+	asc := model.SortDirAsc
+	afterText := base.Cursor{Key: string(model.SortFieldText), Dir: string(model.SortDirAsc), Value: "apple", ID: firstID}
+	afterTextDesc := base.Cursor{Key: string(model.SortFieldText), Dir: string(model.SortDirDesc), Value: "apple", ID: firstID}
+	afterCreated := base.NewTimeCursor(string(model.SortFieldCreatedAt), now, firstID)
+
+	tests := []struct {
+		name        string
+		filter      DictionaryFilter
+		after       *base.Cursor
+		setup       func(mock pgxmock.PgxPoolIface)
+		wantLen     int
+		wantHasNext bool
+		wantErr     bool
+	}{
+		{
+			name:   "first page with next page",
+			filter: DictionaryFilter{Limit: 1},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
+					AddRow(firstID, "Hello", "hello", now, now).
+					AddRow(secondID, "World", "world", now, now)
+				mock.ExpectQuery(`ORDER BY created_at DESC, id DESC LIMIT 2`).
+					WillReturnRows(rows)
+			},
+			wantLen:     1,
+			wantHasNext: true,
+		},
+		{
+			name:   "after text cursor",
+			filter: DictionaryFilter{Limit: 10, SortBy: &textSort, SortDir: &asc},
+			after:  &afterText,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "text", "text_normalized", "created_at", "updated_at"}).
+					AddRow(secondID, "banana", "banana", now, now)
+				mock.ExpectQuery(`\(text, id\) > \(\$1, \$2\) ORDER BY text ASC, id ASC LIMIT 11`).
+					WithArgs("apple", pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			wantLen:     1,
+			wantHasNext: false,
+		},
+		{
+			name:    "cursor for another sort field",
+			filter:  DictionaryFilter{SortBy: &textSort},
+			after:   &afterCreated,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+		{
+			name:    "cursor for another sort direction",
+			filter:  DictionaryFilter{SortBy: &textSort, SortDir: &asc},
+			after:   &afterTextDesc,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewDictionaryRepository(querier)
+
+			tt.setup(mock)
+
+			entries, hasNext, err := repo.FindPage(context.Background(), tt.filter, tt.after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(entries) != tt.wantLen {
+				t.Errorf("FindPage() returned %d entries, want %d", len(entries), tt.wantLen)
+			}
+			if hasNext != tt.wantHasNext {
+				t.Errorf("FindPage() hasNext = %v, want %v", hasNext, tt.wantHasNext)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestEntryCursor(t *testing.T) {
+	entry := model.DictionaryEntry{ID: uuid.New(), Text: "apple", CreatedAt: time.Now()}
+	textSort := model.SortFieldText
+
+	tests := []struct {
+		name    string
+		filter  DictionaryFilter
+		wantKey string
+		wantDir string
+	}{
+		{
+			name:    "default sort",
+			filter:  DictionaryFilter{},
+			wantKey: string(model.SortFieldCreatedAt),
+			wantDir: string(model.SortDirDesc),
+		},
+		{
+			name:    "text sort without direction",
+			filter:  DictionaryFilter{SortBy: &textSort},
+			wantKey: string(model.SortFieldText),
+			wantDir: string(model.SortDirAsc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := EntryCursor(entry, tt.filter)
+			if c.Key != tt.wantKey || c.Dir != tt.wantDir {
+				t.Errorf("EntryCursor() = %s/%s, want %s/%s", c.Key, c.Dir, tt.wantKey, tt.wantDir)
+			}
+			if c.ID != entry.ID {
+				t.Errorf("EntryCursor() ID = %v, want %v", c.ID, entry.ID)
+			}
+		})
+	}
+}
+
+func TestDictionaryRepository_FindDuplicatePairs(t *testing.T) {
+	entryID := uuid.New()
+	duplicateID := uuid.New()
+
+	tests := []struct {
+		name          string
+		minSimilarity float64
+		limit         int
+		setup         func(mock pgxmock.PgxPoolIface)
+		wantLen       int
+		wantErr       bool
+	}{
+		{
+			name:          "returns pairs",
+			minSimilarity: 0.4,
+			limit:         10,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"entry_id", "duplicate_id", "similarity"}).
+					AddRow(entryID, duplicateID, 0.8)
+				mock.ExpectQuery(`a.text_normalized % b.text_normalized`).
+					WithArgs(0.4, 10).
+					WillReturnRows(rows)
+			},
+			wantLen: 1,
+		},
+		{
+			name:          "limit is clamped",
+			minSimilarity: 0.5,
+			limit:         100000,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"entry_id", "duplicate_id", "similarity"})
+				mock.ExpectQuery(`SELECT`).
+					WithArgs(0.5, MaxDuplicateLimit).
+					WillReturnRows(rows)
+			},
+			wantLen: 0,
+		},
+		{
+			name:          "invalid similarity",
+			minSimilarity: 1.5,
+			setup:         func(mock pgxmock.PgxPoolIface) {},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewDictionaryRepository(querier)
+
+			tt.setup(mock)
+
+			pairs, err := repo.FindDuplicatePairs(context.Background(), tt.minSimilarity, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindDuplicatePairs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(pairs) != tt.wantLen {
+				t.Errorf("FindDuplicatePairs() returned %d pairs, want %d", len(pairs), tt.wantLen)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
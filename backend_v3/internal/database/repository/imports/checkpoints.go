@@ -0,0 +1,65 @@
+package imports
+
+import (
+	"context"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// ImportCheckpointRepository хранит отметки инкрементального импорта.
+type ImportCheckpointRepository struct {
+	*base.Base[model.ImportCheckpoint]
+}
+
+// NewImportCheckpointRepository создаёт новый репозиторий отметок импорта.
+func NewImportCheckpointRepository(q database.Querier) *ImportCheckpointRepository {
+	return &ImportCheckpointRepository{
+		Base: base.MustNewBase[model.ImportCheckpoint](q, base.Config{
+			Table:   schema.ImportCheckpoints.Name.String(),
+			Columns: schema.ImportCheckpoints.Columns(),
+		}),
+	}
+}
+
+// Get возвращает отметку источника.
+//
+// Возвращает:
+//   - ErrNotFound: если источник ещё не импортировался
+//   - ErrInvalidInput: если source пустой
+func (r *ImportCheckpointRepository) Get(ctx context.Context, source string) (*model.ImportCheckpoint, error) {
+	if err := base.ValidateString(source, "source"); err != nil {
+		return nil, err
+	}
+	return r.FindOneBy(ctx, schema.ImportCheckpoints.Source.Bare(), source)
+}
+
+// Advance сдвигает отметку источника до until. Отметка только растёт:
+// если сохранённая позже until, она не меняется.
+//
+// Возвращает:
+//   - ErrInvalidInput: если source пустой
+func (r *ImportCheckpointRepository) Advance(ctx context.Context, source string, until time.Time) (*model.ImportCheckpoint, error) {
+	if err := base.ValidateString(source, "source"); err != nil {
+		return nil, err
+	}
+
+	sql, args, err := r.InsertBuilder().
+		Columns(schema.ImportCheckpoints.InsertColumns()...).
+		Values(source, until).
+		Suffix("ON CONFLICT (source) DO UPDATE SET imported_until = " +
+			"GREATEST(import_checkpoints.imported_until, EXCLUDED.imported_until) RETURNING *").
+		ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	var result model.ImportCheckpoint
+	if err := r.QueryRowRaw(ctx, &result, sql, args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
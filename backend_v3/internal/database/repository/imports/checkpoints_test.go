@@ -0,0 +1,70 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var checkpointColumns = []string{"source", "imported_until", "created_at", "updated_at"}
+
+func TestImportCheckpointRepository_Get(t *testing.T) {
+	until := time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewImportCheckpointRepository(querier)
+
+	mock.ExpectQuery(`SELECT .+ FROM import_checkpoints WHERE source = \$1 LIMIT 1`).
+		WithArgs("kindle").
+		WillReturnRows(pgxmock.NewRows(checkpointColumns).AddRow("kindle", until, until, until))
+	mock.ExpectQuery(`SELECT .+ FROM import_checkpoints WHERE source = \$1 LIMIT 1`).
+		WithArgs("other").
+		WillReturnRows(pgxmock.NewRows(checkpointColumns))
+
+	got, err := repo.Get(context.Background(), "kindle")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !got.ImportedUntil.Equal(until) {
+		t.Errorf("Get() imported_until = %v, want %v", got.ImportedUntil, until)
+	}
+
+	if _, err := repo.Get(context.Background(), "other"); !errors.Is(err, database.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+
+	// Пустой источник — без запроса
+	if _, err := repo.Get(context.Background(), ""); !errors.Is(err, database.ErrInvalidInput) {
+		t.Errorf("Get() error = %v, want ErrInvalidInput", err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestImportCheckpointRepository_Advance(t *testing.T) {
+	until := time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewImportCheckpointRepository(querier)
+
+	mock.ExpectQuery(`INSERT INTO import_checkpoints \(source,imported_until\) VALUES \(\$1,\$2\) `+
+		`ON CONFLICT \(source\) DO UPDATE SET imported_until = GREATEST\(import_checkpoints.imported_until, EXCLUDED.imported_until\) RETURNING \*$`).
+		WithArgs("kindle", until).
+		WillReturnRows(pgxmock.NewRows(checkpointColumns).AddRow("kindle", until, now, now))
+
+	got, err := repo.Advance(context.Background(), "kindle", until)
+	if err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	if got.Source != "kindle" || !got.ImportedUntil.Equal(until) {
+		t.Errorf("Advance() = %+v", got)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
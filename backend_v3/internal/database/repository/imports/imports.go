@@ -0,0 +1,140 @@
+// Package imports содержит репозиторий фоновых заданий импорта слов.
+package imports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// ============================================================================
+// REPOSITORY
+// ============================================================================
+
+// ImportJobRepository предоставляет методы для работы с заданиями импорта.
+type ImportJobRepository struct {
+	*base.Base[model.ImportJob]
+}
+
+// NewImportJobRepository создаёт новый репозиторий заданий импорта.
+func NewImportJobRepository(q database.Querier) *ImportJobRepository {
+	return &ImportJobRepository{
+		Base: base.MustNewBase[model.ImportJob](q, base.Config{
+			Table:   schema.ImportJobs.Name.String(),
+			Columns: schema.ImportJobs.Columns(),
+		}),
+	}
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// GetByID получает задание по ID.
+//
+// Возвращает:
+//   - ErrNotFound: если задание не найдено
+//   - ErrInvalidInput: если id пустой
+func (r *ImportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ImportJob, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	return r.Base.GetByID(ctx, schema.ImportJobs.ID.Bare(), id)
+}
+
+// ListUnfinished возвращает ожидающие и прерванные на середине задания
+// в порядке создания.
+func (r *ImportJobRepository) ListUnfinished(ctx context.Context, limit int) ([]model.ImportJob, error) {
+	if limit <= 0 {
+		return []model.ImportJob{}, nil
+	}
+
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.ImportJobs.Status.Bare(): []string{
+			string(model.ImportPending), string(model.ImportRunning),
+		}}).
+		OrderBy(schema.ImportJobs.CreatedAt.Asc()).
+		Limit(uint64(limit))
+
+	return r.List(ctx, query)
+}
+
+// ============================================================================
+// WRITE OPERATIONS
+// ============================================================================
+
+// Create сохраняет новое задание в статусе PENDING.
+// Счётчики пропущенных и ошибочных строк и ошибки строк берутся из job:
+// их заполняет разбор файла.
+//
+// Возвращает:
+//   - ErrInvalidInput: если job nil или не заполнен формат
+func (r *ImportJobRepository) Create(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error) {
+	if job == nil {
+		return nil, fmt.Errorf("%w: job is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateString(job.Format, "format"); err != nil {
+		return nil, err
+	}
+	if job.TotalRows < 0 {
+		return nil, fmt.Errorf("%w: total_rows must be non-negative", database.ErrInvalidInput)
+	}
+
+	insert := r.InsertBuilder().
+		Columns(schema.ImportJobs.InsertColumns()...).
+		Values(
+			job.Format, string(model.ImportPending), jsonOrDefault(job.Options, "{}"),
+			jsonOrDefault(job.Rows, "[]"), job.TotalRows,
+			job.SkippedCount, job.FailedCount, jsonOrDefault(job.RowErrors, "[]"),
+		)
+
+	return r.Base.Create(ctx, insert)
+}
+
+// SaveProgress сохраняет статус, прогресс, счётчики и ошибки задания.
+// Для завершённых статусов проставляется finished_at.
+//
+// Возвращает:
+//   - ErrNotFound: если задание не найдено
+//   - ErrInvalidInput: если job nil или прогресс вне диапазона
+func (r *ImportJobRepository) SaveProgress(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error) {
+	if job == nil {
+		return nil, fmt.Errorf("%w: job is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateUUID(job.ID, "id"); err != nil {
+		return nil, err
+	}
+	if job.ProcessedRows < 0 || job.ProcessedRows > job.TotalRows {
+		return nil, fmt.Errorf("%w: processed_rows must be within [0, total_rows]", database.ErrInvalidInput)
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.ImportJobs.Status.Bare(), string(job.Status)).
+		Set(schema.ImportJobs.ProcessedRows.Bare(), job.ProcessedRows).
+		Set(schema.ImportJobs.CreatedCount.Bare(), job.CreatedCount).
+		Set(schema.ImportJobs.MergedCount.Bare(), job.MergedCount).
+		Set(schema.ImportJobs.SkippedCount.Bare(), job.SkippedCount).
+		Set(schema.ImportJobs.FailedCount.Bare(), job.FailedCount).
+		Set(schema.ImportJobs.RowErrors.Bare(), jsonOrDefault(job.RowErrors, "[]")).
+		Set(schema.ImportJobs.Error.Bare(), job.Error).
+		Where(squirrel.Eq{schema.ImportJobs.ID.Bare(): job.ID})
+	if job.Status.IsFinished() {
+		update = update.Set(schema.ImportJobs.FinishedAt.Bare(), squirrel.Expr("NOW()"))
+	}
+
+	return r.Base.Update(ctx, update)
+}
+
+// jsonOrDefault возвращает JSON-текст для колонки JSONB или def, если он пуст.
+func jsonOrDefault(raw []byte, def string) string {
+	if len(raw) == 0 {
+		return def
+	}
+	return string(raw)
+}
@@ -0,0 +1,130 @@
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var jobColumns = []string{
+	"id", "format", "status", "options", "rows", "total_rows", "processed_rows",
+	"created_count", "merged_count", "skipped_count", "failed_count", "row_errors",
+	"error", "created_at", "updated_at", "finished_at",
+}
+
+func jobRow(id uuid.UUID, status model.ImportJobStatus, processed int) []any {
+	now := time.Now()
+	return []any{
+		id, "csv", status, []byte(`{}`), []byte(`[]`), 10, processed,
+		processed, 0, 1, 0, []byte(`[]`), nil, now, now, nil,
+	}
+}
+
+func TestImportJobRepository_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     *model.ImportJob
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "defaults for empty JSON",
+			job:  &model.ImportJob{Format: "csv", TotalRows: 10, SkippedCount: 1, Rows: json.RawMessage(`[{"line":2}]`)},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`INSERT INTO import_jobs \(format,status,options,rows,total_rows,skipped_count,failed_count,row_errors\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\) RETURNING \*$`).
+					WithArgs("csv", "PENDING", "{}", `[{"line":2}]`, 10, 1, 0, "[]").
+					WillReturnRows(pgxmock.NewRows(jobColumns).AddRow(jobRow(uuid.New(), model.ImportPending, 0)...))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nil job",
+			job:     nil,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+		{
+			name:    "missing format",
+			job:     &model.ImportJob{TotalRows: 1},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewImportJobRepository(querier)
+
+			tt.setup(mock)
+
+			result, err := repo.Create(context.Background(), tt.job)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && result.Status != model.ImportPending {
+				t.Errorf("Create() status = %q, want PENDING", result.Status)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestImportJobRepository_ListUnfinished(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewImportJobRepository(querier)
+
+	rows := pgxmock.NewRows(jobColumns).AddRow(jobRow(uuid.New(), model.ImportRunning, 4)...)
+	mock.ExpectQuery(`SELECT .+ FROM import_jobs WHERE status IN \(\$1,\$2\) ORDER BY import_jobs.created_at ASC LIMIT 1`).
+		WithArgs("PENDING", "RUNNING").
+		WillReturnRows(rows)
+
+	got, err := repo.ListUnfinished(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListUnfinished() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ProcessedRows != 4 {
+		t.Errorf("ListUnfinished() = %+v, want one job with 4 processed rows", got)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestImportJobRepository_SaveProgress(t *testing.T) {
+	id := uuid.New()
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewImportJobRepository(querier)
+
+	// Завершённое задание получает finished_at
+	mock.ExpectQuery(`UPDATE import_jobs SET status = \$1, processed_rows = \$2, .+, finished_at = NOW\(\) WHERE id = \$\d+ RETURNING \*`).
+		WithArgs("COMPLETED", 10, 9, 0, 1, 0, "[]", pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows(jobColumns).AddRow(jobRow(id, model.ImportCompleted, 10)...))
+
+	job := &model.ImportJob{ID: id, Status: model.ImportCompleted, TotalRows: 10, ProcessedRows: 10, CreatedCount: 9, SkippedCount: 1}
+	got, err := repo.SaveProgress(context.Background(), job)
+	if err != nil {
+		t.Fatalf("SaveProgress() error = %v", err)
+	}
+	if got.Status != model.ImportCompleted {
+		t.Errorf("SaveProgress() status = %q, want COMPLETED", got.Status)
+	}
+
+	// Прогресс за пределами total_rows — без запроса
+	job.ProcessedRows = 11
+	if _, err := repo.SaveProgress(context.Background(), job); err == nil {
+		t.Error("SaveProgress() expected error for processed_rows > total_rows")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
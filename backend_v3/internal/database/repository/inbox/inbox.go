@@ -4,6 +4,7 @@ package inbox
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -21,6 +22,9 @@ const (
 	// MaxInboxItems — максимальное количество элементов в inbox.
 	// Предотвращает слишком большие выборки.
 	MaxInboxItems = 1000
+
+	// CursorKey — ключ сортировки в курсорах inbox.
+	CursorKey = "CREATED_AT"
 )
 
 // ============================================================================
@@ -101,6 +105,78 @@ func (r *InboxRepository) ListPaginated(ctx context.Context, limit, offset int)
 	return r.Base.List(ctx, query)
 }
 
+// FindPage возвращает страницу элементов inbox для keyset-пагинации (новые первыми):
+// limit элементов строго после курсора after (nil — с начала) и флаг наличия следующей страницы.
+func (r *InboxRepository) FindPage(ctx context.Context, limit int, after *base.Cursor) ([]model.InboxItem, bool, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > MaxInboxItems {
+		limit = MaxInboxItems
+	}
+
+	createdCol := schema.InboxItems.CreatedAt.Bare()
+	idCol := schema.InboxItems.ID.Bare()
+
+	query := r.SelectBuilder()
+	if after != nil {
+		if after.Key != CursorKey {
+			return nil, false, fmt.Errorf("%w: cursor does not match sort field", database.ErrInvalidInput)
+		}
+		createdAt, err := after.Time()
+		if err != nil {
+			return nil, false, err
+		}
+		query = query.Where(base.KeysetAfter(createdCol, idCol, true, createdAt, after.ID))
+	}
+	query = query.OrderBy(createdCol+" DESC", idCol+" DESC").Limit(uint64(limit + 1))
+
+	items, err := r.Base.List(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(items) > limit
+	if hasNext {
+		items = items[:limit]
+	}
+	return items, hasNext, nil
+}
+
+// ItemCursor возвращает курсор элемента inbox (см. FindPage).
+func ItemCursor(item model.InboxItem) base.Cursor {
+	return base.NewTimeCursor(CursorKey, item.CreatedAt, item.ID)
+}
+
+// ExistsByText проверяет, есть ли в inbox элемент с тем же текстом
+// (без учёта регистра и пробелов по краям).
+func (r *InboxRepository) ExistsByText(ctx context.Context, text string) (bool, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false, fmt.Errorf("%w: text is required", database.ErrInvalidInput)
+	}
+
+	query := base.Builder().
+		Select("1").
+		From(schema.InboxItems.Name.String()).
+		Where(squirrel.Expr("lower(btrim("+schema.InboxItems.Text.Bare()+")) = lower(?)", text)).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, database.WrapDBError(err)
+	}
+
+	var exists int
+	if err := r.Q().QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, database.WrapDBError(err)
+	}
+	return true, nil
+}
+
 // Count возвращает общее количество элементов в inbox.
 func (r *InboxRepository) Count(ctx context.Context) (int64, error) {
 	return r.CountAll(ctx)
@@ -4,12 +4,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/repository/audit"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
 	"github.com/heartmarshall/my-english/internal/database/repository/cards"
+	"github.com/heartmarshall/my-english/internal/database/repository/content"
 	"github.com/heartmarshall/my-english/internal/database/repository/dictionary"
+	"github.com/heartmarshall/my-english/internal/database/repository/suggestioncache"
+	"github.com/heartmarshall/my-english/internal/database/repository/wordlevel"
 	"github.com/heartmarshall/my-english/internal/model"
 )
 
@@ -21,17 +27,37 @@ import (
 type DictionaryRepository interface {
 	// Читающие операции
 	GetByID(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error)
-	FindByNormalizedText(ctx context.Context, text string) (*model.DictionaryEntry, error)
+	FindByNormalizedText(ctx context.Context, language, text string) (*model.DictionaryEntry, error)
+	ListByNormalizedTexts(ctx context.Context, language string, texts []string) ([]model.DictionaryEntry, error)
 	Find(ctx context.Context, filter dictionary.DictionaryFilter) ([]model.DictionaryEntry, error)
 	CountTotal(ctx context.Context, filter dictionary.DictionaryFilter) (int64, error)
-	ExistsByNormalizedText(ctx context.Context, text string) (bool, error)
+	FindPage(ctx context.Context, filter dictionary.DictionaryFilter, after *base.Cursor) ([]model.DictionaryEntry, bool, error)
+	ExistsByNormalizedText(ctx context.Context, language, text string) (bool, error)
 	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.DictionaryEntry, error)
+	FindIDs(ctx context.Context, filter dictionary.DictionaryFilter, limit int) ([]uuid.UUID, error)
+	ListWordLists(ctx context.Context) ([]dictionary.WordList, error)
 
 	// Пишущие операции
 	Create(ctx context.Context, entry *model.DictionaryEntry) (*model.DictionaryEntry, error)
 	CreateOrGet(ctx context.Context, entry *model.DictionaryEntry) (*model.DictionaryEntry, error)
 	Update(ctx context.Context, id uuid.UUID, entry *model.DictionaryEntry) (*model.DictionaryEntry, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Метки и списки
+	UpdateTags(ctx context.Context, ids []uuid.UUID, add, remove []string) ([]model.DictionaryEntry, error)
+	SetList(ctx context.Context, ids []uuid.UUID, list *string) ([]model.DictionaryEntry, error)
+
+	// Корзина (мягкое удаление)
+	SoftDelete(ctx context.Context, id uuid.UUID, at time.Time) (*model.DictionaryEntry, error)
+	SoftDeleteMany(ctx context.Context, ids []uuid.UUID, at time.Time) ([]model.DictionaryEntry, error)
+	Restore(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error)
+	ListDeleted(ctx context.Context, limit, offset int) ([]model.DictionaryEntry, error)
+	CountDeleted(ctx context.Context) (int64, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+
+	// Дубликаты
+	FindDuplicatePairs(ctx context.Context, minSimilarity float64, limit int) ([]dictionary.DuplicatePair, error)
 }
 
 // ============================================================================
@@ -44,33 +70,44 @@ type CardRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Card, error)
 	GetByEntryID(ctx context.Context, entryID uuid.UUID) (*model.Card, error)
 	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Card, error)
-	GetDueCards(ctx context.Context, now time.Time, limit int) ([]model.Card, error)
+	GetDueCards(ctx context.Context, now time.Time, limit int, language *string) ([]model.Card, error)
 	GetDashboardStats(ctx context.Context) (*cards.DashboardStats, error)
 	ListByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]model.Card, error)
 
 	// Пишущие операции
 	Create(ctx context.Context, card *model.Card) (*model.Card, error)
+	BatchCreate(ctx context.Context, cards []model.Card) ([]model.Card, error)
 	Update(ctx context.Context, id uuid.UUID, card *model.Card) (*model.Card, error)
 	UpdateSRSFields(ctx context.Context, id uuid.UUID, status model.LearningStatus, nextReviewAt *time.Time, intervalDays int, easeFactor float64) error
+	ResetProgress(ctx context.Context, ids []uuid.UUID, easeFactor float64) (int64, error)
+	MoveToEntry(ctx context.Context, id uuid.UUID, entryID uuid.UUID) (*model.Card, error)
+	MoveHints(ctx context.Context, fromCardIDs []uuid.UUID, toCardID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 // ReviewLogRepository определяет контракт для работы с логами ревью.
 type ReviewLogRepository interface {
 	Create(ctx context.Context, log *model.ReviewLog) (*model.ReviewLog, error)
+	BatchCreate(ctx context.Context, logs []model.ReviewLog) ([]model.ReviewLog, error)
 	ListByCardID(ctx context.Context, cardID uuid.UUID, limit int) ([]model.ReviewLog, error)
+	ListByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]model.ReviewLog, error)
+	MoveToCard(ctx context.Context, fromCardIDs []uuid.UUID, toCardID uuid.UUID) (int64, error)
 }
 
 // ============================================================================
-// CONTENT (Senses, Examples, Translations, Images, Pronunciations)
+// CONTENT (Senses, Examples, Translations, Relations, Images, Pronunciations)
 // ============================================================================
 
 // SenseRepository определяет контракт для работы со смыслами слов.
 type SenseRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Sense, error)
 	ListByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]model.Sense, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.Sense, error)
 	Create(ctx context.Context, sense *model.Sense) (*model.Sense, error)
 	BatchCreate(ctx context.Context, senses []model.Sense) ([]model.Sense, error)
+	Update(ctx context.Context, id uuid.UUID, sense *model.Sense) (*model.Sense, error)
+	MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error)
+	FillCefrLevel(ctx context.Context, entryID uuid.UUID, level string) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -79,6 +116,8 @@ type ExampleRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Example, error)
 	ListBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]model.Example, error)
 	BatchCreate(ctx context.Context, examples []model.Example) ([]model.Example, error)
+	Update(ctx context.Context, id uuid.UUID, example *model.Example) (*model.Example, error)
+	MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -86,7 +125,20 @@ type ExampleRepository interface {
 type TranslationRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Translation, error)
 	ListBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]model.Translation, error)
+	SearchByText(ctx context.Context, text string, limit int) ([]content.TranslationMatch, error)
 	BatchCreate(ctx context.Context, translations []model.Translation) ([]model.Translation, error)
+	Update(ctx context.Context, id uuid.UUID, translation *model.Translation) (*model.Translation, error)
+	MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// SenseRelationRepository определяет контракт для работы со связями смыслов.
+type SenseRelationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.SenseRelation, error)
+	ListBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]model.SenseRelation, error)
+	ListTargets(ctx context.Context, relationIDs []uuid.UUID) ([]content.RelationTarget, error)
+	BatchCreate(ctx context.Context, relations []model.SenseRelation) ([]model.SenseRelation, error)
+	MoveToSense(ctx context.Context, ids []uuid.UUID, senseID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -95,6 +147,8 @@ type ImageRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Image, error)
 	ListByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]model.Image, error)
 	BatchCreate(ctx context.Context, images []model.Image) ([]model.Image, error)
+	Update(ctx context.Context, id uuid.UUID, image *model.Image) (*model.Image, error)
+	MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -103,9 +157,56 @@ type PronunciationRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Pronunciation, error)
 	ListByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]model.Pronunciation, error)
 	BatchCreate(ctx context.Context, pronunciations []model.Pronunciation) ([]model.Pronunciation, error)
+	Update(ctx context.Context, id uuid.UUID, pronunciation *model.Pronunciation) (*model.Pronunciation, error)
+	MoveToEntry(ctx context.Context, ids []uuid.UUID, entryID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// ============================================================================
+// MEDIA
+// ============================================================================
+
+// MediaRepository определяет контракт для работы с метаданными медиафайлов.
+type MediaRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Media, error)
+	GetBySHA256(ctx context.Context, sha256 string) (*model.Media, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.Media, error)
+	ListUnprocessedImages(ctx context.Context, limit int) ([]model.Media, error)
+	CreateOrGet(ctx context.Context, media *model.Media) (*model.Media, error)
+	MarkProcessed(ctx context.Context, id uuid.UUID, width, height int, thumbnailID, cardID *uuid.UUID) (*model.Media, error)
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+// ============================================================================
+// IMPORTS
+// ============================================================================
+
+// ImportJobRepository определяет контракт для работы с заданиями импорта.
+type ImportJobRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ImportJob, error)
+	ListUnfinished(ctx context.Context, limit int) ([]model.ImportJob, error)
+	Create(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error)
+	SaveProgress(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error)
+}
+
+// ImportCheckpointRepository определяет контракт для отметок инкрементального импорта.
+type ImportCheckpointRepository interface {
+	Get(ctx context.Context, source string) (*model.ImportCheckpoint, error)
+	Advance(ctx context.Context, source string, until time.Time) (*model.ImportCheckpoint, error)
+}
+
+// ============================================================================
+// BACKUP
+// ============================================================================
+
+// BackupRepository определяет контракт для выгрузки и загрузки резервной копии.
+type BackupRepository interface {
+	Stream(ctx context.Context, table string, fn func(row json.RawMessage) error) error
+	Insert(ctx context.Context, table string, rows []json.RawMessage) (int64, error)
+	IsEmpty(ctx context.Context) (bool, error)
+	MediaIDsBySHA256(ctx context.Context, hashes []string) (map[string]uuid.UUID, error)
+}
+
 // ============================================================================
 // INBOX & AUDIT
 // ============================================================================
@@ -116,7 +217,9 @@ type InboxRepository interface {
 	ListAll(ctx context.Context) ([]model.InboxItem, error)
 	List(ctx context.Context, query squirrel.SelectBuilder) ([]model.InboxItem, error)
 	ListPaginated(ctx context.Context, limit, offset int) ([]model.InboxItem, error)
+	FindPage(ctx context.Context, limit int, after *base.Cursor) ([]model.InboxItem, bool, error)
 	Count(ctx context.Context) (int64, error)
+	ExistsByText(ctx context.Context, text string) (bool, error)
 	Create(ctx context.Context, item *model.InboxItem) (*model.InboxItem, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteAll(ctx context.Context) (int64, error)
@@ -125,6 +228,38 @@ type InboxRepository interface {
 // AuditRepository определяет контракт для работы с аудит логами.
 type AuditRepository interface {
 	Create(ctx context.Context, audit *model.AuditRecord) (*model.AuditRecord, error)
+	Find(ctx context.Context, f AuditFilter) ([]model.AuditRecord, error)
+	FindPage(ctx context.Context, f AuditFilter, after *base.Cursor) ([]model.AuditRecord, bool, error)
+	CountTotal(ctx context.Context, f AuditFilter) (int64, error)
+	ListByEntity(ctx context.Context, entityType model.EntityType, entityID uuid.UUID, limit, offset int) ([]model.AuditRecord, error)
+	ListByEntry(ctx context.Context, entryID uuid.UUID, limit, offset int) ([]model.AuditRecord, error)
+	GetLatestSnapshot(ctx context.Context, entryID uuid.UUID, at time.Time) (*model.AuditRecord, error)
+}
+
+// ============================================================================
+// REFERENCE DATA
+// ============================================================================
+
+// WordLevelRepository определяет контракт для справочника уровней слов.
+type WordLevelRepository interface {
+	Lookup(ctx context.Context, word string) (*model.WordLevel, error)
+	ListByWords(ctx context.Context, words []string) ([]model.WordLevel, error)
+	MaxFrequencyRank(ctx context.Context) (int, error)
+	GetCoverage(ctx context.Context) ([]wordlevel.LevelCoverage, error)
+}
+
+// WiktionaryRepository определяет контракт офлайн-словаря из дампа Викисловаря.
+type WiktionaryRepository interface {
+	ListByWord(ctx context.Context, language, word string) ([]model.WiktionaryEntry, error)
+	InsertBatch(ctx context.Context, entries []model.WiktionaryEntry) error
+	DeleteByLanguage(ctx context.Context, language string) (int64, error)
+}
+
+// SuggestionCacheRepository определяет контракт кэша ответов провайдеров подсказок.
+type SuggestionCacheRepository interface {
+	Get(ctx context.Context, provider, text string) (*model.SuggestionCacheEntry, error)
+	Upsert(ctx context.Context, entry model.SuggestionCacheEntry) error
+	Delete(ctx context.Context, filter suggestioncache.CacheFilter) (int64, error)
 }
 
 // ============================================================================
@@ -133,3 +268,21 @@ type AuditRepository interface {
 
 // DashboardStats is an alias for cards.DashboardStats.
 type DashboardStats = cards.DashboardStats
+
+// TranslationMatch is an alias for content.TranslationMatch.
+type TranslationMatch = content.TranslationMatch
+
+// WordList is an alias for dictionary.WordList.
+type WordList = dictionary.WordList
+
+// DuplicatePair is an alias for dictionary.DuplicatePair.
+type DuplicatePair = dictionary.DuplicatePair
+
+// AuditFilter is an alias for audit.AuditFilter.
+type AuditFilter = audit.AuditFilter
+
+// LevelCoverage is an alias for wordlevel.LevelCoverage.
+type LevelCoverage = wordlevel.LevelCoverage
+
+// SuggestionCacheFilter is an alias for suggestioncache.CacheFilter.
+type SuggestionCacheFilter = suggestioncache.CacheFilter
@@ -0,0 +1,174 @@
+// Package media содержит репозиторий метаданных медиафайлов.
+// Само содержимое файлов хранится в internal/storage.
+package media
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// ============================================================================
+// REPOSITORY
+// ============================================================================
+
+// MediaRepository предоставляет методы для работы с метаданными медиафайлов.
+type MediaRepository struct {
+	*base.Base[model.Media]
+}
+
+// NewMediaRepository создаёт новый репозиторий медиафайлов.
+func NewMediaRepository(q database.Querier) *MediaRepository {
+	return &MediaRepository{
+		Base: base.MustNewBase[model.Media](q, base.Config{
+			Table:   schema.Media.Name.String(),
+			Columns: schema.Media.Columns(),
+		}),
+	}
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// GetByID получает медиафайл по ID.
+//
+// Возвращает:
+//   - ErrNotFound: если файл не найден
+//   - ErrInvalidInput: если id пустой
+func (r *MediaRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Media, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	return r.Base.GetByID(ctx, schema.Media.ID.Bare(), id)
+}
+
+// GetBySHA256 получает медиафайл по хешу содержимого.
+//
+// Возвращает:
+//   - ErrNotFound: если файла с таким хешем нет
+//   - ErrInvalidInput: если хеш пустой
+func (r *MediaRepository) GetBySHA256(ctx context.Context, sha256 string) (*model.Media, error) {
+	if err := base.ValidateString(sha256, "sha256"); err != nil {
+		return nil, err
+	}
+	return r.FindOneBy(ctx, schema.Media.SHA256.Bare(), sha256)
+}
+
+// ListByIDs получает медиафайлы по списку ID.
+func (r *MediaRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.Media, error) {
+	if len(ids) == 0 {
+		return []model.Media{}, nil
+	}
+	return r.ListByUUIDs(ctx, schema.Media.ID.Bare(), ids)
+}
+
+// ListUnprocessedImages возвращает изображения, ещё не прошедшие фоновую
+// обработку, в порядке загрузки.
+func (r *MediaRepository) ListUnprocessedImages(ctx context.Context, limit int) ([]model.Media, error) {
+	if limit <= 0 {
+		return []model.Media{}, nil
+	}
+
+	query := r.SelectBuilder().
+		Where(schema.Media.ProcessedAt.IsNull()).
+		Where(schema.Media.ContentType.Like("image/%")).
+		OrderBy(schema.Media.CreatedAt.Asc()).
+		Limit(uint64(limit))
+
+	return r.List(ctx, query)
+}
+
+// ============================================================================
+// WRITE OPERATIONS
+// ============================================================================
+
+// CreateOrGet сохраняет метаданные файла или возвращает существующую запись
+// с тем же SHA256. Так параллельные загрузки одного файла дают одну запись.
+//
+// Возвращает:
+//   - ErrInvalidInput: если media nil или не заполнены обязательные поля
+func (r *MediaRepository) CreateOrGet(ctx context.Context, media *model.Media) (*model.Media, error) {
+	if media == nil {
+		return nil, fmt.Errorf("%w: media is required", database.ErrInvalidInput)
+	}
+	if err := base.ValidateString(media.SHA256, "sha256"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(media.ContentType, "content_type"); err != nil {
+		return nil, err
+	}
+	if media.SizeBytes < 0 {
+		return nil, fmt.Errorf("%w: size_bytes must be non-negative", database.ErrInvalidInput)
+	}
+
+	// Минимальное обновление, чтобы RETURNING вернул существующую строку
+	insert := r.InsertBuilder().
+		Columns(schema.Media.InsertColumns()...).
+		Values(media.SHA256, media.ContentType, media.SizeBytes, media.SourceURL).
+		Suffix("ON CONFLICT (sha256) DO UPDATE SET id = media.id RETURNING *")
+
+	sql, args, err := insert.ToSql()
+	if err != nil {
+		return nil, database.WrapDBError(err)
+	}
+
+	var result model.Media
+	if err := r.QueryRowRaw(ctx, &result, sql, args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarkProcessed сохраняет результат обработки изображения: размеры
+// и ссылки на уменьшенные варианты (nil — вариант не создавался).
+//
+// Возвращает:
+//   - ErrNotFound: если файл не найден
+//   - ErrInvalidInput: если id пустой или размеры не положительные
+func (r *MediaRepository) MarkProcessed(ctx context.Context, id uuid.UUID, width, height int, thumbnailID, cardID *uuid.UUID) (*model.Media, error) {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("%w: width and height must be positive", database.ErrInvalidInput)
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Media.Width.Bare(), width).
+		Set(schema.Media.Height.Bare(), height).
+		Set(schema.Media.ThumbnailID.Bare(), thumbnailID).
+		Set(schema.Media.CardID.Bare(), cardID).
+		Set(schema.Media.ProcessedAt.Bare(), time.Now()).
+		Set(schema.Media.ProcessingError.Bare(), nil).
+		Where(squirrel.Eq{schema.Media.ID.Bare(): id})
+
+	return r.Base.Update(ctx, update)
+}
+
+// MarkFailed помечает изображение как обработанное с ошибкой,
+// чтобы фоновая обработка не повторяла его бесконечно.
+//
+// Возвращает:
+//   - ErrNotFound: если файл не найден
+//   - ErrInvalidInput: если id пустой
+func (r *MediaRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	if err := base.ValidateUUID(id, "id"); err != nil {
+		return err
+	}
+
+	update := r.UpdateBuilder().
+		Set(schema.Media.ProcessedAt.Bare(), time.Now()).
+		Set(schema.Media.ProcessingError.Bare(), reason).
+		Where(squirrel.Eq{schema.Media.ID.Bare(): id})
+
+	_, err := r.Base.Update(ctx, update)
+	return err
+}
@@ -0,0 +1,129 @@
+package media
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var mediaColumns = []string{
+	"id", "sha256", "content_type", "size_bytes", "source_url", "width", "height",
+	"thumbnail_id", "card_id", "processed_at", "processing_error", "created_at",
+}
+
+func TestMediaRepository_CreateOrGet(t *testing.T) {
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name    string
+		media   *model.Media
+		setup   func(mock pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "upsert by sha256",
+			media: &model.Media{
+				SHA256:      hash,
+				ContentType: "image/png",
+				SizeBytes:   4,
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(mediaColumns).
+					AddRow(uuid.New(), hash, "image/png", int64(4), nil, nil, nil, nil, nil, nil, nil, time.Now())
+				mock.ExpectQuery(`INSERT INTO media .+ ON CONFLICT \(sha256\) DO UPDATE`).
+					WithArgs(hash, "image/png", int64(4), pgxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nil media",
+			media:   nil,
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+		{
+			name: "missing content type",
+			media: &model.Media{
+				SHA256:    hash,
+				SizeBytes: 4,
+			},
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewMediaRepository(querier)
+
+			tt.setup(mock)
+
+			result, err := repo.CreateOrGet(context.Background(), tt.media)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateOrGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && result.SHA256 != hash {
+				t.Errorf("CreateOrGet() sha256 = %q, want %q", result.SHA256, hash)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestMediaRepository_GetBySHA256(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewMediaRepository(querier)
+
+	mock.ExpectQuery(`SELECT .+ FROM media WHERE sha256 = \$1`).
+		WithArgs("abc").
+		WillReturnRows(pgxmock.NewRows(mediaColumns))
+
+	if _, err := repo.GetBySHA256(context.Background(), "abc"); err == nil {
+		t.Error("GetBySHA256() expected ErrNotFound")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestMediaRepository_ListUnprocessedImages(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewMediaRepository(querier)
+
+	rows := pgxmock.NewRows(mediaColumns).
+		AddRow(uuid.New(), "abc", "image/png", int64(4), nil, nil, nil, nil, nil, nil, nil, time.Now())
+	mock.ExpectQuery(`SELECT .+ FROM media WHERE media.processed_at IS NULL AND media.content_type LIKE \$1 ORDER BY media.created_at ASC LIMIT 10`).
+		WithArgs("image/%").
+		WillReturnRows(rows)
+
+	got, err := repo.ListUnprocessedImages(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("ListUnprocessedImages() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("ListUnprocessedImages() returned %d items, want 1", len(got))
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestMediaRepository_MarkProcessed_InvalidSize(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewMediaRepository(querier)
+
+	if _, err := repo.MarkProcessed(context.Background(), uuid.New(), 0, 10, nil, nil); err == nil {
+		t.Error("MarkProcessed() expected error for zero width")
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
@@ -4,10 +4,16 @@ package repository
 import (
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository/audit"
+	"github.com/heartmarshall/my-english/internal/database/repository/backup"
 	"github.com/heartmarshall/my-english/internal/database/repository/cards"
 	"github.com/heartmarshall/my-english/internal/database/repository/content"
 	"github.com/heartmarshall/my-english/internal/database/repository/dictionary"
+	"github.com/heartmarshall/my-english/internal/database/repository/imports"
 	"github.com/heartmarshall/my-english/internal/database/repository/inbox"
+	"github.com/heartmarshall/my-english/internal/database/repository/media"
+	"github.com/heartmarshall/my-english/internal/database/repository/suggestioncache"
+	"github.com/heartmarshall/my-english/internal/database/repository/wiktionary"
+	"github.com/heartmarshall/my-english/internal/database/repository/wordlevel"
 )
 
 // ============================================================================
@@ -29,9 +35,13 @@ type Registry struct {
 	Senses         SenseRepository
 	Translations   TranslationRepository
 	Examples       ExampleRepository
+	Relations      SenseRelationRepository
 	Images         ImageRepository
 	Pronunciations PronunciationRepository
 
+	// Медиафайлы
+	Media MediaRepository
+
 	// Карточки и SRS
 	Cards      CardRepository
 	ReviewLogs ReviewLogRepository
@@ -39,8 +49,22 @@ type Registry struct {
 	// Inbox
 	Inbox InboxRepository
 
+	// Фоновые и инкрементальные импорты
+	ImportJobs        ImportJobRepository
+	ImportCheckpoints ImportCheckpointRepository
+
 	// Аудит
 	Audit AuditRepository
+
+	// Справочники
+	WordLevels WordLevelRepository
+	Wiktionary WiktionaryRepository
+
+	// Кэш подсказок
+	SuggestionCache SuggestionCacheRepository
+
+	// Резервные копии
+	Backup BackupRepository
 }
 
 // NewRegistry создает все репозитории, используя переданный Querier.
@@ -56,16 +80,24 @@ type Registry struct {
 //	})
 func NewRegistry(q database.Querier) *Registry {
 	return &Registry{
-		Dictionary:     dictionary.NewDictionaryRepository(q),
-		Senses:         content.NewSenseRepository(q),
-		Translations:   content.NewTranslationRepository(q),
-		Examples:       content.NewExampleRepository(q),
-		Images:         content.NewImageRepository(q),
-		Pronunciations: content.NewPronunciationRepository(q),
-		Cards:          cards.NewCardRepository(q),
-		ReviewLogs:     cards.NewReviewLogRepository(q),
-		Inbox:          inbox.NewInboxRepository(q),
-		Audit:          audit.NewAuditRepository(q),
+		Dictionary:        dictionary.NewDictionaryRepository(q),
+		Senses:            content.NewSenseRepository(q),
+		Translations:      content.NewTranslationRepository(q),
+		Examples:          content.NewExampleRepository(q),
+		Relations:         content.NewSenseRelationRepository(q),
+		Images:            content.NewImageRepository(q),
+		Pronunciations:    content.NewPronunciationRepository(q),
+		Media:             media.NewMediaRepository(q),
+		Cards:             cards.NewCardRepository(q),
+		ReviewLogs:        cards.NewReviewLogRepository(q),
+		Inbox:             inbox.NewInboxRepository(q),
+		ImportJobs:        imports.NewImportJobRepository(q),
+		ImportCheckpoints: imports.NewImportCheckpointRepository(q),
+		Audit:             audit.NewAuditRepository(q),
+		WordLevels:        wordlevel.NewWordLevelRepository(q),
+		Wiktionary:        wiktionary.NewWiktionaryRepository(q),
+		SuggestionCache:   suggestioncache.NewSuggestionCacheRepository(q),
+		Backup:            backup.NewBackupRepository(q),
 	}
 }
 
@@ -76,31 +108,47 @@ func NewRegistry(q database.Querier) *Registry {
 // RegistryConfig позволяет создать Registry с кастомными реализациями.
 // Используется для тестирования с моками.
 type RegistryConfig struct {
-	Dictionary     DictionaryRepository
-	Senses         SenseRepository
-	Translations   TranslationRepository
-	Examples       ExampleRepository
-	Images         ImageRepository
-	Pronunciations PronunciationRepository
-	Cards          CardRepository
-	ReviewLogs     ReviewLogRepository
-	Inbox          InboxRepository
-	Audit          AuditRepository
+	Dictionary        DictionaryRepository
+	Senses            SenseRepository
+	Translations      TranslationRepository
+	Examples          ExampleRepository
+	Relations         SenseRelationRepository
+	Images            ImageRepository
+	Pronunciations    PronunciationRepository
+	Media             MediaRepository
+	Cards             CardRepository
+	ReviewLogs        ReviewLogRepository
+	Inbox             InboxRepository
+	ImportJobs        ImportJobRepository
+	ImportCheckpoints ImportCheckpointRepository
+	Audit             AuditRepository
+	WordLevels        WordLevelRepository
+	Wiktionary        WiktionaryRepository
+	SuggestionCache   SuggestionCacheRepository
+	Backup            BackupRepository
 }
 
 // NewRegistryWithConfig создает Registry с кастомными реализациями.
 func NewRegistryWithConfig(cfg RegistryConfig) *Registry {
 	return &Registry{
-		Dictionary:     cfg.Dictionary,
-		Senses:         cfg.Senses,
-		Translations:   cfg.Translations,
-		Examples:       cfg.Examples,
-		Images:         cfg.Images,
-		Pronunciations: cfg.Pronunciations,
-		Cards:          cfg.Cards,
-		ReviewLogs:     cfg.ReviewLogs,
-		Inbox:          cfg.Inbox,
-		Audit:          cfg.Audit,
+		Dictionary:        cfg.Dictionary,
+		Senses:            cfg.Senses,
+		Translations:      cfg.Translations,
+		Examples:          cfg.Examples,
+		Relations:         cfg.Relations,
+		Images:            cfg.Images,
+		Pronunciations:    cfg.Pronunciations,
+		Media:             cfg.Media,
+		Cards:             cfg.Cards,
+		ReviewLogs:        cfg.ReviewLogs,
+		Inbox:             cfg.Inbox,
+		ImportJobs:        cfg.ImportJobs,
+		ImportCheckpoints: cfg.ImportCheckpoints,
+		Audit:             cfg.Audit,
+		WordLevels:        cfg.WordLevels,
+		Wiktionary:        cfg.Wiktionary,
+		SuggestionCache:   cfg.SuggestionCache,
+		Backup:            cfg.Backup,
 	}
 }
 
@@ -0,0 +1,108 @@
+// Package suggestioncache содержит репозиторий кэша ответов провайдеров подсказок.
+package suggestioncache
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// CacheFilter — условие удаления записей кэша. Пустые поля не ограничивают
+// выборку: пустой фильтр удаляет весь кэш.
+type CacheFilter struct {
+	Provider    string // Slug провайдера
+	Text        string // Нормализованный текст
+	ExpiredOnly bool   // Только записи с истёкшим сроком
+}
+
+// SuggestionCacheRepository предоставляет доступ к таблице suggestion_cache.
+type SuggestionCacheRepository struct {
+	*base.Base[model.SuggestionCacheEntry]
+}
+
+// NewSuggestionCacheRepository создаёт новый репозиторий кэша подсказок.
+func NewSuggestionCacheRepository(q database.Querier) *SuggestionCacheRepository {
+	return &SuggestionCacheRepository{
+		Base: base.MustNewBase[model.SuggestionCacheEntry](q, base.Config{
+			Table:   schema.SuggestionCache.Name.String(),
+			Columns: schema.SuggestionCache.Columns(),
+		}),
+	}
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// Get возвращает действующую запись кэша провайдера для нормализованного текста.
+//
+// Возвращает:
+//   - ErrInvalidInput: если provider или text пустые
+//   - ErrNotFound: если записи нет или её срок истёк
+func (r *SuggestionCacheRepository) Get(ctx context.Context, provider, text string) (*model.SuggestionCacheEntry, error) {
+	if err := base.ValidateString(provider, "provider"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(text, "text"); err != nil {
+		return nil, err
+	}
+
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{
+			schema.SuggestionCache.Provider.Bare():       provider,
+			schema.SuggestionCache.TextNormalized.Bare(): text,
+		}).
+		Where(schema.SuggestionCache.ExpiresAt.Bare() + " > NOW()")
+	return r.GetOne(ctx, query)
+}
+
+// ============================================================================
+// WRITE OPERATIONS
+// ============================================================================
+
+// Upsert сохраняет запись, заменяя прежнюю запись того же провайдера и текста.
+//
+// Возвращает:
+//   - ErrInvalidInput: если provider или text пустые
+func (r *SuggestionCacheRepository) Upsert(ctx context.Context, entry model.SuggestionCacheEntry) error {
+	if err := base.ValidateString(entry.Provider, "provider"); err != nil {
+		return err
+	}
+	if err := base.ValidateString(entry.TextNormalized, "text"); err != nil {
+		return err
+	}
+
+	sql, args, err := r.InsertBuilder().
+		Columns(schema.SuggestionCache.InsertColumns()...).
+		Values(entry.Provider, entry.TextNormalized, entry.Result, entry.FetchedAt, entry.ExpiresAt).
+		Suffix("ON CONFLICT (provider, text_normalized) DO UPDATE SET " +
+			"result = EXCLUDED.result, fetched_at = EXCLUDED.fetched_at, expires_at = EXCLUDED.expires_at").
+		ToSql()
+	if err != nil {
+		return database.WrapDBError(err)
+	}
+	_, err = r.ExecRaw(ctx, sql, args...)
+	return err
+}
+
+// Delete удаляет записи по фильтру и возвращает их количество.
+func (r *SuggestionCacheRepository) Delete(ctx context.Context, filter CacheFilter) (int64, error) {
+	pred := squirrel.And{}
+	if filter.Provider != "" {
+		pred = append(pred, squirrel.Eq{schema.SuggestionCache.Provider.Bare(): filter.Provider})
+	}
+	if filter.Text != "" {
+		pred = append(pred, squirrel.Eq{schema.SuggestionCache.TextNormalized.Bare(): filter.Text})
+	}
+	if filter.ExpiredOnly {
+		pred = append(pred, squirrel.Expr(schema.SuggestionCache.ExpiresAt.Bare()+" <= NOW()"))
+	}
+	if len(pred) == 0 {
+		return r.DeleteWhere(ctx, squirrel.Expr("TRUE"))
+	}
+	return r.DeleteWhere(ctx, pred)
+}
@@ -0,0 +1,121 @@
+package suggestioncache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var cacheColumns = []string{"provider", "text_normalized", "result", "fetched_at", "expires_at"}
+
+func TestSuggestionCacheRepository_Get(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewSuggestionCacheRepository(querier)
+
+	now := testutil.FixedTime()
+	mock.ExpectQuery(`SELECT .+ FROM suggestion_cache WHERE provider = \$1 AND text_normalized = \$2 AND expires_at > NOW\(\)`).
+		WithArgs("freedict", "dog").
+		WillReturnRows(pgxmock.NewRows(cacheColumns).
+			AddRow("freedict", "dog", json.RawMessage(`{"SourceSlug":"freedict"}`), now, now.Add(time.Hour)))
+
+	got, err := repo.Get(context.Background(), "freedict", "dog")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Provider != "freedict" || string(got.Result) != `{"SourceSlug":"freedict"}` || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	// Отрицательный кэш: result = NULL
+	mock.ExpectQuery(`SELECT .+ FROM suggestion_cache`).
+		WithArgs("freedict", "qwzx").
+		WillReturnRows(pgxmock.NewRows(cacheColumns).AddRow("freedict", "qwzx", nil, now, now.Add(time.Hour)))
+
+	got, err = repo.Get(context.Background(), "freedict", "qwzx")
+	if err != nil || got.Result != nil {
+		t.Errorf("Get(negative) = %+v, %v", got, err)
+	}
+
+	mock.ExpectQuery(`SELECT .+ FROM suggestion_cache`).
+		WithArgs("freedict", "cat").
+		WillReturnRows(pgxmock.NewRows(cacheColumns))
+
+	if _, err := repo.Get(context.Background(), "freedict", "cat"); !errors.Is(err, database.ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// Пустой текст — без запроса
+	if _, err := repo.Get(context.Background(), "freedict", ""); !errors.Is(err, database.ErrInvalidInput) {
+		t.Errorf("Get() error = %v, want ErrInvalidInput", err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestSuggestionCacheRepository_Upsert(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewSuggestionCacheRepository(querier)
+
+	now := testutil.FixedTime()
+	entry := model.SuggestionCacheEntry{
+		Provider:       "freedict",
+		TextNormalized: "dog",
+		Result:         json.RawMessage(`{}`),
+		FetchedAt:      now,
+		ExpiresAt:      now.Add(time.Hour),
+	}
+
+	mock.ExpectExec(`INSERT INTO suggestion_cache \(provider,text_normalized,result,fetched_at,expires_at\) `+
+		`VALUES \(\$1,\$2,\$3,\$4,\$5\) ON CONFLICT \(provider, text_normalized\) DO UPDATE SET `+
+		`result = EXCLUDED.result, fetched_at = EXCLUDED.fetched_at, expires_at = EXCLUDED.expires_at`).
+		WithArgs("freedict", "dog", json.RawMessage(`{}`), now, now.Add(time.Hour)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	if err := repo.Upsert(context.Background(), entry); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	entry.Provider = ""
+	if err := repo.Upsert(context.Background(), entry); !errors.Is(err, database.ErrInvalidInput) {
+		t.Errorf("Upsert() error = %v, want ErrInvalidInput", err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestSuggestionCacheRepository_Delete(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewSuggestionCacheRepository(querier)
+
+	mock.ExpectExec(`DELETE FROM suggestion_cache WHERE \(provider = \$1 AND text_normalized = \$2\)$`).
+		WithArgs("freedict", "dog").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(`DELETE FROM suggestion_cache WHERE \(expires_at <= NOW\(\)\)$`).
+		WillReturnResult(pgxmock.NewResult("DELETE", 4))
+	mock.ExpectExec(`DELETE FROM suggestion_cache WHERE TRUE$`).
+		WillReturnResult(pgxmock.NewResult("DELETE", 7))
+
+	tests := []struct {
+		filter CacheFilter
+		want   int64
+	}{
+		{CacheFilter{Provider: "freedict", Text: "dog"}, 1},
+		{CacheFilter{ExpiredOnly: true}, 4},
+		{CacheFilter{}, 7},
+	}
+	for _, tt := range tests {
+		n, err := repo.Delete(context.Background(), tt.filter)
+		if err != nil || n != tt.want {
+			t.Errorf("Delete(%+v) = %d, %v; want %d, nil", tt.filter, n, err, tt.want)
+		}
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
@@ -0,0 +1,111 @@
+// Package wiktionary содержит репозиторий офлайн-словаря из дампа Викисловаря.
+package wiktionary
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// WiktionaryRepository предоставляет доступ к статьям wiktionary_entries.
+// Статьи заполняются импортом дампа и только читаются.
+type WiktionaryRepository struct {
+	*base.Base[model.WiktionaryEntry]
+}
+
+// NewWiktionaryRepository создаёт новый репозиторий офлайн-словаря.
+func NewWiktionaryRepository(q database.Querier) *WiktionaryRepository {
+	return &WiktionaryRepository{
+		Base: base.MustNewBase[model.WiktionaryEntry](q, base.Config{
+			Table:   schema.WiktionaryEntries.Name.String(),
+			Columns: schema.WiktionaryEntries.Columns(),
+		}),
+	}
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// ListByWord возвращает статьи нормализованного слова в порядке дампа.
+//
+// Возвращает:
+//   - ErrInvalidInput: если language или word пустые
+func (r *WiktionaryRepository) ListByWord(ctx context.Context, language, word string) ([]model.WiktionaryEntry, error) {
+	if err := base.ValidateString(language, "language"); err != nil {
+		return nil, err
+	}
+	if err := base.ValidateString(word, "word"); err != nil {
+		return nil, err
+	}
+
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{
+			schema.WiktionaryEntries.Language.Bare():       language,
+			schema.WiktionaryEntries.WordNormalized.Bare(): word,
+		}).
+		OrderBy(schema.WiktionaryEntries.ID.Bare())
+	return r.List(ctx, query)
+}
+
+// ============================================================================
+// WRITE OPERATIONS
+// ============================================================================
+
+// InsertBatch добавляет статьи. Большие пачки разбиваются на запросы
+// по base.MaxBatchSize строк. ID заполняет БД; пустые поля JSONB
+// записываются пустыми массивами.
+func (r *WiktionaryRepository) InsertBatch(ctx context.Context, entries []model.WiktionaryEntry) error {
+	for start := 0; start < len(entries); start += base.MaxBatchSize {
+		insert := r.InsertBuilder().Columns(schema.WiktionaryEntries.InsertColumns()...)
+		for _, e := range entries[start:min(start+base.MaxBatchSize, len(entries))] {
+			insert = insert.Values(e.Language, e.Word, e.WordNormalized, e.POS,
+				jsonOrDefault(e.Senses), jsonOrDefault(e.Sounds), jsonOrDefault(e.Forms), synonymsOrEmpty(e.Synonyms))
+		}
+
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return database.WrapDBError(err)
+		}
+		if _, err := r.ExecRaw(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteByLanguage удаляет все статьи языка и возвращает их количество.
+//
+// Возвращает:
+//   - ErrInvalidInput: если language пустой
+func (r *WiktionaryRepository) DeleteByLanguage(ctx context.Context, language string) (int64, error) {
+	if err := base.ValidateString(language, "language"); err != nil {
+		return 0, err
+	}
+	return r.DeleteWhere(ctx, squirrel.Eq{schema.WiktionaryEntries.Language.Bare(): language})
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// jsonOrDefault заменяет пустое значение пустым массивом JSON.
+func jsonOrDefault(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return json.RawMessage(`[]`)
+	}
+	return data
+}
+
+// synonymsOrEmpty заменяет nil пустым массивом: колонка NOT NULL.
+func synonymsOrEmpty(synonyms []string) []string {
+	if synonyms == nil {
+		return []string{}
+	}
+	return synonyms
+}
@@ -0,0 +1,87 @@
+package wiktionary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var entryColumns = []string{"id", "language", "word", "word_normalized", "pos", "senses", "sounds", "forms", "synonyms"}
+
+func TestWiktionaryRepository_ListByWord(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWiktionaryRepository(querier)
+
+	senses := json.RawMessage(`[{"definition":"A mammal."}]`)
+	mock.ExpectQuery(`SELECT .+ FROM wiktionary_entries WHERE language = \$1 AND word_normalized = \$2 ORDER BY id`).
+		WithArgs("en", "dog").
+		WillReturnRows(pgxmock.NewRows(entryColumns).
+			AddRow(int64(1), "en", "dog", "dog", "noun", senses, json.RawMessage(`[]`), json.RawMessage(`[]`), []string{"hound"}).
+			AddRow(int64(2), "en", "dog", "dog", "verb", json.RawMessage(`[]`), json.RawMessage(`[]`), json.RawMessage(`[]`), []string{}))
+
+	got, err := repo.ListByWord(context.Background(), "en", "dog")
+	if err != nil {
+		t.Fatalf("ListByWord() error = %v", err)
+	}
+	if len(got) != 2 || got[0].POS != "noun" || got[1].POS != "verb" || got[0].Synonyms[0] != "hound" {
+		t.Errorf("ListByWord() = %+v", got)
+	}
+
+	// Пустое слово — без запроса
+	if _, err := repo.ListByWord(context.Background(), "en", ""); !errors.Is(err, database.ErrInvalidInput) {
+		t.Errorf("ListByWord() error = %v, want ErrInvalidInput", err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestWiktionaryRepository_InsertBatch(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWiktionaryRepository(querier)
+
+	entries := []model.WiktionaryEntry{
+		{Language: "en", Word: "dog", WordNormalized: "dog", POS: "noun",
+			Senses: json.RawMessage(`[{"definition":"A mammal."}]`), Synonyms: []string{"hound"}},
+		{Language: "en", Word: "Dog", WordNormalized: "dog", POS: "name"},
+	}
+	empty := json.RawMessage(`[]`)
+
+	mock.ExpectExec(`INSERT INTO wiktionary_entries \(language,word,word_normalized,pos,senses,sounds,forms,synonyms\) `+
+		`VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\),\(\$9,\$10,\$11,\$12,\$13,\$14,\$15,\$16\)$`).
+		WithArgs("en", "dog", "dog", "noun", json.RawMessage(`[{"definition":"A mammal."}]`), empty, empty, []string{"hound"},
+			"en", "Dog", "dog", "name", empty, empty, empty, []string{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	if err := repo.InsertBatch(context.Background(), entries); err != nil {
+		t.Fatalf("InsertBatch() error = %v", err)
+	}
+
+	// Пустая пачка — без запроса
+	if err := repo.InsertBatch(context.Background(), nil); err != nil {
+		t.Errorf("InsertBatch(nil) error = %v", err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestWiktionaryRepository_DeleteByLanguage(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWiktionaryRepository(querier)
+
+	mock.ExpectExec(`DELETE FROM wiktionary_entries WHERE language = \$1`).
+		WithArgs("en").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	n, err := repo.DeleteByLanguage(context.Background(), "en")
+	if err != nil || n != 3 {
+		t.Errorf("DeleteByLanguage() = %d, %v; want 3, nil", n, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
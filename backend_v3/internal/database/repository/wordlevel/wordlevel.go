@@ -0,0 +1,213 @@
+// Package wordlevel содержит репозиторий справочника уровней слов
+// (частотный ранг и уровень CEFR).
+package wordlevel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/model"
+)
+
+// ============================================================================
+// CONSTANTS
+// ============================================================================
+
+const (
+	// MinLemmaLength — минимальная длина леммы после отбрасывания окончания.
+	// Защищает от ложных совпадений вроде "is" -> "i".
+	MinLemmaLength = 3
+)
+
+// ============================================================================
+// DTO TYPES
+// ============================================================================
+
+// LevelCoverage — покрытие уровня CEFR словарём пользователя.
+type LevelCoverage struct {
+	Level        string `db:"level"`
+	TotalWords   int    `db:"total_words"`   // Слов уровня в справочнике
+	InDictionary int    `db:"in_dictionary"` // Из них есть в словаре
+	Known        int    `db:"known"`         // Из них выучено (карточка в REVIEW или MASTERED)
+}
+
+// ============================================================================
+// REPOSITORY
+// ============================================================================
+
+// WordLevelRepository предоставляет доступ к справочнику word_levels.
+// Справочник заполняется миграцией и только читается.
+type WordLevelRepository struct {
+	*base.Base[model.WordLevel]
+}
+
+// NewWordLevelRepository создаёт новый репозиторий справочника уровней.
+func NewWordLevelRepository(q database.Querier) *WordLevelRepository {
+	return &WordLevelRepository{
+		Base: base.MustNewBase[model.WordLevel](q, base.Config{
+			Table:   schema.WordLevels.Name.String(),
+			Columns: schema.WordLevels.Columns(),
+		}),
+	}
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// Lookup находит уровень нормализованного слова.
+// Если самого слова нет в справочнике, пробует его вероятные леммы (см. Candidates).
+//
+// Возвращает:
+//   - ErrNotFound: если ни слова, ни его лемм нет в справочнике
+//   - ErrInvalidInput: если word пустой
+func (r *WordLevelRepository) Lookup(ctx context.Context, word string) (*model.WordLevel, error) {
+	if word == "" {
+		return nil, fmt.Errorf("%w: word is required", database.ErrInvalidInput)
+	}
+
+	candidates := Candidates(word)
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.WordLevels.Word.Bare(): candidates})
+
+	levels, err := r.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Предпочитаем точное совпадение, затем леммы в порядке Candidates
+	byWord := make(map[string]*model.WordLevel, len(levels))
+	for i := range levels {
+		byWord[levels[i].Word] = &levels[i]
+	}
+	for _, c := range candidates {
+		if level, ok := byWord[c]; ok {
+			return level, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// ListByWords возвращает строки справочника для слов words (без лемматизации).
+// Слов, которых нет в справочнике, в результате нет.
+func (r *WordLevelRepository) ListByWords(ctx context.Context, words []string) ([]model.WordLevel, error) {
+	if len(words) == 0 {
+		return []model.WordLevel{}, nil
+	}
+	query := r.SelectBuilder().
+		Where(squirrel.Eq{schema.WordLevels.Word.Bare(): words})
+	return r.List(ctx, query)
+}
+
+// MaxFrequencyRank возвращает наибольший частотный ранг справочника,
+// то есть размер частотного списка; 0, если список пуст.
+func (r *WordLevelRepository) MaxFrequencyRank(ctx context.Context) (int, error) {
+	var rank int
+	sql := `SELECT COALESCE(MAX(frequency_rank), 0) FROM word_levels`
+	if err := r.QueryRowRaw(ctx, &rank, sql); err != nil {
+		return 0, err
+	}
+	return rank, nil
+}
+
+// GetCoverage возвращает покрытие каждого уровня CEFR словарём пользователя.
+// Слово справочника считается присутствующим в словаре при точном совпадении
+// с text_normalized активной английской записи. Уровни сортируются от A1 к C2.
+func (r *WordLevelRepository) GetCoverage(ctx context.Context) ([]LevelCoverage, error) {
+	sql := `
+		SELECT
+			wl.cefr_level AS level,
+			COUNT(*)::int AS total_words,
+			COUNT(de.id)::int AS in_dictionary,
+			COUNT(c.id) FILTER (WHERE c.status IN ('REVIEW', 'MASTERED'))::int AS known
+		FROM word_levels wl
+		LEFT JOIN dictionary_entries de ON de.language = 'en' AND de.text_normalized = wl.word AND de.deleted_at IS NULL
+		LEFT JOIN cards c ON c.entry_id = de.id
+		GROUP BY wl.cefr_level
+		ORDER BY wl.cefr_level ASC
+	`
+
+	coverage := make([]LevelCoverage, 0, len(model.CefrLevels))
+	if err := r.QueryRaw(ctx, &coverage, sql); err != nil {
+		return nil, err
+	}
+	return coverage, nil
+}
+
+// ============================================================================
+// LEMMATIZATION
+// ============================================================================
+
+// Candidates возвращает формы слова для поиска в справочнике: само слово,
+// затем возможные леммы после отбрасывания регулярных окончаний
+// (studies -> study, boxes -> box, stopped -> stop, making -> make, quickly -> quick).
+// Неправильные формы (went, children) не распознаются.
+func Candidates(word string) []string {
+	result := []string{word}
+	seen := map[string]bool{word: true}
+	add := func(lemma string) {
+		if len(lemma) >= MinLemmaLength && !seen[lemma] {
+			seen[lemma] = true
+			result = append(result, lemma)
+		}
+	}
+
+	// Многословные выражения не лемматизируем
+	if strings.ContainsAny(word, " -'") {
+		return result
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		add(strings.TrimSuffix(word, "ies") + "y")
+	case strings.HasSuffix(word, "es"):
+		add(strings.TrimSuffix(word, "es"))
+		add(strings.TrimSuffix(word, "s"))
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		add(strings.TrimSuffix(word, "s"))
+	case strings.HasSuffix(word, "ied"):
+		add(strings.TrimSuffix(word, "ied") + "y")
+	case strings.HasSuffix(word, "ed"):
+		stem := strings.TrimSuffix(word, "ed")
+		add(stem)
+		add(stem + "e")
+		add(undouble(stem))
+	case strings.HasSuffix(word, "ing"):
+		stem := strings.TrimSuffix(word, "ing")
+		add(stem)
+		add(stem + "e")
+		add(undouble(stem))
+	case strings.HasSuffix(word, "ier"):
+		add(strings.TrimSuffix(word, "ier") + "y")
+	case strings.HasSuffix(word, "iest"):
+		add(strings.TrimSuffix(word, "iest") + "y")
+	case strings.HasSuffix(word, "est"):
+		stem := strings.TrimSuffix(word, "est")
+		add(stem)
+		add(stem + "e")
+		add(undouble(stem))
+	case strings.HasSuffix(word, "er"):
+		stem := strings.TrimSuffix(word, "er")
+		add(stem)
+		add(stem + "e")
+		add(undouble(stem))
+	case strings.HasSuffix(word, "ly"):
+		add(strings.TrimSuffix(word, "ly"))
+	}
+
+	return result
+}
+
+// undouble убирает удвоенную согласную на конце основы (stopp -> stop).
+func undouble(stem string) string {
+	n := len(stem)
+	if n >= 2 && stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiou", rune(stem[n-1])) {
+		return stem[:n-1]
+	}
+	return stem
+}
@@ -0,0 +1,187 @@
+package wordlevel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/database/testutil"
+	"github.com/heartmarshall/my-english/internal/model"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+)
+
+var wordLevelColumns = []string{"word", "frequency_rank", "cefr_level"}
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{word: "house", want: []string{"house"}},
+		{word: "studies", want: []string{"studies", "study"}},
+		{word: "boxes", want: []string{"boxes", "box", "boxe"}},
+		{word: "stopped", want: []string{"stopped", "stopp", "stoppe", "stop"}},
+		{word: "making", want: []string{"making", "mak", "make"}},
+		{word: "happier", want: []string{"happier", "happy"}},
+		{word: "quickly", want: []string{"quickly", "quick"}},
+		{word: "is", want: []string{"is"}},
+		{word: "give up", want: []string{"give up"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := Candidates(tt.word)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Candidates(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordLevelRepository_Lookup(t *testing.T) {
+	rank := 120
+
+	tests := []struct {
+		name     string
+		word     string
+		setup    func(mock pgxmock.PgxPoolIface)
+		wantWord string
+		wantErr  bool
+	}{
+		{
+			name: "exact match preferred over lemma",
+			word: "works",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(wordLevelColumns).
+					AddRow("work", &rank, model.CefrA1).
+					AddRow("works", nil, model.CefrB1)
+				mock.ExpectQuery(`SELECT .+ FROM word_levels WHERE word IN \(\$1,\$2\)`).
+					WithArgs("works", "work").
+					WillReturnRows(rows)
+			},
+			wantWord: "works",
+			wantErr:  false,
+		},
+		{
+			name: "lemma match",
+			word: "studies",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(wordLevelColumns).
+					AddRow("study", &rank, model.CefrA1)
+				mock.ExpectQuery(`SELECT .+ FROM word_levels`).
+					WithArgs("studies", "study").
+					WillReturnRows(rows)
+			},
+			wantWord: "study",
+			wantErr:  false,
+		},
+		{
+			name: "not found",
+			word: "zyzzyva",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`SELECT .+ FROM word_levels`).
+					WithArgs("zyzzyva").
+					WillReturnRows(pgxmock.NewRows(wordLevelColumns))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "empty word",
+			word:    "",
+			setup:   func(mock pgxmock.PgxPoolIface) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier, mock := testutil.NewMockQuerier(t)
+			repo := NewWordLevelRepository(querier)
+
+			tt.setup(mock)
+
+			ctx := context.Background()
+			got, err := repo.Lookup(ctx, tt.word)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && got.Word != tt.wantWord {
+				t.Errorf("Lookup() = %q, want %q", got.Word, tt.wantWord)
+			}
+
+			testutil.ExpectationsWereMet(t, mock)
+		})
+	}
+}
+
+func TestWordLevelRepository_ListByWords(t *testing.T) {
+	rank := 300
+
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWordLevelRepository(querier)
+
+	mock.ExpectQuery(`SELECT .+ FROM word_levels WHERE word IN \(\$1,\$2\)`).
+		WithArgs("weather", "zyzzyva").
+		WillReturnRows(pgxmock.NewRows(wordLevelColumns).AddRow("weather", &rank, model.CefrA1))
+
+	got, err := repo.ListByWords(context.Background(), []string{"weather", "zyzzyva"})
+	if err != nil {
+		t.Fatalf("ListByWords() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Word != "weather" || *got[0].FrequencyRank != rank {
+		t.Errorf("ListByWords() = %+v, want weather with rank %d", got, rank)
+	}
+
+	// Пустой список — без запроса
+	got, err = repo.ListByWords(context.Background(), nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListByWords(nil) = %v, %v", got, err)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestWordLevelRepository_MaxFrequencyRank(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWordLevelRepository(querier)
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(frequency_rank\), 0\) FROM word_levels`).
+		WillReturnRows(pgxmock.NewRows([]string{"coalesce"}).AddRow(2000))
+
+	got, err := repo.MaxFrequencyRank(context.Background())
+	if err != nil {
+		t.Fatalf("MaxFrequencyRank() error = %v", err)
+	}
+	if got != 2000 {
+		t.Errorf("MaxFrequencyRank() = %d, want 2000", got)
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
+
+func TestWordLevelRepository_GetCoverage(t *testing.T) {
+	querier, mock := testutil.NewMockQuerier(t)
+	repo := NewWordLevelRepository(querier)
+
+	rows := pgxmock.NewRows([]string{"level", "total_words", "in_dictionary", "known"}).
+		AddRow(model.CefrA1, 500, 120, 80).
+		AddRow(model.CefrA2, 500, 30, 10)
+	mock.ExpectQuery(`SELECT .+ FROM word_levels wl LEFT JOIN dictionary_entries .+ GROUP BY wl.cefr_level`).
+		WillReturnRows(rows)
+
+	got, err := repo.GetCoverage(context.Background())
+	if err != nil {
+		t.Fatalf("GetCoverage() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetCoverage() returned %d levels, want 2", len(got))
+	}
+	if got[0].Level != model.CefrA1 || got[0].Known != 80 {
+		t.Errorf("GetCoverage()[0] = %+v", got[0])
+	}
+
+	testutil.ExpectationsWereMet(t, mock)
+}
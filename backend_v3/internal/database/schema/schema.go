@@ -59,8 +59,16 @@ type DictionaryEntriesTable struct {
 	ID             Column
 	Text           Column
 	TextNormalized Column
+	Language       Column
+	FrequencyRank  Column
+	CefrLevel      Column
+	Notes          Column
+	NotesOnCard    Column
+	Tags           Column
+	List           Column
 	CreatedAt      Column
 	UpdatedAt      Column
+	DeletedAt      Column
 }
 
 var DictionaryEntries = DictionaryEntriesTable{
@@ -68,19 +76,54 @@ var DictionaryEntries = DictionaryEntriesTable{
 	ID:             "dictionary_entries.id",
 	Text:           "dictionary_entries.text",
 	TextNormalized: "dictionary_entries.text_normalized",
+	Language:       "dictionary_entries.language",
+	FrequencyRank:  "dictionary_entries.frequency_rank",
+	CefrLevel:      "dictionary_entries.cefr_level",
+	Notes:          "dictionary_entries.notes",
+	NotesOnCard:    "dictionary_entries.notes_on_card",
+	Tags:           "dictionary_entries.tags",
+	List:           "dictionary_entries.list",
 	CreatedAt:      "dictionary_entries.created_at",
 	UpdatedAt:      "dictionary_entries.updated_at",
+	DeletedAt:      "dictionary_entries.deleted_at",
 }
 
 func (t DictionaryEntriesTable) Columns() []string {
 	return []string{
-		string(t.ID), string(t.Text), string(t.TextNormalized),
-		string(t.CreatedAt), string(t.UpdatedAt),
+		string(t.ID), string(t.Text), string(t.TextNormalized), string(t.Language),
+		string(t.FrequencyRank), string(t.CefrLevel), string(t.Notes), string(t.NotesOnCard),
+		string(t.Tags), string(t.List),
+		string(t.CreatedAt), string(t.UpdatedAt), string(t.DeletedAt),
 	}
 }
 
 func (t DictionaryEntriesTable) InsertColumns() []string {
-	return []string{"text", "text_normalized"}
+	return []string{"text", "text_normalized", "language", "frequency_rank", "cefr_level", "notes", "notes_on_card"}
+}
+
+// NotDeleted возвращает условие, исключающее записи из корзины.
+func (t DictionaryEntriesTable) NotDeleted() squirrel.Eq {
+	return squirrel.Eq{t.DeletedAt.Bare(): nil}
+}
+
+// ActiveEntry возвращает условие для дочерних таблиц: строка принадлежит
+// словарной записи, которая не находится в корзине.
+// entryIDColumn — колонка со ссылкой на dictionary_entries.id.
+func ActiveEntry(entryIDColumn Column) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"EXISTS (SELECT 1 FROM " + DictionaryEntries.Name.String() + " de" +
+			" WHERE de.id = " + string(entryIDColumn) + " AND de.deleted_at IS NULL)",
+	)
+}
+
+// ActiveSense — аналог ActiveEntry для таблиц, ссылающихся на senses.
+// senseIDColumn — колонка со ссылкой на senses.id.
+func ActiveSense(senseIDColumn Column) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"EXISTS (SELECT 1 FROM " + Senses.Name.String() + " s" +
+			" JOIN " + DictionaryEntries.Name.String() + " de ON de.id = s.entry_id" +
+			" WHERE s.id = " + string(senseIDColumn) + " AND de.deleted_at IS NULL)",
+	)
 }
 
 // ============================================================================
@@ -95,6 +138,7 @@ type SensesTable struct {
 	PartOfSpeech Column
 	SourceSlug   Column
 	CefrLevel    Column
+	Notes        Column
 	CreatedAt    Column
 }
 
@@ -106,6 +150,7 @@ var Senses = SensesTable{
 	PartOfSpeech: "senses.part_of_speech",
 	SourceSlug:   "senses.source_slug",
 	CefrLevel:    "senses.cefr_level",
+	Notes:        "senses.notes",
 	CreatedAt:    "senses.created_at",
 }
 
@@ -113,12 +158,12 @@ func (t SensesTable) Columns() []string {
 	return []string{
 		string(t.ID), string(t.EntryID), string(t.Definition),
 		string(t.PartOfSpeech), string(t.SourceSlug), string(t.CefrLevel),
-		string(t.CreatedAt),
+		string(t.Notes), string(t.CreatedAt),
 	}
 }
 
 func (t SensesTable) InsertColumns() []string {
-	return []string{"entry_id", "definition", "part_of_speech", "source_slug", "cefr_level"}
+	return []string{"entry_id", "definition", "part_of_speech", "source_slug", "cefr_level", "notes"}
 }
 
 // ============================================================================
@@ -130,6 +175,7 @@ type TranslationsTable struct {
 	ID         Column
 	SenseID    Column
 	Text       Column
+	Language   Column
 	SourceSlug Column
 }
 
@@ -138,17 +184,18 @@ var Translations = TranslationsTable{
 	ID:         "translations.id",
 	SenseID:    "translations.sense_id",
 	Text:       "translations.text",
+	Language:   "translations.language",
 	SourceSlug: "translations.source_slug",
 }
 
 func (t TranslationsTable) Columns() []string {
 	return []string{
-		string(t.ID), string(t.SenseID), string(t.Text), string(t.SourceSlug),
+		string(t.ID), string(t.SenseID), string(t.Text), string(t.Language), string(t.SourceSlug),
 	}
 }
 
 func (t TranslationsTable) InsertColumns() []string {
-	return []string{"sense_id", "text", "source_slug"}
+	return []string{"sense_id", "text", "language", "source_slug"}
 }
 
 // ============================================================================
@@ -186,6 +233,43 @@ func (t ExamplesTable) InsertColumns() []string {
 	return []string{"sense_id", "sentence", "translation", "source_slug"}
 }
 
+// ============================================================================
+// SENSE RELATIONS
+// ============================================================================
+
+type SenseRelationsTable struct {
+	Name                 Table
+	ID                   Column
+	SenseID              Column
+	Type                 Column
+	TargetText           Column
+	TargetTextNormalized Column
+	SourceSlug           Column
+	CreatedAt            Column
+}
+
+var SenseRelations = SenseRelationsTable{
+	Name:                 "sense_relations",
+	ID:                   "sense_relations.id",
+	SenseID:              "sense_relations.sense_id",
+	Type:                 "sense_relations.type",
+	TargetText:           "sense_relations.target_text",
+	TargetTextNormalized: "sense_relations.target_text_normalized",
+	SourceSlug:           "sense_relations.source_slug",
+	CreatedAt:            "sense_relations.created_at",
+}
+
+func (t SenseRelationsTable) Columns() []string {
+	return []string{
+		string(t.ID), string(t.SenseID), string(t.Type), string(t.TargetText),
+		string(t.TargetTextNormalized), string(t.SourceSlug), string(t.CreatedAt),
+	}
+}
+
+func (t SenseRelationsTable) InsertColumns() []string {
+	return []string{"sense_id", "type", "target_text", "target_text_normalized", "source_slug"}
+}
+
 // ============================================================================
 // IMAGES
 // ============================================================================
@@ -197,6 +281,7 @@ type ImagesTable struct {
 	URL        Column
 	Caption    Column
 	SourceSlug Column
+	MediaID    Column
 }
 
 var Images = ImagesTable{
@@ -206,17 +291,18 @@ var Images = ImagesTable{
 	URL:        "images.url",
 	Caption:    "images.caption",
 	SourceSlug: "images.source_slug",
+	MediaID:    "images.media_id",
 }
 
 func (t ImagesTable) Columns() []string {
 	return []string{
 		string(t.ID), string(t.EntryID), string(t.URL),
-		string(t.Caption), string(t.SourceSlug),
+		string(t.Caption), string(t.SourceSlug), string(t.MediaID),
 	}
 }
 
 func (t ImagesTable) InsertColumns() []string {
-	return []string{"entry_id", "url", "caption", "source_slug"}
+	return []string{"entry_id", "url", "caption", "source_slug", "media_id"}
 }
 
 // ============================================================================
@@ -231,6 +317,7 @@ type PronunciationsTable struct {
 	Transcription Column
 	Region        Column
 	SourceSlug    Column
+	MediaID       Column
 }
 
 var Pronunciations = PronunciationsTable{
@@ -241,17 +328,19 @@ var Pronunciations = PronunciationsTable{
 	Transcription: "pronunciations.transcription",
 	Region:        "pronunciations.region",
 	SourceSlug:    "pronunciations.source_slug",
+	MediaID:       "pronunciations.media_id",
 }
 
 func (t PronunciationsTable) Columns() []string {
 	return []string{
 		string(t.ID), string(t.EntryID), string(t.AudioURL),
 		string(t.Transcription), string(t.Region), string(t.SourceSlug),
+		string(t.MediaID),
 	}
 }
 
 func (t PronunciationsTable) InsertColumns() []string {
-	return []string{"entry_id", "audio_url", "transcription", "region", "source_slug"}
+	return []string{"entry_id", "audio_url", "transcription", "region", "source_slug", "media_id"}
 }
 
 // ============================================================================
@@ -399,8 +488,10 @@ type AuditRecordsTable struct {
 	ID         Column
 	EntityType Column
 	EntityID   Column
+	EntryID    Column
 	Action     Column
 	Changes    Column
+	Snapshot   Column
 	CreatedAt  Column
 }
 
@@ -409,18 +500,257 @@ var AuditRecords = AuditRecordsTable{
 	ID:         "audit_records.id",
 	EntityType: "audit_records.entity_type",
 	EntityID:   "audit_records.entity_id",
+	EntryID:    "audit_records.entry_id",
 	Action:     "audit_records.action",
 	Changes:    "audit_records.changes",
+	Snapshot:   "audit_records.snapshot",
 	CreatedAt:  "audit_records.created_at",
 }
 
 func (t AuditRecordsTable) Columns() []string {
 	return []string{
-		string(t.ID), string(t.EntityType), string(t.EntityID),
-		string(t.Action), string(t.Changes), string(t.CreatedAt),
+		string(t.ID), string(t.EntityType), string(t.EntityID), string(t.EntryID),
+		string(t.Action), string(t.Changes), string(t.Snapshot), string(t.CreatedAt),
 	}
 }
 
 func (t AuditRecordsTable) InsertColumns() []string {
-	return []string{"entity_type", "entity_id", "action", "changes"}
+	return []string{"entity_type", "entity_id", "entry_id", "action", "changes", "snapshot"}
+}
+
+// ============================================================================
+// MEDIA
+// ============================================================================
+
+type MediaTable struct {
+	Name            Table
+	ID              Column
+	SHA256          Column
+	ContentType     Column
+	SizeBytes       Column
+	SourceURL       Column
+	Width           Column
+	Height          Column
+	ThumbnailID     Column
+	CardID          Column
+	ProcessedAt     Column
+	ProcessingError Column
+	CreatedAt       Column
+}
+
+var Media = MediaTable{
+	Name:            "media",
+	ID:              "media.id",
+	SHA256:          "media.sha256",
+	ContentType:     "media.content_type",
+	SizeBytes:       "media.size_bytes",
+	SourceURL:       "media.source_url",
+	Width:           "media.width",
+	Height:          "media.height",
+	ThumbnailID:     "media.thumbnail_id",
+	CardID:          "media.card_id",
+	ProcessedAt:     "media.processed_at",
+	ProcessingError: "media.processing_error",
+	CreatedAt:       "media.created_at",
+}
+
+func (t MediaTable) Columns() []string {
+	return []string{
+		string(t.ID), string(t.SHA256), string(t.ContentType),
+		string(t.SizeBytes), string(t.SourceURL), string(t.Width),
+		string(t.Height), string(t.ThumbnailID), string(t.CardID),
+		string(t.ProcessedAt), string(t.ProcessingError), string(t.CreatedAt),
+	}
+}
+
+func (t MediaTable) InsertColumns() []string {
+	return []string{"sha256", "content_type", "size_bytes", "source_url"}
+}
+
+// ============================================================================
+// IMPORT JOBS
+// ============================================================================
+
+type ImportJobsTable struct {
+	Name          Table
+	ID            Column
+	Format        Column
+	Status        Column
+	Options       Column
+	Rows          Column
+	TotalRows     Column
+	ProcessedRows Column
+	CreatedCount  Column
+	MergedCount   Column
+	SkippedCount  Column
+	FailedCount   Column
+	RowErrors     Column
+	Error         Column
+	CreatedAt     Column
+	UpdatedAt     Column
+	FinishedAt    Column
+}
+
+var ImportJobs = ImportJobsTable{
+	Name:          "import_jobs",
+	ID:            "import_jobs.id",
+	Format:        "import_jobs.format",
+	Status:        "import_jobs.status",
+	Options:       "import_jobs.options",
+	Rows:          "import_jobs.rows",
+	TotalRows:     "import_jobs.total_rows",
+	ProcessedRows: "import_jobs.processed_rows",
+	CreatedCount:  "import_jobs.created_count",
+	MergedCount:   "import_jobs.merged_count",
+	SkippedCount:  "import_jobs.skipped_count",
+	FailedCount:   "import_jobs.failed_count",
+	RowErrors:     "import_jobs.row_errors",
+	Error:         "import_jobs.error",
+	CreatedAt:     "import_jobs.created_at",
+	UpdatedAt:     "import_jobs.updated_at",
+	FinishedAt:    "import_jobs.finished_at",
+}
+
+func (t ImportJobsTable) Columns() []string {
+	return []string{
+		string(t.ID), string(t.Format), string(t.Status), string(t.Options),
+		string(t.Rows), string(t.TotalRows), string(t.ProcessedRows),
+		string(t.CreatedCount), string(t.MergedCount), string(t.SkippedCount),
+		string(t.FailedCount), string(t.RowErrors), string(t.Error),
+		string(t.CreatedAt), string(t.UpdatedAt), string(t.FinishedAt),
+	}
+}
+
+func (t ImportJobsTable) InsertColumns() []string {
+	return []string{
+		"format", "status", "options", "rows", "total_rows",
+		"skipped_count", "failed_count", "row_errors",
+	}
+}
+
+// ============================================================================
+// IMPORT CHECKPOINTS
+// ============================================================================
+
+type ImportCheckpointsTable struct {
+	Name          Table
+	Source        Column
+	ImportedUntil Column
+	CreatedAt     Column
+	UpdatedAt     Column
+}
+
+var ImportCheckpoints = ImportCheckpointsTable{
+	Name:          "import_checkpoints",
+	Source:        "import_checkpoints.source",
+	ImportedUntil: "import_checkpoints.imported_until",
+	CreatedAt:     "import_checkpoints.created_at",
+	UpdatedAt:     "import_checkpoints.updated_at",
+}
+
+func (t ImportCheckpointsTable) Columns() []string {
+	return []string{
+		string(t.Source), string(t.ImportedUntil), string(t.CreatedAt), string(t.UpdatedAt),
+	}
+}
+
+func (t ImportCheckpointsTable) InsertColumns() []string {
+	return []string{"source", "imported_until"}
+}
+
+// ============================================================================
+// WORD LEVELS
+// ============================================================================
+
+type WordLevelsTable struct {
+	Name          Table
+	Word          Column
+	FrequencyRank Column
+	CefrLevel     Column
+}
+
+var WordLevels = WordLevelsTable{
+	Name:          "word_levels",
+	Word:          "word_levels.word",
+	FrequencyRank: "word_levels.frequency_rank",
+	CefrLevel:     "word_levels.cefr_level",
+}
+
+func (t WordLevelsTable) Columns() []string {
+	return []string{
+		string(t.Word), string(t.FrequencyRank), string(t.CefrLevel),
+	}
+}
+
+// ============================================================================
+// WIKTIONARY
+// ============================================================================
+
+type WiktionaryEntriesTable struct {
+	Name           Table
+	ID             Column
+	Language       Column
+	Word           Column
+	WordNormalized Column
+	POS            Column
+	Senses         Column
+	Sounds         Column
+	Forms          Column
+	Synonyms       Column
+}
+
+var WiktionaryEntries = WiktionaryEntriesTable{
+	Name:           "wiktionary_entries",
+	ID:             "wiktionary_entries.id",
+	Language:       "wiktionary_entries.language",
+	Word:           "wiktionary_entries.word",
+	WordNormalized: "wiktionary_entries.word_normalized",
+	POS:            "wiktionary_entries.pos",
+	Senses:         "wiktionary_entries.senses",
+	Sounds:         "wiktionary_entries.sounds",
+	Forms:          "wiktionary_entries.forms",
+	Synonyms:       "wiktionary_entries.synonyms",
+}
+
+func (t WiktionaryEntriesTable) Columns() []string {
+	return []string{
+		string(t.ID), string(t.Language), string(t.Word), string(t.WordNormalized),
+		string(t.POS), string(t.Senses), string(t.Sounds), string(t.Forms), string(t.Synonyms),
+	}
+}
+
+func (t WiktionaryEntriesTable) InsertColumns() []string {
+	return []string{"language", "word", "word_normalized", "pos", "senses", "sounds", "forms", "synonyms"}
+}
+
+// ============================================================================
+// SUGGESTION CACHE
+// ============================================================================
+
+type SuggestionCacheTable struct {
+	Name           Table
+	Provider       Column
+	TextNormalized Column
+	Result         Column
+	FetchedAt      Column
+	ExpiresAt      Column
+}
+
+var SuggestionCache = SuggestionCacheTable{
+	Name:           "suggestion_cache",
+	Provider:       "suggestion_cache.provider",
+	TextNormalized: "suggestion_cache.text_normalized",
+	Result:         "suggestion_cache.result",
+	FetchedAt:      "suggestion_cache.fetched_at",
+	ExpiresAt:      "suggestion_cache.expires_at",
+}
+
+func (t SuggestionCacheTable) Columns() []string {
+	return []string{
+		string(t.Provider), string(t.TextNormalized), string(t.Result), string(t.FetchedAt), string(t.ExpiresAt),
+	}
+}
+
+func (t SuggestionCacheTable) InsertColumns() []string {
+	return []string{"provider", "text_normalized", "result", "fetched_at", "expires_at"}
 }
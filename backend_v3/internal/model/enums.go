@@ -22,6 +22,26 @@ const (
 	PosOther        PartOfSpeech = "OTHER"
 )
 
+// RelationType corresponds to sense_relations.type
+type RelationType string
+
+const (
+	RelationSynonym     RelationType = "SYNONYM"
+	RelationAntonym     RelationType = "ANTONYM"
+	RelationRelated     RelationType = "RELATED"
+	RelationCollocation RelationType = "COLLOCATION"
+	RelationHypernym    RelationType = "HYPERNYM"
+)
+
+// IsValid reports whether the relation type is known
+func (t RelationType) IsValid() bool {
+	switch t {
+	case RelationSynonym, RelationAntonym, RelationRelated, RelationCollocation, RelationHypernym:
+		return true
+	}
+	return false
+}
+
 // LearningStatus corresponds to the Postgres ENUM learning_status
 type LearningStatus string
 
@@ -63,6 +83,21 @@ const (
 	ActionDelete AuditAction = "DELETE"
 )
 
+// ImportJobStatus corresponds to import_jobs.status
+type ImportJobStatus string
+
+const (
+	ImportPending   ImportJobStatus = "PENDING"
+	ImportRunning   ImportJobStatus = "RUNNING"
+	ImportCompleted ImportJobStatus = "COMPLETED"
+	ImportFailed    ImportJobStatus = "FAILED"
+)
+
+// IsFinished reports whether the job will not be processed anymore
+func (s ImportJobStatus) IsFinished() bool {
+	return s == ImportCompleted || s == ImportFailed
+}
+
 // Validate checks if the Enum value is valid (useful for transport->service mapping)
 func (s LearningStatus) IsValid() bool {
 	switch s {
@@ -112,3 +147,16 @@ const (
 	SortDirAsc  SortDirection = "ASC"
 	SortDirDesc SortDirection = "DESC"
 )
+
+// CEFR levels of words (senses.cefr_level, dictionary_entries.cefr_level)
+const (
+	CefrA1 = "A1"
+	CefrA2 = "A2"
+	CefrB1 = "B1"
+	CefrB2 = "B2"
+	CefrC1 = "C1"
+	CefrC2 = "C2"
+)
+
+// CefrLevels lists all CEFR levels from easiest to hardest
+var CefrLevels = []string{CefrA1, CefrA2, CefrB1, CefrB2, CefrC1, CefrC2}
@@ -14,11 +14,19 @@ import (
 // ============================================================================
 
 type DictionaryEntry struct {
-	ID             uuid.UUID `db:"id" json:"id"`
-	Text           string    `db:"text" json:"text"`
-	TextNormalized string    `db:"text_normalized" json:"text_normalized"`
-	CreatedAt      time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	ID             uuid.UUID  `db:"id" json:"id"`
+	Text           string     `db:"text" json:"text"`
+	TextNormalized string     `db:"text_normalized" json:"text_normalized"`
+	Language       string     `db:"language" json:"language"`             // ISO 639, изучаемый язык
+	FrequencyRank  *int       `db:"frequency_rank" json:"frequency_rank"` // Nullable, слова нет в частотном списке
+	CefrLevel      *string    `db:"cefr_level" json:"cefr_level"`         // Nullable, оценка по справочнику word_levels
+	Notes          *string    `db:"notes" json:"notes"`                   // Nullable, личные заметки в Markdown
+	NotesOnCard    bool       `db:"notes_on_card" json:"notes_on_card"`   // Показывать заметки на обратной стороне карточки
+	Tags           []string   `db:"tags" json:"tags"`                     // Метки, отсортированные и без повторов
+	List           *string    `db:"list" json:"list"`                     // Nullable, список, в котором лежит слово
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt      *time.Time `db:"deleted_at" json:"deleted_at"` // Nullable, запись в корзине
 }
 
 type Sense struct {
@@ -28,6 +36,7 @@ type Sense struct {
 	PartOfSpeech *PartOfSpeech `db:"part_of_speech" json:"part_of_speech"`
 	SourceSlug   string        `db:"source_slug" json:"source_slug"`
 	CefrLevel    *string       `db:"cefr_level" json:"cefr_level"`
+	Notes        *string       `db:"notes" json:"notes"` // Nullable, личные заметки в Markdown
 	CreatedAt    time.Time     `db:"created_at" json:"created_at"`
 }
 
@@ -35,6 +44,7 @@ type Translation struct {
 	ID         uuid.UUID `db:"id" json:"id"`
 	SenseID    uuid.UUID `db:"sense_id" json:"sense_id"`
 	Text       string    `db:"text" json:"text"`
+	Language   string    `db:"language" json:"language"` // ISO 639
 	SourceSlug string    `db:"source_slug" json:"source_slug"`
 }
 
@@ -47,21 +57,36 @@ type Example struct {
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 }
 
+// SenseRelation — связь смысла со словом (синоним, антоним, гипероним...).
+// Слово хранится текстом: запись словаря находится по TargetTextNormalized
+// в языке записи смысла.
+type SenseRelation struct {
+	ID                   uuid.UUID    `db:"id" json:"id"`
+	SenseID              uuid.UUID    `db:"sense_id" json:"sense_id"`
+	Type                 RelationType `db:"type" json:"type"`
+	TargetText           string       `db:"target_text" json:"target_text"`
+	TargetTextNormalized string       `db:"target_text_normalized" json:"target_text_normalized"`
+	SourceSlug           string       `db:"source_slug" json:"source_slug"`
+	CreatedAt            time.Time    `db:"created_at" json:"created_at"`
+}
+
 type Image struct {
-	ID         uuid.UUID `db:"id" json:"id"`
-	EntryID    uuid.UUID `db:"entry_id" json:"entry_id"`
-	URL        string    `db:"url" json:"url"`
-	Caption    *string   `db:"caption" json:"caption"`
-	SourceSlug string    `db:"source_slug" json:"source_slug"`
+	ID         uuid.UUID  `db:"id" json:"id"`
+	EntryID    uuid.UUID  `db:"entry_id" json:"entry_id"`
+	URL        string     `db:"url" json:"url"`
+	Caption    *string    `db:"caption" json:"caption"`
+	SourceSlug string     `db:"source_slug" json:"source_slug"`
+	MediaID    *uuid.UUID `db:"media_id" json:"media_id"` // Nullable, файл в хранилище приложения
 }
 
 type Pronunciation struct {
-	ID            uuid.UUID `db:"id" json:"id"`
-	EntryID       uuid.UUID `db:"entry_id" json:"entry_id"`
-	AudioURL      string    `db:"audio_url" json:"audio_url"`
-	Transcription *string   `db:"transcription" json:"transcription"`
-	Region        *string   `db:"region" json:"region"`
-	SourceSlug    string    `db:"source_slug" json:"source_slug"`
+	ID            uuid.UUID  `db:"id" json:"id"`
+	EntryID       uuid.UUID  `db:"entry_id" json:"entry_id"`
+	AudioURL      string     `db:"audio_url" json:"audio_url"`
+	Transcription *string    `db:"transcription" json:"transcription"`
+	Region        *string    `db:"region" json:"region"`
+	SourceSlug    string     `db:"source_slug" json:"source_slug"`
+	MediaID       *uuid.UUID `db:"media_id" json:"media_id"` // Nullable, файл в хранилище приложения
 }
 
 // ============================================================================
@@ -110,11 +135,111 @@ type AuditRecord struct {
 	ID         uuid.UUID   `db:"id" json:"id"`
 	EntityType EntityType  `db:"entity_type" json:"entity_type"`
 	EntityID   *uuid.UUID  `db:"entity_id" json:"entity_id"`
+	EntryID    *uuid.UUID  `db:"entry_id" json:"entry_id"` // Слово, к которому относится сущность
 	Action     AuditAction `db:"action" json:"action"`
-	Changes    JSON        `db:"changes" json:"changes"` // JSONB
+	Changes    JSON        `db:"changes" json:"changes"`   // JSONB
+	Snapshot   JSON        `db:"snapshot" json:"snapshot"` // JSONB, nullable: полный снимок контента слова
 	CreatedAt  time.Time   `db:"created_at" json:"created_at"`
 }
 
+// ============================================================================
+// MEDIA
+// ============================================================================
+
+// Media — файл (изображение или аудио), сохранённый в хранилище приложения.
+// Ключ в хранилище выводится из SHA256, поэтому одинаковые файлы хранятся один раз.
+// Изображения обрабатываются в фоне: заполняются размеры и уменьшенные варианты.
+type Media struct {
+	ID              uuid.UUID  `db:"id" json:"id"`
+	SHA256          string     `db:"sha256" json:"sha256"` // Hex-encoded хеш содержимого
+	ContentType     string     `db:"content_type" json:"content_type"`
+	SizeBytes       int64      `db:"size_bytes" json:"size_bytes"`
+	SourceURL       *string    `db:"source_url" json:"source_url"` // Nullable, адрес, с которого файл был скачан
+	Width           *int       `db:"width" json:"width"`           // Nullable, только для обработанных изображений
+	Height          *int       `db:"height" json:"height"`
+	ThumbnailID     *uuid.UUID `db:"thumbnail_id" json:"thumbnail_id"` // Nullable, уменьшенная копия для списков
+	CardID          *uuid.UUID `db:"card_id" json:"card_id"`           // Nullable, копия для карточки
+	ProcessedAt     *time.Time `db:"processed_at" json:"processed_at"` // NULL — ждёт обработки
+	ProcessingError *string    `db:"processing_error" json:"processing_error"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+}
+
+// MediaURL возвращает путь, по которому HTTP-сервер отдаёт медиафайл.
+func MediaURL(id uuid.UUID) string {
+	return "/media/" + id.String()
+}
+
+// ============================================================================
+// IMPORT JOBS
+// ============================================================================
+
+// ImportJob — фоновый импорт слов из файла.
+// Файл разбирается при создании задания; Rows содержит подготовленные строки
+// (формат определяется сервисом импорта), ProcessedRows — сколько из них
+// уже записано. Счётчики включают строки, отброшенные ещё при разборе.
+type ImportJob struct {
+	ID            uuid.UUID       `db:"id" json:"id"`
+	Format        string          `db:"format" json:"format"` // csv, tsv
+	Status        ImportJobStatus `db:"status" json:"status"`
+	Options       json.RawMessage `db:"options" json:"options"` // JSONB, параметры импорта
+	Rows          json.RawMessage `db:"rows" json:"rows"`       // JSONB, строки для записи
+	TotalRows     int             `db:"total_rows" json:"total_rows"`
+	ProcessedRows int             `db:"processed_rows" json:"processed_rows"`
+	CreatedCount  int             `db:"created_count" json:"created_count"`
+	MergedCount   int             `db:"merged_count" json:"merged_count"`
+	SkippedCount  int             `db:"skipped_count" json:"skipped_count"`
+	FailedCount   int             `db:"failed_count" json:"failed_count"`
+	RowErrors     json.RawMessage `db:"row_errors" json:"row_errors"` // JSONB, [{line, message}]
+	Error         *string         `db:"error" json:"error"`           // Nullable, почему задание прервано
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updated_at"`
+	FinishedAt    *time.Time      `db:"finished_at" json:"finished_at"`
+}
+
+// ImportCheckpoint — до какого момента данные источника уже импортированы.
+type ImportCheckpoint struct {
+	Source        string    `db:"source" json:"source"` // kindle
+	ImportedUntil time.Time `db:"imported_until" json:"imported_until"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// ============================================================================
+// REFERENCE DATA
+// ============================================================================
+
+// WordLevel — строка справочника word_levels: частотный ранг и уровень CEFR леммы.
+type WordLevel struct {
+	Word          string `db:"word" json:"word"`
+	FrequencyRank *int   `db:"frequency_rank" json:"frequency_rank"` // Nullable, слово только из списка CEFR
+	CefrLevel     string `db:"cefr_level" json:"cefr_level"`
+}
+
+// WiktionaryEntry — статья офлайн-словаря из дампа Викисловаря.
+// Senses, Sounds и Forms хранят JSONB в формате сервиса wiktionary.
+type WiktionaryEntry struct {
+	ID             int64           `db:"id" json:"id"`
+	Language       string          `db:"language" json:"language"`
+	Word           string          `db:"word" json:"word"`
+	WordNormalized string          `db:"word_normalized" json:"word_normalized"`
+	POS            string          `db:"pos" json:"pos"`       // Часть речи как в дампе
+	Senses         json.RawMessage `db:"senses" json:"senses"` // JSONB
+	Sounds         json.RawMessage `db:"sounds" json:"sounds"` // JSONB
+	Forms          json.RawMessage `db:"forms" json:"forms"`   // JSONB
+	Synonyms       []string        `db:"synonyms" json:"synonyms"`
+}
+
+// SuggestionCacheEntry — закэшированный ответ провайдера подсказок.
+// Result — JSON ответа в формате сервиса suggestion; nil — провайдер
+// слова не знает.
+type SuggestionCacheEntry struct {
+	Provider       string          `db:"provider" json:"provider"`
+	TextNormalized string          `db:"text_normalized" json:"text_normalized"`
+	Result         json.RawMessage `db:"result" json:"result"` // JSONB
+	FetchedAt      time.Time       `db:"fetched_at" json:"fetched_at"`
+	ExpiresAt      time.Time       `db:"expires_at" json:"expires_at"`
+}
+
 // ============================================================================
 // TYPES & HELPERS
 // ============================================================================
@@ -0,0 +1,668 @@
+package anki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"mime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/apkg"
+)
+
+const (
+	// DefaultDeckName — имя колоды экспорта по умолчанию.
+	DefaultDeckName = "My English"
+
+	// MaxExportEntries — максимальное количество слов в одном пакете.
+	MaxExportEntries = 50000
+
+	// maxDeckNameLength — максимальная длина имени колоды.
+	maxDeckNameLength = 200
+
+	// exportChunkSize — сколько слов загружается из БД за один раз.
+	exportChunkSize = 500
+
+	// noteTypeID — постоянный ID типа записи: при повторном импорте Anki
+	// узнаёт его и не создаёт копию.
+	noteTypeID = 1735689600000
+)
+
+// ============================================================================
+// PUBLIC API
+// ============================================================================
+
+// ExportResult — итог экспорта пакета.
+type ExportResult struct {
+	Notes      int      // Записей (слов) в пакете
+	Cards      int      // Карточек с перенесённым состоянием
+	ReviewLogs int      // Перенесено повторений
+	Media      int      // Добавлено медиафайлов
+	Warnings   []string // Первые MaxWarnings предупреждений
+}
+
+// SplitCommaList разбирает список через запятую (ID слов, уровни CEFR)
+// из параметров экспорта, пропуская пустые элементы.
+func SplitCommaList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// Export пишет в w пакет .apkg со словами, выбранными по input.
+//
+// Каждое слово становится записью типа «My English» (поля NoteFields) с одной
+// карточкой. Изображения и произношения, сохранённые в хранилище приложения,
+// добавляются в пакет как медиафайлы (изображения — в размере для карточки,
+// если он уже готов); внешние ссылки не переносятся. С IncludeScheduling
+// карточки получают состояние SRS и историю повторений, иначе все они новые.
+//
+// Ошибки валидации возвращаются до того, как в w что-либо записано.
+// Отсутствующие слова и недоступные медиафайлы попадают в Warnings.
+func (s *Service) Export(ctx context.Context, w io.Writer, input ExportInput) (*ExportResult, error) {
+	deckName, err := validateExportInput(input)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.exportIDs(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// Дни карточек на повторении отсчитываются от начала сегодняшнего дня
+	created := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	pkg, err := apkg.NewWriter(w, apkg.WriterOptions{
+		DeckID:   deckID(deckName),
+		DeckName: deckName,
+		Created:  created,
+		NoteType: NoteType(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create package: %w", err)
+	}
+
+	exp := &exporter{
+		Service:    s,
+		pkg:        pkg,
+		input:      input,
+		created:    created,
+		now:        now,
+		mediaNames: make(map[uuid.UUID]string),
+		noteIDs:    make(map[int64]bool),
+		reviewIDs:  make(map[int64]bool),
+		result:     &ExportResult{Warnings: []string{}},
+	}
+	for start := 0; start < len(ids); start += exportChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := exp.exportChunk(ctx, ids[start:min(start+exportChunkSize, len(ids))]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pkg.Close(); err != nil {
+		return nil, fmt.Errorf("write package: %w", err)
+	}
+	return exp.result, nil
+}
+
+// validateExportInput проверяет выбор слов и имя колоды.
+// Возвращает имя колоды с учётом значения по умолчанию.
+func validateExportInput(input ExportInput) (string, error) {
+	if input.Filter != nil && len(input.IDs) > 0 {
+		return "", types.NewValidationError("ids", "cannot be combined with filter")
+	}
+	if len(input.IDs) > MaxExportEntries {
+		return "", types.NewValidationError("ids", fmt.Sprintf("cannot export more than %d words at once", MaxExportEntries))
+	}
+
+	deckName := strings.TrimSpace(input.DeckName)
+	if deckName == "" {
+		deckName = DefaultDeckName
+	}
+	if len(deckName) > maxDeckNameLength {
+		return "", types.NewValidationError("deckName", fmt.Sprintf("cannot exceed %d characters", maxDeckNameLength))
+	}
+	return deckName, nil
+}
+
+// exportIDs возвращает ID экспортируемых слов: явный список без повторов
+// или результат фильтра (весь словарь, если фильтра нет).
+func (s *Service) exportIDs(ctx context.Context, input ExportInput) ([]uuid.UUID, error) {
+	if len(input.IDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(input.IDs))
+		seen := make(map[uuid.UUID]bool, len(input.IDs))
+		for i, raw := range input.IDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, types.NewValidationError(fmt.Sprintf("ids[%d]", i), "invalid UUID format")
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	var filter dictionary.DictionaryFilter
+	if input.Filter != nil {
+		filter = *input.Filter
+	}
+	// Запрашиваем на одну запись больше, чтобы обнаружить превышение лимита
+	ids, err := s.dictionary.FindIDs(ctx, filter, MaxExportEntries+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > MaxExportEntries {
+		return nil, types.NewValidationError("filter", fmt.Sprintf("matches more than %d words, narrow it down", MaxExportEntries))
+	}
+	return ids, nil
+}
+
+// deckID выводит ID колоды из имени, чтобы повторный экспорт в колоду
+// с тем же именем попадал в неё же.
+func deckID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	// 53 бита — целые из JSON колод должны точно представляться в float64
+	return int64(h.Sum64()>>11) + apkg.DefaultDeckID + 1
+}
+
+// ============================================================================
+// NOTE TYPE
+// ============================================================================
+
+// Поля типа записи «My English». Имена совпадают с DefaultFieldMapping,
+// поэтому экспортированный пакет импортируется обратно без настройки.
+const (
+	FieldWord               = "Word"
+	FieldTranscription      = "Transcription"
+	FieldDefinition         = "Definition"
+	FieldTranslation        = "Translation"
+	FieldExample            = "Example"
+	FieldExampleTranslation = "Example Translation"
+	FieldAudio              = "Audio"
+	FieldImage              = "Image"
+	FieldNotes              = "Notes"
+)
+
+// NoteFields — поля типа записи в порядке следования.
+var NoteFields = []string{
+	FieldWord, FieldTranscription, FieldDefinition, FieldTranslation,
+	FieldExample, FieldExampleTranslation, FieldAudio, FieldImage, FieldNotes,
+}
+
+// NoteType возвращает тип записи, в котором экспортируются слова:
+// на лицевой стороне слово, транскрипция и произношение, на обороте —
+// изображение, переводы, определения, примеры и заметки.
+func NoteType() apkg.NoteType {
+	section := func(field, class string) string {
+		return "{{#" + field + "}}<div class=\"" + class + "\">{{" + field + "}}</div>{{/" + field + "}}"
+	}
+	front := section(FieldWord, "word") + section(FieldTranscription, "transcription") + "{{" + FieldAudio + "}}"
+	back := "{{FrontSide}}\n<hr id=answer>\n" + strings.Join([]string{
+		section(FieldImage, "image"),
+		section(FieldTranslation, "translation"),
+		section(FieldDefinition, "definition"),
+		section(FieldExample, "example"),
+		section(FieldExampleTranslation, "example-translation"),
+		section(FieldNotes, "notes"),
+	}, "\n")
+
+	return apkg.NoteType{
+		ID:        noteTypeID,
+		Name:      DefaultDeckName,
+		Fields:    NoteFields,
+		Templates: []apkg.Template{{Name: "Recognition", Front: front, Back: back}},
+		CSS: `.card { font-family: arial; font-size: 20px; text-align: center; color: black; background-color: white; }
+.word { font-size: 32px; font-weight: bold; }
+.transcription, .example-translation { color: #777; }
+.example { font-style: italic; margin-top: 12px; }
+.notes { font-size: 16px; text-align: left; margin-top: 16px; }
+.image img { max-width: 100%; max-height: 300px; }`,
+	}
+}
+
+// ============================================================================
+// EXPORTER
+// ============================================================================
+
+// exporter хранит состояние одного экспорта.
+type exporter struct {
+	*Service
+
+	pkg        *apkg.Writer
+	input      ExportInput
+	created    time.Time
+	now        time.Time
+	mediaNames map[uuid.UUID]string // ID медиафайла → имя в пакете ("" — не удалось добавить)
+	noteIDs    map[int64]bool
+	reviewIDs  map[int64]bool
+	position   int // Порядковый номер следующей новой карточки
+	result     *ExportResult
+}
+
+// entryContent — слово со всем содержимым, нужным для записи.
+type entryContent struct {
+	entry          model.DictionaryEntry
+	senses         []model.Sense
+	translations   map[uuid.UUID][]model.Translation // По смыслам
+	examples       map[uuid.UUID][]model.Example     // По смыслам
+	images         []model.Image
+	pronunciations []model.Pronunciation
+	card           *model.Card
+	reviews        []model.ReviewLog
+}
+
+// warn добавляет предупреждение, если их ещё меньше MaxWarnings.
+func (exp *exporter) warn(format string, args ...any) {
+	if len(exp.result.Warnings) < MaxWarnings {
+		exp.result.Warnings = append(exp.result.Warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// exportChunk загружает слова ids с содержимым и добавляет их в пакет
+// в порядке ids.
+func (exp *exporter) exportChunk(ctx context.Context, ids []uuid.UUID) error {
+	contents, err := exp.loadContents(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, c := range contents {
+		if err := exp.exportEntry(ctx, c); err != nil {
+			return fmt.Errorf("export word %s: %w", c.entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// loadContents загружает слова и их содержимое пакетными запросами.
+func (exp *exporter) loadContents(ctx context.Context, ids []uuid.UUID) ([]*entryContent, error) {
+	entries, err := exp.repos.Dictionary.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list entries: %w", err)
+	}
+	byID := make(map[uuid.UUID]*entryContent, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = &entryContent{
+			entry:        e,
+			translations: make(map[uuid.UUID][]model.Translation),
+			examples:     make(map[uuid.UUID][]model.Example),
+		}
+	}
+
+	contents := make([]*entryContent, 0, len(entries))
+	entryIDs := make([]uuid.UUID, 0, len(entries))
+	for _, id := range ids {
+		if c, ok := byID[id]; ok {
+			contents = append(contents, c)
+			entryIDs = append(entryIDs, id)
+		} else {
+			exp.warn("word %s not found", id)
+		}
+	}
+	if len(contents) == 0 {
+		return contents, nil
+	}
+
+	senses, err := exp.repos.Senses.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list senses: %w", err)
+	}
+	sort.SliceStable(senses, func(i, j int) bool { return senses[i].CreatedAt.Before(senses[j].CreatedAt) })
+	senseIDs := make([]uuid.UUID, 0, len(senses))
+	entryOfSense := make(map[uuid.UUID]*entryContent, len(senses))
+	for _, sense := range senses {
+		c := byID[sense.EntryID]
+		c.senses = append(c.senses, sense)
+		senseIDs = append(senseIDs, sense.ID)
+		entryOfSense[sense.ID] = c
+	}
+
+	translations, err := exp.repos.Translations.ListBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list translations: %w", err)
+	}
+	for _, tr := range translations {
+		if c := entryOfSense[tr.SenseID]; c != nil {
+			c.translations[tr.SenseID] = append(c.translations[tr.SenseID], tr)
+		}
+	}
+
+	examples, err := exp.repos.Examples.ListBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list examples: %w", err)
+	}
+	sort.SliceStable(examples, func(i, j int) bool { return examples[i].CreatedAt.Before(examples[j].CreatedAt) })
+	for _, ex := range examples {
+		if c := entryOfSense[ex.SenseID]; c != nil {
+			c.examples[ex.SenseID] = append(c.examples[ex.SenseID], ex)
+		}
+	}
+
+	images, err := exp.repos.Images.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+	for _, img := range images {
+		byID[img.EntryID].images = append(byID[img.EntryID].images, img)
+	}
+
+	prons, err := exp.repos.Pronunciations.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list pronunciations: %w", err)
+	}
+	for _, p := range prons {
+		byID[p.EntryID].pronunciations = append(byID[p.EntryID].pronunciations, p)
+	}
+
+	if !exp.input.IncludeScheduling {
+		return contents, nil
+	}
+
+	cards, err := exp.repos.Cards.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list cards: %w", err)
+	}
+	cardIDs := make([]uuid.UUID, 0, len(cards))
+	entryOfCard := make(map[uuid.UUID]*entryContent, len(cards))
+	for i := range cards {
+		c := byID[cards[i].EntryID]
+		c.card = &cards[i]
+		cardIDs = append(cardIDs, cards[i].ID)
+		entryOfCard[cards[i].ID] = c
+	}
+
+	logs, err := exp.repos.ReviewLogs.ListByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list review logs: %w", err)
+	}
+	for _, l := range logs {
+		if c := entryOfCard[l.CardID]; c != nil {
+			c.reviews = append(c.reviews, l)
+		}
+	}
+	return contents, nil
+}
+
+// exportEntry добавляет медиафайлы, запись и карточку одного слова.
+func (exp *exporter) exportEntry(ctx context.Context, c *entryContent) error {
+	var sounds, images []string
+	for _, p := range c.pronunciations {
+		if p.MediaID == nil {
+			continue
+		}
+		name, err := exp.addMedia(ctx, c.entry, *p.MediaID, false)
+		if err != nil {
+			return err
+		}
+		if name != "" && !slices.Contains(sounds, name) {
+			sounds = append(sounds, name)
+		}
+	}
+	for _, img := range c.images {
+		if img.MediaID == nil {
+			continue
+		}
+		name, err := exp.addMedia(ctx, c.entry, *img.MediaID, true)
+		if err != nil {
+			return err
+		}
+		if name != "" && !slices.Contains(images, name) {
+			images = append(images, name)
+		}
+	}
+
+	noteID := exp.uniqueID(exp.noteIDs, c.entry.CreatedAt.UnixMilli())
+	note := apkg.Note{
+		ID:     noteID,
+		GUID:   apkg.GUIDFromBytes(c.entry.ID[:]),
+		Fields: c.fields(sounds, images),
+		Tags:   c.tags(),
+	}
+	if err := exp.pkg.AddNote(note); err != nil {
+		return err
+	}
+	exp.result.Notes++
+
+	// ID карточки совпадает с ID записи: таблицы разные, а у записи одна карточка
+	card := exp.cardState(c)
+	card.ID, card.NoteID = noteID, noteID
+	if err := exp.pkg.AddCard(card); err != nil {
+		return err
+	}
+	if c.card == nil {
+		return nil
+	}
+	exp.result.Cards++
+
+	for _, l := range c.reviews {
+		review := apkg.Review{
+			ID:     exp.uniqueID(exp.reviewIDs, l.ReviewedAt.UnixMilli()),
+			CardID: card.ID,
+			Ease:   reviewEase(l.Grade),
+			Type:   apkg.ReviewReview,
+		}
+		if l.DurationMs != nil {
+			review.DurationMs = *l.DurationMs
+		}
+		if err := exp.pkg.AddReview(review); err != nil {
+			return err
+		}
+		exp.result.ReviewLogs++
+	}
+	return nil
+}
+
+// uniqueID возвращает первое свободное значение, начиная с id, и занимает его.
+// ID записей и повторений в Anki — время в миллисекундах, поэтому совпадения
+// сдвигаются на миллисекунду.
+func (exp *exporter) uniqueID(used map[int64]bool, id int64) int64 {
+	for used[id] {
+		id++
+	}
+	used[id] = true
+	return id
+}
+
+// addMedia добавляет медиафайл в пакет один раз и возвращает его имя.
+// Для изображений берётся вариант для карточки, если он уже создан.
+// Отсутствующие файлы дают предупреждение и пустое имя.
+func (exp *exporter) addMedia(ctx context.Context, entry model.DictionaryEntry, id uuid.UUID, image bool) (string, error) {
+	if name, ok := exp.mediaNames[id]; ok {
+		return name, nil
+	}
+
+	fileID := id
+	if image {
+		m, err := exp.Service.media.GetByID(ctx, id)
+		if err != nil && !errors.Is(err, types.ErrNotFound) {
+			return "", fmt.Errorf("get media %s: %w", id, err)
+		}
+		if m != nil && m.CardID != nil {
+			fileID = *m.CardID
+		}
+	}
+
+	m, rc, err := exp.Service.media.Open(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, types.ErrNotFound) {
+			exp.mediaNames[id] = ""
+			exp.warn("word %q: media file %s is missing", entry.Text, id)
+			return "", nil
+		}
+		return "", fmt.Errorf("open media %s: %w", fileID, err)
+	}
+	defer rc.Close()
+
+	name := "myenglish-" + fileID.String() + mediaExtension(m.ContentType)
+	if err := exp.pkg.AddMedia(name, rc); err != nil {
+		return "", err
+	}
+	exp.mediaNames[id] = name
+	exp.result.Media++
+	return name, nil
+}
+
+// mediaExtension подбирает расширение файла по типу содержимого:
+// Anki определяет по нему, звук это или изображение.
+func mediaExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/wave", "audio/wav", "audio/x-wav":
+		return ".wav"
+	case "audio/mp4", "audio/x-m4a", "audio/aac":
+		return ".m4a"
+	}
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// ============================================================================
+// FIELDS
+// ============================================================================
+
+// fields собирает значения полей записи в порядке NoteFields.
+func (c *entryContent) fields(sounds, images []string) []string {
+	var transcriptions, definitions, translations, examples, exampleTranslations []string
+	addUnique := func(list *[]string, s string) {
+		if s = strings.TrimSpace(s); s != "" && !slices.Contains(*list, s) {
+			*list = append(*list, s)
+		}
+	}
+
+	for _, p := range c.pronunciations {
+		if p.Transcription != nil {
+			addUnique(&transcriptions, *p.Transcription)
+		}
+	}
+	for _, sense := range c.senses {
+		if sense.Definition != nil {
+			addUnique(&definitions, *sense.Definition)
+		}
+		for _, tr := range c.translations[sense.ID] {
+			addUnique(&translations, tr.Text)
+		}
+		for _, ex := range c.examples[sense.ID] {
+			addUnique(&examples, ex.Sentence)
+			if ex.Translation != nil {
+				addUnique(&exampleTranslations, *ex.Translation)
+			}
+		}
+	}
+
+	media := func(names []string, tag func(string) string) string {
+		tags := make([]string, len(names))
+		for i, name := range names {
+			tags[i] = tag(name)
+		}
+		return strings.Join(tags, "")
+	}
+	notes := ""
+	if c.entry.Notes != nil {
+		notes = apkg.HTMLText(*c.entry.Notes)
+	}
+
+	return []string{
+		apkg.HTMLText(c.entry.Text),
+		apkg.HTMLText(strings.Join(transcriptions, ", ")),
+		apkg.HTMLText(strings.Join(definitions, "\n")),
+		apkg.HTMLText(strings.Join(translations, "; ")),
+		apkg.HTMLText(strings.Join(examples, "\n")),
+		apkg.HTMLText(strings.Join(exampleTranslations, "\n")),
+		media(sounds, apkg.SoundTag),
+		media(images, apkg.ImageTag),
+		notes,
+	}
+}
+
+// tags возвращает теги записи: источник, язык и уровень CEFR.
+func (c *entryContent) tags() []string {
+	tags := []string{"my-english", "lang::" + c.entry.Language}
+	if c.entry.CefrLevel != nil {
+		tags = append(tags, "cefr::"+*c.entry.CefrLevel)
+	}
+	return tags
+}
+
+// ============================================================================
+// SCHEDULING
+// ============================================================================
+
+// cardState переводит SRS-поля карточки в состояние карточки Anki.
+// Слова без карточки (или экспорт без расписания) дают новые карточки
+// в порядке экспорта.
+func (exp *exporter) cardState(c *entryContent) apkg.Card {
+	exp.position++
+	card := apkg.Card{
+		Type:   apkg.CardTypeNew,
+		Queue:  apkg.QueueNew,
+		Due:    int64(exp.position),
+		Factor: apkg.DefaultFactor,
+	}
+	src := c.card
+	if src == nil {
+		return card
+	}
+
+	card.Factor = int(math.Round(src.EaseFactor * 1000))
+	for _, l := range c.reviews {
+		card.Reps++
+		if l.Grade == model.GradeAgain {
+			card.Lapses++
+		}
+	}
+
+	next := exp.now
+	if src.NextReviewAt != nil {
+		next = *src.NextReviewAt
+	}
+	switch src.Status {
+	case model.StatusLearning:
+		card.Type = apkg.CardTypeLearning
+		card.Queue = apkg.QueueLearning
+		card.Due = next.Unix()
+		card.Interval = src.IntervalDays
+	case model.StatusReview, model.StatusMastered:
+		card.Type = apkg.CardTypeReview
+		card.Queue = apkg.QueueReview
+		card.Due = int64(math.Floor(next.Sub(exp.created).Hours() / 24))
+		card.Interval = max(src.IntervalDays, 1)
+	}
+	return card
+}
+
+// reviewEase переводит оценку в кнопку ответа Anki (планировщик v2).
+func reviewEase(grade model.ReviewGrade) int {
+	switch grade {
+	case model.GradeAgain:
+		return 1
+	case model.GradeHard:
+		return 2
+	case model.GradeEasy:
+		return 4
+	}
+	return 3
+}
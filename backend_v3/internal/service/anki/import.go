@@ -0,0 +1,328 @@
+package anki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/apkg"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// ============================================================================
+// PUBLIC API
+// ============================================================================
+
+// ImportResult — итог импорта пакета.
+type ImportResult struct {
+	Notes      int      // Записей в пакете
+	Imported   int      // Создано слов
+	Duplicates int      // Пропущено: слово уже есть в словаре
+	Skipped    int      // Пропущено: пустое слово или некорректные данные
+	Cards      int      // Создано карточек
+	ReviewLogs int      // Перенесено повторений
+	Media      int      // Сохранено медиафайлов
+	Warnings   []string // Первые MaxWarnings предупреждений
+}
+
+// Import импортирует пакет .apkg из r размера size.
+//
+// Каждая запись Anki становится словом с одним смыслом (source_slug "anki").
+// Слова, которые уже есть в словаре, пропускаются. Карточка создаётся для
+// записей, у которых в Anki есть карточка: переносится состояние самой
+// повторяемой карточки записи и её история повторений.
+// Ошибки отдельных записей и медиафайлов не прерывают импорт, а попадают
+// в Warnings. Каждая запись импортируется в своей транзакции; уже
+// импортированные записи при ошибке БД не откатываются.
+func (s *Service) Import(ctx context.Context, r io.ReaderAt, size int64, input ImportInput) (*ImportResult, error) {
+	if err := validateImportInput(input); err != nil {
+		return nil, err
+	}
+
+	pkg, err := apkg.Open(r, size)
+	if err != nil {
+		if errors.Is(err, apkg.ErrInvalidPackage) || errors.Is(err, apkg.ErrUnsupportedFormat) {
+			return nil, types.NewValidationError("file", err.Error())
+		}
+		return nil, fmt.Errorf("open package: %w", err)
+	}
+
+	mapping := DefaultFieldMapping()
+	if input.Mapping != nil {
+		mapping = *input.Mapping
+	}
+
+	imp := &importer{
+		Service:  s,
+		pkg:      pkg,
+		input:    input,
+		mapping:  mapping,
+		cards:    pkg.CardsByNote(),
+		reviews:  pkg.ReviewsByCard(),
+		mediaIDs: make(map[string]*uuid.UUID),
+		now:      time.Now(),
+		result:   &ImportResult{Notes: len(pkg.Notes), Warnings: []string{}},
+	}
+	for _, note := range pkg.Notes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := imp.importNote(ctx, note); err != nil {
+			return nil, fmt.Errorf("import note %d: %w", note.ID, err)
+		}
+	}
+
+	return imp.result, nil
+}
+
+// validateImportInput проверяет коды языков.
+func validateImportInput(input ImportInput) error {
+	if input.Language != "" && !textnorm.IsValidLanguage(input.Language) {
+		return types.NewValidationError("language", "must be an ISO 639 language code")
+	}
+	if input.TranslationLanguage != "" && !textnorm.IsValidLanguage(input.TranslationLanguage) {
+		return types.NewValidationError("translationLanguage", "must be an ISO 639 language code")
+	}
+	return nil
+}
+
+// ============================================================================
+// IMPORTER
+// ============================================================================
+
+// importer хранит состояние одного импорта.
+type importer struct {
+	*Service
+
+	pkg      *apkg.Package
+	input    ImportInput
+	mapping  FieldMapping
+	cards    map[int64][]apkg.Card
+	reviews  map[int64][]apkg.Review
+	mediaIDs map[string]*uuid.UUID // Имя файла → сохранённый медиафайл (nil — не удалось сохранить)
+	now      time.Time
+	result   *ImportResult
+}
+
+// warn добавляет предупреждение, если их ещё меньше MaxWarnings.
+func (imp *importer) warn(note apkg.Note, format string, args ...any) {
+	if len(imp.result.Warnings) < MaxWarnings {
+		imp.result.Warnings = append(imp.result.Warnings, fmt.Sprintf("note %d: ", note.ID)+fmt.Sprintf(format, args...))
+	}
+}
+
+// importNote создаёт слово, его медиафайлы и карточку для одной записи.
+func (imp *importer) importNote(ctx context.Context, note apkg.Note) error {
+	content := extractNote(note, imp.pkg.NoteTypes[note.TypeID], imp.mapping)
+	if content.Text == "" {
+		imp.result.Skipped++
+		imp.warn(note, "word field is empty")
+		return nil
+	}
+
+	language := imp.input.Language
+	if language == "" {
+		language = textnorm.DefaultEntryLanguage
+	}
+	exists, err := imp.repos.Dictionary.ExistsByNormalizedText(ctx, language, textnorm.Normalize(language, content.Text))
+	if err != nil {
+		return fmt.Errorf("check duplicate: %w", err)
+	}
+	if exists {
+		imp.result.Duplicates++
+		return nil
+	}
+
+	input := dictionary.CreateWordInput{
+		Text:     content.Text,
+		Language: imp.input.Language,
+	}
+	if content.Notes != "" {
+		input.Notes = &content.Notes
+	}
+	if sense, ok := content.senseInput(imp.input.TranslationLanguage); ok {
+		input.Senses = []dictionary.SenseInput{sense}
+	}
+	if err := imp.attachMedia(ctx, note, content, &input); err != nil {
+		return err
+	}
+
+	// Слово, карточка, история повторений и запись аудита создаются в одной
+	// транзакции: иначе после сбоя на карточке слово осталось бы без неё,
+	// а повторный импорт пропустил бы его как дубликат.
+	err = imp.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		entry, err := imp.dictionary.WithTx(q).CreateWord(ctx, input)
+		if err != nil {
+			return err
+		}
+		return imp.importCard(ctx, repository.NewRegistry(q), note, entry.ID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrAlreadyExists):
+			imp.result.Duplicates++
+			return nil
+		case types.IsValidationError(err):
+			imp.result.Skipped++
+			imp.warn(note, "%v", err)
+			return nil
+		}
+		return err
+	}
+	imp.result.Imported++
+	return nil
+}
+
+// attachMedia сохраняет изображения и звуки записи и добавляет их к слову.
+// Транскрипция прикрепляется к первому произношению.
+func (imp *importer) attachMedia(ctx context.Context, note apkg.Note, content noteContent, input *dictionary.CreateWordInput) error {
+	for _, name := range content.Images {
+		id, err := imp.saveMedia(ctx, note, name)
+		if err != nil {
+			return err
+		}
+		if id != nil {
+			input.Images = append(input.Images, dictionary.ImageInput{
+				MediaID:    formatUUID(*id),
+				SourceSlug: SourceSlug,
+			})
+		}
+	}
+
+	for _, name := range content.Sounds {
+		id, err := imp.saveMedia(ctx, note, name)
+		if err != nil {
+			return err
+		}
+		if id == nil {
+			continue
+		}
+		pron := dictionary.PronunciationInput{
+			MediaID:    formatUUID(*id),
+			SourceSlug: SourceSlug,
+		}
+		if len(input.Pronunciations) == 0 && content.Transcription != "" {
+			pron.Transcription = &content.Transcription
+		}
+		input.Pronunciations = append(input.Pronunciations, pron)
+	}
+
+	if content.Transcription != "" && len(input.Pronunciations) == 0 {
+		imp.warn(note, "transcription %q skipped: the note has no audio", content.Transcription)
+	}
+	return nil
+}
+
+// saveMedia сохраняет медиафайл пакета и возвращает его ID.
+// Отсутствующие и неподдерживаемые файлы дают предупреждение и nil.
+// Каждый файл сохраняется один раз за импорт.
+func (imp *importer) saveMedia(ctx context.Context, note apkg.Note, name string) (*uuid.UUID, error) {
+	if id, ok := imp.mediaIDs[name]; ok {
+		return id, nil
+	}
+
+	rc, ok, err := imp.pkg.OpenMedia(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		imp.mediaIDs[name] = nil
+		imp.warn(note, "media file %q is missing from the package", name)
+		return nil, nil
+	}
+	defer rc.Close()
+
+	saved, err := imp.media.Upload(ctx, rc, mime.TypeByExtension(strings.ToLower(path.Ext(name))))
+	if err != nil {
+		if types.IsValidationError(err) {
+			imp.mediaIDs[name] = nil
+			imp.warn(note, "media file %q skipped: %v", name, err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("save media %q: %w", name, err)
+	}
+
+	imp.mediaIDs[name] = &saved.ID
+	imp.result.Media++
+	return &saved.ID, nil
+}
+
+// importCard создаёт карточку со состоянием из Anki и переносит историю повторений.
+// Вызывается внутри транзакции записи: repos привязан к ней.
+func (imp *importer) importCard(ctx context.Context, repos *repository.Registry, note apkg.Note, entryID uuid.UUID) error {
+	ankiCard, ok := primaryCard(imp.cards[note.ID])
+	if !ok {
+		return nil
+	}
+
+	card := cardState(imp.pkg, ankiCard, imp.now)
+	card.EntryID = entryID
+
+	var logs []model.ReviewLog
+	for _, r := range imp.reviews[ankiCard.ID] {
+		grade, ok := reviewGrade(imp.pkg.SchedV1, r)
+		if !ok {
+			continue
+		}
+		log := model.ReviewLog{Grade: grade, ReviewedAt: r.ReviewedAt()}
+		if r.DurationMs > 0 {
+			duration := r.DurationMs
+			log.DurationMs = &duration
+		}
+		logs = append(logs, log)
+	}
+
+	created, err := repos.Cards.Create(ctx, &card)
+	if err != nil {
+		return fmt.Errorf("create card: %w", err)
+	}
+	for i := range logs {
+		logs[i].CardID = created.ID
+	}
+	if _, err := repos.ReviewLogs.BatchCreate(ctx, logs); err != nil {
+		return fmt.Errorf("create review logs: %w", err)
+	}
+
+	changes := model.JSON{
+		types.AuditFieldAction:          types.AuditActionImported,
+		types.AuditFieldImportedFrom:    SourceSlug,
+		types.AuditFieldExternalID:      ankiCard.ID,
+		types.AuditFieldStatus:          created.Status,
+		types.AuditFieldIntervalDays:    created.IntervalDays,
+		types.AuditFieldEaseFactor:      created.EaseFactor,
+		types.AuditFieldReviewLogsCount: len(logs),
+	}
+	if created.NextReviewAt != nil {
+		changes[types.AuditFieldNextReviewAt] = created.NextReviewAt.Format(time.RFC3339)
+	}
+	if _, err := repos.Audit.Create(ctx, &model.AuditRecord{
+		EntityType: model.EntityCard,
+		EntityID:   &created.ID,
+		EntryID:    &entryID,
+		Action:     model.ActionCreate,
+		Changes:    changes,
+	}); err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+
+	imp.result.Cards++
+	imp.result.ReviewLogs += len(logs)
+	return nil
+}
+
+// formatUUID форматирует ID для входных данных сервиса словаря.
+func formatUUID(id uuid.UUID) *string {
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,48 @@
+package anki
+
+import "github.com/heartmarshall/my-english/internal/service/dictionary"
+
+// FieldMapping задаёт, какие поля записи Anki во что превращаются.
+// Для каждой цели указываются имена полей-кандидатов (без учёта регистра):
+// используется первое поле, которое есть у типа записи. Пустой список —
+// цель не заполняется.
+type FieldMapping struct {
+	Text               []string // Слово; если ни одного поля нет — первое поле записи
+	Definition         []string // Определение смысла
+	Translation        []string // Переводы: по одному на строку или через «;»
+	Example            []string // Примеры: по одному на строку
+	ExampleTranslation []string // Перевод первого примера
+	Transcription      []string // Транскрипция первого произношения
+	Notes              []string // Личные заметки
+}
+
+// DefaultFieldMapping — сопоставление для стандартных типов записей Anki
+// (Basic и его вариантов) и распространённых словарных колод.
+func DefaultFieldMapping() FieldMapping {
+	return FieldMapping{
+		Text:               []string{"Front", "Word", "Expression"},
+		Definition:         []string{"Definition", "Meaning"},
+		Translation:        []string{"Back", "Translation"},
+		Example:            []string{"Example", "Sentence"},
+		ExampleTranslation: []string{"Example Translation", "Sentence Translation"},
+		Transcription:      []string{"IPA", "Transcription", "Pronunciation"},
+		Notes:              []string{"Notes", "Extra"},
+	}
+}
+
+// ImportInput — параметры импорта пакета.
+type ImportInput struct {
+	Mapping             *FieldMapping // nil — DefaultFieldMapping
+	Language            string        // Язык слов (ISO 639); пусто — английский
+	TranslationLanguage string        // Язык переводов (ISO 639); пусто — русский
+}
+
+// ExportInput — параметры экспорта в пакет .apkg.
+// IDs и Filter взаимоисключающие; если не задано ни то, ни другое,
+// экспортируется весь словарь.
+type ExportInput struct {
+	IDs               []string                     // UUID слов
+	Filter            *dictionary.DictionaryFilter // Все активные слова, подходящие под фильтр
+	DeckName          string                       // Имя колоды; пусто — DefaultDeckName
+	IncludeScheduling bool                         // Перенести состояние карточек и историю повторений
+}
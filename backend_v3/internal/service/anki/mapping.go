@@ -0,0 +1,197 @@
+package anki
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/pkg/apkg"
+)
+
+// SourceSlug — источник контента, импортированного из Anki.
+const SourceSlug = "anki"
+
+// noteContent — поля записи Anki, разложенные по целям сопоставления.
+type noteContent struct {
+	Text               string
+	Definition         string
+	Translations       []string
+	Examples           []string
+	ExampleTranslation string
+	Transcription      string
+	Notes              string
+	Sounds             []string // Имена звуковых файлов из всех полей
+	Images             []string // Имена изображений из всех полей
+}
+
+// extractNote раскладывает поля записи согласно сопоставлению.
+func extractNote(note apkg.Note, nt *apkg.NoteType, m FieldMapping) noteContent {
+	field := func(candidates []string) string {
+		if nt == nil {
+			return ""
+		}
+		for _, name := range candidates {
+			if i := nt.FieldIndex(name); i >= 0 && i < len(note.Fields) {
+				return note.Fields[i]
+			}
+		}
+		return ""
+	}
+
+	text := field(m.Text)
+	if text == "" && len(m.Text) > 0 && len(note.Fields) > 0 && (nt == nil || !hasAnyField(nt, m.Text)) {
+		text = note.Fields[0]
+	}
+
+	c := noteContent{
+		Text:               strings.Join(strings.Split(apkg.PlainText(text), "\n"), " "),
+		Definition:         apkg.PlainText(field(m.Definition)),
+		Translations:       splitList(apkg.PlainText(field(m.Translation)), true),
+		Examples:           splitList(apkg.PlainText(field(m.Example)), false),
+		ExampleTranslation: apkg.PlainText(field(m.ExampleTranslation)),
+		Transcription:      apkg.PlainText(field(m.Transcription)),
+		Notes:              apkg.PlainText(field(m.Notes)),
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range note.Fields {
+		for _, name := range apkg.SoundRefs(f) {
+			if !seen[name] {
+				seen[name] = true
+				c.Sounds = append(c.Sounds, name)
+			}
+		}
+		for _, name := range apkg.ImageRefs(f) {
+			if !seen[name] {
+				seen[name] = true
+				c.Images = append(c.Images, name)
+			}
+		}
+	}
+	return c
+}
+
+// hasAnyField сообщает, есть ли у типа записи хотя бы одно из полей.
+func hasAnyField(nt *apkg.NoteType, names []string) bool {
+	for _, name := range names {
+		if nt.FieldIndex(name) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList делит текст на элементы по строкам (и по «;», если semicolons),
+// убирая пустые и повторяющиеся.
+func splitList(text string, semicolons bool) []string {
+	sep := func(r rune) bool { return r == '\n' || (semicolons && r == ';') }
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, part := range strings.FieldsFunc(text, sep) {
+		part = strings.TrimSpace(part)
+		if part != "" && !seen[part] {
+			seen[part] = true
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// senseInput собирает смысл из определения, переводов и примеров.
+// Возвращает false, если записывать нечего.
+func (c noteContent) senseInput(translationLanguage string) (dictionary.SenseInput, bool) {
+	sense := dictionary.SenseInput{SourceSlug: SourceSlug}
+	if c.Definition != "" {
+		sense.Definition = &c.Definition
+	}
+	for _, tr := range c.Translations {
+		sense.Translations = append(sense.Translations, dictionary.TranslationInput{
+			Text:       tr,
+			Language:   translationLanguage,
+			SourceSlug: SourceSlug,
+		})
+	}
+	for i, ex := range c.Examples {
+		example := dictionary.ExampleInput{Sentence: ex, SourceSlug: SourceSlug}
+		if i == 0 && c.ExampleTranslation != "" {
+			example.Translation = &c.ExampleTranslation
+		}
+		sense.Examples = append(sense.Examples, example)
+	}
+
+	empty := sense.Definition == nil && len(sense.Translations) == 0 && len(sense.Examples) == 0
+	return sense, !empty
+}
+
+// ============================================================================
+// SCHEDULING
+// ============================================================================
+
+// primaryCard выбирает карточку записи, чьё состояние переносится:
+// с наибольшим числом повторений, при равенстве — первый шаблон.
+func primaryCard(cards []apkg.Card) (apkg.Card, bool) {
+	if len(cards) == 0 {
+		return apkg.Card{}, false
+	}
+	best := cards[0]
+	for _, c := range cards[1:] {
+		if c.Reps > best.Reps || (c.Reps == best.Reps && c.Ord < best.Ord) {
+			best = c
+		}
+	}
+	return best, true
+}
+
+// cardState переводит состояние карточки Anki в SRS-поля карточки.
+// now используется для карточек в обучении, у которых нет точного срока.
+func cardState(pkg *apkg.Package, c apkg.Card, now time.Time) model.Card {
+	card := model.Card{
+		Status:     model.StatusNew,
+		EaseFactor: dictionary.DefaultEaseFactor,
+	}
+	if c.Factor > 0 {
+		card.EaseFactor = math.Max(float64(c.Factor)/1000, minEaseFactor)
+	}
+
+	switch c.Type {
+	case apkg.CardTypeLearning, apkg.CardTypeRelearning:
+		card.Status = model.StatusLearning
+		card.IntervalDays = max(c.Interval, 0)
+		next := now
+		switch c.Queue {
+		case apkg.QueueLearning:
+			next = time.Unix(c.Due, 0)
+		case apkg.QueueDayLearn:
+			next = pkg.Created.AddDate(0, 0, int(c.Due))
+		}
+		card.NextReviewAt = &next
+
+	case apkg.CardTypeReview:
+		card.Status = model.StatusReview
+		card.IntervalDays = max(c.Interval, 0)
+		next := pkg.Created.AddDate(0, 0, int(c.Due))
+		card.NextReviewAt = &next
+	}
+	return card
+}
+
+// reviewGrade переводит кнопку ответа Anki в оценку.
+// Ручные изменения расписания не являются повторениями и пропускаются.
+// В планировщике v1 у карточек в обучении три кнопки: Again, Good, Easy.
+func reviewGrade(schedV1 bool, r apkg.Review) (model.ReviewGrade, bool) {
+	if r.Type == apkg.ReviewManual || r.Type == apkg.ReviewRescheduled {
+		return "", false
+	}
+
+	grades := []model.ReviewGrade{model.GradeAgain, model.GradeHard, model.GradeGood, model.GradeEasy}
+	if schedV1 && (r.Type == apkg.ReviewLearn || r.Type == apkg.ReviewRelearn) {
+		grades = []model.ReviewGrade{model.GradeAgain, model.GradeGood, model.GradeEasy}
+	}
+	if r.Ease < 1 || r.Ease > len(grades) {
+		return "", false
+	}
+	return grades[r.Ease-1], true
+}
@@ -0,0 +1,55 @@
+// Package anki импортирует колоды Anki (.apkg) в словарь: записи становятся
+// словами со смыслами, переводами и примерами, медиафайлы — изображениями и
+// произношениями, а состояние карточек и история повторений переносятся,
+// чтобы изучение продолжилось с того же места. Экспорт выполняет обратное
+// преобразование: слова словаря упаковываются в колоду с собственным типом записи.
+package anki
+
+import (
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/media"
+)
+
+const (
+	// MaxWarnings — сколько предупреждений о пропущенных данных возвращается в результате.
+	MaxWarnings = 100
+
+	// minEaseFactor — минимальный ease factor карточки (как в SM-2).
+	minEaseFactor = 1.3
+)
+
+// Service реализует импорт и экспорт колод Anki.
+type Service struct {
+	repos      *repository.Registry
+	tx         *database.TxManager
+	dictionary *dictionary.Service
+	media      *media.Service
+}
+
+// NewService создаёт сервис импорта и экспорта.
+// Слова создаются через сервис словаря, файлы сохраняются через сервис медиафайлов.
+func NewService(repos *repository.Registry, tx *database.TxManager, dict *dictionary.Service, mediaSvc *media.Service) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("tx cannot be nil")
+	}
+	if dict == nil {
+		return nil, fmt.Errorf("dictionary service cannot be nil")
+	}
+	if mediaSvc == nil {
+		return nil, fmt.Errorf("media service cannot be nil")
+	}
+
+	return &Service{
+		repos:      repos,
+		tx:         tx,
+		dictionary: dict,
+		media:      mediaSvc,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	backuprepo "github.com/heartmarshall/my-english/internal/database/repository/backup"
+)
+
+// Export записывает в w резервную копию всех данных. Таблицы читаются из
+// одного снимка БД, поэтому копия согласована, даже если данные меняются
+// во время выгрузки.
+func (s *Service) Export(ctx context.Context, w io.Writer) (*Summary, error) {
+	out := bufio.NewWriter(w)
+	summary := &Summary{}
+
+	err := s.tx.RunInSnapshot(ctx, func(ctx context.Context, q database.Querier) error {
+		repos := repository.NewRegistry(q)
+
+		if err := writeLine(out, Header{
+			Format:        FormatName,
+			Version:       FormatVersion,
+			SchemaVersion: SchemaVersion,
+			CreatedAt:     time.Now().UTC(),
+		}); err != nil {
+			return err
+		}
+
+		counts := make(map[string]int, len(backuprepo.Tables))
+		for _, table := range backuprepo.Tables {
+			prefix := []byte(`{"table":"` + table.Name + `","row":`)
+			err := repos.Backup.Stream(ctx, table.Name, func(row json.RawMessage) error {
+				counts[table.Name]++
+				out.Write(prefix)
+				out.Write(row)
+				_, err := out.WriteString("}\n")
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("export %s: %w", table.Name, err)
+			}
+			summary.add(table.Name, counts[table.Name])
+		}
+
+		return writeLine(out, record{End: true, Counts: counts})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := out.Flush(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// writeLine записывает значение строкой NDJSON.
+func writeLine(out *bufio.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	out.Write(data)
+	return out.WriteByte('\n')
+}
+
+// add добавляет количество строк таблицы в итог.
+func (s *Summary) add(table string, rows int) {
+	s.Tables = append(s.Tables, TableCount{Table: table, Rows: rows})
+	s.Rows += rows
+}
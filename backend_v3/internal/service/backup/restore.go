@@ -0,0 +1,356 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	backuprepo "github.com/heartmarshall/my-english/internal/database/repository/backup"
+	"github.com/heartmarshall/my-english/internal/database/schema"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RestoreInput — параметры восстановления.
+type RestoreInput struct {
+	Mode Mode // Пусто — ModePreserve
+}
+
+// Restore восстанавливает данные из резервной копии одной транзакцией:
+// при любой ошибке (в том числе обрезанном файле) БД не меняется.
+//
+// Копия должна быть сделана с той же версией схемы БД. В режиме
+// ModePreserve БД должна быть пуста; в режиме ModeRemap данные копии
+// добавляются к существующим, и совпадение текста слова с уже
+// существующим словом — ошибка. ID внутри истории изменений (changes,
+// snapshot аудита) не переписываются.
+func (s *Service) Restore(ctx context.Context, r io.Reader, input RestoreInput) (*Summary, error) {
+	mode := input.Mode
+	if mode == "" {
+		mode = ModePreserve
+	}
+	if mode != ModePreserve && mode != ModeRemap {
+		return nil, types.NewValidationError("mode", "must be PRESERVE or REMAP")
+	}
+
+	var summary *Summary
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		rs := &restorer{
+			repos:   repository.NewRegistry(q),
+			mode:    mode,
+			ids:     make(map[string]string),
+			read:    make(map[string]int),
+			summary: &Summary{},
+		}
+		if err := rs.run(ctx, r); err != nil {
+			return err
+		}
+		summary = rs.summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// restorer читает копию и вставляет строки пачками.
+type restorer struct {
+	repos *repository.Registry
+	mode  Mode
+
+	ids     map[string]string // REMAP: ID из копии → новый ID
+	read    map[string]int    // Прочитано строк по таблицам
+	summary *Summary
+
+	table int               // Индекс текущей таблицы в backuprepo.Tables
+	batch []json.RawMessage // Строки текущей таблицы, ещё не вставленные
+	rows  int               // Вставлено строк текущей таблицы
+}
+
+// run читает копию построчно.
+func (rs *restorer) run(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	next := func() ([]byte, bool) {
+		for scanner.Scan() {
+			line++
+			if data := bytes.TrimSpace(scanner.Bytes()); len(data) > 0 {
+				return data, true
+			}
+		}
+		return nil, false
+	}
+
+	data, ok := next()
+	if !ok {
+		if err := scanner.Err(); err != nil {
+			return readError(err, line)
+		}
+		return types.NewValidationError("file", "is empty")
+	}
+	if err := rs.checkHeader(ctx, data); err != nil {
+		return err
+	}
+
+	ended := false
+	for {
+		data, ok := next()
+		if !ok {
+			break
+		}
+		if ended {
+			return types.NewValidationError("file", fmt.Sprintf("line %d: unexpected data after the end record", line))
+		}
+
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return types.NewValidationError("file", fmt.Sprintf("line %d: %v", line, err))
+		}
+		if rec.End {
+			if err := rs.finish(ctx, rec.Counts); err != nil {
+				return err
+			}
+			ended = true
+			continue
+		}
+		if err := rs.add(ctx, rec, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return readError(err, line)
+	}
+	if !ended {
+		return types.NewValidationError("file", "is truncated: the end record is missing")
+	}
+	return nil
+}
+
+// checkHeader проверяет заголовок копии и, для ModePreserve, что БД пуста.
+func (rs *restorer) checkHeader(ctx context.Context, data []byte) error {
+	var header Header
+	if err := json.Unmarshal(data, &header); err != nil || header.Format != FormatName {
+		return types.NewValidationError("file", "is not a backup")
+	}
+	if header.Version != FormatVersion {
+		return types.NewValidationError("file", fmt.Sprintf("backup format version %d is not supported", header.Version))
+	}
+	if header.SchemaVersion != SchemaVersion {
+		return types.NewValidationError("file", fmt.Sprintf(
+			"backup schema version %d does not match database schema version %d", header.SchemaVersion, SchemaVersion))
+	}
+
+	if rs.mode == ModePreserve {
+		empty, err := rs.repos.Backup.IsEmpty(ctx)
+		if err != nil {
+			return fmt.Errorf("check database: %w", err)
+		}
+		if !empty {
+			return types.NewValidationError("mode", "PRESERVE requires an empty database; use REMAP to add the backup to existing data")
+		}
+	}
+	return nil
+}
+
+// add добавляет строку таблицы в пачку. Таблицы должны идти в порядке
+// backuprepo.Tables.
+func (rs *restorer) add(ctx context.Context, rec record, line int) error {
+	idx := slices.IndexFunc(backuprepo.Tables, func(t backuprepo.Table) bool { return t.Name == rec.Table })
+	switch {
+	case idx < 0:
+		return types.NewValidationError("file", fmt.Sprintf("line %d: unknown table %q", line, rec.Table))
+	case idx < rs.table:
+		return types.NewValidationError("file", fmt.Sprintf("line %d: table %q is out of order", line, rec.Table))
+	case len(rec.Row) == 0 || rec.Row[0] != '{':
+		return types.NewValidationError("file", fmt.Sprintf("line %d: row must be a JSON object", line))
+	}
+
+	if err := rs.advance(ctx, idx); err != nil {
+		return err
+	}
+	rs.read[rec.Table]++
+	rs.batch = append(rs.batch, rec.Row)
+	if len(rs.batch) == batchSize {
+		return rs.flush(ctx)
+	}
+	return nil
+}
+
+// advance переходит к таблице idx, дописывая пачку и итог предыдущих таблиц.
+func (rs *restorer) advance(ctx context.Context, idx int) error {
+	for rs.table < idx {
+		if err := rs.flush(ctx); err != nil {
+			return err
+		}
+		rs.summary.add(backuprepo.Tables[rs.table].Name, rs.rows)
+		rs.table++
+		rs.rows = 0
+	}
+	return nil
+}
+
+// finish дописывает оставшиеся таблицы и сверяет количество строк
+// с завершающей записью.
+func (rs *restorer) finish(ctx context.Context, counts map[string]int) error {
+	if err := rs.advance(ctx, len(backuprepo.Tables)); err != nil {
+		return err
+	}
+	for _, t := range backuprepo.Tables {
+		if counts[t.Name] != rs.read[t.Name] {
+			return types.NewValidationError("file", fmt.Sprintf(
+				"table %q has %d rows, the end record expects %d", t.Name, rs.read[t.Name], counts[t.Name]))
+		}
+	}
+	return nil
+}
+
+// flush вставляет пачку текущей таблицы.
+func (rs *restorer) flush(ctx context.Context) error {
+	if len(rs.batch) == 0 {
+		return nil
+	}
+	table := backuprepo.Tables[rs.table]
+
+	rows := rs.batch
+	if rs.mode == ModeRemap {
+		var err error
+		if rows, err = rs.remap(ctx, table, rows); err != nil {
+			return err
+		}
+	}
+
+	n, err := rs.repos.Backup.Insert(ctx, table.Name, rows)
+	if err != nil {
+		return insertError(err, table.Name)
+	}
+	rs.rows += int(n)
+	rs.batch = rs.batch[:0]
+	return nil
+}
+
+// ============================================================================
+// REMAP
+// ============================================================================
+
+// remap выдаёт строкам пачки новые ID и переписывает ссылки на строки,
+// восстановленные раньше. Ссылки на ID, которых нет в копии, остаются
+// как есть. Медиафайлы, уже сохранённые в БД, не вставляются: ссылки на
+// них переписываются на существующие ID.
+func (rs *restorer) remap(ctx context.Context, table backuprepo.Table, batch []json.RawMessage) ([]json.RawMessage, error) {
+	rows := make([]map[string]json.RawMessage, len(batch))
+	for i, data := range batch {
+		if err := json.Unmarshal(data, &rows[i]); err != nil || rows[i] == nil {
+			return nil, types.NewValidationError("file", fmt.Sprintf("table %q: row must be a JSON object", table.Name))
+		}
+	}
+
+	var existing map[string]uuid.UUID
+	if table.Name == schema.Media.Name.String() {
+		hashes := make([]string, 0, len(rows))
+		for _, row := range rows {
+			var hash string
+			if err := json.Unmarshal(row["sha256"], &hash); err == nil && hash != "" {
+				hashes = append(hashes, hash)
+			}
+		}
+		var err error
+		if existing, err = rs.repos.Backup.MediaIDsBySHA256(ctx, hashes); err != nil {
+			return nil, fmt.Errorf("find existing media: %w", err)
+		}
+	}
+
+	result := make([]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		var oldID string
+		if err := json.Unmarshal(row["id"], &oldID); err != nil || oldID == "" {
+			return nil, types.NewValidationError("file", fmt.Sprintf("table %q: row has no id", table.Name))
+		}
+
+		if existing != nil {
+			var hash string
+			_ = json.Unmarshal(row["sha256"], &hash)
+			if id, ok := existing[hash]; ok {
+				rs.ids[oldID] = id.String()
+				rs.summary.ReusedMedia++
+				continue
+			}
+		}
+
+		newID := uuid.NewString()
+		rs.ids[oldID] = newID
+		row["id"] = jsonString(newID)
+
+		for _, col := range table.References {
+			var ref *string
+			if err := json.Unmarshal(row[col], &ref); err != nil || ref == nil {
+				continue
+			}
+			if id, ok := rs.ids[*ref]; ok {
+				row[col] = jsonString(id)
+			}
+		}
+
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+// jsonString кодирует строку в JSON.
+func jsonString(s string) json.RawMessage {
+	data, _ := json.Marshal(s)
+	return data
+}
+
+// ============================================================================
+// ERRORS
+// ============================================================================
+
+// pgDataException — класс ошибок PostgreSQL о некорректных значениях
+// (неверный UUID, дата, значение перечисления и т.п.).
+const pgDataException = "22"
+
+// readError оборачивает ошибку чтения файла.
+func readError(err error, line int) error {
+	if errors.Is(err, bufio.ErrTooLong) {
+		return types.NewValidationError("file", fmt.Sprintf("line %d is too long", line+1))
+	}
+	return fmt.Errorf("read backup: %w", err)
+}
+
+// insertError превращает нарушения ограничений и некорректные значения
+// в ошибку валидации: их причина — содержимое копии или уже существующие
+// данные.
+func insertError(err error, table string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, pgDataException) {
+		return types.NewValidationError("file", fmt.Sprintf("cannot restore table %q: %s", table, pgErr.Message))
+	}
+	if database.IsDuplicateError(err) || database.IsForeignKeyError(err) || database.IsConstraintError(err) {
+		msg := fmt.Sprintf("cannot restore table %q", table)
+		var dbErr *database.DBError
+		if errors.As(err, &dbErr) && dbErr.Detail != "" {
+			msg += ": " + dbErr.Detail
+		}
+		if database.IsDuplicateError(err) {
+			msg += " (the row already exists)"
+		}
+		return types.NewValidationError("file", msg)
+	}
+	return fmt.Errorf("restore %s: %w", table, err)
+}
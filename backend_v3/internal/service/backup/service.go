@@ -0,0 +1,101 @@
+// Package backup выгружает все данные приложения в резервную копию
+// и восстанавливает их из неё.
+//
+// Копия — NDJSON: первая строка — заголовок с версией формата и схемы БД,
+// затем по строке на каждую строку таблиц (в порядке загрузки), последняя
+// строка — количество строк по таблицам, по которому обнаруживается
+// обрезанный файл. Медиафайлы в копию входят только метаданными: сами
+// файлы переносятся вместе с хранилищем.
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+)
+
+const (
+	// FormatName отличает файл резервной копии от других NDJSON.
+	FormatName = "my-english-backup"
+
+	// FormatVersion — версия формата файла.
+	FormatVersion = 1
+
+	// SchemaVersion — версия последней миграции, под которую написан код.
+	// Копия восстанавливается только в БД той же версии схемы.
+	// Обновляется вместе с добавлением миграций.
+	SchemaVersion int64 = 20260203100000
+
+	// batchSize — сколько строк вставляется одним запросом при восстановлении.
+	batchSize = 500
+
+	// maxLineSize — максимальная длина строки файла.
+	maxLineSize = 64 << 20
+)
+
+// Mode — как восстанавливать ID строк.
+type Mode string
+
+const (
+	// ModePreserve сохраняет ID из копии. Только для пустой БД.
+	ModePreserve Mode = "PRESERVE"
+
+	// ModeRemap выдаёт строкам новые ID и переписывает ссылки между ними,
+	// чтобы копию можно было добавить к существующим данным. Медиафайлы
+	// с тем же содержимым не дублируются.
+	ModeRemap Mode = "REMAP"
+)
+
+// Header — первая строка резервной копии.
+type Header struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion int64     `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// record — строка резервной копии после заголовка: строка таблицы или
+// завершающая запись с количеством строк.
+type record struct {
+	Table  string          `json:"table,omitempty"`
+	Row    json.RawMessage `json:"row,omitempty"`
+	End    bool            `json:"end,omitempty"`
+	Counts map[string]int  `json:"counts,omitempty"`
+}
+
+// TableCount — количество строк таблицы.
+type TableCount struct {
+	Table string
+	Rows  int
+}
+
+// Summary — итог выгрузки или восстановления.
+type Summary struct {
+	Tables      []TableCount // В порядке загрузки, включая пустые таблицы
+	Rows        int          // Всего строк
+	ReusedMedia int          // REMAP: медиафайлы, уже бывшие в БД
+}
+
+// Service реализует резервное копирование.
+type Service struct {
+	repos *repository.Registry
+	tx    *database.TxManager
+}
+
+// NewService создаёт сервис резервного копирования.
+func NewService(repos *repository.Registry, tx *database.TxManager) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("tx cannot be nil")
+	}
+
+	return &Service{
+		repos: repos,
+		tx:    tx,
+	}, nil
+}
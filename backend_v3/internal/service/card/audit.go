@@ -10,11 +10,12 @@ import (
 	"github.com/heartmarshall/my-english/internal/service/types"
 )
 
-// createAuditLog создает запись аудита для операции над карточкой.
-func (s *Service) createAuditLog(ctx context.Context, cardID uuid.UUID, action model.AuditAction, changes model.JSON) error {
+// createAuditLog создает запись аудита для операции над карточкой слова entryID.
+func (s *Service) createAuditLog(ctx context.Context, cardID, entryID uuid.UUID, action model.AuditAction, changes model.JSON) error {
 	audit := &model.AuditRecord{
 		EntityType: model.EntityCard,
 		EntityID:   &cardID,
+		EntryID:    &entryID,
 		Action:     action,
 		Changes:    changes,
 	}
@@ -74,7 +74,7 @@ func (s *Service) createCardTx(ctx context.Context, input CreateCardInput, entry
 
 		// Создаем аудит-лог с полной информацией о созданной карточке
 		changes := buildCreateChanges(createdCard)
-		if err := s.createAuditLog(ctx, createdCard.ID, model.ActionCreate, changes); err != nil {
+		if err := s.createAuditLog(ctx, createdCard.ID, createdCard.EntryID, model.ActionCreate, changes); err != nil {
 			return fmt.Errorf("create audit log: %w", err)
 		}
 
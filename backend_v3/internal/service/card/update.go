@@ -84,7 +84,7 @@ func (s *Service) updateCardTx(ctx context.Context, input UpdateCardInput, cardI
 		// Создаем аудит-лог с детальными изменениями полей
 		changes := diffCard(existingCard, updatedCard)
 		if len(changes) > 0 {
-			if err := s.createAuditLog(ctx, cardID, model.ActionUpdate, changes); err != nil {
+			if err := s.createAuditLog(ctx, cardID, existingCard.EntryID, model.ActionUpdate, changes); err != nil {
 				return fmt.Errorf("create audit log: %w", err)
 			}
 		}
@@ -0,0 +1,395 @@
+package csvimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// lookupChunkSize — сколько текстов проверяется на дубликаты одним запросом.
+const lookupChunkSize = 1000
+
+// ============================================================================
+// PUBLIC API
+// ============================================================================
+
+// RowAction — что произойдёт со строкой при импорте.
+type RowAction string
+
+const (
+	ActionNew   RowAction = "NEW"   // Будет создано новое слово
+	ActionMerge RowAction = "MERGE" // Смысл из строки добавится к существующему слову
+	ActionSkip  RowAction = "SKIP"  // Строка будет пропущена (дубликат)
+	ActionError RowAction = "ERROR" // Строка не прошла проверку
+)
+
+// RowReport — результат проверки одной строки.
+type RowReport struct {
+	Line    int // Номер строки файла
+	Text    string
+	Action  RowAction
+	Message string // Причина пропуска или ошибки
+}
+
+// Report — результат предпросмотра импорта.
+type Report struct {
+	Rows  []RowReport
+	New   int
+	Merge int
+	Skip  int
+	Error int
+}
+
+// RowError — ошибка строки в задании импорта.
+type RowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Preview разбирает файл и проверяет каждую строку, ничего не записывая:
+// строка проверяется так же, как при создании слова, а дубликаты ищутся
+// по нормализованному тексту в словаре и выше в том же файле.
+//
+// Результат отражает состояние словаря на момент вызова; при записи
+// дубликаты проверяются заново.
+func (s *Service) Preview(ctx context.Context, r io.Reader, input ImportInput) (*Report, error) {
+	opts, err := validateInput(input)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := parseFile(r, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.plan(ctx, rows, opts)
+	if err != nil {
+		return nil, wrapServiceError(err, "preview import")
+	}
+	return report, nil
+}
+
+// Start разбирает и проверяет файл так же, как Preview, и создаёт задание
+// импорта. Строки NEW и MERGE записываются фоновым обработчиком
+// (ProcessPending); пропущенные и ошибочные строки сразу учитываются
+// в счётчиках задания.
+func (s *Service) Start(ctx context.Context, r io.Reader, input ImportInput) (*model.ImportJob, error) {
+	opts, err := validateInput(input)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := parseFile(r, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.plan(ctx, rows, opts)
+	if err != nil {
+		return nil, wrapServiceError(err, "start import")
+	}
+
+	job := &model.ImportJob{Format: string(opts.Format)}
+	pending := make([]row, 0, report.New+report.Merge)
+	var rowErrors []RowError
+	for i, rr := range report.Rows {
+		switch rr.Action {
+		case ActionNew, ActionMerge:
+			pending = append(pending, rows[i])
+		case ActionSkip:
+			job.SkippedCount++
+		case ActionError:
+			job.FailedCount++
+			rowErrors = appendRowError(rowErrors, rr.Line, rr.Message)
+		}
+	}
+	job.TotalRows = len(pending)
+
+	if job.Options, err = json.Marshal(opts); err != nil {
+		return nil, fmt.Errorf("encode options: %w", err)
+	}
+	if job.Rows, err = json.Marshal(pending); err != nil {
+		return nil, fmt.Errorf("encode rows: %w", err)
+	}
+	if job.RowErrors, err = json.Marshal(rowErrors); err != nil {
+		return nil, fmt.Errorf("encode row errors: %w", err)
+	}
+
+	created, err := s.repos.ImportJobs.Create(ctx, job)
+	if err != nil {
+		return nil, wrapServiceError(err, "create import job")
+	}
+	return created, nil
+}
+
+// GetJob возвращает задание импорта по ID.
+func (s *Service) GetJob(ctx context.Context, id uuid.UUID) (*model.ImportJob, error) {
+	job, err := s.repos.ImportJobs.GetByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, types.ErrNotFound
+		}
+		return nil, fmt.Errorf("get import job: %w", err)
+	}
+	return job, nil
+}
+
+// DecodeRowErrors разбирает ошибки строк задания.
+func DecodeRowErrors(job *model.ImportJob) ([]RowError, error) {
+	rowErrors := []RowError{}
+	if len(job.RowErrors) == 0 {
+		return rowErrors, nil
+	}
+	if err := json.Unmarshal(job.RowErrors, &rowErrors); err != nil {
+		return nil, fmt.Errorf("decode row errors: %w", err)
+	}
+	return rowErrors, nil
+}
+
+// appendRowError добавляет ошибку строки, если их ещё меньше MaxRowErrors.
+func appendRowError(rowErrors []RowError, line int, message string) []RowError {
+	if len(rowErrors) >= MaxRowErrors {
+		return rowErrors
+	}
+	return append(rowErrors, RowError{Line: line, Message: message})
+}
+
+// ============================================================================
+// PLANNING
+// ============================================================================
+
+// plan определяет действие для каждой строки.
+func (s *Service) plan(ctx context.Context, rows []row, opts options) (*Report, error) {
+	language := opts.entryLanguage()
+	report := &Report{Rows: make([]RowReport, len(rows))}
+
+	// Проверяем строки и собираем нормализованные тексты корректных
+	norms := make([]string, len(rows))
+	var lookup []string
+	for i, rw := range rows {
+		report.Rows[i] = RowReport{Line: rw.Line, Text: rw.Text}
+		err := rw.err
+		if err == nil {
+			err = dictionary.ValidateCreateWordInput(rw.createWordInput(opts))
+		}
+		if err != nil {
+			report.Rows[i].Action = ActionError
+			report.Rows[i].Message = err.Error()
+			continue
+		}
+		norms[i] = textnorm.Normalize(language, rw.Text)
+		lookup = append(lookup, norms[i])
+	}
+
+	existing := make(map[string]bool, len(lookup))
+	for start := 0; start < len(lookup); start += lookupChunkSize {
+		entries, err := s.repos.Dictionary.ListByNormalizedTexts(ctx, language, lookup[start:min(start+lookupChunkSize, len(lookup))])
+		if err != nil {
+			return nil, fmt.Errorf("find duplicates: %w", err)
+		}
+		for _, e := range entries {
+			existing[e.TextNormalized] = true
+		}
+	}
+
+	firstLine := make(map[string]int) // Нормализованный текст → строка, которая создаст слово
+	for i, rw := range rows {
+		rr := &report.Rows[i]
+		if rr.Action == ActionError {
+			report.Error++
+			continue
+		}
+
+		norm := norms[i]
+		line, inFile := firstLine[norm]
+		switch {
+		case !existing[norm] && !inFile:
+			rr.Action = ActionNew
+			firstLine[norm] = rw.Line
+			report.New++
+		case opts.Duplicates == DuplicatesMerge && rw.hasSense():
+			rr.Action = ActionMerge
+			if inFile {
+				rr.Message = fmt.Sprintf("adds a sense to the word from line %d", line)
+			} else {
+				rr.Message = "adds a sense to the existing word"
+			}
+			report.Merge++
+		default:
+			rr.Action = ActionSkip
+			switch {
+			case inFile:
+				rr.Message = fmt.Sprintf("duplicate of line %d", line)
+			case opts.Duplicates == DuplicatesMerge:
+				rr.Message = "word already exists and the row has nothing to merge"
+			default:
+				rr.Message = "word already exists"
+			}
+			report.Skip++
+		}
+	}
+	return report, nil
+}
+
+// ============================================================================
+// PROCESSING
+// ============================================================================
+
+// ProcessPending записывает следующую пачку строк самого старого
+// незавершённого задания и сохраняет прогресс. Возвращает false,
+// если заданий нет.
+//
+// Дубликаты проверяются заново: слово, появившееся после запуска импорта,
+// пропускается или дополняется по политике задания. Строки, не прошедшие
+// проверку при записи, попадают в ошибки задания. Ошибка БД прерывает
+// задание со статусом FAILED; при отмене ctx задание остаётся RUNNING
+// и продолжится с последней сохранённой пачки.
+func (s *Service) ProcessPending(ctx context.Context) (bool, error) {
+	jobs, err := s.repos.ImportJobs.ListUnfinished(ctx, 1)
+	if err != nil {
+		return false, fmt.Errorf("list import jobs: %w", err)
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+	job := &jobs[0]
+
+	if err := s.processChunk(ctx, job); err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		message := err.Error()
+		job.Status = model.ImportFailed
+		job.Error = &message
+		if _, saveErr := s.repos.ImportJobs.SaveProgress(ctx, job); saveErr != nil {
+			return false, fmt.Errorf("import job %s: %w (save status: %v)", job.ID, err, saveErr)
+		}
+		return true, fmt.Errorf("import job %s: %w", job.ID, err)
+	}
+	return true, nil
+}
+
+// processChunk записывает очередную пачку строк задания.
+func (s *Service) processChunk(ctx context.Context, job *model.ImportJob) error {
+	var opts options
+	if err := json.Unmarshal(job.Options, &opts); err != nil {
+		return fmt.Errorf("decode options: %w", err)
+	}
+	var rows []row
+	if err := json.Unmarshal(job.Rows, &rows); err != nil {
+		return fmt.Errorf("decode rows: %w", err)
+	}
+	if len(rows) != job.TotalRows {
+		return fmt.Errorf("job has %d rows, expected %d", len(rows), job.TotalRows)
+	}
+	rowErrors, err := DecodeRowErrors(job)
+	if err != nil {
+		return err
+	}
+
+	// Строки пачки и прогресс задания сохраняются в одной транзакции:
+	// после сбоя пачка повторяется целиком и не создаёт слова дважды.
+	// Счётчики меняются на копии задания и переносятся только после фиксации.
+	chunk := rows[job.ProcessedRows:min(job.ProcessedRows+s.chunkSize, len(rows))]
+	progress := *job
+	err = s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		repos := repository.NewRegistry(q)
+		dict := s.dictionary.WithTx(q)
+		for _, rw := range chunk {
+			action, err := writeRow(ctx, repos, dict, rw, opts)
+			if err != nil {
+				if !types.IsValidationError(err) {
+					return fmt.Errorf("line %d: %w", rw.Line, err)
+				}
+				progress.FailedCount++
+				rowErrors = appendRowError(rowErrors, rw.Line, err.Error())
+				continue
+			}
+			switch action {
+			case ActionNew:
+				progress.CreatedCount++
+			case ActionMerge:
+				progress.MergedCount++
+			default:
+				progress.SkippedCount++
+			}
+		}
+
+		progress.ProcessedRows += len(chunk)
+		progress.Status = model.ImportRunning
+		if progress.ProcessedRows == progress.TotalRows {
+			progress.Status = model.ImportCompleted
+		}
+		var err error
+		if progress.RowErrors, err = json.Marshal(rowErrors); err != nil {
+			return fmt.Errorf("encode row errors: %w", err)
+		}
+		if _, err := repos.ImportJobs.SaveProgress(ctx, &progress); err != nil {
+			return fmt.Errorf("save progress: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*job = progress
+	return nil
+}
+
+// writeRow создаёт слово из строки или дополняет существующее.
+// Возвращает выполненное действие. repos и dict привязаны к транзакции пачки;
+// ошибка проверки строки откатывает только её собственные изменения.
+func writeRow(ctx context.Context, repos *repository.Registry, dict *dictionary.Service, rw row, opts options) (RowAction, error) {
+	existing, err := repos.Dictionary.FindByNormalizedText(ctx, opts.entryLanguage(), textnorm.Normalize(opts.entryLanguage(), rw.Text))
+	if err != nil && !database.IsNotFoundError(err) {
+		return "", fmt.Errorf("check duplicate: %w", err)
+	}
+
+	if existing == nil {
+		_, err := dict.CreateWord(ctx, rw.createWordInput(opts))
+		if err == nil {
+			return ActionNew, nil
+		}
+		if !errors.Is(err, types.ErrAlreadyExists) {
+			return "", err
+		}
+		// Слово появилось параллельно — считаем дубликатом
+		if existing, err = repos.Dictionary.FindByNormalizedText(ctx, opts.entryLanguage(), textnorm.Normalize(opts.entryLanguage(), rw.Text)); err != nil {
+			return "", fmt.Errorf("find duplicate: %w", err)
+		}
+	}
+
+	if opts.Duplicates != DuplicatesMerge || !rw.hasSense() {
+		return ActionSkip, nil
+	}
+	sense := rw.senseInput(opts)
+	if _, err := dict.AddSense(ctx, dictionary.AddSenseInput{
+		EntryID:      existing.ID.String(),
+		Definition:   sense.Definition,
+		PartOfSpeech: sense.PartOfSpeech,
+		SourceSlug:   sense.SourceSlug,
+		Translations: sense.Translations,
+		Examples:     sense.Examples,
+	}); err != nil {
+		return "", err
+	}
+	return ActionMerge, nil
+}
+
+// wrapServiceError оборачивает ошибку с контекстом операции, сохраняя типизированные ошибки.
+func wrapServiceError(err error, operation string) error {
+	if errors.Is(err, types.ErrNotFound) ||
+		errors.Is(err, types.ErrInvalidInput) ||
+		types.IsValidationError(err) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", operation, err)
+}
@@ -0,0 +1,40 @@
+package csvimport
+
+// Format — формат файла.
+type Format string
+
+const (
+	FormatCSV Format = "csv" // Значения через запятую
+	FormatTSV Format = "tsv" // Значения через табуляцию
+)
+
+// DuplicatePolicy — что делать со словом, которое уже есть в словаре
+// (или встречалось выше в том же файле).
+type DuplicatePolicy string
+
+const (
+	DuplicatesSkip  DuplicatePolicy = "SKIP"  // Пропустить строку
+	DuplicatesMerge DuplicatePolicy = "MERGE" // Добавить смысл из строки к существующему слову
+)
+
+// ColumnMapping задаёт колонки файла для полей слова.
+// Колонка указывается именем из заголовка (без учёта регистра) или номером
+// начиная с 1. Пустое значение — поле не заполняется.
+type ColumnMapping struct {
+	Text         string // Обязательная
+	Definition   string
+	PartOfSpeech string // NOUN, VERB, ... или сокращения (n, v, adj, adv, ...)
+	Translation  string // Переводы через «;» или по одному на строку
+	Example      string // Примеры по одному на строку
+}
+
+// ImportInput — параметры импорта файла.
+type ImportInput struct {
+	Format              Format // Пусто — CSV
+	Mapping             ColumnMapping
+	HasHeader           bool            // Первая строка — заголовок
+	Language            string          // Язык слов (ISO 639); пусто — английский
+	TranslationLanguage string          // Язык переводов (ISO 639); пусто — русский
+	Duplicates          DuplicatePolicy // Пусто — DuplicatesSkip
+	CreateCards         bool            // Создать карточки для новых слов
+}
@@ -0,0 +1,374 @@
+package csvimport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// row — строка файла, разложенная по полям слова.
+// В таком виде строки сохраняются в задании.
+type row struct {
+	Line         int                 `json:"line"` // Номер строки файла, с которой начинается запись
+	Text         string              `json:"text"`
+	Definition   *string             `json:"definition,omitempty"`
+	PartOfSpeech *model.PartOfSpeech `json:"partOfSpeech,omitempty"`
+	Translations []string            `json:"translations,omitempty"`
+	Examples     []string            `json:"examples,omitempty"`
+
+	err error // Ошибка разбора (например, неизвестная часть речи); не сохраняется
+}
+
+// hasSense сообщает, есть ли в строке содержимое для смысла.
+func (r row) hasSense() bool {
+	return r.Definition != nil || r.PartOfSpeech != nil || len(r.Translations) > 0 || len(r.Examples) > 0
+}
+
+// senseInput собирает смысл из строки.
+func (r row) senseInput(opts options) dictionary.SenseInput {
+	sense := dictionary.SenseInput{
+		Definition:   r.Definition,
+		PartOfSpeech: r.PartOfSpeech,
+		SourceSlug:   SourceSlug,
+	}
+	for _, t := range r.Translations {
+		sense.Translations = append(sense.Translations, dictionary.TranslationInput{
+			Text:       t,
+			Language:   opts.TranslationLanguage,
+			SourceSlug: SourceSlug,
+		})
+	}
+	for _, e := range r.Examples {
+		sense.Examples = append(sense.Examples, dictionary.ExampleInput{
+			Sentence:   e,
+			SourceSlug: SourceSlug,
+		})
+	}
+	return sense
+}
+
+// createWordInput собирает входные данные нового слова из строки.
+func (r row) createWordInput(opts options) dictionary.CreateWordInput {
+	input := dictionary.CreateWordInput{
+		Text:       r.Text,
+		Language:   opts.Language,
+		CreateCard: opts.CreateCards,
+	}
+	if r.hasSense() {
+		input.Senses = []dictionary.SenseInput{r.senseInput(opts)}
+	}
+	return input
+}
+
+// options — параметры импорта, сохраняемые в задании.
+type options struct {
+	Format              Format          `json:"format"`
+	Language            string          `json:"language,omitempty"`
+	TranslationLanguage string          `json:"translationLanguage,omitempty"`
+	Duplicates          DuplicatePolicy `json:"duplicates"`
+	CreateCards         bool            `json:"createCards,omitempty"`
+}
+
+// entryLanguage возвращает язык слов с учётом значения по умолчанию.
+func (o options) entryLanguage() string {
+	if o.Language == "" {
+		return textnorm.DefaultEntryLanguage
+	}
+	return o.Language
+}
+
+// ============================================================================
+// VALIDATION
+// ============================================================================
+
+// validateInput проверяет параметры импорта и возвращает их с учётом
+// значений по умолчанию.
+func validateInput(input ImportInput) (options, error) {
+	opts := options{
+		Format:              input.Format,
+		Language:            input.Language,
+		TranslationLanguage: input.TranslationLanguage,
+		Duplicates:          input.Duplicates,
+		CreateCards:         input.CreateCards,
+	}
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if opts.Format != FormatCSV && opts.Format != FormatTSV {
+		return opts, types.NewValidationError("format", "must be csv or tsv")
+	}
+	if opts.Duplicates == "" {
+		opts.Duplicates = DuplicatesSkip
+	}
+	if opts.Duplicates != DuplicatesSkip && opts.Duplicates != DuplicatesMerge {
+		return opts, types.NewValidationError("duplicates", "must be SKIP or MERGE")
+	}
+	if strings.TrimSpace(input.Mapping.Text) == "" {
+		return opts, types.NewValidationError("mapping.text", "cannot be empty")
+	}
+	if opts.Language != "" && !textnorm.IsValidLanguage(opts.Language) {
+		return opts, types.NewValidationError("language", "must be an ISO 639 language code")
+	}
+	if opts.TranslationLanguage != "" && !textnorm.IsValidLanguage(opts.TranslationLanguage) {
+		return opts, types.NewValidationError("translationLanguage", "must be an ISO 639 language code")
+	}
+	return opts, nil
+}
+
+// ============================================================================
+// PARSING
+// ============================================================================
+
+// columns — индексы колонок файла для полей слова (-1 — поле не заполняется).
+type columns struct {
+	text, definition, partOfSpeech, translation, example int
+}
+
+// parseFile читает файл и раскладывает строки по полям слова.
+// Полностью пустые строки пропускаются. Ошибки отдельных строк
+// сохраняются в row.err; ошибка возвращается, только если файл нельзя
+// прочитать или сопоставление не подходит к файлу.
+func parseFile(r io.Reader, input ImportInput, opts options) ([]row, error) {
+	var records recordReader
+	if opts.Format == FormatTSV {
+		records = newTSVReader(r)
+	} else {
+		records = newCSVReader(r)
+	}
+
+	var (
+		cols   *columns
+		rows   []row
+		header []string
+	)
+	for {
+		record, line, err := records.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cols == nil && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+
+		if cols == nil {
+			if input.HasHeader {
+				header = append([]string(nil), record...)
+			}
+			c, err := resolveColumns(input.Mapping, header)
+			if err != nil {
+				return nil, err
+			}
+			cols = c
+			if input.HasHeader {
+				continue
+			}
+		}
+
+		rw, empty := parseRecord(record, *cols)
+		if empty {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, types.NewValidationError("file", fmt.Sprintf("cannot contain more than %d rows", MaxRows))
+		}
+		rw.Line = line
+		rows = append(rows, rw)
+	}
+
+	if cols == nil {
+		return nil, types.NewValidationError("file", "is empty")
+	}
+	return rows, nil
+}
+
+// maxLineSize — максимальная длина строки TSV.
+const maxLineSize = 1 << 20
+
+// recordReader читает записи файла вместе с номером строки, с которой
+// начинается запись. Конец файла — io.EOF.
+type recordReader interface {
+	next() ([]string, int, error)
+}
+
+// csvReader читает CSV по RFC 4180: поля в кавычках могут содержать
+// запятые и переводы строк.
+type csvReader struct {
+	r *csv.Reader
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &csvReader{r: reader}
+}
+
+func (c *csvReader) next() ([]string, int, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, 0, types.NewValidationError("file", parseErr.Error())
+		}
+		if errors.Is(err, io.EOF) {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, fmt.Errorf("read file: %w", err)
+	}
+	line, _ := c.r.FieldPos(0)
+	return record, line, nil
+}
+
+// tsvReader читает TSV: одна запись на строку, поля через табуляцию.
+// Кавычки не имеют особого смысла, поэтому переводы строк внутри полей
+// не поддерживаются.
+type tsvReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func newTSVReader(r io.Reader) *tsvReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return &tsvReader{s: s}
+}
+
+func (t *tsvReader) next() ([]string, int, error) {
+	if !t.s.Scan() {
+		if err := t.s.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				return nil, 0, types.NewValidationError("file", fmt.Sprintf("line %d is too long", t.line+1))
+			}
+			return nil, 0, fmt.Errorf("read file: %w", err)
+		}
+		return nil, 0, io.EOF
+	}
+	t.line++
+	return strings.Split(strings.TrimSuffix(t.s.Text(), "\r"), "\t"), t.line, nil
+}
+
+// resolveColumns находит колонки сопоставления в заголовке или по номерам.
+func resolveColumns(m ColumnMapping, header []string) (*columns, error) {
+	resolve := func(field, ref string) (int, error) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return -1, nil
+		}
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), ref) {
+				return i, nil
+			}
+		}
+		if n, err := strconv.Atoi(ref); err == nil && n >= 1 {
+			return n - 1, nil
+		}
+		if header == nil {
+			return -1, types.NewValidationError("mapping."+field, "must be a column number when the file has no header")
+		}
+		return -1, types.NewValidationError("mapping."+field, fmt.Sprintf("column %q not found in the header", ref))
+	}
+
+	var (
+		c   columns
+		err error
+	)
+	for _, f := range []struct {
+		name string
+		ref  string
+		dst  *int
+	}{
+		{"text", m.Text, &c.text},
+		{"definition", m.Definition, &c.definition},
+		{"partOfSpeech", m.PartOfSpeech, &c.partOfSpeech},
+		{"translation", m.Translation, &c.translation},
+		{"example", m.Example, &c.example},
+	} {
+		if *f.dst, err = resolve(f.name, f.ref); err != nil {
+			return nil, err
+		}
+	}
+	return &c, nil
+}
+
+// parseRecord раскладывает запись по полям. empty — в сопоставленных
+// колонках ничего нет.
+func parseRecord(record []string, c columns) (rw row, empty bool) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rw.Text = strings.Join(strings.Fields(cell(c.text)), " ")
+	if d := cell(c.definition); d != "" {
+		rw.Definition = &d
+	}
+	if p := cell(c.partOfSpeech); p != "" {
+		if pos, ok := parsePartOfSpeech(p); ok {
+			rw.PartOfSpeech = &pos
+		} else {
+			rw.err = types.NewValidationError("partOfSpeech", fmt.Sprintf("unknown part of speech %q", p))
+		}
+	}
+	rw.Translations = splitList(cell(c.translation), true)
+	rw.Examples = splitList(cell(c.example), false)
+
+	empty = rw.Text == "" && !rw.hasSense() && rw.err == nil
+	return rw, empty
+}
+
+// splitList делит ячейку на элементы по строкам и, если semicolons, по «;».
+// Пустые элементы и повторы отбрасываются.
+func splitList(s string, semicolons bool) []string {
+	if s == "" {
+		return nil
+	}
+	split := func(r rune) bool { return r == '\n' || (semicolons && r == ';') }
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.FieldsFunc(s, split) {
+		if item = strings.TrimSpace(item); item != "" && !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// posAliases — распространённые сокращения частей речи.
+var posAliases = map[string]model.PartOfSpeech{
+	"n": model.PosNoun, "v": model.PosVerb, "vi": model.PosVerb, "vt": model.PosVerb,
+	"adj": model.PosAdjective, "a": model.PosAdjective, "adv": model.PosAdverb,
+	"pron": model.PosPronoun, "prep": model.PosPreposition, "conj": model.PosConjunction,
+	"interj": model.PosInterjection, "int": model.PosInterjection,
+	"phr": model.PosPhrase, "phrase": model.PosPhrase, "idiom": model.PosIdiom,
+}
+
+// parsePartOfSpeech разбирает часть речи: имя значения (без учёта регистра)
+// или сокращение, возможно с точкой на конце.
+func parsePartOfSpeech(s string) (model.PartOfSpeech, bool) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	if pos, ok := posAliases[s]; ok {
+		return pos, true
+	}
+	switch pos := model.PartOfSpeech(strings.ToUpper(s)); pos {
+	case model.PosNoun, model.PosVerb, model.PosAdjective, model.PosAdverb, model.PosPronoun,
+		model.PosPreposition, model.PosConjunction, model.PosInterjection, model.PosPhrase,
+		model.PosIdiom, model.PosOther:
+		return pos, true
+	}
+	return "", false
+}
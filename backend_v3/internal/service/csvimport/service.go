@@ -0,0 +1,66 @@
+// Package csvimport импортирует слова из таблиц CSV/TSV.
+//
+// Колонки файла сопоставляются с полями слова (текст, определение, часть
+// речи, переводы, примеры). Предпросмотр разбирает файл и для каждой строки
+// сообщает, что с ней произойдёт: новое слово, новый смысл существующего
+// слова, пропуск или ошибка. Запуск импорта сохраняет подготовленные строки
+// в задание, которое фоновый обработчик записывает пачками; прогресс
+// задания доступен по его ID.
+package csvimport
+
+import (
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+)
+
+const (
+	// SourceSlug — источник контента, импортированного из таблиц.
+	SourceSlug = "csv"
+
+	// MaxRows — максимальное количество строк данных в одном файле.
+	MaxRows = 10000
+
+	// MaxRowErrors — сколько ошибок строк сохраняется в задании.
+	MaxRowErrors = 100
+
+	// DefaultChunkSize — сколько строк записывается за одну пачку по умолчанию.
+	DefaultChunkSize = 100
+)
+
+// Service реализует импорт слов из таблиц.
+type Service struct {
+	repos      *repository.Registry
+	tx         *database.TxManager
+	dictionary *dictionary.Service
+	chunkSize  int
+}
+
+// NewService создаёт сервис импорта. Слова создаются через сервис словаря;
+// chunkSize — размер пачки записи (0 — DefaultChunkSize).
+func NewService(repos *repository.Registry, tx *database.TxManager, dict *dictionary.Service, chunkSize int) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("tx cannot be nil")
+	}
+	if dict == nil {
+		return nil, fmt.Errorf("dictionary service cannot be nil")
+	}
+	if chunkSize < 0 {
+		return nil, fmt.Errorf("chunk size cannot be negative")
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	return &Service{
+		repos:      repos,
+		tx:         tx,
+		dictionary: dict,
+		chunkSize:  chunkSize,
+	}, nil
+}
@@ -16,7 +16,7 @@ func (s *Service) addSenseTx(ctx context.Context, input AddSenseInput, entryID u
 
 	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем существование записи
-		_, err := s.repos.Dictionary.GetByID(ctx, entryID)
+		entry, err := s.repos.Dictionary.GetByID(ctx, entryID)
 		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
@@ -29,9 +29,12 @@ func (s *Service) addSenseTx(ctx context.Context, input AddSenseInput, entryID u
 			Definition:   input.Definition,
 			PartOfSpeech: input.PartOfSpeech,
 			SourceSlug:   input.SourceSlug,
+			Notes:        input.Notes,
 			Translations: input.Translations,
 			Examples:     input.Examples,
 		})
+		// Новый смысл наследует оценку уровня записи
+		sense.CefrLevel = entry.CefrLevel
 
 		createdSense, err = s.repos.Senses.Create(ctx, sense)
 		if err != nil {
@@ -48,6 +51,12 @@ func (s *Service) addSenseTx(ctx context.Context, input AddSenseInput, entryID u
 			return fmt.Errorf("create examples: %w", err)
 		}
 
+		// Создаем связи
+		createdRelations, err := s.createRelations(ctx, createdSense.ID, entry.Language, input.Relations)
+		if err != nil {
+			return fmt.Errorf("create relations: %w", err)
+		}
+
 		// Создаем аудит-лог для Entry (добавлен новый sense)
 		changes := model.JSON{
 			types.AuditFieldAction: types.AuditActionSenseAdded,
@@ -65,10 +74,13 @@ func (s *Service) addSenseTx(ctx context.Context, input AddSenseInput, entryID u
 		if len(input.Examples) > 0 {
 			changes[types.AuditFieldExamplesCount] = len(input.Examples)
 		}
+		if len(createdRelations) > 0 {
+			changes[types.AuditFieldRelationsCount] = len(createdRelations)
+		}
 
 		// Также создаем отдельный аудит-лог для самого sense
 		senseChanges := buildCreateChanges(createdSense)
-		if err := s.createAuditLogForEntity(ctx, model.EntitySense, createdSense.ID, model.ActionCreate, senseChanges); err != nil {
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntitySense, createdSense.ID, model.ActionCreate, senseChanges); err != nil {
 			return fmt.Errorf("create audit log for sense: %w", err)
 		}
 
@@ -87,10 +99,14 @@ func (s *Service) addSenseTx(ctx context.Context, input AddSenseInput, entryID u
 }
 
 // addExamplesTx выполняет логику добавления примеров внутри транзакции.
-func (s *Service) addExamplesTx(ctx context.Context, input AddExamplesInput, senseID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает смысл, к которому добавлены примеры.
+func (s *Service) addExamplesTx(ctx context.Context, input AddExamplesInput, senseID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем существование смысла
-		sense, err := s.repos.Senses.GetByID(ctx, senseID)
+		var err error
+		sense, err = s.repos.Senses.GetByID(ctx, senseID)
 		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
@@ -109,7 +125,7 @@ func (s *Service) addExamplesTx(ctx context.Context, input AddExamplesInput, sen
 			// Создаем аудит-лог для каждого созданного примера
 			for _, example := range createdExamples {
 				exampleChanges := buildCreateChanges(&example)
-				if err := s.createAuditLogForEntity(ctx, model.EntityExample, example.ID, model.ActionCreate, exampleChanges); err != nil {
+				if err := s.createAuditLogForEntity(ctx, sense.EntryID, model.EntityExample, example.ID, model.ActionCreate, exampleChanges); err != nil {
 					return fmt.Errorf("create audit log for example: %w", err)
 				}
 			}
@@ -127,13 +143,23 @@ func (s *Service) addExamplesTx(ctx context.Context, input AddExamplesInput, sen
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
 }
 
 // addTranslationsTx выполняет логику добавления переводов внутри транзакции.
-func (s *Service) addTranslationsTx(ctx context.Context, input AddTranslationsInput, senseID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает смысл, к которому добавлены переводы.
+func (s *Service) addTranslationsTx(ctx context.Context, input AddTranslationsInput, senseID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем существование смысла
-		sense, err := s.repos.Senses.GetByID(ctx, senseID)
+		var err error
+		sense, err = s.repos.Senses.GetByID(ctx, senseID)
 		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
@@ -176,13 +202,88 @@ func (s *Service) addTranslationsTx(ctx context.Context, input AddTranslationsIn
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
+}
+
+// addRelationsTx выполняет логику добавления связей внутри транзакции.
+// Связи, которые у смысла уже есть, пропускаются.
+// Возвращает смысл, к которому добавлены связи.
+func (s *Service) addRelationsTx(ctx context.Context, input AddRelationsInput, senseID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Связи и аудит — в одной транзакции
+		s := s.WithTx(q)
+
+		// Проверяем существование смысла
+		var err error
+		sense, err = s.repos.Senses.GetByID(ctx, senseID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get sense by ID: %w", err)
+		}
+
+		// Слова связей нормализуются по языку записи
+		entry, err := s.repos.Dictionary.GetByID(ctx, sense.EntryID)
+		if err != nil {
+			return fmt.Errorf("get entry by ID: %w", err)
+		}
+
+		created, err := s.createRelations(ctx, senseID, entry.Language, input.Relations)
+		if err != nil {
+			return fmt.Errorf("create relations: %w", err)
+		}
+		if len(created) == 0 {
+			return nil
+		}
+
+		// Создаем аудит-лог для Entry (добавлены связи)
+		// (SenseRelation не имеет отдельного EntityType, поэтому не создаем отдельный аудит)
+		details := make([]model.JSON, len(created))
+		for i, rel := range created {
+			details[i] = model.JSON{
+				types.AuditFieldRelationID:   rel.ID.String(),
+				types.AuditFieldRelationType: rel.Type,
+				types.AuditFieldTargetText:   rel.TargetText,
+				types.AuditFieldSourceSlug:   rel.SourceSlug,
+			}
+		}
+		changes := model.JSON{
+			types.AuditFieldAction:         types.AuditActionRelationsAdded,
+			types.AuditFieldSenseID:        senseID.String(),
+			types.AuditFieldRelationsCount: len(created),
+			types.AuditFieldRelations:      details,
+		}
+		if err := s.createAuditLog(ctx, sense.EntryID, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
 }
 
 // addImagesTx выполняет логику добавления изображений внутри транзакции.
-func (s *Service) addImagesTx(ctx context.Context, input AddImagesInput, entryID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает запись словаря.
+func (s *Service) addImagesTx(ctx context.Context, input AddImagesInput, entryID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем существование записи
-		_, err := s.repos.Dictionary.GetByID(ctx, entryID)
+		var err error
+		entry, err = s.repos.Dictionary.GetByID(ctx, entryID)
 		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
@@ -201,7 +302,7 @@ func (s *Service) addImagesTx(ctx context.Context, input AddImagesInput, entryID
 			// Создаем аудит-лог для каждого созданного изображения
 			for _, image := range createdImages {
 				imageChanges := buildCreateChanges(&image)
-				if err := s.createAuditLogForEntity(ctx, model.EntityImage, image.ID, model.ActionCreate, imageChanges); err != nil {
+				if err := s.createAuditLogForEntity(ctx, entryID, model.EntityImage, image.ID, model.ActionCreate, imageChanges); err != nil {
 					return fmt.Errorf("create audit log for image: %w", err)
 				}
 			}
@@ -218,13 +319,23 @@ func (s *Service) addImagesTx(ctx context.Context, input AddImagesInput, entryID
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
 }
 
 // addPronunciationsTx выполняет логику добавления произношений внутри транзакции.
-func (s *Service) addPronunciationsTx(ctx context.Context, input AddPronunciationsInput, entryID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает запись словаря.
+func (s *Service) addPronunciationsTx(ctx context.Context, input AddPronunciationsInput, entryID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем существование записи
-		_, err := s.repos.Dictionary.GetByID(ctx, entryID)
+		var err error
+		entry, err = s.repos.Dictionary.GetByID(ctx, entryID)
 		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
@@ -243,7 +354,7 @@ func (s *Service) addPronunciationsTx(ctx context.Context, input AddPronunciatio
 			// Создаем аудит-лог для каждого созданного произношения
 			for _, pronunciation := range createdPronunciations {
 				pronunciationChanges := buildCreateChanges(&pronunciation)
-				if err := s.createAuditLogForEntity(ctx, model.EntityPronunciation, pronunciation.ID, model.ActionCreate, pronunciationChanges); err != nil {
+				if err := s.createAuditLogForEntity(ctx, entryID, model.EntityPronunciation, pronunciation.ID, model.ActionCreate, pronunciationChanges); err != nil {
 					return fmt.Errorf("create audit log for pronunciation: %w", err)
 				}
 			}
@@ -260,4 +371,10 @@ func (s *Service) addPronunciationsTx(ctx context.Context, input AddPronunciatio
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
 }
@@ -12,13 +12,26 @@ import (
 )
 
 // createAuditLog создает запись аудита для операции над записью словаря.
+// Для всех действий, кроме DELETE, к записи прикладывается снимок контента
+// слова после изменения — по нему RestoreWordVersion восстанавливает версию.
 func (s *Service) createAuditLog(ctx context.Context, entityID uuid.UUID, action model.AuditAction, changes model.JSON) error {
 	audit := &model.AuditRecord{
 		EntityType: model.EntityEntry,
 		EntityID:   &entityID,
+		EntryID:    &entityID,
 		Action:     action,
 		Changes:    changes,
 	}
+	if action != model.ActionDelete {
+		snapshot, err := s.loadEntrySnapshot(ctx, entityID)
+		if err != nil {
+			return fmt.Errorf("load entry snapshot: %w", err)
+		}
+		audit.Snapshot, err = snapshot.toJSON()
+		if err != nil {
+			return fmt.Errorf("encode entry snapshot: %w", err)
+		}
+	}
 	_, err := s.repos.Audit.Create(ctx, audit)
 	if err != nil {
 		return fmt.Errorf("create audit log: %w", err)
@@ -26,11 +39,29 @@ func (s *Service) createAuditLog(ctx context.Context, entityID uuid.UUID, action
 	return nil
 }
 
-// createAuditLogForEntity создает запись аудита для любой сущности.
-func (s *Service) createAuditLogForEntity(ctx context.Context, entityType model.EntityType, entityID uuid.UUID, action model.AuditAction, changes model.JSON) error {
+// createAuditLogForEntity создает запись аудита для любой сущности слова entryID.
+func (s *Service) createAuditLogForEntity(ctx context.Context, entryID uuid.UUID, entityType model.EntityType, entityID uuid.UUID, action model.AuditAction, changes model.JSON) error {
 	audit := &model.AuditRecord{
 		EntityType: entityType,
 		EntityID:   &entityID,
+		EntryID:    &entryID,
+		Action:     action,
+		Changes:    changes,
+	}
+	_, err := s.repos.Audit.Create(ctx, audit)
+	if err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+	return nil
+}
+
+// createBulkAuditLog создает одну агрегированную запись аудита для массовой операции.
+// Запись не привязана к конкретному слову: entity_id — ID самой операции,
+// затронутые записи перечислены в changes.
+func (s *Service) createBulkAuditLog(ctx context.Context, operationID uuid.UUID, entityType model.EntityType, action model.AuditAction, changes model.JSON) error {
+	audit := &model.AuditRecord{
+		EntityType: entityType,
+		EntityID:   &operationID,
 		Action:     action,
 		Changes:    changes,
 	}
@@ -59,6 +90,34 @@ func diffDictionaryEntry(old, new *model.DictionaryEntry) model.JSON {
 		}
 	}
 
+	if old.Language != new.Language {
+		changes[types.AuditFieldLanguage] = map[string]any{
+			types.AuditFieldOld: old.Language,
+			types.AuditFieldNew: new.Language,
+		}
+	}
+
+	if !equalStringPtr(old.CefrLevel, new.CefrLevel) {
+		changes[types.AuditFieldCefrLevel] = map[string]any{
+			types.AuditFieldOld: old.CefrLevel,
+			types.AuditFieldNew: new.CefrLevel,
+		}
+	}
+
+	if !equalStringPtr(old.Notes, new.Notes) {
+		changes[types.AuditFieldNotes] = map[string]any{
+			types.AuditFieldOld: old.Notes,
+			types.AuditFieldNew: new.Notes,
+		}
+	}
+
+	if old.NotesOnCard != new.NotesOnCard {
+		changes[types.AuditFieldNotesOnCard] = map[string]any{
+			types.AuditFieldOld: old.NotesOnCard,
+			types.AuditFieldNew: new.NotesOnCard,
+		}
+	}
+
 	return changes
 }
 
@@ -94,6 +153,13 @@ func diffSense(old, new *model.Sense) model.JSON {
 		}
 	}
 
+	if !equalStringPtr(old.Notes, new.Notes) {
+		changes[types.AuditFieldNotes] = map[string]any{
+			types.AuditFieldOld: old.Notes,
+			types.AuditFieldNew: new.Notes,
+		}
+	}
+
 	return changes
 }
 
@@ -143,6 +209,13 @@ func diffTranslation(old, new *model.Translation) model.JSON {
 		}
 	}
 
+	if old.Language != new.Language {
+		changes[types.AuditFieldLanguage] = map[string]any{
+			types.AuditFieldOld: old.Language,
+			types.AuditFieldNew: new.Language,
+		}
+	}
+
 	if old.SourceSlug != new.SourceSlug {
 		changes[types.AuditFieldSourceSlug] = map[string]any{
 			types.AuditFieldOld: old.SourceSlug,
@@ -199,6 +272,13 @@ func diffImage(old, new *model.Image) model.JSON {
 		}
 	}
 
+	if !equalUUIDPtr(old.MediaID, new.MediaID) {
+		changes[types.AuditFieldMediaID] = map[string]any{
+			types.AuditFieldOld: old.MediaID,
+			types.AuditFieldNew: new.MediaID,
+		}
+	}
+
 	if old.SourceSlug != new.SourceSlug {
 		changes[types.AuditFieldSourceSlug] = map[string]any{
 			types.AuditFieldOld: old.SourceSlug,
@@ -234,6 +314,13 @@ func diffPronunciation(old, new *model.Pronunciation) model.JSON {
 		}
 	}
 
+	if !equalUUIDPtr(old.MediaID, new.MediaID) {
+		changes[types.AuditFieldMediaID] = map[string]any{
+			types.AuditFieldOld: old.MediaID,
+			types.AuditFieldNew: new.MediaID,
+		}
+	}
+
 	if old.SourceSlug != new.SourceSlug {
 		changes[types.AuditFieldSourceSlug] = map[string]any{
 			types.AuditFieldOld: old.SourceSlug,
@@ -253,6 +340,9 @@ func buildCreateChanges(entity any) model.JSON {
 	case *model.DictionaryEntry:
 		changes[types.AuditFieldText] = v.Text
 		changes[types.AuditFieldTextNormalized] = v.TextNormalized
+		if v.Notes != nil {
+			changes[types.AuditFieldNotes] = *v.Notes
+		}
 	case *model.Sense:
 		if v.Definition != nil {
 			changes[types.AuditFieldDefinition] = *v.Definition
@@ -264,6 +354,9 @@ func buildCreateChanges(entity any) model.JSON {
 		if v.CefrLevel != nil {
 			changes[types.AuditFieldCefrLevel] = *v.CefrLevel
 		}
+		if v.Notes != nil {
+			changes[types.AuditFieldNotes] = *v.Notes
+		}
 	case *model.Translation:
 		changes[types.AuditFieldText] = v.Text
 		changes[types.AuditFieldSourceSlug] = v.SourceSlug
@@ -279,6 +372,9 @@ func buildCreateChanges(entity any) model.JSON {
 			changes[types.AuditFieldCaption] = *v.Caption
 		}
 		changes[types.AuditFieldSourceSlug] = v.SourceSlug
+		if v.MediaID != nil {
+			changes[types.AuditFieldMediaID] = v.MediaID.String()
+		}
 	case *model.Pronunciation:
 		changes[types.AuditFieldAudioURL] = v.AudioURL
 		if v.Transcription != nil {
@@ -288,6 +384,9 @@ func buildCreateChanges(entity any) model.JSON {
 			changes[types.AuditFieldRegion] = *v.Region
 		}
 		changes[types.AuditFieldSourceSlug] = v.SourceSlug
+		if v.MediaID != nil {
+			changes[types.AuditFieldMediaID] = v.MediaID.String()
+		}
 	case *model.Card:
 		changes[types.AuditFieldEntryID] = v.EntryID.String()
 		changes[types.AuditFieldStatus] = v.Status
@@ -365,6 +464,16 @@ func equalStringPtr(a, b *string) bool {
 	return *a == *b
 }
 
+func equalUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
 func equalPartOfSpeechPtr(a, b *model.PartOfSpeech) bool {
 	if a == nil && b == nil {
 		return true
@@ -6,10 +6,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
 )
 
-// createSenses создает смыслы и связанные с ними сущности (переводы и примеры).
-func (s *Service) createSenses(ctx context.Context, entryID uuid.UUID, senses []SenseInput) error {
+// createSenses создает смыслы и связанные с ними сущности (переводы, примеры
+// и связи). language — язык записи, по нему нормализуются слова связей.
+func (s *Service) createSenses(ctx context.Context, entryID uuid.UUID, language string, senses []SenseInput) error {
 	for i, senseIn := range senses {
 		sense := buildSense(entryID, senseIn)
 		createdSense, err := s.repos.Senses.Create(ctx, sense)
@@ -24,6 +26,10 @@ func (s *Service) createSenses(ctx context.Context, entryID uuid.UUID, senses []
 		if err := s.createExamples(ctx, createdSense.ID, senseIn.Examples); err != nil {
 			return fmt.Errorf("create examples for sense[%d]: %w", i, err)
 		}
+
+		if _, err := s.createRelations(ctx, createdSense.ID, language, senseIn.Relations); err != nil {
+			return fmt.Errorf("create relations for sense[%d]: %w", i, err)
+		}
 	}
 	return nil
 }
@@ -56,6 +62,21 @@ func (s *Service) createExamples(ctx context.Context, senseID uuid.UUID, example
 	return nil
 }
 
+// createRelations создает связи смысла. Связи, которые у смысла уже есть,
+// пропускаются; возвращаются только созданные.
+func (s *Service) createRelations(ctx context.Context, senseID uuid.UUID, language string, relations []RelationInput) ([]model.SenseRelation, error) {
+	if len(relations) == 0 {
+		return nil, nil
+	}
+
+	models := buildRelations(senseID, language, relations)
+	created, err := s.repos.Relations.BatchCreate(ctx, models)
+	if err != nil {
+		return nil, fmt.Errorf("batch create relations: %w", err)
+	}
+	return created, nil
+}
+
 // createImages создает изображения для записи.
 func (s *Service) createImages(ctx context.Context, entryID uuid.UUID, images []ImageInput) error {
 	if len(images) == 0 {
@@ -63,6 +84,14 @@ func (s *Service) createImages(ctx context.Context, entryID uuid.UUID, images []
 	}
 
 	models := buildImages(entryID, images)
+	mediaIDs := make([]*uuid.UUID, len(models))
+	for i := range models {
+		mediaIDs[i] = models[i].MediaID
+	}
+	if err := s.ensureMediaExist(ctx, "images", mediaIDs); err != nil {
+		return err
+	}
+
 	_, err := s.repos.Images.BatchCreate(ctx, models)
 	if err != nil {
 		return fmt.Errorf("batch create images: %w", err)
@@ -77,6 +106,14 @@ func (s *Service) createPronunciations(ctx context.Context, entryID uuid.UUID, p
 	}
 
 	models := buildPronunciations(entryID, pronunciations)
+	mediaIDs := make([]*uuid.UUID, len(models))
+	for i := range models {
+		mediaIDs[i] = models[i].MediaID
+	}
+	if err := s.ensureMediaExist(ctx, "pronunciations", mediaIDs); err != nil {
+		return err
+	}
+
 	_, err := s.repos.Pronunciations.BatchCreate(ctx, models)
 	if err != nil {
 		return fmt.Errorf("batch create pronunciations: %w", err)
@@ -84,6 +121,35 @@ func (s *Service) createPronunciations(ctx context.Context, entryID uuid.UUID, p
 	return nil
 }
 
+// ensureMediaExist проверяет, что указанные медиафайлы сохранены.
+// mediaIDs[i] == nil означает, что i-й объект не ссылается на медиафайл.
+func (s *Service) ensureMediaExist(ctx context.Context, field string, mediaIDs []*uuid.UUID) error {
+	ids := make([]uuid.UUID, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		if id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	found, err := s.repos.Media.ListByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list media: %w", err)
+	}
+	existing := make(map[uuid.UUID]bool, len(found))
+	for _, m := range found {
+		existing[m.ID] = true
+	}
+	for i, id := range mediaIDs {
+		if id != nil && !existing[*id] {
+			return types.NewValidationError(fmt.Sprintf("%s[%d].mediaId", field, i), "media not found")
+		}
+	}
+	return nil
+}
+
 // createCardIfNeeded создает карточку для изучения, если требуется.
 // Использует дефолтные значения для новой карточки согласно алгоритму SM-2.
 func (s *Service) createCardIfNeeded(ctx context.Context, entryID uuid.UUID, createCard bool) error {
@@ -0,0 +1,413 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	ctx_pkg "github.com/heartmarshall/my-english/pkg/context"
+)
+
+// ============================================================================
+// PUBLIC API
+// ============================================================================
+
+// BulkResult — итог массовой операции.
+// При ошибке в одной из частей методы возвращают его вместе с ошибкой:
+// Matched и Affected учитывают только уже зафиксированные части.
+type BulkResult struct {
+	OperationID     uuid.UUID // entity_id агрегированных записей аудита
+	Matched         int       // Активных записей в выборке
+	Affected        int       // Записей, к которым применена операция
+	Chunks          int       // Сколько частей (транзакций) потребовалось
+	CompletedChunks int       // Сколько частей зафиксировано
+}
+
+// BulkDeleteWords помещает выбранные слова в корзину.
+// Карточки и история повторений сохраняются, как и при DeleteWord.
+func (s *Service) BulkDeleteWords(ctx context.Context, input BulkSelectionInput) (*BulkResult, error) {
+	result, err := s.runBulk(ctx, input, (*Service).bulkDeleteTx)
+	if err != nil {
+		return result, wrapServiceError(err, "bulk delete words")
+	}
+	return result, nil
+}
+
+// BulkCreateCards создает карточки для выбранных слов, у которых их ещё нет.
+func (s *Service) BulkCreateCards(ctx context.Context, input BulkSelectionInput) (*BulkResult, error) {
+	result, err := s.runBulk(ctx, input, (*Service).bulkCreateCardsTx)
+	if err != nil {
+		return result, wrapServiceError(err, "bulk create cards")
+	}
+	return result, nil
+}
+
+// BulkResetCards сбрасывает прогресс карточек выбранных слов к состоянию новой карточки.
+// История повторений сохраняется. Слова без карточек пропускаются.
+func (s *Service) BulkResetCards(ctx context.Context, input BulkSelectionInput) (*BulkResult, error) {
+	result, err := s.runBulk(ctx, input, (*Service).bulkResetCardsTx)
+	if err != nil {
+		return result, wrapServiceError(err, "bulk reset cards")
+	}
+	return result, nil
+}
+
+// BulkTag добавляет и снимает метки у выбранных слов.
+// Слова, у которых метки уже такие, пропускаются.
+func (s *Service) BulkTag(ctx context.Context, selection BulkSelectionInput, input BulkTagInput) (*BulkResult, error) {
+	if err := validateBulkTagInput(input); err != nil {
+		return nil, err
+	}
+	add, remove := normalizeTags(input.Add), normalizeTags(input.Remove)
+
+	result, err := s.runBulk(ctx, selection, func(s *Service, ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error) {
+		return s.bulkTagTx(ctx, op, entries, add, remove)
+	})
+	if err != nil {
+		return result, wrapServiceError(err, "bulk tag")
+	}
+	return result, nil
+}
+
+// BulkMoveToList переносит выбранные слова в список list; nil убирает их из списков.
+// Слова, уже лежащие в этом списке, пропускаются.
+func (s *Service) BulkMoveToList(ctx context.Context, selection BulkSelectionInput, list *string) (*BulkResult, error) {
+	if err := validateListName(list); err != nil {
+		return nil, err
+	}
+	list = normalizeListName(list)
+
+	result, err := s.runBulk(ctx, selection, func(s *Service, ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error) {
+		return s.bulkMoveToListTx(ctx, op, entries, list)
+	})
+	if err != nil {
+		return result, wrapServiceError(err, "bulk move to list")
+	}
+	return result, nil
+}
+
+// ============================================================================
+// TRANSACTION LOGIC
+// ============================================================================
+
+// bulkOperation — массовая операция и часть выборки, которая сейчас обрабатывается.
+type bulkOperation struct {
+	ID     uuid.UUID
+	Chunk  int // Номер части, с 1
+	Chunks int // Всего частей
+}
+
+// bulkFunc применяет операцию к активным записям entries внутри транзакции
+// сервиса s и возвращает количество затронутых записей.
+type bulkFunc func(s *Service, ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error)
+
+// runBulk валидирует выборку и выполняет операцию apply.
+//
+// Выборка до BulkChunkSize записей обрабатывается одной транзакцией с одной
+// записью аудита. Большая выборка обрабатывается частями по BulkChunkSize:
+// каждая часть — отдельная транзакция со своей записью аудита под общим
+// OperationID, а поля chunk и chunks в записи показывают прогресс: так каждая
+// зафиксированная часть остаётся в аудите, даже если следующая упадёт.
+// При ошибке уже обработанные части сохраняются и возвращается частичный
+// результат; операции идемпотентны, поэтому повторный запуск обработает
+// оставшиеся записи.
+func (s *Service) runBulk(ctx context.Context, input BulkSelectionInput, apply bulkFunc) (*BulkResult, error) {
+	if err := validateBulkSelectionInput(input); err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	if input.Filter == nil {
+		parsed, err := parseIDs("ids", input.IDs)
+		if err != nil {
+			return nil, err
+		}
+		ids = uniqueIDs(parsed)
+	} else {
+		// Выборка фиксируется до изменений: операция может вывести записи из фильтра.
+		// Берём все ID сразу — они занимают мало памяти даже для большого словаря.
+		var err error
+		ids, err = s.repos.Dictionary.FindIDs(ctx, *input.Filter, math.MaxInt32)
+		if err != nil {
+			return nil, fmt.Errorf("find entries: %w", err)
+		}
+	}
+
+	chunks := (len(ids) + BulkChunkSize - 1) / BulkChunkSize
+	result := &BulkResult{OperationID: uuid.New(), Chunks: chunks}
+	logger := ctx_pkg.L(ctx)
+
+	for i := 0; i < chunks; i++ {
+		op := bulkOperation{ID: result.OperationID, Chunk: i + 1, Chunks: chunks}
+		chunk := ids[i*BulkChunkSize : min((i+1)*BulkChunkSize, len(ids))]
+
+		var matched, affected int
+		err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+			s := s.WithTx(q)
+
+			entries, err := s.repos.Dictionary.ListByIDs(ctx, chunk)
+			if err != nil {
+				return fmt.Errorf("list entries: %w", err)
+			}
+			matched = len(entries)
+			if len(entries) == 0 {
+				return nil
+			}
+
+			affected, err = apply(s, ctx, op, entries)
+			return err
+		})
+		if err != nil {
+			if chunks > 1 {
+				return result, fmt.Errorf("operation %s: chunk %d of %d (%d completed): %w",
+					op.ID, op.Chunk, chunks, result.CompletedChunks, err)
+			}
+			return result, err
+		}
+
+		result.Matched += matched
+		result.Affected += affected
+		result.CompletedChunks++
+		if chunks > 1 {
+			logger.Info("bulk operation progress",
+				slog.String("operation_id", op.ID.String()),
+				slog.Int("chunk", op.Chunk),
+				slog.Int("chunks", chunks),
+				slog.Int("affected", result.Affected))
+		}
+	}
+
+	return result, nil
+}
+
+// createBulkOperationAuditLog создает агрегированную запись аудита части операции.
+func (s *Service) createBulkOperationAuditLog(ctx context.Context, op bulkOperation, entityType model.EntityType, action model.AuditAction, changes model.JSON) error {
+	if op.Chunks > 1 {
+		changes[types.AuditFieldChunk] = op.Chunk
+		changes[types.AuditFieldChunks] = op.Chunks
+	}
+	return s.createBulkAuditLog(ctx, op.ID, entityType, action, changes)
+}
+
+// bulkDeleteTx помещает записи в корзину.
+func (s *Service) bulkDeleteTx(ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error) {
+	trashed, err := s.repos.Dictionary.SoftDeleteMany(ctx, entryIDs(entries), time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("soft delete entries: %w", err)
+	}
+	if len(trashed) == 0 {
+		return 0, nil
+	}
+
+	deleted := make([]any, len(trashed))
+	for i := range trashed {
+		deleted[i] = &trashed[i]
+	}
+	changes := buildBulkChanges("entries", nil, deleted)
+	changes[types.AuditFieldAction] = types.AuditActionBulkTrashed
+	changes[types.AuditFieldEntryIDs] = formatUUIDs(entryIDs(trashed))
+	changes[types.AuditFieldDeletedAt] = formatTimePtr(trashed[0].DeletedAt)
+	if err := s.createBulkOperationAuditLog(ctx, op, model.EntityEntry, model.ActionDelete, changes); err != nil {
+		return 0, err
+	}
+
+	return len(trashed), nil
+}
+
+// bulkCreateCardsTx создает карточки для записей без карточек.
+func (s *Service) bulkCreateCardsTx(ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error) {
+	existing, err := s.repos.Cards.ListByEntryIDs(ctx, entryIDs(entries))
+	if err != nil {
+		return 0, fmt.Errorf("list cards: %w", err)
+	}
+	hasCard := make(map[uuid.UUID]bool, len(existing))
+	for _, c := range existing {
+		hasCard[c.EntryID] = true
+	}
+
+	cards := make([]model.Card, 0, len(entries)-len(existing))
+	for _, e := range entries {
+		if !hasCard[e.ID] {
+			cards = append(cards, model.Card{
+				EntryID:    e.ID,
+				Status:     model.StatusNew,
+				EaseFactor: DefaultEaseFactor,
+			})
+		}
+	}
+	if len(cards) == 0 {
+		return 0, nil
+	}
+
+	created, err := s.repos.Cards.BatchCreate(ctx, cards)
+	if err != nil {
+		return 0, fmt.Errorf("create cards: %w", err)
+	}
+
+	createdAny := make([]any, len(created))
+	for i := range created {
+		createdAny[i] = &created[i]
+	}
+	changes := buildBulkChanges("cards", createdAny, nil)
+	changes[types.AuditFieldAction] = types.AuditActionBulkCardsCreated
+	if err := s.createBulkOperationAuditLog(ctx, op, model.EntityCard, model.ActionCreate, changes); err != nil {
+		return 0, err
+	}
+
+	return len(created), nil
+}
+
+// bulkResetCardsTx сбрасывает прогресс карточек записей.
+// Карточки, уже находящиеся в начальном состоянии, не затрагиваются.
+func (s *Service) bulkResetCardsTx(ctx context.Context, op bulkOperation, entries []model.DictionaryEntry) (int, error) {
+	cards, err := s.repos.Cards.ListByEntryIDs(ctx, entryIDs(entries))
+	if err != nil {
+		return 0, fmt.Errorf("list cards: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(cards))
+	previous := make([]model.JSON, 0, len(cards))
+	for i := range cards {
+		if isFreshCard(&cards[i]) {
+			continue
+		}
+		ids = append(ids, cards[i].ID)
+		previous = append(previous, buildCreateChanges(&cards[i]))
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if _, err := s.repos.Cards.ResetProgress(ctx, ids, DefaultEaseFactor); err != nil {
+		return 0, fmt.Errorf("reset cards: %w", err)
+	}
+
+	// Сохраняем состояние карточек до сброса
+	changes := model.JSON{
+		types.AuditFieldAction:          types.AuditActionBulkCardsReset,
+		types.AuditFieldResetCards:      previous,
+		types.AuditFieldResetCardsCount: len(ids),
+	}
+	if err := s.createBulkOperationAuditLog(ctx, op, model.EntityCard, model.ActionUpdate, changes); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// bulkTagTx добавляет метки add и снимает метки remove у записей.
+func (s *Service) bulkTagTx(ctx context.Context, op bulkOperation, entries []model.DictionaryEntry, add, remove []string) (int, error) {
+	updated, err := s.repos.Dictionary.UpdateTags(ctx, entryIDs(entries), add, remove)
+	if err != nil {
+		return 0, fmt.Errorf("update tags: %w", err)
+	}
+	if len(updated) == 0 {
+		return 0, nil
+	}
+
+	previous := make(map[uuid.UUID][]string, len(entries))
+	for _, e := range entries {
+		previous[e.ID] = e.Tags
+	}
+	tags := make(model.JSON, len(updated))
+	for _, e := range updated {
+		tags[e.ID.String()] = map[string]any{
+			types.AuditFieldOld: previous[e.ID],
+			types.AuditFieldNew: e.Tags,
+		}
+	}
+
+	changes := model.JSON{
+		types.AuditFieldAction:      types.AuditActionBulkTagged,
+		types.AuditFieldEntryIDs:    formatUUIDs(entryIDs(updated)),
+		types.AuditFieldTagsAdded:   add,
+		types.AuditFieldTagsRemoved: remove,
+		types.AuditFieldTags:        tags,
+	}
+	if err := s.createBulkOperationAuditLog(ctx, op, model.EntityEntry, model.ActionUpdate, changes); err != nil {
+		return 0, err
+	}
+
+	return len(updated), nil
+}
+
+// bulkMoveToListTx переносит записи в список list.
+func (s *Service) bulkMoveToListTx(ctx context.Context, op bulkOperation, entries []model.DictionaryEntry, list *string) (int, error) {
+	updated, err := s.repos.Dictionary.SetList(ctx, entryIDs(entries), list)
+	if err != nil {
+		return 0, fmt.Errorf("set list: %w", err)
+	}
+	if len(updated) == 0 {
+		return 0, nil
+	}
+
+	previous := make(map[uuid.UUID]*string, len(entries))
+	for _, e := range entries {
+		previous[e.ID] = e.List
+	}
+	lists := make(model.JSON, len(updated))
+	for _, e := range updated {
+		lists[e.ID.String()] = previous[e.ID]
+	}
+
+	changes := model.JSON{
+		types.AuditFieldAction:        types.AuditActionBulkMovedToList,
+		types.AuditFieldEntryIDs:      formatUUIDs(entryIDs(updated)),
+		types.AuditFieldList:          list,
+		types.AuditFieldPreviousLists: lists,
+	}
+	if err := s.createBulkOperationAuditLog(ctx, op, model.EntityEntry, model.ActionUpdate, changes); err != nil {
+		return 0, err
+	}
+
+	return len(updated), nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// isFreshCard сообщает, что карточка в состоянии только что созданной.
+func isFreshCard(card *model.Card) bool {
+	return card.Status == model.StatusNew &&
+		card.NextReviewAt == nil &&
+		card.IntervalDays == 0 &&
+		card.EaseFactor == DefaultEaseFactor
+}
+
+// entryIDs возвращает ID записей.
+func entryIDs(entries []model.DictionaryEntry) []uuid.UUID {
+	ids := make([]uuid.UUID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// uniqueIDs убирает повторы, сохраняя порядок.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// formatUUIDs форматирует ID для записи аудита.
+func formatUUIDs(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
 	"github.com/heartmarshall/my-english/internal/service/types"
 )
 
@@ -22,6 +24,51 @@ func parseEntryID(idStr string) (uuid.UUID, error) {
 	return id, nil
 }
 
+// parseID парсит строку в UUID для произвольного поля входных данных.
+func parseID(field, idStr string) (uuid.UUID, error) {
+	if idStr == "" {
+		return uuid.Nil, types.NewValidationError(field, "cannot be empty")
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, types.NewValidationError(field, fmt.Sprintf("invalid UUID format: %v", err))
+	}
+
+	return id, nil
+}
+
+// parseIDs парсит список строк в UUID.
+func parseIDs(field string, idStrs []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(idStrs))
+	for i, idStr := range idStrs {
+		id, err := parseID(fmt.Sprintf("%s[%d]", field, i), idStr)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseCursor декодирует курсор пагинации. Пустая строка — начало выборки.
+func parseCursor(after string) (*base.Cursor, error) {
+	cursor, err := base.DecodeCursor(after)
+	if err != nil {
+		return nil, types.NewValidationError("after", "invalid cursor")
+	}
+	return cursor, nil
+}
+
+// wrapPageError преобразует ошибку выборки страницы: курсор, не подходящий
+// к сортировке, — ошибка валидации, остальное оборачивается как обычно.
+func wrapPageError(err error, operation string) error {
+	if errors.Is(err, database.ErrInvalidInput) {
+		return types.NewValidationError("after", "cursor does not match sort order")
+	}
+	return wrapServiceError(err, operation)
+}
+
 // wrapServiceError оборачивает ошибку с контекстом операции, сохраняя типизированные ошибки.
 // Типизированные ошибки (ValidationError, ErrNotFound, ErrAlreadyExists) не оборачиваются.
 func wrapServiceError(err error, operation string) error {
@@ -4,4 +4,31 @@ const (
 	// DefaultEaseFactor — дефолтное значение ease factor для новых карточек (SM-2 алгоритм).
 	// Используется при создании новой карточки для изучения слова.
 	DefaultEaseFactor = 2.5
+
+	// MaxSenseRelations — максимальное количество связей, добавляемых к смыслу за раз.
+	MaxSenseRelations = 100
+
+	// MaxMergeSources — максимальное количество записей, вливаемых за одно слияние.
+	MaxMergeSources = 20
+
+	// MaxBulkEntries — максимальное количество ID в явном списке массовой операции.
+	// Выборка по фильтру не ограничена.
+	MaxBulkEntries = 5000
+
+	// BulkChunkSize — сколько записей массовая операция обрабатывает в одной транзакции.
+	// Выборка больше обрабатывается частями, каждая — своей транзакцией.
+	BulkChunkSize = 1000
+
+	// MaxBulkTags — максимальное количество меток в одной операции bulkTag.
+	MaxBulkTags = 20
+
+	// MaxTagLength — максимальная длина метки в символах.
+	MaxTagLength = 50
+
+	// MaxListNameLength — максимальная длина названия списка в символах.
+	MaxListNameLength = 100
+
+	// DefaultDuplicateSimilarity — порог похожести по умолчанию для кандидатов на слияние.
+	// Подобран так, чтобы находить пары вида "colour"/"color".
+	DefaultDuplicateSimilarity = 0.4
 )
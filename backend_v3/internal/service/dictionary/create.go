@@ -15,7 +15,7 @@ func (s *Service) createWordTx(ctx context.Context, input CreateWordInput, textR
 
 	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Проверяем дубликат
-		exists, err := s.repos.Dictionary.ExistsByNormalizedText(ctx, textNorm)
+		exists, err := s.repos.Dictionary.ExistsByNormalizedText(ctx, input.Language, textNorm)
 		if err != nil {
 			return fmt.Errorf("check duplicate: %w", err)
 		}
@@ -24,7 +24,12 @@ func (s *Service) createWordTx(ctx context.Context, input CreateWordInput, textR
 		}
 
 		// Создаем основную запись (Entry)
-		entry := buildDictionaryEntry(textRaw, textNorm)
+		entry := buildDictionaryEntry(textRaw, textNorm, input.Language)
+		entry.Notes = normalizeNotes(input.Notes)
+		entry.NotesOnCard = input.NotesOnCard
+		if err := s.annotateEntry(ctx, entry); err != nil {
+			return err
+		}
 		createdEntry, err = s.repos.Dictionary.Create(ctx, entry)
 		if err != nil {
 			if database.IsDuplicateError(err) {
@@ -34,9 +39,12 @@ func (s *Service) createWordTx(ctx context.Context, input CreateWordInput, textR
 		}
 
 		// Создаем связанные сущности
-		if err := s.createSenses(ctx, createdEntry.ID, input.Senses); err != nil {
+		if err := s.createSenses(ctx, createdEntry.ID, createdEntry.Language, input.Senses); err != nil {
 			return fmt.Errorf("create senses: %w", err)
 		}
+		if err := s.fillSenseLevels(ctx, createdEntry); err != nil {
+			return err
+		}
 
 		if err := s.createImages(ctx, createdEntry.ID, input.Images); err != nil {
 			return fmt.Errorf("create images: %w", err)
@@ -3,6 +3,7 @@ package dictionary
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/database"
@@ -11,6 +12,8 @@ import (
 )
 
 // deleteWordTx выполняет логику удаления слова внутри транзакции.
+// Слово помещается в корзину: связанные данные, карточка и история повторений сохраняются
+// до восстановления или окончательной очистки.
 func (s *Service) deleteWordTx(ctx context.Context, entryID uuid.UUID) error {
 	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем существующую запись для аудита
@@ -22,16 +25,19 @@ func (s *Service) deleteWordTx(ctx context.Context, entryID uuid.UUID) error {
 			return fmt.Errorf("get entry by ID: %w", err)
 		}
 
-		// Удаляем запись (CASCADE удалит связанные данные)
-		if err := s.repos.Dictionary.Delete(ctx, entryID); err != nil {
+		// Помещаем запись в корзину
+		trashed, err := s.repos.Dictionary.SoftDelete(ctx, entryID, time.Now())
+		if err != nil {
 			if database.IsNotFoundError(err) {
 				return types.ErrNotFound
 			}
-			return fmt.Errorf("delete entry: %w", err)
+			return fmt.Errorf("soft delete entry: %w", err)
 		}
 
 		// Создаем аудит-лог с полной информацией об удаленной сущности
 		changes := buildDeleteChanges(existingEntry)
+		changes[types.AuditFieldAction] = types.AuditActionTrashed
+		changes[types.AuditFieldDeletedAt] = formatTimePtr(trashed.DeletedAt)
 		if err := s.createAuditLog(ctx, entryID, model.ActionDelete, changes); err != nil {
 			return fmt.Errorf("create audit log: %w", err)
 		}
@@ -48,8 +54,11 @@ func (s *Service) deleteWordTx(ctx context.Context, entryID uuid.UUID) error {
 
 // deleteSenseTx выполняет логику удаления смысла внутри транзакции.
 // CASCADE удаление автоматически удалит связанные переводы и примеры.
-func (s *Service) deleteSenseTx(ctx context.Context, senseID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает запись словаря, из которой удален смысл.
+func (s *Service) deleteSenseTx(ctx context.Context, senseID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем смысл для аудита
 		sense, err := s.repos.Senses.GetByID(ctx, senseID)
 		if err != nil {
@@ -81,7 +90,7 @@ func (s *Service) deleteSenseTx(ctx context.Context, senseID uuid.UUID) error {
 
 		// Также создаем отдельный аудит-лог для самого sense
 		senseChanges := buildDeleteChanges(sense)
-		if err := s.createAuditLogForEntity(ctx, model.EntitySense, senseID, model.ActionDelete, senseChanges); err != nil {
+		if err := s.createAuditLogForEntity(ctx, sense.EntryID, model.EntitySense, senseID, model.ActionDelete, senseChanges); err != nil {
 			return fmt.Errorf("create audit log for sense: %w", err)
 		}
 
@@ -89,13 +98,27 @@ func (s *Service) deleteSenseTx(ctx context.Context, senseID uuid.UUID) error {
 			return fmt.Errorf("create audit log: %w", err)
 		}
 
+		entry, err = s.repos.Dictionary.GetByID(ctx, sense.EntryID)
+		if err != nil {
+			return fmt.Errorf("get entry by ID: %w", err)
+		}
+
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
 }
 
 // deleteExampleTx выполняет логику удаления примера внутри транзакции.
-func (s *Service) deleteExampleTx(ctx context.Context, exampleID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает смысл, к которому относился пример.
+func (s *Service) deleteExampleTx(ctx context.Context, exampleID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем пример для аудита
 		example, err := s.repos.Examples.GetByID(ctx, exampleID)
 		if err != nil {
@@ -106,7 +129,7 @@ func (s *Service) deleteExampleTx(ctx context.Context, exampleID uuid.UUID) erro
 		}
 
 		// Получаем смысл для получения entryID
-		sense, err := s.repos.Senses.GetByID(ctx, example.SenseID)
+		sense, err = s.repos.Senses.GetByID(ctx, example.SenseID)
 		if err != nil {
 			return fmt.Errorf("get sense by ID: %w", err)
 		}
@@ -127,8 +150,8 @@ func (s *Service) deleteExampleTx(ctx context.Context, exampleID uuid.UUID) erro
 		}
 
 		// Также создаем отдельный аудит-лог для самого примера
-		exampleChanges := buildDeleteChanges(&example)
-		if err := s.createAuditLogForEntity(ctx, model.EntityExample, exampleID, model.ActionDelete, exampleChanges); err != nil {
+		exampleChanges := buildDeleteChanges(example)
+		if err := s.createAuditLogForEntity(ctx, sense.EntryID, model.EntityExample, exampleID, model.ActionDelete, exampleChanges); err != nil {
 			return fmt.Errorf("create audit log for example: %w", err)
 		}
 
@@ -138,11 +161,20 @@ func (s *Service) deleteExampleTx(ctx context.Context, exampleID uuid.UUID) erro
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
 }
 
 // deleteTranslationTx выполняет логику удаления перевода внутри транзакции.
-func (s *Service) deleteTranslationTx(ctx context.Context, translationID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает смысл, к которому относился перевод.
+func (s *Service) deleteTranslationTx(ctx context.Context, translationID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем перевод для аудита
 		translation, err := s.repos.Translations.GetByID(ctx, translationID)
 		if err != nil {
@@ -153,7 +185,7 @@ func (s *Service) deleteTranslationTx(ctx context.Context, translationID uuid.UU
 		}
 
 		// Получаем смысл для получения entryID
-		sense, err := s.repos.Senses.GetByID(ctx, translation.SenseID)
+		sense, err = s.repos.Senses.GetByID(ctx, translation.SenseID)
 		if err != nil {
 			return fmt.Errorf("get sense by ID: %w", err)
 		}
@@ -181,11 +213,74 @@ func (s *Service) deleteTranslationTx(ctx context.Context, translationID uuid.UU
 
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
+}
+
+// deleteRelationTx выполняет логику удаления связи внутри транзакции.
+// Возвращает смысл, к которому относилась связь.
+func (s *Service) deleteRelationTx(ctx context.Context, relationID uuid.UUID) (*model.Sense, error) {
+	var sense *model.Sense
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Удаление связи и аудит — в одной транзакции
+		s := s.WithTx(q)
+
+		// Получаем связь для аудита
+		relation, err := s.repos.Relations.GetByID(ctx, relationID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get relation by ID: %w", err)
+		}
+
+		// Получаем смысл для получения entryID
+		sense, err = s.repos.Senses.GetByID(ctx, relation.SenseID)
+		if err != nil {
+			return fmt.Errorf("get sense by ID: %w", err)
+		}
+
+		// Удаляем связь
+		if err := s.repos.Relations.Delete(ctx, relationID); err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("delete relation: %w", err)
+		}
+
+		// Создаем аудит-лог для Entry (удалена связь)
+		changes := model.JSON{
+			types.AuditFieldAction:       types.AuditActionRelationDeleted,
+			types.AuditFieldSenseID:      relation.SenseID.String(),
+			types.AuditFieldRelationID:   relationID.String(),
+			types.AuditFieldRelationType: relation.Type,
+			types.AuditFieldTargetText:   relation.TargetText,
+		}
+		if err := s.createAuditLog(ctx, sense.EntryID, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sense, nil
 }
 
 // deleteImageTx выполняет логику удаления изображения внутри транзакции.
-func (s *Service) deleteImageTx(ctx context.Context, imageID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает запись словаря, к которой относилось изображение.
+func (s *Service) deleteImageTx(ctx context.Context, imageID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем изображение для аудита
 		image, err := s.repos.Images.GetByID(ctx, imageID)
 		if err != nil {
@@ -211,8 +306,8 @@ func (s *Service) deleteImageTx(ctx context.Context, imageID uuid.UUID) error {
 		}
 
 		// Также создаем отдельный аудит-лог для самого изображения
-		imageChanges := buildDeleteChanges(&image)
-		if err := s.createAuditLogForEntity(ctx, model.EntityImage, imageID, model.ActionDelete, imageChanges); err != nil {
+		imageChanges := buildDeleteChanges(image)
+		if err := s.createAuditLogForEntity(ctx, image.EntryID, model.EntityImage, imageID, model.ActionDelete, imageChanges); err != nil {
 			return fmt.Errorf("create audit log for image: %w", err)
 		}
 
@@ -220,13 +315,27 @@ func (s *Service) deleteImageTx(ctx context.Context, imageID uuid.UUID) error {
 			return fmt.Errorf("create audit log: %w", err)
 		}
 
+		entry, err = s.repos.Dictionary.GetByID(ctx, image.EntryID)
+		if err != nil {
+			return fmt.Errorf("get entry by ID: %w", err)
+		}
+
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
 }
 
 // deletePronunciationTx выполняет логику удаления произношения внутри транзакции.
-func (s *Service) deletePronunciationTx(ctx context.Context, pronunciationID uuid.UUID) error {
-	return s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+// Возвращает запись словаря, к которой относилось произношение.
+func (s *Service) deletePronunciationTx(ctx context.Context, pronunciationID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
 		// Получаем произношение для аудита
 		pronunciation, err := s.repos.Pronunciations.GetByID(ctx, pronunciationID)
 		if err != nil {
@@ -252,8 +361,8 @@ func (s *Service) deletePronunciationTx(ctx context.Context, pronunciationID uui
 		}
 
 		// Также создаем отдельный аудит-лог для самого произношения
-		pronunciationChanges := buildDeleteChanges(&pronunciation)
-		if err := s.createAuditLogForEntity(ctx, model.EntityPronunciation, pronunciationID, model.ActionDelete, pronunciationChanges); err != nil {
+		pronunciationChanges := buildDeleteChanges(pronunciation)
+		if err := s.createAuditLogForEntity(ctx, pronunciation.EntryID, model.EntityPronunciation, pronunciationID, model.ActionDelete, pronunciationChanges); err != nil {
 			return fmt.Errorf("create audit log for pronunciation: %w", err)
 		}
 
@@ -261,6 +370,17 @@ func (s *Service) deletePronunciationTx(ctx context.Context, pronunciationID uui
 			return fmt.Errorf("create audit log: %w", err)
 		}
 
+		entry, err = s.repos.Dictionary.GetByID(ctx, pronunciation.EntryID)
+		if err != nil {
+			return fmt.Errorf("get entry by ID: %w", err)
+		}
+
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
 }
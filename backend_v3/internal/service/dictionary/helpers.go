@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
 )
 
 // normalizeText нормализует текст для поиска и сравнения.
@@ -13,11 +14,68 @@ func normalizeText(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
 
+// normalizeWord нормализует текст слова с учётом его языка
+// (NFKC, регистр, диакритика — см. textnorm.Normalize).
+func normalizeWord(language, s string) string {
+	return textnorm.Normalize(language, s)
+}
+
+// languageOrDefault возвращает код языка или def, если код не задан.
+func languageOrDefault(language, def string) string {
+	if language == "" {
+		return def
+	}
+	return language
+}
+
+// normalizeTranslationText нормализует текст перевода для обратного поиска
+// по правилам русского (NFKC, регистр, «ё» -> «е»), как это делает индекс по translations.
+func normalizeTranslationText(s string) string {
+	return textnorm.Normalize(textnorm.DefaultTranslationLanguage, s)
+}
+
+// normalizeTag приводит метку к нижнему регистру и схлопывает пробелы:
+// "Travel  Words" и "travel words" — одна метка.
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+// normalizeTags нормализует метки, сохраняя порядок.
+func normalizeTags(tags []string) []string {
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = normalizeTag(tag)
+	}
+	return result
+}
+
+// normalizeListName схлопывает пробелы в названии списка; регистр сохраняется.
+func normalizeListName(list *string) *string {
+	if list == nil {
+		return nil
+	}
+	name := strings.Join(strings.Fields(*list), " ")
+	return &name
+}
+
+// normalizeNotes убирает пробелы по краям заметок; пустые заметки хранятся как NULL.
+func normalizeNotes(notes *string) *string {
+	if notes == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*notes)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
 // buildEntry создает модель DictionaryEntry из входных данных.
-func buildDictionaryEntry(textRaw, textNorm string) *model.DictionaryEntry {
+func buildDictionaryEntry(textRaw, textNorm, language string) *model.DictionaryEntry {
 	return &model.DictionaryEntry{
 		Text:           textRaw,
 		TextNormalized: textNorm,
+		Language:       language,
 	}
 }
 
@@ -28,6 +86,7 @@ func buildSense(entryID uuid.UUID, senseIn SenseInput) *model.Sense {
 		Definition:   senseIn.Definition,
 		PartOfSpeech: senseIn.PartOfSpeech,
 		SourceSlug:   senseIn.SourceSlug,
+		Notes:        normalizeNotes(senseIn.Notes),
 	}
 }
 
@@ -42,6 +101,7 @@ func buildTranslations(senseID uuid.UUID, translations []TranslationInput) []mod
 		result[i] = model.Translation{
 			SenseID:    senseID,
 			Text:       tr.Text,
+			Language:   languageOrDefault(tr.Language, textnorm.DefaultTranslationLanguage),
 			SourceSlug: tr.SourceSlug,
 		}
 	}
@@ -66,6 +126,35 @@ func buildExamples(senseID uuid.UUID, examples []ExampleInput) []model.Example {
 	return result
 }
 
+// buildRelations создает слайс моделей SenseRelation из входных данных.
+// Текст слова нормализуется по правилам языка записи смысла; повторы
+// (тот же тип и нормализованный текст) отбрасываются.
+func buildRelations(senseID uuid.UUID, language string, relations []RelationInput) []model.SenseRelation {
+	if len(relations) == 0 {
+		return nil
+	}
+
+	result := make([]model.SenseRelation, 0, len(relations))
+	seen := make(map[string]struct{}, len(relations))
+	for _, rel := range relations {
+		text := strings.TrimSpace(rel.TargetText)
+		norm := normalizeWord(language, text)
+		key := string(rel.Type) + "|" + norm
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, model.SenseRelation{
+			SenseID:              senseID,
+			Type:                 rel.Type,
+			TargetText:           text,
+			TargetTextNormalized: norm,
+			SourceSlug:           rel.SourceSlug,
+		})
+	}
+	return result
+}
+
 // buildImages создает слайс моделей Image из входных данных.
 func buildImages(entryID uuid.UUID, images []ImageInput) []model.Image {
 	if len(images) == 0 {
@@ -74,11 +163,13 @@ func buildImages(entryID uuid.UUID, images []ImageInput) []model.Image {
 
 	result := make([]model.Image, len(images))
 	for i, img := range images {
+		url, mediaID := resolveMedia(img.URL, img.MediaID)
 		result[i] = model.Image{
 			EntryID:    entryID,
-			URL:        img.URL,
+			URL:        url,
 			Caption:    img.Caption,
 			SourceSlug: img.SourceSlug,
+			MediaID:    mediaID,
 		}
 	}
 	return result
@@ -92,13 +183,41 @@ func buildPronunciations(entryID uuid.UUID, pronunciations []PronunciationInput)
 
 	result := make([]model.Pronunciation, len(pronunciations))
 	for i, p := range pronunciations {
+		url, mediaID := resolveMedia(p.AudioURL, p.MediaID)
 		result[i] = model.Pronunciation{
 			EntryID:       entryID,
-			AudioURL:      p.AudioURL,
+			AudioURL:      url,
 			Transcription: p.Transcription,
 			Region:        p.Region,
 			SourceSlug:    p.SourceSlug,
+			MediaID:       mediaID,
 		}
 	}
 	return result
 }
+
+// resolveMedia разбирает ссылку на медиафайл и возвращает URL объекта.
+// Если URL не указан, объект ссылается на файл в хранилище приложения.
+// Формат mediaID проверяется при валидации входных данных.
+func resolveMedia(url string, mediaID *string) (string, *uuid.UUID) {
+	if mediaID == nil || *mediaID == "" {
+		return url, nil
+	}
+	id, err := uuid.Parse(*mediaID)
+	if err != nil {
+		return url, nil
+	}
+	if strings.TrimSpace(url) == "" {
+		url = model.MediaURL(id)
+	}
+	return url, &id
+}
+
+// formatUUIDPtr преобразует опциональный UUID в строку для входных данных.
+func formatUUIDPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,457 @@
+package dictionary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	repo_audit "github.com/heartmarshall/my-english/internal/database/repository/audit"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// AuditFilter — псевдоним типа из репозитория.
+type AuditFilter = repository.AuditFilter
+
+// ============================================================================
+// AUDIT LOG
+// ============================================================================
+
+// AuditLog возвращает историю изменений по фильтру: сначала новые записи.
+func (s *Service) AuditLog(ctx context.Context, filter AuditFilter) ([]model.AuditRecord, error) {
+	if filter.Limit < 0 {
+		return nil, types.NewValidationError("limit", "cannot be negative")
+	}
+	if filter.Offset < 0 {
+		return nil, types.NewValidationError("offset", "cannot be negative")
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, types.NewValidationError("from", "must not be after to")
+	}
+
+	records, err := s.repos.Audit.Find(ctx, filter)
+	if err != nil {
+		return nil, wrapServiceError(err, "list audit log")
+	}
+	return records, nil
+}
+
+// AuditLogPage возвращает страницу истории изменений для курсорной пагинации.
+// first — размер страницы, after — курсор последней записи предыдущей страницы.
+func (s *Service) AuditLogPage(ctx context.Context, filter AuditFilter, first int, after string) (*types.Page[model.AuditRecord], error) {
+	if first < 0 {
+		return nil, types.NewValidationError("first", "cannot be negative")
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, types.NewValidationError("from", "must not be after to")
+	}
+	cursor, err := parseCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = first
+	filter.Offset = 0
+	records, hasNext, err := s.repos.Audit.FindPage(ctx, filter, cursor)
+	if err != nil {
+		return nil, wrapPageError(err, "find audit log page")
+	}
+
+	total, err := s.repos.Audit.CountTotal(ctx, filter)
+	if err != nil {
+		return nil, wrapServiceError(err, "count audit log")
+	}
+
+	cursors := make([]string, len(records))
+	for i := range records {
+		cursors[i] = repo_audit.RecordCursor(records[i]).Encode()
+	}
+
+	return &types.Page[model.AuditRecord]{
+		Items:           records,
+		Cursors:         cursors,
+		HasNextPage:     hasNext,
+		HasPreviousPage: cursor != nil,
+		TotalCount:      total,
+	}, nil
+}
+
+// RestoreWordVersion восстанавливает контент слова (текст, заметки, смыслы, переводы, примеры, связи,
+// изображения, произношения) по последнему снимку аудита, сделанному не позже at.
+// Изменения применяются точечным патчем: сохранившиеся сущности сохраняют свои ID.
+func (s *Service) RestoreWordVersion(ctx context.Context, id string, at time.Time) (*model.DictionaryEntry, error) {
+	entryID, err := parseEntryID(id)
+	if err != nil {
+		return nil, err
+	}
+	if at.IsZero() {
+		return nil, types.NewValidationError("at", "cannot be empty")
+	}
+
+	entry, err := s.restoreWordVersionTx(ctx, entryID, at)
+	if err != nil {
+		return nil, wrapServiceError(err, "restore word version")
+	}
+
+	return entry, nil
+}
+
+// restoreWordVersionTx выполняет логику восстановления версии слова внутри транзакции.
+func (s *Service) restoreWordVersionTx(ctx context.Context, entryID uuid.UUID, at time.Time) (*model.DictionaryEntry, error) {
+	var updatedEntry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Запись, вложенные сущности и аудит восстанавливаются в одной транзакции
+		s := s.WithTx(q)
+
+		existingEntry, err := s.repos.Dictionary.GetByID(ctx, entryID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get entry by ID: %w", err)
+		}
+
+		record, err := s.repos.Audit.GetLatestSnapshot(ctx, entryID, at)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.NewValidationError("at", "no saved version at or before this time")
+			}
+			return fmt.Errorf("get snapshot: %w", err)
+		}
+		target, err := parseEntrySnapshot(record.Snapshot)
+		if err != nil {
+			return fmt.Errorf("decode snapshot: %w", err)
+		}
+
+		current, err := s.loadEntrySnapshot(ctx, entryID)
+		if err != nil {
+			return fmt.Errorf("load current snapshot: %w", err)
+		}
+
+		// Текст и заметки слова
+		updatedEntry = existingEntry
+		if target.Text != existingEntry.Text || !equalStringPtr(target.Notes, existingEntry.Notes) {
+			textNorm := existingEntry.TextNormalized
+			if target.Text != existingEntry.Text {
+				textNorm = normalizeWord(existingEntry.Language, target.Text)
+				existingByText, err := s.repos.Dictionary.FindByNormalizedText(ctx, existingEntry.Language, textNorm)
+				if err != nil && !database.IsNotFoundError(err) {
+					return fmt.Errorf("check duplicate text: %w", err)
+				}
+				if existingByText != nil && existingByText.ID != entryID {
+					return types.ErrAlreadyExists
+				}
+			}
+
+			entry := buildDictionaryEntry(target.Text, textNorm, existingEntry.Language)
+			entry.Notes = target.Notes
+			entry.NotesOnCard = existingEntry.NotesOnCard
+			if err := s.annotateEntry(ctx, entry); err != nil {
+				return err
+			}
+			updatedEntry, err = s.repos.Dictionary.Update(ctx, entryID, entry)
+			if err != nil {
+				if database.IsDuplicateError(err) {
+					return types.ErrAlreadyExists
+				}
+				return fmt.Errorf("update entry: %w", err)
+			}
+		}
+
+		// Вложенные сущности: патч от текущего состояния к снимку
+		patch := buildVersionPatch(current, target)
+		patchAudit := entryPatchAudit{entryID: entryID}
+		if err := s.patchSenses(ctx, entryID, existingEntry.Language, patch.Senses, patch.DeleteSenseIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch senses: %w", err)
+		}
+		if err := s.patchImages(ctx, entryID, patch.Images, patch.DeleteImageIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch images: %w", err)
+		}
+		if err := s.patchPronunciations(ctx, entryID, patch.Pronunciations, patch.DeletePronunciationIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch pronunciations: %w", err)
+		}
+
+		changes := diffDictionaryEntry(existingEntry, updatedEntry)
+		changes[types.AuditFieldAction] = types.AuditActionVersionRestored
+		changes[types.AuditFieldRestoredFrom] = record.ID.String()
+		changes[types.AuditFieldVersionAt] = record.CreatedAt.Format(time.RFC3339)
+		patchAudit.addTo(changes)
+		if err := s.createAuditLog(ctx, entryID, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedEntry, nil
+}
+
+// ============================================================================
+// SNAPSHOTS
+// ============================================================================
+
+// entrySnapshot — полный снимок контента слова, хранящийся в аудите.
+type entrySnapshot struct {
+	Text           string                `json:"text"`
+	Notes          *string               `json:"notes"`
+	Senses         []senseSnapshot       `json:"senses"`
+	Images         []model.Image         `json:"images"`
+	Pronunciations []model.Pronunciation `json:"pronunciations"`
+}
+
+// senseSnapshot — снимок смысла вместе с переводами, примерами и связями.
+// Relations равен nil в снимках, сделанных до появления связей.
+type senseSnapshot struct {
+	model.Sense
+	Translations []model.Translation   `json:"translations"`
+	Examples     []model.Example       `json:"examples"`
+	Relations    []model.SenseRelation `json:"relations"`
+}
+
+// loadEntrySnapshot загружает текущее состояние контента слова.
+func (s *Service) loadEntrySnapshot(ctx context.Context, entryID uuid.UUID) (*entrySnapshot, error) {
+	entry, err := s.repos.Dictionary.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("get entry: %w", err)
+	}
+
+	entryIDs := []uuid.UUID{entryID}
+	senses, err := s.repos.Senses.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list senses: %w", err)
+	}
+	senseIDs := make([]uuid.UUID, 0, len(senses))
+	for _, sense := range senses {
+		senseIDs = append(senseIDs, sense.ID)
+	}
+
+	var translations []model.Translation
+	var examples []model.Example
+	var relations []model.SenseRelation
+	if len(senseIDs) > 0 {
+		translations, err = s.repos.Translations.ListBySenseIDs(ctx, senseIDs)
+		if err != nil {
+			return nil, fmt.Errorf("list translations: %w", err)
+		}
+		examples, err = s.repos.Examples.ListBySenseIDs(ctx, senseIDs)
+		if err != nil {
+			return nil, fmt.Errorf("list examples: %w", err)
+		}
+		relations, err = s.repos.Relations.ListBySenseIDs(ctx, senseIDs)
+		if err != nil {
+			return nil, fmt.Errorf("list relations: %w", err)
+		}
+	}
+
+	images, err := s.repos.Images.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+	pronunciations, err := s.repos.Pronunciations.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list pronunciations: %w", err)
+	}
+
+	snapshot := &entrySnapshot{
+		Text:           entry.Text,
+		Notes:          entry.Notes,
+		Senses:         make([]senseSnapshot, 0, len(senses)),
+		Images:         images,
+		Pronunciations: pronunciations,
+	}
+	for _, sense := range senses {
+		ss := senseSnapshot{Sense: sense, Relations: []model.SenseRelation{}}
+		for _, tr := range translations {
+			if tr.SenseID == sense.ID {
+				ss.Translations = append(ss.Translations, tr)
+			}
+		}
+		for _, ex := range examples {
+			if ex.SenseID == sense.ID {
+				ss.Examples = append(ss.Examples, ex)
+			}
+		}
+		for _, rel := range relations {
+			if rel.SenseID == sense.ID {
+				ss.Relations = append(ss.Relations, rel)
+			}
+		}
+		snapshot.Senses = append(snapshot.Senses, ss)
+	}
+
+	return snapshot, nil
+}
+
+// toJSON кодирует снимок для хранения в JSONB.
+func (s *entrySnapshot) toJSON() (model.JSON, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var out model.JSON
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// parseEntrySnapshot декодирует снимок из JSONB.
+func parseEntrySnapshot(raw model.JSON) (*entrySnapshot, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot entrySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// buildVersionPatch строит патч, переводящий контент слова из current в target.
+// Сущности, сохранившиеся с момента снимка, обновляются по ID;
+// удалённые с тех пор создаются заново; новые — удаляются.
+// Связи смысла из снимка без связей (сделанного до их появления) не меняются.
+func buildVersionPatch(current, target *entrySnapshot) UpdateWordInput {
+	var patch UpdateWordInput
+
+	currentSenses := make(map[uuid.UUID]senseSnapshot, len(current.Senses))
+	for _, sense := range current.Senses {
+		currentSenses[sense.ID] = sense
+	}
+	keepSenses := make(map[uuid.UUID]bool, len(target.Senses))
+
+	for _, sense := range target.Senses {
+		cur, exists := currentSenses[sense.ID]
+		up := SenseUpsertInput{
+			Definition:   sense.Definition,
+			PartOfSpeech: sense.PartOfSpeech,
+			SourceSlug:   sense.SourceSlug,
+			Notes:        sense.Notes,
+		}
+		if exists {
+			keepSenses[sense.ID] = true
+			up.ID = idPtr(sense.ID)
+		}
+
+		curTranslations := make(map[uuid.UUID]bool, len(cur.Translations))
+		for _, tr := range cur.Translations {
+			curTranslations[tr.ID] = true
+		}
+		keepTranslations := make(map[uuid.UUID]bool, len(sense.Translations))
+		for _, tr := range sense.Translations {
+			in := TranslationUpsertInput{Text: tr.Text, Language: tr.Language, SourceSlug: tr.SourceSlug}
+			if curTranslations[tr.ID] {
+				keepTranslations[tr.ID] = true
+				in.ID = idPtr(tr.ID)
+			}
+			up.Translations = append(up.Translations, in)
+		}
+		for _, tr := range cur.Translations {
+			if !keepTranslations[tr.ID] {
+				up.DeleteTranslationIDs = append(up.DeleteTranslationIDs, tr.ID.String())
+			}
+		}
+
+		curExamples := make(map[uuid.UUID]bool, len(cur.Examples))
+		for _, ex := range cur.Examples {
+			curExamples[ex.ID] = true
+		}
+		keepExamples := make(map[uuid.UUID]bool, len(sense.Examples))
+		for _, ex := range sense.Examples {
+			in := ExampleUpsertInput{Sentence: ex.Sentence, Translation: ex.Translation, SourceSlug: ex.SourceSlug}
+			if curExamples[ex.ID] {
+				keepExamples[ex.ID] = true
+				in.ID = idPtr(ex.ID)
+			}
+			up.Examples = append(up.Examples, in)
+		}
+		for _, ex := range cur.Examples {
+			if !keepExamples[ex.ID] {
+				up.DeleteExampleIDs = append(up.DeleteExampleIDs, ex.ID.String())
+			}
+		}
+
+		if sense.Relations != nil {
+			curRelations := make(map[uuid.UUID]bool, len(cur.Relations))
+			for _, rel := range cur.Relations {
+				curRelations[rel.ID] = true
+			}
+			keepRelations := make(map[uuid.UUID]bool, len(sense.Relations))
+			for _, rel := range sense.Relations {
+				if curRelations[rel.ID] {
+					keepRelations[rel.ID] = true
+					continue
+				}
+				up.Relations = append(up.Relations, RelationInput{Type: rel.Type, TargetText: rel.TargetText, SourceSlug: rel.SourceSlug})
+			}
+			for _, rel := range cur.Relations {
+				if !keepRelations[rel.ID] {
+					up.DeleteRelationIDs = append(up.DeleteRelationIDs, rel.ID.String())
+				}
+			}
+		}
+
+		patch.Senses = append(patch.Senses, up)
+	}
+	for _, sense := range current.Senses {
+		if !keepSenses[sense.ID] {
+			patch.DeleteSenseIDs = append(patch.DeleteSenseIDs, sense.ID.String())
+		}
+	}
+
+	currentImages := make(map[uuid.UUID]bool, len(current.Images))
+	for _, img := range current.Images {
+		currentImages[img.ID] = true
+	}
+	keepImages := make(map[uuid.UUID]bool, len(target.Images))
+	for _, img := range target.Images {
+		in := ImageUpsertInput{URL: img.URL, Caption: img.Caption, SourceSlug: img.SourceSlug, MediaID: formatUUIDPtr(img.MediaID)}
+		if currentImages[img.ID] {
+			keepImages[img.ID] = true
+			in.ID = idPtr(img.ID)
+		}
+		patch.Images = append(patch.Images, in)
+	}
+	for _, img := range current.Images {
+		if !keepImages[img.ID] {
+			patch.DeleteImageIDs = append(patch.DeleteImageIDs, img.ID.String())
+		}
+	}
+
+	currentPronunciations := make(map[uuid.UUID]bool, len(current.Pronunciations))
+	for _, p := range current.Pronunciations {
+		currentPronunciations[p.ID] = true
+	}
+	keepPronunciations := make(map[uuid.UUID]bool, len(target.Pronunciations))
+	for _, p := range target.Pronunciations {
+		in := PronunciationUpsertInput{AudioURL: p.AudioURL, Transcription: p.Transcription, Region: p.Region, SourceSlug: p.SourceSlug, MediaID: formatUUIDPtr(p.MediaID)}
+		if currentPronunciations[p.ID] {
+			keepPronunciations[p.ID] = true
+			in.ID = idPtr(p.ID)
+		}
+		patch.Pronunciations = append(patch.Pronunciations, in)
+	}
+	for _, p := range current.Pronunciations {
+		if !keepPronunciations[p.ID] {
+			patch.DeletePronunciationIDs = append(patch.DeletePronunciationIDs, p.ID.String())
+		}
+	}
+
+	return patch
+}
+
+// idPtr возвращает строковое представление UUID для полей *Input.
+func idPtr(id uuid.UUID) *string {
+	s := id.String()
+	return &s
+}
@@ -5,6 +5,9 @@ import "github.com/heartmarshall/my-english/internal/model"
 // CreateWordInput — полный набор данных для создания слова.
 type CreateWordInput struct {
 	Text           string
+	Language       string  // Код языка слова (ISO 639); пусто — английский
+	Notes          *string // Личные заметки в Markdown
+	NotesOnCard    bool    // Показывать заметки на обратной стороне карточки
 	Senses         []SenseInput
 	Images         []ImageInput
 	Pronunciations []PronunciationInput
@@ -15,12 +18,15 @@ type SenseInput struct {
 	Definition   *string
 	PartOfSpeech *model.PartOfSpeech
 	SourceSlug   string
+	Notes        *string // Личные заметки в Markdown
 	Translations []TranslationInput
 	Examples     []ExampleInput
+	Relations    []RelationInput
 }
 
 type TranslationInput struct {
 	Text       string
+	Language   string // Код языка перевода (ISO 639); пусто — русский
 	SourceSlug string
 }
 
@@ -30,26 +36,99 @@ type ExampleInput struct {
 	SourceSlug  string
 }
 
+// RelationInput — связь смысла со словом того же языка.
+type RelationInput struct {
+	Type       model.RelationType
+	TargetText string
+	SourceSlug string
+}
+
 type ImageInput struct {
-	URL        string
+	URL        string // Можно не указывать, если задан MediaID
 	Caption    *string
 	SourceSlug string
+	MediaID    *string // UUID сохранённого медиафайла
 }
 
 type PronunciationInput struct {
-	AudioURL      string
+	AudioURL      string // Можно не указывать, если задан MediaID
 	Transcription *string
 	Region        *string
 	SourceSlug    string
+	MediaID       *string // UUID сохранённого медиафайла
 }
 
-// UpdateWordInput — полный набор данных для обновления слова.
+// UpdateWordInput — патч для обновления слова.
+//
+// Вложенные сущности обновляются точечно по ID:
+//   - элемент с ID обновляет существующую сущность (ID сохраняется);
+//   - элемент без ID создаёт новую сущность;
+//   - Delete*IDs удаляют сущности по ID;
+//   - сущности, не упомянутые в патче, не изменяются.
 type UpdateWordInput struct {
 	ID             string // UUID слова
 	Text           *string
-	Senses         []SenseInput
-	Images         []ImageInput
-	Pronunciations []PronunciationInput
+	Language       *string
+	Notes          *string // nil — не менять, пустая строка — удалить заметки
+	NotesOnCard    *bool
+	Senses         []SenseUpsertInput
+	Images         []ImageUpsertInput
+	Pronunciations []PronunciationUpsertInput
+
+	DeleteSenseIDs         []string
+	DeleteImageIDs         []string
+	DeletePronunciationIDs []string
+}
+
+// SenseUpsertInput — создание или обновление смысла в рамках UpdateWordInput.
+type SenseUpsertInput struct {
+	ID           *string // UUID смысла; nil — создать новый
+	Definition   *string
+	PartOfSpeech *model.PartOfSpeech
+	SourceSlug   string
+	Notes        *string
+	Translations []TranslationUpsertInput
+	Examples     []ExampleUpsertInput
+	Relations    []RelationInput // Связи не изменяются: уже существующие пропускаются
+
+	DeleteTranslationIDs []string
+	DeleteExampleIDs     []string
+	DeleteRelationIDs    []string
+}
+
+// TranslationUpsertInput — создание или обновление перевода.
+type TranslationUpsertInput struct {
+	ID         *string // UUID перевода; nil — создать новый
+	Text       string
+	Language   string // Пусто — язык не меняется (для нового перевода — русский)
+	SourceSlug string
+}
+
+// ExampleUpsertInput — создание или обновление примера.
+type ExampleUpsertInput struct {
+	ID          *string // UUID примера; nil — создать новый
+	Sentence    string
+	Translation *string
+	SourceSlug  string
+}
+
+// ImageUpsertInput — создание или обновление изображения.
+type ImageUpsertInput struct {
+	ID         *string // UUID изображения; nil — создать новое
+	URL        string
+	Caption    *string
+	SourceSlug string
+	MediaID    *string // UUID сохранённого медиафайла
+}
+
+// PronunciationUpsertInput — создание или обновление произношения.
+type PronunciationUpsertInput struct {
+	ID            *string // UUID произношения; nil — создать новое
+	AudioURL      string
+	Transcription *string
+	Region        *string
+	SourceSlug    string
+	MediaID       *string // UUID сохранённого медиафайла
 }
 
 // DeleteWordInput — входные данные для удаления слова.
@@ -63,8 +142,10 @@ type AddSenseInput struct {
 	Definition   *string
 	PartOfSpeech *model.PartOfSpeech
 	SourceSlug   string
+	Notes        *string
 	Translations []TranslationInput
 	Examples     []ExampleInput
+	Relations    []RelationInput
 }
 
 // AddExamplesInput — входные данные для добавления примеров к смыслу.
@@ -79,6 +160,12 @@ type AddTranslationsInput struct {
 	Translations []TranslationInput
 }
 
+// AddRelationsInput — входные данные для добавления связей к смыслу.
+type AddRelationsInput struct {
+	SenseID   string // UUID смысла
+	Relations []RelationInput
+}
+
 // AddImagesInput — входные данные для добавления изображений к записи.
 type AddImagesInput struct {
 	EntryID string // UUID записи словаря
@@ -106,6 +193,11 @@ type DeleteTranslationInput struct {
 	ID string // UUID перевода
 }
 
+// DeleteRelationInput — входные данные для удаления связи.
+type DeleteRelationInput struct {
+	ID string // UUID связи
+}
+
 // DeleteImageInput — входные данные для удаления изображения.
 type DeleteImageInput struct {
 	ID string // UUID изображения
@@ -115,3 +207,23 @@ type DeleteImageInput struct {
 type DeletePronunciationInput struct {
 	ID string // UUID произношения
 }
+
+// MergeWordsInput — входные данные для слияния записей словаря.
+type MergeWordsInput struct {
+	TargetID  string   // UUID записи, в которую выполняется слияние
+	SourceIDs []string // UUID записей, которые вливаются в целевую и удаляются
+}
+
+// BulkSelectionInput — записи для массовой операции: явный список ID или фильтр.
+// Должно быть задано ровно одно из полей. Пагинация и сортировка фильтра игнорируются.
+type BulkSelectionInput struct {
+	IDs    []string          // UUID записей
+	Filter *DictionaryFilter // Все активные записи, подходящие под фильтр
+}
+
+// BulkTagInput — метки для массовой операции bulkTag.
+// Метка, указанная в обоих списках, снимается.
+type BulkTagInput struct {
+	Add    []string // Метки, которые нужно добавить
+	Remove []string // Метки, которые нужно снять
+}
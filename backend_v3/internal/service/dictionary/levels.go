@@ -0,0 +1,46 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// annotateEntry проставляет записи частотный ранг и оценку уровня CEFR
+// по справочнику word_levels. Слова, которых нет в справочнике, остаются без оценки.
+// Справочник составлен для английского, слова других языков не оцениваются.
+func (s *Service) annotateEntry(ctx context.Context, entry *model.DictionaryEntry) error {
+	if entry.Language != textnorm.DefaultEntryLanguage {
+		entry.FrequencyRank = nil
+		entry.CefrLevel = nil
+		return nil
+	}
+
+	level, err := s.repos.WordLevels.Lookup(ctx, entry.TextNormalized)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			entry.FrequencyRank = nil
+			entry.CefrLevel = nil
+			return nil
+		}
+		return fmt.Errorf("lookup word level: %w", err)
+	}
+
+	entry.FrequencyRank = level.FrequencyRank
+	entry.CefrLevel = &level.CefrLevel
+	return nil
+}
+
+// fillSenseLevels проставляет смыслам записи без уровня CEFR оценку самой записи.
+func (s *Service) fillSenseLevels(ctx context.Context, entry *model.DictionaryEntry) error {
+	if entry.CefrLevel == nil {
+		return nil
+	}
+	if _, err := s.repos.Senses.FillCefrLevel(ctx, entry.ID, *entry.CefrLevel); err != nil {
+		return fmt.Errorf("fill sense levels: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,597 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// ============================================================================
+// PUBLIC API
+// ============================================================================
+
+// DuplicateCandidate — пара записей, которые вероятно являются дубликатами.
+type DuplicateCandidate struct {
+	Entry      model.DictionaryEntry
+	Duplicate  model.DictionaryEntry
+	Similarity float64
+}
+
+// DuplicateCandidates возвращает пары записей с похожим нормализованным текстом
+// (триграммная похожесть не ниже minSimilarity) как кандидатов для MergeWords.
+func (s *Service) DuplicateCandidates(ctx context.Context, minSimilarity float64, limit int) ([]DuplicateCandidate, error) {
+	if minSimilarity <= 0 || minSimilarity > 1 {
+		return nil, types.NewValidationError("minSimilarity", "must be in range (0, 1]")
+	}
+	if limit < 0 {
+		return nil, types.NewValidationError("limit", "cannot be negative")
+	}
+
+	pairs, err := s.repos.Dictionary.FindDuplicatePairs(ctx, minSimilarity, limit)
+	if err != nil {
+		return nil, wrapServiceError(err, "find duplicate pairs")
+	}
+	if len(pairs) == 0 {
+		return []DuplicateCandidate{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(pairs)*2)
+	for _, p := range pairs {
+		ids = append(ids, p.EntryID, p.DuplicateID)
+	}
+	entries, err := s.repos.Dictionary.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, wrapServiceError(err, "list entries")
+	}
+	entriesByID := make(map[uuid.UUID]model.DictionaryEntry, len(entries))
+	for _, e := range entries {
+		entriesByID[e.ID] = e
+	}
+
+	// Сохраняем порядок по похожести из репозитория
+	candidates := make([]DuplicateCandidate, 0, len(pairs))
+	for _, p := range pairs {
+		entry, ok := entriesByID[p.EntryID]
+		if !ok {
+			continue
+		}
+		duplicate, ok := entriesByID[p.DuplicateID]
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, DuplicateCandidate{
+			Entry:      entry,
+			Duplicate:  duplicate,
+			Similarity: p.Similarity,
+		})
+	}
+
+	return candidates, nil
+}
+
+// MergeWords вливает записи SourceIDs в запись TargetID атомарно.
+// Смыслы, изображения и произношения переносятся в целевую запись,
+// идентичный контент не дублируется. История повторений карточек объединяется,
+// у итоговой карточки остается наиболее продвинутое SRS-состояние.
+// Исходные записи удаляются окончательно.
+func (s *Service) MergeWords(ctx context.Context, input MergeWordsInput) (*model.DictionaryEntry, error) {
+	if err := validateMergeWordsInput(input); err != nil {
+		return nil, err
+	}
+
+	targetID, err := parseID("targetId", input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	sourceIDs, err := parseIDs("sourceIds", input.SourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.mergeWordsTx(ctx, targetID, sourceIDs)
+	if err != nil {
+		return nil, wrapServiceError(err, "merge words")
+	}
+
+	return entry, nil
+}
+
+// ============================================================================
+// TRANSACTION LOGIC
+// ============================================================================
+
+// mergeStats — счётчики перенесённого контента для аудита.
+type mergeStats struct {
+	sensesMoved         int
+	sensesMerged        int
+	translationsMoved   int
+	examplesMoved       int
+	relationsMoved      int
+	imagesMoved         int
+	pronunciationsMoved int
+	reviewLogsMoved     int64
+}
+
+// mergeWordsTx выполняет слияние записей внутри транзакции.
+func (s *Service) mergeWordsTx(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID) (*model.DictionaryEntry, error) {
+	var target *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Все шаги слияния и аудит идут в одной транзакции: слияние либо
+		// выполняется целиком, либо не оставляет следов
+		s := s.WithTx(q)
+
+		var err error
+		target, err = s.repos.Dictionary.GetByID(ctx, targetID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get target entry: %w", err)
+		}
+
+		sources, err := s.repos.Dictionary.ListByIDs(ctx, sourceIDs)
+		if err != nil {
+			return fmt.Errorf("list source entries: %w", err)
+		}
+		if len(sources) != len(sourceIDs) {
+			return types.ErrNotFound
+		}
+		for _, source := range sources {
+			if source.Language != target.Language {
+				return types.NewValidationError("sourceIds", "cannot merge words of different languages")
+			}
+		}
+
+		var stats mergeStats
+		if err := s.mergeSenses(ctx, targetID, sourceIDs, &stats); err != nil {
+			return err
+		}
+		if err := s.mergeImages(ctx, targetID, sourceIDs, &stats); err != nil {
+			return err
+		}
+		if err := s.mergePronunciations(ctx, targetID, sourceIDs, &stats); err != nil {
+			return err
+		}
+		cardID, err := s.mergeCards(ctx, targetID, sourceIDs, &stats)
+		if err != nil {
+			return err
+		}
+
+		// Заметки исходных слов дописываются к заметкам целевого
+		oldNotes := target.Notes
+		if notes := mergeNotes(target.Notes, sources); !equalStringPtr(notes, target.Notes) {
+			next := *target
+			next.Notes = notes
+			target, err = s.repos.Dictionary.Update(ctx, targetID, &next)
+			if err != nil {
+				return fmt.Errorf("update target notes: %w", err)
+			}
+		}
+
+		// Удаляем исходные записи. Дублирующийся контент, оставшийся на них,
+		// и пустые карточки удаляются каскадно.
+		for i := range sources {
+			source := &sources[i]
+			if err := s.repos.Dictionary.Delete(ctx, source.ID); err != nil {
+				if database.IsNotFoundError(err) {
+					return types.ErrNotFound
+				}
+				return fmt.Errorf("delete source entry: %w", err)
+			}
+
+			changes := buildDeleteChanges(source)
+			changes[types.AuditFieldAction] = types.AuditActionMerged
+			changes[types.AuditFieldMergedInto] = targetID.String()
+			if err := s.createAuditLog(ctx, source.ID, model.ActionDelete, changes); err != nil {
+				return fmt.Errorf("create audit log: %w", err)
+			}
+		}
+
+		mergedFrom := make([]string, 0, len(sources))
+		for _, source := range sources {
+			mergedFrom = append(mergedFrom, source.Text)
+		}
+		changes := model.JSON{
+			types.AuditFieldAction:              types.AuditActionMerged,
+			types.AuditFieldMergedFrom:          mergedFrom,
+			types.AuditFieldSensesMoved:         stats.sensesMoved,
+			types.AuditFieldSensesMerged:        stats.sensesMerged,
+			types.AuditFieldTranslationsMoved:   stats.translationsMoved,
+			types.AuditFieldExamplesMoved:       stats.examplesMoved,
+			types.AuditFieldRelationsMoved:      stats.relationsMoved,
+			types.AuditFieldImagesMoved:         stats.imagesMoved,
+			types.AuditFieldPronunciationsMoved: stats.pronunciationsMoved,
+			types.AuditFieldReviewLogsMoved:     stats.reviewLogsMoved,
+		}
+		if cardID != uuid.Nil {
+			changes[types.AuditFieldCardID] = cardID.String()
+		}
+		if !equalStringPtr(oldNotes, target.Notes) {
+			changes[types.AuditFieldNotes] = map[string]any{
+				types.AuditFieldOld: oldNotes,
+				types.AuditFieldNew: target.Notes,
+			}
+		}
+		if err := s.createAuditLog(ctx, targetID, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// mergeNotes объединяет заметки целевой записи с заметками исходных.
+// Совпадающие заметки не повторяются, части разделяются пустой строкой.
+func mergeNotes(target *string, sources []model.DictionaryEntry) *string {
+	var parts []string
+	seen := make(map[string]bool)
+	add := func(notes *string) {
+		if notes == nil || *notes == "" || seen[*notes] {
+			return
+		}
+		seen[*notes] = true
+		parts = append(parts, *notes)
+	}
+
+	add(target)
+	for i := range sources {
+		add(sources[i].Notes)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	merged := strings.Join(parts, "\n\n")
+	return &merged
+}
+
+// mergeSenses переносит смыслы исходных записей в целевую.
+// Смысл с той же частью речи и определением не дублируется: вместо этого
+// в существующий смысл переносятся недостающие переводы, примеры и связи.
+func (s *Service) mergeSenses(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, stats *mergeStats) error {
+	entryIDs := append([]uuid.UUID{targetID}, sourceIDs...)
+	senses, err := s.repos.Senses.ListByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return fmt.Errorf("list senses: %w", err)
+	}
+	if len(senses) == 0 {
+		return nil
+	}
+
+	senseIDs := make([]uuid.UUID, 0, len(senses))
+	for _, sense := range senses {
+		senseIDs = append(senseIDs, sense.ID)
+	}
+	translations, err := s.repos.Translations.ListBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return fmt.Errorf("list translations: %w", err)
+	}
+	examples, err := s.repos.Examples.ListBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return fmt.Errorf("list examples: %w", err)
+	}
+	relations, err := s.repos.Relations.ListBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return fmt.Errorf("list relations: %w", err)
+	}
+
+	translationsBySense := make(map[uuid.UUID][]model.Translation)
+	for _, tr := range translations {
+		translationsBySense[tr.SenseID] = append(translationsBySense[tr.SenseID], tr)
+	}
+	examplesBySense := make(map[uuid.UUID][]model.Example)
+	for _, ex := range examples {
+		examplesBySense[ex.SenseID] = append(examplesBySense[ex.SenseID], ex)
+	}
+	relationsBySense := make(map[uuid.UUID][]model.SenseRelation)
+	for _, rel := range relations {
+		relationsBySense[rel.SenseID] = append(relationsBySense[rel.SenseID], rel)
+	}
+
+	// Индексы контента целевой записи: ключ смысла -> ID смысла,
+	// ID смысла -> ключи его переводов, примеров и связей
+	targetSenses := make(map[string]uuid.UUID)
+	translationKeys := make(map[uuid.UUID]map[string]struct{})
+	exampleKeys := make(map[uuid.UUID]map[string]struct{})
+	relationKeys := make(map[uuid.UUID]map[string]struct{})
+	register := func(senseID uuid.UUID, key string) {
+		targetSenses[key] = senseID
+		translationKeys[senseID] = make(map[string]struct{})
+		for _, tr := range translationsBySense[senseID] {
+			translationKeys[senseID][translationKey(tr)] = struct{}{}
+		}
+		exampleKeys[senseID] = make(map[string]struct{})
+		for _, ex := range examplesBySense[senseID] {
+			exampleKeys[senseID][exampleKey(ex)] = struct{}{}
+		}
+		relationKeys[senseID] = make(map[string]struct{})
+		for _, rel := range relationsBySense[senseID] {
+			relationKeys[senseID][relationKey(rel)] = struct{}{}
+		}
+	}
+	for _, sense := range senses {
+		if sense.EntryID == targetID {
+			if _, exists := targetSenses[senseKey(sense)]; !exists {
+				register(sense.ID, senseKey(sense))
+			}
+		}
+	}
+
+	var sensesToMove []uuid.UUID
+	for _, sense := range senses {
+		if sense.EntryID == targetID {
+			continue
+		}
+
+		key := senseKey(sense)
+		existingID, exists := targetSenses[key]
+		if !exists {
+			// Новый смысл переносится целиком вместе с переводами, примерами и связями
+			sensesToMove = append(sensesToMove, sense.ID)
+			register(sense.ID, key)
+			continue
+		}
+
+		// Совпадающий смысл: переносим только недостающие переводы, примеры и связи
+		var translationsToMove []uuid.UUID
+		for _, tr := range translationsBySense[sense.ID] {
+			k := translationKey(tr)
+			if _, dup := translationKeys[existingID][k]; dup {
+				continue
+			}
+			translationKeys[existingID][k] = struct{}{}
+			translationsToMove = append(translationsToMove, tr.ID)
+		}
+		var examplesToMove []uuid.UUID
+		for _, ex := range examplesBySense[sense.ID] {
+			k := exampleKey(ex)
+			if _, dup := exampleKeys[existingID][k]; dup {
+				continue
+			}
+			exampleKeys[existingID][k] = struct{}{}
+			examplesToMove = append(examplesToMove, ex.ID)
+		}
+		var relationsToMove []uuid.UUID
+		for _, rel := range relationsBySense[sense.ID] {
+			k := relationKey(rel)
+			if _, dup := relationKeys[existingID][k]; dup {
+				continue
+			}
+			relationKeys[existingID][k] = struct{}{}
+			relationsToMove = append(relationsToMove, rel.ID)
+		}
+
+		if _, err := s.repos.Translations.MoveToSense(ctx, translationsToMove, existingID); err != nil {
+			return fmt.Errorf("move translations: %w", err)
+		}
+		if _, err := s.repos.Examples.MoveToSense(ctx, examplesToMove, existingID); err != nil {
+			return fmt.Errorf("move examples: %w", err)
+		}
+		if _, err := s.repos.Relations.MoveToSense(ctx, relationsToMove, existingID); err != nil {
+			return fmt.Errorf("move relations: %w", err)
+		}
+		stats.sensesMerged++
+		stats.translationsMoved += len(translationsToMove)
+		stats.examplesMoved += len(examplesToMove)
+		stats.relationsMoved += len(relationsToMove)
+	}
+
+	if _, err := s.repos.Senses.MoveToEntry(ctx, sensesToMove, targetID); err != nil {
+		return fmt.Errorf("move senses: %w", err)
+	}
+	stats.sensesMoved = len(sensesToMove)
+
+	return nil
+}
+
+// mergeImages переносит изображения исходных записей, пропуская совпадающие по URL.
+func (s *Service) mergeImages(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, stats *mergeStats) error {
+	images, err := s.repos.Images.ListByEntryIDs(ctx, append([]uuid.UUID{targetID}, sourceIDs...))
+	if err != nil {
+		return fmt.Errorf("list images: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	for _, img := range images {
+		if img.EntryID == targetID {
+			seen[strings.TrimSpace(img.URL)] = struct{}{}
+		}
+	}
+
+	var toMove []uuid.UUID
+	for _, img := range images {
+		if img.EntryID == targetID {
+			continue
+		}
+		key := strings.TrimSpace(img.URL)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		toMove = append(toMove, img.ID)
+	}
+
+	if _, err := s.repos.Images.MoveToEntry(ctx, toMove, targetID); err != nil {
+		return fmt.Errorf("move images: %w", err)
+	}
+	stats.imagesMoved = len(toMove)
+	return nil
+}
+
+// mergePronunciations переносит произношения исходных записей,
+// пропуская совпадающие по аудио и региону.
+func (s *Service) mergePronunciations(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, stats *mergeStats) error {
+	pronunciations, err := s.repos.Pronunciations.ListByEntryIDs(ctx, append([]uuid.UUID{targetID}, sourceIDs...))
+	if err != nil {
+		return fmt.Errorf("list pronunciations: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	for _, p := range pronunciations {
+		if p.EntryID == targetID {
+			seen[pronunciationKey(p)] = struct{}{}
+		}
+	}
+
+	var toMove []uuid.UUID
+	for _, p := range pronunciations {
+		if p.EntryID == targetID {
+			continue
+		}
+		key := pronunciationKey(p)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		toMove = append(toMove, p.ID)
+	}
+
+	if _, err := s.repos.Pronunciations.MoveToEntry(ctx, toMove, targetID); err != nil {
+		return fmt.Errorf("move pronunciations: %w", err)
+	}
+	stats.pronunciationsMoved = len(toMove)
+	return nil
+}
+
+// mergeCards объединяет карточки: история повторений и подсказки всех карточек
+// переносятся на карточку целевой записи, а ей присваивается наиболее продвинутое
+// SRS-состояние. Если у целевой записи нет карточки, ею становится лучшая из исходных.
+// Возвращает ID итоговой карточки или uuid.Nil, если карточек нет.
+func (s *Service) mergeCards(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, stats *mergeStats) (uuid.UUID, error) {
+	cards, err := s.repos.Cards.ListByEntryIDs(ctx, append([]uuid.UUID{targetID}, sourceIDs...))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("list cards: %w", err)
+	}
+	if len(cards) == 0 {
+		return uuid.Nil, nil
+	}
+
+	var targetCard, strongest *model.Card
+	for i := range cards {
+		card := &cards[i]
+		if card.EntryID == targetID {
+			targetCard = card
+		}
+		if strongest == nil || strongerCard(card, strongest) {
+			strongest = card
+		}
+	}
+
+	if targetCard == nil {
+		// Лучшая исходная карточка переходит к целевой записи
+		targetCard, err = s.repos.Cards.MoveToEntry(ctx, strongest.ID, targetID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("move card: %w", err)
+		}
+		strongest = targetCard
+	}
+
+	otherCardIDs := make([]uuid.UUID, 0, len(cards))
+	for _, card := range cards {
+		if card.ID != targetCard.ID {
+			otherCardIDs = append(otherCardIDs, card.ID)
+		}
+	}
+
+	moved, err := s.repos.ReviewLogs.MoveToCard(ctx, otherCardIDs, targetCard.ID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("move review logs: %w", err)
+	}
+	stats.reviewLogsMoved = moved
+
+	if _, err := s.repos.Cards.MoveHints(ctx, otherCardIDs, targetCard.ID); err != nil {
+		return uuid.Nil, fmt.Errorf("move hints: %w", err)
+	}
+
+	if strongest.ID != targetCard.ID {
+		if err := s.repos.Cards.UpdateSRSFields(
+			ctx, targetCard.ID,
+			strongest.Status, strongest.NextReviewAt, strongest.IntervalDays, strongest.EaseFactor,
+		); err != nil {
+			return uuid.Nil, fmt.Errorf("update card srs state: %w", err)
+		}
+
+		changes := diffCard(targetCard, &model.Card{
+			ID:           targetCard.ID,
+			EntryID:      targetCard.EntryID,
+			Status:       strongest.Status,
+			NextReviewAt: strongest.NextReviewAt,
+			IntervalDays: strongest.IntervalDays,
+			EaseFactor:   strongest.EaseFactor,
+		})
+		changes[types.AuditFieldAction] = types.AuditActionMerged
+		if err := s.createAuditLogForEntity(ctx, targetID, model.EntityCard, targetCard.ID, model.ActionUpdate, changes); err != nil {
+			return uuid.Nil, fmt.Errorf("create card audit log: %w", err)
+		}
+	}
+
+	return targetCard.ID, nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// learningStatusRank — порядок статусов по степени изученности.
+var learningStatusRank = map[model.LearningStatus]int{
+	model.StatusNew:      0,
+	model.StatusLearning: 1,
+	model.StatusReview:   2,
+	model.StatusMastered: 3,
+}
+
+// strongerCard сообщает, что SRS-состояние a продвинутее, чем у b:
+// сравниваются статус, затем интервал, затем ease factor.
+func strongerCard(a, b *model.Card) bool {
+	if ra, rb := learningStatusRank[a.Status], learningStatusRank[b.Status]; ra != rb {
+		return ra > rb
+	}
+	if a.IntervalDays != b.IntervalDays {
+		return a.IntervalDays > b.IntervalDays
+	}
+	return a.EaseFactor > b.EaseFactor
+}
+
+// senseKey — ключ идентичности смысла: часть речи и нормализованное определение.
+func senseKey(sense model.Sense) string {
+	var pos, def string
+	if sense.PartOfSpeech != nil {
+		pos = string(*sense.PartOfSpeech)
+	}
+	if sense.Definition != nil {
+		def = normalizeText(*sense.Definition)
+	}
+	return pos + "|" + def
+}
+
+func translationKey(tr model.Translation) string {
+	return normalizeTranslationText(tr.Text)
+}
+
+func exampleKey(ex model.Example) string {
+	return normalizeText(ex.Sentence)
+}
+
+// relationKey совпадает с уникальным ключом sense_relations внутри смысла.
+func relationKey(rel model.SenseRelation) string {
+	return string(rel.Type) + "|" + rel.TargetTextNormalized
+}
+
+func pronunciationKey(p model.Pronunciation) string {
+	var region string
+	if p.Region != nil {
+		region = *p.Region
+	}
+	return strings.TrimSpace(p.AudioURL) + "|" + region
+}
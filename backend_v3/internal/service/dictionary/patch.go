@@ -0,0 +1,595 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// ============================================================================
+// PATCH AUDIT SUMMARY
+// ============================================================================
+
+// patchStats — ID сущностей одного типа, затронутых патчем.
+type patchStats struct {
+	created []string
+	updated []string
+	deleted []string
+}
+
+// entryPatchAudit собирает сводку изменений вложенных сущностей
+// для аудит-лога самой записи словаря.
+type entryPatchAudit struct {
+	// entryID — запись, к которой относятся аудит-логи вложенных сущностей
+	entryID uuid.UUID
+
+	senses         patchStats
+	examples       patchStats
+	images         patchStats
+	pronunciations patchStats
+	relations      patchStats
+
+	// Translation не имеет отдельного EntityType, поэтому детали
+	// изменений переводов пишутся в аудит записи.
+	translations []model.JSON
+}
+
+// addTo добавляет сводку в changes аудит-лога записи.
+func (a *entryPatchAudit) addTo(changes model.JSON) {
+	a.senses.addTo(changes, types.AuditFieldSensesCreated, types.AuditFieldSensesUpdated, types.AuditFieldSensesDeleted)
+	a.examples.addTo(changes, types.AuditFieldExamplesCreated, types.AuditFieldExamplesUpdated, types.AuditFieldExamplesDeleted)
+	a.images.addTo(changes, types.AuditFieldImagesCreated, types.AuditFieldImagesUpdated, types.AuditFieldImagesDeleted)
+	a.pronunciations.addTo(changes, types.AuditFieldPronunciationsCreated, types.AuditFieldPronunciationsUpdated, types.AuditFieldPronunciationsDeleted)
+	// Связи не обновляются, только создаются и удаляются
+	a.relations.addTo(changes, types.AuditFieldRelationsCreated, "", types.AuditFieldRelationsDeleted)
+
+	if len(a.translations) > 0 {
+		changes[types.AuditFieldTranslations] = a.translations
+	}
+}
+
+func (p *patchStats) addTo(changes model.JSON, createdField, updatedField, deletedField string) {
+	if len(p.created) > 0 {
+		changes[createdField] = p.created
+	}
+	if len(p.updated) > 0 {
+		changes[updatedField] = p.updated
+	}
+	if len(p.deleted) > 0 {
+		changes[deletedField] = p.deleted
+	}
+}
+
+// addTranslationChange записывает изменение перевода в сводку.
+func (a *entryPatchAudit) addTranslationChange(tr *model.Translation, action model.AuditAction, changes model.JSON) {
+	a.translations = append(a.translations, model.JSON{
+		types.AuditFieldTranslationID: tr.ID.String(),
+		types.AuditFieldSenseID:       tr.SenseID.String(),
+		types.AuditFieldAction:        action,
+		types.AuditFieldChanges:       changes,
+	})
+}
+
+// ============================================================================
+// SENSES
+// ============================================================================
+
+// patchSenses применяет upsert/delete операции к смыслам записи.
+// Смыслы, не упомянутые в патче, не изменяются. language — язык записи,
+// по нему нормализуются слова связей.
+func (s *Service) patchSenses(ctx context.Context, entryID uuid.UUID, language string, upserts []SenseUpsertInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(upserts) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existing, err := s.repos.Senses.ListByEntryIDs(ctx, []uuid.UUID{entryID})
+	if err != nil {
+		return fmt.Errorf("list existing senses: %w", err)
+	}
+	existingByID := make(map[uuid.UUID]*model.Sense, len(existing))
+	for i := range existing {
+		existingByID[existing[i].ID] = &existing[i]
+	}
+
+	// 1. Удаление (CASCADE удалит переводы и примеры)
+	deleteIDs, err := parseIDs("deleteSenseIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		sense, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("sense %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Senses.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete sense %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntitySense, id, model.ActionDelete, buildDeleteChanges(sense)); err != nil {
+			return fmt.Errorf("create audit log for sense: %w", err)
+		}
+		audit.senses.deleted = append(audit.senses.deleted, id.String())
+	}
+
+	// 2. Upsert
+	for i, in := range upserts {
+		senseID, isNew, err := s.upsertSense(ctx, entryID, in, existingByID, audit)
+		if err != nil {
+			return fmt.Errorf("upsert sense[%d]: %w", i, err)
+		}
+
+		if err := s.patchTranslations(ctx, senseID, isNew, in.Translations, in.DeleteTranslationIDs, audit); err != nil {
+			return fmt.Errorf("patch translations for sense[%d]: %w", i, err)
+		}
+		if err := s.patchExamples(ctx, senseID, isNew, in.Examples, in.DeleteExampleIDs, audit); err != nil {
+			return fmt.Errorf("patch examples for sense[%d]: %w", i, err)
+		}
+		if err := s.patchRelations(ctx, senseID, isNew, language, in.Relations, in.DeleteRelationIDs, audit); err != nil {
+			return fmt.Errorf("patch relations for sense[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// upsertSense создает новый смысл или обновляет существующий, если его поля изменились.
+// Возвращает ID смысла и флаг, был ли он только что создан.
+func (s *Service) upsertSense(ctx context.Context, entryID uuid.UUID, in SenseUpsertInput, existingByID map[uuid.UUID]*model.Sense, audit *entryPatchAudit) (uuid.UUID, bool, error) {
+	if in.ID == nil {
+		sense := buildSense(entryID, SenseInput{
+			Definition:   in.Definition,
+			PartOfSpeech: in.PartOfSpeech,
+			SourceSlug:   in.SourceSlug,
+			Notes:        in.Notes,
+		})
+		created, err := s.repos.Senses.Create(ctx, sense)
+		if err != nil {
+			return uuid.Nil, false, fmt.Errorf("create sense: %w", err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntitySense, created.ID, model.ActionCreate, buildCreateChanges(created)); err != nil {
+			return uuid.Nil, false, fmt.Errorf("create audit log for sense: %w", err)
+		}
+		audit.senses.created = append(audit.senses.created, created.ID.String())
+		return created.ID, true, nil
+	}
+
+	id, err := parseID("id", *in.ID)
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	old, ok := existingByID[id]
+	if !ok {
+		return uuid.Nil, false, fmt.Errorf("sense %s: %w", id, types.ErrNotFound)
+	}
+
+	next := *old
+	next.Definition = in.Definition
+	next.PartOfSpeech = in.PartOfSpeech
+	next.SourceSlug = in.SourceSlug
+	next.Notes = normalizeNotes(in.Notes)
+
+	changes := diffSense(old, &next)
+	if len(changes) == 0 {
+		return id, false, nil
+	}
+
+	if _, err := s.repos.Senses.Update(ctx, id, &next); err != nil {
+		return uuid.Nil, false, fmt.Errorf("update sense: %w", err)
+	}
+	if err := s.createAuditLogForEntity(ctx, entryID, model.EntitySense, id, model.ActionUpdate, changes); err != nil {
+		return uuid.Nil, false, fmt.Errorf("create audit log for sense: %w", err)
+	}
+	audit.senses.updated = append(audit.senses.updated, id.String())
+
+	return id, false, nil
+}
+
+// ============================================================================
+// TRANSLATIONS
+// ============================================================================
+
+// patchTranslations применяет upsert/delete операции к переводам смысла.
+// Для только что созданного смысла (isNewSense) существующие переводы не загружаются.
+func (s *Service) patchTranslations(ctx context.Context, senseID uuid.UUID, isNewSense bool, upserts []TranslationUpsertInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(upserts) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existingByID := make(map[uuid.UUID]*model.Translation)
+	if !isNewSense {
+		existing, err := s.repos.Translations.ListBySenseIDs(ctx, []uuid.UUID{senseID})
+		if err != nil {
+			return fmt.Errorf("list existing translations: %w", err)
+		}
+		for i := range existing {
+			existingByID[existing[i].ID] = &existing[i]
+		}
+	}
+
+	deleteIDs, err := parseIDs("deleteTranslationIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		tr, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("translation %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Translations.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete translation %s: %w", id, err)
+		}
+		audit.addTranslationChange(tr, model.ActionDelete, buildDeleteChanges(tr))
+	}
+
+	var toCreate []TranslationInput
+	for i, in := range upserts {
+		if in.ID == nil {
+			toCreate = append(toCreate, TranslationInput{Text: in.Text, Language: in.Language, SourceSlug: in.SourceSlug})
+			continue
+		}
+
+		id, err := parseID(fmt.Sprintf("translations[%d].id", i), *in.ID)
+		if err != nil {
+			return err
+		}
+		old, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("translation %s: %w", id, types.ErrNotFound)
+		}
+
+		next := *old
+		next.Text = in.Text
+		if in.Language != "" {
+			next.Language = in.Language
+		}
+		next.SourceSlug = in.SourceSlug
+
+		changes := diffTranslation(old, &next)
+		if len(changes) == 0 {
+			continue
+		}
+		if _, err := s.repos.Translations.Update(ctx, id, &next); err != nil {
+			return fmt.Errorf("update translation %s: %w", id, err)
+		}
+		audit.addTranslationChange(&next, model.ActionUpdate, changes)
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.repos.Translations.BatchCreate(ctx, buildTranslations(senseID, toCreate))
+		if err != nil {
+			return fmt.Errorf("batch create translations: %w", err)
+		}
+		for i := range created {
+			audit.addTranslationChange(&created[i], model.ActionCreate, buildCreateChanges(&created[i]))
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// RELATIONS
+// ============================================================================
+
+// patchRelations удаляет связи смысла и создаёт новые. Связи, которые
+// у смысла уже есть, пропускаются. Для только что созданного смысла
+// (isNewSense) существующие связи не загружаются.
+func (s *Service) patchRelations(ctx context.Context, senseID uuid.UUID, isNewSense bool, language string, relations []RelationInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(relations) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existingByID := make(map[uuid.UUID]bool)
+	if !isNewSense {
+		existing, err := s.repos.Relations.ListBySenseIDs(ctx, []uuid.UUID{senseID})
+		if err != nil {
+			return fmt.Errorf("list existing relations: %w", err)
+		}
+		for _, rel := range existing {
+			existingByID[rel.ID] = true
+		}
+	}
+
+	deleteIDs, err := parseIDs("deleteRelationIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		if !existingByID[id] {
+			return fmt.Errorf("relation %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Relations.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete relation %s: %w", id, err)
+		}
+		audit.relations.deleted = append(audit.relations.deleted, id.String())
+	}
+
+	created, err := s.createRelations(ctx, senseID, language, relations)
+	if err != nil {
+		return err
+	}
+	for _, rel := range created {
+		audit.relations.created = append(audit.relations.created, rel.ID.String())
+	}
+
+	return nil
+}
+
+// ============================================================================
+// EXAMPLES
+// ============================================================================
+
+// patchExamples применяет upsert/delete операции к примерам смысла.
+// Для только что созданного смысла (isNewSense) существующие примеры не загружаются.
+func (s *Service) patchExamples(ctx context.Context, senseID uuid.UUID, isNewSense bool, upserts []ExampleUpsertInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(upserts) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existingByID := make(map[uuid.UUID]*model.Example)
+	if !isNewSense {
+		existing, err := s.repos.Examples.ListBySenseIDs(ctx, []uuid.UUID{senseID})
+		if err != nil {
+			return fmt.Errorf("list existing examples: %w", err)
+		}
+		for i := range existing {
+			existingByID[existing[i].ID] = &existing[i]
+		}
+	}
+
+	deleteIDs, err := parseIDs("deleteExampleIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		ex, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("example %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Examples.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete example %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, audit.entryID, model.EntityExample, id, model.ActionDelete, buildDeleteChanges(ex)); err != nil {
+			return fmt.Errorf("create audit log for example: %w", err)
+		}
+		audit.examples.deleted = append(audit.examples.deleted, id.String())
+	}
+
+	var toCreate []ExampleInput
+	for i, in := range upserts {
+		if in.ID == nil {
+			toCreate = append(toCreate, ExampleInput{Sentence: in.Sentence, Translation: in.Translation, SourceSlug: in.SourceSlug})
+			continue
+		}
+
+		id, err := parseID(fmt.Sprintf("examples[%d].id", i), *in.ID)
+		if err != nil {
+			return err
+		}
+		old, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("example %s: %w", id, types.ErrNotFound)
+		}
+
+		next := *old
+		next.Sentence = in.Sentence
+		next.Translation = in.Translation
+		next.SourceSlug = in.SourceSlug
+
+		changes := diffExample(old, &next)
+		if len(changes) == 0 {
+			continue
+		}
+		if _, err := s.repos.Examples.Update(ctx, id, &next); err != nil {
+			return fmt.Errorf("update example %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, audit.entryID, model.EntityExample, id, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log for example: %w", err)
+		}
+		audit.examples.updated = append(audit.examples.updated, id.String())
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.repos.Examples.BatchCreate(ctx, buildExamples(senseID, toCreate))
+		if err != nil {
+			return fmt.Errorf("batch create examples: %w", err)
+		}
+		for i := range created {
+			if err := s.createAuditLogForEntity(ctx, audit.entryID, model.EntityExample, created[i].ID, model.ActionCreate, buildCreateChanges(&created[i])); err != nil {
+				return fmt.Errorf("create audit log for example: %w", err)
+			}
+			audit.examples.created = append(audit.examples.created, created[i].ID.String())
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// IMAGES
+// ============================================================================
+
+// patchImages применяет upsert/delete операции к изображениям записи.
+func (s *Service) patchImages(ctx context.Context, entryID uuid.UUID, upserts []ImageUpsertInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(upserts) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existing, err := s.repos.Images.ListByEntryIDs(ctx, []uuid.UUID{entryID})
+	if err != nil {
+		return fmt.Errorf("list existing images: %w", err)
+	}
+	existingByID := make(map[uuid.UUID]*model.Image, len(existing))
+	for i := range existing {
+		existingByID[existing[i].ID] = &existing[i]
+	}
+
+	deleteIDs, err := parseIDs("deleteImageIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		img, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("image %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Images.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete image %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntityImage, id, model.ActionDelete, buildDeleteChanges(img)); err != nil {
+			return fmt.Errorf("create audit log for image: %w", err)
+		}
+		audit.images.deleted = append(audit.images.deleted, id.String())
+	}
+
+	var toCreate []ImageInput
+	for i, in := range upserts {
+		if in.ID == nil {
+			toCreate = append(toCreate, ImageInput{URL: in.URL, Caption: in.Caption, SourceSlug: in.SourceSlug, MediaID: in.MediaID})
+			continue
+		}
+
+		id, err := parseID(fmt.Sprintf("images[%d].id", i), *in.ID)
+		if err != nil {
+			return err
+		}
+		old, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("image %s: %w", id, types.ErrNotFound)
+		}
+
+		next := *old
+		next.URL, next.MediaID = resolveMedia(in.URL, in.MediaID)
+		next.Caption = in.Caption
+		next.SourceSlug = in.SourceSlug
+		if err := s.ensureMediaExist(ctx, "images", []*uuid.UUID{next.MediaID}); err != nil {
+			return err
+		}
+
+		changes := diffImage(old, &next)
+		if len(changes) == 0 {
+			continue
+		}
+		if _, err := s.repos.Images.Update(ctx, id, &next); err != nil {
+			return fmt.Errorf("update image %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntityImage, id, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log for image: %w", err)
+		}
+		audit.images.updated = append(audit.images.updated, id.String())
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.repos.Images.BatchCreate(ctx, buildImages(entryID, toCreate))
+		if err != nil {
+			return fmt.Errorf("batch create images: %w", err)
+		}
+		for i := range created {
+			if err := s.createAuditLogForEntity(ctx, entryID, model.EntityImage, created[i].ID, model.ActionCreate, buildCreateChanges(&created[i])); err != nil {
+				return fmt.Errorf("create audit log for image: %w", err)
+			}
+			audit.images.created = append(audit.images.created, created[i].ID.String())
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// PRONUNCIATIONS
+// ============================================================================
+
+// patchPronunciations применяет upsert/delete операции к произношениям записи.
+func (s *Service) patchPronunciations(ctx context.Context, entryID uuid.UUID, upserts []PronunciationUpsertInput, deleteIDStrs []string, audit *entryPatchAudit) error {
+	if len(upserts) == 0 && len(deleteIDStrs) == 0 {
+		return nil
+	}
+
+	existing, err := s.repos.Pronunciations.ListByEntryIDs(ctx, []uuid.UUID{entryID})
+	if err != nil {
+		return fmt.Errorf("list existing pronunciations: %w", err)
+	}
+	existingByID := make(map[uuid.UUID]*model.Pronunciation, len(existing))
+	for i := range existing {
+		existingByID[existing[i].ID] = &existing[i]
+	}
+
+	deleteIDs, err := parseIDs("deletePronunciationIds", deleteIDStrs)
+	if err != nil {
+		return err
+	}
+	for _, id := range deleteIDs {
+		pron, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("pronunciation %s: %w", id, types.ErrNotFound)
+		}
+		if err := s.repos.Pronunciations.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete pronunciation %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntityPronunciation, id, model.ActionDelete, buildDeleteChanges(pron)); err != nil {
+			return fmt.Errorf("create audit log for pronunciation: %w", err)
+		}
+		audit.pronunciations.deleted = append(audit.pronunciations.deleted, id.String())
+	}
+
+	var toCreate []PronunciationInput
+	for i, in := range upserts {
+		if in.ID == nil {
+			toCreate = append(toCreate, PronunciationInput{
+				AudioURL:      in.AudioURL,
+				Transcription: in.Transcription,
+				Region:        in.Region,
+				SourceSlug:    in.SourceSlug,
+				MediaID:       in.MediaID,
+			})
+			continue
+		}
+
+		id, err := parseID(fmt.Sprintf("pronunciations[%d].id", i), *in.ID)
+		if err != nil {
+			return err
+		}
+		old, ok := existingByID[id]
+		if !ok {
+			return fmt.Errorf("pronunciation %s: %w", id, types.ErrNotFound)
+		}
+
+		next := *old
+		next.AudioURL, next.MediaID = resolveMedia(in.AudioURL, in.MediaID)
+		next.Transcription = in.Transcription
+		next.Region = in.Region
+		next.SourceSlug = in.SourceSlug
+		if err := s.ensureMediaExist(ctx, "pronunciations", []*uuid.UUID{next.MediaID}); err != nil {
+			return err
+		}
+
+		changes := diffPronunciation(old, &next)
+		if len(changes) == 0 {
+			continue
+		}
+		if _, err := s.repos.Pronunciations.Update(ctx, id, &next); err != nil {
+			return fmt.Errorf("update pronunciation %s: %w", id, err)
+		}
+		if err := s.createAuditLogForEntity(ctx, entryID, model.EntityPronunciation, id, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log for pronunciation: %w", err)
+		}
+		audit.pronunciations.updated = append(audit.pronunciations.updated, id.String())
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.repos.Pronunciations.BatchCreate(ctx, buildPronunciations(entryID, toCreate))
+		if err != nil {
+			return fmt.Errorf("batch create pronunciations: %w", err)
+		}
+		for i := range created {
+			if err := s.createAuditLogForEntity(ctx, entryID, model.EntityPronunciation, created[i].ID, model.ActionCreate, buildCreateChanges(&created[i])); err != nil {
+				return fmt.Errorf("create audit log for pronunciation: %w", err)
+			}
+			audit.pronunciations.created = append(audit.pronunciations.created, created[i].ID.String())
+		}
+	}
+
+	return nil
+}
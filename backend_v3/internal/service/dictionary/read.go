@@ -17,8 +17,14 @@ import (
 // Это позволяет клиентам сервиса использовать этот тип, импортируя только сервис.
 type DictionaryFilter = repo_dictionary.DictionaryFilter
 
+// WordList — псевдоним типа из репозитория.
+type WordList = repo_dictionary.WordList
+
 // Find ищет слова по фильтру.
 func (s *Service) Find(ctx context.Context, filter DictionaryFilter) ([]model.DictionaryEntry, error) {
+	if err := validateDictionaryFilter(filter); err != nil {
+		return nil, err
+	}
 	entries, err := s.repos.Dictionary.Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("find dictionary entries: %w", err)
@@ -26,6 +32,59 @@ func (s *Service) Find(ctx context.Context, filter DictionaryFilter) ([]model.Di
 	return entries, nil
 }
 
+// FindIDs возвращает ID активных слов по фильтру без пагинации, не больше limit,
+// начиная с самых старых. Используется экспортом.
+func (s *Service) FindIDs(ctx context.Context, filter DictionaryFilter, limit int) ([]uuid.UUID, error) {
+	if err := validateDictionaryFilter(filter); err != nil {
+		return nil, err
+	}
+	ids, err := s.repos.Dictionary.FindIDs(ctx, filter, limit)
+	if err != nil {
+		return nil, wrapServiceError(err, "find dictionary entry ids")
+	}
+	return ids, nil
+}
+
+// FindPage возвращает страницу слов по фильтру для курсорной пагинации.
+// first — размер страницы, after — курсор последнего элемента предыдущей страницы.
+func (s *Service) FindPage(ctx context.Context, filter DictionaryFilter, first int, after string) (*types.Page[model.DictionaryEntry], error) {
+	if first < 0 {
+		return nil, types.NewValidationError("first", "cannot be negative")
+	}
+	if err := validateDictionaryFilter(filter); err != nil {
+		return nil, err
+	}
+	cursor, err := parseCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = first
+	filter.Offset = 0
+	entries, hasNext, err := s.repos.Dictionary.FindPage(ctx, filter, cursor)
+	if err != nil {
+		return nil, wrapPageError(err, "find dictionary page")
+	}
+
+	total, err := s.repos.Dictionary.CountTotal(ctx, filter)
+	if err != nil {
+		return nil, wrapServiceError(err, "count dictionary entries")
+	}
+
+	cursors := make([]string, len(entries))
+	for i := range entries {
+		cursors[i] = repo_dictionary.EntryCursor(entries[i], filter).Encode()
+	}
+
+	return &types.Page[model.DictionaryEntry]{
+		Items:           entries,
+		Cursors:         cursors,
+		HasNextPage:     hasNext,
+		HasPreviousPage: cursor != nil,
+		TotalCount:      total,
+	}, nil
+}
+
 // GetByID получает слово по ID.
 func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*model.DictionaryEntry, error) {
 	entry, err := s.repos.Dictionary.GetByID(ctx, id)
@@ -37,3 +96,85 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*model.DictionaryE
 	}
 	return entry, nil
 }
+
+// TranslationLookupResult — результат обратного поиска по переводу.
+type TranslationLookupResult struct {
+	Entry       model.DictionaryEntry
+	Sense       model.Sense
+	Translation model.Translation
+	Similarity  float64
+}
+
+// LookupByTranslation ищет слова по тексту перевода (например, RU -> EN).
+// Результаты отсортированы по убыванию похожести перевода на запрос.
+func (s *Service) LookupByTranslation(ctx context.Context, text string, limit int) ([]TranslationLookupResult, error) {
+	query := normalizeTranslationText(text)
+	if query == "" {
+		return nil, types.NewValidationError("text", "cannot be empty")
+	}
+	if limit < 0 {
+		return nil, types.NewValidationError("limit", "cannot be negative")
+	}
+
+	matches, err := s.repos.Translations.SearchByText(ctx, query, limit)
+	if err != nil {
+		return nil, wrapServiceError(err, "search translations")
+	}
+	if len(matches) == 0 {
+		return []TranslationLookupResult{}, nil
+	}
+
+	senseIDs := make([]uuid.UUID, 0, len(matches))
+	for _, m := range matches {
+		senseIDs = append(senseIDs, m.SenseID)
+	}
+	senses, err := s.repos.Senses.ListByIDs(ctx, senseIDs)
+	if err != nil {
+		return nil, wrapServiceError(err, "list senses")
+	}
+	sensesByID := make(map[uuid.UUID]model.Sense, len(senses))
+	entryIDs := make([]uuid.UUID, 0, len(senses))
+	for _, sense := range senses {
+		sensesByID[sense.ID] = sense
+		entryIDs = append(entryIDs, sense.EntryID)
+	}
+
+	entries, err := s.repos.Dictionary.ListByIDs(ctx, entryIDs)
+	if err != nil {
+		return nil, wrapServiceError(err, "list entries")
+	}
+	entriesByID := make(map[uuid.UUID]model.DictionaryEntry, len(entries))
+	for _, entry := range entries {
+		entriesByID[entry.ID] = entry
+	}
+
+	// Сохраняем порядок по похожести из репозитория
+	results := make([]TranslationLookupResult, 0, len(matches))
+	for _, m := range matches {
+		sense, ok := sensesByID[m.SenseID]
+		if !ok {
+			continue
+		}
+		entry, ok := entriesByID[sense.EntryID]
+		if !ok {
+			continue
+		}
+		results = append(results, TranslationLookupResult{
+			Entry:       entry,
+			Sense:       sense,
+			Translation: m.Translation,
+			Similarity:  m.Similarity,
+		})
+	}
+
+	return results, nil
+}
+
+// ListWordLists возвращает непустые списки слов по алфавиту с количеством слов.
+func (s *Service) ListWordLists(ctx context.Context) ([]WordList, error) {
+	lists, err := s.repos.Dictionary.ListWordLists(ctx)
+	if err != nil {
+		return nil, wrapServiceError(err, "list word lists")
+	}
+	return lists, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository"
 	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
 )
 
 // Service реализует бизнес-логику для работы со словарем.
@@ -31,6 +32,15 @@ func NewService(repos *repository.Registry, tx *database.TxManager) (*Service, e
 	}, nil
 }
 
+// WithTx возвращает сервис, работающий в открытой транзакции q:
+// его операции становятся частью этой транзакции и откатываются вместе с ней.
+func (s *Service) WithTx(q database.Querier) *Service {
+	return &Service{
+		repos: repository.NewRegistry(q),
+		tx:    s.tx.Bind(q),
+	}
+}
+
 // CreateWord создает слово и все связанные сущности атомарно.
 // Метод выполняет валидацию входных данных, проверку на дубликаты,
 // создание основной записи и всех связанных сущностей (смыслы, переводы,
@@ -41,7 +51,8 @@ func (s *Service) CreateWord(ctx context.Context, input CreateWordInput) (*model
 	}
 
 	textRaw := strings.TrimSpace(input.Text)
-	textNorm := normalizeText(textRaw)
+	input.Language = languageOrDefault(input.Language, textnorm.DefaultEntryLanguage)
+	textNorm := normalizeWord(input.Language, textRaw)
 
 	entry, err := s.createWordTx(ctx, input, textRaw, textNorm)
 	if err != nil {
@@ -51,9 +62,9 @@ func (s *Service) CreateWord(ctx context.Context, input CreateWordInput) (*model
 	return entry, nil
 }
 
-// UpdateWord обновляет слово и все связанные сущности атомарно.
+// UpdateWord обновляет слово и связанные сущности атомарно.
 // Метод выполняет валидацию входных данных, проверку существования записи,
-// обновление основной записи и пересоздание всех связанных сущностей.
+// обновление основной записи и точечный патч вложенных сущностей по ID.
 func (s *Service) UpdateWord(ctx context.Context, input UpdateWordInput) (*model.DictionaryEntry, error) {
 	if err := validateUpdateWordInput(input); err != nil {
 		return nil, err
@@ -72,9 +83,9 @@ func (s *Service) UpdateWord(ctx context.Context, input UpdateWordInput) (*model
 	return entry, nil
 }
 
-// DeleteWord удаляет слово и все связанные сущности атомарно.
-// Метод выполняет валидацию ID, проверку существования записи,
-// удаление записи и создание аудит-лога.
+// DeleteWord помещает слово в корзину (мягкое удаление).
+// Связанные сущности, карточка и история повторений сохраняются и
+// восстанавливаются вместе со словом через RestoreWord.
 func (s *Service) DeleteWord(ctx context.Context, input DeleteWordInput) error {
 	entryID, err := parseEntryID(input.ID)
 	if err != nil {
@@ -109,163 +120,216 @@ func (s *Service) AddSense(ctx context.Context, input AddSenseInput) (*model.Sen
 }
 
 // AddExamples добавляет новые примеры к существующему смыслу без удаления существующих.
-func (s *Service) AddExamples(ctx context.Context, input AddExamplesInput) error {
+// Возвращает смысл, к которому добавлены примеры.
+func (s *Service) AddExamples(ctx context.Context, input AddExamplesInput) (*model.Sense, error) {
 	if err := validateAddExamplesInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	senseID, err := parseEntryID(input.SenseID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.addExamplesTx(ctx, input, senseID); err != nil {
-		return wrapServiceError(err, "add examples")
+	sense, err := s.addExamplesTx(ctx, input, senseID)
+	if err != nil {
+		return nil, wrapServiceError(err, "add examples")
 	}
 
-	return nil
+	return sense, nil
 }
 
 // AddTranslations добавляет новые переводы к существующему смыслу без удаления существующих.
-func (s *Service) AddTranslations(ctx context.Context, input AddTranslationsInput) error {
+// Возвращает смысл, к которому добавлены переводы.
+func (s *Service) AddTranslations(ctx context.Context, input AddTranslationsInput) (*model.Sense, error) {
 	if err := validateAddTranslationsInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	senseID, err := parseEntryID(input.SenseID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.addTranslationsTx(ctx, input, senseID); err != nil {
-		return wrapServiceError(err, "add translations")
+	sense, err := s.addTranslationsTx(ctx, input, senseID)
+	if err != nil {
+		return nil, wrapServiceError(err, "add translations")
 	}
 
-	return nil
+	return sense, nil
+}
+
+// AddRelations добавляет связи смысла со словами (синонимы, антонимы...).
+// Уже существующие связи не дублируются. Возвращает смысл.
+func (s *Service) AddRelations(ctx context.Context, input AddRelationsInput) (*model.Sense, error) {
+	if err := validateAddRelationsInput(input); err != nil {
+		return nil, err
+	}
+
+	senseID, err := parseEntryID(input.SenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	sense, err := s.addRelationsTx(ctx, input, senseID)
+	if err != nil {
+		return nil, wrapServiceError(err, "add relations")
+	}
+
+	return sense, nil
 }
 
 // AddImages добавляет новые изображения к записи словаря без удаления существующих.
-func (s *Service) AddImages(ctx context.Context, input AddImagesInput) error {
+// Возвращает запись словаря.
+func (s *Service) AddImages(ctx context.Context, input AddImagesInput) (*model.DictionaryEntry, error) {
 	if err := validateAddImagesInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	entryID, err := parseEntryID(input.EntryID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.addImagesTx(ctx, input, entryID); err != nil {
-		return wrapServiceError(err, "add images")
+	entry, err := s.addImagesTx(ctx, input, entryID)
+	if err != nil {
+		return nil, wrapServiceError(err, "add images")
 	}
 
-	return nil
+	return entry, nil
 }
 
 // AddPronunciations добавляет новые произношения к записи словаря без удаления существующих.
-func (s *Service) AddPronunciations(ctx context.Context, input AddPronunciationsInput) error {
+// Возвращает запись словаря.
+func (s *Service) AddPronunciations(ctx context.Context, input AddPronunciationsInput) (*model.DictionaryEntry, error) {
 	if err := validateAddPronunciationsInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	entryID, err := parseEntryID(input.EntryID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.addPronunciationsTx(ctx, input, entryID); err != nil {
-		return wrapServiceError(err, "add pronunciations")
+	entry, err := s.addPronunciationsTx(ctx, input, entryID)
+	if err != nil {
+		return nil, wrapServiceError(err, "add pronunciations")
 	}
 
-	return nil
+	return entry, nil
 }
 
 // DeleteSense удаляет смысл и все связанные с ним переводы и примеры (CASCADE).
-func (s *Service) DeleteSense(ctx context.Context, input DeleteSenseInput) error {
+// Возвращает запись словаря, из которой удален смысл.
+func (s *Service) DeleteSense(ctx context.Context, input DeleteSenseInput) (*model.DictionaryEntry, error) {
 	if err := validateDeleteSenseInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	senseID, err := parseEntryID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.deleteSenseTx(ctx, senseID); err != nil {
-		return wrapServiceError(err, "delete sense")
+	entry, err := s.deleteSenseTx(ctx, senseID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete sense")
 	}
 
-	return nil
+	return entry, nil
 }
 
-// DeleteExample удаляет пример.
-func (s *Service) DeleteExample(ctx context.Context, input DeleteExampleInput) error {
+// DeleteExample удаляет пример. Возвращает смысл, к которому относился пример.
+func (s *Service) DeleteExample(ctx context.Context, input DeleteExampleInput) (*model.Sense, error) {
 	if err := validateDeleteExampleInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	exampleID, err := parseEntryID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.deleteExampleTx(ctx, exampleID); err != nil {
-		return wrapServiceError(err, "delete example")
+	sense, err := s.deleteExampleTx(ctx, exampleID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete example")
 	}
 
-	return nil
+	return sense, nil
 }
 
-// DeleteTranslation удаляет перевод.
-func (s *Service) DeleteTranslation(ctx context.Context, input DeleteTranslationInput) error {
+// DeleteTranslation удаляет перевод. Возвращает смысл, к которому относился перевод.
+func (s *Service) DeleteTranslation(ctx context.Context, input DeleteTranslationInput) (*model.Sense, error) {
 	if err := validateDeleteTranslationInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	translationID, err := parseEntryID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.deleteTranslationTx(ctx, translationID); err != nil {
-		return wrapServiceError(err, "delete translation")
+	sense, err := s.deleteTranslationTx(ctx, translationID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete translation")
 	}
 
-	return nil
+	return sense, nil
 }
 
-// DeleteImage удаляет изображение.
-func (s *Service) DeleteImage(ctx context.Context, input DeleteImageInput) error {
+// DeleteRelation удаляет связь. Возвращает смысл, к которому относилась связь.
+func (s *Service) DeleteRelation(ctx context.Context, input DeleteRelationInput) (*model.Sense, error) {
+	if err := validateDeleteRelationInput(input); err != nil {
+		return nil, err
+	}
+
+	relationID, err := parseEntryID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sense, err := s.deleteRelationTx(ctx, relationID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete relation")
+	}
+
+	return sense, nil
+}
+
+// DeleteImage удаляет изображение. Возвращает запись словаря.
+func (s *Service) DeleteImage(ctx context.Context, input DeleteImageInput) (*model.DictionaryEntry, error) {
 	if err := validateDeleteImageInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	imageID, err := parseEntryID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.deleteImageTx(ctx, imageID); err != nil {
-		return wrapServiceError(err, "delete image")
+	entry, err := s.deleteImageTx(ctx, imageID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete image")
 	}
 
-	return nil
+	return entry, nil
 }
 
-// DeletePronunciation удаляет произношение.
-func (s *Service) DeletePronunciation(ctx context.Context, input DeletePronunciationInput) error {
+// DeletePronunciation удаляет произношение. Возвращает запись словаря.
+func (s *Service) DeletePronunciation(ctx context.Context, input DeletePronunciationInput) (*model.DictionaryEntry, error) {
 	if err := validateDeletePronunciationInput(input); err != nil {
-		return err
+		return nil, err
 	}
 
 	pronunciationID, err := parseEntryID(input.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.deletePronunciationTx(ctx, pronunciationID); err != nil {
-		return wrapServiceError(err, "delete pronunciation")
+	entry, err := s.deletePronunciationTx(ctx, pronunciationID)
+	if err != nil {
+		return nil, wrapServiceError(err, "delete pronunciation")
 	}
 
-	return nil
+	return entry, nil
 }
@@ -0,0 +1,195 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// ListTrash возвращает слова из корзины, начиная с недавно удалённых.
+func (s *Service) ListTrash(ctx context.Context, limit, offset int) ([]model.DictionaryEntry, error) {
+	if limit < 0 {
+		return nil, types.NewValidationError("limit", "cannot be negative")
+	}
+	if offset < 0 {
+		return nil, types.NewValidationError("offset", "cannot be negative")
+	}
+
+	entries, err := s.repos.Dictionary.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, wrapServiceError(err, "list trash")
+	}
+	return entries, nil
+}
+
+// RestoreWord возвращает слово из корзины вместе со всеми связанными данными.
+// Возвращает ErrAlreadyExists, если за это время создано активное слово с тем же текстом.
+func (s *Service) RestoreWord(ctx context.Context, id string) (*model.DictionaryEntry, error) {
+	entryID, err := parseEntryID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.restoreWordTx(ctx, entryID)
+	if err != nil {
+		return nil, wrapServiceError(err, "restore word")
+	}
+
+	return entry, nil
+}
+
+// PurgeWord окончательно удаляет слово из корзины.
+// Активные слова сначала должны быть удалены через DeleteWord.
+func (s *Service) PurgeWord(ctx context.Context, id string) error {
+	entryID, err := parseEntryID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.purgeWordTx(ctx, entryID); err != nil {
+		return wrapServiceError(err, "purge word")
+	}
+
+	return nil
+}
+
+// PurgeExpired окончательно удаляет слова, пролежавшие в корзине дольше retention.
+// Возвращает количество удалённых слов.
+func (s *Service) PurgeExpired(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, types.NewValidationError("retention", "must be positive")
+	}
+
+	purged, err := s.purgeExpiredTx(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, wrapServiceError(err, "purge expired")
+	}
+
+	return purged, nil
+}
+
+// purgeExpiredTx удаляет записи, попавшие в корзину раньше cutoff, и пишет
+// одну агрегированную запись аудита со списком удалённых ID.
+func (s *Service) purgeExpiredTx(ctx context.Context, cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		s := s.WithTx(q)
+
+		ids, err := s.repos.Dictionary.PurgeDeletedBefore(ctx, cutoff)
+		if err != nil {
+			return fmt.Errorf("purge entries: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		changes := model.JSON{
+			types.AuditFieldAction:       types.AuditActionPurged,
+			types.AuditFieldEntryIDs:     formatUUIDs(ids),
+			types.AuditFieldPurgedBefore: cutoff.Format(time.RFC3339),
+		}
+		if err := s.createBulkAuditLog(ctx, uuid.New(), model.EntityEntry, model.ActionDelete, changes); err != nil {
+			return err
+		}
+
+		purged = int64(len(ids))
+		return nil
+	})
+
+	return purged, err
+}
+
+// restoreWordTx выполняет логику восстановления слова из корзины внутри транзакции.
+func (s *Service) restoreWordTx(ctx context.Context, entryID uuid.UUID) (*model.DictionaryEntry, error) {
+	var entry *model.DictionaryEntry
+
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Проверка дубликата, восстановление и аудит — в одной транзакции
+		s := s.WithTx(q)
+
+		trashed, err := s.repos.Dictionary.GetDeletedByID(ctx, entryID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get deleted entry: %w", err)
+		}
+
+		// Проверяем, что текст не занят активным словом
+		exists, err := s.repos.Dictionary.ExistsByNormalizedText(ctx, trashed.Language, trashed.TextNormalized)
+		if err != nil {
+			return fmt.Errorf("check duplicate: %w", err)
+		}
+		if exists {
+			return types.ErrAlreadyExists
+		}
+
+		entry, err = s.repos.Dictionary.Restore(ctx, entryID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			if database.IsDuplicateError(err) {
+				return types.ErrAlreadyExists
+			}
+			return fmt.Errorf("restore entry: %w", err)
+		}
+
+		changes := model.JSON{
+			types.AuditFieldAction: types.AuditActionRestored,
+			types.AuditFieldDeletedAt: map[string]any{
+				types.AuditFieldOld: formatTimePtr(trashed.DeletedAt),
+				types.AuditFieldNew: nil,
+			},
+		}
+		if err := s.createAuditLog(ctx, entryID, model.ActionUpdate, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// purgeWordTx выполняет логику окончательного удаления слова из корзины внутри транзакции.
+// CASCADE удалит связанные данные, карточку и историю повторений.
+func (s *Service) purgeWordTx(ctx context.Context, entryID uuid.UUID) error {
+	return s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Удаление и аудит — в одной транзакции
+		s := s.WithTx(q)
+
+		trashed, err := s.repos.Dictionary.GetDeletedByID(ctx, entryID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("get deleted entry: %w", err)
+		}
+
+		if err := s.repos.Dictionary.Delete(ctx, entryID); err != nil {
+			if database.IsNotFoundError(err) {
+				return types.ErrNotFound
+			}
+			return fmt.Errorf("delete entry: %w", err)
+		}
+
+		changes := buildDeleteChanges(trashed)
+		changes[types.AuditFieldAction] = types.AuditActionPurged
+		if err := s.createAuditLog(ctx, entryID, model.ActionDelete, changes); err != nil {
+			return fmt.Errorf("create audit log: %w", err)
+		}
+
+		return nil
+	})
+}
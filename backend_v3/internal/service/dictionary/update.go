@@ -15,7 +15,11 @@ import (
 func (s *Service) updateWordTx(ctx context.Context, input UpdateWordInput, entryID uuid.UUID) (*model.DictionaryEntry, error) {
 	var updatedEntry *model.DictionaryEntry
 
-	err := s.tx.RunInTx(ctx, func(ctx context.Context, _ database.Querier) error {
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Запись, патч вложенных сущностей и аудит применяются в одной
+		// транзакции: ошибка на любом шаге откатывает весь патч
+		s := s.WithTx(q)
+
 		// Получаем существующую запись
 		existingEntry, err := s.repos.Dictionary.GetByID(ctx, entryID)
 		if err != nil {
@@ -28,13 +32,20 @@ func (s *Service) updateWordTx(ctx context.Context, input UpdateWordInput, entry
 		// Определяем текст для обновления
 		textRaw := existingEntry.Text
 		textNorm := existingEntry.TextNormalized
+		language := existingEntry.Language
+		if input.Language != nil {
+			language = *input.Language
+		}
+		retext := input.Text != nil || language != existingEntry.Language
 
-		if input.Text != nil {
-			textRaw = strings.TrimSpace(*input.Text)
-			textNorm = normalizeText(textRaw)
+		if retext {
+			if input.Text != nil {
+				textRaw = strings.TrimSpace(*input.Text)
+			}
+			textNorm = normalizeWord(language, textRaw)
 
 			// Проверяем, не существует ли уже слово с таким нормализованным текстом (кроме текущего)
-			existingByText, err := s.repos.Dictionary.FindByNormalizedText(ctx, textNorm)
+			existingByText, err := s.repos.Dictionary.FindByNormalizedText(ctx, language, textNorm)
 			if err != nil && !database.IsNotFoundError(err) {
 				// TODO: нужно сделать кастомные ошибки чтобы на фронте было легче их отлавливать
 				return fmt.Errorf("check duplicate text: %w", err)
@@ -45,7 +56,22 @@ func (s *Service) updateWordTx(ctx context.Context, input UpdateWordInput, entry
 		}
 
 		// Обновляем основную запись
-		entry := buildDictionaryEntry(textRaw, textNorm)
+		entry := buildDictionaryEntry(textRaw, textNorm, language)
+		entry.FrequencyRank = existingEntry.FrequencyRank
+		entry.CefrLevel = existingEntry.CefrLevel
+		entry.Notes = existingEntry.Notes
+		if input.Notes != nil {
+			entry.Notes = normalizeNotes(input.Notes)
+		}
+		entry.NotesOnCard = existingEntry.NotesOnCard
+		if input.NotesOnCard != nil {
+			entry.NotesOnCard = *input.NotesOnCard
+		}
+		if retext {
+			if err := s.annotateEntry(ctx, entry); err != nil {
+				return err
+			}
+		}
 		updatedEntry, err = s.repos.Dictionary.Update(ctx, entryID, entry)
 		if err != nil {
 			if database.IsDuplicateError(err) {
@@ -54,64 +80,24 @@ func (s *Service) updateWordTx(ctx context.Context, input UpdateWordInput, entry
 			return fmt.Errorf("update entry: %w", err)
 		}
 
-		// Удаляем и пересоздаем связанные сущности
-		if len(input.Senses) > 0 {
-			if err := s.recreateSenses(ctx, entryID, input.Senses); err != nil {
-				return fmt.Errorf("recreate senses: %w", err)
-			}
+		// Точечно применяем изменения вложенных сущностей (ID сохраняются)
+		patchAudit := entryPatchAudit{entryID: entryID}
+		if err := s.patchSenses(ctx, entryID, updatedEntry.Language, input.Senses, input.DeleteSenseIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch senses: %w", err)
 		}
-
-		if len(input.Images) > 0 {
-			if err := s.recreateImages(ctx, entryID, input.Images); err != nil {
-				return fmt.Errorf("recreate images: %w", err)
-			}
+		if err := s.fillSenseLevels(ctx, updatedEntry); err != nil {
+			return err
 		}
-
-		if len(input.Pronunciations) > 0 {
-			if err := s.recreatePronunciations(ctx, entryID, input.Pronunciations); err != nil {
-				return fmt.Errorf("recreate pronunciations: %w", err)
-			}
+		if err := s.patchImages(ctx, entryID, input.Images, input.DeleteImageIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch images: %w", err)
 		}
-
-		// Создаем аудит-лог с детальными изменениями полей
-		changes := diffDictionaryEntry(existingEntry, updatedEntry)
-
-		// Если были изменения в связанных сущностях, получаем детальную информацию
-		if len(input.Senses) > 0 {
-			// Получаем существующие senses для сравнения
-			existingSenses, err := s.repos.Senses.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-			if err == nil {
-				// Создаем список удаленных и созданных senses
-				existingSenseMap := make(map[uuid.UUID]*model.Sense)
-				for i := range existingSenses {
-					existingSenseMap[existingSenses[i].ID] = &existingSenses[i]
-				}
-
-				// Здесь мы не можем точно сопоставить старые и новые senses,
-				// так как они пересоздаются. Записываем факт обновления.
-				changes[types.AuditFieldSensesRecreated] = true
-				changes[types.AuditFieldSensesOldCount] = len(existingSenses)
-				changes[types.AuditFieldSensesNewCount] = len(input.Senses)
-			}
+		if err := s.patchPronunciations(ctx, entryID, input.Pronunciations, input.DeletePronunciationIDs, &patchAudit); err != nil {
+			return fmt.Errorf("patch pronunciations: %w", err)
 		}
 
-		if len(input.Images) > 0 {
-			existingImages, err := s.repos.Images.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-			if err == nil {
-				changes[types.AuditFieldImagesRecreated] = true
-				changes[types.AuditFieldImagesOldCount] = len(existingImages)
-				changes[types.AuditFieldImagesNewCount] = len(input.Images)
-			}
-		}
-
-		if len(input.Pronunciations) > 0 {
-			existingPronunciations, err := s.repos.Pronunciations.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-			if err == nil {
-				changes[types.AuditFieldPronunciationsRecreated] = true
-				changes[types.AuditFieldPronunciationsOldCount] = len(existingPronunciations)
-				changes[types.AuditFieldPronunciationsNewCount] = len(input.Pronunciations)
-			}
-		}
+		// Создаем аудит-лог записи: изменения полей + сводка по вложенным сущностям
+		changes := diffDictionaryEntry(existingEntry, updatedEntry)
+		patchAudit.addTo(changes)
 
 		// Создаем аудит-лог только если были какие-либо изменения
 		if len(changes) > 0 {
@@ -129,70 +115,3 @@ func (s *Service) updateWordTx(ctx context.Context, input UpdateWordInput, entry
 
 	return updatedEntry, nil
 }
-
-// recreateSenses удаляет существующие смыслы и создает новые.
-// CASCADE удаление автоматически удалит связанные переводы и примеры.
-func (s *Service) recreateSenses(ctx context.Context, entryID uuid.UUID, senses []SenseInput) error {
-	existingSenses, err := s.repos.Senses.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-	if err != nil {
-		return fmt.Errorf("list existing senses: %w", err)
-	}
-
-	// Удаляем существующие смыслы (CASCADE удалит переводы и примеры)
-	for _, sense := range existingSenses {
-		if err := s.repos.Senses.Delete(ctx, sense.ID); err != nil {
-			return fmt.Errorf("delete sense %s: %w", sense.ID, err)
-		}
-	}
-
-	// Создаем новые смыслы
-	if err := s.createSenses(ctx, entryID, senses); err != nil {
-		return fmt.Errorf("create new senses: %w", err)
-	}
-
-	return nil
-}
-
-// recreateImages удаляет существующие изображения и создает новые.
-func (s *Service) recreateImages(ctx context.Context, entryID uuid.UUID, images []ImageInput) error {
-	existingImages, err := s.repos.Images.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-	if err != nil {
-		return fmt.Errorf("list existing images: %w", err)
-	}
-
-	// Удаляем существующие изображения
-	for _, img := range existingImages {
-		if err := s.repos.Images.Delete(ctx, img.ID); err != nil {
-			return fmt.Errorf("delete image %s: %w", img.ID, err)
-		}
-	}
-
-	// Создаем новые изображения
-	if err := s.createImages(ctx, entryID, images); err != nil {
-		return fmt.Errorf("create new images: %w", err)
-	}
-
-	return nil
-}
-
-// recreatePronunciations удаляет существующие произношения и создает новые.
-func (s *Service) recreatePronunciations(ctx context.Context, entryID uuid.UUID, pronunciations []PronunciationInput) error {
-	existingPronunciations, err := s.repos.Pronunciations.ListByEntryIDs(ctx, []uuid.UUID{entryID})
-	if err != nil {
-		return fmt.Errorf("list existing pronunciations: %w", err)
-	}
-
-	// Удаляем существующие произношения
-	for _, pron := range existingPronunciations {
-		if err := s.repos.Pronunciations.Delete(ctx, pron.ID); err != nil {
-			return fmt.Errorf("delete pronunciation %s: %w", pron.ID, err)
-		}
-	}
-
-	// Создаем новые произношения
-	if err := s.createPronunciations(ctx, entryID, pronunciations); err != nil {
-		return fmt.Errorf("create new pronunciations: %w", err)
-	}
-
-	return nil
-}
@@ -2,16 +2,30 @@ package dictionary
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/model"
 	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
 )
 
 const (
 	// maxTextLength — максимальная длина текста слова
 	maxTextLength = 500
+
+	// maxNotesLength — максимальная длина заметок слова или смысла
+	maxNotesLength = 10000
 )
 
+// ValidateCreateWordInput проверяет данные слова так же, как CreateWord,
+// не обращаясь к БД. Импорт использует её для предварительной проверки строк.
+func ValidateCreateWordInput(input CreateWordInput) error {
+	return validateCreateWordInput(input)
+}
+
 // validateCreateWordInput валидирует входные данные для создания слова.
 func validateCreateWordInput(input CreateWordInput) error {
 	textRaw := strings.TrimSpace(input.Text)
@@ -21,6 +35,12 @@ func validateCreateWordInput(input CreateWordInput) error {
 	if len(textRaw) > maxTextLength {
 		return types.NewValidationError("text", fmt.Sprintf("cannot exceed %d characters", maxTextLength))
 	}
+	if err := validateLanguage("language", input.Language); err != nil {
+		return err
+	}
+	if err := validateNotes("notes", input.Notes); err != nil {
+		return err
+	}
 
 	// Валидация senses
 	for i, sense := range input.Senses {
@@ -61,26 +81,176 @@ func validateUpdateWordInput(input UpdateWordInput) error {
 			return types.NewValidationError("text", fmt.Sprintf("cannot exceed %d characters", maxTextLength))
 		}
 	}
+	if input.Language != nil {
+		if *input.Language == "" {
+			return types.NewValidationError("language", "cannot be empty if provided")
+		}
+		if err := validateLanguage("language", *input.Language); err != nil {
+			return err
+		}
+	}
+	if err := validateNotes("notes", input.Notes); err != nil {
+		return err
+	}
 
 	// Валидация senses
+	senseIDs := make([]*string, len(input.Senses))
 	for i, sense := range input.Senses {
-		if err := validateSenseInput(sense, i); err != nil {
+		if err := validateSenseUpsertInput(sense, i); err != nil {
 			return err
 		}
+		senseIDs[i] = sense.ID
+	}
+	if err := validatePatchIDs("senses", senseIDs, "deleteSenseIds", input.DeleteSenseIDs); err != nil {
+		return err
 	}
 
 	// Валидация images
+	imageIDs := make([]*string, len(input.Images))
 	for i, img := range input.Images {
-		if err := validateImageInput(img, i); err != nil {
+		if err := validateImageInput(ImageInput{URL: img.URL, Caption: img.Caption, SourceSlug: img.SourceSlug, MediaID: img.MediaID}, i); err != nil {
 			return err
 		}
+		imageIDs[i] = img.ID
+	}
+	if err := validatePatchIDs("images", imageIDs, "deleteImageIds", input.DeleteImageIDs); err != nil {
+		return err
 	}
 
 	// Валидация pronunciations
+	pronunciationIDs := make([]*string, len(input.Pronunciations))
 	for i, pron := range input.Pronunciations {
-		if err := validatePronunciationInput(pron, i); err != nil {
+		if err := validatePronunciationInput(PronunciationInput{
+			AudioURL:      pron.AudioURL,
+			Transcription: pron.Transcription,
+			Region:        pron.Region,
+			SourceSlug:    pron.SourceSlug,
+			MediaID:       pron.MediaID,
+		}, i); err != nil {
 			return err
 		}
+		pronunciationIDs[i] = pron.ID
+	}
+	if err := validatePatchIDs("pronunciations", pronunciationIDs, "deletePronunciationIds", input.DeletePronunciationIDs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSenseUpsertInput валидирует смысл из патча вместе с вложенными операциями.
+func validateSenseUpsertInput(sense SenseUpsertInput, index int) error {
+	if sense.Definition != nil && strings.TrimSpace(*sense.Definition) == "" {
+		return types.NewValidationError(
+			fmt.Sprintf("senses[%d].definition", index),
+			"cannot be empty if provided",
+		)
+	}
+	if sense.SourceSlug == "" {
+		return types.NewValidationError(
+			fmt.Sprintf("senses[%d].sourceSlug", index),
+			"is required",
+		)
+	}
+	if err := validateNotes(fmt.Sprintf("senses[%d].notes", index), sense.Notes); err != nil {
+		return err
+	}
+
+	// Валидация translations
+	translationIDs := make([]*string, len(sense.Translations))
+	for j, tr := range sense.Translations {
+		if strings.TrimSpace(tr.Text) == "" {
+			return types.NewValidationError(
+				fmt.Sprintf("senses[%d].translations[%d].text", index, j),
+				"cannot be empty",
+			)
+		}
+		if tr.SourceSlug == "" {
+			return types.NewValidationError(
+				fmt.Sprintf("senses[%d].translations[%d].sourceSlug", index, j),
+				"is required",
+			)
+		}
+		if err := validateLanguage(fmt.Sprintf("senses[%d].translations[%d].language", index, j), tr.Language); err != nil {
+			return err
+		}
+		translationIDs[j] = tr.ID
+	}
+	if err := validatePatchIDs(
+		fmt.Sprintf("senses[%d].translations", index), translationIDs,
+		fmt.Sprintf("senses[%d].deleteTranslationIds", index), sense.DeleteTranslationIDs,
+	); err != nil {
+		return err
+	}
+
+	// Валидация examples
+	exampleIDs := make([]*string, len(sense.Examples))
+	for j, ex := range sense.Examples {
+		if strings.TrimSpace(ex.Sentence) == "" {
+			return types.NewValidationError(
+				fmt.Sprintf("senses[%d].examples[%d].sentence", index, j),
+				"cannot be empty",
+			)
+		}
+		if ex.SourceSlug == "" {
+			return types.NewValidationError(
+				fmt.Sprintf("senses[%d].examples[%d].sourceSlug", index, j),
+				"is required",
+			)
+		}
+		exampleIDs[j] = ex.ID
+	}
+	if err := validatePatchIDs(
+		fmt.Sprintf("senses[%d].examples", index), exampleIDs,
+		fmt.Sprintf("senses[%d].deleteExampleIds", index), sense.DeleteExampleIDs,
+	); err != nil {
+		return err
+	}
+
+	// Валидация relations
+	if err := validateRelationsInput(fmt.Sprintf("senses[%d].relations", index), sense.Relations); err != nil {
+		return err
+	}
+	if err := validatePatchIDs(
+		fmt.Sprintf("senses[%d].relations", index), nil,
+		fmt.Sprintf("senses[%d].deleteRelationIds", index), sense.DeleteRelationIDs,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePatchIDs проверяет ID в upsert- и delete-операциях патча:
+// формат UUID, отсутствие повторов и пересечений между upsert и delete.
+func validatePatchIDs(upsertField string, upsertIDs []*string, deleteField string, deleteIDs []string) error {
+	seen := make(map[uuid.UUID]struct{}, len(upsertIDs)+len(deleteIDs))
+
+	for i, idStr := range upsertIDs {
+		if idStr == nil {
+			continue
+		}
+		field := fmt.Sprintf("%s[%d].id", upsertField, i)
+		id, err := parseID(field, *idStr)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[id]; ok {
+			return types.NewValidationError(field, "duplicate id in patch")
+		}
+		seen[id] = struct{}{}
+	}
+
+	for i, idStr := range deleteIDs {
+		field := fmt.Sprintf("%s[%d]", deleteField, i)
+		id, err := parseID(field, idStr)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[id]; ok {
+			return types.NewValidationError(field, "id is both updated and deleted or deleted twice")
+		}
+		seen[id] = struct{}{}
 	}
 
 	return nil
@@ -100,6 +270,9 @@ func validateSenseInput(sense SenseInput, index int) error {
 			"is required",
 		)
 	}
+	if err := validateNotes(fmt.Sprintf("senses[%d].notes", index), sense.Notes); err != nil {
+		return err
+	}
 
 	// Валидация translations
 	for j, tr := range sense.Translations {
@@ -115,6 +288,9 @@ func validateSenseInput(sense SenseInput, index int) error {
 				"is required",
 			)
 		}
+		if err := validateLanguage(fmt.Sprintf("senses[%d].translations[%d].language", index, j), tr.Language); err != nil {
+			return err
+		}
 	}
 
 	// Валидация examples
@@ -133,12 +309,16 @@ func validateSenseInput(sense SenseInput, index int) error {
 		}
 	}
 
-	return nil
+	return validateRelationsInput(fmt.Sprintf("senses[%d].relations", index), sense.Relations)
 }
 
 // validateImageInput валидирует входные данные для изображения.
+// URL можно не указывать, если изображение ссылается на сохранённый медиафайл.
 func validateImageInput(img ImageInput, index int) error {
-	if strings.TrimSpace(img.URL) == "" {
+	if err := validateMediaID(fmt.Sprintf("images[%d].mediaId", index), img.MediaID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(img.URL) == "" && img.MediaID == nil {
 		return types.NewValidationError(
 			fmt.Sprintf("images[%d].url", index),
 			"cannot be empty",
@@ -154,8 +334,12 @@ func validateImageInput(img ImageInput, index int) error {
 }
 
 // validatePronunciationInput валидирует входные данные для произношения.
+// URL аудио можно не указывать, если произношение ссылается на сохранённый медиафайл.
 func validatePronunciationInput(pron PronunciationInput, index int) error {
-	if strings.TrimSpace(pron.AudioURL) == "" {
+	if err := validateMediaID(fmt.Sprintf("pronunciations[%d].mediaId", index), pron.MediaID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(pron.AudioURL) == "" && pron.MediaID == nil {
 		return types.NewValidationError(
 			fmt.Sprintf("pronunciations[%d].audioURL", index),
 			"cannot be empty",
@@ -178,6 +362,9 @@ func validateAddSenseInput(input AddSenseInput) error {
 	if input.SourceSlug == "" {
 		return types.NewValidationError("sourceSlug", "is required")
 	}
+	if err := validateNotes("notes", input.Notes); err != nil {
+		return err
+	}
 
 	// Валидация translations
 	for i, tr := range input.Translations {
@@ -193,6 +380,9 @@ func validateAddSenseInput(input AddSenseInput) error {
 				"is required",
 			)
 		}
+		if err := validateLanguage(fmt.Sprintf("translations[%d].language", i), tr.Language); err != nil {
+			return err
+		}
 	}
 
 	// Валидация examples
@@ -211,7 +401,7 @@ func validateAddSenseInput(input AddSenseInput) error {
 		}
 	}
 
-	return nil
+	return validateRelationsInput("relations", input.Relations)
 }
 
 // validateAddExamplesInput валидирует входные данные для добавления примеров.
@@ -263,11 +453,51 @@ func validateAddTranslationsInput(input AddTranslationsInput) error {
 				"is required",
 			)
 		}
+		if err := validateLanguage(fmt.Sprintf("translations[%d].language", i), tr.Language); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// validateAddRelationsInput валидирует входные данные для добавления связей.
+func validateAddRelationsInput(input AddRelationsInput) error {
+	if input.SenseID == "" {
+		return types.NewValidationError("senseID", "cannot be empty")
+	}
+	if len(input.Relations) == 0 {
+		return types.NewValidationError("relations", "cannot be empty")
+	}
+	return validateRelationsInput("relations", input.Relations)
+}
+
+// validateRelationsInput валидирует связи смысла; field — путь к списку.
+func validateRelationsInput(field string, relations []RelationInput) error {
+	if len(relations) > MaxSenseRelations {
+		return types.NewValidationError(field, fmt.Sprintf("cannot exceed %d items", MaxSenseRelations))
+	}
+	for i, rel := range relations {
+		if !rel.Type.IsValid() {
+			return types.NewValidationError(fmt.Sprintf("%s[%d].type", field, i), "is invalid")
+		}
+		text := strings.TrimSpace(rel.TargetText)
+		if text == "" {
+			return types.NewValidationError(fmt.Sprintf("%s[%d].targetText", field, i), "cannot be empty")
+		}
+		if len(text) > maxTextLength {
+			return types.NewValidationError(
+				fmt.Sprintf("%s[%d].targetText", field, i),
+				fmt.Sprintf("cannot exceed %d characters", maxTextLength),
+			)
+		}
+		if rel.SourceSlug == "" {
+			return types.NewValidationError(fmt.Sprintf("%s[%d].sourceSlug", field, i), "is required")
+		}
+	}
+	return nil
+}
+
 // validateAddImagesInput валидирует входные данные для добавления изображений.
 func validateAddImagesInput(input AddImagesInput) error {
 	if input.EntryID == "" {
@@ -328,6 +558,14 @@ func validateDeleteTranslationInput(input DeleteTranslationInput) error {
 	return nil
 }
 
+// validateDeleteRelationInput валидирует входные данные для удаления связи.
+func validateDeleteRelationInput(input DeleteRelationInput) error {
+	if input.ID == "" {
+		return types.NewValidationError("id", "cannot be empty")
+	}
+	return nil
+}
+
 // validateDeleteImageInput валидирует входные данные для удаления изображения.
 func validateDeleteImageInput(input DeleteImageInput) error {
 	if input.ID == "" {
@@ -343,3 +581,137 @@ func validateDeletePronunciationInput(input DeletePronunciationInput) error {
 	}
 	return nil
 }
+
+// validateMergeWordsInput валидирует входные данные для слияния записей.
+func validateMergeWordsInput(input MergeWordsInput) error {
+	if input.TargetID == "" {
+		return types.NewValidationError("targetId", "cannot be empty")
+	}
+	if len(input.SourceIDs) == 0 {
+		return types.NewValidationError("sourceIds", "at least one source is required")
+	}
+	if len(input.SourceIDs) > MaxMergeSources {
+		return types.NewValidationError("sourceIds", fmt.Sprintf("cannot merge more than %d words at once", MaxMergeSources))
+	}
+
+	seen := make(map[string]struct{}, len(input.SourceIDs))
+	for i, id := range input.SourceIDs {
+		if id == input.TargetID {
+			return types.NewValidationError(fmt.Sprintf("sourceIds[%d]", i), "cannot be the same as targetId")
+		}
+		if _, ok := seen[id]; ok {
+			return types.NewValidationError(fmt.Sprintf("sourceIds[%d]", i), "duplicate id")
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// validateBulkSelectionInput валидирует выбор записей для массовой операции.
+func validateBulkSelectionInput(input BulkSelectionInput) error {
+	if input.Filter != nil && len(input.IDs) > 0 {
+		return types.NewValidationError("ids", "cannot be combined with filter")
+	}
+	if input.Filter == nil && len(input.IDs) == 0 {
+		return types.NewValidationError("ids", "either ids or filter is required")
+	}
+	if len(input.IDs) > MaxBulkEntries {
+		return types.NewValidationError("ids", fmt.Sprintf("cannot process more than %d words at once", MaxBulkEntries))
+	}
+	if input.Filter != nil {
+		return validateDictionaryFilter(*input.Filter)
+	}
+	return nil
+}
+
+// validateBulkTagInput валидирует метки массовой операции bulkTag.
+func validateBulkTagInput(input BulkTagInput) error {
+	if len(input.Add) == 0 && len(input.Remove) == 0 {
+		return types.NewValidationError("add", "either add or remove is required")
+	}
+	if len(input.Add)+len(input.Remove) > MaxBulkTags {
+		return types.NewValidationError("add", fmt.Sprintf("cannot change more than %d tags at once", MaxBulkTags))
+	}
+	fields := []struct {
+		name string
+		tags []string
+	}{{"add", input.Add}, {"remove", input.Remove}}
+	for _, field := range fields {
+		for i, tag := range field.tags {
+			tag = normalizeTag(tag)
+			if tag == "" {
+				return types.NewValidationError(fmt.Sprintf("%s[%d]", field.name, i), "cannot be empty")
+			}
+			if utf8.RuneCountInString(tag) > MaxTagLength {
+				return types.NewValidationError(fmt.Sprintf("%s[%d]", field.name, i), fmt.Sprintf("cannot exceed %d characters", MaxTagLength))
+			}
+		}
+	}
+	return nil
+}
+
+// validateListName валидирует название списка; nil — убрать из списков.
+func validateListName(list *string) error {
+	if list == nil {
+		return nil
+	}
+	name := normalizeListName(list)
+	if *name == "" {
+		return types.NewValidationError("list", "cannot be empty")
+	}
+	if utf8.RuneCountInString(*name) > MaxListNameLength {
+		return types.NewValidationError("list", fmt.Sprintf("cannot exceed %d characters", MaxListNameLength))
+	}
+	return nil
+}
+
+// validateDictionaryFilter валидирует фильтры по уровню CEFR и частотному диапазону.
+func validateDictionaryFilter(filter DictionaryFilter) error {
+	if filter.Language != nil {
+		if err := validateLanguage("language", *filter.Language); err != nil {
+			return err
+		}
+	}
+	for i, level := range filter.CefrLevels {
+		if !slices.Contains(model.CefrLevels, level) {
+			return types.NewValidationError(fmt.Sprintf("cefrLevels[%d]", i), "must be one of A1, A2, B1, B2, C1, C2")
+		}
+	}
+	if filter.MinFrequencyRank != nil && *filter.MinFrequencyRank < 1 {
+		return types.NewValidationError("minFrequencyRank", "must be positive")
+	}
+	if filter.MaxFrequencyRank != nil && *filter.MaxFrequencyRank < 1 {
+		return types.NewValidationError("maxFrequencyRank", "must be positive")
+	}
+	if filter.MinFrequencyRank != nil && filter.MaxFrequencyRank != nil && *filter.MinFrequencyRank > *filter.MaxFrequencyRank {
+		return types.NewValidationError("minFrequencyRank", "cannot exceed maxFrequencyRank")
+	}
+	return nil
+}
+
+// validateLanguage проверяет код языка. Пустой код допустим и означает язык по умолчанию.
+func validateLanguage(field, code string) error {
+	if code != "" && !textnorm.IsValidLanguage(code) {
+		return types.NewValidationError(field, "must be a lowercase ISO 639 language code")
+	}
+	return nil
+}
+
+// validateNotes проверяет длину заметок. nil допустим.
+func validateNotes(field string, notes *string) error {
+	if notes != nil && len(*notes) > maxNotesLength {
+		return types.NewValidationError(field, fmt.Sprintf("cannot exceed %d characters", maxNotesLength))
+	}
+	return nil
+}
+
+// validateMediaID проверяет формат ссылки на медиафайл. nil допустим.
+func validateMediaID(field string, mediaID *string) error {
+	if mediaID == nil {
+		return nil
+	}
+	if _, err := uuid.Parse(*mediaID); err != nil {
+		return types.NewValidationError(field, "invalid UUID format")
+	}
+	return nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/database/repository/base"
+	repo_inbox "github.com/heartmarshall/my-english/internal/database/repository/inbox"
 	"github.com/heartmarshall/my-english/internal/model"
 	"github.com/heartmarshall/my-english/internal/service/dictionary"
 	"github.com/heartmarshall/my-english/internal/service/types"
@@ -22,7 +25,7 @@ type DictionaryService interface {
 	// UpdateWord обновляет существующее слово и все связанные сущности атомарно.
 	UpdateWord(ctx context.Context, input dictionary.UpdateWordInput) (*model.DictionaryEntry, error)
 
-	// DeleteWord удаляет слово и все связанные сущности атомарно.
+	// DeleteWord помещает слово в корзину.
 	DeleteWord(ctx context.Context, input dictionary.DeleteWordInput) error
 }
 
@@ -73,6 +76,62 @@ func (s *Service) AddToInbox(ctx context.Context, text string, contextStr *strin
 	return created, nil
 }
 
+// MaxBatchSize — максимальное количество заметок в AddToInboxBatch.
+const MaxBatchSize = 1000
+
+// ItemInput — новая заметка для AddToInboxBatch.
+type ItemInput struct {
+	Text    string
+	Context *string
+}
+
+// AddToInboxBatch создает заметки одной транзакцией и возвращает созданные.
+// Тексты, которые уже есть во входящих или повторяются в items
+// (без учета регистра), пропускаются.
+func (s *Service) AddToInboxBatch(ctx context.Context, items []ItemInput) ([]model.InboxItem, error) {
+	if len(items) > MaxBatchSize {
+		return nil, types.NewValidationError("items", fmt.Sprintf("at most %d items", MaxBatchSize))
+	}
+	for i, item := range items {
+		if strings.TrimSpace(item.Text) == "" {
+			return nil, types.NewValidationError(fmt.Sprintf("items[%d].text", i), "cannot be empty")
+		}
+	}
+
+	created := make([]model.InboxItem, 0, len(items))
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		repos := repository.NewRegistry(q)
+		seen := make(map[string]bool, len(items))
+		for _, in := range items {
+			text := strings.TrimSpace(in.Text)
+			key := strings.ToLower(text)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			exists, err := repos.Inbox.ExistsByText(ctx, text)
+			if err != nil {
+				return fmt.Errorf("check inbox: %w", err)
+			}
+			if exists {
+				continue
+			}
+			item, err := repos.Inbox.Create(ctx, &model.InboxItem{Text: text, Context: in.Context})
+			if err != nil {
+				return fmt.Errorf("create inbox item: %w", err)
+			}
+			created = append(created, *item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
 // Delete удаляет заметку из входящих.
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
@@ -101,6 +160,44 @@ func (s *Service) List(ctx context.Context) ([]model.InboxItem, error) {
 	return items, nil
 }
 
+// ListPage возвращает страницу входящих заметок (новые первыми) для курсорной пагинации.
+// first — размер страницы, after — курсор последнего элемента предыдущей страницы.
+func (s *Service) ListPage(ctx context.Context, first int, after string) (*types.Page[model.InboxItem], error) {
+	if first < 0 {
+		return nil, types.NewValidationError("first", "cannot be negative")
+	}
+	cursor, err := base.DecodeCursor(after)
+	if err != nil {
+		return nil, types.NewValidationError("after", "invalid cursor")
+	}
+
+	items, hasNext, err := s.repos.Inbox.FindPage(ctx, first, cursor)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidInput) {
+			return nil, types.NewValidationError("after", "cursor does not match sort order")
+		}
+		return nil, fmt.Errorf("list inbox page: %w", err)
+	}
+
+	total, err := s.repos.Inbox.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count inbox items: %w", err)
+	}
+
+	cursors := make([]string, len(items))
+	for i := range items {
+		cursors[i] = repo_inbox.ItemCursor(items[i]).Encode()
+	}
+
+	return &types.Page[model.InboxItem]{
+		Items:           items,
+		Cursors:         cursors,
+		HasNextPage:     hasNext,
+		HasPreviousPage: cursor != nil,
+		TotalCount:      total,
+	}, nil
+}
+
 // ConvertToWord превращает InboxItem в DictionaryEntry.
 // Метод проверяет существование inbox item и создает слово через DictionaryService.
 func (s *Service) ConvertToWord(ctx context.Context, inboxID uuid.UUID, input dictionary.CreateWordInput) (*model.DictionaryEntry, error) {
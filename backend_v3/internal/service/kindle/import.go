@@ -0,0 +1,208 @@
+package kindle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/kindlevocab"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// ImportInput — параметры импорта.
+type ImportInput struct {
+	Full bool // Не учитывать отметку прошлого импорта и просмотреть все обращения
+}
+
+// ImportResult — итог импорта.
+type ImportResult struct {
+	Lookups       int        // Обращений к словарю в файле
+	NewLookups    int        // Из них новее отметки прошлого импорта
+	Imported      int        // Создано заметок inbox
+	Duplicates    int        // Пропущено: слово уже есть в словаре или inbox
+	Mastered      int        // Пропущено: слово отмечено в Kindle как выученное
+	ImportedUntil *time.Time // Отметка после импорта; nil, если обращений ещё не было
+}
+
+// Import импортирует слова из содержимого vocab.db одной транзакцией.
+//
+// Обращения к одному слову объединяются в одну заметку: текст — слово
+// в том виде, в каком оно встретилось в книге, контекст — предложения
+// с названием книги, по строке на обращение. Слово пропускается, если
+// оно или его начальная форма уже есть в словаре или inbox.
+func (s *Service) Import(ctx context.Context, r io.Reader, input ImportInput) (*ImportResult, error) {
+	data, err := io.ReadAll(io.LimitReader(r, kindlevocab.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	if len(data) > kindlevocab.MaxFileSize {
+		return nil, types.NewValidationError("file", fmt.Sprintf("exceeds %d bytes", kindlevocab.MaxFileSize))
+	}
+	lookups, err := kindlevocab.Read(data)
+	if err != nil {
+		if errors.Is(err, kindlevocab.ErrInvalidFile) {
+			return nil, types.NewValidationError("file", err.Error())
+		}
+		return nil, fmt.Errorf("read vocab.db: %w", err)
+	}
+
+	var result *ImportResult
+	err = s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		imp := &importer{repos: repository.NewRegistry(q), result: &ImportResult{Lookups: len(lookups)}}
+		if err := imp.run(ctx, lookups, input); err != nil {
+			return err
+		}
+		result = imp.result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ============================================================================
+// IMPORTER
+// ============================================================================
+
+// importer хранит состояние одного импорта.
+type importer struct {
+	repos  *repository.Registry
+	result *ImportResult
+}
+
+// word — обращения к одному слову, собранные в заметку.
+type word struct {
+	text     string
+	stem     string
+	language string
+	mastered bool
+	contexts []string
+}
+
+// run создаёт заметки для обращений новее отметки и сдвигает отметку.
+func (imp *importer) run(ctx context.Context, lookups []kindlevocab.Lookup, input ImportInput) error {
+	var since time.Time
+	checkpoint, err := imp.repos.ImportCheckpoints.Get(ctx, CheckpointSource)
+	switch {
+	case err == nil:
+		imp.result.ImportedUntil = &checkpoint.ImportedUntil
+		if !input.Full {
+			since = checkpoint.ImportedUntil
+		}
+	case !database.IsNotFoundError(err):
+		return fmt.Errorf("get checkpoint: %w", err)
+	}
+
+	words, latest, n := groupLookups(lookups, since)
+	imp.result.NewLookups = n
+
+	for _, w := range words {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := imp.importWord(ctx, w); err != nil {
+			return fmt.Errorf("import %q: %w", w.text, err)
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+	checkpoint, err = imp.repos.ImportCheckpoints.Advance(ctx, CheckpointSource, latest)
+	if err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	imp.result.ImportedUntil = &checkpoint.ImportedUntil
+	return nil
+}
+
+// importWord создаёт заметку для слова, если его ещё нет в словаре и inbox.
+func (imp *importer) importWord(ctx context.Context, w *word) error {
+	if w.mastered {
+		imp.result.Mastered++
+		return nil
+	}
+
+	candidates := []string{w.text}
+	if w.stem != "" && !strings.EqualFold(w.stem, w.text) {
+		candidates = append(candidates, w.stem)
+	}
+	for _, text := range candidates {
+		exists, err := imp.repos.Dictionary.ExistsByNormalizedText(ctx, w.language, textnorm.Normalize(w.language, text))
+		if err != nil {
+			return fmt.Errorf("check dictionary: %w", err)
+		}
+		if !exists {
+			if exists, err = imp.repos.Inbox.ExistsByText(ctx, text); err != nil {
+				return fmt.Errorf("check inbox: %w", err)
+			}
+		}
+		if exists {
+			imp.result.Duplicates++
+			return nil
+		}
+	}
+
+	item := &model.InboxItem{Text: w.text}
+	if len(w.contexts) > 0 {
+		joined := strings.Join(w.contexts, "\n")
+		item.Context = &joined
+	}
+	if _, err := imp.repos.Inbox.Create(ctx, item); err != nil {
+		return fmt.Errorf("create inbox item: %w", err)
+	}
+	imp.result.Imported++
+	return nil
+}
+
+// groupLookups объединяет обращения новее since по словам в порядке первого
+// обращения. Возвращает также время последнего из них и их количество.
+func groupLookups(lookups []kindlevocab.Lookup, since time.Time) (words []*word, latest time.Time, n int) {
+	byKey := make(map[string]*word)
+	for _, l := range lookups {
+		if !l.Time.After(since) {
+			continue
+		}
+		n++
+		if l.Time.After(latest) {
+			latest = l.Time
+		}
+
+		language := l.Language
+		if !textnorm.IsValidLanguage(language) {
+			language = textnorm.DefaultEntryLanguage
+		}
+		key := language + ":" + textnorm.Normalize(language, l.Word)
+		w, ok := byKey[key]
+		if !ok {
+			w = &word{text: l.Word, stem: l.Stem, language: language, mastered: l.Mastered}
+			byKey[key] = w
+			words = append(words, w)
+		}
+		if c := lookupContext(l); c != "" && !slices.Contains(w.contexts, c) {
+			w.contexts = append(w.contexts, c)
+		}
+	}
+	return words, latest, n
+}
+
+// lookupContext возвращает строку контекста: предложение и название книги.
+func lookupContext(l kindlevocab.Lookup) string {
+	switch {
+	case l.Usage != "" && l.Book != "":
+		return l.Usage + " — " + l.Book
+	case l.Usage != "":
+		return l.Usage
+	default:
+		return l.Book
+	}
+}
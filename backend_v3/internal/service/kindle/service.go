@@ -0,0 +1,37 @@
+// Package kindle импортирует слова из Vocabulary Builder Kindle (vocab.db)
+// во входящие: каждое слово становится заметкой inbox, а предложения из
+// книги и её название — контекстом. Время последнего импортированного
+// обращения сохраняется, поэтому повторный импорт того же файла добавляет
+// только новые слова.
+package kindle
+
+import (
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+)
+
+// CheckpointSource — источник в отметках инкрементального импорта.
+const CheckpointSource = "kindle"
+
+// Service реализует импорт vocab.db.
+type Service struct {
+	repos *repository.Registry
+	tx    *database.TxManager
+}
+
+// NewService создаёт сервис импорта Kindle.
+func NewService(repos *repository.Registry, tx *database.TxManager) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("tx cannot be nil")
+	}
+
+	return &Service{
+		repos: repos,
+		tx:    tx,
+	}, nil
+}
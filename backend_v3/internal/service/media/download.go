@@ -0,0 +1,116 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// maxRedirects — сколько перенаправлений допускается при скачивании по URL.
+const maxRedirects = 10
+
+// errBlockedAddress — адрес назначения находится во внутренней сети.
+var errBlockedAddress = errors.New("address is not publicly routable")
+
+// blockedPrefixes — диапазоны, не покрытые методами netip.Addr, из которых
+// нельзя скачивать файлы: «эта сеть», CGNAT (в том числе метаданные
+// некоторых облаков), сети тестирования производительности.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// isPublicAddr сообщает, можно ли скачивать файлы с адреса addr.
+// Отклоняются loopback, частные, link-local (включая 169.254.169.254 —
+// метаданные облака), multicast и неуказанные адреса.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialControl проверяет адрес соединения уже после разрешения DNS:
+// имя, которое при проверке указывало на публичный адрес, а при
+// соединении — на внутренний (DNS rebinding), тоже будет отклонено.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parse dial address %q: %w", address, err)
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("dial %s: %w", address, errBlockedAddress)
+	}
+	return nil
+}
+
+// checkHost разрешает имя хоста и проверяет, что все его адреса публичные.
+func checkHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublicAddr(addr) {
+			return fmt.Errorf("host %s: %w", host, errBlockedAddress)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !isPublicAddr(addr) {
+			return fmt.Errorf("host %s resolves to %s: %w", host, addr, errBlockedAddress)
+		}
+	}
+	return nil
+}
+
+// checkRedirect проверяет цель каждого перенаправления так же, как исходный URL.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+	}
+	return checkHost(req.Context(), req.URL.Hostname())
+}
+
+// newDownloadClient создаёт HTTP-клиент для скачивания по URL, который
+// соединяется только с публичными адресами. Прокси из окружения
+// не используется: иначе проверялся бы адрес прокси, а не сервера.
+func newDownloadClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+	}
+}
@@ -0,0 +1,112 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/storage"
+	"github.com/heartmarshall/my-english/pkg/imageproc"
+)
+
+const (
+	// DefaultThumbnailSize — большая сторона миниатюры по умолчанию (для списков).
+	DefaultThumbnailSize = 256
+
+	// DefaultCardSize — большая сторона варианта для карточки по умолчанию.
+	DefaultCardSize = 1024
+)
+
+// ============================================================================
+// IMAGE PROCESSING
+// ============================================================================
+
+// ProcessPending обрабатывает до limit ещё не обработанных изображений:
+// запоминает размеры оригинала и создаёт миниатюру и вариант для карточки.
+// Варианты перекодируются в JPEG или PNG без метаданных (EXIF и пр.)
+// и сохраняются как обычные медиафайлы.
+//
+// Изображение, которое не удалось декодировать, помечается ошибкой и больше
+// не обрабатывается. Ошибки хранилища и БД прерывают обработку: изображение
+// останется в очереди до следующего запуска.
+// Возвращает количество обработанных изображений.
+func (s *Service) ProcessPending(ctx context.Context, limit int) (int, error) {
+	pending, err := s.repos.Media.ListUnprocessedImages(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list unprocessed images: %w", err)
+	}
+
+	processed := 0
+	for i := range pending {
+		if err := s.processImage(ctx, &pending[i]); err != nil {
+			return processed, fmt.Errorf("process media %s: %w", pending[i].ID, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// processImage обрабатывает одно изображение.
+func (s *Service) processImage(ctx context.Context, m *model.Media) error {
+	data, err := s.readObject(ctx, m)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return s.repos.Media.MarkFailed(ctx, m.ID, "file is missing from storage")
+		}
+		return err
+	}
+
+	img, err := imageproc.Decode(data)
+	if err != nil {
+		return s.repos.Media.MarkFailed(ctx, m.ID, err.Error())
+	}
+	bounds := img.Bounds()
+
+	thumbnail, err := s.saveVariant(ctx, img, s.cfg.ThumbnailSize)
+	if err != nil {
+		return fmt.Errorf("thumbnail: %w", err)
+	}
+	card, err := s.saveVariant(ctx, img, s.cfg.CardSize)
+	if err != nil {
+		return fmt.Errorf("card: %w", err)
+	}
+
+	_, err = s.repos.Media.MarkProcessed(ctx, m.ID, bounds.Dx(), bounds.Dy(), &thumbnail.ID, &card.ID)
+	return err
+}
+
+// saveVariant уменьшает изображение и сохраняет результат как медиафайл.
+// Вариант сразу помечается обработанным, чтобы не порождать варианты вариантов.
+func (s *Service) saveVariant(ctx context.Context, img image.Image, maxSide int) (*model.Media, error) {
+	res, err := imageproc.Fit(img, maxSide)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := s.save(ctx, res.Data, res.ContentType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if variant.ProcessedAt != nil {
+		return variant, nil
+	}
+	return s.repos.Media.MarkProcessed(ctx, variant.ID, res.Size.Width, res.Size.Height, nil, nil)
+}
+
+// readObject читает содержимое медиафайла из хранилища.
+func (s *Service) readObject(ctx context.Context, m *model.Media) ([]byte, error) {
+	rc, err := s.store.Get(ctx, storage.KeyForHash(m.SHA256))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read media object: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,295 @@
+// Package media реализует сохранение медиафайлов (изображений и аудио) в хранилище
+// приложения: загрузку пользователем, скачивание по URL и выдачу содержимого.
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/internal/storage"
+	ctx_pkg "github.com/heartmarshall/my-english/pkg/context"
+)
+
+const (
+	// DefaultMaxSize — максимальный размер файла по умолчанию (10 МБ).
+	DefaultMaxSize = 10 << 20
+
+	// DefaultDownloadTimeout — таймаут скачивания файла по URL по умолчанию.
+	DefaultDownloadTimeout = 15 * time.Second
+
+	// sniffLen — сколько байт смотрит http.DetectContentType.
+	sniffLen = 512
+)
+
+// errDownloadFailed — общая ошибка скачивания по URL без подробностей.
+var errDownloadFailed = types.NewValidationError("url", "download failed")
+
+// Config содержит настройки сервиса медиафайлов.
+type Config struct {
+	MaxSize         int64         // Максимальный размер файла в байтах
+	DownloadTimeout time.Duration // Таймаут скачивания по URL
+	HTTPClient      *http.Client  // Необязательно, клиент для скачивания
+	ThumbnailSize   int           // Большая сторона миниатюры, px
+	CardSize        int           // Большая сторона варианта для карточки, px
+
+	// Разрешить скачивание с адресов локальной сети (для разработки и тестов).
+	// По умолчанию ImportURL соединяется только с публичными адресами.
+	AllowPrivateNetworks bool
+}
+
+// Service реализует бизнес-логику медиафайлов.
+// Содержимое хранится в storage.Storage под ключом из SHA-256,
+// метаданные — в таблице media. Одинаковые файлы хранятся один раз.
+type Service struct {
+	repos  *repository.Registry
+	store  storage.Storage
+	cfg    Config
+	client *http.Client
+}
+
+// NewService создаёт сервис медиафайлов.
+// Возвращает ошибку, если repos или store равны nil.
+func NewService(repos *repository.Registry, store storage.Storage, cfg Config) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("storage cannot be nil")
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	if cfg.DownloadTimeout <= 0 {
+		cfg.DownloadTimeout = DefaultDownloadTimeout
+	}
+	if cfg.ThumbnailSize <= 0 {
+		cfg.ThumbnailSize = DefaultThumbnailSize
+	}
+	if cfg.CardSize <= 0 {
+		cfg.CardSize = DefaultCardSize
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		if cfg.AllowPrivateNetworks {
+			client = &http.Client{Timeout: cfg.DownloadTimeout}
+		} else {
+			client = newDownloadClient(cfg.DownloadTimeout)
+		}
+	}
+
+	return &Service{
+		repos:  repos,
+		store:  store,
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// ============================================================================
+// WRITE OPERATIONS
+// ============================================================================
+
+// Upload сохраняет загруженный файл. contentType — тип, заявленный клиентом;
+// фактический тип определяется по содержимому.
+// Если такой файл уже сохранён, возвращается существующая запись.
+func (s *Service) Upload(ctx context.Context, r io.Reader, contentType string) (*model.Media, error) {
+	data, err := s.readLimited(r, "file")
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, data, contentType, nil)
+}
+
+// ImportURL скачивает файл по URL и сохраняет его, чтобы он оставался доступен,
+// даже если исходная ссылка перестанет работать.
+// Адреса внутренней сети отклоняются (см. Config.AllowPrivateNetworks).
+// Причина неудачного скачивания только логируется: ответ не должен
+// раскрывать, какие адреса и порты доступны серверу.
+func (s *Service) ImportURL(ctx context.Context, rawURL string) (*model.Media, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, types.NewValidationError("url", "must be an absolute http(s) URL")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.DownloadTimeout)
+	defer cancel()
+
+	logger := ctx_pkg.L(ctx).With(slog.String("url", u.Redacted()))
+	if !s.cfg.AllowPrivateNetworks {
+		if err := checkHost(ctx, u.Hostname()); err != nil {
+			logger.Warn("media download rejected", slog.Any("error", err))
+			return nil, errDownloadFailed
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, types.NewValidationError("url", "invalid URL")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Warn("media download failed", slog.Any("error", err))
+		return nil, errDownloadFailed
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logger.Warn("media download failed", slog.Int("status", resp.StatusCode))
+		return nil, errDownloadFailed
+	}
+
+	data, err := s.readLimited(resp.Body, "url")
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, data, resp.Header.Get("Content-Type"), &rawURL)
+}
+
+// save определяет тип содержимого, кладёт файл в хранилище и сохраняет метаданные.
+func (s *Service) save(ctx context.Context, data []byte, declaredType string, sourceURL *string) (*model.Media, error) {
+	contentType, err := detectContentType(data, declaredType)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	key := storage.KeyForHash(hash)
+
+	existing, err := s.repos.Media.GetBySHA256(ctx, hash)
+	if err != nil && !database.IsNotFoundError(err) {
+		return nil, fmt.Errorf("get media by hash: %w", err)
+	}
+	if existing != nil {
+		// Метаданные есть, но объект мог пропасть из хранилища — восстанавливаем
+		ok, err := s.store.Exists(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("check media object: %w", err)
+		}
+		if !ok {
+			if err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), existing.ContentType); err != nil {
+				return nil, fmt.Errorf("store media object: %w", err)
+			}
+		}
+		return existing, nil
+	}
+
+	// Сначала объект, потом строка в БД: строка без объекта хуже, чем
+	// объект без строки (его перезапишет следующая загрузка того же файла)
+	if err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, fmt.Errorf("store media object: %w", err)
+	}
+
+	created, err := s.repos.Media.CreateOrGet(ctx, &model.Media{
+		SHA256:      hash,
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		SourceURL:   sourceURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create media: %w", err)
+	}
+	return created, nil
+}
+
+// readLimited читает содержимое целиком, отклоняя пустые файлы и файлы больше MaxSize.
+func (s *Service) readLimited(r io.Reader, field string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.cfg.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read media: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, types.NewValidationError(field, "file is empty")
+	}
+	if int64(len(data)) > s.cfg.MaxSize {
+		return nil, types.NewValidationError(field, fmt.Sprintf("file exceeds %d bytes", s.cfg.MaxSize))
+	}
+	return data, nil
+}
+
+// ============================================================================
+// READ OPERATIONS
+// ============================================================================
+
+// GetByID возвращает метаданные медиафайла.
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*model.Media, error) {
+	m, err := s.repos.Media.GetByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, types.ErrNotFound
+		}
+		return nil, fmt.Errorf("get media: %w", err)
+	}
+	return m, nil
+}
+
+// ListByIDs возвращает метаданные медиафайлов по списку ID (для DataLoader).
+func (s *Service) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]model.Media, error) {
+	return s.repos.Media.ListByIDs(ctx, ids)
+}
+
+// Open возвращает метаданные и содержимое медиафайла. Вызывающий обязан закрыть ReadCloser.
+func (s *Service) Open(ctx context.Context, id uuid.UUID) (*model.Media, io.ReadCloser, error) {
+	m, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.store.Get(ctx, storage.KeyForHash(m.SHA256))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, types.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("open media object: %w", err)
+	}
+	return m, rc, nil
+}
+
+// ============================================================================
+// CONTENT TYPE
+// ============================================================================
+
+// detectContentType определяет тип файла по содержимому и проверяет, что это
+// изображение или аудио. Заявленный тип используется, только если содержимое
+// не распознано (например, AAC или M4A без явной сигнатуры).
+// SVG не принимается: он может содержать скрипты.
+func detectContentType(data []byte, declared string) (string, error) {
+	sniffed := http.DetectContentType(data[:min(len(data), sniffLen)])
+	sniffed, _, _ = mime.ParseMediaType(sniffed)
+	declared, _, _ = mime.ParseMediaType(declared)
+
+	switch {
+	case sniffed == "application/ogg":
+		return "audio/ogg", nil
+	case isAllowedType(sniffed):
+		return sniffed, nil
+	case (sniffed == "application/octet-stream" || sniffed == "video/mp4") && strings.HasPrefix(declared, "audio/"):
+		return declared, nil
+	}
+	return "", types.NewValidationError("file", fmt.Sprintf("unsupported content type %q: only images and audio are allowed", sniffed))
+}
+
+// isAllowedType сообщает, можно ли хранить файл с таким типом.
+func isAllowedType(contentType string) bool {
+	if contentType == "image/svg+xml" {
+		return false
+	}
+	return strings.HasPrefix(contentType, "image/") || strings.HasPrefix(contentType, "audio/")
+}
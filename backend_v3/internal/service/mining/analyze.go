@@ -0,0 +1,387 @@
+package mining
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/database/repository/wordlevel"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textmine"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// lookupChunkSize — сколько слов проверяется одним запросом.
+const lookupChunkSize = 1000
+
+// AnalyzeInput — параметры подбора слов.
+type AnalyzeInput struct {
+	Format textmine.Format // Формат текста; пустой — определить по содержимому
+	Limit  int             // Сколько слов вернуть; 0 — DefaultLimit
+}
+
+// Candidate — незнакомое слово из текста.
+type Candidate struct {
+	Lemma         string   // Начальная форма
+	Forms         []string // Формы, встретившиеся в тексте, в порядке появления
+	Count         int      // Сколько раз встретились все формы
+	FrequencyRank *int     // Ранг частотности; nil, если слова нет в частотном списке
+	CefrLevel     *string  // Уровень CEFR; nil, если неизвестен
+	Score         float64  // Оценка для сортировки: чем выше, тем полезнее слово
+	Context       string   // Первое предложение текста со словом
+	Examples      []string // Первые предложения со словом, не больше textmine.MaxWordSentences
+}
+
+// Result — итог подбора слов.
+type Result struct {
+	Format     textmine.Format // Формат текста (определённый, если не был указан)
+	Tokens     int             // Всего слов в тексте
+	Words      int             // Разных слов без служебных и имён собственных
+	Known      int             // Из них уже есть в словаре
+	Candidates []Candidate     // Незнакомые слова по убыванию оценки, не больше Limit
+}
+
+// Analyze подбирает незнакомые слова из текста.
+//
+// Формы слова объединяются по начальной форме из частотного справочника
+// (walked, walking -> walk). Для слов вне справочника начальной формой
+// считается самая короткая из возможных лемм, которая есть в словаре или
+// сама встречается в тексте (blogged, blogs + blog -> blog); если такой нет,
+// слово остаётся как есть.
+// Слово считается знакомым, если в словаре есть любая его форма.
+// Оценка — (1 + ln(count)) * rarity, где rarity растёт от 0 для самых
+// частых слов языка до 1 для последних слов частотного списка и слов вне его.
+func (s *Service) Analyze(ctx context.Context, r io.Reader, input AnalyzeInput) (*Result, error) {
+	if input.Format != "" && !input.Format.IsValid() {
+		return nil, types.NewValidationError("format", "unknown format")
+	}
+	limit, err := validateLimit(input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxTextSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read text: %w", err)
+	}
+	if len(data) > MaxTextSize {
+		return nil, types.NewValidationError("text", fmt.Sprintf("exceeds %d bytes", MaxTextSize))
+	}
+	text := string(data)
+	if !utf8.ValidString(text) {
+		return nil, types.NewValidationError("text", "must be UTF-8")
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, types.NewValidationError("text", "cannot be empty")
+	}
+
+	format := input.Format
+	if format == "" {
+		format = textmine.DetectFormat(text)
+	}
+	parsed := textmine.Analyze(text, format)
+
+	lemmas, err := s.groupByLemma(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.classify(ctx, lemmas, false); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Format: format, Tokens: parsed.Tokens, Words: len(lemmas)}
+	var unknown []*lemma
+	for _, l := range lemmas {
+		if l.knowledge != unknownWord {
+			result.Known++
+			continue
+		}
+		unknown = append(unknown, l)
+	}
+	if result.Candidates, err = s.rank(ctx, unknown, limit); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateLimit проверяет количество возвращаемых слов; 0 — DefaultLimit.
+func validateLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultLimit, nil
+	}
+	if limit < 0 || limit > MaxLimit {
+		return 0, types.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", MaxLimit))
+	}
+	return limit, nil
+}
+
+// ============================================================================
+// LEMMAS
+// ============================================================================
+
+// knowledge — насколько слово знакомо.
+type knowledge int
+
+const (
+	unknownWord  knowledge = iota // Слова нет в словаре
+	learningWord                  // Слово в словаре, но ещё не выучено
+	knownWord                     // Карточка слова в REVIEW или MASTERED
+)
+
+// lemma — формы одного слова текста.
+type lemma struct {
+	Candidate
+	sentences []int    // Индексы предложений со словом по возрастанию
+	lookup    []string // Формы для поиска в словаре
+	knowledge knowledge
+}
+
+// groupByLemma объединяет слова текста по начальной форме в порядке появления.
+// Начальные формы из списка служебных слов отбрасываются (did -> do).
+func (s *Service) groupByLemma(ctx context.Context, text *textmine.Text) ([]*lemma, error) {
+	candidates := make([][]string, len(text.Words))
+	var all []string
+	seen := make(map[string]bool)
+	for i, w := range text.Words {
+		candidates[i] = wordlevel.Candidates(w.Word)
+		for _, c := range candidates[i] {
+			if !seen[c] {
+				seen[c] = true
+				all = append(all, c)
+			}
+		}
+	}
+
+	levels := make(map[string]model.WordLevel, len(all))
+	for start := 0; start < len(all); start += lookupChunkSize {
+		rows, err := s.repos.WordLevels.ListByWords(ctx, all[start:min(start+lookupChunkSize, len(all))])
+		if err != nil {
+			return nil, fmt.Errorf("find word levels: %w", err)
+		}
+		for _, row := range rows {
+			levels[row.Word] = row
+		}
+	}
+
+	guesses, err := s.guessBaseForms(ctx, text, candidates, levels)
+	if err != nil {
+		return nil, err
+	}
+
+	byLemma := make(map[string]*lemma)
+	var result []*lemma
+	for i, w := range text.Words {
+		base := w.Word
+		level, found := model.WordLevel{}, false
+		for _, c := range candidates[i] {
+			if level, found = levels[c]; found {
+				base = c
+				break
+			}
+		}
+		if !found && guesses[i] != "" {
+			base = guesses[i]
+		}
+		if textmine.IsStopWord(base) {
+			continue
+		}
+
+		l, ok := byLemma[base]
+		if !ok {
+			l = &lemma{Candidate: Candidate{Lemma: base}}
+			if found {
+				l.FrequencyRank = level.FrequencyRank
+				l.CefrLevel = &level.CefrLevel
+			}
+			byLemma[base] = l
+			result = append(result, l)
+		}
+		l.Forms = append(l.Forms, w.Word)
+		l.Count += w.Count
+		l.sentences = mergeSentences(l.sentences, w.Sentences)
+		l.lookup = append(l.lookup, candidates[i]...)
+	}
+
+	for _, l := range result {
+		for _, i := range l.sentences {
+			l.Examples = append(l.Examples, text.Sentences[i])
+		}
+		l.Context = l.Examples[0]
+	}
+	return result, nil
+}
+
+// guessBaseForms подбирает начальную форму словам текста, ни одной формы
+// которых нет в частотном справочнике: самую короткую из возможных лемм
+// (см. wordlevel.Candidates), которая есть в словаре или сама встречается
+// в тексте. Возвращает формы по индексам text.Words; "" — форма не найдена.
+func (s *Service) guessBaseForms(ctx context.Context, text *textmine.Text, candidates [][]string, levels map[string]model.WordLevel) ([]string, error) {
+	inText := make(map[string]bool, len(text.Words))
+	for _, w := range text.Words {
+		inText[w.Word] = true
+	}
+
+	var unlisted []int
+	var lookup []string
+	seen := make(map[string]bool)
+	for i, forms := range candidates {
+		if slices.ContainsFunc(forms, func(c string) bool { _, ok := levels[c]; return ok }) {
+			continue
+		}
+		unlisted = append(unlisted, i)
+		for _, c := range forms[1:] {
+			norm := textnorm.Normalize(textnorm.DefaultEntryLanguage, c)
+			if !seen[norm] {
+				seen[norm] = true
+				lookup = append(lookup, norm)
+			}
+		}
+	}
+
+	inDictionary := make(map[string]bool)
+	for start := 0; start < len(lookup); start += lookupChunkSize {
+		entries, err := s.repos.Dictionary.ListByNormalizedTexts(ctx, textnorm.DefaultEntryLanguage,
+			lookup[start:min(start+lookupChunkSize, len(lookup))])
+		if err != nil {
+			return nil, fmt.Errorf("find base forms: %w", err)
+		}
+		for _, e := range entries {
+			inDictionary[e.TextNormalized] = true
+		}
+	}
+
+	guesses := make([]string, len(text.Words))
+	for _, i := range unlisted {
+		// Первая форма — само слово; остальные — леммы после отбрасывания окончаний
+		for _, c := range candidates[i][1:] {
+			if !inText[c] && !inDictionary[textnorm.Normalize(textnorm.DefaultEntryLanguage, c)] {
+				continue
+			}
+			if guesses[i] == "" || utf8.RuneCountInString(c) < utf8.RuneCountInString(guesses[i]) {
+				guesses[i] = c
+			}
+		}
+	}
+	return guesses, nil
+}
+
+// mergeSentences объединяет возрастающие списки индексов предложений,
+// оставляя первые textmine.MaxWordSentences.
+func mergeSentences(a, b []int) []int {
+	merged := append(append([]int{}, a...), b...)
+	sort.Ints(merged)
+	result := merged[:0]
+	for _, i := range merged {
+		if len(result) == textmine.MaxWordSentences {
+			break
+		}
+		if len(result) == 0 || result[len(result)-1] != i {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// classify отмечает слова, у которых хотя бы одна форма есть в словаре.
+// С withCards слова с карточкой в REVIEW или MASTERED отмечаются выученными.
+func (s *Service) classify(ctx context.Context, lemmas []*lemma, withCards bool) error {
+	owners := make(map[string][]*lemma)
+	var lookup []string
+	for _, l := range lemmas {
+		for _, form := range l.lookup {
+			norm := textnorm.Normalize(textnorm.DefaultEntryLanguage, form)
+			if _, ok := owners[norm]; !ok {
+				lookup = append(lookup, norm)
+			}
+			owners[norm] = append(owners[norm], l)
+		}
+	}
+
+	entryOwners := make(map[uuid.UUID][]*lemma)
+	var entryIDs []uuid.UUID
+	for start := 0; start < len(lookup); start += lookupChunkSize {
+		entries, err := s.repos.Dictionary.ListByNormalizedTexts(ctx, textnorm.DefaultEntryLanguage,
+			lookup[start:min(start+lookupChunkSize, len(lookup))])
+		if err != nil {
+			return fmt.Errorf("find known words: %w", err)
+		}
+		for _, e := range entries {
+			for _, l := range owners[e.TextNormalized] {
+				l.knowledge = max(l.knowledge, learningWord)
+			}
+			entryOwners[e.ID] = owners[e.TextNormalized]
+			entryIDs = append(entryIDs, e.ID)
+		}
+	}
+	if !withCards {
+		return nil
+	}
+
+	for start := 0; start < len(entryIDs); start += lookupChunkSize {
+		cards, err := s.repos.Cards.ListByEntryIDs(ctx, entryIDs[start:min(start+lookupChunkSize, len(entryIDs))])
+		if err != nil {
+			return fmt.Errorf("find cards: %w", err)
+		}
+		for _, c := range cards {
+			if c.Status != model.StatusReview && c.Status != model.StatusMastered {
+				continue
+			}
+			for _, l := range entryOwners[c.EntryID] {
+				l.knowledge = knownWord
+			}
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// RANKING
+// ============================================================================
+
+// rank оценивает слова и возвращает limit лучших по убыванию оценки.
+// Редкость отсчитывается от размера частотного списка (см. score).
+func (s *Service) rank(ctx context.Context, lemmas []*lemma, limit int) ([]Candidate, error) {
+	horizon, err := s.repos.WordLevels.MaxFrequencyRank(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find frequency list size: %w", err)
+	}
+
+	candidates := make([]Candidate, 0, len(lemmas))
+	for _, l := range lemmas {
+		l.Score = score(l.Count, l.FrequencyRank, horizon)
+		candidates = append(candidates, l.Candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Lemma < b.Lemma
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// score оценивает полезность слова: частые в тексте и редкие в языке — выше.
+// horizon — ранг, начиная с которого слово считается полностью редким
+// (последний ранг частотного списка); слова вне списка тоже редкие.
+func score(count int, rank *int, horizon int) float64 {
+	rarity := 1.0
+	if rank != nil && *rank > 0 && horizon > 1 {
+		rarity = math.Min(1, math.Log(float64(*rank))/math.Log(float64(horizon)))
+	}
+	return math.Round((1+math.Log(float64(count)))*rarity*1000) / 1000
+}
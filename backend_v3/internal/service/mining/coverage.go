@@ -0,0 +1,132 @@
+package mining
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/service/inbox"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/epub"
+	"github.com/heartmarshall/my-english/pkg/textmine"
+)
+
+// CoverageInput — параметры анализа книги.
+type CoverageInput struct {
+	Limit      int  // Сколько незнакомых слов вернуть; 0 — DefaultLimit
+	AddToInbox bool // Добавить возвращённые незнакомые слова во входящие
+}
+
+// CoverageResult — итог анализа книги.
+type CoverageResult struct {
+	Title          string
+	Author         string
+	Tokens         int         // Всего слов в книге
+	KnownTokens    int         // Из них выученных слов (карточка в REVIEW или MASTERED)
+	FunctionTokens int         // Из них служебных слов и имён собственных
+	Coverage       float64     // (KnownTokens + FunctionTokens) / Tokens * 100
+	Words          int         // Разных слов без служебных и имён собственных
+	KnownWords     int         // Из них выучено
+	LearningWords  int         // Из них есть в словаре, но ещё не выучено
+	UnknownWords   int         // Из них нет в словаре
+	Candidates     []Candidate // Незнакомые слова по убыванию оценки, не больше Limit
+	AddedToInbox   int         // Создано заметок во входящих
+}
+
+// Coverage оценивает, насколько понятна книга EPUB: какая доля слов текста
+// (с повторами) приходится на выученные слова. Служебные слова и имена
+// собственные считаются понятными. Незнакомые слова — те, которых нет
+// в словаре, — ранжируются так же, как в Analyze, с примерами из книги.
+//
+// С AddToInbox возвращённые слова добавляются во входящие: текст — начальная
+// форма, контекст — примеры с названием книги. Слова, которые уже есть
+// во входящих, пропускаются.
+func (s *Service) Coverage(ctx context.Context, r io.Reader, input CoverageInput) (*CoverageResult, error) {
+	limit, err := validateLimit(input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, epub.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	if len(data) > epub.MaxFileSize {
+		return nil, types.NewValidationError("file", fmt.Sprintf("exceeds %d bytes", epub.MaxFileSize))
+	}
+	book, err := epub.Open(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		if errors.Is(err, epub.ErrInvalidFile) {
+			return nil, types.NewValidationError("file", err.Error())
+		}
+		return nil, fmt.Errorf("read book: %w", err)
+	}
+
+	parsed := textmine.Analyze(book.Text(), textmine.FormatText)
+	lemmas, err := s.groupByLemma(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.classify(ctx, lemmas, true); err != nil {
+		return nil, err
+	}
+
+	result := &CoverageResult{
+		Title:          book.Title,
+		Author:         book.Author,
+		Tokens:         parsed.Tokens,
+		FunctionTokens: parsed.Tokens,
+		Words:          len(lemmas),
+	}
+	// Всё, что не вошло в слова, — служебные слова и имена собственные
+	var unknown []*lemma
+	for _, l := range lemmas {
+		result.FunctionTokens -= l.Count
+		switch l.knowledge {
+		case knownWord:
+			result.KnownWords++
+			result.KnownTokens += l.Count
+		case learningWord:
+			result.LearningWords++
+		default:
+			result.UnknownWords++
+			unknown = append(unknown, l)
+		}
+	}
+	if result.Tokens > 0 {
+		result.Coverage = float64(result.KnownTokens+result.FunctionTokens) / float64(result.Tokens) * 100
+	}
+	if result.Candidates, err = s.rank(ctx, unknown, limit); err != nil {
+		return nil, err
+	}
+
+	if input.AddToInbox && len(result.Candidates) > 0 {
+		created, err := s.inbox.AddToInboxBatch(ctx, inboxItems(result.Candidates, book.Title))
+		if err != nil {
+			return nil, fmt.Errorf("add to inbox: %w", err)
+		}
+		result.AddedToInbox = len(created)
+	}
+	return result, nil
+}
+
+// inboxItems превращает слова в заметки: контекст — примеры, по строке
+// на пример, с названием книги.
+func inboxItems(candidates []Candidate, title string) []inbox.ItemInput {
+	items := make([]inbox.ItemInput, len(candidates))
+	for i, c := range candidates {
+		lines := make([]string, len(c.Examples))
+		for j, example := range c.Examples {
+			lines[j] = example
+			if title != "" {
+				lines[j] += " — " + title
+			}
+		}
+		context := strings.Join(lines, "\n")
+		items[i] = inbox.ItemInput{Text: c.Lemma, Context: &context}
+	}
+	return items
+}
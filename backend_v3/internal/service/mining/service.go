@@ -0,0 +1,55 @@
+// Package mining подбирает незнакомые слова из текста статьи, субтитров
+// (SRT, WebVTT) или книги EPUB: слова приводятся к начальной форме, служебные
+// и уже добавленные в словарь отбрасываются, остальные ранжируются по частоте
+// в тексте и редкости в языке. Выбранные слова добавляются во входящие
+// с предложением из текста в качестве контекста. Для книги считается также
+// доля текста, покрытая выученными словами.
+package mining
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/inbox"
+)
+
+const (
+	// MaxTextSize — максимальный размер текста в байтах.
+	MaxTextSize = 5 << 20
+
+	// DefaultLimit — сколько слов возвращается по умолчанию.
+	DefaultLimit = 100
+
+	// MaxLimit — максимальное количество возвращаемых слов.
+	MaxLimit = 1000
+)
+
+// InboxService определяет интерфейс для добавления слов во входящие.
+// Интерфейс объявлен здесь, так как используется в этом пакете.
+type InboxService interface {
+	// AddToInboxBatch создает заметки, пропуская тексты, которые уже есть во входящих.
+	AddToInboxBatch(ctx context.Context, items []inbox.ItemInput) ([]model.InboxItem, error)
+}
+
+// Service реализует подбор слов из текста.
+type Service struct {
+	repos *repository.Registry
+	inbox InboxService
+}
+
+// NewService создаёт сервис подбора слов.
+func NewService(repos *repository.Registry, inbox InboxService) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if inbox == nil {
+		return nil, fmt.Errorf("inbox service cannot be nil")
+	}
+
+	return &Service{
+		repos: repos,
+		inbox: inbox,
+	}, nil
+}
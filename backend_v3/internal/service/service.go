@@ -5,10 +5,18 @@ import (
 
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+	"github.com/heartmarshall/my-english/internal/service/backup"
+	"github.com/heartmarshall/my-english/internal/service/csvimport"
 	"github.com/heartmarshall/my-english/internal/service/dictionary"
 	"github.com/heartmarshall/my-english/internal/service/inbox"
+	"github.com/heartmarshall/my-english/internal/service/kindle"
+	"github.com/heartmarshall/my-english/internal/service/media"
+	"github.com/heartmarshall/my-english/internal/service/mining"
 	"github.com/heartmarshall/my-english/internal/service/study"
 	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/internal/service/wiktionary"
+	"github.com/heartmarshall/my-english/internal/storage"
 )
 
 // Services объединяет все сервисы приложения.
@@ -18,13 +26,27 @@ type Services struct {
 	Inbox      *inbox.Service      // Сервис для работы с входящими заметками
 	Study      *study.Service      // Сервис для работы с изучением карточек
 	Suggestion *suggestion.Service // Сервис для получения подсказок из внешних источников
+	Media      *media.Service      // Сервис для хранения изображений и аудио
+	Anki       *anki.Service       // Сервис импорта и экспорта колод Anki
+	CSVImport  *csvimport.Service  // Сервис импорта слов из таблиц CSV/TSV
+	Kindle     *kindle.Service     // Сервис импорта слов из Vocabulary Builder Kindle
+	Backup     *backup.Service     // Сервис резервного копирования всех данных
+	Mining     *mining.Service     // Сервис подбора незнакомых слов из текста, субтитров и книг
+	Wiktionary *wiktionary.Service // Офлайн-словарь из дампа Викисловаря
 }
 
 // Deps содержит зависимости, необходимые для создания сервисов.
 type Deps struct {
-	Repos     *repository.Registry  // Реестр репозиториев для доступа к данным
-	TxManager *database.TxManager   // Менеджер транзакций для атомарных операций
-	Providers []suggestion.Provider // Провайдеры подсказок из внешних источников
+	Repos      *repository.Registry  // Реестр репозиториев для доступа к данным
+	TxManager  *database.TxManager   // Менеджер транзакций для атомарных операций
+	Providers  []suggestion.Provider // Провайдеры подсказок из внешних источников
+	Storage    storage.Storage       // Хранилище медиафайлов
+	Media      media.Config          // Настройки сервиса медиафайлов
+	ChunkSize  int                   // Размер пачки фонового импорта (0 — по умолчанию)
+	Wiktionary bool                  // Зарегистрировать офлайн-словарь Викисловаря провайдером подсказок
+
+	// Кэш подсказок в таблице suggestion_cache; nil — без кэша
+	SuggestionCache *suggestion.CacheConfig
 }
 
 // NewServices инициализирует и возвращает все сервисы приложения.
@@ -37,6 +59,9 @@ func NewServices(deps Deps) (*Services, error) {
 	if deps.TxManager == nil {
 		return nil, fmt.Errorf("tx manager cannot be nil")
 	}
+	if deps.Storage == nil {
+		return nil, fmt.Errorf("storage cannot be nil")
+	}
 
 	// Создаем сервисы в порядке зависимостей
 	dictSvc, err := dictionary.NewService(deps.Repos, deps.TxManager)
@@ -54,10 +79,65 @@ func NewServices(deps Deps) (*Services, error) {
 		return nil, fmt.Errorf("create study service: %w", err)
 	}
 
+	mediaSvc, err := media.NewService(deps.Repos, deps.Storage, deps.Media)
+	if err != nil {
+		return nil, fmt.Errorf("create media service: %w", err)
+	}
+
+	ankiSvc, err := anki.NewService(deps.Repos, deps.TxManager, dictSvc, mediaSvc)
+	if err != nil {
+		return nil, fmt.Errorf("create anki service: %w", err)
+	}
+
+	csvImportSvc, err := csvimport.NewService(deps.Repos, deps.TxManager, dictSvc, deps.ChunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("create csv import service: %w", err)
+	}
+
+	kindleSvc, err := kindle.NewService(deps.Repos, deps.TxManager)
+	if err != nil {
+		return nil, fmt.Errorf("create kindle service: %w", err)
+	}
+
+	backupSvc, err := backup.NewService(deps.Repos, deps.TxManager)
+	if err != nil {
+		return nil, fmt.Errorf("create backup service: %w", err)
+	}
+
+	miningSvc, err := mining.NewService(deps.Repos, inboxSvc)
+	if err != nil {
+		return nil, fmt.Errorf("create mining service: %w", err)
+	}
+
+	wiktionarySvc, err := wiktionary.NewService(deps.Repos, deps.TxManager)
+	if err != nil {
+		return nil, fmt.Errorf("create wiktionary service: %w", err)
+	}
+
+	var suggestionCache *suggestion.Cache
+	if deps.SuggestionCache != nil {
+		suggestionCache, err = suggestion.NewCache(deps.Repos.SuggestionCache, *deps.SuggestionCache)
+		if err != nil {
+			return nil, fmt.Errorf("create suggestion cache: %w", err)
+		}
+	}
+
+	providers := append([]suggestion.Provider{}, deps.Providers...)
+	if deps.Wiktionary {
+		providers = append(providers, wiktionarySvc)
+	}
+
 	return &Services{
 		Dictionary: dictSvc,
 		Inbox:      inboxSvc,
 		Study:      studySvc,
-		Suggestion: suggestion.NewService(deps.Providers...),
+		Suggestion: suggestion.NewService(suggestionCache, providers...),
+		Media:      mediaSvc,
+		Anki:       ankiSvc,
+		CSVImport:  csvImportSvc,
+		Kindle:     kindleSvc,
+		Backup:     backupSvc,
+		Mining:     miningSvc,
+		Wiktionary: wiktionarySvc,
 	}, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/heartmarshall/my-english/internal/database/repository/cards"
 	"github.com/heartmarshall/my-english/internal/model"
 	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
 )
 
 // Service реализует бизнес-логику для работы с изучением карточек.
@@ -136,13 +137,17 @@ func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*Revie
 // GetStudyQueue возвращает очередь карточек для изучения.
 // Метод возвращает слова, которые пора повторять, отсортированные в порядке приоритета.
 // Логика выборки инкапсулирована в репозитории.
-func (s *Service) GetStudyQueue(ctx context.Context, limit int) ([]model.DictionaryEntry, error) {
+// Если language задан, в очередь попадают только слова этого языка.
+func (s *Service) GetStudyQueue(ctx context.Context, limit int, language *string) ([]model.DictionaryEntry, error) {
 	if limit <= 0 {
 		return nil, types.NewValidationError("limit", "must be greater than 0")
 	}
+	if language != nil && !textnorm.IsValidLanguage(*language) {
+		return nil, types.NewValidationError("language", "must be a lowercase ISO 639 language code")
+	}
 
 	// Получаем карточки, которые пора повторять
-	cards, err := s.repos.Cards.GetDueCards(ctx, time.Now(), limit)
+	cards, err := s.repos.Cards.GetDueCards(ctx, time.Now(), limit, language)
 	if err != nil {
 		return nil, fmt.Errorf("get due cards: %w", err)
 	}
@@ -191,6 +196,17 @@ func (s *Service) GetDashboardStats(ctx context.Context) (*cards.DashboardStats,
 	return stats, nil
 }
 
+// GetVocabularyCoverage возвращает покрытие уровней CEFR словарём:
+// сколько слов каждого уровня есть в словаре и сколько из них выучено.
+func (s *Service) GetVocabularyCoverage(ctx context.Context) ([]repository.LevelCoverage, error) {
+	coverage, err := s.repos.WordLevels.GetCoverage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get vocabulary coverage: %w", err)
+	}
+
+	return coverage, nil
+}
+
 // GetCardHistory возвращает историю повторений карточки.
 func (s *Service) GetCardHistory(ctx context.Context, cardID uuid.UUID, limit int) ([]model.ReviewLog, error) {
 	logs, err := s.repos.ReviewLogs.ListByCardID(ctx, cardID, limit)
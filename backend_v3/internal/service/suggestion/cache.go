@@ -0,0 +1,228 @@
+package suggestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	ctx_pkg "github.com/heartmarshall/my-english/pkg/context"
+)
+
+// CacheConfig — настройки кэша ответов провайдеров.
+type CacheConfig struct {
+	TTL         time.Duration            // Срок хранения найденного ответа
+	NegativeTTL time.Duration            // Срок хранения ответа «слово не найдено»; 0 — не кэшируется
+	ProviderTTL map[string]time.Duration // Срок найденного ответа по slug провайдера; 0 — не кэшируется
+	MemorySize  int                      // Записей в LRU в памяти перед таблицей; 0 — без него
+}
+
+// OfflineProvider — провайдер, читающий локальные данные (файлы, таблицы БД).
+// Его ответы не кэшируются: прочитать их не дороже, чем кэш, а после
+// обновления данных кэш устарел бы.
+type OfflineProvider interface {
+	Offline() bool
+}
+
+// InvalidateInput — какие записи кэша удалить. Пустые поля не ограничивают
+// выборку: пустой ввод очищает весь кэш.
+type InvalidateInput struct {
+	Provider    string
+	Text        string
+	ExpiredOnly bool // Только записи с истёкшим сроком
+}
+
+// Cache хранит ответы провайдеров по ключу (провайдер, нормализованный текст)
+// в таблице suggestion_cache и, если задан MemorySize, в LRU в памяти.
+// Ошибки кэша не прерывают запрос подсказок: они логируются, а провайдер
+// запрашивается как при промахе.
+type Cache struct {
+	repo   repository.SuggestionCacheRepository
+	cfg    CacheConfig
+	memory *lru.Cache[cacheKey, cacheItem] // nil, если MemorySize == 0
+}
+
+type cacheKey struct {
+	provider string
+	text     string
+}
+
+// cacheItem — запись LRU; result == nil — слово не найдено.
+type cacheItem struct {
+	result    *Result
+	expiresAt time.Time
+}
+
+// NewCache создаёт кэш подсказок. repo может быть nil — тогда кэш
+// хранится только в памяти.
+func NewCache(repo repository.SuggestionCacheRepository, cfg CacheConfig) (*Cache, error) {
+	if cfg.TTL <= 0 {
+		return nil, fmt.Errorf("cache ttl must be positive")
+	}
+	if cfg.NegativeTTL < 0 {
+		return nil, fmt.Errorf("cache negative ttl cannot be negative")
+	}
+	if repo == nil && cfg.MemorySize <= 0 {
+		return nil, fmt.Errorf("cache needs a repository or memory size")
+	}
+
+	c := &Cache{repo: repo, cfg: cfg}
+	if cfg.MemorySize > 0 {
+		memory, err := lru.New[cacheKey, cacheItem](cfg.MemorySize)
+		if err != nil {
+			return nil, fmt.Errorf("create memory cache: %w", err)
+		}
+		c.memory = memory
+	}
+	return c, nil
+}
+
+// ttl возвращает срок хранения найденного ответа провайдера.
+func (c *Cache) ttl(provider string) time.Duration {
+	if ttl, ok := c.cfg.ProviderTTL[provider]; ok {
+		return ttl
+	}
+	return c.cfg.TTL
+}
+
+// cacheable сообщает, кэшируются ли ответы провайдера. Нулевой срок
+// в ProviderTTL выключает кэш провайдера, в том числе отрицательный.
+func (c *Cache) cacheable(p Provider) bool {
+	if offline, ok := p.(OfflineProvider); ok && offline.Offline() {
+		return false
+	}
+	return c.ttl(p.Slug()) > 0
+}
+
+// get ищет ответ провайдера. found == false — промах; result == nil
+// при found — закэшированное «слово не найдено».
+func (c *Cache) get(ctx context.Context, provider, text string) (result *Result, found bool) {
+	key := cacheKey{provider: provider, text: normalizeKey(text)}
+	now := time.Now()
+
+	if c.memory != nil {
+		if item, ok := c.memory.Get(key); ok {
+			if now.Before(item.expiresAt) {
+				return item.result, true
+			}
+			c.memory.Remove(key)
+		}
+	}
+	if c.repo == nil {
+		return nil, false
+	}
+
+	logger := ctx_pkg.L(ctx)
+	entry, err := c.repo.Get(ctx, key.provider, key.text)
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			logger.Warn("suggestion cache read failed", slog.String("provider", provider), slog.Any("error", err))
+		}
+		return nil, false
+	}
+	if entry.Result != nil {
+		result = &Result{}
+		if err := json.Unmarshal(entry.Result, result); err != nil {
+			logger.Warn("suggestion cache entry is malformed", slog.String("provider", provider), slog.Any("error", err))
+			return nil, false
+		}
+	}
+
+	if c.memory != nil {
+		c.memory.Add(key, cacheItem{result: result, expiresAt: entry.ExpiresAt})
+	}
+	return result, true
+}
+
+// set сохраняет ответ провайдера; result == nil — слово не найдено.
+func (c *Cache) set(ctx context.Context, provider, text string, result *Result) {
+	ttl := c.ttl(provider)
+	if result == nil {
+		ttl = c.cfg.NegativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	key := cacheKey{provider: provider, text: normalizeKey(text)}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	if c.memory != nil {
+		c.memory.Add(key, cacheItem{result: result, expiresAt: expiresAt})
+	}
+	if c.repo == nil {
+		return
+	}
+
+	entry := model.SuggestionCacheEntry{
+		Provider:       key.provider,
+		TextNormalized: key.text,
+		FetchedAt:      now,
+		ExpiresAt:      expiresAt,
+	}
+	if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			ctx_pkg.L(ctx).Warn("encode suggestion cache entry", slog.String("provider", provider), slog.Any("error", err))
+			return
+		}
+		entry.Result = data
+	}
+	if err := c.repo.Upsert(ctx, entry); err != nil {
+		ctx_pkg.L(ctx).Warn("suggestion cache write failed", slog.String("provider", provider), slog.Any("error", err))
+	}
+}
+
+// invalidate удаляет записи по условию и возвращает их количество
+// (в таблице, а без неё — в памяти).
+func (c *Cache) invalidate(ctx context.Context, input InvalidateInput) (int, error) {
+	text := normalizeKey(input.Text)
+
+	removed := 0
+	if c.memory != nil {
+		now := time.Now()
+		for _, key := range c.memory.Keys() {
+			if input.Provider != "" && key.provider != input.Provider {
+				continue
+			}
+			if text != "" && key.text != text {
+				continue
+			}
+			if input.ExpiredOnly {
+				item, ok := c.memory.Peek(key)
+				if !ok || now.Before(item.expiresAt) {
+					continue
+				}
+			}
+			if c.memory.Remove(key) {
+				removed++
+			}
+		}
+	}
+	if c.repo == nil {
+		return removed, nil
+	}
+
+	n, err := c.repo.Delete(ctx, repository.SuggestionCacheFilter{
+		Provider:    input.Provider,
+		Text:        text,
+		ExpiredOnly: input.ExpiredOnly,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("invalidate suggestion cache: %w", err)
+	}
+	return int(n), nil
+}
+
+// normalizeKey приводит текст запроса к ключу кэша: регистр и лишние
+// пробелы не влияют на ответ провайдеров.
+func normalizeKey(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
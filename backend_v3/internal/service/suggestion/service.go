@@ -25,6 +25,15 @@ type Result struct {
 	Senses         []dictionary.SenseInput // Используем те же Input структуры, что и для создания слова
 	Images         []dictionary.ImageInput
 	Pronunciations []dictionary.PronunciationInput
+	Synonyms       []string // Синонимы слова
+	Forms          []Form   // Словоформы: dogs (plural), went (past)
+	Cached         bool     `json:"-"` // Ответ взят из кэша
+}
+
+// Form — словоформа с грамматическими пометами.
+type Form struct {
+	Text string
+	Tags []string // plural, past, comparative...
 }
 
 // Provider — интерфейс внешнего источника данных для подсказок.
@@ -39,20 +48,24 @@ type Provider interface {
 }
 
 // Service реализует бизнес-логику для получения подсказок из внешних источников.
-// Использует паттерны Scatter-Gather и Singleflight для оптимизации запросов.
+// Использует паттерны Scatter-Gather и Singleflight для оптимизации запросов,
+// ответы провайдеров сохраняются в кэше.
 type Service struct {
 	providers map[string]Provider
+	cache     *Cache // nil — без кэша
 	sf        singleflight.Group
 }
 
 // NewService создает новый экземпляр сервиса подсказок и регистрирует провайдеров.
-func NewService(providers ...Provider) *Service {
+// cache может быть nil — тогда каждый запрос идёт к провайдерам.
+func NewService(cache *Cache, providers ...Provider) *Service {
 	pMap := make(map[string]Provider, len(providers))
 	for _, p := range providers {
 		pMap[p.Slug()] = p
 	}
 	return &Service{
 		providers: pMap,
+		cache:     cache,
 	}
 }
 
@@ -115,6 +128,23 @@ func (s *Service) fetchInternal(ctx context.Context, text string, sources []stri
 	for _, provider := range targets {
 		p := provider // capture loop var
 		g.Go(func() error {
+			cacheable := s.cache != nil && s.cache.cacheable(p)
+			if cacheable {
+				if res, found := s.cache.get(gCtx, p.Slug(), text); found {
+					if res == nil {
+						return nil
+					}
+					cached := *res
+					cached.Cached = true
+					select {
+					case resultsCh <- cached:
+					case <-gCtx.Done():
+						return gCtx.Err()
+					}
+					return nil
+				}
+			}
+
 			// У каждого провайдера должен быть свой таймаут, чтобы не вешать общий запрос надолго.
 			// (Обычно это делается внутри клиента, но safety net здесь не помешает).
 			childCtx, cancel := context.WithTimeout(gCtx, ProviderTimeout)
@@ -135,6 +165,11 @@ func (s *Service) fetchInternal(ctx context.Context, text string, sources []stri
 				return nil
 			}
 
+			// Ошибки не кэшируются, пустой ответ — отрицательный кэш
+			if cacheable {
+				s.cache.set(gCtx, p.Slug(), text, res)
+			}
+
 			// Если результат пустой (но без ошибки), тоже можно залогировать
 			if res == nil {
 				logger.Debug("suggestion provider returned no data", slog.String("provider", p.Slug()))
@@ -166,3 +201,12 @@ func (s *Service) fetchInternal(ctx context.Context, text string, sources []stri
 
 	return results, nil
 }
+
+// InvalidateCache удаляет записи кэша подсказок и возвращает их количество.
+// Без кэша возвращает 0.
+func (s *Service) InvalidateCache(ctx context.Context, input InvalidateInput) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	return s.cache.invalidate(ctx, input)
+}
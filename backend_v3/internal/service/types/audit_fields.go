@@ -26,6 +26,9 @@ const (
 const (
 	AuditFieldText           = "text"
 	AuditFieldTextNormalized = "text_normalized"
+	AuditFieldLanguage       = "language"
+	AuditFieldNotes          = "notes"
+	AuditFieldNotesOnCard    = "notes_on_card"
 )
 
 // ============================================================================
@@ -58,6 +61,17 @@ const (
 	AuditFieldTranslation = "translation"
 )
 
+// ============================================================================
+// RELATION FIELDS
+// ============================================================================
+
+const (
+	AuditFieldRelationID   = "relation_id"
+	AuditFieldRelations    = "relations"
+	AuditFieldRelationType = "relation_type"
+	AuditFieldTargetText   = "target_text"
+)
+
 // ============================================================================
 // IMAGE FIELDS
 // ============================================================================
@@ -66,6 +80,7 @@ const (
 	AuditFieldImageID = "image_id"
 	AuditFieldURL     = "url"
 	AuditFieldCaption = "caption"
+	AuditFieldMediaID = "media_id" // Также используется для произношений
 )
 
 // ============================================================================
@@ -107,6 +122,7 @@ const (
 	AuditFieldSensesCount         = "senses_count"
 	AuditFieldTranslationsCount   = "translations_count"
 	AuditFieldExamplesCount       = "examples_count"
+	AuditFieldRelationsCount      = "relations_count"
 	AuditFieldImagesCount         = "images_count"
 	AuditFieldPronunciationsCount = "pronunciations_count"
 )
@@ -119,29 +135,37 @@ const (
 	AuditActionSenseAdded         = "sense_added"
 	AuditActionExamplesAdded      = "examples_added"
 	AuditActionTranslationsAdded  = "translations_added"
+	AuditActionRelationsAdded     = "relations_added"
 	AuditActionImagesAdded        = "images_added"
 	AuditActionPronunciationsAdded = "pronunciations_added"
 	AuditActionSenseDeleted       = "sense_deleted"
 	AuditActionExampleDeleted     = "example_deleted"
 	AuditActionTranslationDeleted = "translation_deleted"
+	AuditActionRelationDeleted    = "relation_deleted"
 	AuditActionImageDeleted       = "image_deleted"
 	AuditActionPronunciationDeleted = "pronunciation_deleted"
 )
 
 // ============================================================================
-// RECREATION FIELDS
+// PATCH FIELDS (UpdateWord)
 // ============================================================================
 
 const (
-	AuditFieldSensesRecreated         = "senses_recreated"
-	AuditFieldImagesRecreated         = "images_recreated"
-	AuditFieldPronunciationsRecreated = "pronunciations_recreated"
-	AuditFieldSensesOldCount          = "senses_old_count"
-	AuditFieldSensesNewCount          = "senses_new_count"
-	AuditFieldImagesOldCount          = "images_old_count"
-	AuditFieldImagesNewCount          = "images_new_count"
-	AuditFieldPronunciationsOldCount  = "pronunciations_old_count"
-	AuditFieldPronunciationsNewCount  = "pronunciations_new_count"
+	AuditFieldChanges               = "changes"
+	AuditFieldSensesCreated         = "senses_created"
+	AuditFieldSensesUpdated         = "senses_updated"
+	AuditFieldSensesDeleted         = "senses_deleted"
+	AuditFieldExamplesCreated       = "examples_created"
+	AuditFieldExamplesUpdated       = "examples_updated"
+	AuditFieldExamplesDeleted       = "examples_deleted"
+	AuditFieldImagesCreated         = "images_created"
+	AuditFieldImagesUpdated         = "images_updated"
+	AuditFieldImagesDeleted         = "images_deleted"
+	AuditFieldPronunciationsCreated = "pronunciations_created"
+	AuditFieldPronunciationsUpdated = "pronunciations_updated"
+	AuditFieldPronunciationsDeleted = "pronunciations_deleted"
+	AuditFieldRelationsCreated      = "relations_created"
+	AuditFieldRelationsDeleted      = "relations_deleted"
 )
 
 // ============================================================================
@@ -163,3 +187,82 @@ const (
 	AuditFieldReviewLogID      = "review_log_id"
 )
 
+
+// ============================================================================
+// TRASH FIELDS (soft delete)
+// ============================================================================
+
+const (
+	AuditFieldDeletedAt    = "deleted_at"
+	AuditFieldPurgedBefore = "purged_before"
+
+	AuditActionTrashed  = "trashed"
+	AuditActionRestored = "restored"
+	AuditActionPurged   = "purged"
+)
+
+// ============================================================================
+// MERGE FIELDS (mergeWords)
+// ============================================================================
+
+const (
+	AuditFieldMergedFrom          = "merged_from"
+	AuditFieldMergedInto          = "merged_into"
+	AuditFieldSensesMoved         = "senses_moved"
+	AuditFieldSensesMerged        = "senses_merged"
+	AuditFieldTranslationsMoved   = "translations_moved"
+	AuditFieldExamplesMoved       = "examples_moved"
+	AuditFieldRelationsMoved      = "relations_moved"
+	AuditFieldImagesMoved         = "images_moved"
+	AuditFieldPronunciationsMoved = "pronunciations_moved"
+	AuditFieldReviewLogsMoved     = "review_logs_moved"
+	AuditFieldCardID              = "card_id"
+
+	AuditActionMerged = "merged"
+)
+
+// ============================================================================
+// VERSION FIELDS (restoreWordVersion)
+// ============================================================================
+
+const (
+	AuditFieldRestoredFrom = "restored_from"
+	AuditFieldVersionAt    = "version_at"
+
+	AuditActionVersionRestored = "version_restored"
+)
+
+// ============================================================================
+// BULK FIELDS (bulkDeleteWords, bulkCreateCards, bulkResetCards, bulkTag, bulkMoveToList)
+// ============================================================================
+
+const (
+	AuditFieldEntryIDs        = "entry_ids"
+	AuditFieldResetCards      = "reset_cards"
+	AuditFieldResetCardsCount = "reset_cards_count"
+	AuditFieldTags            = "tags"
+	AuditFieldTagsAdded       = "tags_added"
+	AuditFieldTagsRemoved     = "tags_removed"
+	AuditFieldList            = "list"
+	AuditFieldPreviousLists   = "previous_lists"
+	AuditFieldChunk           = "chunk"
+	AuditFieldChunks          = "chunks"
+
+	AuditActionBulkTrashed      = "bulk_trashed"
+	AuditActionBulkCardsCreated = "bulk_cards_created"
+	AuditActionBulkCardsReset   = "bulk_cards_reset"
+	AuditActionBulkTagged       = "bulk_tagged"
+	AuditActionBulkMovedToList  = "bulk_moved_to_list"
+)
+
+// ============================================================================
+// IMPORT FIELDS (importAnki)
+// ============================================================================
+
+const (
+	AuditFieldImportedFrom    = "imported_from"
+	AuditFieldExternalID      = "external_id"
+	AuditFieldReviewLogsCount = "review_logs_count"
+
+	AuditActionImported = "imported"
+)
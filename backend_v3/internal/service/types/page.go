@@ -0,0 +1,31 @@
+package types
+
+// Page — страница keyset-пагинации (Relay connection).
+type Page[T any] struct {
+	Items []T
+
+	// Cursors — непрозрачный курсор для каждого элемента Items (по индексу)
+	Cursors []string
+
+	HasNextPage     bool
+	HasPreviousPage bool
+
+	// TotalCount — общее количество элементов по фильтру без пагинации
+	TotalCount int64
+}
+
+// StartCursor возвращает курсор первого элемента страницы или nil для пустой страницы.
+func (p *Page[T]) StartCursor() *string {
+	if len(p.Cursors) == 0 {
+		return nil
+	}
+	return &p.Cursors[0]
+}
+
+// EndCursor возвращает курсор последнего элемента страницы или nil для пустой страницы.
+func (p *Page[T]) EndCursor() *string {
+	if len(p.Cursors) == 0 {
+		return nil
+	}
+	return &p.Cursors[len(p.Cursors)-1]
+}
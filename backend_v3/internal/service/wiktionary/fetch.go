@@ -0,0 +1,199 @@
+package wiktionary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+)
+
+// Fetch строит подсказку по импортированным статьям английского слова:
+// значения всех частей речи в порядке дампа (не больше MaxSenses),
+// произношение, синонимы и словоформы без повторов.
+// Для слова, которого нет в словаре, возвращает nil без ошибки.
+func (s *Service) Fetch(ctx context.Context, text string) (*suggestion.Result, error) {
+	word := textnorm.Normalize(textnorm.DefaultEntryLanguage, text)
+	if word == "" {
+		return nil, nil
+	}
+
+	entries, err := s.repos.Wiktionary.ListByWord(ctx, textnorm.DefaultEntryLanguage, word)
+	if err != nil {
+		return nil, fmt.Errorf("find wiktionary entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	b := newResultBuilder(s.Name())
+	for _, e := range entries {
+		if err := b.add(e); err != nil {
+			return nil, fmt.Errorf("decode wiktionary entry %d: %w", e.ID, err)
+		}
+	}
+	return b.result, nil
+}
+
+// ============================================================================
+// RESULT BUILDER
+// ============================================================================
+
+// resultBuilder собирает подсказку из нескольких статей слова.
+type resultBuilder struct {
+	result       *suggestion.Result
+	seenSounds   map[storedSound]bool
+	seenSynonyms map[string]bool
+	seenForms    map[string]bool
+}
+
+func newResultBuilder(name string) *resultBuilder {
+	return &resultBuilder{
+		result: &suggestion.Result{
+			SourceSlug:     Slug,
+			SourceName:     name,
+			Senses:         []dictionary.SenseInput{},
+			Images:         []dictionary.ImageInput{},
+			Pronunciations: []dictionary.PronunciationInput{},
+			Synonyms:       []string{},
+			Forms:          []suggestion.Form{},
+		},
+		seenSounds:   make(map[storedSound]bool),
+		seenSynonyms: make(map[string]bool),
+		seenForms:    make(map[string]bool),
+	}
+}
+
+// add добавляет в подсказку статью e.
+func (b *resultBuilder) add(e model.WiktionaryEntry) error {
+	var senses []storedSense
+	var sounds []storedSound
+	var forms []storedForm
+	if err := unmarshalJSON(e.Senses, &senses); err != nil {
+		return err
+	}
+	if err := unmarshalJSON(e.Sounds, &sounds); err != nil {
+		return err
+	}
+	if err := unmarshalJSON(e.Forms, &forms); err != nil {
+		return err
+	}
+
+	for _, s := range senses {
+		if len(b.result.Senses) == MaxSenses {
+			break
+		}
+		b.result.Senses = append(b.result.Senses, senseInput(s, e.POS))
+	}
+
+	for _, s := range sounds {
+		if b.seenSounds[s] {
+			continue
+		}
+		b.seenSounds[s] = true
+		b.result.Pronunciations = append(b.result.Pronunciations, dictionary.PronunciationInput{
+			AudioURL:      s.AudioURL,
+			Transcription: optional(s.IPA),
+			Region:        optional(s.Region),
+			SourceSlug:    Slug,
+		})
+	}
+
+	for _, syn := range e.Synonyms {
+		if !b.seenSynonyms[syn] {
+			b.seenSynonyms[syn] = true
+			b.result.Synonyms = append(b.result.Synonyms, syn)
+		}
+	}
+
+	for _, f := range forms {
+		key := f.Form + "\x00" + strings.Join(f.Tags, ",")
+		if b.seenForms[key] {
+			continue
+		}
+		b.seenForms[key] = true
+		tags := f.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		b.result.Forms = append(b.result.Forms, suggestion.Form{Text: f.Form, Tags: tags})
+	}
+	return nil
+}
+
+// senseInput превращает сохранённое значение в смысл подсказки.
+func senseInput(s storedSense, pos string) dictionary.SenseInput {
+	definition := s.Definition
+	sense := dictionary.SenseInput{
+		Definition:   &definition,
+		PartOfSpeech: mapPartOfSpeech(pos, s.Idiomatic),
+		SourceSlug:   Slug,
+	}
+	for _, ex := range s.Examples {
+		sense.Examples = append(sense.Examples, dictionary.ExampleInput{
+			Sentence:    ex.Sentence,
+			Translation: optional(ex.Translation),
+			SourceSlug:  Slug,
+		})
+	}
+	for _, t := range s.Translations {
+		sense.Translations = append(sense.Translations, dictionary.TranslationInput{
+			Text:       t.Text,
+			Language:   t.Language,
+			SourceSlug: Slug,
+		})
+	}
+	return sense
+}
+
+// partsOfSpeech — части речи Wiktextract и их соответствие в модели.
+var partsOfSpeech = map[string]model.PartOfSpeech{
+	"noun":        model.PosNoun,
+	"name":        model.PosNoun,
+	"verb":        model.PosVerb,
+	"adj":         model.PosAdjective,
+	"adv":         model.PosAdverb,
+	"pron":        model.PosPronoun,
+	"prep":        model.PosPreposition,
+	"postp":       model.PosPreposition,
+	"conj":        model.PosConjunction,
+	"intj":        model.PosInterjection,
+	"phrase":      model.PosPhrase,
+	"prep_phrase": model.PosPhrase,
+	"proverb":     model.PosPhrase,
+}
+
+// mapPartOfSpeech возвращает часть речи модели; значение с пометой
+// idiomatic — IDIOM, неизвестные части речи — OTHER, пустая — nil.
+func mapPartOfSpeech(pos string, idiomatic bool) *model.PartOfSpeech {
+	if pos == "" {
+		return nil
+	}
+	result, ok := partsOfSpeech[pos]
+	switch {
+	case idiomatic:
+		result = model.PosIdiom
+	case !ok:
+		result = model.PosOther
+	}
+	return &result
+}
+
+// unmarshalJSON разбирает JSONB; пустое значение оставляет v без изменений.
+func unmarshalJSON(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,259 @@
+package wiktionary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/pkg/textnorm"
+	"github.com/heartmarshall/my-english/pkg/wiktextract"
+)
+
+// ImportInput — параметры импорта дампа.
+type ImportInput struct {
+	Language            string // Язык слов (ISO 639); пусто — английский
+	TranslationLanguage string // Язык переводов (ISO 639); пусто — русский
+}
+
+// ImportResult — итог импорта.
+type ImportResult struct {
+	Language string   // Язык импортированных слов
+	Lines    int      // Прочитано статей
+	Imported int      // Записано статей
+	Skipped  int      // Статьи других языков и без значений
+	Invalid  int      // Строки, которые не разобрались
+	Replaced int64    // Удалено статей прежнего импорта
+	Warnings []string // Первые ошибки разбора строк
+}
+
+// Import загружает дамп Wiktextract (JSONL) из r. Статьи языка Language
+// прежнего импорта заменяются целиком в одной транзакции: до её завершения
+// подсказки строятся по старым данным. Статьи других языков и статьи без
+// значений пропускаются, строки, которые не разобрались, — тоже (первые
+// попадают в Warnings). Из примеров остаются короткие, без цитат.
+func (s *Service) Import(ctx context.Context, r io.Reader, input ImportInput) (*ImportResult, error) {
+	if input.Language == "" {
+		input.Language = textnorm.DefaultEntryLanguage
+	}
+	if input.TranslationLanguage == "" {
+		input.TranslationLanguage = textnorm.DefaultTranslationLanguage
+	}
+	if !textnorm.IsValidLanguage(input.Language) {
+		return nil, types.NewValidationError("language", "must be an ISO 639 language code")
+	}
+	if !textnorm.IsValidLanguage(input.TranslationLanguage) {
+		return nil, types.NewValidationError("translationLanguage", "must be an ISO 639 language code")
+	}
+
+	result := &ImportResult{Language: input.Language}
+	err := s.tx.RunInTx(ctx, func(ctx context.Context, q database.Querier) error {
+		repos := repository.NewRegistry(q)
+
+		replaced, err := repos.Wiktionary.DeleteByLanguage(ctx, input.Language)
+		if err != nil {
+			return fmt.Errorf("delete previous entries: %w", err)
+		}
+		result.Replaced = replaced
+
+		reader := wiktextract.NewReader(r)
+		batch := make([]model.WiktionaryEntry, 0, batchSize)
+		flush := func() error {
+			if err := repos.Wiktionary.InsertBatch(ctx, batch); err != nil {
+				return fmt.Errorf("insert entries: %w", err)
+			}
+			result.Imported += len(batch)
+			batch = batch[:0]
+			return nil
+		}
+
+		for {
+			e, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			if errors.Is(err, wiktextract.ErrInvalidLine) {
+				result.Invalid++
+				if len(result.Warnings) < maxWarnings {
+					result.Warnings = append(result.Warnings, err.Error())
+				}
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("read dump: %w", err)
+			}
+			result.Lines++
+
+			entry, ok, err := toStored(e, input)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", reader.Line(), err)
+			}
+			if !ok {
+				result.Skipped++
+				continue
+			}
+			batch = append(batch, entry)
+			if len(batch) == batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return flush()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ============================================================================
+// CONVERSION
+// ============================================================================
+
+// toStored превращает статью дампа в строку wiktionary_entries.
+// Возвращает false для статьи другого языка или без значений.
+func toStored(e *wiktextract.Entry, input ImportInput) (model.WiktionaryEntry, bool, error) {
+	word := strings.TrimSpace(e.Word)
+	if e.LangCode != input.Language || word == "" {
+		return model.WiktionaryEntry{}, false, nil
+	}
+
+	senses := storedSenses(e, input.TranslationLanguage)
+	if len(senses) == 0 {
+		return model.WiktionaryEntry{}, false, nil
+	}
+
+	var forms []storedForm
+	for _, f := range e.WordForms() {
+		forms = append(forms, storedForm{Form: f.Form, Tags: f.Tags})
+	}
+
+	entry := model.WiktionaryEntry{
+		Language:       input.Language,
+		Word:           word,
+		WordNormalized: textnorm.Normalize(input.Language, word),
+		POS:            e.POS,
+		Synonyms:       e.AllSynonyms(),
+	}
+	var err error
+	if entry.Senses, err = json.Marshal(senses); err != nil {
+		return model.WiktionaryEntry{}, false, err
+	}
+	if entry.Sounds, err = marshalOrNil(storedSounds(e.Sounds)); err != nil {
+		return model.WiktionaryEntry{}, false, err
+	}
+	if entry.Forms, err = marshalOrNil(forms); err != nil {
+		return model.WiktionaryEntry{}, false, err
+	}
+	return entry, true, nil
+}
+
+// storedSenses возвращает значения с определением: примеры без цитат
+// и переводы на translationLanguage.
+func storedSenses(e *wiktextract.Entry, translationLanguage string) []storedSense {
+	translations := e.SenseTranslations(translationLanguage)
+
+	var senses []storedSense
+	for i := range e.Senses {
+		src := &e.Senses[i]
+		definition := src.Gloss()
+		if definition == "" || hasTag(src.Tags, "no-gloss") {
+			continue
+		}
+
+		sense := storedSense{Definition: definition, Idiomatic: hasTag(src.Tags, "idiomatic")}
+		for _, ex := range src.Examples {
+			text := strings.TrimSpace(ex.Text)
+			if text == "" || ex.Type == "quotation" || len(text) > maxExampleLength {
+				continue
+			}
+			translation := strings.TrimSpace(ex.Translation)
+			if translation == "" {
+				translation = strings.TrimSpace(ex.English)
+			}
+			sense.Examples = append(sense.Examples, storedExample{Sentence: text, Translation: translation})
+			if len(sense.Examples) == MaxExamples {
+				break
+			}
+		}
+		for _, t := range translations[i] {
+			sense.Translations = append(sense.Translations, storedTranslation{Text: t, Language: translationLanguage})
+		}
+		senses = append(senses, sense)
+	}
+	return senses
+}
+
+// storedSounds сопоставляет записям произношения транскрипции того же
+// варианта (или первую транскрипцию). Транскрипции, не попавшие ни к одной
+// записи, сохраняются без записи.
+func storedSounds(sounds []wiktextract.Sound) []storedSound {
+	var firstIPA string
+	ipaByRegion := make(map[string]string)
+	for i := range sounds {
+		ipa := strings.TrimSpace(sounds[i].IPA)
+		if ipa == "" {
+			continue
+		}
+		if firstIPA == "" {
+			firstIPA = ipa
+		}
+		if region := sounds[i].Region(); region != "" && ipaByRegion[region] == "" {
+			ipaByRegion[region] = ipa
+		}
+	}
+
+	var result []storedSound
+	usedIPA := make(map[string]bool)
+	seenAudio := make(map[string]bool)
+	for i := range sounds {
+		audio := sounds[i].AudioURL()
+		if audio == "" || seenAudio[audio] {
+			continue
+		}
+		seenAudio[audio] = true
+
+		region := sounds[i].Region()
+		ipa := ipaByRegion[region]
+		if ipa == "" {
+			ipa = firstIPA
+		}
+		usedIPA[ipa] = true
+		result = append(result, storedSound{IPA: ipa, AudioURL: audio, Region: region})
+	}
+
+	for i := range sounds {
+		ipa := strings.TrimSpace(sounds[i].IPA)
+		if ipa == "" || usedIPA[ipa] {
+			continue
+		}
+		usedIPA[ipa] = true
+		result = append(result, storedSound{IPA: ipa, Region: sounds[i].Region()})
+	}
+	return result
+}
+
+// marshalOrNil кодирует непустой срез; для пустого возвращает nil
+// (в БД записывается пустой массив).
+func marshalOrNil[T any](items []T) (json.RawMessage, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(items)
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
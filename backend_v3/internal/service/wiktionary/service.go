@@ -0,0 +1,109 @@
+// Package wiktionary — офлайн-словарь из дампа Викисловаря в формате
+// Wiktextract (https://kaikki.org): импорт дампа в БД и провайдер подсказок
+// по импортированным статьям. Подсказки не требуют сети, поэтому не зависят
+// от ограничений и сбоев внешних API.
+//
+// Статьи хранятся в wiktionary_entries уже в виде, близком к подсказке:
+// значения с примерами и переводами, произношение, словоформы и синонимы
+// в JSONB (форматы storedSense, storedSound, storedForm).
+package wiktionary
+
+import (
+	"fmt"
+
+	"github.com/heartmarshall/my-english/internal/database"
+	"github.com/heartmarshall/my-english/internal/database/repository"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+)
+
+const (
+	// Slug — идентификатор провайдера в запросах подсказок.
+	Slug = "wiktionary"
+
+	// MaxSenses — сколько значений слова возвращается в подсказке.
+	// У самых многозначных слов (set, run) их больше сотни.
+	MaxSenses = 50
+
+	// MaxExamples — сколько примеров значения сохраняется при импорте.
+	MaxExamples = 3
+
+	// maxExampleLength — более длинные примеры (обычно цитаты) пропускаются.
+	maxExampleLength = 300
+
+	// batchSize — сколько статей записывается одним запросом при импорте.
+	batchSize = 1000
+
+	// maxWarnings — сколько ошибок разбора строк попадает в итог импорта.
+	maxWarnings = 20
+)
+
+// Service импортирует дамп и реализует suggestion.Provider.
+type Service struct {
+	repos *repository.Registry
+	tx    *database.TxManager
+}
+
+var (
+	_ suggestion.Provider        = (*Service)(nil)
+	_ suggestion.OfflineProvider = (*Service)(nil)
+)
+
+// NewService создаёт сервис офлайн-словаря.
+func NewService(repos *repository.Registry, tx *database.TxManager) (*Service, error) {
+	if repos == nil {
+		return nil, fmt.Errorf("repos cannot be nil")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("tx cannot be nil")
+	}
+
+	return &Service{
+		repos: repos,
+		tx:    tx,
+	}, nil
+}
+
+// Slug возвращает идентификатор провайдера.
+func (s *Service) Slug() string { return Slug }
+
+// Name возвращает название провайдера.
+func (s *Service) Name() string { return "Wiktionary (offline)" }
+
+// Offline сообщает, что ответы читаются локально и не кэшируются.
+func (s *Service) Offline() bool { return true }
+
+// ============================================================================
+// STORED FORMAT
+// ============================================================================
+
+// storedSense — значение статьи в колонке senses.
+type storedSense struct {
+	Definition   string              `json:"definition"`
+	Idiomatic    bool                `json:"idiomatic,omitempty"` // Значение с пометой idiomatic
+	Examples     []storedExample     `json:"examples,omitempty"`
+	Translations []storedTranslation `json:"translations,omitempty"`
+}
+
+type storedExample struct {
+	Sentence    string `json:"sentence"`
+	Translation string `json:"translation,omitempty"`
+}
+
+type storedTranslation struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// storedSound — произношение в колонке sounds: запись с транскрипцией
+// или только транскрипция (AudioURL пустой).
+type storedSound struct {
+	IPA      string `json:"ipa,omitempty"`
+	AudioURL string `json:"audio_url,omitempty"`
+	Region   string `json:"region,omitempty"`
+}
+
+// storedForm — словоформа в колонке forms.
+type storedForm struct {
+	Form string   `json:"form"`
+	Tags []string `json:"tags,omitempty"`
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage_RoundTrip(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+	key := KeyForHash("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+
+	if ok, err := s.Exists(ctx, key); err != nil || ok {
+		t.Fatalf("Exists() before Put = %v, %v; want false, nil", ok, err)
+	}
+
+	if err := s.Put(ctx, key, strings.NewReader("test"), 4, "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if ok, err := s.Exists(ctx, key); err != nil || !ok {
+		t.Fatalf("Exists() after Put = %v, %v; want true, nil", ok, err)
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "test" {
+		t.Errorf("Get() = %q, want %q", data, "test")
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of missing object error = %v", err)
+	}
+}
+
+func TestLocalStorage_SizeMismatch(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "a/b", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatal("Put() with wrong size expected error")
+	}
+	if ok, _ := s.Exists(ctx, "a/b"); ok {
+		t.Error("Put() with wrong size left a partial object")
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{key: "9f/86/9f86d0", wantErr: false},
+		{key: "thumb/9f86d0.webp", wantErr: false},
+		{key: "", wantErr: true},
+		{key: "/etc/passwd", wantErr: true},
+		{key: "../secret", wantErr: true},
+		{key: "a/../../b", wantErr: true},
+		{key: "a//b", wantErr: true},
+		{key: `a\b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := validateKey(tt.key); (err != nil) != tt.wantErr {
+				t.Errorf("validateKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 — минимальный S3-совместимый сервер в памяти для тестов.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	authErr bool
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+		r.Header.Get("X-Amz-Content-Sha256") != unsignedPayload ||
+		r.Header.Get("X-Amz-Date") == "" {
+		f.authErr = true
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage_RoundTrip(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "media",
+		Prefix:          "dev",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "9f/86/abc", strings.NewReader("test"), 4, "audio/mpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := fake.objects["/media/dev/9f/86/abc"]; !ok {
+		t.Fatalf("Put() stored keys %v, want /media/dev/9f/86/abc", fake.objects)
+	}
+
+	if ok, err := s.Exists(ctx, "9f/86/abc"); err != nil || !ok {
+		t.Fatalf("Exists() = %v, %v; want true, nil", ok, err)
+	}
+
+	rc, err := s.Get(ctx, "9f/86/abc")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "test" {
+		t.Errorf("Get() = %q, want %q", data, "test")
+	}
+
+	if err := s.Delete(ctx, "9f/86/abc"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, "9f/86/abc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if fake.authErr {
+		t.Error("requests were not signed")
+	}
+}
+
+func TestS3Storage_ErrorCode(t *testing.T) {
+	srv := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	defer srv.Close()
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "media",
+		AccessKeyID:     "WRONG",
+		SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+
+	err = s.Put(context.Background(), "a", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put() error = %v, want AccessDenied", err)
+	}
+}
+
+// TestSigningKey проверяет вывод ключа подписи на примере из документации AWS.
+func TestSigningKey(t *testing.T) {
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("signingKey() = %s, want %s", got, want)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	if got := uriEncode("dir/a b+c~.txt", false); got != "dir/a%20b%2Bc~.txt" {
+		t.Errorf("uriEncode(path) = %q", got)
+	}
+	if got := uriEncode("a/b", true); got != "a%2Fb" {
+		t.Errorf("uriEncode(query) = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package storage содержит хранилища содержимого медиафайлов:
+// локальную файловую систему и S3-совместимое объектное хранилище.
+// Метаданные файлов хранятся в БД (см. repository/media).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ============================================================================
+// ERRORS
+// ============================================================================
+
+// ErrNotFound возвращается, когда объекта с указанным ключом нет в хранилище.
+var ErrNotFound = errors.New("storage: object not found")
+
+// ============================================================================
+// INTERFACE
+// ============================================================================
+
+// Storage — хранилище объектов по ключу.
+// Реализации должны быть безопасны для конкурентного использования.
+type Storage interface {
+	// Put сохраняет объект. size — длина содержимого в байтах.
+	// Повторная запись с тем же ключом перезаписывает объект.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get открывает объект на чтение. Вызывающий обязан закрыть ReadCloser.
+	// Возвращает ErrNotFound, если объекта нет.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists проверяет наличие объекта.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete удаляет объект. Удаление отсутствующего объекта не является ошибкой.
+	Delete(ctx context.Context, key string) error
+}
+
+// ============================================================================
+// KEYS
+// ============================================================================
+
+// KeyForHash возвращает ключ объекта для hex-encoded SHA-256 содержимого.
+// Первые два байта хеша становятся префиксом, чтобы не складывать все файлы
+// в один каталог: "9f86d0..." -> "9f/86/9f86d0...".
+func KeyForHash(sha256Hex string) string {
+	if len(sha256Hex) < 4 {
+		return sha256Hex
+	}
+	return sha256Hex[:2] + "/" + sha256Hex[2:4] + "/" + sha256Hex
+}
+
+// validateKey отклоняет пустые ключи и ключи, выходящие за пределы хранилища.
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("storage: empty key")
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	return nil
+}
@@ -49,13 +49,20 @@ type Loaders struct {
 	ImagesByEntryID         *dataloadgen.Loader[uuid.UUID, []model.Image]
 	PronunciationsByEntryID *dataloadgen.Loader[uuid.UUID, []model.Pronunciation]
 
-	// 1:N Loaders (Один смысл -> Много примеров/переводов)
+	// 1:N Loaders (Один смысл -> Много примеров/переводов/связей)
 	ExamplesBySenseID     *dataloadgen.Loader[uuid.UUID, []model.Example]
 	TranslationsBySenseID *dataloadgen.Loader[uuid.UUID, []model.Translation]
+	RelationsBySenseID    *dataloadgen.Loader[uuid.UUID, []model.SenseRelation]
 
 	// 1:1 Loaders (Одно слово -> Одна карточка)
 	CardByEntryID *dataloadgen.Loader[uuid.UUID, *model.Card]
 
+	// 1:1 Loaders (Изображение/произношение -> Медиафайл)
+	MediaByID *dataloadgen.Loader[uuid.UUID, *model.Media]
+
+	// 1:1 Loaders (Связь -> ID записи словаря с её словом)
+	RelationTargetByID *dataloadgen.Loader[uuid.UUID, *uuid.UUID]
+
 	// Конфигурация
 	config LoaderConfig
 }
@@ -88,11 +95,26 @@ func NewLoaders(repos *repository.Registry, config LoaderConfig) *Loaders {
 			dataloadgen.WithWait(config.WaitTime),
 			dataloadgen.WithBatchCapacity(config.MaxBatchSize),
 		),
+		RelationsBySenseID: dataloadgen.NewLoader(
+			newRelationsBySenseIDFetcher(repos.Relations, config.Logger),
+			dataloadgen.WithWait(config.WaitTime),
+			dataloadgen.WithBatchCapacity(config.MaxBatchSize),
+		),
 		CardByEntryID: dataloadgen.NewLoader(
 			newCardByEntryIDFetcher(repos.Cards, config.Logger),
 			dataloadgen.WithWait(config.WaitTime),
 			dataloadgen.WithBatchCapacity(config.MaxBatchSize),
 		),
+		MediaByID: dataloadgen.NewLoader(
+			newMediaByIDFetcher(repos.Media, config.Logger),
+			dataloadgen.WithWait(config.WaitTime),
+			dataloadgen.WithBatchCapacity(config.MaxBatchSize),
+		),
+		RelationTargetByID: dataloadgen.NewLoader(
+			newRelationTargetByIDFetcher(repos.Relations, config.Logger),
+			dataloadgen.WithWait(config.WaitTime),
+			dataloadgen.WithBatchCapacity(config.MaxBatchSize),
+		),
 		config: config,
 	}
 }
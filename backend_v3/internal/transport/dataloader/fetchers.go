@@ -179,6 +179,37 @@ func newTranslationsBySenseIDFetcher(repo repository.TranslationRepository, logg
 	}
 }
 
+func newRelationsBySenseIDFetcher(repo repository.SenseRelationRepository, logger *slog.Logger) func(context.Context, []uuid.UUID) ([]([]model.SenseRelation), []error) {
+	return func(ctx context.Context, keys []uuid.UUID) ([]([]model.SenseRelation), []error) {
+		items, err := repo.ListBySenseIDs(ctx, keys)
+		if err != nil {
+			if logger != nil {
+				logger.Error("failed to fetch relations",
+					slog.Int("count", len(keys)),
+					slog.Any("error", err),
+				)
+			}
+			errors := make([]error, len(keys))
+			for i := range errors {
+				errors[i] = fmt.Errorf("fetch relations: %w", err)
+			}
+			return nil, errors
+		}
+
+		grouped := make(map[uuid.UUID][]model.SenseRelation, len(keys))
+		for _, item := range items {
+			grouped[item.SenseID] = append(grouped[item.SenseID], item)
+		}
+
+		result := make([]([]model.SenseRelation), len(keys))
+		for i, key := range keys {
+			result[i] = grouped[key]
+		}
+
+		return result, nil
+	}
+}
+
 // ============================================================================
 // 1:1 FETCHERS (Pointer Fetchers)
 // Паттерн для nullable связей (Card может не быть у слова).
@@ -217,3 +248,68 @@ func newCardByEntryIDFetcher(repo repository.CardRepository, logger *slog.Logger
 		return result, nil
 	}
 }
+
+// newMediaByIDFetcher создает fetcher для загрузки медиафайлов по ID.
+func newMediaByIDFetcher(repo repository.MediaRepository, logger *slog.Logger) func(context.Context, []uuid.UUID) ([]*model.Media, []error) {
+	return func(ctx context.Context, keys []uuid.UUID) ([]*model.Media, []error) {
+		items, err := repo.ListByIDs(ctx, keys)
+		if err != nil {
+			if logger != nil {
+				logger.Error("failed to fetch media",
+					slog.Int("count", len(keys)),
+					slog.Any("error", err),
+				)
+			}
+			errors := make([]error, len(keys))
+			for i := range errors {
+				errors[i] = fmt.Errorf("fetch media: %w", err)
+			}
+			return nil, errors
+		}
+
+		mapped := make(map[uuid.UUID]*model.Media, len(items))
+		for i := range items {
+			mapped[items[i].ID] = &items[i]
+		}
+
+		result := make([]*model.Media, len(keys))
+		for i, key := range keys {
+			result[i] = mapped[key]
+		}
+
+		return result, nil
+	}
+}
+
+// newRelationTargetByIDFetcher создает fetcher для поиска записей словаря,
+// на которые указывают связи. nil — слова связи нет в словаре.
+func newRelationTargetByIDFetcher(repo repository.SenseRelationRepository, logger *slog.Logger) func(context.Context, []uuid.UUID) ([]*uuid.UUID, []error) {
+	return func(ctx context.Context, keys []uuid.UUID) ([]*uuid.UUID, []error) {
+		items, err := repo.ListTargets(ctx, keys)
+		if err != nil {
+			if logger != nil {
+				logger.Error("failed to fetch relation targets",
+					slog.Int("count", len(keys)),
+					slog.Any("error", err),
+				)
+			}
+			errors := make([]error, len(keys))
+			for i := range errors {
+				errors[i] = fmt.Errorf("fetch relation targets: %w", err)
+			}
+			return nil, errors
+		}
+
+		mapped := make(map[uuid.UUID]*uuid.UUID, len(items))
+		for i := range items {
+			mapped[items[i].RelationID] = &items[i].EntryID
+		}
+
+		result := make([]*uuid.UUID, len(keys))
+		for i, key := range keys {
+			result[i] = mapped[key]
+		}
+
+		return result, nil
+	}
+}
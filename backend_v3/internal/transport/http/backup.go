@@ -0,0 +1,124 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/internal/service/backup"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// requireToken пропускает только запросы с заголовком
+// Authorization: Bearer <token>.
+func requireToken(token string, next http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="backup"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// backupExportHandler отдаёт резервную копию всех данных по пути /backup.
+type backupExportHandler struct {
+	backup *backup.Service
+	logger *slog.Logger
+}
+
+// ServeHTTP выгружает копию прямо в ответ.
+func (h *backupExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filename := "my-english-backup-" + time.Now().UTC().Format("20060102-150405") + ".ndjson"
+	out := &lazyHeaderWriter{ResponseWriter: w, setHeaders: func(hdr http.Header) {
+		hdr.Set("Content-Type", "application/x-ndjson")
+		hdr.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		hdr.Set("Cache-Control", "no-store")
+	}}
+
+	summary, err := h.backup.Export(r.Context(), out)
+	if err != nil {
+		if out.written {
+			// Ответ уже начат — остаётся только оборвать его
+			h.logger.Error("backup export interrupted", slog.Any("error", err))
+			panic(http.ErrAbortHandler)
+		}
+		h.logger.Error("failed to export backup", slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	h.logger.Info("backup exported", slog.Int("rows", summary.Rows))
+}
+
+// backupRestoreHandler восстанавливает данные из копии в теле запроса
+// по пути /backup/restore. Параметр mode — PRESERVE (по умолчанию) или REMAP.
+type backupRestoreHandler struct {
+	backup  *backup.Service
+	maxSize int64
+	logger  *slog.Logger
+}
+
+// backupSummary — ответ на восстановление.
+type backupSummary struct {
+	Tables      []backupTableCount `json:"tables"`
+	Rows        int                `json:"rows"`
+	ReusedMedia int                `json:"reusedMedia"`
+}
+
+type backupTableCount struct {
+	Table string `json:"table"`
+	Rows  int    `json:"rows"`
+}
+
+// ServeHTTP восстанавливает копию и отвечает количеством строк по таблицам.
+func (h *backupRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	input := backup.RestoreInput{Mode: backup.Mode(strings.ToUpper(r.URL.Query().Get("mode")))}
+	body := http.MaxBytesReader(w, r.Body, h.maxSize)
+
+	summary, err := h.backup.Restore(r.Context(), body, input)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.logger.Info("backup restored",
+		slog.String("mode", string(input.Mode)),
+		slog.Int("rows", summary.Rows),
+		slog.Int("reused_media", summary.ReusedMedia))
+
+	resp := backupSummary{
+		Tables:      make([]backupTableCount, len(summary.Tables)),
+		Rows:        summary.Rows,
+		ReusedMedia: summary.ReusedMedia,
+	}
+	for i, t := range summary.Tables {
+		resp.Tables[i] = backupTableCount{Table: t.Table, Rows: t.Rows}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Warn("failed to write restore response", slog.Any("error", err))
+	}
+}
+
+// writeError отвечает 400 для ошибок валидации, 413 для слишком большой
+// копии и 500 для остальных.
+func (h *backupRestoreHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var vErr *types.ValidationError
+	if errors.As(err, &vErr) {
+		http.Error(w, vErr.Error(), http.StatusBadRequest)
+		return
+	}
+	var sizeErr *http.MaxBytesError
+	if errors.As(err, &sizeErr) {
+		http.Error(w, fmt.Sprintf("backup exceeds %d bytes", sizeErr.Limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	h.logger.Error("failed to restore backup", slog.String("path", r.URL.Path), slog.Any("error", err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
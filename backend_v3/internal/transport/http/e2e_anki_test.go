@@ -0,0 +1,284 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+	"github.com/heartmarshall/my-english/pkg/apkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ankiDeckPath is a small legacy-format deck shared with the pkg/apkg tests:
+//   - "apple" (Basic): review card, ivl 10, factor 2300, 3 reviews and 1 manual reschedule;
+//   - "serendipity" (Vocabulary: Word, Meaning, Example, IPA, Media): new card, audio and image;
+//   - an empty Basic note;
+//   - "banana" (Basic): learning card with 1 review.
+const ankiDeckPath = "../../../pkg/apkg/testdata/deck.apkg"
+
+const importAnkiMutation = `
+	mutation($file: Upload!, $input: AnkiImportInput) {
+		importAnki(file: $file, input: $input) {
+			notes imported duplicates skipped cards reviewLogs media warnings
+		}
+	}
+`
+
+const ankiWordQuery = `
+	query($search: String!) {
+		dictionary(filter: { search: $search }) {
+			text
+			senses {
+				definition
+				sourceSlug
+				translations { text language }
+				examples { sentence }
+			}
+			images { mediaId }
+			pronunciations { transcription mediaId media { contentType } }
+			card {
+				status intervalDays easeFactor nextReviewAt
+				reviewHistory(limit: 10) { grade durationMs reviewedAt }
+			}
+		}
+	}
+`
+
+// assertTime compares an RFC 3339 timestamp from a response regardless of its time zone.
+func assertTime(t *testing.T, expected string, actual interface{}) {
+	t.Helper()
+	want, err := time.Parse(time.RFC3339, expected)
+	require.NoError(t, err)
+	s, ok := actual.(string)
+	require.True(t, ok, "expected a timestamp, got %v", actual)
+	got, err := time.Parse(time.RFC3339Nano, s)
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got), "expected %s, got %s", expected, s)
+}
+
+// TestAnkiImport tests importing a deck with field mapping, media, card state and review history.
+func TestAnkiImport(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	deck, err := os.ReadFile(ankiDeckPath)
+	require.NoError(t, err)
+
+	// Words already in the dictionary are skipped
+	createTestWord(t, app, "banana")
+
+	resp := app.executeUpload(t, importAnkiMutation, nil, "deck.apkg", "application/zip", deck)
+	require.Empty(t, resp.Errors)
+	result := extractObject(t, resp.Data, "importAnki")
+	assert.Equal(t, float64(4), result["notes"])
+	assert.Equal(t, float64(2), result["imported"])
+	assert.Equal(t, float64(1), result["duplicates"])
+	assert.Equal(t, float64(1), result["skipped"])
+	assert.Equal(t, float64(2), result["cards"])
+	assert.Equal(t, float64(3), result["reviewLogs"], "Manual reschedules are not reviews")
+	assert.Equal(t, float64(2), result["media"])
+	assert.Contains(t, result["warnings"], "note 2003: word field is empty")
+
+	// Basic note: Front -> word, Back -> one translation per line; review card state and history
+	resp = app.executeGraphQL(t, ankiWordQuery, map[string]interface{}{"search": "apple"})
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	apple := words[0].(map[string]interface{})
+	assert.Equal(t, "apple", apple["text"])
+	sense := apple["senses"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "anki", sense["sourceSlug"])
+	assert.Nil(t, sense["definition"])
+	translations := sense["translations"].([]interface{})
+	require.Len(t, translations, 2)
+	assert.Equal(t, "яблоко", translations[0].(map[string]interface{})["text"])
+	assert.Equal(t, "яблоня", translations[1].(map[string]interface{})["text"])
+
+	card := apple["card"].(map[string]interface{})
+	assert.Equal(t, "REVIEW", card["status"])
+	assert.Equal(t, float64(10), card["intervalDays"])
+	assert.InDelta(t, 2.3, card["easeFactor"], 0.001)
+	// Due day 100 counted from the collection creation (2023-11-14T22:13:20Z)
+	assertTime(t, "2024-02-22T22:13:20Z", card["nextReviewAt"])
+
+	history := card["reviewHistory"].([]interface{})
+	require.Len(t, history, 3)
+	latest := history[0].(map[string]interface{})
+	assert.Equal(t, "GOOD", latest["grade"])
+	assert.Equal(t, float64(4000), latest["durationMs"])
+	assertTime(t, "2023-11-18T09:33:20Z", latest["reviewedAt"])
+	assert.Equal(t, "AGAIN", history[2].(map[string]interface{})["grade"])
+
+	// Custom note type: Meaning -> definition, Example, IPA on the pronunciation, media stored
+	resp = app.executeGraphQL(t, ankiWordQuery, map[string]interface{}{"search": "serendipity"})
+	require.Empty(t, resp.Errors)
+	words = extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	word := words[0].(map[string]interface{})
+	sense = word["senses"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, `"happy accident"`, sense["definition"])
+	assert.Equal(t, "It was pure serendipity.", sense["examples"].([]interface{})[0].(map[string]interface{})["sentence"])
+
+	images := word["images"].([]interface{})
+	require.Len(t, images, 1)
+	assert.NotNil(t, images[0].(map[string]interface{})["mediaId"])
+	pronunciations := word["pronunciations"].([]interface{})
+	require.Len(t, pronunciations, 1)
+	pron := pronunciations[0].(map[string]interface{})
+	assert.Equal(t, "/ˌsɛrənˈdɪpɪti/", pron["transcription"])
+	assert.Equal(t, "audio/mpeg", pron["media"].(map[string]interface{})["contentType"])
+
+	card = word["card"].(map[string]interface{})
+	assert.Equal(t, "NEW", card["status"])
+	assert.Nil(t, card["nextReviewAt"])
+	assert.Empty(t, card["reviewHistory"])
+
+	// Importing the same deck again only finds duplicates
+	resp = app.executeUpload(t, importAnkiMutation, nil, "deck.apkg", "application/zip", deck)
+	require.Empty(t, resp.Errors)
+	result = extractObject(t, resp.Data, "importAnki")
+	assert.Equal(t, float64(0), result["imported"])
+	assert.Equal(t, float64(3), result["duplicates"])
+}
+
+// TestAnkiImportMapping tests a custom field mapping and languages.
+func TestAnkiImportMapping(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	deck, err := os.ReadFile(ankiDeckPath)
+	require.NoError(t, err)
+
+	// Back becomes the definition; translations are disabled
+	resp := app.executeUpload(t, importAnkiMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"language": "de",
+			"mapping": map[string]interface{}{
+				"definition":  []string{"Back"},
+				"translation": []string{},
+			},
+		},
+	}, "deck.apkg", "application/zip", deck)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 3, extractInt(t, resp.Data, "importAnki", "imported"))
+
+	resp = app.executeGraphQL(t, `
+		query {
+			dictionary(filter: { language: "de", search: "banana" }) {
+				language
+				senses { definition translations { text } }
+			}
+		}
+	`, nil)
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	word := words[0].(map[string]interface{})
+	assert.Equal(t, "de", word["language"])
+	sense := word["senses"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "банан", sense["definition"])
+	assert.Empty(t, sense["translations"])
+}
+
+// TestAnkiImportInvalidFile tests that a file that is not an Anki package is rejected.
+func TestAnkiImportInvalidFile(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeUpload(t, importAnkiMutation, nil, "deck.apkg", "application/zip", []byte("not a zip archive"))
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeUpload(t, importAnkiMutation, map[string]interface{}{
+		"input": map[string]interface{}{"language": "German"},
+	}, "deck.apkg", "application/zip", []byte("not a zip archive"))
+	require.NotEmpty(t, resp.Errors)
+}
+
+// exportAnki downloads a deck from /export/anki and parses it.
+func exportAnki(t *testing.T, app *testApp, query string) *apkg.Package {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/export/anki?"+query, nil)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment;")
+
+	data := rec.Body.Bytes()
+	pkg, err := apkg.Open(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return pkg
+}
+
+// TestAnkiExport tests exporting words with media and scheduling and importing them back.
+func TestAnkiExport(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	deck, err := os.ReadFile(ankiDeckPath)
+	require.NoError(t, err)
+	resp := app.executeUpload(t, importAnkiMutation, nil, "deck.apkg", "application/zip", deck)
+	require.Empty(t, resp.Errors)
+
+	pkg := exportAnki(t, app, "deck=Imported%20words&includeScheduling=true")
+	require.Len(t, pkg.Notes, 3)
+	require.Len(t, pkg.Cards, 3)
+	nt := pkg.NoteTypes[pkg.Notes[0].TypeID]
+	require.NotNil(t, nt)
+	assert.Equal(t, anki.NoteFields, nt.Fields)
+
+	notes := make(map[string]apkg.Note)
+	for _, n := range pkg.Notes {
+		notes[n.Fields[nt.FieldIndex(anki.FieldWord)]] = n
+	}
+	apple := notes["apple"]
+	assert.Equal(t, "яблоко; яблоня", apple.Fields[nt.FieldIndex(anki.FieldTranslation)])
+	assert.Contains(t, apple.Tags, "lang::en")
+
+	// Card state and history of "apple" survive the round trip
+	card := pkg.CardsByNote()[apple.ID][0]
+	assert.Equal(t, apkg.CardTypeReview, card.Type)
+	assert.Equal(t, 10, card.Interval)
+	assert.Equal(t, 2300, card.Factor)
+	assert.Equal(t, 3, card.Reps)
+	reviews := pkg.ReviewsByCard()[card.ID]
+	require.Len(t, reviews, 3)
+	assert.Equal(t, 1, reviews[0].Ease)
+	assert.Equal(t, 3, reviews[2].Ease)
+
+	// Media files are packed and referenced from the fields
+	serendipity := notes["serendipity"]
+	assert.Contains(t, serendipity.Fields[nt.FieldIndex(anki.FieldAudio)], "[sound:myenglish-")
+	assert.Contains(t, serendipity.Fields[nt.FieldIndex(anki.FieldImage)], "<img src=\"myenglish-")
+	assert.Len(t, pkg.MediaNames(), 2)
+
+	// Without scheduling all cards are new; a filter narrows the selection
+	pkg = exportAnki(t, app, "search=banana")
+	require.Len(t, pkg.Notes, 1)
+	assert.Equal(t, apkg.CardTypeNew, pkg.Cards[0].Type)
+	assert.Empty(t, pkg.Reviews)
+
+	// Tag and list filters select words by their tags and list
+	_, err = app.pool.Exec(context.Background(),
+		`UPDATE dictionary_entries SET tags = '{fruit}', list = 'Kitchen' WHERE text = 'apple'`)
+	require.NoError(t, err)
+	pkg = exportAnki(t, app, "tag=Fruit")
+	require.Len(t, pkg.Notes, 1)
+	assert.Equal(t, "apple", pkg.Notes[0].Fields[nt.FieldIndex(anki.FieldWord)])
+	pkg = exportAnki(t, app, "list=Kitchen")
+	require.Len(t, pkg.Notes, 1)
+
+	// Validation errors are reported before the download starts
+	for _, query := range []string{"ids=not-a-uuid", "hasCard=maybe", "ids=" + uuid.NewString() + "&search=apple"} {
+		req := httptest.NewRequest(http.MethodGet, "/export/anki?"+query, nil)
+		rec := httptest.NewRecorder()
+		app.handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -0,0 +1,242 @@
+package http_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/service/backup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackupToken is the token that protects /backup in the test app.
+const testBackupToken = "test-backup-token"
+
+const backupWordQuery = `
+	query {
+		dictionary {
+			id
+			text
+			senses { id definition translations { text } examples { sentence } }
+			images { mediaId }
+			card { id status reviewHistory { grade } }
+		}
+		inboxItems { id text }
+	}
+`
+
+// backupRequest sends a request to the backup endpoints with the test token.
+func backupRequest(t *testing.T, app *testApp, method, target string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testBackupToken)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// truncateBackupTables removes all data that a backup covers.
+func truncateBackupTables(t *testing.T, app *testApp) {
+	t.Helper()
+	_, err := app.pool.Exec(context.Background(), `
+		TRUNCATE media, dictionary_entries, senses, translations, examples, images,
+			pronunciations, cards, review_logs, hints, inbox_items, audit_records CASCADE
+	`)
+	require.NoError(t, err)
+}
+
+// TestBackupRoundTrip tests exporting the whole dataset and restoring it with and without new IDs.
+func TestBackupRoundTrip(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	uploadResp := app.uploadMedia(t, "cat.png", "image/png", testPNG)
+	require.Empty(t, uploadResp.Errors)
+	mediaID := extractString(t, uploadResp.Data, "uploadMedia", "id")
+
+	createResp := app.executeGraphQL(t, `
+		mutation($mediaId: UUID!) {
+			createWord(input: {
+				text: "cat"
+				createCard: true
+				senses: [{
+					definition: "a small domesticated feline"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+					translations: [{ text: "кошка", sourceSlug: "user" }]
+					examples: [{ sentence: "The cat sleeps.", sourceSlug: "user" }]
+				}]
+				images: [{ mediaId: $mediaId }]
+			}) { id card { id } }
+		}
+	`, map[string]interface{}{"mediaId": mediaID})
+	require.Empty(t, createResp.Errors)
+	entryID := extractString(t, createResp.Data, "createWord", "id")
+	cardID := extractString(t, createResp.Data, "createWord", "card", "id")
+
+	reviewResp := app.executeGraphQL(t, `
+		mutation($cardId: UUID!) {
+			reviewCard(cardId: $cardId, grade: GOOD) { nextReviewAt }
+		}
+	`, map[string]interface{}{"cardId": cardID})
+	require.Empty(t, reviewResp.Errors)
+
+	inboxResp := app.executeGraphQL(t, `mutation { addToInbox(text: "dog", context: "a dog barks") { id } }`, nil)
+	require.Empty(t, inboxResp.Errors)
+
+	before := app.executeGraphQL(t, backupWordQuery, nil)
+	require.Empty(t, before.Errors)
+
+	// The token is required
+	req := httptest.NewRequest(http.MethodGet, "/backup", nil)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = backupRequest(t, app, http.MethodGet, "/backup", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment;")
+	dump := rec.Body.Bytes()
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(dump))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.GreaterOrEqual(t, len(lines), 2)
+
+	var header backup.Header
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, backup.FormatName, header.Format)
+	assert.Equal(t, backup.FormatVersion, header.Version)
+	assert.Equal(t, backup.SchemaVersion, header.SchemaVersion)
+
+	var end struct {
+		End    bool           `json:"end"`
+		Counts map[string]int `json:"counts"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &end))
+	assert.True(t, end.End)
+	assert.Equal(t, 1, end.Counts["media"])
+	assert.Equal(t, 1, end.Counts["dictionary_entries"])
+	assert.Equal(t, 1, end.Counts["review_logs"])
+	assert.Equal(t, 1, end.Counts["inbox_items"])
+	assert.Positive(t, end.Counts["audit_records"])
+
+	// PRESERVE needs an empty database
+	rec = backupRequest(t, app, http.MethodPost, "/backup/restore", dump)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "empty database")
+
+	// REMAP into the same data conflicts on the word text and changes nothing
+	rec = backupRequest(t, app, http.MethodPost, "/backup/restore?mode=remap", dump)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	unchanged := app.executeGraphQL(t, backupWordQuery, nil)
+	require.Empty(t, unchanged.Errors)
+	assert.JSONEq(t, string(before.Data), string(unchanged.Data))
+
+	// PRESERVE restores the same data with the same IDs
+	truncateBackupTables(t, app)
+	rec = backupRequest(t, app, http.MethodPost, "/backup/restore", dump)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var summary struct {
+		Tables []struct {
+			Table string `json:"table"`
+			Rows  int    `json:"rows"`
+		} `json:"tables"`
+		Rows        int `json:"rows"`
+		ReusedMedia int `json:"reusedMedia"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	assert.Equal(t, len(lines)-2, summary.Rows)
+	assert.Zero(t, summary.ReusedMedia)
+
+	after := app.executeGraphQL(t, backupWordQuery, nil)
+	require.Empty(t, after.Errors)
+	assert.JSONEq(t, string(before.Data), string(after.Data))
+
+	// REMAP into a database that already has the media reuses it and gives new IDs
+	truncateBackupTables(t, app)
+	reupload := app.uploadMedia(t, "cat.png", "image/png", testPNG)
+	require.Empty(t, reupload.Errors)
+	newMediaID := extractString(t, reupload.Data, "uploadMedia", "id")
+	require.NotEqual(t, mediaID, newMediaID)
+
+	rec = backupRequest(t, app, http.MethodPost, "/backup/restore?mode=REMAP", dump)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	assert.Equal(t, 1, summary.ReusedMedia)
+
+	remapped := app.executeGraphQL(t, backupWordQuery, nil)
+	require.Empty(t, remapped.Errors)
+	words := extractArray(t, remapped.Data, "dictionary")
+	require.Len(t, words, 1)
+	word := words[0].(map[string]interface{})
+	assert.NotEqual(t, entryID, word["id"])
+	assert.Equal(t, "cat", word["text"])
+
+	senses := word["senses"].([]interface{})
+	require.Len(t, senses, 1)
+	sense := senses[0].(map[string]interface{})
+	assert.Len(t, sense["translations"], 1)
+	assert.Len(t, sense["examples"], 1)
+
+	images := word["images"].([]interface{})
+	require.Len(t, images, 1)
+	assert.Equal(t, newMediaID, images[0].(map[string]interface{})["mediaId"])
+
+	card := word["card"].(map[string]interface{})
+	assert.NotEqual(t, cardID, card["id"])
+	assert.Len(t, card["reviewHistory"], 1)
+	assert.Len(t, extractArray(t, remapped.Data, "inboxItems"), 1)
+}
+
+// TestBackupRestoreInvalidFile tests that broken backups are rejected without changing data.
+func TestBackupRestoreInvalidFile(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "apple")
+	rec := backupRequest(t, app, http.MethodGet, "/backup", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	dump := rec.Body.String()
+	truncateBackupTables(t, app)
+
+	lines := strings.Split(strings.TrimSpace(dump), "\n")
+	truncated := strings.Join(lines[:len(lines)-1], "\n")
+	otherSchema := strings.Replace(dump, `"schemaVersion":`, `"schemaVersion":1`, 1)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "not a backup", body: `{"hello":"world"}`, want: "is not a backup"},
+		{name: "truncated", body: truncated, want: "is truncated"},
+		{name: "schema version", body: otherSchema, want: "does not match database schema version"},
+		{name: "unknown mode", body: dump, want: "must be PRESERVE or REMAP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/backup/restore"
+			if tt.name == "unknown mode" {
+				target += "?mode=merge"
+			}
+			rec := backupRequest(t, app, http.MethodPost, target, []byte(tt.body))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.want)
+		})
+	}
+
+	resp := app.executeGraphQL(t, `query { dictionary { id } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.Empty(t, extractArray(t, resp.Data, "dictionary"))
+}
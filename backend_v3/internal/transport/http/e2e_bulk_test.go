@@ -0,0 +1,381 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bulkCreateCardsMutation = `
+		mutation($ids: [UUID!], $filter: WordFilter) {
+			bulkCreateCards(ids: $ids, filter: $filter) { operationId matched affected }
+		}
+	`
+	bulkResetCardsMutation = `
+		mutation($ids: [UUID!], $filter: WordFilter) {
+			bulkResetCards(ids: $ids, filter: $filter) { operationId matched affected }
+		}
+	`
+	bulkDeleteWordsMutation = `
+		mutation($ids: [UUID!], $filter: WordFilter) {
+			bulkDeleteWords(ids: $ids, filter: $filter) { operationId matched affected }
+		}
+	`
+	bulkTagMutation = `
+		mutation($ids: [UUID!], $filter: WordFilter, $add: [String!], $remove: [String!]) {
+			bulkTag(ids: $ids, filter: $filter, add: $add, remove: $remove) { operationId matched affected chunks }
+		}
+	`
+	bulkMoveToListMutation = `
+		mutation($ids: [UUID!], $filter: WordFilter, $list: String) {
+			bulkMoveToList(ids: $ids, filter: $filter, list: $list) { operationId matched affected }
+		}
+	`
+)
+
+// bulkAuditChanges loads the aggregated audit record written by a bulk operation.
+func bulkAuditChanges(t *testing.T, app *testApp, operationID string) (string, string, map[string]interface{}) {
+	t.Helper()
+
+	var entityType, action string
+	var raw []byte
+	err := app.pool.QueryRow(context.Background(),
+		`SELECT entity_type::text, action::text, changes FROM audit_records WHERE entity_id = $1`, operationID,
+	).Scan(&entityType, &action, &raw)
+	require.NoError(t, err)
+
+	var changes map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &changes))
+	return entityType, action, changes
+}
+
+// TestBulkOperations tests bulk card creation, card reset and deletion by IDs and by filter.
+func TestBulkOperations(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	appleID, _ := createTestWord(t, app, "apple")
+	bananaID, _ := createTestWord(t, app, "banana")
+	cherryID, _ := createTestWord(t, app, "cherry")
+
+	// Create cards by IDs; duplicate IDs are counted once
+	resp := app.executeGraphQL(t, bulkCreateCardsMutation, map[string]interface{}{
+		"ids": []string{appleID, bananaID, appleID},
+	})
+	require.Empty(t, resp.Errors)
+	result := extractObject(t, resp.Data, "bulkCreateCards")
+	assert.Equal(t, float64(2), result["matched"])
+	assert.Equal(t, float64(2), result["affected"])
+
+	entityType, action, changes := bulkAuditChanges(t, app, result["operationId"].(string))
+	assert.Equal(t, "CARD", entityType)
+	assert.Equal(t, "CREATE", action)
+	assert.Equal(t, "bulk_cards_created", changes["action"])
+	assert.Equal(t, float64(2), changes["created_cards_count"])
+
+	// Words that already have cards are skipped
+	resp = app.executeGraphQL(t, bulkCreateCardsMutation, map[string]interface{}{
+		"ids": []string{appleID, bananaID},
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 0, extractInt(t, resp.Data, "bulkCreateCards", "affected"))
+
+	// Reset progress of every word with a card
+	_, err := app.pool.Exec(context.Background(),
+		`UPDATE cards SET status = 'REVIEW', interval_days = 6, ease_factor = 2.2, next_review_at = NOW() + INTERVAL '6 days'`)
+	require.NoError(t, err)
+
+	resp = app.executeGraphQL(t, bulkResetCardsMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"hasCard": true},
+	})
+	require.Empty(t, resp.Errors)
+	result = extractObject(t, resp.Data, "bulkResetCards")
+	assert.Equal(t, float64(2), result["matched"])
+	assert.Equal(t, float64(2), result["affected"])
+
+	entityType, action, changes = bulkAuditChanges(t, app, result["operationId"].(string))
+	assert.Equal(t, "CARD", entityType)
+	assert.Equal(t, "UPDATE", action)
+	resetCards := changes["reset_cards"].([]interface{})
+	require.Len(t, resetCards, 2)
+	assert.Equal(t, "REVIEW", resetCards[0].(map[string]interface{})["status"], "Audit keeps the state before reset")
+
+	resp = app.executeGraphQL(t, `
+		query($id: UUID!) {
+			dictionaryEntry(id: $id) { card { status intervalDays easeFactor nextReviewAt } }
+		}
+	`, map[string]interface{}{"id": appleID})
+	require.Empty(t, resp.Errors)
+	card := extractObject(t, resp.Data, "dictionaryEntry", "card")
+	assert.Equal(t, "NEW", card["status"])
+	assert.Equal(t, float64(0), card["intervalDays"])
+	assert.Equal(t, 2.5, card["easeFactor"])
+	assert.Nil(t, card["nextReviewAt"])
+
+	// Delete every word with a card; pagination of the filter is ignored
+	resp = app.executeGraphQL(t, bulkDeleteWordsMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"hasCard": true, "limit": 1},
+	})
+	require.Empty(t, resp.Errors)
+	result = extractObject(t, resp.Data, "bulkDeleteWords")
+	assert.Equal(t, float64(2), result["matched"])
+	assert.Equal(t, float64(2), result["affected"])
+
+	entityType, action, changes = bulkAuditChanges(t, app, result["operationId"].(string))
+	assert.Equal(t, "ENTRY", entityType)
+	assert.Equal(t, "DELETE", action)
+	assert.Equal(t, "bulk_trashed", changes["action"])
+	assert.Equal(t, float64(2), changes["deleted_entries_count"])
+	assert.ElementsMatch(t, []interface{}{appleID, bananaID}, changes["entry_ids"])
+
+	resp = app.executeGraphQL(t, `query { dictionary { id } }`, nil)
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	assert.Equal(t, cherryID, words[0].(map[string]interface{})["id"])
+
+	resp = app.executeGraphQL(t, `query { trash { id } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.Len(t, extractArray(t, resp.Data, "trash"), 2)
+
+	// Deleted words no longer match
+	resp = app.executeGraphQL(t, bulkDeleteWordsMutation, map[string]interface{}{
+		"ids": []string{appleID},
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 0, extractInt(t, resp.Data, "bulkDeleteWords", "matched"))
+}
+
+// TestBulkOperationsValidation tests that exactly one of ids and filter is required.
+func TestBulkOperationsValidation(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	wordID, _ := createTestWord(t, app, "apple")
+
+	resp := app.executeGraphQLWithError(t, bulkDeleteWordsMutation, nil)
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeGraphQLWithError(t, bulkDeleteWordsMutation, map[string]interface{}{
+		"ids":    []string{wordID},
+		"filter": map[string]interface{}{"hasCard": false},
+	})
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeGraphQLWithError(t, bulkResetCardsMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"cefrLevels": []string{"D1"}},
+	})
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestBulkTagAndMoveToList tests tagging words and moving them between lists.
+func TestBulkTagAndMoveToList(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	appleID, _ := createTestWord(t, app, "apple")
+	bananaID, _ := createTestWord(t, app, "banana")
+	cherryID, _ := createTestWord(t, app, "cherry")
+
+	// Tags are lowercased and stored sorted without duplicates
+	resp := app.executeGraphQL(t, bulkTagMutation, map[string]interface{}{
+		"ids": []string{appleID, bananaID},
+		"add": []string{"Fruit", "  yellow  "},
+	})
+	require.Empty(t, resp.Errors)
+	result := extractObject(t, resp.Data, "bulkTag")
+	assert.Equal(t, float64(2), result["affected"])
+	assert.Equal(t, float64(1), result["chunks"])
+
+	entityType, action, changes := bulkAuditChanges(t, app, result["operationId"].(string))
+	assert.Equal(t, "ENTRY", entityType)
+	assert.Equal(t, "UPDATE", action)
+	assert.Equal(t, "bulk_tagged", changes["action"])
+	assert.Equal(t, []interface{}{"fruit", "yellow"}, changes["tags_added"])
+	tagChanges := changes["tags"].(map[string]interface{})
+	require.Contains(t, tagChanges, appleID)
+	assert.Equal(t, []interface{}{"fruit", "yellow"}, tagChanges[appleID].(map[string]interface{})["new"])
+
+	// Words that already have the tags are skipped; a tag in both lists is removed
+	resp = app.executeGraphQL(t, bulkTagMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"tag": "FRUIT"},
+		"add":    []string{"fruit"},
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 2, extractInt(t, resp.Data, "bulkTag", "matched"))
+	assert.Equal(t, 0, extractInt(t, resp.Data, "bulkTag", "affected"))
+
+	resp = app.executeGraphQL(t, bulkTagMutation, map[string]interface{}{
+		"ids":    []string{appleID},
+		"add":    []string{"red"},
+		"remove": []string{"yellow"},
+	})
+	require.Empty(t, resp.Errors)
+
+	resp = app.executeGraphQL(t, `
+		query($id: UUID!) { dictionaryEntry(id: $id) { tags list } }
+	`, map[string]interface{}{"id": appleID})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, []interface{}{"fruit", "red"}, extractArray(t, resp.Data, "dictionaryEntry", "tags"))
+
+	// Move tagged words to a list, then one of them back out
+	resp = app.executeGraphQL(t, bulkMoveToListMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"tag": "fruit"},
+		"list":   " Kitchen ",
+	})
+	require.Empty(t, resp.Errors)
+	result = extractObject(t, resp.Data, "bulkMoveToList")
+	assert.Equal(t, float64(2), result["affected"])
+
+	_, _, changes = bulkAuditChanges(t, app, result["operationId"].(string))
+	assert.Equal(t, "bulk_moved_to_list", changes["action"])
+	assert.Equal(t, "Kitchen", changes["list"])
+	assert.ElementsMatch(t, []interface{}{appleID, bananaID}, changes["entry_ids"])
+
+	resp = app.executeGraphQL(t, bulkMoveToListMutation, map[string]interface{}{
+		"ids":  []string{cherryID},
+		"list": "Garden",
+	})
+	require.Empty(t, resp.Errors)
+	resp = app.executeGraphQL(t, bulkMoveToListMutation, map[string]interface{}{
+		"ids":  []string{bananaID},
+		"list": nil,
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 1, extractInt(t, resp.Data, "bulkMoveToList", "affected"))
+
+	resp = app.executeGraphQL(t, `query { wordLists { name wordCount } }`, nil)
+	require.Empty(t, resp.Errors)
+	lists := extractArray(t, resp.Data, "wordLists")
+	require.Len(t, lists, 2)
+	assert.Equal(t, "Garden", lists[0].(map[string]interface{})["name"])
+	assert.Equal(t, "Kitchen", lists[1].(map[string]interface{})["name"])
+	assert.Equal(t, float64(1), lists[1].(map[string]interface{})["wordCount"])
+
+	resp = app.executeGraphQL(t, `
+		query { dictionary(filter: { list: "Kitchen" }) { id list } }
+	`, nil)
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	assert.Equal(t, appleID, words[0].(map[string]interface{})["id"])
+
+	// Empty tag changes and blank list names are rejected
+	resp = app.executeGraphQLWithError(t, bulkTagMutation, map[string]interface{}{"ids": []string{appleID}})
+	require.NotEmpty(t, resp.Errors)
+	resp = app.executeGraphQLWithError(t, bulkTagMutation, map[string]interface{}{
+		"ids": []string{appleID},
+		"add": []string{"  "},
+	})
+	require.NotEmpty(t, resp.Errors)
+	resp = app.executeGraphQLWithError(t, bulkMoveToListMutation, map[string]interface{}{
+		"ids":  []string{appleID},
+		"list": " ",
+	})
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestBulkOperationsInChunks tests that large selections are processed in chunks,
+// each with its own audit record under the shared operation ID.
+func TestBulkOperationsInChunks(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+	ctx := context.Background()
+
+	_, err := app.pool.Exec(ctx, `
+		INSERT INTO dictionary_entries (text, text_normalized, list)
+		SELECT 'word' || g, 'word' || g, 'Import' FROM generate_series(1, 2500) AS g
+	`)
+	require.NoError(t, err)
+
+	resp := app.executeGraphQL(t, bulkTagMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"list": "Import"},
+		"add":    []string{"imported"},
+	})
+	require.Empty(t, resp.Errors)
+	result := extractObject(t, resp.Data, "bulkTag")
+	assert.Equal(t, float64(2500), result["matched"])
+	assert.Equal(t, float64(2500), result["affected"])
+	assert.Equal(t, float64(3), result["chunks"])
+
+	rows, err := app.pool.Query(ctx,
+		`SELECT changes FROM audit_records WHERE entity_id = $1 ORDER BY (changes->>'chunk')::int`,
+		result["operationId"])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var chunks []float64
+	for rows.Next() {
+		var raw []byte
+		require.NoError(t, rows.Scan(&raw))
+		var changes map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &changes))
+		assert.Equal(t, float64(3), changes["chunks"])
+		chunks = append(chunks, changes["chunk"].(float64))
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []float64{1, 2, 3}, chunks)
+
+	var tagged int
+	require.NoError(t, app.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM dictionary_entries WHERE tags @> ARRAY['imported']`,
+	).Scan(&tagged))
+	assert.Equal(t, 2500, tagged)
+}
+
+// TestBulkOperationsChunkFailure tests that a chunk failing part-way keeps the
+// committed chunks and reports them in the error extensions.
+func TestBulkOperationsChunkFailure(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+	ctx := context.Background()
+
+	_, err := app.pool.Exec(ctx, `
+		INSERT INTO dictionary_entries (text, text_normalized, list)
+		SELECT 'word' || g, 'word' || g, 'Import' FROM generate_series(1, 2500) AS g
+	`)
+	require.NoError(t, err)
+
+	// Второй UPDATE по словарю падает; счётчик — последовательность, чтобы
+	// откат части его не сбрасывал.
+	_, err = app.pool.Exec(ctx, `
+		CREATE SEQUENCE bulk_fail_seq;
+		CREATE FUNCTION bulk_fail() RETURNS trigger AS $$
+		BEGIN
+			IF nextval('bulk_fail_seq') >= 2 THEN
+				RAISE EXCEPTION 'bulk chunk failure';
+			END IF;
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+		CREATE TRIGGER bulk_fail AFTER UPDATE ON dictionary_entries
+			FOR EACH STATEMENT EXECUTE FUNCTION bulk_fail();
+	`)
+	require.NoError(t, err)
+
+	resp := app.executeGraphQL(t, bulkTagMutation, map[string]interface{}{
+		"filter": map[string]interface{}{"list": "Import"},
+		"add":    []string{"imported"},
+	})
+	require.NotEmpty(t, resp.Errors)
+	ext := resp.Errors[0].Extensions
+	require.NotNil(t, ext)
+	assert.NotEmpty(t, ext["operationId"])
+	assert.Equal(t, float64(1), ext["completedChunks"])
+	assert.Equal(t, float64(3), ext["chunks"])
+	assert.Equal(t, float64(1000), ext["affected"])
+
+	var tagged, audits int
+	require.NoError(t, app.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM dictionary_entries WHERE tags @> ARRAY['imported']`,
+	).Scan(&tagged))
+	assert.Equal(t, 1000, tagged)
+	require.NoError(t, app.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM audit_records WHERE entity_id::text = $1`, ext["operationId"],
+	).Scan(&audits))
+	assert.Equal(t, 1, audits)
+}
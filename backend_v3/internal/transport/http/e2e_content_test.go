@@ -0,0 +1,308 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestWord creates a word with one sense and returns entry and sense IDs.
+func createTestWord(t *testing.T, app *testApp, text string) (string, string) {
+	t.Helper()
+
+	query := `
+		mutation($text: String!) {
+			createWord(input: {
+				text: $text
+				createCard: false
+				senses: [{
+					definition: "a definition"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+				}]
+			}) {
+				id
+				senses {
+					id
+				}
+			}
+		}
+	`
+
+	resp := app.executeGraphQL(t, query, map[string]interface{}{"text": text})
+	require.Empty(t, resp.Errors)
+
+	entryID := extractString(t, resp.Data, "createWord", "id")
+	senses := extractArray(t, resp.Data, "createWord", "senses")
+	require.Len(t, senses, 1)
+
+	return entryID, senses[0].(map[string]interface{})["id"].(string)
+}
+
+// TestAddAndDeleteSense tests addSense and deleteSense mutations.
+func TestAddAndDeleteSense(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, firstSenseID := createTestWord(t, app, "light")
+
+	addQuery := `
+		mutation($entryId: UUID!, $input: SenseInput!) {
+			addSense(entryId: $entryId, input: $input) {
+				id
+				senses {
+					id
+					definition
+					translations { text }
+				}
+			}
+		}
+	`
+
+	addResp := app.executeGraphQL(t, addQuery, map[string]interface{}{
+		"entryId": entryID,
+		"input": map[string]interface{}{
+			"definition":   "not heavy",
+			"partOfSpeech": "ADJECTIVE",
+			"sourceSlug":   "user",
+			"translations": []map[string]interface{}{
+				{"text": "лёгкий", "sourceSlug": "user"},
+			},
+		},
+	})
+	require.Empty(t, addResp.Errors)
+	assert.Equal(t, entryID, extractString(t, addResp.Data, "addSense", "id"))
+	senses := extractArray(t, addResp.Data, "addSense", "senses")
+	require.Len(t, senses, 2, "Entry should have two senses after addSense")
+
+	deleteQuery := `
+		mutation($id: UUID!) {
+			deleteSense(id: $id) {
+				id
+				senses {
+					id
+				}
+			}
+		}
+	`
+
+	deleteResp := app.executeGraphQL(t, deleteQuery, map[string]interface{}{"id": firstSenseID})
+	require.Empty(t, deleteResp.Errors)
+	assert.Equal(t, entryID, extractString(t, deleteResp.Data, "deleteSense", "id"))
+	senses = extractArray(t, deleteResp.Data, "deleteSense", "senses")
+	require.Len(t, senses, 1)
+	assert.NotEqual(t, firstSenseID, senses[0].(map[string]interface{})["id"])
+}
+
+// TestAddAndDeleteExamples tests addExamples and deleteExample mutations.
+func TestAddAndDeleteExamples(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	_, senseID := createTestWord(t, app, "book")
+
+	addQuery := `
+		mutation($senseId: UUID!, $examples: [ExampleInput!]!) {
+			addExamples(senseId: $senseId, examples: $examples) {
+				id
+				examples {
+					id
+					sentence
+				}
+			}
+		}
+	`
+
+	addResp := app.executeGraphQL(t, addQuery, map[string]interface{}{
+		"senseId": senseID,
+		"examples": []map[string]interface{}{
+			{"sentence": "I read a book.", "sourceSlug": "user"},
+			{"sentence": "This book is good.", "sourceSlug": "user"},
+		},
+	})
+	require.Empty(t, addResp.Errors)
+	assert.Equal(t, senseID, extractString(t, addResp.Data, "addExamples", "id"))
+	examples := extractArray(t, addResp.Data, "addExamples", "examples")
+	require.Len(t, examples, 2)
+
+	exampleID := examples[0].(map[string]interface{})["id"]
+
+	deleteQuery := `
+		mutation($id: UUID!) {
+			deleteExample(id: $id) {
+				id
+				examples {
+					id
+				}
+			}
+		}
+	`
+
+	deleteResp := app.executeGraphQL(t, deleteQuery, map[string]interface{}{"id": exampleID})
+	require.Empty(t, deleteResp.Errors)
+	assert.Equal(t, senseID, extractString(t, deleteResp.Data, "deleteExample", "id"))
+	examples = extractArray(t, deleteResp.Data, "deleteExample", "examples")
+	assert.Len(t, examples, 1)
+}
+
+// TestAddAndDeleteTranslations tests addTranslations and deleteTranslation mutations.
+func TestAddAndDeleteTranslations(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	_, senseID := createTestWord(t, app, "house")
+
+	addQuery := `
+		mutation($senseId: UUID!, $translations: [TranslationInput!]!) {
+			addTranslations(senseId: $senseId, translations: $translations) {
+				id
+				translations {
+					id
+					text
+				}
+			}
+		}
+	`
+
+	addResp := app.executeGraphQL(t, addQuery, map[string]interface{}{
+		"senseId": senseID,
+		"translations": []map[string]interface{}{
+			{"text": "дом", "sourceSlug": "user"},
+		},
+	})
+	require.Empty(t, addResp.Errors)
+	translations := extractArray(t, addResp.Data, "addTranslations", "translations")
+	require.Len(t, translations, 1)
+	assert.Equal(t, "дом", translations[0].(map[string]interface{})["text"])
+
+	deleteQuery := `
+		mutation($id: UUID!) {
+			deleteTranslation(id: $id) {
+				id
+				translations {
+					id
+				}
+			}
+		}
+	`
+
+	deleteResp := app.executeGraphQL(t, deleteQuery, map[string]interface{}{
+		"id": translations[0].(map[string]interface{})["id"],
+	})
+	require.Empty(t, deleteResp.Errors)
+	assert.Equal(t, senseID, extractString(t, deleteResp.Data, "deleteTranslation", "id"))
+	assert.Empty(t, extractArray(t, deleteResp.Data, "deleteTranslation", "translations"))
+}
+
+// TestAddAndDeleteImages tests addImages and deleteImage mutations.
+func TestAddAndDeleteImages(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, _ := createTestWord(t, app, "cat")
+
+	addQuery := `
+		mutation($entryId: UUID!, $images: [ImageInput!]!) {
+			addImages(entryId: $entryId, images: $images) {
+				id
+				images {
+					id
+					url
+				}
+			}
+		}
+	`
+
+	addResp := app.executeGraphQL(t, addQuery, map[string]interface{}{
+		"entryId": entryID,
+		"images": []map[string]interface{}{
+			{"url": "https://example.com/cat.jpg", "sourceSlug": "user"},
+		},
+	})
+	require.Empty(t, addResp.Errors)
+	images := extractArray(t, addResp.Data, "addImages", "images")
+	require.Len(t, images, 1)
+
+	deleteQuery := `
+		mutation($id: UUID!) {
+			deleteImage(id: $id) {
+				id
+				images {
+					id
+				}
+			}
+		}
+	`
+
+	deleteResp := app.executeGraphQL(t, deleteQuery, map[string]interface{}{
+		"id": images[0].(map[string]interface{})["id"],
+	})
+	require.Empty(t, deleteResp.Errors)
+	assert.Equal(t, entryID, extractString(t, deleteResp.Data, "deleteImage", "id"))
+	assert.Empty(t, extractArray(t, deleteResp.Data, "deleteImage", "images"))
+}
+
+// TestAddAndDeletePronunciations tests addPronunciations and deletePronunciation mutations.
+func TestAddAndDeletePronunciations(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, _ := createTestWord(t, app, "tomato")
+
+	addQuery := `
+		mutation($entryId: UUID!, $pronunciations: [PronunciationInput!]!) {
+			addPronunciations(entryId: $entryId, pronunciations: $pronunciations) {
+				id
+				pronunciations {
+					id
+					region
+				}
+			}
+		}
+	`
+
+	addResp := app.executeGraphQL(t, addQuery, map[string]interface{}{
+		"entryId": entryID,
+		"pronunciations": []map[string]interface{}{
+			{"audioUrl": "https://example.com/us.mp3", "region": "US", "sourceSlug": "user"},
+			{"audioUrl": "https://example.com/uk.mp3", "region": "UK", "sourceSlug": "user"},
+		},
+	})
+	require.Empty(t, addResp.Errors)
+	pronunciations := extractArray(t, addResp.Data, "addPronunciations", "pronunciations")
+	require.Len(t, pronunciations, 2)
+
+	deleteQuery := `
+		mutation($id: UUID!) {
+			deletePronunciation(id: $id) {
+				id
+				pronunciations {
+					id
+				}
+			}
+		}
+	`
+
+	deleteResp := app.executeGraphQL(t, deleteQuery, map[string]interface{}{
+		"id": pronunciations[0].(map[string]interface{})["id"],
+	})
+	require.Empty(t, deleteResp.Errors)
+	assert.Equal(t, entryID, extractString(t, deleteResp.Data, "deletePronunciation", "id"))
+	assert.Len(t, extractArray(t, deleteResp.Data, "deletePronunciation", "pronunciations"), 1)
+}
+
+// TestDeleteNonExistentContent tests that granular deletes return not found errors.
+func TestDeleteNonExistentContent(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	mutations := []string{"deleteSense", "deleteExample", "deleteTranslation", "deleteImage", "deletePronunciation"}
+	for _, m := range mutations {
+		query := `mutation($id: UUID!) { ` + m + `(id: $id) { id } }`
+		resp := app.executeGraphQLWithError(t, query, map[string]interface{}{
+			"id": "00000000-0000-0000-0000-000000000001",
+		})
+		require.NotEmpty(t, resp.Errors, "Expected error for %s", m)
+	}
+}
@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bookPath is an EPUB fixture "A Rainy Day" with two chapters, a cover
+// without text and a non-linear notes page.
+const bookPath = "../../../pkg/epub/testdata/book.epub"
+
+const analyzeBookCoverageMutation = `
+	mutation($file: Upload!, $input: BookCoverageInput) {
+		analyzeBookCoverage(file: $file, input: $input) {
+			title author tokens knownTokens functionTokens coverage
+			words knownWords learningWords unknownWords addedToInbox
+			candidates { lemma count context examples }
+		}
+	}
+`
+
+// TestBookCoverage tests EPUB coverage by learned words, unknown words with examples and pushing them to the inbox.
+func TestBookCoverage(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	book, err := os.ReadFile(bookPath)
+	require.NoError(t, err)
+
+	// "weather" is learned, "rain" is in the dictionary without a card
+	weatherID, _ := createTestWord(t, app, "weather")
+	createTestWord(t, app, "rain")
+	resp := app.executeGraphQL(t, `mutation($ids: [UUID!]) { bulkCreateCards(ids: $ids) { affected } }`,
+		map[string]interface{}{"ids": []string{weatherID}})
+	require.Empty(t, resp.Errors)
+	_, err = app.pool.Exec(context.Background(), `UPDATE cards SET status = 'REVIEW' WHERE entry_id = $1`, weatherID)
+	require.NoError(t, err)
+
+	resp = app.executeUpload(t, analyzeBookCoverageMutation, map[string]interface{}{
+		"input": map[string]interface{}{"limit": 2},
+	}, "book.epub", "application/epub+zip", book)
+	require.Empty(t, resp.Errors)
+
+	result := extractObject(t, resp.Data, "analyzeBookCoverage")
+	assert.Equal(t, "A Rainy Day", result["title"])
+	assert.Equal(t, "Jane Doe, John Roe", result["author"])
+	assert.EqualValues(t, 36, result["tokens"])
+	assert.EqualValues(t, 1, result["knownTokens"])
+	assert.EqualValues(t, 15, result["functionTokens"])
+	assert.InDelta(t, 16.0/36*100, result["coverage"], 0.001)
+	assert.EqualValues(t, 17, result["words"])
+	assert.EqualValues(t, 1, result["knownWords"])
+	assert.EqualValues(t, 1, result["learningWords"])
+	assert.EqualValues(t, 15, result["unknownWords"])
+	assert.EqualValues(t, 0, result["addedToInbox"])
+
+	// Equal scores are ordered by lemma
+	candidates := extractArray(t, resp.Data, "analyzeBookCoverage", "candidates")
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "chapter", candidates[0].(map[string]interface{})["lemma"])
+	melancholy := candidates[1].(map[string]interface{})
+	assert.Equal(t, "melancholy", melancholy["lemma"])
+	assert.EqualValues(t, 2, melancholy["count"])
+	assert.Equal(t, []interface{}{
+		"Poor Elizabeth felt a strange melancholy.",
+		"The melancholy lingered, and the rain returned.",
+	}, melancholy["examples"])
+
+	// One click pushes the listed words to the inbox, once
+	for _, added := range []int{2, 0} {
+		resp = app.executeUpload(t, analyzeBookCoverageMutation, map[string]interface{}{
+			"input": map[string]interface{}{"limit": 2, "addToInbox": true},
+		}, "book.epub", "application/epub+zip", book)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, added, extractInt(t, resp.Data, "analyzeBookCoverage", "addedToInbox"))
+	}
+
+	listResp := app.executeGraphQL(t, `query { inboxItems { text context } }`, nil)
+	require.Empty(t, listResp.Errors)
+	items := make(map[string]interface{})
+	for _, item := range extractArray(t, listResp.Data, "inboxItems") {
+		m := item.(map[string]interface{})
+		items[m["text"].(string)] = m["context"]
+	}
+	require.Len(t, items, 2)
+	assert.Equal(t, "Poor Elizabeth felt a strange melancholy. — A Rainy Day\n"+
+		"The melancholy lingered, and the rain returned. — A Rainy Day", items["melancholy"])
+}
+
+// TestBookCoverageInvalidFile tests that files other than EPUB are rejected.
+func TestBookCoverageInvalidFile(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeUpload(t, analyzeBookCoverageMutation, nil, "book.epub", "application/epub+zip", []byte("not a book"))
+	require.NotEmpty(t, resp.Errors)
+
+	data, err := os.ReadFile("../../../pkg/apkg/testdata/deck.apkg")
+	require.NoError(t, err)
+	resp = app.executeUpload(t, analyzeBookCoverageMutation, nil, "book.epub", "application/epub+zip", data)
+	require.NotEmpty(t, resp.Errors)
+}
@@ -0,0 +1,272 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const previewCSVImportMutation = `
+	mutation($file: Upload!, $input: CsvImportInput!) {
+		previewCsvImport(file: $file, input: $input) {
+			rows { line text action message }
+			new merge skip error
+		}
+	}
+`
+
+const startCSVImportMutation = `
+	mutation($file: Upload!, $input: CsvImportInput!) {
+		startCsvImport(file: $file, input: $input) {
+			id status totalRows processedRows created merged skipped failed
+			rowErrors { line message }
+		}
+	}
+`
+
+const csvImportJobQuery = `
+	query($id: UUID!) {
+		csvImportJob(id: $id) {
+			id status totalRows processedRows created merged skipped failed
+			rowErrors { line message }
+			error finishedAt
+		}
+	}
+`
+
+// csvImportFile covers every row action:
+//   - line 2: "apple" already exists in the dictionary;
+//   - lines 3-4: a new word with a multi-line example;
+//   - line 5: a duplicate of line 3 without content;
+//   - line 6: an unknown part of speech;
+//   - line 7: an empty word;
+//   - line 8: a new word.
+const csvImportFile = "Word,Meaning,POS,Translation,Example\n" +
+	"apple,round fruit,n,яблоко,An apple a day.\n" +
+	"serendipity,a happy accident,noun,счастливая случайность; интуиция,\"Pure serendipity.\nIt was serendipity.\"\n" +
+	"Serendipity,,,,\n" +
+	"run,,xyz,бежать,\n" +
+	",orphan definition,,,\n" +
+	"ephemeral,lasting a short time,adj.,,\n"
+
+// csvImportMapping maps the columns of csvImportFile by header name and number.
+var csvImportMapping = map[string]interface{}{
+	"text":         "word",
+	"definition":   "Meaning",
+	"partOfSpeech": "pos",
+	"translation":  "4",
+	"example":      "Example",
+}
+
+// processImports runs the background import until no unfinished jobs are left.
+func processImports(t *testing.T, app *testApp) {
+	t.Helper()
+	for {
+		processed, err := app.services.CSVImport.ProcessPending(context.Background())
+		require.NoError(t, err)
+		if !processed {
+			return
+		}
+	}
+}
+
+// TestCSVImportPreview tests that a dry run reports the action for every row and writes nothing.
+func TestCSVImportPreview(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "apple")
+
+	resp := app.executeUpload(t, previewCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{"mapping": csvImportMapping},
+	}, "words.csv", "text/csv", []byte(csvImportFile))
+	require.Empty(t, resp.Errors)
+
+	preview := extractObject(t, resp.Data, "previewCsvImport")
+	assert.Equal(t, float64(2), preview["new"])
+	assert.Equal(t, float64(0), preview["merge"])
+	assert.Equal(t, float64(2), preview["skip"])
+	assert.Equal(t, float64(2), preview["error"])
+
+	rows := preview["rows"].([]interface{})
+	require.Len(t, rows, 6)
+	expected := []struct {
+		line   float64
+		text   string
+		action string
+	}{
+		{2, "apple", "SKIP"},
+		{3, "serendipity", "NEW"},
+		{5, "Serendipity", "SKIP"},
+		{6, "run", "ERROR"},
+		{7, "", "ERROR"},
+		{8, "ephemeral", "NEW"},
+	}
+	for i, want := range expected {
+		row := rows[i].(map[string]interface{})
+		assert.Equal(t, want.line, row["line"], "row %d", i)
+		assert.Equal(t, want.text, row["text"], "row %d", i)
+		assert.Equal(t, want.action, row["action"], "row %d", i)
+	}
+	assert.Equal(t, "duplicate of line 3", rows[2].(map[string]interface{})["message"])
+	assert.Contains(t, rows[3].(map[string]interface{})["message"], "unknown part of speech")
+	assert.Nil(t, rows[1].(map[string]interface{})["message"])
+
+	// Nothing is written
+	resp = app.executeGraphQL(t, `query { dictionary { text } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.Len(t, extractArray(t, resp.Data, "dictionary"), 1)
+
+	// Merge policy: the existing word gets a sense, an in-file duplicate without content is still skipped
+	resp = app.executeUpload(t, previewCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{"mapping": csvImportMapping, "duplicates": "MERGE"},
+	}, "words.csv", "text/csv", []byte(csvImportFile))
+	require.Empty(t, resp.Errors)
+	preview = extractObject(t, resp.Data, "previewCsvImport")
+	assert.Equal(t, float64(1), preview["merge"])
+	assert.Equal(t, float64(1), preview["skip"])
+	assert.Equal(t, "MERGE", preview["rows"].([]interface{})[0].(map[string]interface{})["action"])
+}
+
+// TestCSVImport tests starting an import, processing it in the background and querying the progress.
+func TestCSVImport(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "apple")
+
+	resp := app.executeUpload(t, startCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"mapping":     csvImportMapping,
+			"duplicates":  "MERGE",
+			"createCards": true,
+		},
+	}, "words.csv", "text/csv", []byte(csvImportFile))
+	require.Empty(t, resp.Errors)
+
+	job := extractObject(t, resp.Data, "startCsvImport")
+	jobID := job["id"].(string)
+	assert.Equal(t, "PENDING", job["status"])
+	assert.Equal(t, float64(3), job["totalRows"], "NEW and MERGE rows are written")
+	assert.Equal(t, float64(0), job["processedRows"])
+	assert.Equal(t, float64(1), job["skipped"])
+	assert.Equal(t, float64(2), job["failed"])
+	assert.Len(t, job["rowErrors"], 2)
+
+	processImports(t, app)
+
+	resp = app.executeGraphQL(t, csvImportJobQuery, map[string]interface{}{"id": jobID})
+	require.Empty(t, resp.Errors)
+	job = extractObject(t, resp.Data, "csvImportJob")
+	assert.Equal(t, "COMPLETED", job["status"])
+	assert.Equal(t, float64(3), job["processedRows"])
+	assert.Equal(t, float64(2), job["created"])
+	assert.Equal(t, float64(1), job["merged"])
+	assert.Equal(t, float64(1), job["skipped"])
+	assert.Equal(t, float64(2), job["failed"])
+	assert.Nil(t, job["error"])
+	assert.NotNil(t, job["finishedAt"])
+	rowErrors := job["rowErrors"].([]interface{})
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, float64(6), rowErrors[0].(map[string]interface{})["line"])
+	assert.Equal(t, float64(7), rowErrors[1].(map[string]interface{})["line"])
+
+	// New word with every mapped field
+	query := `
+		query($search: String!) {
+			dictionary(filter: { search: $search }) {
+				text
+				senses {
+					definition partOfSpeech sourceSlug
+					translations { text language }
+					examples { sentence }
+				}
+				card { status }
+			}
+		}
+	`
+	resp = app.executeGraphQL(t, query, map[string]interface{}{"search": "serendipity"})
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	word := words[0].(map[string]interface{})
+	assert.NotNil(t, word["card"])
+	senses := word["senses"].([]interface{})
+	require.Len(t, senses, 1)
+	sense := senses[0].(map[string]interface{})
+	assert.Equal(t, "a happy accident", sense["definition"])
+	assert.Equal(t, "NOUN", sense["partOfSpeech"])
+	assert.Equal(t, "csv", sense["sourceSlug"])
+	translations := sense["translations"].([]interface{})
+	require.Len(t, translations, 2)
+	assert.Equal(t, "счастливая случайность", translations[0].(map[string]interface{})["text"])
+	assert.Equal(t, "ru", translations[0].(map[string]interface{})["language"])
+	assert.Len(t, sense["examples"], 2)
+
+	// Existing word got a second sense
+	resp = app.executeGraphQL(t, query, map[string]interface{}{"search": "apple"})
+	require.Empty(t, resp.Errors)
+	words = extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	senses = words[0].(map[string]interface{})["senses"].([]interface{})
+	require.Len(t, senses, 2)
+
+	// Importing the same file again only skips
+	resp = app.executeUpload(t, startCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{"mapping": csvImportMapping},
+	}, "words.csv", "text/csv", []byte(csvImportFile))
+	require.Empty(t, resp.Errors)
+	job = extractObject(t, resp.Data, "startCsvImport")
+	assert.Equal(t, float64(0), job["totalRows"])
+	assert.Equal(t, float64(4), job["skipped"])
+
+	processImports(t, app)
+	resp = app.executeGraphQL(t, csvImportJobQuery, map[string]interface{}{"id": job["id"]})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, "COMPLETED", extractString(t, resp.Data, "csvImportJob", "status"))
+}
+
+// TestCSVImportTSV tests a headerless TSV file mapped by column numbers.
+func TestCSVImportTSV(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	file := "hello\tпривет\n\"quoted\" word\tслово\n"
+	resp := app.executeUpload(t, previewCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"format":    "TSV",
+			"hasHeader": false,
+			"mapping":   map[string]interface{}{"text": "1", "translation": "2"},
+		},
+	}, "words.tsv", "text/tab-separated-values", []byte(file))
+	require.Empty(t, resp.Errors)
+
+	rows := extractArray(t, resp.Data, "previewCsvImport", "rows")
+	require.Len(t, rows, 2)
+	assert.Equal(t, "hello", rows[0].(map[string]interface{})["text"])
+	assert.Equal(t, `"quoted" word`, rows[1].(map[string]interface{})["text"], "Quotes are literal in TSV")
+	assert.Equal(t, "NEW", rows[1].(map[string]interface{})["action"])
+}
+
+// TestCSVImportInvalidInput tests that a mapping not matching the file and an unknown job are rejected.
+func TestCSVImportInvalidInput(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeUpload(t, previewCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{"mapping": map[string]interface{}{"text": "lemma"}},
+	}, "words.csv", "text/csv", []byte(csvImportFile))
+	require.NotEmpty(t, resp.Errors)
+	assert.Contains(t, resp.Errors[0].Message, "lemma")
+
+	resp = app.executeUpload(t, startCSVImportMutation, map[string]interface{}{
+		"input": map[string]interface{}{"mapping": csvImportMapping},
+	}, "words.csv", "text/csv", []byte{})
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeGraphQL(t, csvImportJobQuery, map[string]interface{}{"id": uuid.NewString()})
+	require.NotEmpty(t, resp.Errors)
+}
@@ -0,0 +1,341 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const historyAuditLogQuery = `
+	query($id: UUID!, $entityType: EntityType) {
+		dictionaryEntry(id: $id) {
+			auditLog(entityType: $entityType) {
+				entityType
+				entityId
+				entryId
+				action
+				createdAt
+			}
+		}
+	}
+`
+
+// TestAuditLog tests that the entry audit log includes child entity records.
+func TestAuditLog(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, senseID := createTestWord(t, app, "history")
+
+	deleteSenseResp := app.executeGraphQL(t, `
+		mutation($id: UUID!) {
+			deleteSense(id: $id) { id }
+		}
+	`, map[string]interface{}{"id": senseID})
+	require.Empty(t, deleteSenseResp.Errors)
+
+	resp := app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, resp.Errors)
+	records := extractArray(t, resp.Data, "dictionaryEntry", "auditLog")
+	require.NotEmpty(t, records)
+
+	entityTypes := make(map[string]bool)
+	for _, r := range records {
+		record := r.(map[string]interface{})
+		assert.Equal(t, entryID, record["entryId"])
+		entityTypes[record["entityType"].(string)] = true
+	}
+	assert.True(t, entityTypes["ENTRY"], "Entry records should be included")
+	assert.True(t, entityTypes["SENSE"], "Sense records should be included")
+
+	// Newest first: word creation is the oldest record
+	last := records[len(records)-1].(map[string]interface{})
+	assert.Equal(t, "ENTRY", last["entityType"])
+	assert.Equal(t, "CREATE", last["action"])
+
+	// Filter by entity type
+	resp = app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{
+		"id":         entryID,
+		"entityType": "SENSE",
+	})
+	require.Empty(t, resp.Errors)
+	senseRecords := extractArray(t, resp.Data, "dictionaryEntry", "auditLog")
+	require.Len(t, senseRecords, 1)
+	assert.Equal(t, senseID, senseRecords[0].(map[string]interface{})["entityId"])
+}
+
+// TestRestoreWordVersion tests rebuilding word content from an earlier audit snapshot.
+func TestRestoreWordVersion(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createResp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "version"
+				senses: [{
+					definition: "a particular form of something"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+					translations: [{ text: "версия", sourceSlug: "user" }]
+				}]
+			}) {
+				id
+				senses { id }
+			}
+		}
+	`, nil)
+	require.Empty(t, createResp.Errors)
+	entryID := extractString(t, createResp.Data, "createWord", "id")
+	senses := extractArray(t, createResp.Data, "createWord", "senses")
+	require.Len(t, senses, 1)
+	senseID := senses[0].(map[string]interface{})["id"].(string)
+
+	// Version to restore: the moment the word was created
+	logResp := app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{
+		"id":         entryID,
+		"entityType": "ENTRY",
+	})
+	require.Empty(t, logResp.Errors)
+	records := extractArray(t, logResp.Data, "dictionaryEntry", "auditLog")
+	require.Len(t, records, 1)
+	createdAt := records[0].(map[string]interface{})["createdAt"]
+
+	updateResp := app.executeGraphQL(t, `
+		mutation($id: UUID!, $input: UpdateWordInput!) {
+			updateWord(id: $id, input: $input) { id }
+		}
+	`, map[string]interface{}{
+		"id": entryID,
+		"input": map[string]interface{}{
+			"text":           "versions",
+			"deleteSenseIds": []string{senseID},
+			"senses": []map[string]interface{}{
+				{"definition": "an account from one point of view", "partOfSpeech": "NOUN", "sourceSlug": "user"},
+			},
+		},
+	})
+	require.Empty(t, updateResp.Errors)
+
+	restoreResp := app.executeGraphQL(t, `
+		mutation($entryId: UUID!, $at: Time!) {
+			restoreWordVersion(entryId: $entryId, at: $at) {
+				id
+				text
+				senses {
+					definition
+					translations { text }
+				}
+			}
+		}
+	`, map[string]interface{}{"entryId": entryID, "at": createdAt})
+	require.Empty(t, restoreResp.Errors)
+	assert.Equal(t, "version", extractString(t, restoreResp.Data, "restoreWordVersion", "text"))
+
+	restored := extractArray(t, restoreResp.Data, "restoreWordVersion", "senses")
+	require.Len(t, restored, 1)
+	sense := restored[0].(map[string]interface{})
+	assert.Equal(t, "a particular form of something", sense["definition"])
+	require.Len(t, sense["translations"], 1)
+
+	// Restoring is itself recorded in history
+	logResp = app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{
+		"id":         entryID,
+		"entityType": "ENTRY",
+	})
+	require.Empty(t, logResp.Errors)
+	assert.Len(t, extractArray(t, logResp.Data, "dictionaryEntry", "auditLog"), 3)
+}
+
+// TestRestoreWordVersionRelations tests that restoring a version brings back sense relations.
+func TestRestoreWordVersionRelations(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createResp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "cold"
+				senses: [
+					{
+						definition: "having a low temperature"
+						sourceSlug: "user"
+						relations: [{ type: ANTONYM, targetText: "hot", sourceSlug: "user" }]
+					}
+					{
+						definition: "unfriendly"
+						sourceSlug: "user"
+						relations: [{ type: SYNONYM, targetText: "distant", sourceSlug: "user" }]
+					}
+				]
+			}) {
+				id
+				senses { id definition relations { id targetText } }
+			}
+		}
+	`, nil)
+	require.Empty(t, createResp.Errors)
+	entryID := extractString(t, createResp.Data, "createWord", "id")
+	senses := extractArray(t, createResp.Data, "createWord", "senses")
+	require.Len(t, senses, 2)
+	keptSense := senses[0].(map[string]interface{})
+	deletedSenseID := senses[1].(map[string]interface{})["id"].(string)
+	hotID := keptSense["relations"].([]interface{})[0].(map[string]interface{})["id"].(string)
+
+	logResp := app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{
+		"id":         entryID,
+		"entityType": "ENTRY",
+	})
+	require.Empty(t, logResp.Errors)
+	records := extractArray(t, logResp.Data, "dictionaryEntry", "auditLog")
+	require.Len(t, records, 1)
+	createdAt := records[0].(map[string]interface{})["createdAt"]
+
+	// Change relations of the kept sense and delete the other sense
+	resp := app.executeGraphQL(t, `
+		mutation($senseId: UUID!) {
+			addRelations(senseId: $senseId, relations: [{ type: RELATED, targetText: "ice", sourceSlug: "user" }]) { id }
+		}
+	`, map[string]interface{}{"senseId": keptSense["id"]})
+	require.Empty(t, resp.Errors)
+	resp = app.executeGraphQL(t, `mutation($id: UUID!) { deleteRelation(id: $id) { id } }`,
+		map[string]interface{}{"id": hotID})
+	require.Empty(t, resp.Errors)
+	resp = app.executeGraphQL(t, `
+		mutation($id: UUID!, $input: UpdateWordInput!) {
+			updateWord(id: $id, input: $input) { id }
+		}
+	`, map[string]interface{}{
+		"id":    entryID,
+		"input": map[string]interface{}{"text": "cold", "deleteSenseIds": []string{deletedSenseID}},
+	})
+	require.Empty(t, resp.Errors)
+
+	restoreResp := app.executeGraphQL(t, `
+		mutation($entryId: UUID!, $at: Time!) {
+			restoreWordVersion(entryId: $entryId, at: $at) {
+				senses { id definition relations { type targetText } }
+			}
+		}
+	`, map[string]interface{}{"entryId": entryID, "at": createdAt})
+	require.Empty(t, restoreResp.Errors)
+
+	restored := extractArray(t, restoreResp.Data, "restoreWordVersion", "senses")
+	require.Len(t, restored, 2)
+	relationsByDefinition := make(map[string][]interface{})
+	for _, r := range restored {
+		sense := r.(map[string]interface{})
+		relationsByDefinition[sense["definition"].(string)] = sense["relations"].([]interface{})
+	}
+
+	kept := relationsByDefinition["having a low temperature"]
+	require.Len(t, kept, 1, "The added relation is removed and the deleted one recreated")
+	assert.Equal(t, "ANTONYM", kept[0].(map[string]interface{})["type"])
+	assert.Equal(t, "hot", kept[0].(map[string]interface{})["targetText"])
+
+	recreated := relationsByDefinition["unfriendly"]
+	require.Len(t, recreated, 1, "A recreated sense gets its relations back")
+	assert.Equal(t, "SYNONYM", recreated[0].(map[string]interface{})["type"])
+	assert.Equal(t, "distant", recreated[0].(map[string]interface{})["targetText"])
+
+	// The restore record lists changed relations
+	logResp = app.executeGraphQL(t, `
+		query($id: UUID!) {
+			dictionaryEntry(id: $id) { auditLog(entityType: ENTRY) { changes } }
+		}
+	`, map[string]interface{}{"id": entryID})
+	require.Empty(t, logResp.Errors)
+	records = extractArray(t, logResp.Data, "dictionaryEntry", "auditLog")
+	changes := records[0].(map[string]interface{})["changes"].(map[string]interface{})
+	assert.Equal(t, "version_restored", changes["action"])
+	assert.Len(t, changes["relations_created"], 2)
+	assert.Len(t, changes["relations_deleted"], 1)
+}
+
+// TestRestoreWordVersionRollback tests that a failed restore leaves the word unchanged.
+func TestRestoreWordVersionRollback(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+	ctx := context.Background()
+
+	createResp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "draft"
+				senses: [{ definition: "a preliminary version", partOfSpeech: NOUN, sourceSlug: "user" }]
+			}) {
+				id
+				senses { id }
+			}
+		}
+	`, nil)
+	require.Empty(t, createResp.Errors)
+	entryID := extractString(t, createResp.Data, "createWord", "id")
+	senseID := extractArray(t, createResp.Data, "createWord", "senses")[0].(map[string]interface{})["id"].(string)
+
+	logResp := app.executeGraphQL(t, historyAuditLogQuery, map[string]interface{}{
+		"id":         entryID,
+		"entityType": "ENTRY",
+	})
+	require.Empty(t, logResp.Errors)
+	createdAt := extractArray(t, logResp.Data, "dictionaryEntry", "auditLog")[0].(map[string]interface{})["createdAt"]
+
+	updateResp := app.executeGraphQL(t, `
+		mutation($id: UUID!, $input: UpdateWordInput!) {
+			updateWord(id: $id, input: $input) { id }
+		}
+	`, map[string]interface{}{
+		"id":    entryID,
+		"input": map[string]interface{}{"text": "drafts", "deleteSenseIds": []string{senseID}},
+	})
+	require.Empty(t, updateResp.Errors)
+
+	// The text is restored first; recreating the sense then fails on an invalid part of speech
+	_, err := app.pool.Exec(ctx, `
+		UPDATE audit_records
+		SET snapshot = jsonb_set(snapshot, '{senses,0,part_of_speech}', '"BOGUS"')
+		WHERE id = (
+			SELECT id FROM audit_records
+			WHERE entry_id = $1 AND snapshot IS NOT NULL
+			ORDER BY created_at, id
+			LIMIT 1
+		)
+	`, entryID)
+	require.NoError(t, err)
+
+	var auditBefore int
+	require.NoError(t, app.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_records WHERE entry_id = $1`, entryID).Scan(&auditBefore))
+
+	resp := app.executeGraphQLWithError(t, `
+		mutation($entryId: UUID!, $at: Time!) {
+			restoreWordVersion(entryId: $entryId, at: $at) { id }
+		}
+	`, map[string]interface{}{"entryId": entryID, "at": createdAt})
+	require.NotEmpty(t, resp.Errors)
+
+	var text string
+	var senses, auditAfter int
+	require.NoError(t, app.pool.QueryRow(ctx, `SELECT text FROM dictionary_entries WHERE id = $1`, entryID).Scan(&text))
+	require.NoError(t, app.pool.QueryRow(ctx, `SELECT COUNT(*) FROM senses WHERE entry_id = $1`, entryID).Scan(&senses))
+	require.NoError(t, app.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_records WHERE entry_id = $1`, entryID).Scan(&auditAfter))
+	assert.Equal(t, "drafts", text)
+	assert.Zero(t, senses)
+	assert.Equal(t, auditBefore, auditAfter)
+}
+
+// TestRestoreWordVersionWithoutSnapshot tests that restoring to a time before the word existed fails.
+func TestRestoreWordVersionWithoutSnapshot(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, _ := createTestWord(t, app, "ancient")
+
+	resp := app.executeGraphQLWithError(t, `
+		mutation($entryId: UUID!, $at: Time!) {
+			restoreWordVersion(entryId: $entryId, at: $at) { id }
+		}
+	`, map[string]interface{}{"entryId": entryID, "at": "2000-01-01T00:00:00Z"})
+	require.NotEmpty(t, resp.Errors)
+}
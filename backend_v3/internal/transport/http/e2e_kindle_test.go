@@ -0,0 +1,101 @@
+package http_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kindleVocabPath is a vocab.db fixture with lookups from two books:
+//   - "civility" and "countenance" (two sentences) from Pride and Prejudice;
+//   - "gloom" (mastered) and "boats" (stem "boat") from The Great Gatsby;
+//   - "Zeitgeist" (de) from a book missing in BOOK_INFO, without a sentence;
+//   - "colour" (en-GB) without lookups.
+const kindleVocabPath = "../../../pkg/kindlevocab/testdata/vocab.db"
+
+const importKindleMutation = `
+	mutation($file: Upload!, $input: KindleImportInput) {
+		importKindleVocab(file: $file, input: $input) {
+			lookups newLookups imported duplicates mastered importedUntil
+		}
+	}
+`
+
+// TestKindleImport tests importing vocab.db into the inbox, duplicate skipping and incremental re-imports.
+func TestKindleImport(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	vocab, err := os.ReadFile(kindleVocabPath)
+	require.NoError(t, err)
+
+	// "boats" is skipped by its stem, "civility" — by the inbox item in another case
+	createTestWord(t, app, "boat")
+	inboxResp := app.executeGraphQL(t, `mutation { addToInbox(text: "Civility") { id } }`, nil)
+	require.Empty(t, inboxResp.Errors)
+
+	resp := app.executeUpload(t, importKindleMutation, nil, "vocab.db", "application/octet-stream", vocab)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 7, extractInt(t, resp.Data, "importKindleVocab", "lookups"))
+	assert.Equal(t, 7, extractInt(t, resp.Data, "importKindleVocab", "newLookups"))
+	assert.Equal(t, 3, extractInt(t, resp.Data, "importKindleVocab", "imported"))
+	assert.Equal(t, 2, extractInt(t, resp.Data, "importKindleVocab", "duplicates"))
+	assert.Equal(t, 1, extractInt(t, resp.Data, "importKindleVocab", "mastered"))
+	importedUntil, err := time.Parse(time.RFC3339, extractString(t, resp.Data, "importKindleVocab", "importedUntil"))
+	require.NoError(t, err)
+	assert.True(t, importedUntil.Equal(time.UnixMilli(1700000500000)), "importedUntil = %v", importedUntil)
+
+	listResp := app.executeGraphQL(t, `query { inboxItems { id text context } }`, nil)
+	require.Empty(t, listResp.Errors)
+	items := make(map[string]map[string]interface{})
+	for _, item := range extractArray(t, listResp.Data, "inboxItems") {
+		m := item.(map[string]interface{})
+		items[m["text"].(string)] = m
+	}
+	require.Len(t, items, 4)
+	require.Contains(t, items, "countenance")
+	assert.Equal(t, "His countenance was grave. — Pride and Prejudice\nHer countenance brightened. — Pride and Prejudice",
+		items["countenance"]["context"])
+	require.Contains(t, items, "Zeitgeist")
+	assert.Nil(t, items["Zeitgeist"]["context"])
+	assert.Contains(t, items, "colour")
+	assert.NotContains(t, items, "gloom")
+
+	// The same file again adds nothing
+	resp = app.executeUpload(t, importKindleMutation, nil, "vocab.db", "application/octet-stream", vocab)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 0, extractInt(t, resp.Data, "importKindleVocab", "newLookups"))
+	assert.Equal(t, 0, extractInt(t, resp.Data, "importKindleVocab", "imported"))
+	assert.Equal(t, importedUntil.Format(time.RFC3339), extractString(t, resp.Data, "importKindleVocab", "importedUntil"))
+
+	// A full re-import brings back a deleted item and skips the rest
+	deleteResp := app.executeGraphQL(t, `mutation($id: UUID!) { deleteInboxItem(id: $id) }`,
+		map[string]interface{}{"id": items["countenance"]["id"]})
+	require.Empty(t, deleteResp.Errors)
+
+	resp = app.executeUpload(t, importKindleMutation, map[string]interface{}{
+		"input": map[string]interface{}{"full": true},
+	}, "vocab.db", "application/octet-stream", vocab)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, 7, extractInt(t, resp.Data, "importKindleVocab", "newLookups"))
+	assert.Equal(t, 1, extractInt(t, resp.Data, "importKindleVocab", "imported"))
+	assert.Equal(t, 4, extractInt(t, resp.Data, "importKindleVocab", "duplicates"))
+	assert.Equal(t, 1, extractInt(t, resp.Data, "importKindleVocab", "mastered"))
+}
+
+// TestKindleImportInvalidFile tests that files other than vocab.db are rejected.
+func TestKindleImportInvalidFile(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeUpload(t, importKindleMutation, nil, "vocab.db", "application/octet-stream", []byte("not a database"))
+	require.NotEmpty(t, resp.Errors)
+
+	data, err := os.ReadFile("../../../pkg/sqlitefile/testdata/sample.db")
+	require.NoError(t, err)
+	resp = app.executeUpload(t, importKindleMutation, nil, "vocab.db", "application/octet-stream", data)
+	require.NotEmpty(t, resp.Errors)
+}
@@ -0,0 +1,136 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const languagesCreateWordMutation = `
+	mutation($text: String!, $language: String, $translation: String!, $translationLanguage: String) {
+		createWord(input: {
+			text: $text
+			language: $language
+			createCard: true
+			senses: [{
+				definition: "a definition"
+				sourceSlug: "user"
+				translations: [{ text: $translation, language: $translationLanguage, sourceSlug: "user" }]
+			}]
+		}) {
+			id
+			text
+			textNormalized
+			language
+			cefrLevel
+			senses { translations { text language } }
+		}
+	}
+`
+
+// TestWordLanguages tests per-language entries, normalization and language scoping.
+func TestWordLanguages(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	// English entry with the default languages
+	resp := app.executeGraphQL(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":        "Hand",
+		"translation": "рука",
+	})
+	require.Empty(t, resp.Errors)
+	entry := extractObject(t, resp.Data, "createWord")
+	assert.Equal(t, "en", entry["language"])
+	englishID := entry["id"].(string)
+	translations := extractArray(t, resp.Data, "createWord", "senses")[0].(map[string]interface{})["translations"].([]interface{})
+	assert.Equal(t, "ru", translations[0].(map[string]interface{})["language"])
+
+	// The same spelling in German is a separate entry
+	resp = app.executeGraphQL(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":                "Hand",
+		"language":            "de",
+		"translation":         "hand",
+		"translationLanguage": "en",
+	})
+	require.Empty(t, resp.Errors)
+	entry = extractObject(t, resp.Data, "createWord")
+	assert.Equal(t, "de", entry["language"])
+	assert.Nil(t, entry["cefrLevel"], "Word levels are only estimated for English")
+	germanID := entry["id"].(string)
+	translations = extractArray(t, resp.Data, "createWord", "senses")[0].(map[string]interface{})["translations"].([]interface{})
+	assert.Equal(t, "en", translations[0].(map[string]interface{})["language"])
+
+	// Duplicates are still rejected within one language
+	resp = app.executeGraphQLWithError(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":        "hand",
+		"language":    "de",
+		"translation": "рука",
+	})
+	require.NotEmpty(t, resp.Errors)
+
+	// Normalization is Unicode-aware: NFKC and diacritics folding for English
+	resp = app.executeGraphQL(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":        "Café",
+		"translation": "кафе",
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, "cafe", extractString(t, resp.Data, "createWord", "textNormalized"))
+
+	// German keeps umlauts
+	resp = app.executeGraphQL(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":        "Mädchen",
+		"language":    "de",
+		"translation": "девочка",
+	})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, "mädchen", extractString(t, resp.Data, "createWord", "textNormalized"))
+
+	// Filter by language
+	resp = app.executeGraphQL(t, `
+		query($filter: WordFilter) {
+			dictionary(filter: $filter) { id language }
+		}
+	`, map[string]interface{}{
+		"filter": map[string]interface{}{"language": "de"},
+	})
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 2)
+	for _, w := range words {
+		assert.Equal(t, "de", w.(map[string]interface{})["language"])
+	}
+
+	// Study queue scoped by language
+	_, err := app.pool.Exec(context.Background(), `UPDATE cards SET next_review_at = NOW() - INTERVAL '1 hour'`)
+	require.NoError(t, err)
+
+	studyQuery := `
+		query($language: String) {
+			studyQueue(language: $language) { id }
+		}
+	`
+	resp = app.executeGraphQL(t, studyQuery, map[string]interface{}{"language": "de"})
+	require.Empty(t, resp.Errors)
+	queue := extractArray(t, resp.Data, "studyQueue")
+	require.Len(t, queue, 2)
+	ids := []string{
+		queue[0].(map[string]interface{})["id"].(string),
+		queue[1].(map[string]interface{})["id"].(string),
+	}
+	assert.Contains(t, ids, germanID)
+	assert.NotContains(t, ids, englishID)
+
+	resp = app.executeGraphQL(t, studyQuery, nil)
+	require.Empty(t, resp.Errors)
+	assert.Len(t, extractArray(t, resp.Data, "studyQueue"), 4)
+
+	// Invalid language code
+	resp = app.executeGraphQLWithError(t, languagesCreateWordMutation, map[string]interface{}{
+		"text":        "hallo",
+		"language":    "German",
+		"translation": "привет",
+	})
+	require.NotEmpty(t, resp.Errors)
+}
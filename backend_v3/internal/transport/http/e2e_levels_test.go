@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWordLevelAnnotation tests that new entries get a frequency rank and CEFR estimate.
+func TestWordLevelAnnotation(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	// Inflected form is resolved through its lemma ("houses" -> "house")
+	resp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "Houses"
+				senses: [{ definition: "buildings for living in", partOfSpeech: NOUN, sourceSlug: "user" }]
+			}) {
+				id
+				frequencyRank
+				cefrLevel
+				senses { cefrLevel }
+			}
+		}
+	`, nil)
+	require.Empty(t, resp.Errors)
+	entry := extractObject(t, resp.Data, "createWord")
+	require.NotNil(t, entry["frequencyRank"])
+	require.NotNil(t, entry["cefrLevel"])
+	level := entry["cefrLevel"].(string)
+
+	// Senses without their own level inherit the estimate
+	senses := extractArray(t, resp.Data, "createWord", "senses")
+	require.Len(t, senses, 1)
+	assert.Equal(t, level, senses[0].(map[string]interface{})["cefrLevel"])
+
+	// Unknown words stay unannotated
+	unknownID, _ := createTestWord(t, app, "qwxzv")
+	resp = app.executeGraphQL(t, `
+		query($id: UUID!) {
+			dictionaryEntry(id: $id) { frequencyRank cefrLevel }
+		}
+	`, map[string]interface{}{"id": unknownID})
+	require.Empty(t, resp.Errors)
+	unknown := extractObject(t, resp.Data, "dictionaryEntry")
+	assert.Nil(t, unknown["frequencyRank"])
+	assert.Nil(t, unknown["cefrLevel"])
+
+	// Filter by level and frequency band
+	query := `
+		query($filter: WordFilter) {
+			dictionary(filter: $filter) { text }
+		}
+	`
+	resp = app.executeGraphQL(t, query, map[string]interface{}{
+		"filter": map[string]interface{}{"cefrLevels": []string{level}},
+	})
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	assert.Equal(t, "Houses", words[0].(map[string]interface{})["text"])
+
+	resp = app.executeGraphQL(t, query, map[string]interface{}{
+		"filter": map[string]interface{}{"minFrequencyRank": 1, "maxFrequencyRank": 5000},
+	})
+	require.Empty(t, resp.Errors)
+	assert.Len(t, extractArray(t, resp.Data, "dictionary"), 1)
+
+	// Invalid level is rejected
+	resp = app.executeGraphQLWithError(t, query, map[string]interface{}{
+		"filter": map[string]interface{}{"cefrLevels": []string{"D1"}},
+	})
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestVocabularyCoverage tests per-level coverage stats.
+func TestVocabularyCoverage(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "house")
+
+	resp := app.executeGraphQL(t, `
+		query {
+			vocabularyCoverage { level totalWords inDictionary known knownPercent }
+		}
+	`, nil)
+	require.Empty(t, resp.Errors)
+	levels := extractArray(t, resp.Data, "vocabularyCoverage")
+	require.Len(t, levels, 6)
+	assert.Equal(t, "A1", levels[0].(map[string]interface{})["level"])
+	assert.Equal(t, "C2", levels[5].(map[string]interface{})["level"])
+
+	inDictionary := 0
+	for _, l := range levels {
+		level := l.(map[string]interface{})
+		assert.Greater(t, level["totalWords"], float64(0))
+		assert.Equal(t, float64(0), level["known"], "No cards were reviewed")
+		inDictionary += int(level["inDictionary"].(float64))
+	}
+	assert.Equal(t, 1, inDictionary)
+}
@@ -0,0 +1,349 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/service/media"
+	"github.com/heartmarshall/my-english/internal/service/types"
+	"github.com/heartmarshall/my-english/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG is a PNG signature followed by arbitrary bytes; enough for content sniffing.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), []byte("fake image payload")...)
+
+// testMP3 is an ID3-tagged payload that sniffs as audio/mpeg.
+var testMP3 = append([]byte("ID3\x03\x00\x00\x00\x00\x00\x00"), []byte("fake audio payload")...)
+
+const uploadMediaMutation = `
+	mutation($file: Upload!) {
+		uploadMedia(file: $file) { id url contentType sizeBytes sha256 sourceUrl }
+	}
+`
+
+// uploadMedia sends uploadMedia as a multipart request per the GraphQL multipart request spec.
+func (app *testApp) uploadMedia(t *testing.T, filename, contentType string, data []byte) *graphQLResponse {
+	t.Helper()
+	return app.executeUpload(t, uploadMediaMutation, nil, filename, contentType, data)
+}
+
+// executeUpload sends a mutation with the file bound to variables.file as a multipart request.
+func (app *testApp) executeUpload(t *testing.T, query string, variables map[string]interface{}, filename, contentType string, data []byte) *graphQLResponse {
+	t.Helper()
+
+	vars := map[string]interface{}{"file": nil}
+	for k, v := range variables {
+		vars[k] = v
+	}
+	operations, err := json.Marshal(graphQLRequest{
+		Query:     query,
+		Variables: vars,
+	})
+	require.NoError(t, err)
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	require.NoError(t, w.WriteField("operations", string(operations)))
+	require.NoError(t, w.WriteField("map", `{"0": ["variables.file"]}`))
+	part, err := w.CreatePart(map[string][]string{
+		"Content-Disposition": {`form-data; name="0"; filename="` + filename + `"`},
+		"Content-Type":        {contentType},
+	})
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/query", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, "Body: %s", rec.Body.String())
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return &resp
+}
+
+// TestMediaUploadAndServe tests uploading, deduplication, serving and linking media to a word.
+func TestMediaUploadAndServe(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.uploadMedia(t, "cat.png", "image/png", testPNG)
+	require.Empty(t, resp.Errors)
+	media := extractObject(t, resp.Data, "uploadMedia")
+	mediaID := media["id"].(string)
+	assert.Equal(t, "/media/"+mediaID, media["url"])
+	assert.Equal(t, "image/png", media["contentType"])
+	assert.Equal(t, float64(len(testPNG)), media["sizeBytes"])
+	assert.Nil(t, media["sourceUrl"])
+
+	// The same content is stored once
+	resp = app.uploadMedia(t, "copy.png", "image/png", testPNG)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, mediaID, extractString(t, resp.Data, "uploadMedia", "id"))
+
+	// Served over HTTP with caching headers
+	req := httptest.NewRequest(http.MethodGet, "/media/"+mediaID, nil)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, testPNG, rec.Body.Bytes())
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, `"`+media["sha256"].(string)+`"`, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/media/"+mediaID, nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/media/00000000-0000-0000-0000-000000000001", nil)
+	rec = httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Images may reference media instead of an external URL
+	resp = app.executeGraphQL(t, `
+		mutation($mediaId: UUID!) {
+			createWord(input: {
+				text: "cat"
+				images: [{ mediaId: $mediaId, caption: "a cat" }]
+			}) {
+				images { url mediaId media { id contentType } }
+			}
+		}
+	`, map[string]interface{}{"mediaId": mediaID})
+	require.Empty(t, resp.Errors)
+	images := extractArray(t, resp.Data, "createWord", "images")
+	require.Len(t, images, 1)
+	image := images[0].(map[string]interface{})
+	assert.Equal(t, "/media/"+mediaID, image["url"])
+	assert.Equal(t, mediaID, image["mediaId"])
+	assert.Equal(t, "image/png", image["media"].(map[string]interface{})["contentType"])
+
+	// Unknown media is rejected
+	resp = app.executeGraphQLWithError(t, `
+		mutation {
+			createWord(input: {
+				text: "dog"
+				images: [{ mediaId: "00000000-0000-0000-0000-000000000001" }]
+			}) { id }
+		}
+	`, nil)
+	require.NotEmpty(t, resp.Errors)
+
+	// Neither url nor mediaId
+	resp = app.executeGraphQLWithError(t, `
+		mutation {
+			createWord(input: { text: "dog", images: [{ caption: "nothing" }] }) { id }
+		}
+	`, nil)
+	require.NotEmpty(t, resp.Errors)
+
+	// Only images and audio are accepted
+	resp = app.uploadMedia(t, "notes.txt", "text/plain", []byte("plain text"))
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestMediaImport tests downloading media by URL and linking it to a pronunciation.
+func TestMediaImport(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hello.mp3" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write(testMP3)
+	}))
+	defer source.Close()
+
+	importMutation := `
+		mutation($url: String!) {
+			importMedia(url: $url) { id contentType sourceUrl }
+		}
+	`
+	resp := app.executeGraphQL(t, importMutation, map[string]interface{}{"url": source.URL + "/hello.mp3"})
+	require.Empty(t, resp.Errors)
+	media := extractObject(t, resp.Data, "importMedia")
+	mediaID := media["id"].(string)
+	assert.Equal(t, "audio/mpeg", media["contentType"])
+	assert.Equal(t, source.URL+"/hello.mp3", media["sourceUrl"])
+
+	resp = app.executeGraphQL(t, `
+		mutation($mediaId: UUID!) {
+			createWord(input: {
+				text: "hello"
+				pronunciations: [{ mediaId: $mediaId, region: "US" }]
+			}) {
+				pronunciations { audioUrl mediaId }
+			}
+		}
+	`, map[string]interface{}{"mediaId": mediaID})
+	require.Empty(t, resp.Errors)
+	pronunciations := extractArray(t, resp.Data, "createWord", "pronunciations")
+	require.Len(t, pronunciations, 1)
+	assert.Equal(t, "/media/"+mediaID, pronunciations[0].(map[string]interface{})["audioUrl"])
+
+	// The file is served even if the source disappears
+	source.Close()
+	req := httptest.NewRequest(http.MethodGet, "/media/"+mediaID, nil)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testMP3, rec.Body.Bytes())
+
+	// Broken links and non-http URLs are validation errors
+	resp = app.executeGraphQLWithError(t, importMutation, map[string]interface{}{"url": source.URL + "/missing.mp3"})
+	require.NotEmpty(t, resp.Errors)
+	resp = app.executeGraphQLWithError(t, importMutation, map[string]interface{}{"url": "file:///etc/passwd"})
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestMediaImportPrivateNetworks tests that downloads from internal addresses are rejected
+// with a generic error that does not reveal the cause.
+func TestMediaImportPrivateNetworks(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+	ctx := context.Background()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	svc, err := media.NewService(app.repos, store, media.Config{})
+	require.NoError(t, err)
+
+	requested := false
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write(testMP3)
+	}))
+	defer source.Close()
+
+	for _, url := range []string{
+		source.URL + "/hello.mp3",
+		"http://localhost:1/hello.mp3",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/hello.mp3",
+		"http://10.0.0.1/hello.mp3",
+	} {
+		_, err := svc.ImportURL(ctx, url)
+		require.Error(t, err, url)
+		var validationErr *types.ValidationError
+		require.ErrorAs(t, err, &validationErr, url)
+		assert.Equal(t, "download failed", validationErr.Message, url)
+	}
+	assert.False(t, requested, "Private addresses must not be contacted")
+}
+
+// encodeTestPNG renders an opaque width x height PNG.
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// TestMediaImageProcessing tests thumbnail and card variants created by the background pipeline.
+func TestMediaImageProcessing(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.uploadMedia(t, "wide.png", "image/png", encodeTestPNG(t, 2000, 1000))
+	require.Empty(t, resp.Errors)
+	mediaID := extractString(t, resp.Data, "uploadMedia", "id")
+
+	// A broken image is marked as failed instead of blocking the queue
+	resp = app.uploadMedia(t, "broken.png", "image/png", testPNG)
+	require.Empty(t, resp.Errors)
+
+	imageQuery := `
+		query($id: UUID!) {
+			dictionaryEntry(id: $id) {
+				images {
+					thumbnailUrl width height
+					media { width height thumbnailUrl cardUrl processedAt }
+				}
+			}
+		}
+	`
+	resp = app.executeGraphQL(t, `
+		mutation($mediaId: UUID!) {
+			createWord(input: { text: "panorama", images: [{ mediaId: $mediaId }] }) { id }
+		}
+	`, map[string]interface{}{"mediaId": mediaID})
+	require.Empty(t, resp.Errors)
+	entryID := extractString(t, resp.Data, "createWord", "id")
+
+	// Not processed yet
+	resp = app.executeGraphQL(t, imageQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, resp.Errors)
+	entryImage := extractArray(t, resp.Data, "dictionaryEntry", "images")[0].(map[string]interface{})
+	assert.Nil(t, entryImage["thumbnailUrl"])
+	assert.Nil(t, entryImage["width"])
+	assert.Nil(t, entryImage["media"].(map[string]interface{})["processedAt"])
+
+	processed, err := app.services.Media.ProcessPending(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	// Variants are not processed again
+	processed, err = app.services.Media.ProcessPending(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	resp = app.executeGraphQL(t, imageQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, resp.Errors)
+	entryImage = extractArray(t, resp.Data, "dictionaryEntry", "images")[0].(map[string]interface{})
+	assert.Equal(t, float64(2000), entryImage["width"])
+	assert.Equal(t, float64(1000), entryImage["height"])
+	media := entryImage["media"].(map[string]interface{})
+	assert.NotNil(t, media["processedAt"])
+	thumbnailURL, ok := entryImage["thumbnailUrl"].(string)
+	require.True(t, ok)
+	assert.Equal(t, media["thumbnailUrl"], thumbnailURL)
+	assert.NotEqual(t, "/media/"+mediaID, thumbnailURL)
+
+	// Thumbnail is a re-encoded JPEG that fits into 256x256
+	req := httptest.NewRequest(http.MethodGet, thumbnailURL, nil)
+	rec := httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 256, cfg.Width)
+	assert.Equal(t, 128, cfg.Height)
+
+	// Card variant fits into 1024x1024
+	req = httptest.NewRequest(http.MethodGet, media["cardUrl"].(string), nil)
+	rec = httptest.NewRecorder()
+	app.handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	cfg, _, err = image.DecodeConfig(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 1024, cfg.Width)
+	assert.Equal(t, 512, cfg.Height)
+}
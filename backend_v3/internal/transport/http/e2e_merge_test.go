@@ -0,0 +1,181 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMergeWords tests merging a duplicate word into a target with content deduplication.
+func TestMergeWords(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createQuery := `
+		mutation($input: CreateWordInput!) {
+			createWord(input: $input) {
+				id
+				card { id }
+			}
+		}
+	`
+
+	// Target: one sense with a translation, card without reviews
+	targetResp := app.executeGraphQL(t, createQuery, map[string]interface{}{
+		"input": map[string]interface{}{
+			"text":       "colour",
+			"createCard": true,
+			"senses": []map[string]interface{}{
+				{
+					"definition":   "the property of reflecting light",
+					"partOfSpeech": "NOUN",
+					"sourceSlug":   "user",
+					"translations": []map[string]interface{}{
+						{"text": "цвет", "sourceSlug": "user"},
+					},
+				},
+			},
+		},
+	})
+	require.Empty(t, targetResp.Errors)
+	targetID := extractString(t, targetResp.Data, "createWord", "id")
+	targetCardID := extractString(t, targetResp.Data, "createWord", "card", "id")
+
+	// Source: the same sense (with a duplicate and a new translation), a new sense and an image
+	sourceResp := app.executeGraphQL(t, createQuery, map[string]interface{}{
+		"input": map[string]interface{}{
+			"text":       "color",
+			"createCard": true,
+			"senses": []map[string]interface{}{
+				{
+					"definition":   "The property of reflecting light",
+					"partOfSpeech": "NOUN",
+					"sourceSlug":   "user",
+					"translations": []map[string]interface{}{
+						{"text": "Цвет", "sourceSlug": "user"},
+						{"text": "окрас", "sourceSlug": "user"},
+					},
+				},
+				{
+					"definition":   "to give colour to something",
+					"partOfSpeech": "VERB",
+					"sourceSlug":   "user",
+				},
+			},
+			"images": []map[string]interface{}{
+				{"url": "https://example.com/color.jpg", "sourceSlug": "user"},
+			},
+		},
+	})
+	require.Empty(t, sourceResp.Errors)
+	sourceID := extractString(t, sourceResp.Data, "createWord", "id")
+	sourceCardID := extractString(t, sourceResp.Data, "createWord", "card", "id")
+
+	// Source card gets review history, so its SRS state is stronger
+	reviewQuery := `
+		mutation($cardId: UUID!) {
+			reviewCard(cardId: $cardId, grade: GOOD) { nextReviewAt }
+		}
+	`
+	reviewResp := app.executeGraphQL(t, reviewQuery, map[string]interface{}{"cardId": sourceCardID})
+	require.Empty(t, reviewResp.Errors)
+
+	// The pair is suggested as a duplicate candidate
+	candidatesResp := app.executeGraphQL(t, `
+		query {
+			duplicateCandidates(minSimilarity: 0.4) {
+				entry { id }
+				duplicate { id }
+				similarity
+			}
+		}
+	`, nil)
+	require.Empty(t, candidatesResp.Errors)
+	candidates := extractArray(t, candidatesResp.Data, "duplicateCandidates")
+	require.Len(t, candidates, 1)
+	pair := candidates[0].(map[string]interface{})
+	pairIDs := []interface{}{
+		pair["entry"].(map[string]interface{})["id"],
+		pair["duplicate"].(map[string]interface{})["id"],
+	}
+	assert.ElementsMatch(t, []interface{}{targetID, sourceID}, pairIDs)
+
+	mergeQuery := `
+		mutation($targetId: UUID!, $sourceIds: [UUID!]!) {
+			mergeWords(targetId: $targetId, sourceIds: $sourceIds) {
+				id
+				senses {
+					partOfSpeech
+					translations { text }
+				}
+				images { url }
+				card {
+					id
+					status
+					reviewHistory { grade }
+				}
+			}
+		}
+	`
+	mergeResp := app.executeGraphQL(t, mergeQuery, map[string]interface{}{
+		"targetId":  targetID,
+		"sourceIds": []string{sourceID},
+	})
+	require.Empty(t, mergeResp.Errors)
+	assert.Equal(t, targetID, extractString(t, mergeResp.Data, "mergeWords", "id"))
+
+	senses := extractArray(t, mergeResp.Data, "mergeWords", "senses")
+	require.Len(t, senses, 2, "Identical sense should be merged, new sense moved")
+	for _, s := range senses {
+		sense := s.(map[string]interface{})
+		if sense["partOfSpeech"] == "NOUN" {
+			assert.Len(t, sense["translations"], 2, "Duplicate translation should be skipped")
+		}
+	}
+	assert.Len(t, extractArray(t, mergeResp.Data, "mergeWords", "images"), 1)
+
+	assert.Equal(t, targetCardID, extractString(t, mergeResp.Data, "mergeWords", "card", "id"))
+	assert.NotEqual(t, "NEW", extractString(t, mergeResp.Data, "mergeWords", "card", "status"),
+		"Stronger SRS state should be kept")
+	assert.Len(t, extractArray(t, mergeResp.Data, "mergeWords", "card", "reviewHistory"), 1,
+		"Review history should be merged")
+
+	// Source is deleted
+	dictResp := app.executeGraphQL(t, `query { dictionary { id } }`, nil)
+	require.Empty(t, dictResp.Errors)
+	assert.Len(t, extractArray(t, dictResp.Data, "dictionary"), 1)
+}
+
+// TestMergeWordsValidation tests merge input validation.
+func TestMergeWordsValidation(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	targetID, _ := createTestWord(t, app, "grey")
+
+	mergeQuery := `
+		mutation($targetId: UUID!, $sourceIds: [UUID!]!) {
+			mergeWords(targetId: $targetId, sourceIds: $sourceIds) { id }
+		}
+	`
+
+	tests := []struct {
+		name      string
+		sourceIDs []string
+	}{
+		{name: "empty sources", sourceIDs: []string{}},
+		{name: "target among sources", sourceIDs: []string{targetID}},
+		{name: "unknown source", sourceIDs: []string{"00000000-0000-0000-0000-000000000001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := app.executeGraphQLWithError(t, mergeQuery, map[string]interface{}{
+				"targetId":  targetID,
+				"sourceIds": tt.sourceIDs,
+			})
+			require.NotEmpty(t, resp.Errors)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mineWordsMutation = `
+	mutation($text: String, $file: Upload, $input: MineWordsInput) {
+		mineWords(text: $text, file: $file, input: $input) {
+			format tokens words known
+			candidates { lemma forms count frequencyRank cefrLevel score context }
+		}
+	}
+`
+
+// minedWords indexes mineWords candidates by lemma.
+func minedWords(t *testing.T, resp *graphQLResponse) map[string]map[string]interface{} {
+	t.Helper()
+	words := make(map[string]map[string]interface{})
+	for _, c := range extractArray(t, resp.Data, "mineWords", "candidates") {
+		m := c.(map[string]interface{})
+		words[m["lemma"].(string)] = m
+	}
+	return words
+}
+
+// TestMineWordsText tests lemmatization, known and proper noun filtering and ranking of a plain text.
+func TestMineWordsText(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "rain")
+
+	text := "We walked home in the rain. Walking in the rain is an ephemeral joy.\n\n" +
+		"I met Sherlock there, and the rains stopped."
+	resp := app.executeGraphQL(t, mineWordsMutation, map[string]interface{}{"text": text})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, "TEXT", extractString(t, resp.Data, "mineWords", "format"))
+	assert.Equal(t, 1, extractInt(t, resp.Data, "mineWords", "known"))
+
+	words := minedWords(t, resp)
+	assert.NotContains(t, words, "rain")
+	assert.NotContains(t, words, "sherlock")
+	assert.NotContains(t, words, "walked")
+
+	require.Contains(t, words, "walk")
+	assert.Equal(t, []interface{}{"walked", "walking"}, words["walk"]["forms"])
+	assert.EqualValues(t, 2, words["walk"]["count"])
+	assert.NotNil(t, words["walk"]["frequencyRank"])
+	assert.Equal(t, "We walked home in the rain.", words["walk"]["context"])
+
+	// A CEFR-only word has no rank and counts as rare
+	require.Contains(t, words, "ephemeral")
+	assert.Nil(t, words["ephemeral"]["frequencyRank"])
+	assert.Equal(t, "C2", words["ephemeral"]["cefrLevel"])
+	assert.EqualValues(t, 1, words["ephemeral"]["score"])
+
+	// Candidates are sorted by score
+	candidates := extractArray(t, resp.Data, "mineWords", "candidates")
+	for i := 1; i < len(candidates); i++ {
+		prev := candidates[i-1].(map[string]interface{})["score"].(float64)
+		cur := candidates[i].(map[string]interface{})["score"].(float64)
+		assert.GreaterOrEqual(t, prev, cur)
+	}
+
+	// The limit cuts the list
+	resp = app.executeGraphQL(t, mineWordsMutation, map[string]interface{}{
+		"text":  text,
+		"input": map[string]interface{}{"limit": 1},
+	})
+	require.Empty(t, resp.Errors)
+	assert.Len(t, extractArray(t, resp.Data, "mineWords", "candidates"), 1)
+}
+
+// TestMineWordsUnlistedBaseForms tests grouping forms of words missing from the frequency list.
+func TestMineWordsUnlistedBaseForms(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createTestWord(t, app, "zorb")
+
+	text := "She vlogged all summer. Her vlogs were popular, and one vlog went viral.\n\n" +
+		"Zorbing down the hill was fun."
+	resp := app.executeGraphQL(t, mineWordsMutation, map[string]interface{}{"text": text})
+	require.Empty(t, resp.Errors)
+
+	words := minedWords(t, resp)
+
+	// A shorter form that appears in the text becomes the base form
+	require.Contains(t, words, "vlog")
+	assert.NotContains(t, words, "vlogged")
+	assert.NotContains(t, words, "vlogs")
+	assert.Equal(t, []interface{}{"vlogged", "vlogs", "vlog"}, words["vlog"]["forms"])
+	assert.EqualValues(t, 3, words["vlog"]["count"])
+	assert.Nil(t, words["vlog"]["frequencyRank"])
+
+	// A form that is already in the dictionary makes the word known
+	assert.NotContains(t, words, "zorbing")
+	assert.NotContains(t, words, "zorb")
+	assert.GreaterOrEqual(t, extractInt(t, resp.Data, "mineWords", "known"), 1)
+}
+
+// TestMineWordsSubtitles tests mining an uploaded .srt file and adding the selected words to the inbox.
+func TestMineWordsSubtitles(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	srt := "1\r\n00:00:01,000 --> 00:00:03,500\r\n<i>The weather is dreadful.</i>\r\n\r\n" +
+		"2\r\n00:00:03,600 --> 00:00:06,000\r\n[THUNDER] JOHN: Dreadful indeed!\r\n"
+	resp := app.executeUpload(t, mineWordsMutation, nil, "episode.srt", "application/x-subrip", []byte(srt))
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, "SRT", extractString(t, resp.Data, "mineWords", "format"))
+
+	words := minedWords(t, resp)
+	assert.NotContains(t, words, "thunder")
+	assert.NotContains(t, words, "john")
+	require.Contains(t, words, "dreadful")
+	assert.EqualValues(t, 2, words["dreadful"]["count"])
+	require.Contains(t, words, "weather")
+	assert.Equal(t, "The weather is dreadful.", words["weather"]["context"])
+
+	// Selected words go to the inbox; texts already there are skipped
+	inboxResp := app.executeGraphQL(t, `mutation { addToInbox(text: "Weather") { id } }`, nil)
+	require.Empty(t, inboxResp.Errors)
+
+	batchResp := app.executeGraphQL(t, `
+		mutation($items: [InboxItemInput!]!) {
+			addToInboxBatch(items: $items) { id text context }
+		}
+	`, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"text": "dreadful", "context": words["dreadful"]["context"]},
+			map[string]interface{}{"text": "weather", "context": words["weather"]["context"]},
+			map[string]interface{}{"text": "Dreadful"},
+		},
+	})
+	require.Empty(t, batchResp.Errors)
+	created := extractArray(t, batchResp.Data, "addToInboxBatch")
+	require.Len(t, created, 1)
+	item := created[0].(map[string]interface{})
+	assert.Equal(t, "dreadful", item["text"])
+	assert.Equal(t, "The weather is dreadful.", item["context"])
+
+	listResp := app.executeGraphQL(t, `query { inboxItems { id } }`, nil)
+	require.Empty(t, listResp.Errors)
+	assert.Len(t, extractArray(t, listResp.Data, "inboxItems"), 2)
+}
+
+// TestMineWordsValidation tests that exactly one of text and file is required.
+func TestMineWordsValidation(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeGraphQL(t, mineWordsMutation, nil)
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeUpload(t, mineWordsMutation, map[string]interface{}{"text": "Some text"},
+		"episode.srt", "application/x-subrip", []byte("Some text"))
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeGraphQL(t, mineWordsMutation, map[string]interface{}{"text": "   "})
+	require.NotEmpty(t, resp.Errors)
+
+	resp = app.executeGraphQL(t, mineWordsMutation, map[string]interface{}{
+		"text":  "Some text",
+		"input": map[string]interface{}{"limit": 5000},
+	})
+	require.NotEmpty(t, resp.Errors)
+}
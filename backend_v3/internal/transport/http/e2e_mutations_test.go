@@ -195,6 +195,9 @@ func TestUpdateWord(t *testing.T) {
 				}]
 			}) {
 				id
+				senses {
+					id
+				}
 			}
 		}
 	`
@@ -202,6 +205,9 @@ func TestUpdateWord(t *testing.T) {
 	createResp := app.executeGraphQL(t, createQuery, nil)
 	require.Empty(t, createResp.Errors)
 	wordID := extractString(t, createResp.Data, "createWord", "id")
+	createdSenses := extractArray(t, createResp.Data, "createWord", "senses")
+	require.Len(t, createdSenses, 1)
+	senseID := createdSenses[0].(map[string]interface{})["id"]
 
 	// Update the word
 	updateQuery := `
@@ -210,6 +216,7 @@ func TestUpdateWord(t *testing.T) {
 				id
 				text
 				senses {
+					id
 					definition
 				}
 			}
@@ -221,6 +228,7 @@ func TestUpdateWord(t *testing.T) {
 		"input": map[string]interface{}{
 			"senses": []map[string]interface{}{
 				{
+					"id":           senseID,
 					"definition":   "updated definition",
 					"partOfSpeech": "NOUN",
 					"sourceSlug":   "user",
@@ -234,9 +242,130 @@ func TestUpdateWord(t *testing.T) {
 	assert.Len(t, senses, 1, "Should have one sense after update")
 
 	sense := senses[0].(map[string]interface{})
+	assert.Equal(t, senseID, sense["id"], "Sense ID should be stable across updates")
 	assert.Equal(t, "updated definition", sense["definition"])
 }
 
+// TestUpdateWordPatch tests adding and deleting nested entities via updateWord.
+func TestUpdateWordPatch(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createQuery := `
+		mutation {
+			createWord(input: {
+				text: "run"
+				createCard: false
+				senses: [{
+					definition: "move fast"
+					partOfSpeech: VERB
+					sourceSlug: "user"
+					translations: [{ text: "бежать", sourceSlug: "user" }]
+				}, {
+					definition: "a period of running"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+				}]
+			}) {
+				id
+				senses {
+					id
+					definition
+					translations { id text }
+				}
+			}
+		}
+	`
+
+	createResp := app.executeGraphQL(t, createQuery, nil)
+	require.Empty(t, createResp.Errors)
+	wordID := extractString(t, createResp.Data, "createWord", "id")
+	createdSenses := extractArray(t, createResp.Data, "createWord", "senses")
+	require.Len(t, createdSenses, 2)
+
+	var verbSense, nounSense map[string]interface{}
+	for _, raw := range createdSenses {
+		sense := raw.(map[string]interface{})
+		if sense["definition"] == "move fast" {
+			verbSense = sense
+		} else {
+			nounSense = sense
+		}
+	}
+	require.NotNil(t, verbSense)
+	require.NotNil(t, nounSense)
+	translationID := verbSense["translations"].([]interface{})[0].(map[string]interface{})["id"]
+
+	updateQuery := `
+		mutation($id: UUID!, $input: UpdateWordInput!) {
+			updateWord(id: $id, input: $input) {
+				senses {
+					id
+					translations { id text }
+				}
+			}
+		}
+	`
+
+	// Обновляем перевод, добавляем новый и удаляем второй смысл
+	updateResp := app.executeGraphQL(t, updateQuery, map[string]interface{}{
+		"id": wordID,
+		"input": map[string]interface{}{
+			"senses": []map[string]interface{}{
+				{
+					"id":           verbSense["id"],
+					"definition":   "move fast",
+					"partOfSpeech": "VERB",
+					"sourceSlug":   "user",
+					"translations": []map[string]interface{}{
+						{"id": translationID, "text": "бегать", "sourceSlug": "user"},
+						{"text": "мчаться", "sourceSlug": "user"},
+					},
+				},
+			},
+			"deleteSenseIds": []interface{}{nounSense["id"]},
+		},
+	})
+
+	require.Empty(t, updateResp.Errors)
+	senses := extractArray(t, updateResp.Data, "updateWord", "senses")
+	require.Len(t, senses, 1, "Deleted sense should be gone")
+
+	sense := senses[0].(map[string]interface{})
+	assert.Equal(t, verbSense["id"], sense["id"])
+
+	translations := sense["translations"].([]interface{})
+	require.Len(t, translations, 2)
+	texts := make(map[interface{}]interface{})
+	for _, raw := range translations {
+		tr := raw.(map[string]interface{})
+		texts[tr["id"]] = tr["text"]
+	}
+	assert.Equal(t, "бегать", texts[translationID], "Translation should be updated in place")
+
+	// Ошибка на втором смысле откатывает весь патч, включая текст и первый смысл
+	failedResp := app.executeGraphQLWithError(t, updateQuery, map[string]interface{}{
+		"id": wordID,
+		"input": map[string]interface{}{
+			"text": "sprint",
+			"senses": []map[string]interface{}{
+				{"id": verbSense["id"], "definition": "move very fast", "partOfSpeech": "VERB", "sourceSlug": "user"},
+				{"id": "00000000-0000-0000-0000-000000000001", "definition": "missing", "sourceSlug": "user"},
+			},
+		},
+	})
+	require.NotEmpty(t, failedResp.Errors)
+
+	getResp := app.executeGraphQL(t, `
+		query($id: UUID!) { dictionaryEntry(id: $id) { text senses { definition } } }
+	`, map[string]interface{}{"id": wordID})
+	require.Empty(t, getResp.Errors)
+	assert.Equal(t, "run", extractString(t, getResp.Data, "dictionaryEntry", "text"))
+	senses = extractArray(t, getResp.Data, "dictionaryEntry", "senses")
+	require.Len(t, senses, 1)
+	assert.Equal(t, "move fast", senses[0].(map[string]interface{})["definition"])
+}
+
 // TestDeleteWord tests deleting a word.
 func TestDeleteWord(t *testing.T) {
 	app := setupTestApp(t)
@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWordNotes tests Markdown notes on entries and senses, their audit and search.
+func TestWordNotes(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "ephemeral"
+				notes: "**Remember:** sounds like *a femoral* artery"
+				senses: [{
+					definition: "lasting for a very short time"
+					partOfSpeech: ADJECTIVE
+					sourceSlug: "user"
+					notes: "Often about fame or fashion"
+				}]
+			}) {
+				id
+				notes
+				notesOnCard
+				cardBack
+				senses { id notes }
+			}
+		}
+	`, nil)
+	require.Empty(t, resp.Errors)
+	entry := extractObject(t, resp.Data, "createWord")
+	entryID := entry["id"].(string)
+	assert.Equal(t, "**Remember:** sounds like *a femoral* artery", entry["notes"])
+	assert.Equal(t, false, entry["notesOnCard"])
+	assert.Nil(t, entry["cardBack"], "Notes are not on the card unless enabled")
+	senses := extractArray(t, resp.Data, "createWord", "senses")
+	require.Len(t, senses, 1)
+	sense := senses[0].(map[string]interface{})
+	assert.Equal(t, "Often about fame or fashion", sense["notes"])
+
+	// Update notes and show them on the card
+	resp = app.executeGraphQL(t, `
+		mutation($id: UUID!, $senseId: UUID!) {
+			updateWord(id: $id, input: {
+				notes: "Sounds like *a femoral* artery"
+				notesOnCard: true
+				senses: [{
+					id: $senseId
+					definition: "lasting for a very short time"
+					partOfSpeech: ADJECTIVE
+					sourceSlug: "user"
+					notes: "Synonym: fleeting"
+				}]
+			}) {
+				notes
+				notesOnCard
+				cardBack
+				senses { notes }
+			}
+		}
+	`, map[string]interface{}{"id": entryID, "senseId": sense["id"]})
+	require.Empty(t, resp.Errors)
+	updated := extractObject(t, resp.Data, "updateWord")
+	assert.Equal(t, "Sounds like *a femoral* artery", updated["notes"])
+	assert.Equal(t, true, updated["notesOnCard"])
+	assert.Equal(t, "Sounds like *a femoral* artery", updated["cardBack"])
+	assert.Equal(t, "Synonym: fleeting", extractArray(t, resp.Data, "updateWord", "senses")[0].(map[string]interface{})["notes"])
+
+	// Audit log records the notes diff
+	resp = app.executeGraphQL(t, `
+		query($id: UUID!) {
+			dictionaryEntry(id: $id) {
+				auditLog(entityType: ENTRY) { action changes }
+			}
+		}
+	`, map[string]interface{}{"id": entryID})
+	require.Empty(t, resp.Errors)
+	records := extractArray(t, resp.Data, "dictionaryEntry", "auditLog")
+	require.NotEmpty(t, records)
+	latest := records[0].(map[string]interface{})
+	assert.Equal(t, "UPDATE", latest["action"])
+	changes := latest["changes"].(map[string]interface{})
+	require.Contains(t, changes, "notes")
+	require.Contains(t, changes, "notes_on_card")
+	notesDiff := changes["notes"].(map[string]interface{})
+	assert.Equal(t, "**Remember:** sounds like *a femoral* artery", notesDiff["old"])
+	assert.Equal(t, "Sounds like *a femoral* artery", notesDiff["new"])
+
+	// Full-text search finds words by their notes and sense notes
+	createTestWord(t, app, "artery")
+	searchQuery := `
+		query($search: String!) {
+			dictionary(filter: { search: $search }) { id text }
+		}
+	`
+	resp = app.executeGraphQL(t, searchQuery, map[string]interface{}{"search": "femoral"})
+	require.Empty(t, resp.Errors)
+	words := extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	assert.Equal(t, entryID, words[0].(map[string]interface{})["id"])
+
+	resp = app.executeGraphQL(t, searchQuery, map[string]interface{}{"search": "fleeting"})
+	require.Empty(t, resp.Errors)
+	words = extractArray(t, resp.Data, "dictionary")
+	require.Len(t, words, 1)
+	assert.Equal(t, entryID, words[0].(map[string]interface{})["id"])
+
+	// An empty string clears the notes
+	resp = app.executeGraphQL(t, `
+		mutation($id: UUID!) {
+			updateWord(id: $id, input: { notes: "" }) { notes cardBack }
+		}
+	`, map[string]interface{}{"id": entryID})
+	require.Empty(t, resp.Errors)
+	cleared := extractObject(t, resp.Data, "updateWord")
+	assert.Nil(t, cleared["notes"])
+	assert.Nil(t, cleared["cardBack"])
+}
@@ -0,0 +1,135 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paginationDictionaryQuery = `
+	query($filter: WordFilter, $first: Int, $after: String) {
+		dictionaryConnection(filter: $filter, first: $first, after: $after) {
+			edges {
+				cursor
+				node { id text }
+			}
+			pageInfo {
+				hasNextPage
+				hasPreviousPage
+				startCursor
+				endCursor
+			}
+			totalCount
+		}
+	}
+`
+
+// TestDictionaryConnection tests keyset pagination over the dictionary.
+func TestDictionaryConnection(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	for _, text := range []string{"cherry", "apple", "banana"} {
+		createTestWord(t, app, text)
+	}
+
+	filter := map[string]interface{}{"sortBy": "TEXT", "sortDir": "ASC"}
+
+	// First page
+	resp := app.executeGraphQL(t, paginationDictionaryQuery, map[string]interface{}{
+		"filter": filter,
+		"first":  2,
+	})
+	require.Empty(t, resp.Errors)
+	edges := extractArray(t, resp.Data, "dictionaryConnection", "edges")
+	require.Len(t, edges, 2)
+	assert.Equal(t, "apple", edges[0].(map[string]interface{})["node"].(map[string]interface{})["text"])
+	assert.Equal(t, "banana", edges[1].(map[string]interface{})["node"].(map[string]interface{})["text"])
+	assert.Equal(t, 3, extractInt(t, resp.Data, "dictionaryConnection", "totalCount"))
+	assert.True(t, extractBool(t, resp.Data, "dictionaryConnection", "pageInfo", "hasNextPage"))
+	assert.False(t, extractBool(t, resp.Data, "dictionaryConnection", "pageInfo", "hasPreviousPage"))
+	endCursor := extractString(t, resp.Data, "dictionaryConnection", "pageInfo", "endCursor")
+	assert.Equal(t, edges[1].(map[string]interface{})["cursor"], endCursor)
+
+	// Second page
+	resp = app.executeGraphQL(t, paginationDictionaryQuery, map[string]interface{}{
+		"filter": filter,
+		"first":  2,
+		"after":  endCursor,
+	})
+	require.Empty(t, resp.Errors)
+	edges = extractArray(t, resp.Data, "dictionaryConnection", "edges")
+	require.Len(t, edges, 1)
+	assert.Equal(t, "cherry", edges[0].(map[string]interface{})["node"].(map[string]interface{})["text"])
+	assert.Equal(t, 3, extractInt(t, resp.Data, "dictionaryConnection", "totalCount"))
+	assert.False(t, extractBool(t, resp.Data, "dictionaryConnection", "pageInfo", "hasNextPage"))
+	assert.True(t, extractBool(t, resp.Data, "dictionaryConnection", "pageInfo", "hasPreviousPage"))
+
+	// A cursor from one sort order cannot be used with another
+	resp = app.executeGraphQLWithError(t, paginationDictionaryQuery, map[string]interface{}{
+		"first": 2,
+		"after": endCursor,
+	})
+	require.NotEmpty(t, resp.Errors)
+
+	// Nor with the same field in the other direction
+	resp = app.executeGraphQLWithError(t, paginationDictionaryQuery, map[string]interface{}{
+		"filter": map[string]interface{}{"sortBy": "TEXT", "sortDir": "DESC"},
+		"first":  2,
+		"after":  endCursor,
+	})
+	require.NotEmpty(t, resp.Errors)
+
+	// Malformed cursor
+	resp = app.executeGraphQLWithError(t, paginationDictionaryQuery, map[string]interface{}{
+		"first": 2,
+		"after": "not-a-cursor",
+	})
+	require.NotEmpty(t, resp.Errors)
+}
+
+// TestInboxItemsConnection tests keyset pagination over inbox items.
+func TestInboxItemsConnection(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	for _, text := range []string{"one", "two", "three"} {
+		resp := app.executeGraphQL(t, `
+			mutation($text: String!) {
+				addToInbox(text: $text) { id }
+			}
+		`, map[string]interface{}{"text": text})
+		require.Empty(t, resp.Errors)
+	}
+
+	query := `
+		query($first: Int, $after: String) {
+			inboxItemsConnection(first: $first, after: $after) {
+				edges { node { id text } }
+				pageInfo { hasNextPage endCursor }
+				totalCount
+			}
+		}
+	`
+
+	seen := make(map[string]bool)
+	var after interface{}
+	for page := 0; page < 3; page++ {
+		resp := app.executeGraphQL(t, query, map[string]interface{}{"first": 2, "after": after})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, 3, extractInt(t, resp.Data, "inboxItemsConnection", "totalCount"))
+
+		for _, e := range extractArray(t, resp.Data, "inboxItemsConnection", "edges") {
+			id := e.(map[string]interface{})["node"].(map[string]interface{})["id"].(string)
+			assert.False(t, seen[id], "Items must not repeat across pages")
+			seen[id] = true
+		}
+
+		if !extractBool(t, resp.Data, "inboxItemsConnection", "pageInfo", "hasNextPage") {
+			break
+		}
+		after = extractString(t, resp.Data, "inboxItemsConnection", "pageInfo", "endCursor")
+	}
+	assert.Len(t, seen, 3)
+}
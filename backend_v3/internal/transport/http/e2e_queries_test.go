@@ -251,3 +251,68 @@ func TestDashboardStatsWithData(t *testing.T) {
 	assert.Equal(t, 1, extractInt(t, resp.Data, "dashboardStats", "totalCards"))
 	assert.Equal(t, 1, extractInt(t, resp.Data, "dashboardStats", "newCards"))
 }
+
+// TestLookupByTranslationQuery tests reverse lookup by translation text.
+func TestLookupByTranslationQuery(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createQuery := `
+		mutation($text: String!, $translation: String!) {
+			createWord(input: {
+				text: $text
+				createCard: false
+				senses: [{
+					definition: "definition"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+					translations: [{ text: $translation, sourceSlug: "user" }]
+				}]
+			}) {
+				id
+			}
+		}
+	`
+
+	helloResp := app.executeGraphQL(t, createQuery, map[string]interface{}{
+		"text":        "hello",
+		"translation": "привет",
+	})
+	require.Empty(t, helloResp.Errors)
+	helloID := extractString(t, helloResp.Data, "createWord", "id")
+
+	hedgehogResp := app.executeGraphQL(t, createQuery, map[string]interface{}{
+		"text":        "hedgehog",
+		"translation": "Ёж",
+	})
+	require.Empty(t, hedgehogResp.Errors)
+	hedgehogID := extractString(t, hedgehogResp.Data, "createWord", "id")
+
+	query := `
+		query($text: String!) {
+			lookupByTranslation(text: $text) {
+				entry { id text }
+				sense { id }
+				translation { text }
+				similarity
+			}
+		}
+	`
+
+	// Регистр не учитывается
+	resp := app.executeGraphQL(t, query, map[string]interface{}{"text": "ПРИВЕТ"})
+	require.Empty(t, resp.Errors)
+	arr := extractArray(t, resp.Data, "lookupByTranslation")
+	require.Len(t, arr, 1)
+	match := arr[0].(map[string]interface{})
+	assert.Equal(t, helloID, match["entry"].(map[string]interface{})["id"])
+	assert.Equal(t, "привет", match["translation"].(map[string]interface{})["text"])
+
+	// «ё» и «е» считаются одной буквой
+	resp = app.executeGraphQL(t, query, map[string]interface{}{"text": "еж"})
+	require.Empty(t, resp.Errors)
+	arr = extractArray(t, resp.Data, "lookupByTranslation")
+	require.Len(t, arr, 1)
+	match = arr[0].(map[string]interface{})
+	assert.Equal(t, hedgehogID, match["entry"].(map[string]interface{})["id"])
+}
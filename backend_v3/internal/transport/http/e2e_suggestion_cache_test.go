@@ -0,0 +1,102 @@
+package http_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubProvider is an online suggestion provider that counts Fetch calls.
+// It knows every word except "qwzx".
+type stubProvider struct {
+	calls atomic.Int32
+}
+
+func (p *stubProvider) Slug() string { return "stub" }
+func (p *stubProvider) Name() string { return "Stub" }
+
+func (p *stubProvider) Fetch(_ context.Context, text string) (*suggestion.Result, error) {
+	p.calls.Add(1)
+	if strings.EqualFold(strings.TrimSpace(text), "qwzx") {
+		return nil, nil
+	}
+	return &suggestion.Result{
+		SourceSlug: p.Slug(),
+		SourceName: p.Name(),
+		Synonyms:   []string{strings.ToLower(strings.TrimSpace(text)) + "-synonym"},
+	}, nil
+}
+
+const cachedSuggestionsQuery = `
+	query($text: String!) {
+		fetchSuggestions(text: $text, sources: ["stub", "wordnet"]) { sourceSlug synonyms cached }
+	}
+`
+
+// fetchCached returns the cached flag by provider slug.
+func fetchCached(t *testing.T, app *testApp, text string) map[string]bool {
+	t.Helper()
+	resp := app.executeGraphQL(t, cachedSuggestionsQuery, map[string]interface{}{"text": text})
+	require.Empty(t, resp.Errors)
+	cached := make(map[string]bool)
+	for _, r := range extractArray(t, resp.Data, "fetchSuggestions") {
+		result := r.(map[string]interface{})
+		cached[result["sourceSlug"].(string)] = result["cached"].(bool)
+	}
+	return cached
+}
+
+func invalidateSuggestionCache(t *testing.T, app *testApp, args string) int {
+	t.Helper()
+	resp := app.executeGraphQL(t, `mutation { invalidateSuggestionCache`+args+` }`, nil)
+	require.Empty(t, resp.Errors)
+	return extractInt(t, resp.Data, "invalidateSuggestionCache")
+}
+
+// TestSuggestionCache tests caching provider answers, negative caching and invalidation.
+func TestSuggestionCache(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	// First request goes to the providers
+	assert.Equal(t, map[string]bool{"stub": false, "wordnet": false}, fetchCached(t, app, "Dog"))
+	assert.EqualValues(t, 1, app.stub.calls.Load())
+
+	// Case and spaces do not matter; offline providers are never cached
+	assert.Equal(t, map[string]bool{"stub": true, "wordnet": false}, fetchCached(t, app, "  dog "))
+	assert.EqualValues(t, 1, app.stub.calls.Load())
+
+	resp := app.executeGraphQL(t, cachedSuggestionsQuery, map[string]interface{}{"text": "DOG"})
+	require.Empty(t, resp.Errors)
+	stubResult := extractArray(t, resp.Data, "fetchSuggestions")[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"dog-synonym"}, stubResult["synonyms"], "The cached answer is the original one")
+
+	// The answer is stored in PostgreSQL
+	var rows int
+	err := app.pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM suggestion_cache WHERE provider = 'stub' AND text_normalized = 'dog' AND result IS NOT NULL`).Scan(&rows)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rows)
+
+	// "Not found" is cached too
+	assert.Empty(t, fetchCached(t, app, "qwzx"))
+	assert.Empty(t, fetchCached(t, app, "qwzx"))
+	assert.EqualValues(t, 2, app.stub.calls.Load())
+
+	// Invalidation of one word makes the next request go to the provider
+	assert.Equal(t, 1, invalidateSuggestionCache(t, app, `(provider: "stub", text: " DOG")`))
+	assert.Equal(t, map[string]bool{"stub": false, "wordnet": false}, fetchCached(t, app, "dog"))
+	assert.EqualValues(t, 3, app.stub.calls.Load())
+
+	assert.Equal(t, 0, invalidateSuggestionCache(t, app, `(expiredOnly: true)`))
+	assert.Equal(t, 0, invalidateSuggestionCache(t, app, `(provider: "wordnet")`))
+	assert.Equal(t, 2, invalidateSuggestionCache(t, app, ""))
+
+	assert.Equal(t, map[string]bool{"stub": false, "wordnet": false}, fetchCached(t, app, "dog"))
+	assert.EqualValues(t, 4, app.stub.calls.Load())
+}
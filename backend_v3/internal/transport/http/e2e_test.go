@@ -15,10 +15,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/heartmarshall/my-english/internal/clients/wordnet"
 	"github.com/heartmarshall/my-english/internal/config"
 	"github.com/heartmarshall/my-english/internal/database"
 	"github.com/heartmarshall/my-english/internal/database/repository"
 	"github.com/heartmarshall/my-english/internal/service"
+	"github.com/heartmarshall/my-english/internal/service/media"
+	"github.com/heartmarshall/my-english/internal/service/suggestion"
+	"github.com/heartmarshall/my-english/internal/storage"
 	transportHttp "github.com/heartmarshall/my-english/internal/transport/http"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/require"
@@ -31,6 +35,7 @@ type testApp struct {
 	pool      *pgxpool.Pool
 	repos     *repository.Registry
 	services  *service.Services
+	stub      *stubProvider // Online suggestion provider whose answers are cached
 	handler   http.Handler
 	logger    *slog.Logger
 	container testcontainers.Container
@@ -96,11 +101,31 @@ func setupTestApp(t *testing.T) *testApp {
 	repos := repository.NewRegistry(pool)
 	txManager := database.NewTxManager(pool)
 
+	// Media files go to a per-test temp directory
+	mediaStore, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// WordNet reads the fixture database; no external providers for e2e tests
+	wn, err := wordnet.Open(wordnetDictPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { wn.Close() })
+	stub := &stubProvider{}
+
 	// Initialize services
 	services, err := service.NewServices(service.Deps{
-		Repos:     repos,
-		TxManager: txManager,
-		Providers: nil, // No external providers for e2e tests
+		Repos:      repos,
+		TxManager:  txManager,
+		Providers:  []suggestion.Provider{wn, stub},
+		Wiktionary: true, // The offline provider reads only the test database
+		Storage:    mediaStore,
+		Media: media.Config{
+			AllowPrivateNetworks: true, // Media sources are local httptest servers
+		},
+		SuggestionCache: &suggestion.CacheConfig{
+			TTL:         time.Hour,
+			NegativeTTL: time.Minute,
+			MemorySize:  100,
+		},
 	})
 	require.NoError(t, err, "Failed to initialize services")
 
@@ -138,6 +163,10 @@ func setupTestApp(t *testing.T) *testApp {
 			Level:  "error",
 			Format: "text",
 		},
+		Backup: config.BackupConfig{
+			Token:          testBackupToken,
+			MaxRestoreSize: 10 << 20,
+		},
 	}
 
 	// Create HTTP handler
@@ -147,6 +176,7 @@ func setupTestApp(t *testing.T) *testApp {
 		pool:      pool,
 		repos:     repos,
 		services:  services,
+		stub:      stub,
 		handler:   handler,
 		logger:    logger,
 		container: container,
@@ -0,0 +1,193 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	trashDeleteWordQuery = `
+		mutation($id: UUID!) {
+			deleteWord(id: $id)
+		}
+	`
+	trashListQuery = `
+		query {
+			trash {
+				id
+				text
+				deletedAt
+			}
+		}
+	`
+	trashRestoreWordQuery = `
+		mutation($id: UUID!) {
+			restoreWord(id: $id) {
+				id
+				deletedAt
+				senses { id }
+				card { id }
+			}
+		}
+	`
+	trashPurgeWordQuery = `
+		mutation($id: UUID!) {
+			purgeWord(id: $id)
+		}
+	`
+)
+
+// TestTrashDeleteAndRestore tests that deleted words go to trash and can be restored with their card.
+func TestTrashDeleteAndRestore(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	createQuery := `
+		mutation {
+			createWord(input: {
+				text: "forest"
+				createCard: true
+				senses: [{
+					definition: "a large area of trees"
+					partOfSpeech: NOUN
+					sourceSlug: "user"
+				}]
+			}) {
+				id
+				card { id }
+			}
+		}
+	`
+	createResp := app.executeGraphQL(t, createQuery, nil)
+	require.Empty(t, createResp.Errors)
+	entryID := extractString(t, createResp.Data, "createWord", "id")
+	cardID := extractString(t, createResp.Data, "createWord", "card", "id")
+
+	deleteResp := app.executeGraphQL(t, trashDeleteWordQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, deleteResp.Errors)
+	assert.True(t, extractBool(t, deleteResp.Data, "deleteWord"))
+
+	// Word disappears from dictionary and stats
+	dictResp := app.executeGraphQL(t, `query { dictionary { id } }`, nil)
+	require.Empty(t, dictResp.Errors)
+	assert.Empty(t, extractArray(t, dictResp.Data, "dictionary"))
+
+	statsResp := app.executeGraphQL(t, `query { dashboardStats { totalWords totalCards } }`, nil)
+	require.Empty(t, statsResp.Errors)
+	assert.Equal(t, 0, extractInt(t, statsResp.Data, "dashboardStats", "totalWords"))
+	assert.Equal(t, 0, extractInt(t, statsResp.Data, "dashboardStats", "totalCards"))
+
+	// ...and shows up in trash
+	trashResp := app.executeGraphQL(t, trashListQuery, nil)
+	require.Empty(t, trashResp.Errors)
+	trash := extractArray(t, trashResp.Data, "trash")
+	require.Len(t, trash, 1)
+	trashed := trash[0].(map[string]interface{})
+	assert.Equal(t, entryID, trashed["id"])
+	assert.NotNil(t, trashed["deletedAt"])
+
+	// Restore brings back senses and card
+	restoreResp := app.executeGraphQL(t, trashRestoreWordQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, restoreResp.Errors)
+	assert.Equal(t, entryID, extractString(t, restoreResp.Data, "restoreWord", "id"))
+	assert.Len(t, extractArray(t, restoreResp.Data, "restoreWord", "senses"), 1)
+	assert.Equal(t, cardID, extractString(t, restoreResp.Data, "restoreWord", "card", "id"))
+
+	trashResp = app.executeGraphQL(t, trashListQuery, nil)
+	require.Empty(t, trashResp.Errors)
+	assert.Empty(t, extractArray(t, trashResp.Data, "trash"))
+}
+
+// TestTrashRestoreConflict tests that a word cannot be restored while an active duplicate exists.
+func TestTrashRestoreConflict(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	oldID, _ := createTestWord(t, app, "river")
+
+	deleteResp := app.executeGraphQL(t, trashDeleteWordQuery, map[string]interface{}{"id": oldID})
+	require.Empty(t, deleteResp.Errors)
+
+	// Text is freed: the same word can be created again
+	newID, _ := createTestWord(t, app, "river")
+	assert.NotEqual(t, oldID, newID)
+
+	restoreResp := app.executeGraphQLWithError(t, trashRestoreWordQuery, map[string]interface{}{"id": oldID})
+	require.NotEmpty(t, restoreResp.Errors, "Restore should fail while an active duplicate exists")
+}
+
+// TestTrashPurgeWord tests permanent deletion of trashed words.
+func TestTrashPurgeWord(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	entryID, _ := createTestWord(t, app, "mountain")
+
+	// Active word cannot be purged
+	purgeResp := app.executeGraphQLWithError(t, trashPurgeWordQuery, map[string]interface{}{"id": entryID})
+	require.NotEmpty(t, purgeResp.Errors, "Purge should fail for an active word")
+
+	deleteResp := app.executeGraphQL(t, trashDeleteWordQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, deleteResp.Errors)
+
+	purgeResp = app.executeGraphQL(t, trashPurgeWordQuery, map[string]interface{}{"id": entryID})
+	require.Empty(t, purgeResp.Errors)
+	assert.True(t, extractBool(t, purgeResp.Data, "purgeWord"))
+
+	trashResp := app.executeGraphQL(t, trashListQuery, nil)
+	require.Empty(t, trashResp.Errors)
+	assert.Empty(t, extractArray(t, trashResp.Data, "trash"))
+
+	restoreResp := app.executeGraphQLWithError(t, trashRestoreWordQuery, map[string]interface{}{"id": entryID})
+	require.NotEmpty(t, restoreResp.Errors, "Purged word cannot be restored")
+}
+
+// TestTrashPurgeExpired tests the background purge and its aggregated audit record.
+func TestTrashPurgeExpired(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+	ctx := context.Background()
+
+	oldID, _ := createTestWord(t, app, "glacier")
+	freshID, _ := createTestWord(t, app, "valley")
+	for _, id := range []string{oldID, freshID} {
+		resp := app.executeGraphQL(t, trashDeleteWordQuery, map[string]interface{}{"id": id})
+		require.Empty(t, resp.Errors)
+	}
+
+	// Only the word trashed long ago is past retention
+	_, err := app.pool.Exec(ctx,
+		`UPDATE dictionary_entries SET deleted_at = NOW() - INTERVAL '40 days' WHERE id = $1`, oldID)
+	require.NoError(t, err)
+
+	purged, err := app.services.Dictionary.PurgeExpired(ctx, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	trashResp := app.executeGraphQL(t, trashListQuery, nil)
+	require.Empty(t, trashResp.Errors)
+	trash := extractArray(t, trashResp.Data, "trash")
+	require.Len(t, trash, 1)
+	assert.Equal(t, freshID, trash[0].(map[string]interface{})["id"])
+
+	// One audit record lists every purged entry
+	var raw []byte
+	err = app.pool.QueryRow(ctx,
+		`SELECT changes FROM audit_records WHERE entry_id IS NULL AND changes->>'action' = 'purged'`,
+	).Scan(&raw)
+	require.NoError(t, err)
+	var changes map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &changes))
+	assert.Equal(t, []interface{}{oldID}, changes["entry_ids"])
+	assert.NotEmpty(t, changes["purged_before"])
+
+	// Nothing left to purge: no new audit record
+	purged, err = app.services.Dictionary.PurgeExpired(ctx, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
@@ -0,0 +1,113 @@
+package http_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/heartmarshall/my-english/internal/service/wiktionary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wiktionaryDumpPath is a Wiktextract fixture with:
+//   - "dog" (noun): two glossed senses, a no-gloss sense, an example and a quotation,
+//     IPA and audio for US and UK, Russian and German translations, synonyms and forms;
+//   - "dog" (verb) with a sense-level translation and a form;
+//   - a truncated line and a German entry.
+const wiktionaryDumpPath = "../../../pkg/wiktextract/testdata/dump.jsonl"
+
+const wiktionarySuggestionsQuery = `
+	query($text: String!) {
+		fetchSuggestions(text: $text, sources: ["wiktionary"]) {
+			sourceSlug
+			senses { definition partOfSpeech examples { sentence } translations }
+			pronunciations { audioUrl transcription region }
+			synonyms
+			forms { text tags }
+		}
+	}
+`
+
+func importWiktionaryDump(t *testing.T, app *testApp) *wiktionary.ImportResult {
+	t.Helper()
+	f, err := os.Open(wiktionaryDumpPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	result, err := app.services.Wiktionary.Import(context.Background(), f, wiktionary.ImportInput{})
+	require.NoError(t, err)
+	return result
+}
+
+// TestWiktionaryProvider tests importing a Wiktextract dump and serving suggestions from it.
+func TestWiktionaryProvider(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	result := importWiktionaryDump(t, app)
+	assert.Equal(t, "en", result.Language)
+	assert.Equal(t, 3, result.Lines)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 1, result.Invalid)
+	assert.Len(t, result.Warnings, 1)
+	assert.Zero(t, result.Replaced)
+
+	// Lookup is case-insensitive and merges both parts of speech
+	resp := app.executeGraphQL(t, wiktionarySuggestionsQuery, map[string]interface{}{"text": "Dog"})
+	require.Empty(t, resp.Errors)
+	results := extractArray(t, resp.Data, "fetchSuggestions")
+	require.Len(t, results, 1)
+	res := results[0].(map[string]interface{})
+	assert.Equal(t, "wiktionary", res["sourceSlug"])
+
+	senses := res["senses"].([]interface{})
+	require.Len(t, senses, 3)
+	mammal := senses[0].(map[string]interface{})
+	assert.Equal(t, "NOUN", mammal["partOfSpeech"])
+	assert.Equal(t, []interface{}{"соба́ка", "пёс"}, mammal["translations"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"sentence": "The dog barked all night."}}, mammal["examples"])
+	man := senses[1].(map[string]interface{})
+	assert.Equal(t, "A dull, unattractive man.", man["definition"])
+	assert.Equal(t, []interface{}{"тип"}, man["translations"])
+	pursue := senses[2].(map[string]interface{})
+	assert.Equal(t, "VERB", pursue["partOfSpeech"])
+	assert.Equal(t, []interface{}{"пресле́довать"}, pursue["translations"])
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"audioUrl":      "https://upload.wikimedia.org/wikipedia/commons/transcoded/e/e2/En-us-dog.ogg/En-us-dog.ogg.mp3",
+			"transcription": "/dɔɡ/",
+			"region":        "US",
+		},
+		map[string]interface{}{
+			"audioUrl":      "https://upload.wikimedia.org/wikipedia/commons/1/1a/En-uk-dog.ogg",
+			"transcription": "/dɒɡ/",
+			"region":        "UK",
+		},
+	}, res["pronunciations"])
+	assert.Equal(t, []interface{}{"doggy", "hound", "cur"}, res["synonyms"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"text": "dogs", "tags": []interface{}{"plural"}},
+		map[string]interface{}{"text": "dogged", "tags": []interface{}{"past"}},
+	}, res["forms"])
+
+	// Unknown words give no result
+	resp = app.executeGraphQL(t, wiktionarySuggestionsQuery, map[string]interface{}{"text": "qwertyuiop"})
+	require.Empty(t, resp.Errors)
+	assert.Empty(t, extractArray(t, resp.Data, "fetchSuggestions"))
+
+	// A re-import replaces the previous entries instead of duplicating them
+	result = importWiktionaryDump(t, app)
+	assert.Equal(t, int64(2), result.Replaced)
+	resp = app.executeGraphQL(t, wiktionarySuggestionsQuery, map[string]interface{}{"text": "dog"})
+	require.Empty(t, resp.Errors)
+	results = extractArray(t, resp.Data, "fetchSuggestions")
+	require.Len(t, results, 1)
+	assert.Len(t, results[0].(map[string]interface{})["senses"], 3)
+
+	// Invalid language codes are rejected
+	_, err := app.services.Wiktionary.Import(context.Background(), nil, wiktionary.ImportInput{Language: "English"})
+	require.Error(t, err)
+}
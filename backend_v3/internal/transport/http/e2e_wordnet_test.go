@@ -0,0 +1,164 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wordnetDictPath is a WordNet fixture with:
+//   - "dog" (noun): "dog, domestic dog" with the hypernym "canine, canid" and "frump, dog";
+//   - "dog" (verb) in the synset "chase, chase after, tail";
+//   - "cold" and "hot" (adjectives) as antonyms.
+const wordnetDictPath = "../../clients/wordnet/testdata/dict"
+
+const senseRelationsFields = `relations { id type targetText targetEntryId sourceSlug }`
+
+// findRelation returns the relation with the given target text.
+func findRelation(t *testing.T, relations []interface{}, targetText string) map[string]interface{} {
+	t.Helper()
+	for _, r := range relations {
+		rel := r.(map[string]interface{})
+		if rel["targetText"] == targetText {
+			return rel
+		}
+	}
+	require.Failf(t, "relation not found", "no relation to %q in %v", targetText, relations)
+	return nil
+}
+
+// TestWordNetProvider tests WordNet suggestions with suggested sense relations.
+func TestWordNetProvider(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeGraphQL(t, `
+		query($text: String!) {
+			fetchSuggestions(text: $text, sources: ["wordnet"]) {
+				sourceSlug
+				senses { definition partOfSpeech examples { sentence } relations { type text } }
+				synonyms
+			}
+		}
+	`, map[string]interface{}{"text": "dogs"})
+	require.Empty(t, resp.Errors)
+	results := extractArray(t, resp.Data, "fetchSuggestions")
+	require.Len(t, results, 1)
+	result := results[0].(map[string]interface{})
+	assert.Equal(t, "wordnet", result["sourceSlug"])
+
+	senses := result["senses"].([]interface{})
+	require.Len(t, senses, 3)
+	dog := senses[0].(map[string]interface{})
+	assert.Equal(t, "a member of the genus Canis", dog["definition"])
+	assert.Equal(t, "NOUN", dog["partOfSpeech"])
+	assert.Len(t, dog["examples"], 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "SYNONYM", "text": "domestic dog"},
+		map[string]interface{}{"type": "SYNONYM", "text": "Canis familiaris"},
+		map[string]interface{}{"type": "HYPERNYM", "text": "canine"},
+		map[string]interface{}{"type": "HYPERNYM", "text": "canid"},
+	}, dog["relations"])
+	assert.Equal(t, "VERB", senses[2].(map[string]interface{})["partOfSpeech"])
+	assert.Equal(t, []interface{}{"domestic dog", "Canis familiaris", "frump", "chase", "tail"}, result["synonyms"])
+
+	resp = app.executeGraphQL(t, `
+		query($text: String!) {
+			fetchSuggestions(text: $text, sources: ["wordnet"]) {
+				senses { relations { type text } }
+			}
+		}
+	`, map[string]interface{}{"text": "cold"})
+	require.Empty(t, resp.Errors)
+	coldSenses := extractArray(t, resp.Data, "fetchSuggestions")[0].(map[string]interface{})["senses"].([]interface{})
+	require.Len(t, coldSenses, 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "ANTONYM", "text": "hot"},
+	}, coldSenses[0].(map[string]interface{})["relations"])
+}
+
+// TestSenseRelations tests accepting relations into the dictionary, resolving their targets and deleting them.
+func TestSenseRelations(t *testing.T) {
+	app := setupTestApp(t)
+	defer app.teardown(t)
+
+	resp := app.executeGraphQL(t, `
+		mutation {
+			createWord(input: {
+				text: "cold"
+				senses: [{
+					definition: "having a low or inadequate temperature"
+					partOfSpeech: ADJECTIVE
+					sourceSlug: "wordnet"
+					relations: [
+						{ type: ANTONYM, targetText: "hot", sourceSlug: "wordnet" }
+						{ type: SYNONYM, targetText: "Chilly", sourceSlug: "wordnet" }
+						{ type: SYNONYM, targetText: "chilly ", sourceSlug: "wordnet" }
+					]
+				}]
+			}) {
+				senses { id `+senseRelationsFields+` }
+			}
+		}
+	`, nil)
+	require.Empty(t, resp.Errors)
+	senses := extractArray(t, resp.Data, "createWord", "senses")
+	require.Len(t, senses, 1)
+	sense := senses[0].(map[string]interface{})
+	senseID := sense["id"].(string)
+	relations := sense["relations"].([]interface{})
+	require.Len(t, relations, 2, "Relations with the same normalized text are merged")
+	hot := findRelation(t, relations, "hot")
+	assert.Equal(t, "ANTONYM", hot["type"])
+	assert.Equal(t, "hot", hot["targetText"])
+	assert.Equal(t, "wordnet", hot["sourceSlug"])
+	assert.Nil(t, hot["targetEntryId"], "The target word is not in the dictionary yet")
+	assert.Equal(t, "SYNONYM", findRelation(t, relations, "Chilly")["type"])
+
+	// The relation finds the target entry once the word is added
+	hotID, _ := createTestWord(t, app, "Hot")
+
+	resp = app.executeGraphQL(t, `
+		mutation($senseId: UUID!) {
+			addRelations(senseId: $senseId, relations: [
+				{ type: ANTONYM, targetText: "HOT", sourceSlug: "user" }
+				{ type: RELATED, targetText: "ice", sourceSlug: "user" }
+			]) { `+senseRelationsFields+` }
+		}
+	`, map[string]interface{}{"senseId": senseID})
+	require.Empty(t, resp.Errors)
+	relations = extractArray(t, resp.Data, "addRelations", "relations")
+	require.Len(t, relations, 3, "An existing relation is not duplicated")
+	assert.Equal(t, hotID, findRelation(t, relations, "hot")["targetEntryId"])
+	assert.Equal(t, "RELATED", findRelation(t, relations, "ice")["type"])
+	assert.Nil(t, findRelation(t, relations, "ice")["targetEntryId"])
+
+	// Validation
+	resp = app.executeGraphQLWithError(t, `
+		mutation($senseId: UUID!) {
+			addRelations(senseId: $senseId, relations: [{ type: SYNONYM, targetText: "  ", sourceSlug: "user" }]) { id }
+		}
+	`, map[string]interface{}{"senseId": senseID})
+	require.NotEmpty(t, resp.Errors)
+
+	// Delete
+	resp = app.executeGraphQL(t, `
+		mutation($id: UUID!) {
+			deleteRelation(id: $id) { `+senseRelationsFields+` }
+		}
+	`, map[string]interface{}{"id": hot["id"]})
+	require.Empty(t, resp.Errors)
+	relations = extractArray(t, resp.Data, "deleteRelation", "relations")
+	require.Len(t, relations, 2)
+	for _, r := range relations {
+		assert.NotEqual(t, "hot", r.(map[string]interface{})["targetText"])
+	}
+
+	resp = app.executeGraphQLWithError(t, `
+		mutation($id: UUID!) {
+			deleteRelation(id: $id) { id }
+		}
+	`, map[string]interface{}{"id": hot["id"]})
+	require.NotEmpty(t, resp.Errors)
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/heartmarshall/my-english/internal/model"
+	"github.com/heartmarshall/my-english/internal/service/anki"
+	"github.com/heartmarshall/my-english/internal/service/dictionary"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// unsafeFilenameChars — символы, заменяемые в имени скачиваемого файла.
+var unsafeFilenameChars = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+
+// ankiExportHandler отдаёт колоду Anki по пути /export/anki.
+//
+// Параметры запроса:
+//   - ids — ID слов через запятую (нельзя сочетать с фильтром);
+//   - search, language, partOfSpeech, hasCard, cefrLevels (через запятую),
+//     minFrequencyRank, maxFrequencyRank, tag, list — фильтр словаря;
+//   - deck — имя колоды;
+//   - includeScheduling — перенести состояние карточек и историю повторений.
+type ankiExportHandler struct {
+	anki   *anki.Service
+	logger *slog.Logger
+}
+
+// ServeHTTP собирает пакет прямо в ответ. Заголовки отправляются с первой
+// записью пакета, поэтому ошибки валидации ещё отвечают 400.
+func (h *ankiExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	input, err := parseExportQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	deckName := input.DeckName
+	if strings.TrimSpace(deckName) == "" {
+		deckName = anki.DefaultDeckName
+	}
+	filename := strings.Trim(unsafeFilenameChars.ReplaceAllString(deckName, "-"), "-")
+	if filename == "" {
+		filename = "export"
+	}
+
+	out := &lazyHeaderWriter{ResponseWriter: w, setHeaders: func(hdr http.Header) {
+		hdr.Set("Content-Type", "application/octet-stream")
+		hdr.Set("Content-Disposition", fmt.Sprintf(`attachment; filename*=UTF-8''%s.apkg`, url.PathEscape(filename)))
+		hdr.Set("Cache-Control", "no-store")
+	}}
+
+	result, err := h.anki.Export(r.Context(), out, input)
+	if err != nil {
+		if out.written {
+			// Ответ уже начат — остаётся только оборвать его
+			h.logger.Error("anki export interrupted", slog.Any("error", err))
+			panic(http.ErrAbortHandler)
+		}
+		h.writeError(w, r, err)
+		return
+	}
+	h.logger.Info("anki export completed",
+		slog.Int("notes", result.Notes),
+		slog.Int("cards", result.Cards),
+		slog.Int("media", result.Media),
+		slog.Int("warnings", len(result.Warnings)))
+}
+
+// writeError отвечает 400 для ошибок валидации и 500 для остальных.
+func (h *ankiExportHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var vErr *types.ValidationError
+	if errors.As(err, &vErr) {
+		http.Error(w, vErr.Error(), http.StatusBadRequest)
+		return
+	}
+	h.logger.Error("failed to export anki deck", slog.String("path", r.URL.Path), slog.Any("error", err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// parseExportQuery разбирает параметры запроса экспорта.
+func parseExportQuery(q url.Values) (anki.ExportInput, error) {
+	input := anki.ExportInput{DeckName: q.Get("deck")}
+
+	if v := q.Get("includeScheduling"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, types.NewValidationError("includeScheduling", "must be a boolean")
+		}
+		input.IncludeScheduling = b
+	}
+	input.IDs = anki.SplitCommaList(q.Get("ids"))
+
+	var filter dictionary.DictionaryFilter
+	hasFilter := false
+	if v := strings.TrimSpace(q.Get("search")); v != "" {
+		filter.Search, hasFilter = v, true
+	}
+	if v := strings.TrimSpace(q.Get("language")); v != "" {
+		filter.Language, hasFilter = &v, true
+	}
+	if v := strings.TrimSpace(q.Get("partOfSpeech")); v != "" {
+		pos := model.PartOfSpeech(strings.ToUpper(v))
+		filter.PartOfSpeech, hasFilter = &pos, true
+	}
+	if v := q.Get("hasCard"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, types.NewValidationError("hasCard", "must be a boolean")
+		}
+		filter.HasCard, hasFilter = &b, true
+	}
+	if levels := anki.SplitCommaList(q.Get("cefrLevels")); len(levels) > 0 {
+		filter.CefrLevels, hasFilter = levels, true
+	}
+	if v := strings.TrimSpace(q.Get("tag")); v != "" {
+		filter.Tag, hasFilter = &v, true
+	}
+	if v := strings.TrimSpace(q.Get("list")); v != "" {
+		filter.List, hasFilter = &v, true
+	}
+	for name, dst := range map[string]**int{
+		"minFrequencyRank": &filter.MinFrequencyRank,
+		"maxFrequencyRank": &filter.MaxFrequencyRank,
+	} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return input, types.NewValidationError(name, "must be an integer")
+			}
+			*dst, hasFilter = &n, true
+		}
+	}
+	if hasFilter {
+		input.Filter = &filter
+	}
+	return input, nil
+}
+
+// lazyHeaderWriter устанавливает заголовки ответа перед первой записью тела.
+type lazyHeaderWriter struct {
+	http.ResponseWriter
+	setHeaders func(http.Header)
+	written    bool
+}
+
+func (w *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.written = true
+		w.setHeaders(w.Header())
+	}
+	return w.ResponseWriter.Write(p)
+}
@@ -77,7 +77,7 @@ func NewHandlerWithConfig(cfg HandlerConfig) http.Handler {
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.MultipartForm{MaxUploadSize: cfg.Config.GraphQL.MaxUploadSize})
 
 	// 4. Настройка кэширования запросов и сложности
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](cfg.Config.GraphQL.QueryCacheSize))
@@ -112,6 +112,22 @@ func NewHandlerWithConfig(cfg HandlerConfig) http.Handler {
 	// Основной GraphQL Endpoint
 	mux.Handle("/query", srv)
 
+	// Сохранённые изображения и аудио
+	mux.Handle("GET /media/{id}", &mediaHandler{media: cfg.Services.Media, logger: cfg.Logger})
+
+	// Выгрузка словаря колодой Anki
+	mux.Handle("GET /export/anki", &ankiExportHandler{anki: cfg.Services.Anki, logger: cfg.Logger})
+
+	// Резервное копирование (только с токеном доступа)
+	if token := cfg.Config.Backup.Token; token != "" {
+		mux.Handle("GET /backup", requireToken(token, &backupExportHandler{backup: cfg.Services.Backup, logger: cfg.Logger}))
+		mux.Handle("POST /backup/restore", requireToken(token, &backupRestoreHandler{
+			backup:  cfg.Services.Backup,
+			maxSize: cfg.Config.Backup.MaxRestoreSize,
+			logger:  cfg.Logger,
+		}))
+	}
+
 	// 7. Подключение Middleware (порядок важен!)
 	// Middleware применяются в обратном порядке (последний в коде выполняется первым)
 	var handler http.Handler = mux
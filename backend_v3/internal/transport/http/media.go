@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/my-english/internal/service/media"
+	"github.com/heartmarshall/my-english/internal/service/types"
+)
+
+// mediaCacheControl — файлы адресуются по содержимому и никогда не меняются.
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+// mediaHandler отдаёт сохранённые медиафайлы по пути /media/{id}.
+type mediaHandler struct {
+	media  *media.Service
+	logger *slog.Logger
+}
+
+// ServeHTTP отдаёт содержимое файла с типом из метаданных.
+// ETag равен SHA-256 содержимого, поэтому повторные запросы получают 304.
+func (h *mediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	m, err := h.media.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	etag := `"` + m.SHA256 + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", mediaCacheControl)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	_, body, err := h.media.Open(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", m.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(m.SizeBytes, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		h.logger.Warn("media response interrupted", slog.String("media_id", id.String()), slog.Any("error", err))
+	}
+}
+
+// writeError отвечает 404 для отсутствующих файлов и 500 для остальных ошибок.
+func (h *mediaHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, types.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	h.logger.Error("failed to serve media", slog.String("path", r.URL.Path), slog.Any("error", err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Индекс для обратного поиска по переводам (RU -> EN).
+-- Индексируем нормализованное выражение: нижний регистр + ё/е folding.
+-- Выражение должно в точности совпадать с тем, что использует
+-- TranslationRepository.SearchByText, иначе индекс не будет использован.
+CREATE INDEX IF NOT EXISTS ix_translations_text_norm_trgm
+ON translations
+USING GIN ((replace(lower(text), 'ё', 'е')) gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_translations_text_norm_trgm;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Мягкое удаление словарных записей.
+-- Запись с заполненным deleted_at находится в корзине: она исключается из всех
+-- операций чтения, но senses, cards и review_logs сохраняются до окончательной очистки.
+ALTER TABLE dictionary_entries ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Уникальность text_normalized обеспечивается только среди активных записей,
+-- чтобы слово можно было создать заново, пока старая версия лежит в корзине.
+DROP INDEX IF EXISTS ux_dictionary_entries_text_norm;
+CREATE UNIQUE INDEX ux_dictionary_entries_text_norm
+ON dictionary_entries (text_normalized)
+WHERE deleted_at IS NULL;
+
+-- Индекс для просмотра корзины и фоновой очистки.
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_deleted_at
+ON dictionary_entries (deleted_at)
+WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_dictionary_entries_deleted_at;
+DELETE FROM dictionary_entries WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS ux_dictionary_entries_text_norm;
+CREATE UNIQUE INDEX ux_dictionary_entries_text_norm
+ON dictionary_entries (text_normalized);
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Индекс для поиска дубликатов по нормализованному тексту (mergeWords / duplicateCandidates).
+-- Используется оператором % в DictionaryRepository.FindDuplicatePairs.
+-- Частичный: записи из корзины в поиске дубликатов не участвуют.
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_text_norm_trgm
+ON dictionary_entries
+USING GIN (text_normalized gin_trgm_ops)
+WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_dictionary_entries_text_norm_trgm;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- entry_id — слово, к которому относится запись аудита (для вложенных сущностей и карточек).
+-- Позволяет получить историю слова целиком, включая смыслы, примеры и т.д.
+-- Внешнего ключа нет: история должна переживать окончательное удаление слова.
+ALTER TABLE audit_records ADD COLUMN entry_id UUID;
+
+-- snapshot — полный снимок контента слова после изменения (только для записей ENTRY).
+-- Используется restoreWordVersion для восстановления состояния на момент времени.
+ALTER TABLE audit_records ADD COLUMN snapshot JSONB;
+
+-- Заполняем entry_id для существующих записей (по данным, которые ещё есть в базе)
+UPDATE audit_records SET entry_id = entity_id WHERE entity_type = 'ENTRY';
+
+UPDATE audit_records a SET entry_id = s.entry_id
+FROM senses s
+WHERE a.entity_type = 'SENSE' AND a.entity_id = s.id;
+
+UPDATE audit_records a SET entry_id = s.entry_id
+FROM examples e
+JOIN senses s ON s.id = e.sense_id
+WHERE a.entity_type = 'EXAMPLE' AND a.entity_id = e.id;
+
+UPDATE audit_records a SET entry_id = i.entry_id
+FROM images i
+WHERE a.entity_type = 'IMAGE' AND a.entity_id = i.id;
+
+UPDATE audit_records a SET entry_id = p.entry_id
+FROM pronunciations p
+WHERE a.entity_type = 'PRONUNCIATION' AND a.entity_id = p.id;
+
+UPDATE audit_records a SET entry_id = c.entry_id
+FROM cards c
+WHERE a.entity_type = 'CARD' AND a.entity_id = c.id;
+
+CREATE INDEX IF NOT EXISTS ix_audit_records_entry_created
+ON audit_records(entry_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_audit_records_entry_created;
+ALTER TABLE audit_records DROP COLUMN IF EXISTS snapshot;
+ALTER TABLE audit_records DROP COLUMN IF EXISTS entry_id;
@@ -0,0 +1,312 @@
+-- +goose Up
+-- Справочник уровней слов: частотный ранг и уровень CEFR.
+-- Частотный список — около 2000 самых употребительных английских лемм
+-- в порядке убывания частоты (приблизительно, по открытым корпусным спискам).
+-- Уровень CEFR для слов частотного списка оценивается по диапазону ранга,
+-- для остальных берётся из тематических списков ниже (без ранга).
+CREATE TABLE word_levels (
+    word TEXT PRIMARY KEY,
+    frequency_rank INT,
+    cefr_level TEXT NOT NULL CHECK (cefr_level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2'))
+);
+
+CREATE INDEX ix_word_levels_cefr ON word_levels(cefr_level);
+
+-- Частотный список: ранг = позиция слова в списке
+INSERT INTO word_levels (word, frequency_rank, cefr_level)
+SELECT word, rank,
+    CASE
+        WHEN rank <= 500 THEN 'A1'
+        WHEN rank <= 1000 THEN 'A2'
+        WHEN rank <= 1500 THEN 'B1'
+        ELSE 'B2'
+    END
+FROM regexp_split_to_table(btrim('
+the be and of a in to have it i that for you he with on do say this they at we from not by she
+or as what go their can who get if would her all my make about know will up one time there year
+so think when which them some me people take out into just see him your come could now than like
+other how then its our two more these want way look first also new because day use no man find
+here thing give many well only those tell very even back any good woman through us life child
+work down may after should call world over school still try last ask need too feel three state
+never become between high really something most another family own leave put old while mean keep
+student why let great same big group begin seem country help talk where turn problem every start
+hand might american show part against place such again few case week company system each right
+program hear question during play government run small number off always move night live point
+believe hold today bring happen next without before large million must home under water room
+write mother area national money story young fact month different lot study book eye job word
+though business issue side kind four head far black long both little house yes since provide
+service around friend important father sit away until power hour game often yet line political
+end among ever stand bad lose however member pay law meet car city almost include continue set
+later community much name five once white least president learn real change team minute best
+several idea kid body information nothing ago lead social understand whether watch together
+follow parent stop face anything create public already speak others read level allow add office
+spend door health person art sure war history party within grow result open morning walk reason
+low win research girl guy early food moment himself air teacher force offer enough education
+across although remember foot second boy maybe toward able age policy everything love process
+music including consider appear actually buy probably human wait serve market die send expect
+sense build stay fall oh nation plan cut college interest death course someone experience behind
+reach local kill six remain effect yeah suggest class control raise care perhaps late hard field
+else pass former sell major sometimes require along development themselves report role better
+economic effort decide rate strong possible heart drug leader light voice wife whole police mind
+finally pull return free military price less according decision explain son hope develop view
+relationship carry town road drive arm true federal break difference thank receive value
+international building action full model join season society tax director position player agree
+especially record pick wear paper special space ground form support event official whose matter
+everyone center couple site project hit base activity star table court produce eat teach oil
+half situation easy cost industry figure street image itself phone either data cover quite
+picture clear practice piece land recent describe product doctor wall patient worker news test
+movie certain north personal simply third technology catch step baby computer type attention
+draw film tree source red nearly organization choose cause hair century evidence window
+difficult listen soon culture billion chance brother energy period summer realize hundred
+available plant likely opportunity term short letter condition choice single rule daughter
+administration south husband floor campaign material population economy medical hospital church
+close thousand risk current fire future wrong involve defense anyone increase security bank
+myself certainly west sport board seek per subject officer private rest behavior deal
+performance fight throw top quickly past goal bed order author fill represent focus foreign drop
+blood upon agency push nature color recently store reduce sound note fine near movement page
+enter share common poor natural race concern series significant similar hot language usually
+response dead rise animal factor decade article shoot east save seven artist scene stock career
+despite central eight thus treatment beyond happy exactly protect approach lie size dog fund
+serious occur media ready sign thought list individual simple quality pressure accept answer
+resource identify left meeting determine prepare disease whatever success argue cup particularly
+amount ability staff recognize indicate character growth loss degree wonder attack herself
+region television box training pretty trade election everybody physical lay general feeling
+standard bill message fail outside arrive analysis benefit sex forward lawyer present section
+environmental glass skill sister professor operation financial crime stage ok compare authority
+miss design sort act ten knowledge gun station blue strategy clearly discuss indeed truth song
+example democratic check environment leg dark various rather laugh guess executive prove hang
+entire rock forget claim remove manager enjoy network legal religious cold final main science
+green memory card above seat cell establish nice trial expert spring firm radio visit management
+avoid imagine tonight huge ball finish yourself theory impact respond statement maintain charge
+popular traditional onto reveal direction weapon employee cultural contain peace pain apply
+measure wide shake fly interview manage chair fish particular camera structure politics perform
+bit weight suddenly discover candidate production treat trip evening affect inside conference
+unit style adult worry range mention deep edge specific writer trouble necessary throughout
+challenge fear shoulder institution middle sea dream bar beautiful property instead improve
+stuff detail method somebody magazine hotel soldier reflect heavy sexual bag heat marriage tough
+sing surface purpose exist pattern whom skin agent owner machine gas ahead generation commercial
+address cancer item reality coach yard beat violence total tend investment discussion finger
+garden notice collection modern task partner positive civil kitchen consumer shot budget wish
+painting scientist safe agreement capital mouth nor victim newspaper threat responsibility smile
+attorney score account interesting audience rich dinner vote western relate travel debate
+prevent citizen majority none front born admit senior assume wind key professional mission fast
+alone customer suffer speech successful option participant southern fresh eventually forest
+video global senate reform access restaurant judge publish relation release bird opinion credit
+critical corner concerned recall version stare safety effective neighborhood original troop
+income directly hurt species immediately track basic strike sky freedom absolutely plane nobody
+achieve object attitude labor refer concept client powerful perfect nine therefore conduct
+announce conversation examine touch please attend completely vary variety sleep involved
+investigation nuclear researcher press conflict spirit replace british encourage argument camp
+brain feature afternoon weekend dozen possibility insurance department battle beginning date
+generally african sorry crisis complete fan stick define easily hole element vision status
+normal chinese ship solution stone slowly scale university introduce driver attempt park spot
+lack boat drink sun distance wood handle truck mountain survey supposed tradition winter village
+soviet refuse sales roll communication screen gain resident hide gold club farm potential
+european presence independent district shape reader contract crowd christian express apartment
+willing strength previous band obviously horse interested target prison ride guard terms demand
+reporter deliver text tool wild vehicle observe flight facility understanding average emerge
+advantage quick leadership earn pound basis bright operate guest sample contribute tiny block
+protection settle feed collect additional highly identity title mostly lesson faith river
+promote living count unless marry tomorrow technique path ear shop folk principle survive lift
+border competition jump gather limit fit cry equipment worth associate critic warm aspect insist
+failure annual french christmas comment responsible affair procedure regular spread chairman
+baseball soft ignore egg belief demonstrate anybody murder gift religion review editor engage
+coffee document speed cross influence anyway threaten commit female youth wave afraid quarter
+background native broad wonderful deny apparently slightly reaction twice suit perspective
+growing blow construction intelligence destroy cook connection burn shoe grade context committee
+hey mistake location clothes indian quiet dress promise aware neighbor function bone active
+extend chief combine wine below cool voter learning bus hell dangerous remind moral united
+category relatively victory academic internet healthy negative following historical medicine
+tour depend photo finding grab direct classroom contact justice participate daily fair pair
+famous exercise knee flower tape hire familiar appropriate supply fully actor birth search tie
+democracy eastern primary yesterday circle device progress bottom island exchange clean studio
+train lady colleague application neck lean damage plastic tall plate hate otherwise writing male
+alive expression football intend chicken army abuse theater shut map extra session danger
+welcome domestic lots literature rain desire assessment injury respect northern nod paint fuel
+leaf dry russian instruction pool climb sweet engine fourth salt expand importance metal fat
+ticket software disappear corporate strange lip reading urban mental increasingly lunch
+educational somewhere farmer sugar planet favorite explore obtain enemy greatest complex
+surround athlete invite repeat carefully soul scientific impossible panel meaning mom married
+instrument predict weather presidential emotional commitment supreme bear pocket thin
+temperature surprise poll proposal consequence breath sight balance adopt minority straight
+connect works teaching belong aid advice okay photograph empty regional trail novel code somehow
+organize jury breast acknowledge theme storm union desk thanks fruit expensive yellow conclusion
+prime shadow struggle conclude analyst dance regulation being ring largely shift revenue mark
+locate county appearance package difficulty bridge recommend obvious basically email generate
+anymore propose thinking possibly trend visitor loan currently comfortable investor profit angry
+crew accident meal hearing traffic muscle notion capture prefer truly earth japanese chest thick
+cash museum beauty emergency unique internal ethnic link stress content select root nose declare
+appreciate actual bottle hardly setting launch file sick outcome ad defend duty sheet ought
+ensure catholic extremely extent component mix long-term slow contrast zone wake airport brown
+shirt pilot warn ultimately cat contribution capacity ourselves estate guide circumstance snow
+english politician steal pursue slip percentage meat funny neither soil surgery correct blame
+estimate due basketball golf investigate crazy significantly chain branch combination frequently
+governor relief user dad kick manner ancient silence rating golden motion german gender solve
+fee landscape used bowl equal forth frame typical except conservative eliminate host hall trust
+ocean row producer afford meanwhile regime division confirm fix appeal mirror tooth smart length
+entirely rely topic complain variable telephone perception attract confidence bedroom secret
+debt rare tank nurse coverage opposition aside anywhere bond pleasure master era requirement fun
+expectation wing separate somewhat pour stir judgment beer reference tear doubt grant seriously
+minister totally hero industrial cloud stretch winner volume seed surprised fashion pepper busy
+intervention copy tip cheap aim cite welfare vegetable gray dish beach improvement everywhere
+opening overall divide initial terrible oppose contemporary route multiple essential league
+criminal careful core upper rush necessarily specifically tired employ holiday vast resolution
+household fewer abortion apart witness match barely sector representative beneath beside
+incident limited proud flow faculty waste mere increased merely mass emphasize experiment
+definitely bomb enormous tone liberal massive engineer wheel decline invest cable towards expose
+rural narrow secretary gate solid hill typically noise grass unfortunately hat legislation
+succeed celebrate achievement fishing accuse useful reject talent taste characteristic milk
+escape cast sentence unusual closely convince height physician assess plenty virtually addition
+sharp creative lower approve explanation gay campus proper guilty acquire compete technical plus
+immigrant weak illegal hi alternative interaction column personality signal curriculum honor
+passenger assistance forever regard association twenty knock wrap lab display criticism asset
+depression spiritual musical journalist prayer suspect scholar warning climate cheese
+observation childhood payment sir permit cigarette definition priority bread creation graduate
+request emotion scream dramatic universe gap excellent deeply prosecutor lucky drag airline
+library agenda recover factory selection primarily roof unable expense initiative diet arrest
+funding therapy wash schedule sad brief housing post purchase existing steel regarding shout
+remaining visual fairly chip violent silent suppose self bike tea perceive comparison settlement
+layer planning description slide widely wedding inform portion territory immediate opponent
+abandon lake transform tension leading bother consist alcohol enable bend saving desert shall
+error cop arab double sand spanish print preserve passage formal transition existence album
+participation arrange atmosphere joint reply cycle opposite lock deserve consistent resistance
+discovery exposure pose stream sale pot grand mine hello coalition tale knife resolve racial
+phase joke coat mexican symptom manufacturer philosophy potato foundation quote online
+negotiation urge occasion dust breathe elect investigator jacket glad ordinary reduction rarely
+pack suicide numerous substance discipline elsewhere iron practical moreover passion volunteer
+implement essentially gene enforcement sauce independence marketing priest amazing intense
+advance employer shock inspire adjust retire visible kiss illness cap habit competitive juice
+congressional involvement dominate previously whenever transfer analyze attach disaster parking
+prospect boss complaint championship fundamental severe enhance mystery impose poverty entry
+spending king evaluate symbol maker mood accomplish emphasis illustrate boot monitor asian
+entertainment bean evaluation creature commander digital arrangement concentrate usual anger
+psychological heavily peak approximately increasing disorder missile equally vice collapse
+wealth
+', E' \n'), '\s+') WITH ORDINALITY AS t(word, rank);
+
+-- Бытовая лексика, редкая в корпусах, но входящая в базовые учебные списки
+INSERT INTO word_levels (word, cefr_level)
+SELECT word, 'A2'
+FROM regexp_split_to_table(btrim('
+weekly recovery tight cousin virus apple bet relative pink tongue hungry pride doll toy lamp
+sofa blanket pillow towel soap shampoo toothbrush cupboard shelf drawer fork spoon mug kettle
+oven fridge freezer sink tap toilet bath shower carpet curtain ceiling stairs chimney fence
+garage lawn hedge neighbour colour favourite centre theatre metre litre programme cheque
+jewellery aeroplane birthday cake candle balloon invitation celebration festival parade concert
+clap cheer whisper giggle yawn sneeze cough fever headache stomachache toothache flu pill
+dentist clinic ambulance bandage injection vaccine sore ache bleed bruise scratch wound heal
+relax nap nightmare alarm clock calendar diary appointment deadline hurry punctual queue jam
+taxi tram subway underground railway platform luggage suitcase backpack passport visa customs
+tourist compass souvenir postcard stamp envelope parcel mailbox journey voyage cruise excursion
+hike tent campfire torch flashlight rope ladder hammer nail screw drill saw axe shovel rake
+bucket hose brush glue scissors ruler pencil pen eraser sharpener notebook textbook dictionary
+homework exam quiz pupil classmate headmaster principal lecture semester tuition scholarship
+diploma playground gym locker canteen cafeteria lunchbox snack sandwich biscuit cookie candy
+chocolate dessert pie pudding honey butter yogurt cereal porridge toast pancake omelette sausage
+bacon ham steak beef pork lamb turkey duck salmon tuna shrimp crab lobster rice pasta noodle
+soup salad pizza burger chips fries ketchup mustard mayonnaise vinegar spice herb garlic onion
+carrot cabbage lettuce cucumber tomato pea corn mushroom pumpkin spinach broccoli cauliflower
+celery radish beetroot lemon lime orange banana grape peach pear plum cherry strawberry
+raspberry blueberry melon watermelon pineapple mango coconut kiwi apricot fig nut almond peanut
+walnut hazelnut flour dough bakery butcher grocer supermarket cashier receipt discount bargain
+refund wallet purse coin debit mortgage salary wage invoice
+', E' \n'), '\s+') AS word
+ON CONFLICT (word) DO NOTHING;
+
+-- Академическая лексика
+INSERT INTO word_levels (word, cefr_level)
+SELECT word, 'C1'
+FROM regexp_split_to_table(btrim('
+hunt organic psychology distinct dynamic beneficial consult evolution furthermore tactic
+ancestor reservation grocery undergo recipe luxury thread bake imply strain tremendous pile
+tribe drain spectrum crash organism acid sensitive intellectual tube rough slave compound vessel
+inevitable assign relevant exhibit clinical hint sustain flexible mechanism guideline framework
+fiber vertical plea rescue worship rhythm chronic bias constitutional radical precisely
+integrate terror submit inspection fraction sophisticated diagnosis gradually infant laboratory
+conventional pace retain controversial cognitive jurisdiction prominent diversity shelter compel
+pension glimpse anxiety ambition elaborate scandal offensive explicit advocate consensus
+abstract sovereign legitimate intervene interpret notable corridor preliminary contemplate
+vulnerable threshold coherent deteriorate ambiguous allegation accountable ideological subsidy
+hypothesis alliance bureaucracy rhetoric confront domain peer feasible compensate equivalent
+emission inherent chaos accumulate adequate arbitrary discourse empirical paradigm scrutiny
+notorious verdict disposal eligible harsh incentive lever mandate merit predominantly provoke
+reluctant restraint resilient robust speculate stimulus subsequent successor suppress tangible
+transparent turmoil unprecedented utility viable volatile withdraw yield adjacent allocate amend
+anonymous apparatus applicable aspire assert attain attribute authentic bulk cease coincide
+commence commodity comprise concede conceive confine conform consent constrain contend
+contradict controversy convert convey criterion cumulative deduce denote depict deprive derive
+detect deviate devote diminish discrete displace distort diverse dogma drastic dwell elicit
+embody empower encompass endorse enrich entity erode evoke exceed exclude exert exploit
+facilitate finite fluctuate format foster fragment gauge generic hierarchy highlight hinder
+ideology implicit incidence incline inclusive induce infer infrastructure inhibit initiate
+innovate insight integrity intrinsic invoke isolate likewise manipulate marginal maximize
+mediate migrate minimize modify neutral nonetheless norm nevertheless objective offset orient
+overlap paradox parameter persist plausible ponder portray precede predominant presume prevail
+prior proceed prohibit protocol ratio rational recur refine regulate reinforce reluctance render
+resemble restore retrieve revise rigid scenario scope sequence simulate sole specify sphere
+stable straightforward subordinate subsidiary substitute successive sufficient supplement
+suspend sustainable tense terminate thesis trace transmit trigger ultimate undermine underlying
+uniform valid verify versus via violate whereas whereby widespread
+', E' \n'), '\s+') AS word
+ON CONFLICT (word) DO NOTHING;
+
+-- Редкая книжная лексика
+INSERT INTO word_levels (word, cefr_level)
+SELECT word, 'C2'
+FROM regexp_split_to_table(btrim('
+abolish abundant acclaim adamant adept admonish adversary aesthetic affable affluent aggravate
+alleviate aloof ambivalent amiable anecdote animosity antagonize apathy appease arduous
+articulate assiduous astute audacious austere avid banal benevolent bequeath bolster bombastic
+brevity cajole callous candid capricious castigate catalyst caustic censure chicanery
+circumspect clandestine coalesce cogent commensurate complacent concise condone conundrum
+copious corroborate credulous culpable cursory dearth debilitate decorum deference delineate
+denounce deride desultory diatribe didactic diffident digress dilatory discern disdain disparage
+dispel disseminate dissent dissipate docile dogmatic duplicity ebullient eclectic efficacy
+effrontery egregious elated eloquent elucidate embellish emulate endemic enervate engender
+enigma ephemeral equanimity equivocal eradicate erratic erudite esoteric euphemism exacerbate
+exalt exasperate exemplary exonerate expedient extol extraneous facetious fallacy fastidious
+fervent flagrant flippant florid forbearance fortuitous frugal furtive garrulous gregarious
+hackneyed haughty hegemony heresy hubris iconoclast idiosyncrasy ignominious impassive
+impeccable impetuous implacable impudent incessant incisive incongruous indolent ineffable inept
+inexorable ingenuous innocuous insatiable insidious insipid intransigent intrepid inundate
+invective irascible irreverent jovial judicious laconic languid largesse laud lethargic levity
+loquacious lucid magnanimous malevolent malleable maverick meticulous misanthrope mitigate
+mollify morose mundane myriad nefarious negligent nonchalant nuance obdurate obsequious
+obstinate obtuse officious ominous onerous opulent ostentatious paragon partisan paucity
+pedantic penchant penurious perfidious perfunctory pernicious perspicacious pervasive petulant
+philanthropic placate platitude plethora poignant pragmatic precocious predilection prescient
+prodigal prodigious profound profuse proliferate propensity prosaic prudent pugnacious quandary
+querulous quixotic rancor recalcitrant recant reclusive rectitude redundant refute relegate
+reprehensible repudiate rescind resolute reticent reverent sagacious salient sanguine scrupulous
+sedentary serene solicitous soporific sparse spurious squander stoic strident stringent sublime
+substantiate succinct superfluous surreptitious sycophant taciturn tedious temerity tenacious
+tenuous timorous torpid tractable transient trepidation trite truculent ubiquitous unfathomable
+unilateral urbane vacillate venerate veracity verbose vex vicarious vigilant vilify vindicate
+virtuoso vitriolic vociferous wane wary whimsical zealous zenith
+', E' \n'), '\s+') AS word
+ON CONFLICT (word) DO NOTHING;
+
+-- Аннотация словарных записей: заполняется сервисом при создании и смене текста
+ALTER TABLE dictionary_entries
+    ADD COLUMN frequency_rank INT,
+    ADD COLUMN cefr_level TEXT;
+
+UPDATE dictionary_entries de
+SET frequency_rank = wl.frequency_rank,
+    cefr_level = wl.cefr_level
+FROM word_levels wl
+WHERE wl.word = de.text_normalized;
+
+-- Фильтры WordFilter по уровню и частотному диапазону
+CREATE INDEX ix_dictionary_entries_cefr_level ON dictionary_entries(cefr_level) WHERE deleted_at IS NULL;
+CREATE INDEX ix_dictionary_entries_frequency_rank ON dictionary_entries(frequency_rank) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_dictionary_entries_frequency_rank;
+DROP INDEX IF EXISTS ix_dictionary_entries_cefr_level;
+
+ALTER TABLE dictionary_entries
+    DROP COLUMN IF EXISTS cefr_level,
+    DROP COLUMN IF EXISTS frequency_rank;
+
+DROP TABLE IF EXISTS word_levels;
@@ -0,0 +1,90 @@
+-- +goose Up
+-- Язык словарной записи (изучаемый язык, ISO 639) и язык перевода.
+-- Существующие данные — англо-русский словарь.
+ALTER TABLE dictionary_entries ADD COLUMN language TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE translations ADD COLUMN language TEXT NOT NULL DEFAULT 'ru';
+
+-- Одно и то же написание может быть словом в разных языках ("gift" в en и de),
+-- поэтому уникальность text_normalized — в пределах языка.
+DROP INDEX IF EXISTS ux_dictionary_entries_text_norm;
+
+-- Пересчитываем text_normalized существующих (английских) слов так же,
+-- как textnorm.Normalize("en", text): NFKC, нижний регистр, схлопывание
+-- пробелов, снятие диакритики ("Café" -> "cafe"). Диакритика снимается
+-- удалением комбинируемых знаков после NFD; диапазоны покрывают знаки,
+-- встречающиеся в латинице.
+UPDATE dictionary_entries
+SET text_normalized = normalize(
+    regexp_replace(
+        normalize(btrim(regexp_replace(lower(normalize(text, NFKC)), '\s+', ' ', 'g')), NFD),
+        '[\u0300-\u036f\u1ab0-\u1aff\u1dc0-\u1dff\u20d0-\u20ff\ufe20-\ufe2f]', '', 'g'
+    ),
+    NFC
+);
+
+-- Слова, различавшиеся только диакритикой ("café" и "cafe"), теперь совпадают.
+-- Автоматически выбрать одно из них нельзя: их нужно объединить до миграции.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(text_normalized, ', ' ORDER BY text_normalized) INTO duplicates
+    FROM (
+        SELECT text_normalized
+        FROM dictionary_entries
+        WHERE deleted_at IS NULL
+        GROUP BY text_normalized
+        HAVING COUNT(*) > 1
+    ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'entries collide after normalization: %; merge them before migrating', duplicates;
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+CREATE UNIQUE INDEX ux_dictionary_entries_text_norm
+ON dictionary_entries (language, text_normalized)
+WHERE deleted_at IS NULL;
+
+-- Обратный поиск по переводам нормализует их как textnorm.Normalize("ru", text):
+-- к регистру и ё/е добавляется NFKC. Выражение должно в точности совпадать
+-- с TranslationRepository.SearchByText.
+DROP INDEX IF EXISTS ix_translations_text_norm_trgm;
+CREATE INDEX ix_translations_text_norm_trgm
+ON translations
+USING GIN ((replace(lower(normalize(text, NFKC)), 'ё', 'е')) gin_trgm_ops);
+
+-- Фильтры словаря и очередь изучения по языку
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_language
+ON dictionary_entries (language)
+WHERE deleted_at IS NULL;
+
+-- +goose Down
+-- Откат возможен только для англо-русского словаря: слова других языков
+-- и переводы не на русский потеряли бы язык, а не-английские слова могли бы
+-- нарушить глобальную уникальность text_normalized. Их нужно удалить вручную.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM dictionary_entries WHERE language <> 'en') THEN
+        RAISE EXCEPTION 'cannot roll back: dictionary has non-English entries';
+    END IF;
+    IF EXISTS (SELECT 1 FROM translations WHERE language <> 'ru') THEN
+        RAISE EXCEPTION 'cannot roll back: dictionary has non-Russian translations';
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS ix_dictionary_entries_language;
+DROP INDEX IF EXISTS ix_translations_text_norm_trgm;
+CREATE INDEX ix_translations_text_norm_trgm
+ON translations
+USING GIN ((replace(lower(text), 'ё', 'е')) gin_trgm_ops);
+DROP INDEX IF EXISTS ux_dictionary_entries_text_norm;
+CREATE UNIQUE INDEX ux_dictionary_entries_text_norm
+ON dictionary_entries (text_normalized)
+WHERE deleted_at IS NULL;
+ALTER TABLE translations DROP COLUMN IF EXISTS language;
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS language;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Личные заметки и мнемоники в Markdown к словам и смыслам.
+-- notes_on_card — показывать заметки слова на обратной стороне карточки.
+ALTER TABLE dictionary_entries ADD COLUMN notes TEXT;
+ALTER TABLE dictionary_entries ADD COLUMN notes_on_card BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE senses ADD COLUMN notes TEXT;
+
+-- Полнотекстовый поиск по заметкам (DictionaryRepository.applyFilters).
+-- Конфигурация 'simple': заметки пишутся на разных языках, стемминг не нужен.
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_notes_fts
+ON dictionary_entries
+USING GIN (to_tsvector('simple', coalesce(notes, '')));
+
+CREATE INDEX IF NOT EXISTS ix_senses_notes_fts
+ON senses
+USING GIN (to_tsvector('simple', coalesce(notes, '')));
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_senses_notes_fts;
+DROP INDEX IF EXISTS ix_dictionary_entries_notes_fts;
+ALTER TABLE senses DROP COLUMN IF EXISTS notes;
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS notes_on_card;
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS notes;
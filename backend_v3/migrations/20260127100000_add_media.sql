@@ -0,0 +1,25 @@
+-- +goose Up
+-- Медиафайлы (изображения и аудио произношений), сохранённые в хранилище приложения.
+-- Файлы дедуплицируются по SHA-256 содержимого; ключ в хранилище выводится из хеша.
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sha256 TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    source_url TEXT, -- Откуда файл был скачан (NULL для загрузок)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Ссылки контента на сохранённые файлы. url/audio_url остаются как исходный адрес.
+ALTER TABLE images ADD COLUMN media_id UUID REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE pronunciations ADD COLUMN media_id UUID REFERENCES media(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS ix_images_media_id ON images(media_id) WHERE media_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS ix_pronunciations_media_id ON pronunciations(media_id) WHERE media_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_pronunciations_media_id;
+DROP INDEX IF EXISTS ix_images_media_id;
+ALTER TABLE pronunciations DROP COLUMN IF EXISTS media_id;
+ALTER TABLE images DROP COLUMN IF EXISTS media_id;
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Обработка изображений: размеры оригинала и уменьшенные варианты.
+-- Варианты — обычные записи media (дедуплицируются и отдаются тем же маршрутом).
+-- processed_at IS NULL означает, что изображение ждёт фоновой обработки.
+ALTER TABLE media ADD COLUMN width INT CHECK (width > 0);
+ALTER TABLE media ADD COLUMN height INT CHECK (height > 0);
+ALTER TABLE media ADD COLUMN thumbnail_id UUID REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE media ADD COLUMN card_id UUID REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE media ADD COLUMN processed_at TIMESTAMPTZ;
+ALTER TABLE media ADD COLUMN processing_error TEXT; -- Почему изображение не удалось обработать
+
+-- Очередь фоновой обработки (MediaRepository.ListUnprocessedImages)
+CREATE INDEX IF NOT EXISTS ix_media_unprocessed_images
+ON media(created_at)
+WHERE processed_at IS NULL AND content_type LIKE 'image/%';
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_media_unprocessed_images;
+ALTER TABLE media DROP COLUMN IF EXISTS processing_error;
+ALTER TABLE media DROP COLUMN IF EXISTS processed_at;
+ALTER TABLE media DROP COLUMN IF EXISTS card_id;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_id;
+ALTER TABLE media DROP COLUMN IF EXISTS height;
+ALTER TABLE media DROP COLUMN IF EXISTS width;
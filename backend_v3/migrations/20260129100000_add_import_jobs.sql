@@ -0,0 +1,39 @@
+-- +goose Up
+-- Фоновые импорты слов из файлов (CSV/TSV).
+-- Файл разбирается и проверяется при создании задания; rows хранит строки,
+-- подготовленные к записи, а processed_rows — сколько из них уже записано.
+-- Строки записываются пачками, прогресс сохраняется после каждой пачки,
+-- поэтому прерванный импорт продолжается с того же места.
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    format TEXT NOT NULL, -- csv, tsv
+    status TEXT NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED')),
+    options JSONB NOT NULL DEFAULT '{}', -- Язык, политика дубликатов, создание карточек
+    rows JSONB NOT NULL DEFAULT '[]',
+    total_rows INT NOT NULL DEFAULT 0 CHECK (total_rows >= 0),
+    processed_rows INT NOT NULL DEFAULT 0 CHECK (processed_rows >= 0 AND processed_rows <= total_rows),
+    created_count INT NOT NULL DEFAULT 0,
+    merged_count INT NOT NULL DEFAULT 0,
+    skipped_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    row_errors JSONB NOT NULL DEFAULT '[]', -- [{line, message}]
+    error TEXT, -- Почему задание прервано (status = FAILED)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+-- Очередь фоновой обработки (ImportJobRepository.ListUnfinished)
+CREATE INDEX IF NOT EXISTS ix_import_jobs_unfinished
+ON import_jobs(created_at)
+WHERE status IN ('PENDING', 'RUNNING');
+
+CREATE TRIGGER trg_import_jobs_updated
+BEFORE UPDATE ON import_jobs
+FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_import_jobs_updated ON import_jobs;
+DROP INDEX IF EXISTS ix_import_jobs_unfinished;
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Отметки инкрементального импорта: до какого момента данные источника
+-- уже импортированы. Повторный импорт того же файла (например, vocab.db
+-- Kindle) берёт только записи новее отметки.
+CREATE TABLE IF NOT EXISTS import_checkpoints (
+    source TEXT PRIMARY KEY, -- kindle
+    imported_until TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_import_checkpoints_updated
+BEFORE UPDATE ON import_checkpoints
+FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_import_checkpoints_updated ON import_checkpoints;
+DROP TABLE IF EXISTS import_checkpoints;
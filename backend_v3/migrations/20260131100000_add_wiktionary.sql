@@ -0,0 +1,23 @@
+-- +goose Up
+-- Офлайн-словарь из дампа Викисловаря (Wiktextract, https://kaikki.org).
+-- Статья — слово одной части речи; значения, произношение и словоформы
+-- хранятся в JSONB в формате сервиса wiktionary. Таблица заполняется
+-- командой wiktionaryimport: повторный импорт языка заменяет его статьи.
+CREATE TABLE IF NOT EXISTS wiktionary_entries (
+    id BIGSERIAL PRIMARY KEY, -- Порядок статей в дампе
+    language TEXT NOT NULL, -- Код языка слова (ISO 639)
+    word TEXT NOT NULL,
+    word_normalized TEXT NOT NULL,
+    pos TEXT NOT NULL, -- Часть речи как в дампе: noun, verb, adj, name...
+    senses JSONB NOT NULL DEFAULT '[]', -- [{definition, examples, translations}]
+    sounds JSONB NOT NULL DEFAULT '[]', -- [{ipa, audio_url, region}]
+    forms JSONB NOT NULL DEFAULT '[]', -- [{form, tags}]
+    synonyms TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS ix_wiktionary_entries_word
+ON wiktionary_entries(language, word_normalized);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_wiktionary_entries_word;
+DROP TABLE IF EXISTS wiktionary_entries;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Связи смыслов со словами: синонимы, антонимы, гиперонимы (WordNet и др.).
+-- Связь хранит текст слова, а не ID записи: слово может ещё не быть
+-- в словаре, а запись находится по нормализованному тексту в языке
+-- записи смысла при чтении (SenseRelationRepository.ListTargets).
+CREATE TABLE IF NOT EXISTS sense_relations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sense_id UUID NOT NULL REFERENCES senses(id) ON DELETE CASCADE,
+    type TEXT NOT NULL
+        CHECK (type IN ('SYNONYM', 'ANTONYM', 'RELATED', 'COLLOCATION', 'HYPERNYM')),
+    target_text TEXT NOT NULL,
+    target_text_normalized TEXT NOT NULL,
+    source_slug TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (sense_id, type, target_text_normalized)
+);
+
+-- Поиск связей, ведущих к слову
+CREATE INDEX IF NOT EXISTS ix_sense_relations_target
+ON sense_relations(target_text_normalized);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_sense_relations_target;
+DROP TABLE IF EXISTS sense_relations;
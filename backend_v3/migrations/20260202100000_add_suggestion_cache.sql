@@ -0,0 +1,21 @@
+-- +goose Up
+-- Кэш ответов провайдеров подсказок: повторные запросы слова не ходят
+-- во внешние API. result — ответ провайдера в JSONB; NULL — провайдер
+-- слова не знает (отрицательный кэш). Строки после expires_at не читаются
+-- и перезаписываются при следующем запросе.
+CREATE TABLE IF NOT EXISTS suggestion_cache (
+    provider TEXT NOT NULL, -- Slug провайдера
+    text_normalized TEXT NOT NULL,
+    result JSONB,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (provider, text_normalized)
+);
+
+-- Очистка устаревших записей
+CREATE INDEX IF NOT EXISTS ix_suggestion_cache_expires_at
+ON suggestion_cache(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_suggestion_cache_expires_at;
+DROP TABLE IF EXISTS suggestion_cache;
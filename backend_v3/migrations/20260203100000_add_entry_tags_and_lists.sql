@@ -0,0 +1,22 @@
+-- +goose Up
+-- Метки слова (сколько угодно) и список, в котором оно лежит (не больше одного).
+-- Назначаются массовыми операциями bulkTag и bulkMoveToList и используются
+-- в фильтре словаря. Метки хранятся отсортированными и без повторов.
+ALTER TABLE dictionary_entries ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE dictionary_entries ADD COLUMN list TEXT;
+
+-- Фильтр по метке (tags @> ARRAY[...])
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_tags
+ON dictionary_entries USING GIN (tags)
+WHERE deleted_at IS NULL;
+
+-- Фильтр по списку и перечень списков
+CREATE INDEX IF NOT EXISTS ix_dictionary_entries_list
+ON dictionary_entries (list)
+WHERE deleted_at IS NULL AND list IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_dictionary_entries_list;
+DROP INDEX IF EXISTS ix_dictionary_entries_tags;
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS list;
+ALTER TABLE dictionary_entries DROP COLUMN IF EXISTS tags;
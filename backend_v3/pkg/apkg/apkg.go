@@ -0,0 +1,439 @@
+// Package apkg читает и пишет пакеты колод Anki (.apkg).
+//
+// Пакет — это zip-архив с коллекцией SQLite (collection.anki21 или
+// collection.anki2), файлом media (JSON: номер файла в архиве → имя) и самими
+// медиафайлами под номерами. Формат collection.anki21b (zstd + protobuf),
+// который Anki 2.1.50+ пишет по умолчанию, не поддерживается: такие колоды
+// нужно экспортировать с флажком «Support older Anki versions».
+package apkg
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/pkg/sqlitefile"
+)
+
+const (
+	// FieldSeparator разделяет значения полей в notes.flds.
+	FieldSeparator = "\x1f"
+
+	// MaxCollectionSize — максимальный размер распакованной коллекции.
+	MaxCollectionSize = 512 << 20
+)
+
+// Типы карточек (cards.type).
+const (
+	CardTypeNew        = 0
+	CardTypeLearning   = 1
+	CardTypeReview     = 2
+	CardTypeRelearning = 3
+)
+
+// Очереди карточек (cards.queue).
+const (
+	QueueSuspended = -1
+	QueueNew       = 0
+	QueueLearning  = 1
+	QueueReview    = 2
+	QueueDayLearn  = 3
+)
+
+// Типы записей истории повторений (revlog.type).
+const (
+	ReviewLearn       = 0
+	ReviewReview      = 1
+	ReviewRelearn     = 2
+	ReviewFiltered    = 3
+	ReviewManual      = 4
+	ReviewRescheduled = 5
+)
+
+var (
+	// ErrUnsupportedFormat возвращается для пакетов только в формате anki21b.
+	ErrUnsupportedFormat = errors.New("apkg: package uses the new collection format; export it with \"Support older Anki versions\" enabled")
+
+	// ErrInvalidPackage возвращается, если архив не похож на пакет Anki.
+	ErrInvalidPackage = errors.New("apkg: invalid package")
+)
+
+// Package — содержимое пакета Anki.
+type Package struct {
+	Created   time.Time // Дата создания коллекции (начало отсчёта дней в cards.due)
+	SchedV1   bool      // Коллекция использует старый планировщик v1
+	NoteTypes map[int64]*NoteType
+	Notes     []Note
+	Cards     []Card
+	Reviews   []Review
+
+	media map[string]*zip.File // Имя медиафайла → файл в архиве
+}
+
+// NoteType — тип записи с именами полей.
+type NoteType struct {
+	ID     int64
+	Name   string
+	Fields []string // Имена полей в порядке ord
+
+	// Заполняются только для записи пакета
+	Templates []Template
+	CSS       string
+}
+
+// FieldIndex возвращает индекс поля по имени (без учёта регистра) или -1.
+func (nt *NoteType) FieldIndex(name string) int {
+	for i, f := range nt.Fields {
+		if strings.EqualFold(strings.TrimSpace(f), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Note — запись Anki.
+type Note struct {
+	ID     int64
+	GUID   string // Глобальный ID: по нему Anki узнаёт запись при повторном импорте
+	TypeID int64
+	Fields []string // Значения полей в HTML
+	Tags   []string
+}
+
+// Card — карточка записи с её состоянием планировщика.
+type Card struct {
+	ID       int64
+	NoteID   int64
+	Ord      int   // Номер шаблона карточки
+	Type     int   // CardType*
+	Queue    int   // Queue*; отрицательные — отложена или скрыта
+	Due      int64 // Для review — номер дня от Created, для learning — unix-время
+	Interval int   // Дни; отрицательные — секунды (в обучении)
+	Factor   int   // Лёгкость в промилле (2500 = 2.5)
+	Reps     int
+	Lapses   int
+}
+
+// Review — запись истории повторений.
+type Review struct {
+	ID         int64 // Время повторения в миллисекундах unix
+	CardID     int64
+	Ease       int // Кнопка ответа: 1–4, 0 — ручное изменение
+	Interval   int
+	Factor     int
+	DurationMs int
+	Type       int // Review*
+}
+
+// ReviewedAt возвращает время повторения.
+func (r Review) ReviewedAt() time.Time {
+	return time.UnixMilli(r.ID)
+}
+
+// ============================================================================
+// OPEN
+// ============================================================================
+
+// Open читает пакет из r размера size.
+func Open(r io.ReaderAt, size int64) (*Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	// anki21 приоритетнее: в пакетах с поддержкой старых версий рядом лежит
+	// collection.anki2 с заглушкой «обновите Anki»
+	collection := files["collection.anki21"]
+	if collection == nil {
+		if files["collection.anki21b"] != nil {
+			return nil, ErrUnsupportedFormat
+		}
+		collection = files["collection.anki2"]
+	}
+	if collection == nil {
+		return nil, fmt.Errorf("%w: collection not found", ErrInvalidPackage)
+	}
+
+	data, err := readZipFile(collection, MaxCollectionSize)
+	if err != nil {
+		return nil, fmt.Errorf("read collection: %w", err)
+	}
+	db, err := sqlitefile.Open(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	pkg := &Package{media: make(map[string]*zip.File)}
+	if err := pkg.readMediaMap(files); err != nil {
+		return nil, err
+	}
+	if err := pkg.readCollection(db); err != nil {
+		return nil, err
+	}
+	if err := pkg.readNoteTypes(db); err != nil {
+		return nil, err
+	}
+	if err := pkg.readNotes(db); err != nil {
+		return nil, err
+	}
+	if err := pkg.readCards(db); err != nil {
+		return nil, err
+	}
+	if err := pkg.readReviews(db); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// OpenMedia открывает медиафайл по имени, под которым он упомянут в полях.
+// Возвращает false, если файла нет в пакете.
+func (p *Package) OpenMedia(name string) (io.ReadCloser, bool, error) {
+	f, ok := p.media[name]
+	if !ok {
+		return nil, false, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, true, fmt.Errorf("open media %q: %w", name, err)
+	}
+	return rc, true, nil
+}
+
+// MediaNames возвращает имена всех медиафайлов пакета.
+func (p *Package) MediaNames() []string {
+	names := make([]string, 0, len(p.media))
+	for name := range p.media {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CardsByNote группирует карточки по записям.
+func (p *Package) CardsByNote() map[int64][]Card {
+	result := make(map[int64][]Card, len(p.Notes))
+	for _, c := range p.Cards {
+		result[c.NoteID] = append(result[c.NoteID], c)
+	}
+	return result
+}
+
+// ReviewsByCard группирует историю повторений по карточкам (по возрастанию времени).
+func (p *Package) ReviewsByCard() map[int64][]Review {
+	result := make(map[int64][]Review)
+	for _, r := range p.Reviews {
+		result[r.CardID] = append(result[r.CardID], r)
+	}
+	return result
+}
+
+// ============================================================================
+// COLLECTION
+// ============================================================================
+
+// readMediaMap читает JSON-карту медиафайлов.
+func (p *Package) readMediaMap(files map[string]*zip.File) error {
+	f := files["media"]
+	if f == nil {
+		return nil
+	}
+	data, err := readZipFile(f, MaxCollectionSize)
+	if err != nil {
+		return fmt.Errorf("read media map: %w", err)
+	}
+
+	var mapping map[string]string
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		// В новом формате карта медиафайлов — protobuf, сжатый zstd
+		return ErrUnsupportedFormat
+	}
+	for num, name := range mapping {
+		if zf := files[num]; zf != nil && name != "" {
+			p.media[name] = zf
+		}
+	}
+	return nil
+}
+
+// readCollection читает дату создания и версию планировщика из таблицы col.
+func (p *Package) readCollection(db *sqlitefile.DB) error {
+	return db.Rows("col", func(r sqlitefile.Row) error {
+		p.Created = time.Unix(r.IntBy("crt"), 0)
+
+		var conf struct {
+			SchedVer int `json:"schedVer"`
+		}
+		if raw := r.TextBy("conf"); raw != "" {
+			_ = json.Unmarshal([]byte(raw), &conf)
+		}
+		p.SchedV1 = conf.SchedVer <= 1
+		if db.HasTable("config") {
+			// Схема 18 хранит настройки в отдельной таблице и всегда использует v2+
+			p.SchedV1 = false
+		}
+		return nil
+	})
+}
+
+// readNoteTypes читает типы записей: из JSON в col.models (схема 11)
+// или из таблиц notetypes и fields (схема 18).
+func (p *Package) readNoteTypes(db *sqlitefile.DB) error {
+	p.NoteTypes = make(map[int64]*NoteType)
+
+	if db.HasTable("notetypes") && db.HasTable("fields") {
+		if err := db.Rows("notetypes", func(r sqlitefile.Row) error {
+			id := r.IntBy("id")
+			p.NoteTypes[id] = &NoteType{ID: id, Name: r.TextBy("name")}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("read notetypes: %w", err)
+		}
+
+		type field struct {
+			ord  int64
+			name string
+		}
+		fields := make(map[int64][]field)
+		if err := db.Rows("fields", func(r sqlitefile.Row) error {
+			id := r.IntBy("ntid")
+			fields[id] = append(fields[id], field{ord: r.IntBy("ord"), name: r.TextBy("name")})
+			return nil
+		}); err != nil {
+			return fmt.Errorf("read fields: %w", err)
+		}
+		for id, ff := range fields {
+			nt := p.NoteTypes[id]
+			if nt == nil {
+				continue
+			}
+			sort.Slice(ff, func(i, j int) bool { return ff[i].ord < ff[j].ord })
+			for _, f := range ff {
+				nt.Fields = append(nt.Fields, f.name)
+			}
+		}
+		return nil
+	}
+
+	return db.Rows("col", func(r sqlitefile.Row) error {
+		var models map[string]struct {
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+				Ord  int    `json:"ord"`
+			} `json:"flds"`
+		}
+		if err := json.Unmarshal([]byte(r.TextBy("models")), &models); err != nil {
+			return fmt.Errorf("%w: note types: %v", ErrInvalidPackage, err)
+		}
+		for key, m := range models {
+			id, err := strconv.ParseInt(key, 10, 64)
+			if err != nil {
+				continue
+			}
+			flds := m.Fields
+			sort.Slice(flds, func(i, j int) bool { return flds[i].Ord < flds[j].Ord })
+			nt := &NoteType{ID: id, Name: m.Name}
+			for _, f := range flds {
+				nt.Fields = append(nt.Fields, f.Name)
+			}
+			p.NoteTypes[id] = nt
+		}
+		return nil
+	})
+}
+
+// readNotes читает записи.
+func (p *Package) readNotes(db *sqlitefile.DB) error {
+	err := db.Rows("notes", func(r sqlitefile.Row) error {
+		p.Notes = append(p.Notes, Note{
+			ID:     r.IntBy("id"),
+			GUID:   r.TextBy("guid"),
+			TypeID: r.IntBy("mid"),
+			Fields: strings.Split(r.TextBy("flds"), FieldSeparator),
+			Tags:   strings.Fields(r.TextBy("tags")),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read notes: %w", err)
+	}
+	return nil
+}
+
+// readCards читает карточки.
+func (p *Package) readCards(db *sqlitefile.DB) error {
+	err := db.Rows("cards", func(r sqlitefile.Row) error {
+		p.Cards = append(p.Cards, Card{
+			ID:       r.IntBy("id"),
+			NoteID:   r.IntBy("nid"),
+			Ord:      int(r.IntBy("ord")),
+			Type:     int(r.IntBy("type")),
+			Queue:    int(r.IntBy("queue")),
+			Due:      r.IntBy("due"),
+			Interval: int(r.IntBy("ivl")),
+			Factor:   int(r.IntBy("factor")),
+			Reps:     int(r.IntBy("reps")),
+			Lapses:   int(r.IntBy("lapses")),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read cards: %w", err)
+	}
+	return nil
+}
+
+// readReviews читает историю повторений.
+func (p *Package) readReviews(db *sqlitefile.DB) error {
+	if !db.HasTable("revlog") {
+		return nil
+	}
+	err := db.Rows("revlog", func(r sqlitefile.Row) error {
+		p.Reviews = append(p.Reviews, Review{
+			ID:         r.IntBy("id"),
+			CardID:     r.IntBy("cid"),
+			Ease:       int(r.IntBy("ease")),
+			Interval:   int(r.IntBy("ivl")),
+			Factor:     int(r.IntBy("factor")),
+			DurationMs: int(r.IntBy("time")),
+			Type:       int(r.IntBy("type")),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read revlog: %w", err)
+	}
+	return nil
+}
+
+// readZipFile читает файл архива целиком, не больше limit байт.
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, limit)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,161 @@
+package apkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func openDeck(t *testing.T) *Package {
+	t.Helper()
+	f, err := os.Open("testdata/deck.apkg")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	pkg, err := Open(f, info.Size())
+	if err != nil {
+		t.Fatalf("anki.Open() error = %v", err)
+	}
+	return pkg
+}
+
+func TestOpen(t *testing.T) {
+	pkg := openDeck(t)
+
+	if !pkg.Created.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Created = %v", pkg.Created)
+	}
+	if pkg.SchedV1 {
+		t.Error("SchedV1 = true, want false")
+	}
+
+	basic := pkg.NoteTypes[1001]
+	if basic == nil || !reflect.DeepEqual(basic.Fields, []string{"Front", "Back"}) {
+		t.Fatalf("Basic note type = %+v, want fields [Front Back]", basic)
+	}
+	if basic.FieldIndex("back") != 1 || basic.FieldIndex("Missing") != -1 {
+		t.Errorf("FieldIndex() = %d, %d", basic.FieldIndex("back"), basic.FieldIndex("Missing"))
+	}
+
+	if len(pkg.Notes) != 4 {
+		t.Fatalf("len(Notes) = %d, want 4", len(pkg.Notes))
+	}
+	apple := pkg.Notes[0]
+	if apple.ID != 2001 || apple.TypeID != 1001 || !reflect.DeepEqual(apple.Fields, []string{"apple", "яблоко<br>яблоня"}) {
+		t.Errorf("Notes[0] = %+v", apple)
+	}
+	if !reflect.DeepEqual(apple.Tags, []string{"vocab", "fruit"}) {
+		t.Errorf("Tags = %v", apple.Tags)
+	}
+
+	cards := pkg.CardsByNote()[2001]
+	if len(cards) != 2 {
+		t.Fatalf("cards of note 2001 = %d, want 2", len(cards))
+	}
+	if c := cards[0]; c.Type != CardTypeReview || c.Interval != 10 || c.Factor != 2300 || c.Due != 100 {
+		t.Errorf("review card = %+v", c)
+	}
+
+	reviews := pkg.ReviewsByCard()[3001]
+	if len(reviews) != 4 {
+		t.Fatalf("reviews of card 3001 = %d, want 4", len(reviews))
+	}
+	if r := reviews[0]; r.Ease != 1 || r.DurationMs != 8000 || !r.ReviewedAt().Equal(time.UnixMilli(1700100000000)) {
+		t.Errorf("first review = %+v", r)
+	}
+}
+
+func TestPackage_Media(t *testing.T) {
+	pkg := openDeck(t)
+
+	if names := pkg.MediaNames(); !reflect.DeepEqual(names, []string{"seren.mp3", "seren.png"}) {
+		t.Errorf("MediaNames() = %v", names)
+	}
+
+	rc, ok, err := pkg.OpenMedia("seren.mp3")
+	if err != nil || !ok {
+		t.Fatalf("OpenMedia() = %v, %v", ok, err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if !bytes.HasPrefix(data, []byte("ID3")) {
+		t.Errorf("media content = %q...", data[:min(len(data), 8)])
+	}
+
+	if _, ok, _ := pkg.OpenMedia("missing.mp3"); ok {
+		t.Error("OpenMedia(missing) ok = true")
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	zipOf := func(files map[string]string) *bytes.Reader {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			w, _ := zw.Create(name)
+			w.Write([]byte(content))
+		}
+		zw.Close()
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	tests := []struct {
+		name string
+		r    *bytes.Reader
+		want error
+	}{
+		{name: "not a zip", r: bytes.NewReader([]byte("plain text")), want: ErrInvalidPackage},
+		{name: "no collection", r: zipOf(map[string]string{"media": "{}"}), want: ErrInvalidPackage},
+		{name: "new format", r: zipOf(map[string]string{"collection.anki21b": "zstd"}), want: ErrUnsupportedFormat},
+		{name: "not sqlite", r: zipOf(map[string]string{"collection.anki2": "garbage"}), want: ErrInvalidPackage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Open(tt.r, tt.r.Size())
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Open() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "<b>banana</b>", want: "banana"},
+		{in: "яблоко<br>яблоня", want: "яблоко\nяблоня"},
+		{in: "<div>one</div><div>two&nbsp; three</div>", want: "one\ntwo three"},
+		{in: "&quot;happy accident&quot;", want: `"happy accident"`},
+		{in: "word [sound:word.mp3]", want: "word"},
+		{in: "The {{c1::cat::animal}} sat", want: "The cat sat"},
+		{in: "&nbsp;", want: ""},
+	}
+	for _, tt := range tests {
+		if got := PlainText(tt.in); got != tt.want {
+			t.Errorf("PlainText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMediaRefs(t *testing.T) {
+	field := `[sound:a.mp3] text <img src="b.png"> <IMG class=x src='c d.jpg'/> <img src=e.gif>[sound:f&amp;g.ogg]`
+
+	if got := SoundRefs(field); !reflect.DeepEqual(got, []string{"a.mp3", "f&g.ogg"}) {
+		t.Errorf("SoundRefs() = %v", got)
+	}
+	if got := ImageRefs(field); !reflect.DeepEqual(got, []string{"b.png", "c d.jpg", "e.gif"}) {
+		t.Errorf("ImageRefs() = %v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package apkg
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	soundRe     = regexp.MustCompile(`\[sound:([^\]]+)\]`)
+	imageRe     = regexp.MustCompile(`(?i)<img[^>]*?\ssrc\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	lineBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</(?:div|p|li|tr)>`)
+	tagRe       = regexp.MustCompile(`(?s)<[^>]*>`)
+	clozeRe     = regexp.MustCompile(`\{\{c\d+::(.*?)(?:::[^}]*)?\}\}`)
+	spaceRe     = regexp.MustCompile(`[ \t\x{00a0}]+`)
+)
+
+// PlainText превращает HTML поля в обычный текст: переводы строк сохраняются,
+// теги, ссылки на звук и разметка cloze удаляются, сущности раскодируются.
+func PlainText(field string) string {
+	s := soundRe.ReplaceAllString(field, "")
+	s = clozeRe.ReplaceAllString(s, "$1")
+	s = lineBreakRe.ReplaceAllString(s, "\n")
+	s = tagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	result := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimSpace(spaceRe.ReplaceAllString(line, " "))
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// SoundRefs возвращает имена звуковых файлов из ссылок [sound:...].
+func SoundRefs(field string) []string {
+	var names []string
+	for _, m := range soundRe.FindAllStringSubmatch(field, -1) {
+		names = append(names, html.UnescapeString(strings.TrimSpace(m[1])))
+	}
+	return names
+}
+
+// ImageRefs возвращает имена файлов изображений из тегов <img src>.
+func ImageRefs(field string) []string {
+	var names []string
+	for _, m := range imageRe.FindAllStringSubmatch(field, -1) {
+		name := m[1] + m[2] + m[3]
+		if name != "" {
+			names = append(names, html.UnescapeString(name))
+		}
+	}
+	return names
+}
+
+// HTMLText превращает обычный текст в значение поля: спецсимволы
+// экранируются, переводы строк становятся <br>.
+func HTMLText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(strings.TrimSpace(text)), "\n", "<br>")
+}
+
+// SoundTag возвращает ссылку на звуковой файл для поля записи.
+func SoundTag(name string) string {
+	return "[sound:" + name + "]"
+}
+
+// ImageTag возвращает тег изображения для поля записи.
+func ImageTag(name string) string {
+	return `<img src="` + html.EscapeString(name) + `">`
+}
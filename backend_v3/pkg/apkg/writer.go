@@ -0,0 +1,416 @@
+package apkg
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/pkg/sqlitefile"
+)
+
+// Значения по умолчанию для новых пакетов.
+const (
+	// DefaultDeckID — колода Default, которая есть в каждой коллекции.
+	DefaultDeckID = 1
+
+	// DefaultFactor — начальная лёгкость карточек Anki в промилле.
+	DefaultFactor = 2500
+
+	// usnUnsynced помечает объекты, ещё не отправленные на AnkiWeb.
+	usnUnsynced = -1
+
+	// schemaVersion — версия схемы collection.anki2, которую пишет Writer.
+	// Её читают все версии Anki: новые обновляют схему при импорте.
+	schemaVersion = 11
+)
+
+// ErrWriterClosed возвращается при записи в закрытый пакет.
+var ErrWriterClosed = errors.New("apkg: writer is closed")
+
+// Template — шаблон карточки типа записи: поля подставляются как {{Имя}}.
+type Template struct {
+	Name  string
+	Front string
+	Back  string
+}
+
+// WriterOptions — параметры создаваемого пакета.
+type WriterOptions struct {
+	DeckID   int64     // ID колоды; записи с тем же ID при повторном импорте попадут в неё же
+	DeckName string    // Имя колоды; «::» задаёт вложенность
+	Created  time.Time // Начало отсчёта дней в Card.Due для карточек на повторении
+	NoteType NoteType  // Тип всех записей пакета; нужны поля и хотя бы один шаблон
+}
+
+// Writer пишет пакет .apkg: медиафайлы сразу попадают в архив, а коллекция
+// копится в памяти и записывается в Close.
+//
+// Коллекция создаётся в схеме 11 (collection.anki2, планировщик v2), как при
+// экспорте из Anki с флажком «Support older Anki versions».
+type Writer struct {
+	zw     *zip.Writer
+	db     *sqlitefile.Writer
+	opts   WriterOptions
+	mod    int64 // Время изменения объектов, секунды unix
+	notes  map[int64]bool
+	cards  map[int64]bool
+	media  map[string]string // Номер файла в архиве → имя
+	names  map[string]bool
+	closed bool
+}
+
+// NewWriter начинает пакет, который пишется в w. Вызывающий закрывает w сам
+// после Close.
+func NewWriter(w io.Writer, opts WriterOptions) (*Writer, error) {
+	if opts.DeckID <= DefaultDeckID {
+		return nil, errors.New("apkg: deck id must be greater than 1")
+	}
+	if strings.TrimSpace(opts.DeckName) == "" {
+		return nil, errors.New("apkg: deck name is required")
+	}
+	if opts.NoteType.ID <= 0 || opts.NoteType.Name == "" {
+		return nil, errors.New("apkg: note type id and name are required")
+	}
+	if len(opts.NoteType.Fields) == 0 || len(opts.NoteType.Templates) == 0 {
+		return nil, errors.New("apkg: note type needs at least one field and one template")
+	}
+	if opts.Created.IsZero() {
+		opts.Created = time.Now()
+	}
+
+	db, err := sqlitefile.NewWriter(0)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range collectionSchema {
+		if strings.HasPrefix(stmt, "CREATE INDEX") {
+			err = db.CreateIndex(stmt)
+		} else {
+			err = db.CreateTable(stmt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("apkg: create schema: %w", err)
+		}
+	}
+
+	return &Writer{
+		zw:    zip.NewWriter(w),
+		db:    db,
+		opts:  opts,
+		mod:   time.Now().Unix(),
+		notes: make(map[int64]bool),
+		cards: make(map[int64]bool),
+		media: make(map[string]string),
+		names: make(map[string]bool),
+	}, nil
+}
+
+// AddMedia добавляет медиафайл, на который поля ссылаются по имени name
+// (см. SoundTag и ImageTag). Содержимое копируется в архив сразу.
+func (w *Writer) AddMedia(name string, r io.Reader) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("apkg: invalid media name %q", name)
+	}
+	if w.names[name] {
+		return fmt.Errorf("apkg: duplicate media name %q", name)
+	}
+
+	num := strconv.Itoa(len(w.media))
+	// Изображения и звук уже сжаты, поэтому хранятся без сжатия
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: num, Method: zip.Store, Modified: time.Unix(w.mod, 0)})
+	if err != nil {
+		return fmt.Errorf("apkg: add media %q: %w", name, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("apkg: add media %q: %w", name, err)
+	}
+	w.media[num] = name
+	w.names[name] = true
+	return nil
+}
+
+// AddNote добавляет запись типа WriterOptions.NoteType (TypeID игнорируется).
+// Пустой GUID выводится из ID.
+func (w *Writer) AddNote(note Note) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	if len(note.Fields) != len(w.opts.NoteType.Fields) {
+		return fmt.Errorf("apkg: note %d has %d fields, note type has %d", note.ID, len(note.Fields), len(w.opts.NoteType.Fields))
+	}
+	guid := note.GUID
+	if guid == "" {
+		guid = GUID(uint64(note.ID))
+	}
+
+	sortField := strings.ReplaceAll(PlainText(note.Fields[0]), "\n", " ")
+	tags := ""
+	if len(note.Tags) > 0 {
+		// Anki хранит теги через пробел с пробелами по краям
+		tags = " " + strings.Join(note.Tags, " ") + " "
+	}
+	err := w.db.Insert("notes",
+		note.ID, guid, w.opts.NoteType.ID, w.mod, usnUnsynced, tags,
+		strings.Join(note.Fields, FieldSeparator), sortField, fieldChecksum(sortField), 0, "")
+	if err != nil {
+		return fmt.Errorf("apkg: add note: %w", err)
+	}
+	w.notes[note.ID] = true
+	return nil
+}
+
+// AddCard добавляет карточку уже добавленной записи в колоду пакета.
+func (w *Writer) AddCard(card Card) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	if !w.notes[card.NoteID] {
+		return fmt.Errorf("apkg: card %d refers to unknown note %d", card.ID, card.NoteID)
+	}
+	if card.Ord < 0 || card.Ord >= len(w.opts.NoteType.Templates) {
+		return fmt.Errorf("apkg: card %d has no template %d", card.ID, card.Ord)
+	}
+
+	// left — оставшиеся шаги обучения; для карточек в обучении хватает одного
+	left := 0
+	if card.Type == CardTypeLearning || card.Type == CardTypeRelearning {
+		left = 1
+	}
+	err := w.db.Insert("cards",
+		card.ID, card.NoteID, w.opts.DeckID, card.Ord, w.mod, usnUnsynced,
+		card.Type, card.Queue, card.Due, card.Interval, card.Factor, card.Reps, card.Lapses,
+		left, 0, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("apkg: add card: %w", err)
+	}
+	w.cards[card.ID] = true
+	return nil
+}
+
+// AddReview добавляет запись истории повторений уже добавленной карточки.
+// ID (время в миллисекундах) должен быть уникальным в пакете.
+func (w *Writer) AddReview(r Review) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	if !w.cards[r.CardID] {
+		return fmt.Errorf("apkg: review %d refers to unknown card %d", r.ID, r.CardID)
+	}
+	// lastIvl не хранится в Review: Anki использует его только для статистики
+	err := w.db.Insert("revlog",
+		r.ID, r.CardID, usnUnsynced, r.Ease, r.Interval, 0, r.Factor, r.DurationMs, r.Type)
+	if err != nil {
+		return fmt.Errorf("apkg: add review: %w", err)
+	}
+	return nil
+}
+
+// Close записывает коллекцию и карту медиафайлов и завершает архив.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	w.closed = true
+
+	if err := w.writeCollectionRow(); err != nil {
+		return err
+	}
+	data, err := w.db.Bytes()
+	if err != nil {
+		return fmt.Errorf("apkg: build collection: %w", err)
+	}
+	if err := w.writeFile("collection.anki2", data); err != nil {
+		return err
+	}
+
+	media, err := json.Marshal(w.media)
+	if err != nil {
+		return fmt.Errorf("apkg: encode media map: %w", err)
+	}
+	if err := w.writeFile("media", media); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// writeFile добавляет в архив файл со сжатием.
+func (w *Writer) writeFile(name string, data []byte) error {
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Unix(w.mod, 0)})
+	if err != nil {
+		return fmt.Errorf("apkg: write %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("apkg: write %s: %w", name, err)
+	}
+	return nil
+}
+
+// ============================================================================
+// COLLECTION
+// ============================================================================
+
+// collectionSchema — таблицы и индексы коллекции схемы 11.
+var collectionSchema = []string{
+	`CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null, models text not null, decks text not null, dconf text not null, tags text not null)`,
+	`CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null, mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null, ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null, odue integer not null, odid integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null, ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null, type integer not null)`,
+	`CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)`,
+	`CREATE INDEX ix_notes_usn on notes (usn)`,
+	`CREATE INDEX ix_cards_usn on cards (usn)`,
+	`CREATE INDEX ix_revlog_usn on revlog (usn)`,
+	`CREATE INDEX ix_cards_nid on cards (nid)`,
+	`CREATE INDEX ix_cards_sched on cards (did, queue, due)`,
+	`CREATE INDEX ix_revlog_cid on revlog (cid)`,
+	`CREATE INDEX ix_notes_csum on notes (csum)`,
+}
+
+// writeCollectionRow добавляет строку col: настройки, тип записи и колоды в JSON.
+func (w *Writer) writeCollectionRow() error {
+	nt := w.opts.NoteType
+	deckID := w.opts.DeckID
+
+	conf := map[string]any{
+		"activeDecks":   []int64{deckID},
+		"curDeck":       deckID,
+		"curModel":      nt.ID,
+		"nextPos":       len(w.notes) + 1,
+		"schedVer":      2,
+		"sortType":      "noteFld",
+		"sortBackwards": false,
+		"addToCur":      true,
+		"newSpread":     0,
+		"collapseTime":  1200,
+		"timeLim":       0,
+		"estTimes":      true,
+		"dueCounts":     true,
+	}
+
+	fields := make([]map[string]any, len(nt.Fields))
+	for i, name := range nt.Fields {
+		fields[i] = map[string]any{
+			"name": name, "ord": i, "sticky": false, "rtl": false,
+			"font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+	templates := make([]map[string]any, len(nt.Templates))
+	for i, t := range nt.Templates {
+		templates[i] = map[string]any{
+			"name": t.Name, "ord": i, "qfmt": t.Front, "afmt": t.Back,
+			"bqfmt": "", "bafmt": "", "did": nil,
+		}
+	}
+	models := map[string]any{
+		strconv.FormatInt(nt.ID, 10): map[string]any{
+			"id":        nt.ID,
+			"name":      nt.Name,
+			"type":      0,
+			"mod":       w.mod,
+			"usn":       usnUnsynced,
+			"sortf":     0,
+			"did":       deckID,
+			"flds":      fields,
+			"tmpls":     templates,
+			"css":       nt.CSS,
+			"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+			"latexPost": "\\end{document}",
+			"latexsvg":  false,
+			"req":       [][]any{{0, "any", []int{0}}},
+			"tags":      []string{},
+			"vers":      []any{},
+		},
+	}
+
+	deck := func(id int64, name string) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "mod": w.mod, "usn": usnUnsynced, "desc": "",
+			"dyn": 0, "conf": 1, "collapsed": false, "browserCollapsed": false,
+			"extendNew": 0, "extendRev": 0,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0},
+			"lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+	decks := map[string]any{
+		strconv.Itoa(DefaultDeckID):   deck(DefaultDeckID, "Default"),
+		strconv.FormatInt(deckID, 10): deck(deckID, w.opts.DeckName),
+	}
+
+	dconf := map[string]any{
+		"1": map[string]any{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0,
+			"maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+			"new": map[string]any{
+				"bury": false, "delays": []float64{1, 10}, "initialFactor": DefaultFactor,
+				"ints": []int{1, 4, 0}, "order": 1, "perDay": 20,
+			},
+			"rev": map[string]any{
+				"bury": false, "ease4": 1.3, "ivlFct": 1, "maxIvl": 36500,
+				"perDay": 200, "hardFactor": 1.2,
+			},
+			"lapse": map[string]any{
+				"delays": []float64{10}, "leechAction": 1, "leechFails": 8,
+				"minInt": 1, "mult": 0,
+			},
+		},
+	}
+
+	encoded := make([]string, 4)
+	for i, v := range []any{conf, models, decks, dconf} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("apkg: encode collection: %w", err)
+		}
+		encoded[i] = string(data)
+	}
+
+	err := w.db.Insert("col",
+		1, w.opts.Created.Unix(), w.mod*1000, w.mod*1000, schemaVersion, 0, 0, 0,
+		encoded[0], encoded[1], encoded[2], encoded[3], "{}")
+	if err != nil {
+		return fmt.Errorf("apkg: write collection: %w", err)
+	}
+	return nil
+}
+
+// fieldChecksum — контрольная сумма поля сортировки для поиска дубликатов:
+// первые 8 hex-цифр SHA-1.
+func fieldChecksum(text string) int64 {
+	sum := sha1.Sum([]byte(text))
+	v, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+	return v
+}
+
+// guidChars — алфавит base91, которым Anki кодирует GUID записей.
+const guidChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&()*+,-./:;<=>?@[]^_`{|}~"
+
+// GUID кодирует 64-битное значение в GUID записи, как это делает Anki.
+// Записи с тем же GUID при повторном импорте обновляются, а не дублируются.
+func GUID(v uint64) string {
+	var b []byte
+	for v > 0 || len(b) == 0 {
+		b = append(b, guidChars[v%uint64(len(guidChars))])
+		v /= uint64(len(guidChars))
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// GUIDFromBytes выводит GUID из первых 8 байт b (например, UUID).
+func GUIDFromBytes(b []byte) string {
+	var buf [8]byte
+	copy(buf[:], b)
+	return GUID(binary.BigEndian.Uint64(buf[:]))
+}
@@ -0,0 +1,145 @@
+package apkg
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testNoteType() NoteType {
+	return NoteType{
+		ID:        1700000000001,
+		Name:      "Vocabulary",
+		Fields:    []string{"Word", "Translation", "Audio"},
+		Templates: []Template{{Name: "Card 1", Front: "{{Word}}{{Audio}}", Back: "{{FrontSide}}<hr id=answer>{{Translation}}"}},
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, WriterOptions{DeckID: 42, DeckName: "My English::Export", Created: created, NoteType: testNoteType()})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	if err := w.AddMedia("hello.mp3", strings.NewReader("ID3 audio")); err != nil {
+		t.Fatalf("AddMedia() error = %v", err)
+	}
+	notes := []Note{
+		{ID: 10, GUID: "abc", Fields: []string{"hello", "привет; здравствуйте", SoundTag("hello.mp3")}, Tags: []string{"lang::en", "cefr::a1"}},
+		{ID: 11, Fields: []string{"<b>world</b>", HTMLText("мир\n<свет>"), ""}},
+	}
+	for _, n := range notes {
+		if err := w.AddNote(n); err != nil {
+			t.Fatalf("AddNote() error = %v", err)
+		}
+	}
+	card := Card{ID: 20, NoteID: 10, Type: CardTypeReview, Queue: QueueReview, Due: 30, Interval: 12, Factor: 2600, Reps: 2, Lapses: 1}
+	if err := w.AddCard(card); err != nil {
+		t.Fatalf("AddCard() error = %v", err)
+	}
+	if err := w.AddCard(Card{ID: 21, NoteID: 11, Type: CardTypeNew, Queue: QueueNew, Due: 2, Factor: DefaultFactor}); err != nil {
+		t.Fatalf("AddCard() error = %v", err)
+	}
+	review := Review{ID: 1767225600000, CardID: 20, Ease: 3, Interval: 12, Factor: 2600, DurationMs: 4000, Type: ReviewReview}
+	if err := w.AddReview(review); err != nil {
+		t.Fatalf("AddReview() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	pkg, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !pkg.Created.Equal(created) || pkg.SchedV1 {
+		t.Errorf("Created = %v, SchedV1 = %v", pkg.Created, pkg.SchedV1)
+	}
+	nt := pkg.NoteTypes[testNoteType().ID]
+	if nt == nil || nt.Name != "Vocabulary" || !reflect.DeepEqual(nt.Fields, testNoteType().Fields) {
+		t.Fatalf("note type = %+v", nt)
+	}
+
+	if len(pkg.Notes) != 2 {
+		t.Fatalf("len(Notes) = %d, want 2", len(pkg.Notes))
+	}
+	if n := pkg.Notes[0]; n.GUID != "abc" || !reflect.DeepEqual(n.Fields, notes[0].Fields) || !reflect.DeepEqual(n.Tags, notes[0].Tags) {
+		t.Errorf("Notes[0] = %+v", n)
+	}
+	if n := pkg.Notes[1]; n.GUID != GUID(11) || PlainText(n.Fields[1]) != "мир\n<свет>" {
+		t.Errorf("Notes[1] = %+v", n)
+	}
+
+	if got := pkg.CardsByNote()[10]; len(got) != 1 || got[0] != card {
+		t.Errorf("cards of note 10 = %+v, want %+v", got, card)
+	}
+	if got := pkg.ReviewsByCard()[20]; len(got) != 1 || got[0] != review {
+		t.Errorf("reviews of card 20 = %+v, want %+v", got, review)
+	}
+
+	rc, ok, err := pkg.OpenMedia("hello.mp3")
+	if err != nil || !ok {
+		t.Fatalf("OpenMedia() = %v, %v", ok, err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "ID3 audio" {
+		t.Errorf("media content = %q", data)
+	}
+}
+
+func TestWriter_Errors(t *testing.T) {
+	if _, err := NewWriter(io.Discard, WriterOptions{DeckID: 1, DeckName: "x", NoteType: testNoteType()}); err == nil {
+		t.Error("NewWriter(default deck id) error = nil")
+	}
+	if _, err := NewWriter(io.Discard, WriterOptions{DeckID: 2, DeckName: "x", NoteType: NoteType{ID: 1, Name: "x", Fields: []string{"a"}}}); err == nil {
+		t.Error("NewWriter(no templates) error = nil")
+	}
+
+	w, err := NewWriter(io.Discard, WriterOptions{DeckID: 2, DeckName: "x", NoteType: testNoteType()})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.AddMedia("../a.mp3", strings.NewReader("")); err == nil {
+		t.Error("AddMedia(path) error = nil")
+	}
+	if err := w.AddMedia("a.mp3", strings.NewReader("")); err != nil {
+		t.Fatalf("AddMedia() error = %v", err)
+	}
+	if err := w.AddMedia("a.mp3", strings.NewReader("")); err == nil {
+		t.Error("AddMedia(duplicate) error = nil")
+	}
+	if err := w.AddNote(Note{ID: 1, Fields: []string{"only one"}}); err == nil {
+		t.Error("AddNote(wrong field count) error = nil")
+	}
+	if err := w.AddCard(Card{ID: 1, NoteID: 99}); err == nil {
+		t.Error("AddCard(unknown note) error = nil")
+	}
+	if err := w.AddReview(Review{ID: 1, CardID: 99}); err == nil {
+		t.Error("AddReview(unknown card) error = nil")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := w.AddNote(Note{ID: 2, Fields: []string{"a", "b", "c"}}); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("AddNote(after Close) error = %v, want ErrWriterClosed", err)
+	}
+}
+
+func TestGUID(t *testing.T) {
+	if got := GUID(0); got != "a" {
+		t.Errorf("GUID(0) = %q, want a", got)
+	}
+	if got := GUID(91); got != "ba" {
+		t.Errorf("GUID(91) = %q, want ba", got)
+	}
+	if a, b := GUIDFromBytes([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}), GUIDFromBytes([]byte{1, 2, 3, 4, 5, 6, 7, 8, 10}); a != b {
+		t.Errorf("GUIDFromBytes() uses more than 8 bytes: %q != %q", a, b)
+	}
+}
@@ -0,0 +1,314 @@
+// Package dsl читает словари ABBYY Lingvo в формате DSL (.dsl или сжатый
+// gzip .dsl.dz): заголовок с названием и языками, карточки с заголовками
+// и телом статьи в разметке DSL.
+//
+// Словарь загружается в память целиком. Файлы аннотаций (.ann) и ресурсов
+// (.files.zip) не поддерживаются.
+package dsl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxFileSize — максимальный размер распакованного файла словаря.
+const MaxFileSize = 512 << 20
+
+// ErrInvalidFile возвращается, если файл не похож на словарь DSL.
+var ErrInvalidFile = errors.New("dsl: invalid dictionary")
+
+// Card — карточка словаря: один или несколько заголовков с общей статьёй.
+type Card struct {
+	Headwords []string // Заголовки для показа: без фигурных скобок неиндексируемых частей
+	Body      []string // Строки статьи без отступа, в разметке DSL
+}
+
+// Dictionary — загруженный словарь. Безопасен для одновременного чтения.
+type Dictionary struct {
+	Name             string // #NAME
+	IndexLanguage    string // #INDEX_LANGUAGE: язык заголовков, например "English"
+	ContentsLanguage string // #CONTENTS_LANGUAGE: язык статей, например "Russian"
+
+	cards []Card
+	index map[string][]int
+}
+
+// Open читает словарь из файла .dsl или .dsl.dz.
+func Open(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".dz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFile, path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidFile, path, MaxFileSize)
+	}
+	return Parse(data)
+}
+
+// Parse разбирает содержимое файла DSL в кодировке UTF-16 (с BOM или без)
+// или UTF-8.
+func Parse(data []byte) (*Dictionary, error) {
+	text, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	text = commentPattern.ReplaceAllString(text, "")
+
+	d := &Dictionary{index: make(map[string][]int)}
+	var card *Card
+	flush := func() {
+		if card != nil && len(card.Headwords) > 0 {
+			d.addCard(*card)
+		}
+		card = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			if card != nil {
+				card.Body = append(card.Body, strings.TrimSpace(line))
+			}
+		case line[0] == '#' && card == nil && len(d.cards) == 0:
+			d.readHeader(line)
+		default:
+			// Заголовки подряд относятся к одной карточке
+			if card != nil && len(card.Body) > 0 {
+				flush()
+			}
+			if card == nil {
+				card = &Card{}
+			}
+			card.Headwords = append(card.Headwords, DisplayHeadword(line))
+			d.indexHeadword(line, len(d.cards))
+		}
+	}
+	flush()
+
+	if len(d.cards) == 0 && d.Name == "" {
+		return nil, fmt.Errorf("%w: no header and no cards", ErrInvalidFile)
+	}
+	return d, nil
+}
+
+// Cards возвращает количество карточек.
+func (d *Dictionary) Cards() int {
+	return len(d.cards)
+}
+
+// Lookup возвращает карточки слова в порядке файла. Регистр и лишние
+// пробелы не учитываются. Для слова, которого нет в словаре, возвращает nil.
+func (d *Dictionary) Lookup(word string) []Card {
+	ids := d.index[Key(word)]
+	if len(ids) == 0 {
+		return nil
+	}
+	cards := make([]Card, 0, len(ids))
+	for _, id := range ids {
+		cards = append(cards, d.cards[id])
+	}
+	return cards
+}
+
+// Key возвращает ключ поиска слова: нижний регистр, пробелы схлопнуты.
+func Key(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
+
+// ============================================================================
+// PARSING
+// ============================================================================
+
+// commentPattern — комментарии {{...}}, в том числе многострочные.
+var commentPattern = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+
+// headerPattern — строка заголовка файла: #NAME "Название".
+var headerPattern = regexp.MustCompile(`^#(\w+)\s+"?(.*?)"?\s*$`)
+
+func (d *Dictionary) readHeader(line string) {
+	m := headerPattern.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+	switch strings.ToUpper(m[1]) {
+	case "NAME":
+		d.Name = m[2]
+	case "INDEX_LANGUAGE":
+		d.IndexLanguage = m[2]
+	case "CONTENTS_LANGUAGE":
+		d.ContentsLanguage = m[2]
+	}
+}
+
+// addCard добавляет карточку; её заголовки уже проиндексированы
+// под номером len(d.cards).
+func (d *Dictionary) addCard(card Card) {
+	d.cards = append(d.cards, card)
+}
+
+// indexHeadword добавляет в индекс все варианты заголовка.
+func (d *Dictionary) indexHeadword(line string, id int) {
+	for _, variant := range HeadwordVariants(line) {
+		key := Key(variant)
+		if key == "" {
+			continue
+		}
+		ids := d.index[key]
+		if len(ids) > 0 && ids[len(ids)-1] == id {
+			continue
+		}
+		d.index[key] = append(ids, id)
+	}
+}
+
+// decode переводит содержимое файла в строку UTF-8.
+func decode(data []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], false), nil
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], true), nil
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	case len(data) >= 2 && data[1] == 0 && data[0] != 0:
+		// UTF-16LE без BOM: у ASCII-символов второй байт нулевой
+		return decodeUTF16(data, false), nil
+	}
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("%w: unsupported encoding (expected UTF-16 or UTF-8)", ErrInvalidFile)
+	}
+	return string(data), nil
+}
+
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// ============================================================================
+// HEADWORDS
+// ============================================================================
+
+// HeadwordVariants возвращает варианты заголовка для индекса: неиндексируемые
+// части {...} убираются, необязательные части (...) дают варианты с ними
+// и без них: colo(u)r — color и colour. Экранированные символы (\( и т. п.)
+// остаются как есть.
+func HeadwordVariants(line string) []string {
+	variants := []string{""}
+	depth := 0 // Вложенность {...}
+	var optional *strings.Builder
+	runes := []rune(strings.TrimSpace(line))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			i++
+			r = runes[i]
+		} else {
+			switch r {
+			case '{':
+				depth++
+				continue
+			case '}':
+				depth = max(depth-1, 0)
+				continue
+			case '(':
+				if depth == 0 && optional == nil {
+					optional = &strings.Builder{}
+					continue
+				}
+			case ')':
+				if depth == 0 && optional != nil {
+					part := optional.String()
+					optional = nil
+					n := len(variants)
+					for j := 0; j < n; j++ {
+						variants = append(variants, variants[j]+part)
+					}
+					continue
+				}
+			}
+		}
+		if depth > 0 {
+			continue
+		}
+		if optional != nil {
+			optional.WriteRune(r)
+			continue
+		}
+		for j := range variants {
+			variants[j] += string(r)
+		}
+	}
+
+	if optional != nil {
+		// Незакрытая скобка — обычный текст
+		for j := range variants {
+			variants[j] += optional.String()
+		}
+	}
+
+	result := make([]string, 0, len(variants))
+	seen := make(map[string]bool, len(variants))
+	for _, v := range variants {
+		v = strings.Join(strings.Fields(v), " ")
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// DisplayHeadword возвращает заголовок для показа: со всеми частями,
+// без фигурных скобок и экранирования.
+func DisplayHeadword(line string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(line))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			i++
+			b.WriteRune(runes[i])
+			continue
+		}
+		if r == '{' || r == '}' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
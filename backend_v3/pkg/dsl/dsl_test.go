@@ -0,0 +1,171 @@
+package dsl
+
+import (
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	d, err := Open("testdata/test.dsl")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if d.Name != "Test En-Ru" || d.IndexLanguage != "English" || d.ContentsLanguage != "Russian" {
+		t.Errorf("header = %q, %q, %q", d.Name, d.IndexLanguage, d.ContentsLanguage)
+	}
+	if d.Cards() != 3 {
+		t.Errorf("Cards() = %d, want 3", d.Cards())
+	}
+
+	cards := d.Lookup(" Colour ")
+	if len(cards) != 1 {
+		t.Fatalf("Lookup(colour) = %d cards, want 1", len(cards))
+	}
+	wantHeadwords := []string{"colo(u)r", "colo(u)r (AmE)"}
+	if !reflect.DeepEqual(cards[0].Headwords, wantHeadwords) {
+		t.Errorf("Headwords = %q, want %q", cards[0].Headwords, wantHeadwords)
+	}
+	if len(cards[0].Body) != 5 || cards[0].Body[0] != "[m0][b]colour[/b] [t]ˈkʌlə[/t]" {
+		t.Errorf("Body = %q", cards[0].Body)
+	}
+
+	if cards := d.Lookup("color"); len(cards) != 1 {
+		t.Errorf("Lookup(color) = %d cards, want 1", len(cards))
+	}
+	if cards := d.Lookup("rock (n)"); len(cards) != 1 {
+		t.Errorf("Lookup(rock (n)) = %d cards, want 1", len(cards))
+	}
+	if cards := d.Lookup("cat"); cards != nil {
+		t.Errorf("Lookup(cat) = %v, want nil", cards)
+	}
+}
+
+func TestOpenGzip(t *testing.T) {
+	data, err := os.ReadFile("testdata/test.dsl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "test.dsl.dz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if cards := d.Lookup("dog"); len(cards) != 1 {
+		t.Errorf("Lookup(dog) = %d cards, want 1", len(cards))
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("utf-8", func(t *testing.T) {
+		d, err := Parse([]byte("\xef\xbb\xbf#NAME \"Utf8\"\r\ncat\r\n\t[trn]кошка[/trn]\r\n"))
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		cards := d.Lookup("cat")
+		if d.Name != "Utf8" || len(cards) != 1 || cards[0].Body[0] != "[trn]кошка[/trn]" {
+			t.Errorf("got name %q, cards %v", d.Name, cards)
+		}
+	})
+
+	t.Run("utf-16be", func(t *testing.T) {
+		data := []byte{0xFE, 0xFF}
+		for _, r := range "cat\n\tкот\n" {
+			data = append(data, byte(r>>8), byte(r))
+		}
+		d, err := Parse(data)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		if cards := d.Lookup("cat"); len(cards) != 1 || cards[0].Body[0] != "кот" {
+			t.Errorf("cards = %v", cards)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := Parse([]byte{0xC3, 0x28, 0xA0}); !errors.Is(err, ErrInvalidFile) {
+			t.Errorf("err = %v, want ErrInvalidFile", err)
+		}
+		if _, err := Parse([]byte("\n\n")); !errors.Is(err, ErrInvalidFile) {
+			t.Errorf("empty: err = %v, want ErrInvalidFile", err)
+		}
+	})
+}
+
+func TestHeadwordVariants(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"dog", []string{"dog"}},
+		{"colo(u)r", []string{"color", "colour"}},
+		{"(to) look( up)", []string{"look", "to look", "look up", "to look up"}},
+		{"bank{ (river)}", []string{"bank"}},
+		{`rock \(n\)`, []string{"rock (n)"}},
+		{"half(", []string{"half"}},
+		{"{only unsorted}", []string{}},
+	}
+	for _, tt := range tests {
+		if got := HeadwordVariants(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("HeadwordVariants(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	zones, rest := Extract(`1) [p]сущ.[/p] [trn]цвет[/trn] \[p]x [trn]оттенок`, TagTranslation)
+	if !reflect.DeepEqual(zones, []string{"цвет", "оттенок"}) {
+		t.Errorf("zones = %q", zones)
+	}
+	if rest != `1) [p]сущ.[/p]  \[p]x ` {
+		t.Errorf("rest = %q", rest)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"[m2][ex][lang id=1033]bright colours[/lang] — яркие цвета[/ex]", "bright colours — яркие цвета"},
+		{"[c red]см.[/c] <<hound>>", "см. hound"},
+		{"[s]colour.wav[/s] colour", "colour"},
+		{`a \[b\] c`, "a [b] c"},
+		{"[unclosed", "[unclosed"},
+	}
+	for _, tt := range tests {
+		if got := Plain(tt.in); got != tt.want {
+			t.Errorf("Plain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	if got := LanguageCode(" English "); got != "en" {
+		t.Errorf("LanguageCode(English) = %q", got)
+	}
+	if got := LanguageCode("GermanNewSpelling"); got != "de" {
+		t.Errorf("LanguageCode(GermanNewSpelling) = %q", got)
+	}
+	if got := LanguageCode("Klingon"); got != "" {
+		t.Errorf("LanguageCode(Klingon) = %q", got)
+	}
+}
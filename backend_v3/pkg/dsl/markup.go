@@ -0,0 +1,131 @@
+package dsl
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ============================================================================
+// MARKUP
+// ============================================================================
+
+// Основные теги разметки DSL.
+const (
+	TagTranscription = "t"   // Транскрипция
+	TagPartOfSpeech  = "p"   // Помета: часть речи, стиль
+	TagTranslation   = "trn" // Зона перевода
+	TagExample       = "ex"  // Пример
+	TagComment       = "com" // Комментарий
+	TagSound         = "s"   // Звук или картинка (имя файла)
+)
+
+// tagPattern — открывающий или закрывающий тег: [b], [/b], [m1], [c red],
+// [lang id=1033].
+var tagPattern = regexp.MustCompile(`\[/?[a-z*'!]+\d*(?:\s[^\]]*)?\]`)
+
+// Extract вырезает из строки зоны [tag]...[/tag] и возвращает их содержимое
+// (в разметке) и строку без них. Незакрытая зона продолжается до конца строки.
+func Extract(line, tag string) (zones []string, rest string) {
+	open, closing := "["+tag+"]", "[/"+tag+"]"
+	var b strings.Builder
+	for {
+		start := indexUnescaped(line, open)
+		if start < 0 {
+			b.WriteString(line)
+			break
+		}
+		b.WriteString(line[:start])
+		line = line[start+len(open):]
+
+		end := indexUnescaped(line, closing)
+		if end < 0 {
+			zones = append(zones, line)
+			break
+		}
+		zones = append(zones, line[:end])
+		line = line[end+len(closing):]
+	}
+	return zones, b.String()
+}
+
+// Plain убирает разметку: теги, ссылки <<...>>, имена медиафайлов [s]...[/s]
+// и экранирование. Пробелы схлопываются.
+func Plain(s string) string {
+	_, s = Extract(s, TagSound)
+	s = strings.NewReplacer("<<", "", ">>", "").Replace(s)
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case s[i] == '[':
+			if loc := tagPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				i += loc[1] - 1
+				continue
+			}
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// indexUnescaped ищет sub, перед которым не стоит обратная косая черта.
+func indexUnescaped(s, sub string) int {
+	offset := 0
+	for {
+		i := strings.Index(s[offset:], sub)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		if i == 0 || s[i-1] != '\\' {
+			return i
+		}
+		offset = i + 1
+	}
+}
+
+// ============================================================================
+// LANGUAGES
+// ============================================================================
+
+// languageCodes — названия языков в заголовке DSL и коды ISO 639-1.
+var languageCodes = map[string]string{
+	"english":           "en",
+	"russian":           "ru",
+	"german":            "de",
+	"germannewspelling": "de",
+	"french":            "fr",
+	"spanish":           "es",
+	"italian":           "it",
+	"portuguese":        "pt",
+	"ukrainian":         "uk",
+	"belarusian":        "be",
+	"polish":            "pl",
+	"czech":             "cs",
+	"dutch":             "nl",
+	"swedish":           "sv",
+	"norwegian":         "no",
+	"danish":            "da",
+	"finnish":           "fi",
+	"greek":             "el",
+	"turkish":           "tr",
+	"latin":             "la",
+	"chinese":           "zh",
+	"japanese":          "ja",
+	"korean":            "ko",
+	"arabic":            "ar",
+	"hebrew":            "he",
+	"kazakh":            "kk",
+	"tatar":             "tt",
+}
+
+// LanguageCode возвращает код ISO 639-1 языка из заголовка словаря
+// ("English", "GermanNewSpelling") или пустую строку, если язык неизвестен.
+func LanguageCode(name string) string {
+	return languageCodes[strings.ToLower(strings.TrimSpace(name))]
+}
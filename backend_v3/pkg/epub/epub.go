@@ -0,0 +1,262 @@
+// Package epub извлекает текст из электронных книг EPUB 2 и 3.
+//
+// Книга — это zip-архив: META-INF/container.xml указывает на файл пакета
+// (OPF) с метаданными, списком файлов (manifest) и порядком чтения (spine).
+// Главы — XHTML-документы из spine; их текст извлекается без разметки,
+// абзацы разделяются пустой строкой. Защищённые DRM книги не поддерживаются:
+// их главы зашифрованы и не разбираются как XHTML.
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxFileSize — максимальный размер файла книги.
+	MaxFileSize = 100 << 20
+
+	// MaxTextSize — максимальный суммарный размер распакованных глав.
+	MaxTextSize = 64 << 20
+)
+
+// ErrInvalidFile возвращается, если файл не похож на книгу EPUB.
+var ErrInvalidFile = errors.New("epub: invalid book")
+
+// Book — текст книги.
+type Book struct {
+	Title    string
+	Author   string   // Авторы через запятую
+	Language string   // Код языка из метаданных как есть (en, en-US); может быть пустым
+	Chapters []string // Текст глав в порядке чтения, абзацы через пустую строку
+}
+
+// Text возвращает текст всех глав, разделённых пустой строкой.
+func (b *Book) Text() string {
+	return strings.Join(b.Chapters, "\n\n")
+}
+
+// ============================================================================
+// OPEN
+// ============================================================================
+
+// container — META-INF/container.xml.
+type container struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// packageDoc — файл пакета OPF.
+type packageDoc struct {
+	Metadata struct {
+		Titles    []string `xml:"title"`
+		Creators  []string `xml:"creator"`
+		Languages []string `xml:"language"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef  string `xml:"idref,attr"`
+		Linear string `xml:"linear,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// Open читает книгу из r размера size. Главы вне основного порядка чтения
+// (linear="no": сноски, всплывающие комментарии) пропускаются.
+func Open(r io.ReaderAt, size int64) (*Book, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	opfPath, err := findPackage(files)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readZipFile(files[opfPath], MaxTextSize)
+	if err != nil {
+		return nil, fmt.Errorf("read package: %w", err)
+	}
+	var opf packageDoc
+	if err := unmarshalXML(data, &opf); err != nil {
+		return nil, fmt.Errorf("%w: package: %v", ErrInvalidFile, err)
+	}
+
+	book := &Book{
+		Title:    firstNonEmpty(opf.Metadata.Titles),
+		Author:   strings.Join(trimAll(opf.Metadata.Creators), ", "),
+		Language: firstNonEmpty(opf.Metadata.Languages),
+	}
+
+	hrefs := make(map[string]string, len(opf.Manifest))
+	for _, item := range opf.Manifest {
+		if isXHTML(item.MediaType) {
+			hrefs[item.ID] = item.Href
+		}
+	}
+
+	dir := path.Dir(opfPath)
+	remaining := int64(MaxTextSize)
+	for _, ref := range opf.Spine {
+		href, ok := hrefs[ref.IDRef]
+		if !ok || ref.Linear == "no" {
+			continue
+		}
+		name, err := url.PathUnescape(href)
+		if err != nil {
+			name = href
+		}
+		f, ok := files[path.Join(dir, name)]
+		if !ok {
+			continue
+		}
+		data, err := readZipFile(f, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("%w: chapter: %v", ErrInvalidFile, err)
+		}
+		remaining -= int64(len(data))
+		if text := PlainText(string(data)); text != "" {
+			book.Chapters = append(book.Chapters, text)
+		}
+	}
+	if len(book.Chapters) == 0 {
+		return nil, fmt.Errorf("%w: no text chapters", ErrInvalidFile)
+	}
+	return book, nil
+}
+
+// findPackage возвращает путь к файлу OPF из META-INF/container.xml.
+func findPackage(files map[string]*zip.File) (string, error) {
+	f, ok := files["META-INF/container.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: META-INF/container.xml not found", ErrInvalidFile)
+	}
+	data, err := readZipFile(f, 1<<20)
+	if err != nil {
+		return "", fmt.Errorf("read container: %w", err)
+	}
+	var c container
+	if err := unmarshalXML(data, &c); err != nil {
+		return "", fmt.Errorf("%w: container: %v", ErrInvalidFile, err)
+	}
+	for _, rf := range c.Rootfiles {
+		if rf.MediaType != "" && rf.MediaType != "application/oebps-package+xml" {
+			continue
+		}
+		if _, ok := files[rf.FullPath]; ok {
+			return rf.FullPath, nil
+		}
+	}
+	return "", fmt.Errorf("%w: package file not found", ErrInvalidFile)
+}
+
+// ============================================================================
+// TEXT
+// ============================================================================
+
+var (
+	// hiddenRe — элементы, текст которых не показывается читателю.
+	hiddenRe = regexp.MustCompile(`(?is)<head[\s>].*?</head>|<script[\s>].*?</script>|<style[\s>].*?</style>|<!--.*?-->`)
+
+	// blockRe — границы абзацев: конец блочного элемента или перевод строки.
+	blockRe = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|h[1-6]|li|tr|blockquote|section|pre|dd|dt)\s*>`)
+
+	tagRe   = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaceRe = regexp.MustCompile(`[\s\x{00a0}]+`)
+)
+
+// PlainText превращает XHTML главы в текст: абзацы разделяются пустой
+// строкой, переводы строк внутри абзаца заменяются пробелами, сущности
+// раскодируются.
+func PlainText(xhtml string) string {
+	s := hiddenRe.ReplaceAllString(xhtml, "")
+	s = blockRe.ReplaceAllString(s, "\x00")
+	s = tagRe.ReplaceAllString(s, "")
+
+	var paragraphs []string
+	for _, p := range strings.Split(s, "\x00") {
+		p = strings.TrimSpace(spaceRe.ReplaceAllString(html.UnescapeString(p), " "))
+		if p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// unmarshalXML разбирает XML, допуская сущности HTML.
+func unmarshalXML(data []byte, v any) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	return d.Decode(v)
+}
+
+// isXHTML проверяет, что файл манифеста — глава (XHTML или HTML).
+func isXHTML(mediaType string) bool {
+	return mediaType == "application/xhtml+xml" || mediaType == "text/html"
+}
+
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func trimAll(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// readZipFile читает файл архива не длиннее limit байт.
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, limit)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package epub
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	data, err := os.ReadFile("testdata/book.epub")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	book, err := Open(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if book.Title != "A Rainy Day" || book.Author != "Jane Doe, John Roe" || book.Language != "en-GB" {
+		t.Errorf("metadata = %q, %q, %q", book.Title, book.Author, book.Language)
+	}
+
+	// Обложка без текста и сноски вне порядка чтения пропущены
+	want := []string{
+		"Chapter One\n\n" +
+			"The weather was dreadful & the rain never stopped.\n\n" +
+			"We walked home through the gloomy streets.\n\n" +
+			"Walking was our only pleasure.",
+		"Chapter Two\n\n" +
+			"Poor Elizabeth felt a strange melancholy.\n\n" +
+			"The melancholy lingered, and the rain returned.",
+	}
+	if !reflect.DeepEqual(book.Chapters, want) {
+		t.Errorf("Chapters = %q, want %q", book.Chapters, want)
+	}
+	if got := book.Text(); got != want[0]+"\n\n"+want[1] {
+		t.Errorf("Text() = %q", got)
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	data := []byte("not a book")
+	if _, err := Open(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Open() error = %v, want ErrInvalidFile", err)
+	}
+
+	// zip-архив без META-INF/container.xml
+	data, err := os.ReadFile("../apkg/testdata/deck.apkg")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if _, err := Open(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Open() error = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	got := PlainText(`<html><head><title>T</title></head><body>
+<div class="x"><p>One&nbsp;<b>two</b>
+three.</p><ul><li>Four</li><li>Five</li></ul></div></body></html>`)
+	want := "One two three.\n\nFour\n\nFive"
+	if got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+}
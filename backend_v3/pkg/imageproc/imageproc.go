@@ -0,0 +1,135 @@
+// Package imageproc уменьшает и перекодирует изображения для показа в вебе.
+//
+// Перекодирование отбрасывает все метаданные исходного файла (EXIF, ICC, XMP),
+// в том числе геолокацию. Непрозрачные изображения кодируются в JPEG,
+// изображения с прозрачностью — в PNG. WebP поддерживается только на входе:
+// в стандартной библиотеке и golang.org/x/image нет кодировщика WebP.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	// Регистрация декодеров входных форматов
+	_ "image/gif"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MaxPixels — максимальное число пикселей исходного изображения.
+	// Защищает от «декомпрессионных бомб»: маленький файл с огромными размерами.
+	MaxPixels = 50_000_000
+
+	// JPEGQuality — качество JPEG для результата.
+	JPEGQuality = 85
+)
+
+// ErrTooLarge возвращается, если у изображения больше MaxPixels пикселей.
+var ErrTooLarge = errors.New("imageproc: image dimensions too large")
+
+// Size — размеры изображения в пикселях.
+type Size struct {
+	Width  int
+	Height int
+}
+
+// Result — перекодированное изображение.
+type Result struct {
+	Data        []byte
+	ContentType string // image/jpeg или image/png
+	Size        Size
+}
+
+// DecodeSize читает размеры изображения из заголовка, не декодируя пиксели.
+func DecodeSize(data []byte) (Size, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Size{}, fmt.Errorf("imageproc: decode config: %w", err)
+	}
+	return Size{Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// Decode декодирует изображение, предварительно проверив его размеры.
+func Decode(data []byte) (image.Image, error) {
+	size, err := DecodeSize(data)
+	if err != nil {
+		return nil, err
+	}
+	if size.Width <= 0 || size.Height <= 0 {
+		return nil, fmt.Errorf("imageproc: empty image")
+	}
+	if int64(size.Width)*int64(size.Height) > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("imageproc: decode: %w", err)
+	}
+	return img, nil
+}
+
+// Fit уменьшает изображение так, чтобы большая сторона не превышала maxSide,
+// сохраняя пропорции, и перекодирует результат. Маленькие изображения
+// не увеличиваются, но всё равно перекодируются (без метаданных).
+func Fit(img image.Image, maxSide int) (*Result, error) {
+	if maxSide <= 0 {
+		return nil, fmt.Errorf("imageproc: invalid max side %d", maxSide)
+	}
+
+	b := img.Bounds()
+	size := scaledSize(Size{Width: b.Dx(), Height: b.Dy()}, maxSide)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size.Width, size.Height))
+	if size.Width == b.Dx() && size.Height == b.Dy() {
+		draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	}
+
+	var buf bytes.Buffer
+	result := &Result{Size: size}
+	if isOpaque(dst) {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: JPEGQuality}); err != nil {
+			return nil, fmt.Errorf("imageproc: encode jpeg: %w", err)
+		}
+		result.ContentType = "image/jpeg"
+	} else {
+		enc := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := enc.Encode(&buf, dst); err != nil {
+			return nil, fmt.Errorf("imageproc: encode png: %w", err)
+		}
+		result.ContentType = "image/png"
+	}
+	result.Data = buf.Bytes()
+	return result, nil
+}
+
+// scaledSize вписывает размеры в квадрат maxSide x maxSide. Стороны не меньше 1px.
+func scaledSize(s Size, maxSide int) Size {
+	if s.Width <= maxSide && s.Height <= maxSide {
+		return s
+	}
+	if s.Width >= s.Height {
+		return Size{Width: maxSide, Height: max(1, s.Height*maxSide/s.Width)}
+	}
+	return Size{Width: max(1, s.Width*maxSide/s.Height), Height: maxSide}
+}
+
+// isOpaque сообщает, что во всём изображении нет прозрачных пикселей.
+func isOpaque(img *image.RGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xff {
+			return false
+		}
+	}
+	return true
+}
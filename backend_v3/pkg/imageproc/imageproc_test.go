@@ -0,0 +1,96 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func filled(w, h int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestScaledSize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Size
+		max  int
+		want Size
+	}{
+		{name: "landscape", in: Size{Width: 2000, Height: 1000}, max: 256, want: Size{Width: 256, Height: 128}},
+		{name: "portrait", in: Size{Width: 600, Height: 1200}, max: 300, want: Size{Width: 150, Height: 300}},
+		{name: "small is kept", in: Size{Width: 100, Height: 80}, max: 256, want: Size{Width: 100, Height: 80}},
+		{name: "thin strip", in: Size{Width: 5000, Height: 2}, max: 100, want: Size{Width: 100, Height: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scaledSize(tt.in, tt.max); got != tt.want {
+				t.Errorf("scaledSize(%v, %d) = %v, want %v", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFit_OpaqueToJPEG(t *testing.T) {
+	img, err := Decode(encodePNG(t, filled(400, 200, color.NRGBA{R: 200, G: 10, B: 10, A: 255})))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	res, err := Fit(img, 100)
+	if err != nil {
+		t.Fatalf("Fit() error = %v", err)
+	}
+	if res.ContentType != "image/jpeg" {
+		t.Errorf("ContentType = %q, want image/jpeg", res.ContentType)
+	}
+	if res.Size != (Size{Width: 100, Height: 50}) {
+		t.Errorf("Size = %v, want 100x50", res.Size)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(res.Data)); err != nil {
+		t.Errorf("result is not a valid JPEG: %v", err)
+	}
+}
+
+func TestFit_TransparentToPNG(t *testing.T) {
+	img, err := Decode(encodePNG(t, filled(50, 50, color.NRGBA{R: 0, G: 0, B: 255, A: 128})))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	res, err := Fit(img, 256)
+	if err != nil {
+		t.Fatalf("Fit() error = %v", err)
+	}
+	if res.ContentType != "image/png" {
+		t.Errorf("ContentType = %q, want image/png", res.ContentType)
+	}
+	if res.Size != (Size{Width: 50, Height: 50}) {
+		t.Errorf("Size = %v, want 50x50 (no upscaling)", res.Size)
+	}
+}
+
+func TestDecode_RejectsGarbage(t *testing.T) {
+	if _, err := Decode([]byte("\x89PNG\r\n\x1a\nnot really a png")); err == nil {
+		t.Error("Decode() expected error for a truncated PNG")
+	}
+}
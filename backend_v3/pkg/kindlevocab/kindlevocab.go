@@ -0,0 +1,174 @@
+// Package kindlevocab читает базу Vocabulary Builder Kindle (vocab.db).
+//
+// Kindle записывает каждое обращение к словарю в таблицу LOOKUPS: слово
+// (ссылка на WORDS), книгу (ссылка на BOOK_INFO), предложение из книги
+// и время в миллисекундах unix. Файл копируется с устройства из папки
+// system/vocabulary; журнал WAL (vocab.db-wal) не читается, поэтому
+// перед копированием Kindle стоит отключить от компьютера штатно.
+package kindlevocab
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/my-english/pkg/sqlitefile"
+)
+
+const (
+	// MaxFileSize — максимальный размер файла vocab.db.
+	MaxFileSize = 256 << 20
+
+	// categoryMastered — WORDS.category слова, отмеченного в Vocabulary
+	// Builder как выученное.
+	categoryMastered = 100
+)
+
+// ErrInvalidFile возвращается, если файл не похож на vocab.db.
+var ErrInvalidFile = errors.New("kindlevocab: not a Kindle vocabulary database")
+
+// Lookup — обращение к словарю: слово и предложение, в котором оно встретилось.
+type Lookup struct {
+	Word     string    // Слово в том виде, в каком оно было в книге
+	Stem     string    // Начальная форма по данным Kindle
+	Language string    // Код языка слова (ISO 639-1, без региона)
+	Mastered bool      // Слово отмечено в Vocabulary Builder как выученное
+	Usage    string    // Предложение из книги; может быть пустым
+	Book     string    // Название книги; пусто, если книги нет в BOOK_INFO
+	Authors  string    // Авторы книги
+	Time     time.Time // Время обращения
+}
+
+// Read читает обращения к словарю из содержимого vocab.db по возрастанию
+// времени. Слово без обращений (такое бывает после очистки истории)
+// возвращается одним обращением без предложения со временем добавления слова.
+func Read(data []byte) ([]Lookup, error) {
+	db, err := sqlitefile.Open(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	for _, name := range []string{"WORDS", "LOOKUPS"} {
+		if !db.HasTable(name) {
+			return nil, fmt.Errorf("%w: table %s not found", ErrInvalidFile, name)
+		}
+	}
+
+	words, err := readWords(db)
+	if err != nil {
+		return nil, err
+	}
+	books, err := readBooks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookups []Lookup
+	used := make(map[string]bool, len(words))
+	err = db.Rows("LOOKUPS", func(r sqlitefile.Row) error {
+		key := r.TextBy("word_key")
+		w, ok := words[key]
+		if !ok {
+			return nil
+		}
+		used[key] = true
+
+		l := w.Lookup
+		l.Usage = strings.TrimSpace(r.TextBy("usage"))
+		l.Time = time.UnixMilli(r.IntBy("timestamp"))
+		if b, ok := books[r.TextBy("book_key")]; ok {
+			l.Book, l.Authors = b.title, b.authors
+		}
+		lookups = append(lookups, l)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read lookups: %w", err)
+	}
+
+	for key, w := range words {
+		if !used[key] {
+			l := w.Lookup
+			l.Time = w.added
+			lookups = append(lookups, l)
+		}
+	}
+
+	sort.SliceStable(lookups, func(i, j int) bool {
+		if !lookups[i].Time.Equal(lookups[j].Time) {
+			return lookups[i].Time.Before(lookups[j].Time)
+		}
+		return lookups[i].Word < lookups[j].Word
+	})
+	return lookups, nil
+}
+
+// ============================================================================
+// TABLES
+// ============================================================================
+
+// word — строка WORDS: поля обращения, общие для всех обращений к слову.
+type word struct {
+	Lookup
+	added time.Time
+}
+
+// book — строка BOOK_INFO.
+type book struct {
+	title   string
+	authors string
+}
+
+// readWords читает WORDS по ключу id ("en:word"). Строки без слова пропускаются.
+func readWords(db *sqlitefile.DB) (map[string]word, error) {
+	words := make(map[string]word)
+	err := db.Rows("WORDS", func(r sqlitefile.Row) error {
+		text := strings.TrimSpace(r.TextBy("word"))
+		if text == "" {
+			return nil
+		}
+		words[r.TextBy("id")] = word{
+			Lookup: Lookup{
+				Word:     text,
+				Stem:     strings.TrimSpace(r.TextBy("stem")),
+				Language: language(r.TextBy("lang")),
+				Mastered: r.IntBy("category") == categoryMastered,
+			},
+			added: time.UnixMilli(r.IntBy("timestamp")),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read words: %w", err)
+	}
+	return words, nil
+}
+
+// readBooks читает BOOK_INFO по ключу id. Таблицы может не быть.
+func readBooks(db *sqlitefile.DB) (map[string]book, error) {
+	books := make(map[string]book)
+	if !db.HasTable("BOOK_INFO") {
+		return books, nil
+	}
+	err := db.Rows("BOOK_INFO", func(r sqlitefile.Row) error {
+		books[r.TextBy("id")] = book{
+			title:   strings.TrimSpace(r.TextBy("title")),
+			authors: strings.TrimSpace(r.TextBy("authors")),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read books: %w", err)
+	}
+	return books, nil
+}
+
+// language приводит код языка Kindle ("en", "en-GB") к коду без региона.
+func language(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
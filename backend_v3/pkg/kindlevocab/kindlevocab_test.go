@@ -0,0 +1,66 @@
+package kindlevocab
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	data, err := os.ReadFile("testdata/vocab.db")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	lookups, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := []Lookup{
+		{Word: "civility", Stem: "civility", Language: "en", Usage: "She was received with the utmost civility.",
+			Book: "Pride and Prejudice", Authors: "Jane Austen", Time: time.UnixMilli(1700000000000)},
+		{Word: "gloom", Stem: "gloom", Language: "en", Mastered: true, Usage: "The gloom of the evening.",
+			Book: "The Great Gatsby", Authors: "F. Scott Fitzgerald", Time: time.UnixMilli(1700000050000)},
+		{Word: "countenance", Stem: "countenance", Language: "en", Usage: "His countenance was grave.",
+			Book: "Pride and Prejudice", Authors: "Jane Austen", Time: time.UnixMilli(1700000100000)},
+		{Word: "countenance", Stem: "countenance", Language: "en", Usage: "Her countenance brightened.",
+			Book: "Pride and Prejudice", Authors: "Jane Austen", Time: time.UnixMilli(1700000200000)},
+		{Word: "boats", Stem: "boat", Language: "en", Usage: "So we beat on, boats against the current.",
+			Book: "The Great Gatsby", Authors: "F. Scott Fitzgerald", Time: time.UnixMilli(1700000300000)},
+		// Книги нет в BOOK_INFO, предложение пустое
+		{Word: "Zeitgeist", Stem: "Zeitgeist", Language: "de", Time: time.UnixMilli(1700000400000)},
+		// Слово без обращений, язык с регионом
+		{Word: "colour", Stem: "colour", Language: "en", Time: time.UnixMilli(1700000500000)},
+	}
+
+	if len(lookups) != len(want) {
+		t.Fatalf("len(Read()) = %d, want %d: %+v", len(lookups), len(want), lookups)
+	}
+	for i := range want {
+		got := lookups[i]
+		if !got.Time.Equal(want[i].Time) {
+			t.Errorf("lookups[%d].Time = %v, want %v", i, got.Time, want[i].Time)
+		}
+		got.Time = want[i].Time
+		if got != want[i] {
+			t.Errorf("lookups[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	if _, err := Read([]byte("not a database")); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Read() error = %v, want ErrInvalidFile", err)
+	}
+
+	// SQLite-файл без таблиц Kindle
+	data, err := os.ReadFile("../sqlitefile/testdata/sample.db")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if _, err := Read(data); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Read() error = %v, want ErrInvalidFile", err)
+	}
+}
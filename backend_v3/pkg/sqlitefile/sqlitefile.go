@@ -0,0 +1,500 @@
+// Package sqlitefile читает и пишет файлы базы данных SQLite без драйвера и cgo.
+//
+// Поддерживается только то, что нужно для обмена коллекциями с другими
+// приложениями: обход B-деревьев таблиц (в том числе overflow-страниц),
+// декодирование записей и сборка нового файла из таблиц со строками (Writer).
+// Индексы, WITHOUT ROWID-таблицы и журнал WAL не читаются — файл должен быть
+// закрытой базой после checkpoint, как в экспортах Anki.
+package sqlitefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	headerMagic = "SQLite format 3\x00"
+	headerSize  = 100
+
+	pageInteriorTable = 0x05
+	pageLeafTable     = 0x0D
+
+	// maxDepth — ограничение глубины B-дерева, защищает от зацикленных страниц.
+	maxDepth = 64
+
+	encodingUTF8 = 1
+)
+
+// ErrCorrupt возвращается, если структура файла не соответствует формату SQLite.
+var ErrCorrupt = errors.New("sqlitefile: file is corrupt")
+
+// ErrNoTable возвращается, если таблицы нет в схеме.
+var ErrNoTable = errors.New("sqlitefile: no such table")
+
+// DB — открытый файл базы данных. Содержимое целиком хранится в памяти.
+type DB struct {
+	data     []byte
+	pageSize int
+	usable   int
+	tables   map[string]*Table
+}
+
+// Table — описание таблицы из sqlite_master.
+type Table struct {
+	Name    string
+	Columns []string // Имена колонок в порядке объявления
+
+	rootPage int
+	rowIDCol int // Индекс колонки INTEGER PRIMARY KEY или -1
+}
+
+// Row — строка таблицы. Значения имеют типы nil, int64, float64, string или []byte.
+type Row struct {
+	RowID  int64
+	Values []any
+
+	table *Table
+}
+
+// Open разбирает заголовок файла и схему базы данных.
+func Open(data []byte) (*DB, error) {
+	if len(data) < headerSize || string(data[:len(headerMagic)]) != headerMagic {
+		return nil, fmt.Errorf("%w: not an SQLite database", ErrCorrupt)
+	}
+
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("%w: invalid page size %d", ErrCorrupt, pageSize)
+	}
+	if enc := binary.BigEndian.Uint32(data[56:60]); enc != 0 && enc != encodingUTF8 {
+		return nil, fmt.Errorf("sqlitefile: unsupported text encoding %d, only UTF-8 is supported", enc)
+	}
+
+	db := &DB{
+		data:     data,
+		pageSize: pageSize,
+		usable:   pageSize - int(data[20]),
+		tables:   make(map[string]*Table),
+	}
+	if db.usable < 480 {
+		return nil, fmt.Errorf("%w: invalid reserved space", ErrCorrupt)
+	}
+
+	// sqlite_master: type, name, tbl_name, rootpage, sql
+	master := &Table{Name: "sqlite_master", rootPage: 1, rowIDCol: -1}
+	err := db.walk(master, master.rootPage, 0, func(r Row) error {
+		if r.Text(0) != "table" {
+			return nil
+		}
+		t := &Table{
+			Name:     r.Text(1),
+			rootPage: int(r.Int(3)),
+			rowIDCol: -1,
+		}
+		t.Columns, t.rowIDCol = parseColumns(r.Text(4))
+		db.tables[strings.ToLower(t.Name)] = t
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+	return db, nil
+}
+
+// Table возвращает описание таблицы по имени (без учёта регистра).
+func (db *DB) Table(name string) (*Table, error) {
+	t, ok := db.tables[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoTable, name)
+	}
+	return t, nil
+}
+
+// HasTable сообщает, есть ли таблица в схеме.
+func (db *DB) HasTable(name string) bool {
+	_, ok := db.tables[strings.ToLower(name)]
+	return ok
+}
+
+// Rows вызывает fn для каждой строки таблицы в порядке rowid.
+// Ошибка fn прерывает обход и возвращается как есть.
+func (db *DB) Rows(name string, fn func(Row) error) error {
+	t, err := db.Table(name)
+	if err != nil {
+		return err
+	}
+	return db.walk(t, t.rootPage, 0, fn)
+}
+
+// ============================================================================
+// ROW ACCESS
+// ============================================================================
+
+// Column возвращает индекс колонки по имени или -1.
+func (t *Table) Column(name string) int {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value возвращает значение колонки по имени; nil, если колонки нет.
+func (r Row) Value(name string) any {
+	i := r.table.Column(name)
+	if i < 0 || i >= len(r.Values) {
+		return nil
+	}
+	return r.Values[i]
+}
+
+// Int возвращает целое значение колонки i. Вещественные числа округляются
+// к нулю, строки и NULL дают 0.
+func (r Row) Int(i int) int64 {
+	if i < 0 || i >= len(r.Values) {
+		return 0
+	}
+	switch v := r.Values[i].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// Text возвращает строковое значение колонки i. BLOB приводится к строке,
+// числа и NULL дают пустую строку.
+func (r Row) Text(i int) string {
+	if i < 0 || i >= len(r.Values) {
+		return ""
+	}
+	switch v := r.Values[i].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+// IntBy возвращает целое значение колонки по имени.
+func (r Row) IntBy(name string) int64 {
+	return r.Int(r.table.Column(name))
+}
+
+// TextBy возвращает строковое значение колонки по имени.
+func (r Row) TextBy(name string) string {
+	return r.Text(r.table.Column(name))
+}
+
+// ============================================================================
+// B-TREE
+// ============================================================================
+
+// page возвращает содержимое страницы n (нумерация с 1).
+func (db *DB) page(n int) ([]byte, error) {
+	start := (n - 1) * db.pageSize
+	if n < 1 || start+db.pageSize > len(db.data) {
+		return nil, fmt.Errorf("%w: page %d out of range", ErrCorrupt, n)
+	}
+	return db.data[start : start+db.pageSize], nil
+}
+
+// walk обходит B-дерево таблицы с корнем на странице n.
+func (db *DB) walk(t *Table, n, depth int, fn func(Row) error) error {
+	if depth > maxDepth {
+		return fmt.Errorf("%w: b-tree is too deep", ErrCorrupt)
+	}
+	p, err := db.page(n)
+	if err != nil {
+		return err
+	}
+
+	// У первой страницы заголовок B-дерева идёт после заголовка файла
+	off := 0
+	if n == 1 {
+		off = headerSize
+	}
+	if off+8 > len(p) {
+		return fmt.Errorf("%w: page %d header", ErrCorrupt, n)
+	}
+
+	kind := p[off]
+	cells := int(binary.BigEndian.Uint16(p[off+3 : off+5]))
+	hdr := 8
+	if kind == pageInteriorTable {
+		hdr = 12
+	}
+	if off+hdr+cells*2 > len(p) {
+		return fmt.Errorf("%w: page %d cell pointers", ErrCorrupt, n)
+	}
+	ptrs := p[off+hdr : off+hdr+cells*2]
+
+	switch kind {
+	case pageInteriorTable:
+		for i := 0; i < cells; i++ {
+			c := int(binary.BigEndian.Uint16(ptrs[i*2:]))
+			if c+4 > db.usable {
+				return fmt.Errorf("%w: page %d cell %d", ErrCorrupt, n, i)
+			}
+			child := int(binary.BigEndian.Uint32(p[c : c+4]))
+			if err := db.walk(t, child, depth+1, fn); err != nil {
+				return err
+			}
+		}
+		right := int(binary.BigEndian.Uint32(p[off+8 : off+12]))
+		return db.walk(t, right, depth+1, fn)
+
+	case pageLeafTable:
+		for i := 0; i < cells; i++ {
+			c := int(binary.BigEndian.Uint16(ptrs[i*2:]))
+			row, err := db.leafCell(t, p, c)
+			if err != nil {
+				return fmt.Errorf("page %d cell %d: %w", n, i, err)
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: page %d is not a table b-tree page (type 0x%02x)", ErrCorrupt, n, kind)
+}
+
+// leafCell декодирует ячейку листа таблицы, начинающуюся со смещения c.
+func (db *DB) leafCell(t *Table, p []byte, c int) (Row, error) {
+	if c >= db.usable {
+		return Row{}, ErrCorrupt
+	}
+	size, n := readVarint(p[c:db.usable])
+	if n == 0 || size < 0 {
+		return Row{}, ErrCorrupt
+	}
+	c += n
+	rowID, n := readVarint(p[c:db.usable])
+	if n == 0 {
+		return Row{}, ErrCorrupt
+	}
+	c += n
+
+	payload, err := db.payload(p, c, int(size))
+	if err != nil {
+		return Row{}, err
+	}
+	values, err := decodeRecord(payload)
+	if err != nil {
+		return Row{}, err
+	}
+
+	// INTEGER PRIMARY KEY хранится как NULL и равен rowid
+	if t.rowIDCol >= 0 && t.rowIDCol < len(values) && values[t.rowIDCol] == nil {
+		values[t.rowIDCol] = rowID
+	}
+	return Row{RowID: rowID, Values: values, table: t}, nil
+}
+
+// payload собирает содержимое ячейки размера size, продолжая его
+// по цепочке overflow-страниц, если оно не поместилось на странице.
+func (db *DB) payload(p []byte, c, size int) ([]byte, error) {
+	u := db.usable
+	maxLocal := u - 35
+	local := size
+	if size > maxLocal {
+		minLocal := (u-12)*32/255 - 23
+		local = minLocal + (size-minLocal)%(u-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if c+local > u {
+		return nil, ErrCorrupt
+	}
+	if local == size {
+		return p[c : c+size], nil
+	}
+
+	if c+local+4 > u {
+		return nil, ErrCorrupt
+	}
+	buf := make([]byte, 0, size)
+	buf = append(buf, p[c:c+local]...)
+	next := int(binary.BigEndian.Uint32(p[c+local:]))
+	for pages := 0; len(buf) < size; pages++ {
+		if next == 0 || pages*db.pageSize > len(db.data) {
+			return nil, fmt.Errorf("%w: overflow chain is broken", ErrCorrupt)
+		}
+		op, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := min(size-len(buf), u-4)
+		buf = append(buf, op[4:4+chunk]...)
+		next = int(binary.BigEndian.Uint32(op[:4]))
+	}
+	return buf, nil
+}
+
+// ============================================================================
+// RECORD FORMAT
+// ============================================================================
+
+// decodeRecord декодирует запись: заголовок с типами колонок и их значения.
+func decodeRecord(rec []byte) ([]any, error) {
+	hdrSize, n := readVarint(rec)
+	if n == 0 || hdrSize < int64(n) || hdrSize > int64(len(rec)) {
+		return nil, ErrCorrupt
+	}
+
+	hdr := rec[n:hdrSize]
+	body := rec[hdrSize:]
+	var values []any
+	for len(hdr) > 0 {
+		st, n := readVarint(hdr)
+		if n == 0 {
+			return nil, ErrCorrupt
+		}
+		hdr = hdr[n:]
+
+		size := serialSize(st)
+		if size < 0 || size > len(body) {
+			return nil, ErrCorrupt
+		}
+		values = append(values, serialValue(st, body[:size]))
+		body = body[size:]
+	}
+	return values, nil
+}
+
+// serialSize возвращает размер значения с типом st в байтах или -1.
+func serialSize(st int64) int {
+	switch {
+	case st >= 0 && st <= 4:
+		return int(st)
+	case st == 5:
+		return 6
+	case st == 6 || st == 7:
+		return 8
+	case st == 8 || st == 9:
+		return 0
+	case st >= 12:
+		return int((st - 12) / 2)
+	}
+	return -1
+}
+
+// serialValue декодирует значение с типом st.
+func serialValue(st int64, b []byte) any {
+	switch {
+	case st == 0:
+		return nil
+	case st >= 1 && st <= 6:
+		// Целое со знаком в big-endian переменной длины
+		v := int64(int8(b[0]))
+		for _, x := range b[1:] {
+			v = v<<8 | int64(x)
+		}
+		return v
+	case st == 7:
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	case st == 8:
+		return int64(0)
+	case st == 9:
+		return int64(1)
+	case st%2 == 0:
+		return bytes.Clone(b)
+	}
+	return string(b)
+}
+
+// readVarint читает varint SQLite (1–9 байт, big-endian).
+// Возвращает значение и число прочитанных байт; 0 — данных не хватило.
+func readVarint(b []byte) (int64, int) {
+	var v uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(b) {
+			return 0, 0
+		}
+		if i == 8 {
+			return int64(v<<8 | uint64(b[i])), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return int64(v), i + 1
+		}
+	}
+	return 0, 0
+}
+
+// ============================================================================
+// SCHEMA
+// ============================================================================
+
+// parseColumns извлекает имена колонок из CREATE TABLE и индекс колонки
+// INTEGER PRIMARY KEY (или -1). Табличные ограничения пропускаются.
+func parseColumns(sql string) ([]string, int) {
+	start := strings.Index(sql, "(")
+	end := strings.LastIndex(sql, ")")
+	if start < 0 || end <= start {
+		return nil, -1
+	}
+
+	var columns []string
+	rowIDCol := -1
+	for _, def := range splitTopLevel(sql[start+1 : end]) {
+		fields := strings.Fields(def)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "CONSTRAINT":
+			continue
+		}
+
+		name := strings.Trim(fields[0], "`\"[]'")
+		upper := strings.ToUpper(strings.Join(fields[1:], " "))
+		if strings.HasPrefix(upper, "INTEGER") && strings.Contains(upper, "PRIMARY KEY") &&
+			!strings.Contains(upper, "DESC") {
+			rowIDCol = len(columns)
+		}
+		columns = append(columns, name)
+	}
+	return columns, rowIDCol
+}
+
+// splitTopLevel делит список определений по запятым вне скобок и кавычек.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, last := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '[':
+			quote = ']'
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == ',' && depth == 0:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
@@ -0,0 +1,165 @@
+package sqlitefile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testdata/sample.db создан sqlite3 с page_size=512: таблица words
+// занимает несколько уровней B-дерева, а последняя строка — overflow-страницы.
+func openSample(t *testing.T) *DB {
+	t.Helper()
+	data, err := os.ReadFile("testdata/sample.db")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	db, err := Open(data)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return db
+}
+
+func TestOpen_Schema(t *testing.T) {
+	db := openSample(t)
+
+	words, err := db.Table("WORDS")
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	want := []string{"id", "text", "weight", "data", "count"}
+	if !reflect.DeepEqual(words.Columns, want) {
+		t.Errorf("Columns = %v, want %v", words.Columns, want)
+	}
+	if !db.HasTable("empty") {
+		t.Error("HasTable(empty) = false, want true")
+	}
+	if _, err := db.Table("missing"); !errors.Is(err, ErrNoTable) {
+		t.Errorf("Table(missing) error = %v, want ErrNoTable", err)
+	}
+}
+
+func TestRows(t *testing.T) {
+	db := openSample(t)
+
+	var rows []Row
+	err := db.Rows("words", func(r Row) error {
+		rows = append(rows, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Rows() error = %v", err)
+	}
+	if len(rows) != 301 {
+		t.Fatalf("len(rows) = %d, want 301", len(rows))
+	}
+
+	first := rows[0]
+	if first.IntBy("id") != 1000 || first.RowID != 1000 {
+		t.Errorf("id = %d, rowid = %d, want 1000", first.IntBy("id"), first.RowID)
+	}
+	if first.TextBy("text") != "word001" {
+		t.Errorf("text = %q, want word001", first.TextBy("text"))
+	}
+	if first.Value("weight") != 0.25 {
+		t.Errorf("weight = %v, want 0.25", first.Value("weight"))
+	}
+	if first.Value("data") != nil {
+		t.Errorf("data = %v, want nil", first.Value("data"))
+	}
+	if first.IntBy("count") != -100000 {
+		t.Errorf("count = %d, want -100000", first.IntBy("count"))
+	}
+
+	// Строки идут в порядке rowid
+	for i := 1; i < len(rows); i++ {
+		if rows[i].RowID <= rows[i-1].RowID {
+			t.Fatalf("rows are not ordered by rowid at %d", i)
+		}
+	}
+
+	last := rows[len(rows)-1]
+	if last.IntBy("id") != 1_000_000_000_000 {
+		t.Errorf("id = %d, want 1e12", last.IntBy("id"))
+	}
+	if text := last.TextBy("text"); text != "long "+strings.Repeat("x", 5000) {
+		t.Errorf("overflow text has length %d, want 5005", len(text))
+	}
+	if data, _ := last.Value("data").([]byte); !bytes.Equal(data, []byte{0, 1, 2, 255}) {
+		t.Errorf("data = %v, want [0 1 2 255]", last.Value("data"))
+	}
+	if last.IntBy("count") != 1<<40 {
+		t.Errorf("count = %d, want 2^40", last.IntBy("count"))
+	}
+}
+
+func TestRows_StopsOnError(t *testing.T) {
+	db := openSample(t)
+
+	stop := errors.New("stop")
+	calls := 0
+	err := db.Rows("words", func(Row) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Rows() error = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	if _, err := Open([]byte("not a database")); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Open() error = %v, want ErrCorrupt", err)
+	}
+
+	data, err := os.ReadFile("testdata/sample.db")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	// Обрезанный файл: корневые страницы таблиц за его пределами
+	db, err := Open(data[:1024])
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := db.Rows("words", func(Row) error { return nil }); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Rows() error = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestReadVarint(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want int64
+		n    int
+	}{
+		{in: []byte{0x00}, want: 0, n: 1},
+		{in: []byte{0x7f}, want: 127, n: 1},
+		{in: []byte{0x81, 0x00}, want: 128, n: 2},
+		{in: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: -1, n: 9},
+		{in: []byte{0x81}, want: 0, n: 0},
+	}
+	for _, tt := range tests {
+		got, n := readVarint(tt.in)
+		if got != tt.want || n != tt.n {
+			t.Errorf("readVarint(%x) = %d, %d; want %d, %d", tt.in, got, n, tt.want, tt.n)
+		}
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	cols, rowID := parseColumns(`CREATE TABLE notes (
+		id integer primary key,
+		guid text not null,
+		flds text not null,
+		data text CHECK (length(data) < 10),
+		PRIMARY KEY (guid, flds)
+	)`)
+	want := []string{"id", "guid", "flds", "data"}
+	if !reflect.DeepEqual(cols, want) || rowID != 0 {
+		t.Errorf("parseColumns() = %v, %d; want %v, 0", cols, rowID, want)
+	}
+}
@@ -0,0 +1,783 @@
+package sqlitefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultPageSize — размер страницы новых файлов (как у sqlite3 по умолчанию).
+	DefaultPageSize = 4096
+
+	// maxInteriorCell — наибольший размер ячейки внутренней страницы:
+	// номер страницы и varint ключа.
+	maxInteriorCell = 4 + 9
+
+	// schemaFormat 4 разрешает serial types 8 и 9 для целых 0 и 1.
+	schemaFormat = 4
+
+	// sqliteVersion записывается в заголовок как версия, создавшая файл.
+	sqliteVersion = 3040001
+)
+
+// ErrTableExists возвращается при повторном создании таблицы или индекса.
+var ErrTableExists = errors.New("sqlitefile: table already exists")
+
+// Writer собирает новый файл базы данных в памяти.
+//
+// Поддерживаются обычные таблицы с rowid и индексы по колонкам по возрастанию
+// с сопоставлением BINARY. Страницы заполняются целиком, без свободного места:
+// этого достаточно, чтобы sqlite3 и Anki открыли файл как обычную базу.
+type Writer struct {
+	pageSize int
+	tables   []*writerTable
+	indexes  []*writerIndex
+	byName   map[string]*writerTable
+}
+
+// writerTable — таблица, строки которой копятся до вызова Bytes.
+type writerTable struct {
+	Table
+	sql    string
+	rows   map[int64][]any // rowid → значения колонок
+	lastID int64
+}
+
+// writerIndex — индекс по колонкам таблицы.
+type writerIndex struct {
+	name    string
+	sql     string
+	table   *writerTable
+	columns []int // Индексы колонок таблицы
+}
+
+// NewWriter создаёт пустую базу с размером страницы pageSize
+// (степень двойки от 512 до 65536; 0 — DefaultPageSize).
+func NewWriter(pageSize int) (*Writer, error) {
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize < 512 || pageSize > 65536 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("sqlitefile: invalid page size %d", pageSize)
+	}
+	return &Writer{pageSize: pageSize, byName: make(map[string]*writerTable)}, nil
+}
+
+// CreateTable добавляет таблицу по оператору CREATE TABLE.
+// Оператор сохраняется в sqlite_master как есть.
+func (w *Writer) CreateTable(sql string) error {
+	name := parseTableName(sql)
+	if name == "" {
+		return fmt.Errorf("sqlitefile: cannot parse table name in %q", sql)
+	}
+	if err := w.checkName(name); err != nil {
+		return err
+	}
+
+	t := &writerTable{Table: Table{Name: name}, sql: sql, rows: make(map[int64][]any)}
+	t.Columns, t.rowIDCol = parseColumns(sql)
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("sqlitefile: table %s has no columns", name)
+	}
+	w.tables = append(w.tables, t)
+	w.byName[strings.ToLower(name)] = t
+	return nil
+}
+
+// CreateIndex добавляет индекс по оператору CREATE [UNIQUE] INDEX.
+// Колонки сортируются по возрастанию; DESC и COLLATE не поддерживаются,
+// уникальность не проверяется.
+func (w *Writer) CreateIndex(sql string) error {
+	name, table, columns := parseIndex(sql)
+	if name == "" || table == "" || len(columns) == 0 {
+		return fmt.Errorf("sqlitefile: cannot parse index in %q", sql)
+	}
+	if err := w.checkName(name); err != nil {
+		return err
+	}
+	t := w.byName[strings.ToLower(table)]
+	if t == nil {
+		return fmt.Errorf("%w: %s", ErrNoTable, table)
+	}
+
+	idx := &writerIndex{name: name, sql: sql, table: t}
+	for _, c := range columns {
+		fields := strings.Fields(c)
+		if len(fields) != 1 && !(len(fields) == 2 && strings.EqualFold(fields[1], "ASC")) {
+			return fmt.Errorf("sqlitefile: index %s: only ascending columns are supported", name)
+		}
+		i := t.Column(strings.Trim(fields[0], "`\"[]'"))
+		if i < 0 {
+			return fmt.Errorf("sqlitefile: index %s: no such column %s", name, fields[0])
+		}
+		idx.columns = append(idx.columns, i)
+	}
+	w.indexes = append(w.indexes, idx)
+	w.byName[strings.ToLower(name)] = nil
+	return nil
+}
+
+// checkName проверяет, что имя таблицы или индекса ещё не занято.
+func (w *Writer) checkName(name string) error {
+	if _, ok := w.byName[strings.ToLower(name)]; ok {
+		return fmt.Errorf("%w: %s", ErrTableExists, name)
+	}
+	return nil
+}
+
+// Insert добавляет строку. Значения перечисляются в порядке колонок и имеют
+// типы nil, bool, int, int64, float64, string или []byte.
+// rowid берётся из колонки INTEGER PRIMARY KEY, а без неё назначается
+// следующим после наибольшего.
+func (w *Writer) Insert(table string, values ...any) error {
+	t := w.byName[strings.ToLower(table)]
+	if t == nil {
+		return fmt.Errorf("%w: %s", ErrNoTable, table)
+	}
+	if len(values) != len(t.Columns) {
+		return fmt.Errorf("sqlitefile: table %s has %d columns, got %d values", t.Name, len(t.Columns), len(values))
+	}
+
+	values = append([]any(nil), values...)
+	for i, v := range values {
+		if _, _, err := serialEncode(v); err != nil {
+			return fmt.Errorf("sqlitefile: table %s: column %s: %w", t.Name, t.Columns[i], err)
+		}
+		if n, ok := toInt64(v); ok {
+			values[i] = n
+		}
+	}
+
+	rowID := t.lastID + 1
+	if t.rowIDCol >= 0 && values[t.rowIDCol] != nil {
+		id, ok := values[t.rowIDCol].(int64)
+		if !ok {
+			return fmt.Errorf("sqlitefile: table %s: primary key must be an integer", t.Name)
+		}
+		rowID = id
+	}
+	if _, ok := t.rows[rowID]; ok {
+		return fmt.Errorf("sqlitefile: table %s: duplicate rowid %d", t.Name, rowID)
+	}
+	t.rows[rowID] = values
+	t.lastID = max(t.lastID, rowID)
+	return nil
+}
+
+// Bytes возвращает содержимое файла базы данных.
+func (w *Writer) Bytes() ([]byte, error) {
+	b := &builder{pageSize: w.pageSize}
+	b.alloc() // Страница 1 — корень sqlite_master, заполняется последней
+
+	var master []cell
+	addMaster := func(values ...any) error {
+		rec, err := encodeRecord(values)
+		if err != nil {
+			return err
+		}
+		master = append(master, b.tableCell(int64(len(master)+1), rec))
+		return nil
+	}
+
+	for _, t := range w.tables {
+		cells, err := t.cells(b)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", t.Name, err)
+		}
+		root, err := b.buildTable(cells, 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", t.Name, err)
+		}
+		if err := addMaster("table", t.Name, t.Name, int64(root), t.sql); err != nil {
+			return nil, err
+		}
+	}
+	for _, idx := range w.indexes {
+		cells, err := idx.cells(b)
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", idx.name, err)
+		}
+		root, err := b.buildIndex(cells)
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", idx.name, err)
+		}
+		if err := addMaster("index", idx.name, idx.table.Name, int64(root), idx.sql); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := b.buildTable(master, 1, headerSize); err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	b.writeHeader()
+	return b.bytes(), nil
+}
+
+// rowIDs возвращает rowid строк таблицы по возрастанию.
+func (t *writerTable) rowIDs() []int64 {
+	ids := make([]int64, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// cells кодирует строки таблицы в ячейки листьев по возрастанию rowid.
+func (t *writerTable) cells(b *builder) ([]cell, error) {
+	ids := t.rowIDs()
+	cells := make([]cell, len(ids))
+	for i, id := range ids {
+		values := t.rows[id]
+		if t.rowIDCol >= 0 {
+			// INTEGER PRIMARY KEY хранится как NULL и равен rowid
+			values = append([]any(nil), values...)
+			values[t.rowIDCol] = nil
+		}
+		rec, err := encodeRecord(values)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = b.tableCell(id, rec)
+	}
+	return cells, nil
+}
+
+// cells кодирует ключи индекса (значения колонок и rowid) в порядке сортировки.
+func (idx *writerIndex) cells(b *builder) ([]cell, error) {
+	t := idx.table
+	keys := make([][]any, 0, len(t.rows))
+	for _, id := range t.rowIDs() {
+		key := make([]any, 0, len(idx.columns)+1)
+		for _, c := range idx.columns {
+			if c == t.rowIDCol {
+				key = append(key, id)
+			} else {
+				key = append(key, t.rows[id][c])
+			}
+		}
+		keys = append(keys, append(key, id))
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return compareKeys(keys[i], keys[j]) < 0 })
+
+	cells := make([]cell, len(keys))
+	for i, key := range keys {
+		rec, err := encodeRecord(key)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = b.indexCell(rec)
+	}
+	return cells, nil
+}
+
+// compareKeys сравнивает ключи индекса по правилам SQLite:
+// NULL < числа < текст < BLOB, текст и BLOB — побайтово.
+func compareKeys(a, b []any) int {
+	for i := range a {
+		if c := compareValues(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareValues сравнивает два значения записи.
+func compareValues(a, b any) int {
+	ca, cb := valueClass(a), valueClass(b)
+	if ca != cb {
+		return ca - cb
+	}
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case []byte:
+		return bytes.Compare(a, b.([]byte))
+	case int64, float64, bool:
+		x, y := numericValue(a), numericValue(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		// Целые, неразличимые как float64, сравниваются точно
+		xi, xok := a.(int64)
+		yi, yok := b.(int64)
+		if xok && yok {
+			return cmpInt(xi, yi)
+		}
+	}
+	return 0
+}
+
+// valueClass возвращает порядок класса значения при сортировке.
+func valueClass(v any) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case string:
+		return 2
+	case []byte:
+		return 3
+	}
+	return 1
+}
+
+// numericValue приводит число к float64 для сравнения.
+func numericValue(v any) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+	}
+	return 0
+}
+
+// cmpInt сравнивает два целых.
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// ============================================================================
+// PAGES
+// ============================================================================
+
+// Типы страниц индексов.
+const (
+	pageInteriorIndex = 0x02
+	pageLeafIndex     = 0x0A
+)
+
+// cell — ячейка страницы B-дерева. key — rowid для таблиц.
+type cell struct {
+	key  int64
+	data []byte
+}
+
+// builder раскладывает ячейки по страницам.
+type builder struct {
+	pageSize int
+	pages    [][]byte
+}
+
+// alloc выделяет новую страницу и возвращает её номер (с 1).
+func (b *builder) alloc() int {
+	b.pages = append(b.pages, make([]byte, b.pageSize))
+	return len(b.pages)
+}
+
+// tableCell кодирует ячейку листа таблицы.
+func (b *builder) tableCell(rowID int64, payload []byte) cell {
+	prefix := appendVarint(nil, int64(len(payload)))
+	prefix = appendVarint(prefix, rowID)
+	return cell{key: rowID, data: b.payloadCell(prefix, payload, b.pageSize-35)}
+}
+
+// indexCell кодирует ячейку индекса. Во внутренних страницах перед ней
+// дописывается номер левой дочерней страницы.
+func (b *builder) indexCell(payload []byte) cell {
+	prefix := appendVarint(nil, int64(len(payload)))
+	return cell{data: b.payloadCell(prefix, payload, (b.pageSize-12)*64/255-23)}
+}
+
+// payloadCell дописывает к prefix содержимое ячейки. Часть, не поместившаяся
+// на странице (больше maxLocal), выносится в цепочку overflow-страниц.
+func (b *builder) payloadCell(prefix, payload []byte, maxLocal int) []byte {
+	local := localPayloadSize(b.pageSize, maxLocal, len(payload))
+	data := append(prefix, payload[:local]...)
+	if local == len(payload) {
+		return data
+	}
+
+	// Цепочка заполняется с конца, чтобы каждая страница знала следующую
+	rest := payload[local:]
+	chunk := b.pageSize - 4
+	next := 0
+	for end := len(rest); end > 0; {
+		start := (end - 1) / chunk * chunk
+		n := b.alloc()
+		p := b.pages[n-1]
+		binary.BigEndian.PutUint32(p, uint32(next))
+		copy(p[4:], rest[start:end])
+		next, end = n, start
+	}
+	return binary.BigEndian.AppendUint32(data, uint32(next))
+}
+
+// localPayloadSize возвращает, сколько байт записи размера size хранится
+// в самой ячейке (по правилам формата).
+func localPayloadSize(usable, maxLocal, size int) int {
+	if size <= maxLocal {
+		return size
+	}
+	minLocal := (usable-12)*32/255 - 23
+	local := minLocal + (size-minLocal)%(usable-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	return local
+}
+
+// buildTable записывает B-дерево таблицы из отсортированных ячеек
+// и возвращает номер корня. Если root > 0, корень записывается на эту
+// уже выделенную страницу со смещением заголовка off (так sqlite_master
+// занимает первую страницу после заголовка файла).
+func (b *builder) buildTable(cells []cell, root, off int) (int, error) {
+	place := func() (int, int) {
+		if root > 0 {
+			return root, off
+		}
+		return b.alloc(), 0
+	}
+
+	if b.take(cells, off+8, 0) == len(cells) {
+		n, o := place()
+		b.writeTableLeaf(n, o, cells)
+		return n, nil
+	}
+
+	// Листья: столько ячеек подряд, сколько помещается на странице
+	var level []cell // Ссылки на дочерние страницы: key — наибольший rowid поддерева
+	for len(cells) > 0 {
+		k := b.take(cells, 8, 0)
+		if k == 0 {
+			return 0, errors.New("cell does not fit on a page")
+		}
+		n := b.alloc()
+		b.writeTableLeaf(n, 0, cells[:k])
+		level = append(level, cell{key: cells[k-1].key, data: pageRef(n)})
+		cells = cells[k:]
+	}
+
+	// Внутренние уровни: ссылки делятся поровну, чтобы на каждой странице
+	// было не меньше двух; последняя ссылка группы уходит в right-most pointer
+	perPage := (b.pageSize-12)/(2+maxInteriorCell) + 1
+	rootPerPage := (b.pageSize-off-12)/(2+maxInteriorCell) + 1
+	for len(level) > rootPerPage {
+		groups := (len(level) + perPage - 1) / perPage
+		size := (len(level) + groups - 1) / groups
+		var parents []cell
+		for len(level) > 0 {
+			k := min(size, len(level))
+			n := b.alloc()
+			b.writeTableInterior(n, 0, level[:k])
+			parents = append(parents, cell{key: level[k-1].key, data: pageRef(n)})
+			level = level[k:]
+		}
+		level = parents
+	}
+	n, o := place()
+	b.writeTableInterior(n, o, level)
+	return n, nil
+}
+
+// buildIndex записывает B-дерево индекса из отсортированных ячеек и
+// возвращает номер корня. В отличие от таблиц, ключ-разделитель между
+// соседними страницами хранится только во внутренней странице.
+func (b *builder) buildIndex(cells []cell) (int, error) {
+	if len(cells) == 0 {
+		n := b.alloc()
+		b.writeIndexPage(n, pageLeafIndex, nil, 0)
+		return n, nil
+	}
+
+	// Листья; за каждым, кроме последнего, следует разделитель
+	var children []int
+	var dividers []cell
+	for i := 0; i < len(cells); {
+		k := b.take(cells[i:], 8, 0)
+		if i+k+1 == len(cells) && k > 1 {
+			k-- // Разделителю нужен непустой правый лист
+		}
+		if k == 0 {
+			return 0, errors.New("cell does not fit on a page")
+		}
+		n := b.alloc()
+		b.writeIndexPage(n, pageLeafIndex, cells[i:i+k], 0)
+		children = append(children, n)
+		if i += k; i < len(cells) {
+			dividers = append(dividers, cells[i])
+			i++
+		}
+	}
+
+	// Внутренние уровни: страница берёт k разделителей с левыми детьми,
+	// следующий ребёнок становится right-most pointer, следующий разделитель
+	// поднимается на уровень выше
+	for len(children) > 1 {
+		var upChildren []int
+		var upDividers []cell
+		for i := 0; i < len(children); {
+			k := b.take(dividers[i:len(children)-1], 12, 4)
+			if rest := len(children) - (i + k + 1); rest == 1 && k > 1 {
+				k-- // На следующей странице должен остаться хотя бы один разделитель
+			}
+			if k == 0 {
+				return 0, errors.New("cell does not fit on a page")
+			}
+			n := b.alloc()
+			refs := make([]cell, k)
+			for j := range refs {
+				refs[j] = cell{data: append(pageRef(children[i+j]), dividers[i+j].data...)}
+			}
+			b.writeIndexPage(n, pageInteriorIndex, refs, children[i+k])
+			upChildren = append(upChildren, n)
+			if i += k + 1; i < len(children) {
+				upDividers = append(upDividers, dividers[i-1])
+			}
+		}
+		children, dividers = upChildren, upDividers
+	}
+	return children[0], nil
+}
+
+// take возвращает, сколько первых ячеек помещается на страницу с заголовком
+// hdr; extra — дополнительные байты каждой ячейки.
+func (b *builder) take(cells []cell, hdr, extra int) int {
+	used := hdr
+	for i, c := range cells {
+		used += 2 + extra + len(c.data)
+		if used > b.pageSize {
+			return i
+		}
+	}
+	return len(cells)
+}
+
+// writeTableLeaf записывает лист таблицы на страницу n;
+// off — смещение заголовка B-дерева.
+func (b *builder) writeTableLeaf(n, off int, cells []cell) {
+	p := b.pages[n-1]
+	p[off] = pageLeafTable
+	b.writeCells(p, off+8, off, cells, func(c cell) []byte { return c.data })
+}
+
+// writeTableInterior записывает внутреннюю страницу таблицы на страницу n: ссылки кроме
+// последней становятся ячейками, последняя — right-most pointer.
+func (b *builder) writeTableInterior(n, off int, refs []cell) {
+	p := b.pages[n-1]
+	p[off] = pageInteriorTable
+	copy(p[off+8:off+12], refs[len(refs)-1].data)
+	b.writeCells(p, off+12, off, refs[:len(refs)-1], func(c cell) []byte {
+		return appendVarint(append([]byte(nil), c.data...), c.key)
+	})
+}
+
+// writeIndexPage записывает страницу индекса; right — right-most pointer
+// внутренней страницы.
+func (b *builder) writeIndexPage(n int, kind byte, cells []cell, right int) {
+	p := b.pages[n-1]
+	p[0] = kind
+	hdr := 8
+	if kind == pageInteriorIndex {
+		binary.BigEndian.PutUint32(p[8:12], uint32(right))
+		hdr = 12
+	}
+	b.writeCells(p, hdr, 0, cells, func(c cell) []byte { return c.data })
+}
+
+// writeCells раскладывает ячейки с конца страницы и заполняет массив указателей.
+func (b *builder) writeCells(p []byte, ptrs, off int, cells []cell, encode func(cell) []byte) {
+	content := len(p)
+	for i, c := range cells {
+		data := encode(c)
+		content -= len(data)
+		copy(p[content:], data)
+		binary.BigEndian.PutUint16(p[ptrs+i*2:], uint16(content))
+	}
+	binary.BigEndian.PutUint16(p[off+3:], uint16(len(cells)))
+	if content == 65536 {
+		content = 0 // 0 означает 65536 для страниц максимального размера
+	}
+	binary.BigEndian.PutUint16(p[off+5:], uint16(content))
+}
+
+// writeHeader заполняет заголовок файла на первой странице.
+func (b *builder) writeHeader() {
+	h := b.pages[0][:headerSize]
+	copy(h, headerMagic)
+	pageSize := b.pageSize
+	if pageSize == 65536 {
+		pageSize = 1
+	}
+	binary.BigEndian.PutUint16(h[16:], uint16(pageSize))
+	h[18], h[19] = 1, 1 // Журнал отката, без WAL
+	h[21], h[22], h[23] = 64, 32, 32
+	binary.BigEndian.PutUint32(h[24:], 1) // Счётчик изменений
+	binary.BigEndian.PutUint32(h[28:], uint32(len(b.pages)))
+	binary.BigEndian.PutUint32(h[40:], 1) // Schema cookie
+	binary.BigEndian.PutUint32(h[44:], schemaFormat)
+	binary.BigEndian.PutUint32(h[56:], encodingUTF8)
+	binary.BigEndian.PutUint32(h[92:], 1) // Совпадает со счётчиком изменений
+	binary.BigEndian.PutUint32(h[96:], sqliteVersion)
+}
+
+// bytes склеивает страницы в файл.
+func (b *builder) bytes() []byte {
+	data := make([]byte, 0, len(b.pages)*b.pageSize)
+	for _, p := range b.pages {
+		data = append(data, p...)
+	}
+	return data
+}
+
+// pageRef кодирует номер дочерней страницы.
+func pageRef(n int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(n))
+}
+
+// ============================================================================
+// RECORD ENCODING
+// ============================================================================
+
+// encodeRecord кодирует значения в формат записи SQLite.
+func encodeRecord(values []any) ([]byte, error) {
+	var hdr, body []byte
+	for i, v := range values {
+		st, data, err := serialEncode(v)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		hdr = appendVarint(hdr, st)
+		body = append(body, data...)
+	}
+
+	// Размер заголовка включает собственный varint
+	size := len(hdr) + 1
+	for len(appendVarint(nil, int64(size))) > size-len(hdr) {
+		size++
+	}
+	rec := appendVarint(make([]byte, 0, size+len(body)), int64(size))
+	rec = append(rec, hdr...)
+	return append(rec, body...), nil
+}
+
+// serialEncode возвращает serial type и байты значения.
+func serialEncode(v any) (int64, []byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil, nil
+	case string:
+		return int64(len(v))*2 + 13, []byte(v), nil
+	case []byte:
+		return int64(len(v))*2 + 12, v, nil
+	case float64:
+		return 7, binary.BigEndian.AppendUint64(nil, math.Float64bits(v)), nil
+	case bool:
+		if v {
+			return 9, nil, nil
+		}
+		return 8, nil, nil
+	}
+
+	n, ok := toInt64(v)
+	if !ok {
+		return 0, nil, fmt.Errorf("unsupported type %T", v)
+	}
+	switch {
+	case n == 0:
+		return 8, nil, nil
+	case n == 1:
+		return 9, nil, nil
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return 1, []byte{byte(n)}, nil
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		return 2, binary.BigEndian.AppendUint16(nil, uint16(n)), nil
+	case n >= -1<<23 && n < 1<<23:
+		return 3, []byte{byte(n >> 16), byte(n >> 8), byte(n)}, nil
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		return 4, binary.BigEndian.AppendUint32(nil, uint32(n)), nil
+	case n >= -1<<47 && n < 1<<47:
+		return 5, binary.BigEndian.AppendUint64(nil, uint64(n))[2:], nil
+	}
+	return 6, binary.BigEndian.AppendUint64(nil, uint64(n)), nil
+}
+
+// toInt64 приводит целые типы Go к int64.
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// appendVarint дописывает varint SQLite (1–9 байт, big-endian).
+func appendVarint(b []byte, v int64) []byte {
+	u := uint64(v)
+	if u > 1<<56-1 {
+		// Девятый байт хранит 8 бит целиком
+		var buf [9]byte
+		buf[8] = byte(u)
+		u >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(u&0x7f) | 0x80
+			u >>= 7
+		}
+		return append(b, buf[:]...)
+	}
+
+	var buf [8]byte
+	i := len(buf) - 1
+	buf[i] = byte(u & 0x7f)
+	for u >>= 7; u > 0; u >>= 7 {
+		i--
+		buf[i] = byte(u&0x7f) | 0x80
+	}
+	return append(b, buf[i:]...)
+}
+
+// parseTableName извлекает имя таблицы из CREATE TABLE.
+func parseTableName(sql string) string {
+	end := strings.Index(sql, "(")
+	if end < 0 {
+		return ""
+	}
+	fields := strings.Fields(sql[:end])
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "CREATE") || !strings.EqualFold(fields[1], "TABLE") {
+		return ""
+	}
+	return strings.Trim(fields[len(fields)-1], "`\"[]'")
+}
+
+// parseIndex извлекает имя индекса, таблицы и определения колонок из CREATE INDEX.
+func parseIndex(sql string) (name, table string, columns []string) {
+	start := strings.Index(sql, "(")
+	end := strings.LastIndex(sql, ")")
+	if start < 0 || end <= start {
+		return "", "", nil
+	}
+	fields := strings.Fields(sql[:start])
+	if len(fields) < 5 || !strings.EqualFold(fields[0], "CREATE") || !strings.EqualFold(fields[len(fields)-2], "ON") {
+		return "", "", nil
+	}
+	name = strings.Trim(fields[len(fields)-3], "`\"[]'")
+	table = strings.Trim(fields[len(fields)-1], "`\"[]'")
+	for _, c := range splitTopLevel(sql[start+1 : end]) {
+		if c = strings.TrimSpace(c); c != "" {
+			columns = append(columns, c)
+		}
+	}
+	return name, table, columns
+}
@@ -0,0 +1,217 @@
+package sqlitefile
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	// Маленькая страница: таблица занимает несколько уровней B-дерева,
+	// а длинная строка — цепочку overflow-страниц
+	w, err := NewWriter(512)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.CreateTable("CREATE TABLE words (id integer primary key, text text not null, weight real, data blob, count integer)"); err != nil {
+		t.Fatalf("CreateTable(words) error = %v", err)
+	}
+	if err := w.CreateTable("CREATE TABLE empty (a, b)"); err != nil {
+		t.Fatalf("CreateTable(empty) error = %v", err)
+	}
+
+	long := strings.Repeat("overflow ", 400)
+	for i := 3000; i > 0; i-- {
+		text := "word"
+		if i == 3000 {
+			text = long
+		}
+		if err := w.Insert("words", int64(i), text, float64(i)/2, []byte{byte(i)}, int64(i)*1_000_000_007); err != nil {
+			t.Fatalf("Insert(%d) error = %v", i, err)
+		}
+	}
+
+	data, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	db, err := Open(data)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if !db.HasTable("empty") {
+		t.Error("HasTable(empty) = false, want true")
+	}
+	words, _ := db.Table("words")
+	if want := []string{"id", "text", "weight", "data", "count"}; !reflect.DeepEqual(words.Columns, want) {
+		t.Errorf("Columns = %v, want %v", words.Columns, want)
+	}
+
+	var rows []Row
+	if err := db.Rows("words", func(r Row) error {
+		rows = append(rows, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("Rows() error = %v", err)
+	}
+	if len(rows) != 3000 {
+		t.Fatalf("len(rows) = %d, want 3000", len(rows))
+	}
+	for i, r := range rows {
+		id := int64(i + 1)
+		if r.RowID != id || r.IntBy("id") != id {
+			t.Fatalf("rows[%d] id = %d/%d, want %d", i, r.RowID, r.IntBy("id"), id)
+		}
+		if got := r.Value("count"); got != id*1_000_000_007 {
+			t.Fatalf("rows[%d] count = %v, want %d", i, got, id*1_000_000_007)
+		}
+		if got := r.Value("weight"); got != float64(id)/2 {
+			t.Fatalf("rows[%d] weight = %v", i, got)
+		}
+	}
+	if got := rows[2999].TextBy("text"); got != long {
+		t.Errorf("overflow text length = %d, want %d", len(got), len(long))
+	}
+}
+
+func TestWriter_AutoRowID(t *testing.T) {
+	w, _ := NewWriter(0)
+	if err := w.CreateTable("CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)"); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := w.Insert("graves", 0, i, true); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+
+	data, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	db, err := Open(data)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	var got []int64
+	_ = db.Rows("graves", func(r Row) error {
+		got = append(got, r.RowID, r.IntBy("oid"), r.IntBy("type"))
+		return nil
+	})
+	want := []int64{1, 0, 1, 2, 1, 1, 3, 2, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Errors(t *testing.T) {
+	if _, err := NewWriter(1000); err == nil {
+		t.Error("NewWriter(1000) error = nil, want invalid page size")
+	}
+
+	w, _ := NewWriter(0)
+	if err := w.CreateTable("CREATE TABLE t (id integer primary key, v text)"); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	if err := w.CreateTable("create table T (x)"); !errors.Is(err, ErrTableExists) {
+		t.Errorf("CreateTable(duplicate) error = %v, want ErrTableExists", err)
+	}
+	if err := w.Insert("missing", 1); !errors.Is(err, ErrNoTable) {
+		t.Errorf("Insert(missing) error = %v, want ErrNoTable", err)
+	}
+	if err := w.Insert("t", 1); err == nil {
+		t.Error("Insert(wrong arity) error = nil")
+	}
+	if err := w.Insert("t", 1, "a"); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if err := w.Insert("t", 1, "b"); err == nil {
+		t.Error("Insert(duplicate rowid) error = nil")
+	}
+	if err := w.Insert("t", 2, struct{}{}); err == nil {
+		t.Error("Insert(unsupported type) error = nil")
+	}
+}
+
+func TestAppendVarint(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 16383, 16384, 1 << 40, 1<<56 - 1, 1 << 56, -1, -1 << 63} {
+		b := appendVarint(nil, v)
+		got, n := readVarint(b)
+		if got != v || n != len(b) {
+			t.Errorf("varint(%d) = %d (%d of %d bytes)", v, got, n, len(b))
+		}
+	}
+}
+
+func TestWriter_CreateIndex(t *testing.T) {
+	w, _ := NewWriter(512)
+	if err := w.CreateTable("CREATE TABLE cards (id integer primary key, nid integer not null, due integer not null)"); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	if err := w.CreateIndex("CREATE INDEX ix_cards_nid ON cards (nid)"); err != nil {
+		t.Fatalf("CreateIndex() error = %v", err)
+	}
+	if err := w.CreateIndex("create unique index if not exists ix_cards_sched on cards (nid asc, due, id)"); err != nil {
+		t.Fatalf("CreateIndex(unique) error = %v", err)
+	}
+
+	if err := w.CreateIndex("CREATE INDEX ix_cards_nid ON cards (due)"); !errors.Is(err, ErrTableExists) {
+		t.Errorf("CreateIndex(duplicate) error = %v, want ErrTableExists", err)
+	}
+	if err := w.CreateIndex("CREATE INDEX ix_missing ON missing (a)"); !errors.Is(err, ErrNoTable) {
+		t.Errorf("CreateIndex(missing table) error = %v, want ErrNoTable", err)
+	}
+	if err := w.CreateIndex("CREATE INDEX ix_desc ON cards (due DESC)"); err == nil {
+		t.Error("CreateIndex(DESC) error = nil")
+	}
+	if err := w.CreateIndex("CREATE INDEX ix_bad ON cards (missing)"); err == nil {
+		t.Error("CreateIndex(missing column) error = nil")
+	}
+	if err := w.Insert("ix_cards_nid", 1); !errors.Is(err, ErrNoTable) {
+		t.Errorf("Insert(index) error = %v, want ErrNoTable", err)
+	}
+
+	// Индекс занимает несколько уровней B-дерева
+	for i := 1; i <= 2000; i++ {
+		if err := w.Insert("cards", i, (i*7919)%500, i%3); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+	data, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	db, err := Open(data)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	count := 0
+	_ = db.Rows("cards", func(Row) error { count++; return nil })
+	if count != 2000 {
+		t.Errorf("rows = %d, want 2000", count)
+	}
+}
+
+func TestCompareKeys(t *testing.T) {
+	ordered := [][]any{
+		{nil, int64(5)},
+		{int64(-3), int64(1)},
+		{int64(2), int64(1)},
+		{2.5, int64(1)},
+		{int64(3), int64(0)},
+		{int64(3), int64(2)},
+		{"B", int64(1)},
+		{"a", int64(1)},
+		{[]byte{0}, int64(1)},
+	}
+	for i := 1; i < len(ordered); i++ {
+		if c := compareKeys(ordered[i-1], ordered[i]); c >= 0 {
+			t.Errorf("compareKeys(%v, %v) = %d, want < 0", ordered[i-1], ordered[i], c)
+		}
+		if c := compareKeys(ordered[i], ordered[i]); c != 0 {
+			t.Errorf("compareKeys(%v, itself) = %d, want 0", ordered[i], c)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package stardict
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MaxDataSize — максимальный размер статей, распаковываемых целиком
+// (файл .dz без таблицы блоков dictzip).
+const MaxDataSize = 512 << 20
+
+// openData открывает .dict или, если его нет, .dict.dz.
+func openData(base string) (dataReader, error) {
+	f, err := os.Open(base + ".dict")
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	f, err = os.Open(base + ".dict.dz")
+	if err != nil {
+		return nil, err
+	}
+	dz, err := newDictzip(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return dz, nil
+}
+
+// ============================================================================
+// DICTZIP
+// ============================================================================
+
+// dictzip читает файл dictzip — gzip, сжатый независимыми блоками. Таблица
+// блоков хранится в поле заголовка FEXTRA с подполем RA: версия, длина
+// распакованного блока, количество блоков и сжатые размеры блоков.
+// Нужный диапазон читается распаковкой только своих блоков.
+type dictzip struct {
+	f         *os.File
+	chunkLen  int64   // Длина распакованного блока
+	offsets   []int64 // Смещения сжатых блоков в файле; последний — конец данных
+	plainData []byte  // Данные целиком, если таблицы блоков нет
+}
+
+// gzip-флаги заголовка (RFC 1952).
+const (
+	flagHCRC    = 1 << 1
+	flagExtra   = 1 << 2
+	flagName    = 1 << 3
+	flagComment = 1 << 4
+)
+
+func newDictzip(f *os.File) (*dictzip, error) {
+	header := make([]byte, 64<<10)
+	n, err := f.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	header = header[:n]
+	if len(header) < 10 || header[0] != 0x1f || header[1] != 0x8b || header[2] != 8 {
+		return nil, fmt.Errorf("%w: %s is not gzip", ErrInvalidFile, f.Name())
+	}
+
+	flags := header[3]
+	pos := 10
+	var chunkLen int64
+	var sizes []int64
+	if flags&flagExtra != 0 {
+		if len(header) < pos+2 {
+			return nil, fmt.Errorf("%w: truncated gzip header", ErrInvalidFile)
+		}
+		xlen := int(binary.LittleEndian.Uint16(header[pos:]))
+		pos += 2
+		if len(header) < pos+xlen {
+			return nil, fmt.Errorf("%w: truncated gzip header", ErrInvalidFile)
+		}
+		chunkLen, sizes = parseRA(header[pos : pos+xlen])
+		pos += xlen
+	}
+	for _, flag := range []byte{flagName, flagComment} {
+		if flags&flag == 0 {
+			continue
+		}
+		end := bytes.IndexByte(header[pos:], 0)
+		if end < 0 {
+			return nil, fmt.Errorf("%w: truncated gzip header", ErrInvalidFile)
+		}
+		pos += end + 1
+	}
+	if flags&flagHCRC != 0 {
+		pos += 2
+	}
+
+	dz := &dictzip{f: f, chunkLen: chunkLen}
+	if chunkLen == 0 || len(sizes) == 0 {
+		// Обычный gzip: распаковываем целиком
+		if dz.plainData, err = readAllGzip(f); err != nil {
+			return nil, err
+		}
+		return dz, nil
+	}
+
+	dz.offsets = make([]int64, len(sizes)+1)
+	dz.offsets[0] = int64(pos)
+	for i, size := range sizes {
+		dz.offsets[i+1] = dz.offsets[i] + size
+	}
+	return dz, nil
+}
+
+// parseRA находит в поле FEXTRA подполе RA и возвращает длину блока
+// и сжатые размеры блоков. Без подполя возвращает нули.
+func parseRA(extra []byte) (int64, []int64) {
+	for len(extra) >= 4 {
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		if len(extra) < 4+size {
+			return 0, nil
+		}
+		field := extra[4 : 4+size]
+		if extra[0] == 'R' && extra[1] == 'A' && len(field) >= 6 {
+			chunkLen := int64(binary.LittleEndian.Uint16(field[2:]))
+			count := int(binary.LittleEndian.Uint16(field[4:]))
+			if len(field) < 6+2*count {
+				return 0, nil
+			}
+			sizes := make([]int64, count)
+			for i := range sizes {
+				sizes[i] = int64(binary.LittleEndian.Uint16(field[6+2*i:]))
+			}
+			return chunkLen, sizes
+		}
+		extra = extra[4+size:]
+	}
+	return 0, nil
+}
+
+func readAllGzip(f *os.File) ([]byte, error) {
+	zr, err := gzip.NewReader(io.NewSectionReader(f, 0, 1<<62))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(io.LimitReader(zr, MaxDataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if len(data) > MaxDataSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidFile, f.Name(), MaxDataSize)
+	}
+	return data, nil
+}
+
+// ReadAt читает распакованные данные с позиции off.
+func (dz *dictzip) ReadAt(p []byte, off int64) (int, error) {
+	if dz.plainData != nil {
+		if off >= int64(len(dz.plainData)) {
+			return 0, io.EOF
+		}
+		n := copy(p, dz.plainData[off:])
+		if n < len(p) {
+			return n, io.EOF
+		}
+		return n, nil
+	}
+
+	read := 0
+	for read < len(p) {
+		pos := off + int64(read)
+		chunk := int(pos / dz.chunkLen)
+		if chunk >= len(dz.offsets)-1 {
+			return read, io.EOF
+		}
+		data, err := dz.chunk(chunk)
+		if err != nil {
+			return read, err
+		}
+		start := int(pos - int64(chunk)*dz.chunkLen)
+		if start >= len(data) {
+			return read, io.EOF
+		}
+		read += copy(p[read:], data[start:])
+	}
+	return read, nil
+}
+
+// chunk распаковывает блок i. Блоки завершаются полным сбросом deflate,
+// поэтому каждый распаковывается отдельно от остальных.
+func (dz *dictzip) chunk(i int) ([]byte, error) {
+	compressed := make([]byte, dz.offsets[i+1]-dz.offsets[i])
+	if _, err := dz.f.ReadAt(compressed, dz.offsets[i]); err != nil {
+		return nil, fmt.Errorf("read chunk %d: %w", i, err)
+	}
+
+	fr := flate.NewReader(bytes.NewReader(compressed))
+	defer fr.Close()
+	data := make([]byte, dz.chunkLen)
+	n, err := io.ReadFull(fr, data)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("%w: chunk %d: %v", ErrInvalidFile, i, err)
+	}
+	// Короче длины блока может быть только последний блок
+	if int64(n) < dz.chunkLen && i < len(dz.offsets)-2 {
+		return nil, fmt.Errorf("%w: chunk %d is truncated", ErrInvalidFile, i)
+	}
+	return data[:n], nil
+}
+
+// Close закрывает файл.
+func (dz *dictzip) Close() error {
+	return dz.f.Close()
+}
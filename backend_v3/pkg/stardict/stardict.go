@@ -0,0 +1,320 @@
+// Package stardict читает словари StarDict: описание (.ifo), индекс
+// (.idx или .idx.gz) и статьи (.dict или сжатый dictzip .dict.dz).
+//
+// Индекс загружается в память целиком, статьи читаются с диска по запросу:
+// у dictzip — распаковкой только нужных блоков. Файл синонимов (.syn)
+// не поддерживается.
+package stardict
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ifoMagic — первая строка файла .ifo.
+	ifoMagic = "StarDict's dict ifo file"
+
+	// MaxIndexSize — максимальный размер распакованного индекса.
+	MaxIndexSize = 256 << 20
+
+	// MaxArticleSize — статьи больше считаются повреждёнными.
+	MaxArticleSize = 16 << 20
+)
+
+// ErrInvalidFile возвращается, если файлы не похожи на словарь StarDict.
+var ErrInvalidFile = errors.New("stardict: invalid dictionary")
+
+// Info — описание словаря из .ifo.
+type Info struct {
+	Version          string
+	BookName         string
+	WordCount        int
+	Author           string
+	Description      string
+	SameTypeSequence string // Типы частей каждой статьи; пусто — тип указан в статье
+	IdxOffsetBits    int    // 32 или 64
+}
+
+// Part — часть статьи. Типы в нижнем регистре — текст:
+// m (простой текст), h (HTML), x (XDXF), g (разметка Pango), t (транскрипция),
+// y (YinBiao/Kana); в верхнем — двоичные данные (W — звук, P — картинка).
+type Part struct {
+	Type byte
+	Data []byte
+}
+
+// Article — статья словаря.
+type Article struct {
+	Word  string
+	Parts []Part
+}
+
+// Dictionary — открытый словарь. Безопасен для одновременного чтения.
+type Dictionary struct {
+	Info  Info
+	index map[string][]location
+	data  dataReader
+}
+
+// location — положение статьи в файле .dict.
+type location struct {
+	word   string
+	offset int64
+	size   int64
+}
+
+// dataReader читает статьи из .dict или .dict.dz.
+type dataReader interface {
+	ReadAt(p []byte, off int64) (int, error)
+	Close() error
+}
+
+// Open открывает словарь по пути к файлу .ifo. Файлы индекса и статей
+// ищутся рядом с тем же именем.
+func Open(ifoPath string) (*Dictionary, error) {
+	info, err := readInfo(ifoPath)
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(ifoPath, ".ifo")
+
+	index, err := readIndex(base, info.IdxOffsetBits)
+	if err != nil {
+		return nil, err
+	}
+	data, err := openData(base)
+	if err != nil {
+		return nil, err
+	}
+	return &Dictionary{Info: info, index: index, data: data}, nil
+}
+
+// Close закрывает файл статей.
+func (d *Dictionary) Close() error {
+	return d.data.Close()
+}
+
+// Words возвращает количество слов в индексе.
+func (d *Dictionary) Words() int {
+	n := 0
+	for _, locs := range d.index {
+		n += len(locs)
+	}
+	return n
+}
+
+// Lookup возвращает статьи слова в порядке индекса. Регистр и пробелы
+// по краям не учитываются. Для слова, которого нет в словаре, возвращает nil.
+func (d *Dictionary) Lookup(word string) ([]Article, error) {
+	locs := d.index[Key(word)]
+	articles := make([]Article, 0, len(locs))
+	for _, loc := range locs {
+		buf := make([]byte, loc.size)
+		if n, err := d.data.ReadAt(buf, loc.offset); n < len(buf) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("stardict: read article %q: %w", loc.word, err)
+		}
+		parts, err := parseParts(buf, d.Info.SameTypeSequence)
+		if err != nil {
+			return nil, fmt.Errorf("%w: article %q: %v", ErrInvalidFile, loc.word, err)
+		}
+		articles = append(articles, Article{Word: loc.word, Parts: parts})
+	}
+	if len(articles) == 0 {
+		return nil, nil
+	}
+	return articles, nil
+}
+
+// Key возвращает ключ поиска слова: нижний регистр без пробелов по краям.
+func Key(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// ============================================================================
+// IFO
+// ============================================================================
+
+// readInfo читает описание словаря.
+func readInfo(path string) (Info, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Info{}, err
+	}
+	defer f.Close()
+
+	info := Info{IdxOffsetBits: 32}
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() || strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff")) != ifoMagic {
+		return Info{}, fmt.Errorf("%w: %s is not an .ifo file", ErrInvalidFile, path)
+	}
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "version":
+			info.Version = value
+		case "bookname":
+			info.BookName = value
+		case "wordcount":
+			info.WordCount, _ = strconv.Atoi(value)
+		case "author":
+			info.Author = value
+		case "description":
+			info.Description = value
+		case "sametypesequence":
+			info.SameTypeSequence = value
+		case "idxoffsetbits":
+			if value == "64" {
+				info.IdxOffsetBits = 64
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Info{}, fmt.Errorf("read %s: %w", path, err)
+	}
+	return info, nil
+}
+
+// ============================================================================
+// IDX
+// ============================================================================
+
+// readIndex читает .idx или .idx.gz: записи «слово\0, смещение, размер»
+// с числами big-endian.
+func readIndex(base string, offsetBits int) (map[string][]location, error) {
+	data, err := readMaybeGzip(base+".idx", base+".idx.gz", MaxIndexSize)
+	if err != nil {
+		return nil, err
+	}
+
+	offsetSize := offsetBits / 8
+	index := make(map[string][]location)
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 || len(data) < end+1+offsetSize+4 {
+			return nil, fmt.Errorf("%w: truncated index", ErrInvalidFile)
+		}
+		word := string(data[:end])
+		data = data[end+1:]
+
+		var loc location
+		if offsetSize == 8 {
+			loc.offset = int64(binary.BigEndian.Uint64(data))
+		} else {
+			loc.offset = int64(binary.BigEndian.Uint32(data))
+		}
+		loc.size = int64(binary.BigEndian.Uint32(data[offsetSize:]))
+		data = data[offsetSize+4:]
+
+		if loc.size > MaxArticleSize {
+			return nil, fmt.Errorf("%w: article %q exceeds %d bytes", ErrInvalidFile, word, MaxArticleSize)
+		}
+		loc.word = word
+		key := Key(word)
+		index[key] = append(index[key], loc)
+	}
+	return index, nil
+}
+
+// readMaybeGzip читает файл plain или, если его нет, сжатый gz.
+func readMaybeGzip(plain, gz string, limit int64) ([]byte, error) {
+	f, err := os.Open(plain)
+	compressed := false
+	if errors.Is(err, os.ErrNotExist) {
+		f, err = os.Open(gz)
+		compressed = true
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if compressed {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFile, gz, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", f.Name(), err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidFile, f.Name(), limit)
+	}
+	return data, nil
+}
+
+// ============================================================================
+// ARTICLE DATA
+// ============================================================================
+
+// parseParts делит статью на части. С sametypesequence типы частей заданы
+// описанием, и у последней части нет ни завершающего нуля, ни размера.
+// Без неё каждая часть начинается с байта типа.
+func parseParts(data []byte, sequence string) ([]Part, error) {
+	var parts []Part
+	if sequence != "" {
+		for i := 0; i < len(sequence); i++ {
+			part, rest, err := readPart(sequence[i], data, i == len(sequence)-1)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+			data = rest
+		}
+		return parts, nil
+	}
+
+	for len(data) > 0 {
+		part, rest, err := readPart(data[0], data[1:], false)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		data = rest
+	}
+	return parts, nil
+}
+
+// readPart читает часть типа t: текст до нуля или двоичные данные
+// с размером uint32. Последняя часть занимает остаток статьи.
+func readPart(t byte, data []byte, last bool) (Part, []byte, error) {
+	if last {
+		return Part{Type: t, Data: data}, nil, nil
+	}
+	if t >= 'a' && t <= 'z' {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return Part{Type: t, Data: data}, nil, nil
+		}
+		return Part{Type: t, Data: data[:end]}, data[end+1:], nil
+	}
+	if len(data) < 4 {
+		return Part{}, nil, fmt.Errorf("truncated part %q", t)
+	}
+	size := int(binary.BigEndian.Uint32(data))
+	if len(data) < 4+size {
+		return Part{}, nil, fmt.Errorf("truncated part %q", t)
+	}
+	return Part{Type: t, Data: data[4 : 4+size]}, data[4+size:], nil
+}
@@ -0,0 +1,173 @@
+package stardict
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	xdxfDog = "<k>Dog</k>\n<tr>dɒɡ</tr>\n<abr>n.</abr> <dtrn>собака</dtrn>; <dtrn>пёс</dtrn>\n<ex>a hunting dog — охотничья собака</ex>"
+	xdxfRun = "<k>run</k>\n<tr>rʌn</tr>\n<abr>v</abr>\n1) бежать; бегать\n2) управлять <co>(делом)</co>\n<ex>run a business — управлять делом</ex>"
+)
+
+func TestOpen_Dictzip(t *testing.T) {
+	d, err := Open("testdata/xdxf.ifo")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer d.Close()
+
+	if d.Info.BookName != "Test XDXF En-Ru" || d.Info.WordCount != 2 || d.Info.SameTypeSequence != "x" {
+		t.Errorf("Info = %+v", d.Info)
+	}
+	if d.Words() != 2 {
+		t.Errorf("Words() = %d, want 2", d.Words())
+	}
+
+	// Статьи занимают несколько блоков по 32 байта
+	for word, want := range map[string]string{"dog": xdxfDog, " RUN ": xdxfRun} {
+		articles, err := d.Lookup(word)
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", word, err)
+		}
+		if len(articles) != 1 || len(articles[0].Parts) != 1 {
+			t.Fatalf("Lookup(%q) = %+v", word, articles)
+		}
+		part := articles[0].Parts[0]
+		if part.Type != 'x' || string(part.Data) != want {
+			t.Errorf("Lookup(%q) part = %c %q", word, part.Type, part.Data)
+		}
+	}
+
+	articles, err := d.Lookup("dog")
+	if err != nil || len(articles) != 1 || articles[0].Word != "Dog" {
+		t.Errorf("Lookup(dog) = %+v, %v; want word Dog", articles, err)
+	}
+
+	articles, err = d.Lookup("cat")
+	if err != nil || articles != nil {
+		t.Errorf("Lookup(cat) = %v, %v; want nil, nil", articles, err)
+	}
+}
+
+func TestOpen_PlainGzip(t *testing.T) {
+	// .dict.dz без таблицы блоков распаковывается целиком
+	dir := t.TempDir()
+	copyFile(t, "testdata/xdxf.ifo", filepath.Join(dir, "xdxf.ifo"))
+	copyFile(t, "testdata/xdxf.idx", filepath.Join(dir, "xdxf.idx"))
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(xdxfDog + xdxfRun))
+	zw.Close()
+	if err := os.WriteFile(filepath.Join(dir, "xdxf.dict.dz"), buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Open(filepath.Join(dir, "xdxf.ifo"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer d.Close()
+
+	articles, err := d.Lookup("run")
+	if err != nil || len(articles) != 1 || string(articles[0].Parts[0].Data) != xdxfRun {
+		t.Errorf("Lookup(run) = %+v, %v", articles, err)
+	}
+}
+
+func TestOpen_TypedParts(t *testing.T) {
+	d, err := Open("testdata/plain.ifo")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer d.Close()
+
+	articles, err := d.Lookup("cat")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if len(articles) != 2 || articles[0].Word != "cat" || articles[1].Word != "Cat" {
+		t.Fatalf("Lookup(cat) = %+v", articles)
+	}
+	if got := partTypes(articles[0]); got != "tm" {
+		t.Errorf("cat parts = %q, want tm", got)
+	}
+	if string(articles[0].Parts[0].Data) != "kæt" {
+		t.Errorf("transcription = %q", articles[0].Parts[0].Data)
+	}
+	if got := partTypes(articles[1]); got != "h" {
+		t.Errorf("Cat parts = %q, want h", got)
+	}
+
+	articles, err = d.Lookup("purr")
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("Lookup(purr) = %+v, %v", articles, err)
+	}
+	if got := partTypes(articles[0]); got != "tWm" {
+		t.Errorf("purr parts = %q, want tWm", got)
+	}
+	if !bytes.Equal(articles[0].Parts[1].Data, []byte{0, 1, 2, 0}) {
+		t.Errorf("audio = %v", articles[0].Parts[1].Data)
+	}
+	if string(articles[0].Parts[2].Data) != "v. to make a low vibrating sound" {
+		t.Errorf("text = %q", articles[0].Parts[2].Data)
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	bad := filepath.Join(dir, "bad.ifo")
+	os.WriteFile(bad, []byte("not a dictionary\n"), 0o644)
+	if _, err := Open(bad); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("bad magic: err = %v, want ErrInvalidFile", err)
+	}
+
+	if _, err := Open(filepath.Join(dir, "missing.ifo")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing: err = %v, want ErrNotExist", err)
+	}
+
+	// Индекс обрывается посреди записи
+	copyFile(t, "testdata/xdxf.ifo", filepath.Join(dir, "cut.ifo"))
+	idx, _ := os.ReadFile("testdata/xdxf.idx")
+	os.WriteFile(filepath.Join(dir, "cut.idx"), idx[:len(idx)-3], 0o644)
+	if _, err := Open(filepath.Join(dir, "cut.ifo")); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("truncated index: err = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestParseParts(t *testing.T) {
+	parts, err := parseParts([]byte("kæt\x00a cat"), "tm")
+	if err != nil || len(parts) != 2 || string(parts[0].Data) != "kæt" || string(parts[1].Data) != "a cat" {
+		t.Errorf("sametypesequence: %+v, %v", parts, err)
+	}
+
+	if _, err := parseParts([]byte("W\x00\x00\x00\x09ab"), ""); err == nil {
+		t.Error("truncated binary part: want error")
+	}
+}
+
+func partTypes(a Article) string {
+	var b strings.Builder
+	for _, p := range a.Parts {
+		b.WriteByte(p.Type)
+	}
+	return b.String()
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
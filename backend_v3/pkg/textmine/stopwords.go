@@ -0,0 +1,41 @@
+package textmine
+
+import "strings"
+
+// stopWords — служебные и самые частые английские слова: местоимения,
+// артикли, предлоги, союзы, вспомогательные глаголы и их сокращения,
+// междометия субтитров. Учить их отдельно нет смысла.
+var stopWords = toSet(`
+a about above after again against all am an and any are aren't as at
+be because been before being below between both but by
+can can't cannot could couldn't
+did didn't do does doesn't doing don't down during
+each few for from further
+had hadn't has hasn't have haven't having he he'd he'll her here hers herself him himself his how
+i i'd i'll i'm i've if in into is isn't it it'll its itself
+let's me more most mustn't my myself
+no nor not of off on once only or other ought our ours ourselves out over own
+same shan't she she'd she'll should shouldn't so some such
+than that that'll the their theirs them themselves then there there'll these they they'd they'll they're they've
+this those through to too
+under until up very
+was wasn't we we'd we'll we're we've were weren't what what'll what're when where which while who who'll whom why
+will with won't would wouldn't
+you you'd you'll you're you've your yours yourself yourselves
+ah eh er hey hi hmm huh mm oh okay ok uh uh-huh um whoa wow yeah yep yes
+gonna gotta wanna y'all ain't
+`)
+
+// IsStopWord проверяет, что слово (в нижнем регистре) служебное.
+func IsStopWord(word string) bool {
+	return stopWords[word]
+}
+
+// toSet собирает множество слов, разделённых пробелами и переводами строк.
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,257 @@
+// Package textmine извлекает слова из текста и субтитров (SRT, WebVTT):
+// делит текст на предложения, выделяет слова, отбрасывает служебные слова
+// и имена собственные и считает, сколько раз встретилось каждое слово.
+// Поддерживается английский текст.
+package textmine
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Format — формат исходного текста.
+type Format string
+
+const (
+	FormatText Format = "TEXT" // Обычный текст
+	FormatSRT  Format = "SRT"  // Субтитры SubRip
+	FormatVTT  Format = "VTT"  // Субтитры WebVTT
+)
+
+// IsValid проверяет, что формат известен.
+func (f Format) IsValid() bool {
+	return f == FormatText || f == FormatSRT || f == FormatVTT
+}
+
+const (
+	// MaxSentenceLength — предложения длиннее (в символах) обрезаются.
+	MaxSentenceLength = 300
+
+	// MaxWordSentences — сколько предложений со словом запоминается.
+	MaxWordSentences = 3
+)
+
+// Word — слово текста.
+type Word struct {
+	Word      string // В нижнем регистре
+	Count     int    // Сколько раз встретилось
+	Sentences []int  // Индексы первых предложений со словом в Text.Sentences, не больше MaxWordSentences
+}
+
+// Text — результат разбора текста.
+type Text struct {
+	Sentences []string
+	Words     []Word // В порядке первого появления, без служебных слов и имён
+	Tokens    int    // Всего слов в тексте, включая служебные и имена
+}
+
+// ============================================================================
+// ANALYZE
+// ============================================================================
+
+// Analyze разбирает текст формата format; пустой формат определяется по
+// содержимому (см. DetectFormat).
+//
+// Именем собственным считается слово, которое ни разу не встретилось
+// в нижнем регистре, но хотя бы раз — с заглавной буквы не в начале предложения.
+func Analyze(text string, format Format) *Text {
+	if format == "" {
+		format = DetectFormat(text)
+	}
+	if format != FormatText {
+		text = ExtractSubtitles(text)
+	}
+
+	result := &Text{Sentences: Sentences(text)}
+	type stat struct {
+		Word
+		lower  bool // Встречалось в нижнем регистре
+		titled bool // Встречалось с заглавной буквы не в начале предложения
+	}
+	stats := make(map[string]*stat)
+	var order []*stat
+
+	for i, sentence := range result.Sentences {
+		for j, token := range tokenize(sentence) {
+			result.Tokens++
+			word := strings.ToLower(token)
+			s, ok := stats[word]
+			if !ok {
+				s = &stat{Word: Word{Word: word}}
+				stats[word] = s
+				order = append(order, s)
+			}
+			s.Count++
+			if n := len(s.Sentences); n < MaxWordSentences && (n == 0 || s.Sentences[n-1] != i) {
+				s.Sentences = append(s.Sentences, i)
+			}
+			switch {
+			case token == word:
+				s.lower = true
+			case j > 0 && isTitleCase(token):
+				s.titled = true
+			}
+		}
+	}
+
+	for _, s := range order {
+		if IsStopWord(s.Word.Word) || (s.titled && !s.lower) {
+			continue
+		}
+		result.Words = append(result.Words, s.Word)
+	}
+	return result
+}
+
+// isTitleCase проверяет, что слово начинается с заглавной буквы, а дальше строчные.
+func isTitleCase(token string) bool {
+	first, size := utf8.DecodeRuneInString(token)
+	return unicode.IsUpper(first) && strings.ToLower(token[size:]) == token[size:]
+}
+
+// ============================================================================
+// SUBTITLES
+// ============================================================================
+
+var (
+	// timingRe — строка тайминга реплики: "00:00:01,000 --> 00:00:04,000" (SRT)
+	// или "00:01.000 --> 00:04.000 align:start" (WebVTT).
+	timingRe = regexp.MustCompile(`^\s*(\d+:)?\d+:\d+[,.]\d+\s+-->\s+(\d+:)?\d+:\d+[,.]\d+`)
+
+	// markupRe — теги (<i>, <v Roger>, <00:01.500>) и команды ASS ({\an8}).
+	markupRe = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+
+	// descriptionRe — описания звуков для слабослышащих: [MUSIC], (laughs).
+	descriptionRe = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+
+	// speakerRe — имя говорящего в начале реплики: "JOHN:", "- MARY:".
+	speakerRe = regexp.MustCompile(`^[-–—\s]*[\p{Lu}][\p{Lu}\s.'-]*:\s*`)
+)
+
+// DetectFormat определяет формат по содержимому: заголовок WEBVTT — WebVTT,
+// строка тайминга — SRT, иначе обычный текст.
+func DetectFormat(text string) Format {
+	text = strings.TrimPrefix(text, "\ufeff")
+	if strings.HasPrefix(strings.TrimSpace(text), "WEBVTT") {
+		return FormatVTT
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if timingRe.MatchString(line) {
+			return FormatSRT
+		}
+	}
+	return FormatText
+}
+
+// ExtractSubtitles возвращает текст реплик субтитров SRT или WebVTT:
+// без номеров, таймингов, разметки и описаний звуков, по реплике на абзац.
+func ExtractSubtitles(text string) string {
+	text = strings.ReplaceAll(strings.TrimPrefix(text, "\ufeff"), "\r\n", "\n")
+
+	var cues []string
+	for _, block := range strings.Split(text, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		start := -1
+		for i, line := range lines {
+			if timingRe.MatchString(line) {
+				start = i + 1
+				break
+			}
+		}
+		// Блоки без тайминга — заголовок WEBVTT, NOTE, STYLE, REGION
+		if start < 0 {
+			continue
+		}
+
+		var parts []string
+		for _, line := range lines[start:] {
+			line = html.UnescapeString(markupRe.ReplaceAllString(line, ""))
+			line = descriptionRe.ReplaceAllString(line, "")
+			line = speakerRe.ReplaceAllString(line, "")
+			line = strings.TrimLeft(strings.TrimSpace(line), "-–— ")
+			if line != "" {
+				parts = append(parts, line)
+			}
+		}
+		if len(parts) > 0 {
+			cues = append(cues, strings.Join(parts, " "))
+		}
+	}
+	return strings.Join(cues, "\n")
+}
+
+// ============================================================================
+// SENTENCES & TOKENS
+// ============================================================================
+
+// Sentences делит текст на предложения по знакам .!?… и пустым строкам.
+// Переводы строк внутри абзаца и реплики субтитров, разорванные посреди
+// предложения, склеиваются.
+func Sentences(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var result []string
+	var current strings.Builder
+	flush := func() {
+		s := strings.Join(strings.Fields(current.String()), " ")
+		current.Reset()
+		if s == "" || !strings.ContainsFunc(s, unicode.IsLetter) {
+			return
+		}
+		if utf8.RuneCountInString(s) > MaxSentenceLength {
+			s = string([]rune(s)[:MaxSentenceLength-1]) + "…"
+		}
+		result = append(result, s)
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' && i+1 < len(runes) && runes[i+1] == '\n' {
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+		if !strings.ContainsRune(".!?…", r) {
+			continue
+		}
+		// Продолжение многоточия и закрывающие кавычки остаются в предложении
+		for i+1 < len(runes) && strings.ContainsRune(".!?…\"'”’)", runes[i+1]) {
+			i++
+			current.WriteRune(runes[i])
+		}
+		if i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+// tokenize выделяет слова предложения: буквы с апострофами и дефисами
+// внутри. Притяжательное 's отбрасывается; слова с цифрами и другие
+// слова с апострофом (сокращения вроде o'clock) пропускаются.
+func tokenize(sentence string) []string {
+	var tokens []string
+	fields := strings.FieldsFunc(sentence, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’' && r != '-'
+	})
+	for _, f := range fields {
+		f = strings.ReplaceAll(f, "’", "'")
+		f = strings.Trim(f, "'-")
+		if strings.HasSuffix(f, "'s") || strings.HasSuffix(f, "'S") {
+			f = f[:len(f)-2]
+		}
+		if utf8.RuneCountInString(f) < 2 || strings.ContainsFunc(f, unicode.IsDigit) || strings.Contains(f, "--") {
+			continue
+		}
+		if strings.Contains(f, "'") && !IsStopWord(strings.ToLower(f)) {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
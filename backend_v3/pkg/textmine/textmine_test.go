@@ -0,0 +1,133 @@
+package textmine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSRT = "\ufeff1\r\n00:00:01,000 --> 00:00:03,500\r\n<i>The weather is dreadful</i>\r\n\r\n" +
+	"2\r\n00:00:03,600 --> 00:00:06,000\r\n- JOHN: but we walked anyway.\r\n- [THUNDER] We always walk.\r\n\r\n" +
+	"3\r\n00:00:06,100 --> 00:00:08,000\r\n{\\an8}Did you see Sherlock walking?\r\n"
+
+const testVTT = `WEBVTT
+Kind: captions
+
+NOTE the weather note is ignored
+
+STYLE
+::cue { color: yellow }
+
+intro
+00:01.000 --> 00:03.500 align:start position:10%
+<v Roger>The weather is &amp; was dreadful.
+
+00:03.600 --> 00:06.000
+(sighs) <00:04.000>Dreadful indeed!
+`
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		text string
+		want Format
+	}{
+		{testSRT, FormatSRT},
+		{testVTT, FormatVTT},
+		{"An article about the weather --> not subtitles.", FormatText},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.text); got != tt.want {
+			t.Errorf("DetectFormat(%.20q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExtractSubtitles(t *testing.T) {
+	got := ExtractSubtitles(testSRT)
+	want := "The weather is dreadful\nbut we walked anyway. We always walk.\nDid you see Sherlock walking?"
+	if got != want {
+		t.Errorf("ExtractSubtitles(SRT) = %q, want %q", got, want)
+	}
+
+	got = ExtractSubtitles(testVTT)
+	want = "The weather is & was dreadful.\nDreadful indeed!"
+	if got != want {
+		t.Errorf("ExtractSubtitles(VTT) = %q, want %q", got, want)
+	}
+}
+
+func TestSentences(t *testing.T) {
+	text := "Mr Smith arrived... Late again!\nHe said \"hello.\" Then left\n\nNew paragraph without a stop\n\n-- 42 --"
+	want := []string{
+		"Mr Smith arrived...",
+		"Late again!",
+		`He said "hello."`,
+		"Then left",
+		"New paragraph without a stop",
+	}
+	if got := Sentences(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sentences() = %q, want %q", got, want)
+	}
+
+	long := strings.Repeat("word ", 100)
+	if got := Sentences(long); len([]rune(got[0])) != MaxSentenceLength {
+		t.Errorf("len(Sentences(long)[0]) = %d, want %d", len([]rune(got[0])), MaxSentenceLength)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("Don't touch Mary’s well-known 3D cat's o'clock toy -- x 2024!")
+	want := []string{"Don't", "touch", "Mary", "well-known", "cat", "toy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	text := Analyze(testSRT, "")
+
+	wantSentences := []string{
+		"The weather is dreadful but we walked anyway.",
+		"We always walk.",
+		"Did you see Sherlock walking?",
+	}
+	if !reflect.DeepEqual(text.Sentences, wantSentences) {
+		t.Errorf("Sentences = %q, want %q", text.Sentences, wantSentences)
+	}
+	if text.Tokens != 16 {
+		t.Errorf("Tokens = %d, want 16", text.Tokens)
+	}
+
+	// Служебные слова и имя Sherlock отброшены
+	want := []Word{
+		{Word: "weather", Count: 1, Sentences: []int{0}},
+		{Word: "dreadful", Count: 1, Sentences: []int{0}},
+		{Word: "walked", Count: 1, Sentences: []int{0}},
+		{Word: "anyway", Count: 1, Sentences: []int{0}},
+		{Word: "always", Count: 1, Sentences: []int{1}},
+		{Word: "walk", Count: 1, Sentences: []int{1}},
+		{Word: "see", Count: 1, Sentences: []int{2}},
+		{Word: "walking", Count: 1, Sentences: []int{2}},
+	}
+	if !reflect.DeepEqual(text.Words, want) {
+		t.Errorf("Words = %+v, want %+v", text.Words, want)
+	}
+}
+
+func TestAnalyzeCapitalized(t *testing.T) {
+	// Слово с заглавной буквы в начале предложения — не имя;
+	// встретившееся в нижнем регистре — тоже
+	text := Analyze("Rain again. We hate the Rain and the rain hates Bob.", FormatText)
+
+	var words []string
+	for _, w := range text.Words {
+		words = append(words, w.Word)
+	}
+	want := []string{"rain", "hate", "hates"}
+	if !reflect.DeepEqual(words, want) {
+		t.Errorf("Words = %q, want %q", words, want)
+	}
+	if text.Words[0].Count != 3 || !reflect.DeepEqual(text.Words[0].Sentences, []int{0, 1}) {
+		t.Errorf("rain = %+v, want count 3 in sentences [0 1]", text.Words[0])
+	}
+}
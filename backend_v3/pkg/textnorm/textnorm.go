@@ -0,0 +1,78 @@
+// Package textnorm нормализует текст слов и переводов с учётом языка.
+//
+// Нормализованная форма используется для проверки дубликатов и поиска:
+// слова, совпадающие после нормализации, считаются одним словом.
+package textnorm
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ============================================================================
+// LANGUAGES
+// ============================================================================
+
+const (
+	// DefaultEntryLanguage — язык слов по умолчанию (изучаемый язык).
+	DefaultEntryLanguage = "en"
+
+	// DefaultTranslationLanguage — язык переводов по умолчанию (родной язык).
+	DefaultTranslationLanguage = "ru"
+)
+
+// languageCodeRe — код языка ISO 639-1 или ISO 639-3 в нижнем регистре.
+var languageCodeRe = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// IsValidLanguage проверяет, что code — код языка ISO 639 ("en", "de", "ru").
+func IsValidLanguage(code string) bool {
+	return languageCodeRe.MatchString(code)
+}
+
+// foldDiacritics — языки, в которых диакритика не различает слова словаря:
+// "café" и "cafe", "naïve" и "naive" — одно и то же слово.
+// В остальных языках диакритика значима (нем. "schon" и "schön").
+var foldDiacritics = map[string]bool{
+	"en": true,
+}
+
+// ============================================================================
+// NORMALIZATION
+// ============================================================================
+
+// Normalize возвращает нормализованную форму текста на языке lang:
+//   - Unicode NFKC (лигатуры, полноширинные символы, совместимые формы);
+//   - нижний регистр по правилам языка (тур. "I" -> "ı");
+//   - схлопывание пробелов;
+//   - снятие диакритики для языков из foldDiacritics;
+//   - "ё" -> "е" для русского.
+func Normalize(lang, s string) string {
+	s = norm.NFKC.String(s)
+	s = cases.Lower(language.Make(lang)).String(s)
+	s = strings.Join(strings.Fields(s), " ")
+
+	if foldDiacritics[lang] {
+		s = stripDiacritics(s)
+	}
+	if lang == "ru" {
+		s = strings.ReplaceAll(s, "ё", "е")
+	}
+	return s
+}
+
+// stripDiacritics удаляет комбинируемые знаки после канонической декомпозиции.
+func stripDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package textnorm
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		in   string
+		want string
+	}{
+		{name: "lowercase and trim", lang: "en", in: "  Hello World ", want: "hello world"},
+		{name: "collapse spaces", lang: "en", in: "give   up", want: "give up"},
+		{name: "english diacritics folded", lang: "en", in: "Café", want: "cafe"},
+		{name: "ligature NFKC", lang: "en", in: "ﬁne", want: "fine"},
+		{name: "fullwidth NFKC", lang: "en", in: "ＡＢＣ", want: "abc"},
+		{name: "german umlauts kept", lang: "de", in: "Schön", want: "schön"},
+		{name: "german eszett kept", lang: "de", in: "Straße", want: "straße"},
+		{name: "russian yo folded", lang: "ru", in: "Ёлка", want: "елка"},
+		{name: "turkish dotted i", lang: "tr", in: "İstanbul", want: "istanbul"},
+		{name: "turkish dotless i", lang: "tr", in: "IRMAK", want: "ırmak"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.lang, tt.in); got != tt.want {
+				t.Errorf("Normalize(%q, %q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidLanguage(t *testing.T) {
+	for _, code := range []string{"en", "de", "ru", "yue"} {
+		if !IsValidLanguage(code) {
+			t.Errorf("IsValidLanguage(%q) = false, want true", code)
+		}
+	}
+	for _, code := range []string{"", "e", "EN", "en-US", "engl"} {
+		if IsValidLanguage(code) {
+			t.Errorf("IsValidLanguage(%q) = true, want false", code)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Package wiktextract читает дампы Викисловаря в формате Wiktextract
+// (https://kaikki.org): JSONL, по статье на строку. Статья — слово одной
+// части речи одного языка: значения с примерами, произношение, переводы,
+// синонимы и словоформы.
+//
+// Читаются только поля, нужные словарю; остальные игнорируются.
+package wiktextract
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// MaxLineSize — максимальная длина строки дампа. Статьи самых
+// многозначных слов занимают несколько мегабайт.
+const MaxLineSize = 64 << 20
+
+// ErrInvalidLine возвращается для строки, которая не разбирается как статья.
+// Чтение можно продолжить со следующей строки.
+var ErrInvalidLine = errors.New("wiktextract: invalid line")
+
+// ============================================================================
+// ENTRY
+// ============================================================================
+
+// Entry — статья дампа.
+type Entry struct {
+	Word         string        `json:"word"`
+	LangCode     string        `json:"lang_code"` // Язык слова (en)
+	POS          string        `json:"pos"`       // noun, verb, adj, adv, name, phrase...
+	Senses       []Sense       `json:"senses"`
+	Sounds       []Sound       `json:"sounds"`
+	Translations []Translation `json:"translations"` // Переводы статьи; Sense указывает значение
+	Synonyms     []Link        `json:"synonyms"`
+	Forms        []Form        `json:"forms"`
+}
+
+// Sense — значение слова.
+type Sense struct {
+	Glosses      []string      `json:"glosses"` // От общего значения к частному
+	Tags         []string      `json:"tags"`
+	Examples     []Example     `json:"examples"`
+	Synonyms     []Link        `json:"synonyms"`
+	Translations []Translation `json:"translations"` // Есть в дампах новых версий
+}
+
+// Example — пример употребления или цитата.
+type Example struct {
+	Text        string `json:"text"`
+	Translation string `json:"translation"`
+	English     string `json:"english"` // Перевод на английский в статьях других языков
+	Type        string `json:"type"`    // example, quotation
+}
+
+// Sound — транскрипция или запись произношения.
+type Sound struct {
+	IPA    string   `json:"ipa"`
+	Audio  string   `json:"audio"` // Имя файла на Викискладе
+	OggURL string   `json:"ogg_url"`
+	MP3URL string   `json:"mp3_url"`
+	Tags   []string `json:"tags"` // Вариант произношения: US, UK, Received-Pronunciation...
+}
+
+// Translation — перевод слова на другой язык.
+type Translation struct {
+	Code  string   `json:"code"` // Язык перевода (ru)
+	Lang  string   `json:"lang"`
+	Word  string   `json:"word"`
+	Sense string   `json:"sense"` // Краткое описание значения, к которому относится перевод
+	Tags  []string `json:"tags"`
+}
+
+// Link — ссылка на другое слово (синоним).
+type Link struct {
+	Word string `json:"word"`
+}
+
+// Form — словоформа: dogs (plural), went (past).
+type Form struct {
+	Form string   `json:"form"`
+	Tags []string `json:"tags"`
+}
+
+// ============================================================================
+// READER
+// ============================================================================
+
+// Reader читает статьи дампа по одной.
+type Reader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewReader создаёт Reader, читающий дамп из r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, 1<<20)}
+}
+
+// Line возвращает номер последней прочитанной строки.
+func (r *Reader) Line() int { return r.line }
+
+// Next возвращает следующую статью; в конце дампа — io.EOF. Пустые строки
+// пропускаются. Для строки, которая не разбирается, возвращает ошибку
+// с ErrInvalidLine; следующий вызов читает следующую строку.
+func (r *Reader) Next() (*Entry, error) {
+	for {
+		data, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("%w %d: %v", ErrInvalidLine, r.line, err)
+		}
+		return &e, nil
+	}
+}
+
+// readLine читает строку без перевода строки. Слишком длинная строка
+// дочитывается и возвращается ошибкой.
+func (r *Reader) readLine() ([]byte, error) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, isPrefix, err := r.r.ReadLine()
+		if err != nil {
+			if err == io.EOF && (len(line) > 0 || tooLong) {
+				break
+			}
+			return nil, err
+		}
+		if !tooLong {
+			line = append(line, chunk...)
+			if len(line) > MaxLineSize {
+				line, tooLong = nil, true
+			}
+		}
+		if !isPrefix {
+			break
+		}
+	}
+	if tooLong {
+		r.line++
+		return nil, fmt.Errorf("%w %d: exceeds %d bytes", ErrInvalidLine, r.line, MaxLineSize)
+	}
+	return line, nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// Gloss возвращает определение значения: самое частное из Glosses.
+func (s *Sense) Gloss() string {
+	for i := len(s.Glosses) - 1; i >= 0; i-- {
+		if g := strings.TrimSpace(s.Glosses[i]); g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+// SenseTranslations распределяет переводы на язык code по значениям статьи:
+// result[i] — переводы значения i без повторов. Переводы из самого значения
+// берутся как есть; переводы статьи относятся к первому значению, определение
+// которого начинается с их Sense или содержит его, а без совпадения —
+// к первому значению.
+func (e *Entry) SenseTranslations(code string) [][]string {
+	result := make([][]string, len(e.Senses))
+	if len(e.Senses) == 0 {
+		return result
+	}
+	seen := make([]map[string]bool, len(e.Senses))
+	add := func(i int, t Translation) {
+		word := strings.TrimSpace(t.Word)
+		if t.Code != code || word == "" {
+			return
+		}
+		if seen[i] == nil {
+			seen[i] = make(map[string]bool)
+		}
+		if !seen[i][word] {
+			seen[i][word] = true
+			result[i] = append(result[i], word)
+		}
+	}
+
+	glosses := make([]string, len(e.Senses))
+	for i := range e.Senses {
+		glosses[i] = strings.ToLower(e.Senses[i].Gloss())
+		for _, t := range e.Senses[i].Translations {
+			add(i, t)
+		}
+	}
+	for _, t := range e.Translations {
+		add(matchSense(glosses, t.Sense), t)
+	}
+	return result
+}
+
+// matchSense находит значение, к которому относится описание sense.
+func matchSense(glosses []string, sense string) int {
+	sense = strings.ToLower(strings.TrimSpace(sense))
+	if sense == "" {
+		return 0
+	}
+	for i, g := range glosses {
+		if strings.HasPrefix(g, sense) {
+			return i
+		}
+	}
+	for i, g := range glosses {
+		if strings.Contains(g, sense) {
+			return i
+		}
+	}
+	return 0
+}
+
+// AudioURL возвращает ссылку на запись: MP3, если есть, иначе Ogg.
+// Пусто, если записи нет.
+func (s *Sound) AudioURL() string {
+	if u := strings.TrimSpace(s.MP3URL); u != "" {
+		return u
+	}
+	return strings.TrimSpace(s.OggURL)
+}
+
+// regions — теги вариантов произношения и их коды.
+var regions = map[string]string{
+	"US":                     "US",
+	"General-American":       "US",
+	"UK":                     "UK",
+	"Received-Pronunciation": "UK",
+	"British":                "UK",
+	"Australia":              "AU",
+	"General-Australian":     "AU",
+	"Canada":                 "CA",
+	"New-Zealand":            "NZ",
+	"Ireland":                "IE",
+	"Scotland":               "SCO",
+	"India":                  "IN",
+}
+
+// Region возвращает вариант произношения (US, UK, AU...) по тегам записи,
+// а если тегов нет — по имени файла (En-us-dog.ogg -> US). Пусто, если
+// вариант не указан.
+func (s *Sound) Region() string {
+	for _, tag := range s.Tags {
+		if region, ok := regions[tag]; ok {
+			return region
+		}
+	}
+	name := strings.ToLower(path.Base(s.Audio))
+	for prefix, region := range map[string]string{"en-us-": "US", "en-uk-": "UK", "en-au-": "AU", "en-ca-": "CA"} {
+		if strings.HasPrefix(name, prefix) {
+			return region
+		}
+	}
+	return ""
+}
+
+// formTagsSkipped — служебные «словоформы»: шаблоны и заголовки таблиц.
+var formTagsSkipped = map[string]bool{
+	"table-tags":          true,
+	"inflection-template": true,
+	"class":               true,
+	"romanization":        true,
+}
+
+// WordForms возвращает словоформы статьи без служебных записей и повторов
+// самого слова.
+func (e *Entry) WordForms() []Form {
+	var result []Form
+	seen := make(map[string]bool)
+	for _, f := range e.Forms {
+		form := strings.TrimSpace(f.Form)
+		if form == "" || form == e.Word || form == "-" || seen[form] || hasAny(f.Tags, formTagsSkipped) {
+			continue
+		}
+		seen[form] = true
+		result = append(result, Form{Form: form, Tags: f.Tags})
+	}
+	return result
+}
+
+// AllSynonyms возвращает синонимы статьи и её значений без повторов.
+func (e *Entry) AllSynonyms() []string {
+	var result []string
+	seen := map[string]bool{e.Word: true}
+	add := func(links []Link) {
+		for _, l := range links {
+			if w := strings.TrimSpace(l.Word); w != "" && !seen[w] {
+				seen[w] = true
+				result = append(result, w)
+			}
+		}
+	}
+	add(e.Synonyms)
+	for i := range e.Senses {
+		add(e.Senses[i].Synonyms)
+	}
+	return result
+}
+
+func hasAny(tags []string, set map[string]bool) bool {
+	for _, t := range tags {
+		if set[t] {
+			return true
+		}
+	}
+	return false
+}
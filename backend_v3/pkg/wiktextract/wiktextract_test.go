@@ -0,0 +1,164 @@
+package wiktextract
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, r *Reader) ([]*Entry, []int) {
+	t.Helper()
+	var entries []*Entry
+	var invalid []int
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			return entries, invalid
+		}
+		if errors.Is(err, ErrInvalidLine) {
+			invalid = append(invalid, r.Line())
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+func TestReader(t *testing.T) {
+	f, err := os.Open("testdata/dump.jsonl")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+
+	entries, invalid := readAll(t, NewReader(f))
+
+	// Пустая строка пропущена, обрезанная строка — ошибка, чтение продолжается
+	if !reflect.DeepEqual(invalid, []int{4}) {
+		t.Errorf("invalid lines = %v, want [4]", invalid)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("len(entries) = %d, want 3", len(entries))
+	}
+
+	dog := entries[0]
+	if dog.Word != "dog" || dog.LangCode != "en" || dog.POS != "noun" {
+		t.Errorf("entries[0] = %s %s %s", dog.Word, dog.LangCode, dog.POS)
+	}
+	if len(dog.Senses) != 3 || len(dog.Sounds) != 4 || len(dog.Translations) != 5 {
+		t.Errorf("entries[0] senses/sounds/translations = %d/%d/%d",
+			len(dog.Senses), len(dog.Sounds), len(dog.Translations))
+	}
+	if got := dog.Senses[0].Examples[1].Type; got != "quotation" {
+		t.Errorf("example type = %q", got)
+	}
+	if entries[2].LangCode != "de" {
+		t.Errorf("entries[2].LangCode = %q", entries[2].LangCode)
+	}
+}
+
+func TestReader_LongLine(t *testing.T) {
+	long := `{"word": "` + strings.Repeat("a", MaxLineSize) + `"}`
+	r := NewReader(strings.NewReader(long + "\n" + `{"word": "b"}`))
+
+	if _, err := r.Next(); !errors.Is(err, ErrInvalidLine) {
+		t.Fatalf("Next() error = %v, want ErrInvalidLine", err)
+	}
+	e, err := r.Next()
+	if err != nil || e.Word != "b" || r.Line() != 2 {
+		t.Fatalf("Next() = %+v, %v at line %d", e, err, r.Line())
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Next() error = %v, want io.EOF", err)
+	}
+}
+
+func TestSense_Gloss(t *testing.T) {
+	s := Sense{Glosses: []string{"A man.", " A dull, unattractive man. ", ""}}
+	if got := s.Gloss(); got != "A dull, unattractive man." {
+		t.Errorf("Gloss() = %q", got)
+	}
+	if got := (&Sense{}).Gloss(); got != "" {
+		t.Errorf("Gloss() of empty sense = %q", got)
+	}
+}
+
+func TestEntry_SenseTranslations(t *testing.T) {
+	e := Entry{
+		Senses: []Sense{
+			{Glosses: []string{"A mammal, Canis familiaris."}},
+			{Glosses: []string{"A man.", "A dull, unattractive man."}},
+			{
+				Glosses:      []string{"Something inferior."},
+				Translations: []Translation{{Code: "ru", Word: "дрянь"}, {Code: "de", Word: "Mist"}},
+			},
+		},
+		Translations: []Translation{
+			{Code: "ru", Word: "собака", Sense: "a mammal"},
+			{Code: "ru", Word: "пёс", Sense: "canis"},
+			{Code: "ru", Word: "тип", Sense: "A dull, unattractive man"},
+			{Code: "ru", Word: "собака", Sense: "a mammal"},
+			{Code: "ru", Word: "псина", Sense: "unknown sense"},
+			{Code: "ru", Word: " "},
+			{Code: "de", Word: "Hund", Sense: "a mammal"},
+		},
+	}
+
+	want := [][]string{{"собака", "пёс", "псина"}, {"тип"}, {"дрянь"}}
+	if got := e.SenseTranslations("ru"); !reflect.DeepEqual(got, want) {
+		t.Errorf("SenseTranslations() = %v, want %v", got, want)
+	}
+	if got := (&Entry{}).SenseTranslations("ru"); len(got) != 0 {
+		t.Errorf("SenseTranslations() without senses = %v", got)
+	}
+}
+
+func TestSound_AudioURLAndRegion(t *testing.T) {
+	tests := []struct {
+		sound  Sound
+		url    string
+		region string
+	}{
+		{Sound{OggURL: "https://x/a.ogg", MP3URL: "https://x/a.mp3", Tags: []string{"General-American"}}, "https://x/a.mp3", "US"},
+		{Sound{OggURL: "https://x/a.ogg", Tags: []string{"Received-Pronunciation"}}, "https://x/a.ogg", "UK"},
+		{Sound{Audio: "En-au-dog.ogg", OggURL: "https://x/b.ogg"}, "https://x/b.ogg", "AU"},
+		{Sound{IPA: "/dɒɡ/"}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.sound.AudioURL(); got != tt.url {
+			t.Errorf("AudioURL(%+v) = %q, want %q", tt.sound, got, tt.url)
+		}
+		if got := tt.sound.Region(); got != tt.region {
+			t.Errorf("Region(%+v) = %q, want %q", tt.sound, got, tt.region)
+		}
+	}
+}
+
+func TestEntry_WordFormsAndSynonyms(t *testing.T) {
+	e := Entry{
+		Word: "dog",
+		Forms: []Form{
+			{Form: "dogs", Tags: []string{"plural"}},
+			{Form: "en-noun", Tags: []string{"inflection-template"}},
+			{Form: "dog"},
+			{Form: "dogs", Tags: []string{"plural"}},
+			{Form: "-"},
+		},
+		Synonyms: []Link{{Word: "doggy"}, {Word: "dog"}},
+		Senses:   []Sense{{Synonyms: []Link{{Word: "hound"}}}, {Synonyms: []Link{{Word: "cur"}, {Word: "hound"}}}},
+	}
+
+	wantForms := []Form{{Form: "dogs", Tags: []string{"plural"}}}
+	if got := e.WordForms(); !reflect.DeepEqual(got, wantForms) {
+		t.Errorf("WordForms() = %+v, want %+v", got, wantForms)
+	}
+	wantSynonyms := []string{"doggy", "hound", "cur"}
+	if got := e.AllSynonyms(); !reflect.DeepEqual(got, wantSynonyms) {
+		t.Errorf("AllSynonyms() = %v, want %v", got, wantSynonyms)
+	}
+}
@@ -0,0 +1,489 @@
+// Package wndb читает базу Princeton WordNet в формате WNdb (файлы
+// index.*, data.* и *.exc каталога dict): синсеты слова с глоссами
+// и указателями на другие синсеты (антонимы, гиперонимы и т. д.).
+//
+// Индексы и списки исключений загружаются в память, синсеты читаются
+// из файлов data.* по смещению. Начальная форма слова ищется так же,
+// как в морфологии WordNet (morphy): по спискам исключений и правилам
+// отбрасывания окончаний.
+package wndb
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// POS — часть речи WordNet.
+type POS byte
+
+const (
+	Noun      POS = 'n'
+	Verb      POS = 'v'
+	Adjective POS = 'a'
+	Satellite POS = 's' // Прилагательное-сателлит; хранится в файлах прилагательных
+	Adverb    POS = 'r'
+)
+
+// AllPOS — части речи в порядке выдачи результатов.
+var AllPOS = []POS{Noun, Verb, Adjective, Adverb}
+
+// fileNames — суффиксы файлов части речи.
+var fileNames = map[POS]string{
+	Noun:      "noun",
+	Verb:      "verb",
+	Adjective: "adj",
+	Adverb:    "adv",
+}
+
+// Символы указателей, которые чаще всего нужны.
+const (
+	PointerAntonym          = "!"
+	PointerHypernym         = "@"
+	PointerInstanceHypernym = "@i"
+	PointerHyponym          = "~"
+	PointerSimilarTo        = "&"
+)
+
+// maxLineSize — строки data.* длиннее считаются повреждёнными.
+const maxLineSize = 1 << 20
+
+// ErrInvalidFile возвращается, если файлы не похожи на базу WordNet.
+var ErrInvalidFile = errors.New("wndb: invalid database")
+
+// Word — слово синсета.
+type Word struct {
+	Lemma string // С подчёркиваниями вместо пробелов: hot_dog
+	LexID int
+}
+
+// Text возвращает слово с пробелами: hot dog.
+func (w Word) Text() string {
+	return strings.ReplaceAll(w.Lemma, "_", " ")
+}
+
+// Pointer — указатель на другой синсет или слово в нём.
+type Pointer struct {
+	Symbol string
+	Offset int64
+	POS    POS
+	Source int // Номер слова этого синсета (с 1); 0 — указатель всего синсета
+	Target int // Номер слова целевого синсета (с 1); 0 — весь синсет
+}
+
+// Synset — синсет: набор синонимов с общим значением.
+type Synset struct {
+	Offset   int64
+	POS      POS
+	LexFile  int
+	Words    []Word
+	Pointers []Pointer
+	Gloss    string // Определение и примеры: `definition; "example"`
+}
+
+// Definition возвращает определение из глоссы (без примеров).
+func (s *Synset) Definition() string {
+	definition, _ := splitGloss(s.Gloss)
+	return definition
+}
+
+// Examples возвращает примеры из глоссы без кавычек.
+func (s *Synset) Examples() []string {
+	_, examples := splitGloss(s.Gloss)
+	return examples
+}
+
+// WordIndex возвращает номер слова lemma в синсете (с 1) или 0.
+func (s *Synset) WordIndex(lemma string) int {
+	for i, w := range s.Words {
+		if strings.EqualFold(w.Lemma, lemma) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Database — открытая база. Безопасна для одновременного чтения.
+type Database struct {
+	index      map[POS]map[string][]int64
+	exceptions map[POS]map[string][]string
+	data       map[POS]*os.File
+}
+
+// Open открывает базу в каталоге dir (обычно WordNet-3.0/dict).
+func Open(dir string) (*Database, error) {
+	db := &Database{
+		index:      make(map[POS]map[string][]int64),
+		exceptions: make(map[POS]map[string][]string),
+		data:       make(map[POS]*os.File),
+	}
+	for _, pos := range AllPOS {
+		name := fileNames[pos]
+
+		index, err := readIndex(filepath.Join(dir, "index."+name))
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		db.index[pos] = index
+
+		exceptions, err := readExceptions(filepath.Join(dir, name+".exc"))
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		db.exceptions[pos] = exceptions
+
+		f, err := os.Open(filepath.Join(dir, "data."+name))
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		db.data[pos] = f
+	}
+	return db, nil
+}
+
+// Close закрывает файлы data.*.
+func (db *Database) Close() error {
+	var errs []error
+	for _, f := range db.data {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Lemmas возвращает начальные формы слова, которые есть в индексе части
+// речи pos: само слово, формы из списка исключений и формы по правилам
+// (dogs → dog, went → go). Регистр не учитывается.
+func (db *Database) Lemmas(word string, pos POS) []string {
+	word = Key(word)
+	if word == "" {
+		return nil
+	}
+	index := db.index[pos]
+
+	var result []string
+	seen := make(map[string]bool)
+	add := func(lemma string) {
+		if _, ok := index[lemma]; ok && !seen[lemma] {
+			seen[lemma] = true
+			result = append(result, lemma)
+		}
+	}
+
+	add(word)
+	for _, base := range db.exceptions[pos][word] {
+		add(base)
+	}
+	for _, rule := range detachRules[pos] {
+		if strings.HasSuffix(word, rule.suffix) && len(word) > len(rule.suffix) {
+			add(strings.TrimSuffix(word, rule.suffix) + rule.ending)
+		}
+	}
+	return result
+}
+
+// Lookup возвращает синсеты слова части речи pos в порядке частотности
+// значений. Слово приводится к начальной форме (см. Lemmas).
+func (db *Database) Lookup(word string, pos POS) ([]Synset, error) {
+	var synsets []Synset
+	seen := make(map[int64]bool)
+	for _, lemma := range db.Lemmas(word, pos) {
+		for _, offset := range db.index[pos][lemma] {
+			if seen[offset] {
+				continue
+			}
+			seen[offset] = true
+			s, err := db.Synset(pos, offset)
+			if err != nil {
+				return nil, err
+			}
+			synsets = append(synsets, *s)
+		}
+	}
+	return synsets, nil
+}
+
+// Synset читает синсет по смещению в файле data части речи pos.
+// Для сателлитов используется файл прилагательных.
+func (db *Database) Synset(pos POS, offset int64) (*Synset, error) {
+	if pos == Satellite {
+		pos = Adjective
+	}
+	f, ok := db.data[pos]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown part of speech %q", ErrInvalidFile, pos)
+	}
+
+	line, err := readLine(f, offset)
+	if err != nil {
+		return nil, fmt.Errorf("wndb: read synset %c %08d: %w", pos, offset, err)
+	}
+	s, err := parseSynset(line)
+	if err != nil {
+		return nil, fmt.Errorf("%w: synset %c %08d: %v", ErrInvalidFile, pos, offset, err)
+	}
+	if s.Offset != offset {
+		return nil, fmt.Errorf("%w: synset at %08d has offset %08d", ErrInvalidFile, offset, s.Offset)
+	}
+	return s, nil
+}
+
+// Key возвращает ключ слова в индексе: нижний регистр, подчёркивания
+// вместо пробелов.
+func Key(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), "_"))
+}
+
+// ============================================================================
+// MORPHY
+// ============================================================================
+
+type detachRule struct {
+	suffix, ending string
+}
+
+// detachRules — правила отбрасывания окончаний морфологии WordNet.
+var detachRules = map[POS][]detachRule{
+	Noun: {
+		{"s", ""}, {"ses", "s"}, {"xes", "x"}, {"zes", "z"}, {"ches", "ch"},
+		{"shes", "sh"}, {"men", "man"}, {"ies", "y"},
+	},
+	Verb: {
+		{"s", ""}, {"ies", "y"}, {"es", "e"}, {"es", ""}, {"ed", "e"},
+		{"ed", ""}, {"ing", "e"}, {"ing", ""},
+	},
+	Adjective: {
+		{"er", ""}, {"est", ""}, {"er", "e"}, {"est", "e"},
+	},
+}
+
+// ============================================================================
+// FILES
+// ============================================================================
+
+// readIndex читает index.*: «лемма часть_речи число_синсетов число_указателей
+// [символы указателей] число_значений число_размеченных смещения...».
+// Строки лицензии начинаются с пробела.
+func readIndex(path string) (map[string][]int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	index := make(map[string][]int64)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64<<10), maxLineSize)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		if text == "" || text[0] == ' ' {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("%w: %s:%d: too few fields", ErrInvalidFile, path, line)
+		}
+		synsetCount, err1 := strconv.Atoi(fields[2])
+		pointerCount, err2 := strconv.Atoi(fields[3])
+		if err1 != nil || err2 != nil || len(fields) != 4+pointerCount+2+synsetCount {
+			return nil, fmt.Errorf("%w: %s:%d: malformed entry", ErrInvalidFile, path, line)
+		}
+
+		offsets := make([]int64, 0, synsetCount)
+		for _, field := range fields[len(fields)-synsetCount:] {
+			offset, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s:%d: bad offset %q", ErrInvalidFile, path, line, field)
+			}
+			offsets = append(offsets, offset)
+		}
+		index[fields[0]] = offsets
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return index, nil
+}
+
+// readExceptions читает *.exc: «словоформа начальная_форма...».
+// Отсутствующий файл — пустой список.
+func readExceptions(path string) (map[string][]string, error) {
+	exceptions := make(map[string][]string)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return exceptions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 {
+			exceptions[fields[0]] = append(exceptions[fields[0]], fields[1:]...)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return exceptions, nil
+}
+
+// readLine читает строку файла, начинающуюся с offset.
+func readLine(r io.ReaderAt, offset int64) (string, error) {
+	var line []byte
+	buf := make([]byte, 4096)
+	for len(line) < maxLineSize {
+		n, err := r.ReadAt(buf, offset+int64(len(line)))
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return string(append(line, buf[:i]...)), nil
+		}
+		line = append(line, buf[:n]...)
+		if err == io.EOF {
+			if len(line) == 0 {
+				return "", io.ErrUnexpectedEOF
+			}
+			return string(line), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("line exceeds %d bytes", maxLineSize)
+}
+
+// parseSynset разбирает строку data.*: «смещение лексикограф_файл тип
+// число_слов(hex) [слово lex_id(hex)]... число_указателей [символ смещение
+// часть_речи источник/цель(hex)]... [рамки глаголов] | глосса».
+func parseSynset(line string) (*Synset, error) {
+	head, gloss, _ := strings.Cut(line, "|")
+	fields := strings.Fields(head)
+	if len(fields) < 4 {
+		return nil, errors.New("too few fields")
+	}
+
+	s := &Synset{Gloss: strings.TrimSpace(gloss)}
+	var err error
+	if s.Offset, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return nil, fmt.Errorf("bad offset %q", fields[0])
+	}
+	if s.LexFile, err = strconv.Atoi(fields[1]); err != nil {
+		return nil, fmt.Errorf("bad lexicographer file %q", fields[1])
+	}
+	if len(fields[2]) != 1 {
+		return nil, fmt.Errorf("bad synset type %q", fields[2])
+	}
+	s.POS = POS(fields[2][0])
+
+	wordCount, err := strconv.ParseInt(fields[3], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("bad word count %q", fields[3])
+	}
+	pos := 4
+	if len(fields) < pos+2*int(wordCount)+1 {
+		return nil, errors.New("truncated word list")
+	}
+	for i := 0; i < int(wordCount); i++ {
+		lexID, err := strconv.ParseInt(fields[pos+1], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("bad lex id %q", fields[pos+1])
+		}
+		s.Words = append(s.Words, Word{Lemma: stripMarker(fields[pos]), LexID: int(lexID)})
+		pos += 2
+	}
+
+	pointerCount, err := strconv.Atoi(fields[pos])
+	if err != nil {
+		return nil, fmt.Errorf("bad pointer count %q", fields[pos])
+	}
+	pos++
+	if len(fields) < pos+4*pointerCount {
+		return nil, errors.New("truncated pointer list")
+	}
+	for i := 0; i < pointerCount; i++ {
+		p, err := parsePointer(fields[pos : pos+4])
+		if err != nil {
+			return nil, err
+		}
+		s.Pointers = append(s.Pointers, p)
+		pos += 4
+	}
+	return s, nil
+}
+
+func parsePointer(fields []string) (Pointer, error) {
+	offset, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || len(fields[2]) != 1 || len(fields[3]) != 4 {
+		return Pointer{}, fmt.Errorf("bad pointer %q", strings.Join(fields, " "))
+	}
+	source, err1 := strconv.ParseInt(fields[3][:2], 16, 32)
+	target, err2 := strconv.ParseInt(fields[3][2:], 16, 32)
+	if err1 != nil || err2 != nil {
+		return Pointer{}, fmt.Errorf("bad pointer source/target %q", fields[3])
+	}
+	return Pointer{
+		Symbol: fields[0],
+		Offset: offset,
+		POS:    POS(fields[2][0]),
+		Source: int(source),
+		Target: int(target),
+	}, nil
+}
+
+// stripMarker убирает у прилагательного синтаксическую помету: big(a) → big.
+func stripMarker(lemma string) string {
+	if i := strings.IndexByte(lemma, '('); i > 0 && strings.HasSuffix(lemma, ")") {
+		return lemma[:i]
+	}
+	return lemma
+}
+
+// splitGloss делит глоссу на определение и примеры в кавычках. Части
+// разделены точкой с запятой вне кавычек; подпись после примера
+// ("..." - Shakespeare) отбрасывается.
+func splitGloss(gloss string) (string, []string) {
+	var parts []string
+	inQuote, start := false, 0
+	for i := 0; i < len(gloss); i++ {
+		switch gloss[i] {
+		case '"':
+			inQuote = !inQuote
+		case ';':
+			if !inQuote {
+				parts = append(parts, gloss[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, gloss[start:])
+
+	var definition, examples []string
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, `"`) {
+			if end := strings.LastIndexByte(part, '"'); end > 0 {
+				part = part[1:end]
+			}
+			if example := strings.TrimSpace(strings.Trim(part, `"`)); example != "" {
+				examples = append(examples, example)
+			}
+			continue
+		}
+		if part != "" {
+			definition = append(definition, part)
+		}
+	}
+	return strings.Join(definition, "; "), examples
+}
@@ -0,0 +1,189 @@
+package wndb
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func openTestDB(t *testing.T) *Database {
+	t.Helper()
+	db, err := Open("testdata/dict")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestLookup(t *testing.T) {
+	db := openTestDB(t)
+
+	synsets, err := db.Lookup("Dog", Noun)
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if len(synsets) != 2 {
+		t.Fatalf("Lookup(dog, n) = %d synsets, want 2", len(synsets))
+	}
+
+	dog := synsets[0]
+	if dog.POS != Noun || dog.LexFile != 5 {
+		t.Errorf("POS, LexFile = %c, %d", dog.POS, dog.LexFile)
+	}
+	wantWords := []Word{{"dog", 0}, {"domestic_dog", 0}, {"Canis_familiaris", 0}}
+	if !reflect.DeepEqual(dog.Words, wantWords) {
+		t.Errorf("Words = %+v", dog.Words)
+	}
+	if dog.Words[1].Text() != "domestic dog" {
+		t.Errorf("Text() = %q", dog.Words[1].Text())
+	}
+	if dog.Definition() != "a member of the genus Canis" {
+		t.Errorf("Definition() = %q", dog.Definition())
+	}
+	if !reflect.DeepEqual(dog.Examples(), []string{"the dog barked all night"}) {
+		t.Errorf("Examples() = %q", dog.Examples())
+	}
+	if len(dog.Pointers) != 1 || dog.Pointers[0].Symbol != PointerHypernym || dog.Pointers[0].POS != Noun {
+		t.Fatalf("Pointers = %+v", dog.Pointers)
+	}
+
+	hypernym, err := db.Synset(dog.Pointers[0].POS, dog.Pointers[0].Offset)
+	if err != nil {
+		t.Fatalf("Synset: %v", err)
+	}
+	if hypernym.Words[0].Lemma != "canine" {
+		t.Errorf("hypernym = %+v", hypernym.Words)
+	}
+
+	if synsets[1].WordIndex("DOG") != 2 || synsets[1].WordIndex("cat") != 0 {
+		t.Errorf("WordIndex = %d, %d", synsets[1].WordIndex("DOG"), synsets[1].WordIndex("cat"))
+	}
+}
+
+func TestLookup_VerbFrames(t *testing.T) {
+	db := openTestDB(t)
+
+	// Рамки глаголов после указателей пропускаются, ";" в кавычках
+	// не делит пример, подпись после примера отбрасывается
+	synsets, err := db.Lookup("chasing", Verb)
+	if err != nil || len(synsets) != 1 {
+		t.Fatalf("Lookup(chasing, v) = %+v, %v", synsets, err)
+	}
+	s := synsets[0]
+	if s.Definition() != "go after with the intent to catch" {
+		t.Errorf("Definition() = %q", s.Definition())
+	}
+	want := []string{"The policeman chased the mugger; then he ran", "the dog chased the rabbit"}
+	if !reflect.DeepEqual(s.Examples(), want) {
+		t.Errorf("Examples() = %q", s.Examples())
+	}
+	if s.Words[2] != (Word{"tail", 1}) {
+		t.Errorf("Words[2] = %+v", s.Words[2])
+	}
+}
+
+func TestLookup_Adjectives(t *testing.T) {
+	db := openTestDB(t)
+
+	synsets, err := db.Lookup("cold", Adjective)
+	if err != nil || len(synsets) != 1 {
+		t.Fatalf("Lookup(cold, a) = %+v, %v", synsets, err)
+	}
+	ptrs := synsets[0].Pointers
+	if len(ptrs) != 2 || ptrs[0].Symbol != PointerAntonym || ptrs[0].Source != 1 || ptrs[0].Target != 1 {
+		t.Fatalf("Pointers = %+v", ptrs)
+	}
+
+	// Сателлит читается из файла прилагательных, помета (p) убрана
+	satellite, err := db.Synset(ptrs[1].POS, ptrs[1].Offset)
+	if err != nil {
+		t.Fatalf("Synset: %v", err)
+	}
+	if satellite.POS != Satellite || satellite.Words[0].Lemma != "chilly" {
+		t.Errorf("satellite = %c %+v", satellite.POS, satellite.Words)
+	}
+
+	synsets, err = db.Lookup("chilly", Adjective)
+	if err != nil || len(synsets) != 1 || synsets[0].POS != Satellite {
+		t.Errorf("Lookup(chilly, a) = %+v, %v", synsets, err)
+	}
+}
+
+func TestLemmas(t *testing.T) {
+	db := openTestDB(t)
+
+	tests := []struct {
+		word string
+		pos  POS
+		want []string
+	}{
+		{"dogs", Noun, []string{"dog"}},
+		{"Domestic Dog", Noun, []string{"domestic_dog"}},
+		{"canines", Noun, []string{"canine"}},
+		{"went", Verb, []string{"go"}},
+		{"chased", Verb, []string{"chase"}},
+		{"colder", Adjective, []string{"cold"}},
+		{"quickly", Adverb, []string{"quickly"}},
+		{"cat", Noun, nil},
+		{"", Noun, nil},
+	}
+	for _, tt := range tests {
+		if got := db.Lemmas(tt.word, tt.pos); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Lemmas(%q, %c) = %q, want %q", tt.word, tt.pos, got, tt.want)
+		}
+	}
+
+	synsets, err := db.Lookup("cat", Noun)
+	if err != nil || synsets != nil {
+		t.Errorf("Lookup(cat) = %v, %v; want nil, nil", synsets, err)
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	if _, err := Open(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("empty dir: err = %v, want ErrNotExist", err)
+	}
+
+	// Число смещений не совпадает с числом синсетов
+	dir := t.TempDir()
+	for _, name := range []string{"index.noun", "index.verb", "index.adj", "index.adv", "data.noun", "data.verb", "data.adj", "data.adv"} {
+		data, err := os.ReadFile(filepath.Join("testdata/dict", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "index.verb" {
+			data = append(data, "run v 2 0 2 0 00000132\n"...)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Open(dir); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("malformed index: err = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestSynset_BadOffset(t *testing.T) {
+	db := openTestDB(t)
+
+	// Смещение не на начале строки синсета
+	if _, err := db.Synset(Noun, 140); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("err = %v, want ErrInvalidFile", err)
+	}
+	if _, err := db.Synset(Noun, 1<<20); err == nil {
+		t.Error("offset past end: want error")
+	}
+}
+
+func TestSplitGloss(t *testing.T) {
+	definition, examples := splitGloss(`change location; move, travel, or proceed; "How fast does your new car go?"`)
+	if definition != "change location; move, travel, or proceed" {
+		t.Errorf("definition = %q", definition)
+	}
+	if !reflect.DeepEqual(examples, []string{"How fast does your new car go?"}) {
+		t.Errorf("examples = %q", examples)
+	}
+}